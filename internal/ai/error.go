@@ -14,6 +14,12 @@
 
 package ai
 
-import "github.com/ecodeclub/webook/internal/ai/internal/service/llm/handler/credit"
+import (
+	"github.com/ecodeclub/webook/internal/ai/internal/service/llm/handler/config"
+	"github.com/ecodeclub/webook/internal/ai/internal/service/llm/handler/credit"
+)
 
-var ErrInsufficientCredit = credit.ErrInsufficientCredit
+var (
+	ErrInsufficientCredit = credit.ErrInsufficientCredit
+	ErrBizConfigNotFound  = config.ErrBizConfigNotFound
+)
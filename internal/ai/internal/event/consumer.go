@@ -43,14 +43,22 @@ func (k *KnowledgeBaseConsumer) Consume(ctx context.Context) error {
 		return fmt.Errorf("解析消息失败: %w", err)
 	}
 	log.Println("xxxxx", evt)
-	err = k.svc.UploadFile(ctx, domain.KnowledgeBaseFile{
+	file := domain.KnowledgeBaseFile{
 		Biz:             evt.Biz,
 		BizID:           evt.BizID,
 		Name:            evt.Name,
 		Data:            evt.Data,
 		Type:            evt.Type,
 		KnowledgeBaseID: evt.KnowledgeBaseID,
-	})
+	}
+	if evt.Action == KnowledgeBaseActionDelete {
+		err = k.svc.DeleteFile(ctx, file)
+		if err != nil {
+			return fmt.Errorf("从知识库删除文件失败 %w", err)
+		}
+		return nil
+	}
+	err = k.svc.UploadFile(ctx, file)
 	if err != nil {
 		return fmt.Errorf("上传文件到知识库失败 %w", err)
 	}
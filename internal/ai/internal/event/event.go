@@ -11,8 +11,28 @@ type KnowledgeBaseUploadEvent struct {
 	// 用途
 	Type            string `json:"type"`
 	KnowledgeBaseID string `json:"knowledgeBaseID"`
+	// 操作类型，为空的时候代表上传
+	Action string `json:"action"`
 }
 
 const (
 	KnowledgeBaseUploadTopic = "knowledge_base_upload_topic"
+	// KnowledgeBaseActionDelete 从知识库中删除文件，例如内容下线了
+	KnowledgeBaseActionDelete = "delete"
 )
+
+const (
+	UserDeletionEventName     = "user_deletion_events"
+	UserDeletionDoneEventName = "user_deletion_done_events"
+)
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
@@ -0,0 +1,75 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/ai/internal/service"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// UserDeletionConsumer 注销账号之后，删除调用 AI 的记录
+type UserDeletionConsumer struct {
+	svc      service.RecordService
+	consumer mq.Consumer
+	producer *mqx.GeneralProducer[UserDeletionDoneEvent]
+	logger   *elog.Component
+}
+
+func NewUserDeletionConsumer(svc service.RecordService, q mq.MQ) (*UserDeletionConsumer, error) {
+	groupID := "ai"
+	consumer, err := q.Consumer(UserDeletionEventName, groupID)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := mqx.NewGeneralProducer[UserDeletionDoneEvent](q, UserDeletionDoneEventName)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDeletionConsumer{
+		svc:      svc,
+		consumer: consumer,
+		producer: producer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserDeletionConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费注销账号事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserDeletionConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt UserDeletionEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.svc.DeleteUserRecords(ctx, evt.Uid)
+	if err != nil {
+		return fmt.Errorf("清理注销账号的数据失败 %w, uid %d", err, evt.Uid)
+	}
+	// 告诉隐私模块这边清理完了，发送失败的话隐私模块会重新通知
+	err = c.producer.Produce(ctx, UserDeletionDoneEvent{Uid: evt.Uid, Module: "ai"})
+	if err != nil {
+		return fmt.Errorf("发送注销账号确认事件失败 %w, uid %d", err, evt.Uid)
+	}
+	return nil
+}
+
+func (c *UserDeletionConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
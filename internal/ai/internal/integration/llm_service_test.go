@@ -743,6 +743,44 @@ func (s *LLMServiceSuite) TestHandler_AnalysisJD() {
 	}
 }
 
+func (s *LLMServiceSuite) TestRecordService() {
+	t := s.T()
+	ctx := context.Background()
+	const deletedUid, otherUid = 3001, 3002
+	err := s.db.Create(&[]dao.LLMRecord{
+		{Tid: "deleted-tid", Uid: deletedUid, Biz: "simple", Input: sqlx.JsonColumn[[]string]{Valid: true, Val: []string{"in"}}},
+		{Tid: "other-tid", Uid: otherUid, Biz: "simple"},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.LLMCredit{
+		{Tid: "deleted-tid", Uid: deletedUid, Biz: "simple"},
+		{Tid: "other-tid", Uid: otherUid, Biz: "simple"},
+	}).Error
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mou, err := startup.InitModule(s.db, hdlmocks.NewMockHandler(ctrl), nil, &credit.Module{}, nil)
+	require.NoError(t, err)
+	records, err := mou.RecordSvc.UserRecords(ctx, deletedUid)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "deleted-tid", records[0].Tid)
+	assert.Equal(t, []string{"in"}, records[0].Input)
+
+	require.NoError(t, mou.RecordSvc.DeleteUserRecords(ctx, deletedUid))
+	records, err = mou.RecordSvc.UserRecords(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	var creditCnt int64
+	err = s.db.Model(&dao.LLMCredit{}).Where("uid = ?", deletedUid).Count(&creditCnt).Error
+	require.NoError(t, err)
+	assert.Zero(t, creditCnt)
+	records, err = mou.RecordSvc.UserRecords(ctx, otherUid)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
 func (s *LLMServiceSuite) assertLog(wantLog dao.LLMRecord, actual dao.LLMRecord) {
 	require.True(s.T(), actual.Ctime != 0)
 	require.True(s.T(), actual.Utime != 0)
@@ -58,9 +58,11 @@ func InitModule(db *egorm.Component,
 		service.NewGeneralService,
 		service.NewJDService,
 		service.NewConfigService,
+		service.NewRecordService,
+		service.NewExportSource,
 		web.NewHandler,
 		web.NewAdminHandler,
-		wire.Struct(new(ai.Module), "*"),
+		wire.Struct(new(ai.Module), "Svc", "KnowledgeBaseSvc", "RecordSvc", "Hdl", "AdminHandler", "C", "ExportSource"),
 		wire.FieldsOf(new(*credit.Module), "Svc"),
 	)
 	return new(ai.Module), nil
@@ -51,12 +51,16 @@ func InitModule(db *gorm.DB, hdl *hdlmocks.MockHandler, baseSvc knowledge_base.R
 	webHandler := web.NewHandler(generalService, jdService)
 	configService := service.NewConfigService(configRepository)
 	adminHandler := web.NewAdminHandler(configService)
+	recordService := service.NewRecordService(llmLogRepo, llmCreditLogRepo)
+	source := service.NewExportSource(recordService)
 	module := &ai.Module{
 		Svc:              llmService,
 		KnowledgeBaseSvc: baseSvc,
+		RecordSvc:        recordService,
 		Hdl:              webHandler,
 		AdminHandler:     adminHandler,
 		C:                consumer,
+		ExportSource:     source,
 	}
 	return module, nil
 }
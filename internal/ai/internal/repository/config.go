@@ -22,6 +22,8 @@ import (
 	"github.com/ecodeclub/webook/internal/ai/internal/repository/dao"
 )
 
+var ErrConfigNotFound = dao.ErrConfigNotFound
+
 type ConfigRepository interface {
 	GetConfig(ctx context.Context, biz string) (domain.BizConfig, error)
 	Save(ctx context.Context, cfg domain.BizConfig) (int64, error)
@@ -9,6 +9,7 @@ import (
 
 type LLMCreditLogRepo interface {
 	SaveCredit(ctx context.Context, l domain.LLMCredit) (int64, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type llmCreditLogRepo struct {
@@ -36,3 +37,7 @@ func (g *llmCreditLogRepo) SaveCredit(ctx context.Context, l domain.LLMCredit) (
 	logEntity := g.creditLogToEntity(l)
 	return g.logDao.SaveCredit(ctx, logEntity)
 }
+
+func (g *llmCreditLogRepo) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.logDao.DeleteByUid(ctx, uid)
+}
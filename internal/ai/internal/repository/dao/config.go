@@ -19,9 +19,12 @@ import (
 	"time"
 
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+var ErrConfigNotFound = gorm.ErrRecordNotFound
+
 type ConfigDAO interface {
 	GetConfig(ctx context.Context, biz string) (BizConfig, error)
 	Save(ctx context.Context, cfg BizConfig) (int64, error)
@@ -26,6 +26,7 @@ func (l LLMCredit) TableName() string {
 
 type LLMCreditDAO interface {
 	SaveCredit(ctx context.Context, l LLMCredit) (int64, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type GORMLLMCreditDAO struct {
@@ -49,3 +50,7 @@ func (g *GORMLLMCreditDAO) SaveCredit(ctx context.Context, l LLMCredit) (int64,
 		}).Create(&l).Error
 	return l.Id, err
 }
+
+func (g *GORMLLMCreditDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&LLMCredit{}).Error
+}
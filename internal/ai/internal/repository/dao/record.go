@@ -26,6 +26,9 @@ import (
 
 type LLMRecordDAO interface {
 	Save(ctx context.Context, r LLMRecord) (int64, error)
+	// FindByUid 用户所有的调用记录，最早的在前面
+	FindByUid(ctx context.Context, uid int64) ([]LLMRecord, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 // GORMLLMLogDAO => GORM LLM LogDAO
@@ -55,6 +58,16 @@ func (g *GORMLLMLogDAO) FirstLog(ctx context.Context, id int64) (*LLMRecord, err
 	return logModel, err
 }
 
+func (g *GORMLLMLogDAO) FindByUid(ctx context.Context, uid int64) ([]LLMRecord, error) {
+	var res []LLMRecord
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (g *GORMLLMLogDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&LLMRecord{}).Error
+}
+
 type LLMRecord struct {
 	Id             int64                     `gorm:"primaryKey;autoIncrement;comment:积分流水表自增ID"`
 	Tid            string                    `gorm:"type:varchar(256);not null;uniqueIndex:unq_tid;comment:一次请求的Tid只能有一次"`
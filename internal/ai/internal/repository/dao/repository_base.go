@@ -16,6 +16,7 @@ var ErrBaseFileNotFound = gorm.ErrRecordNotFound
 type KnowledgeBaseDAO interface {
 	Save(ctx context.Context, file KnowledgeBaseFile) error
 	GetInfo(ctx context.Context, platform, baseID, name string) (KnowledgeBaseFile, error)
+	Delete(ctx context.Context, platform, baseID, name string) error
 }
 
 type KnowledgeBaseFile struct {
@@ -77,3 +78,9 @@ func (r *knowledgeBaseDAO) GetInfo(ctx context.Context, platform, baseID, name s
 	}
 	return file, nil
 }
+
+func (r *knowledgeBaseDAO) Delete(ctx context.Context, platform, baseID, name string) error {
+	return r.db.WithContext(ctx).
+		Where("name = ? and platform = ? and knowledge_base_id = ?", name, platform, baseID).
+		Delete(&KnowledgeBaseFile{}).Error
+}
@@ -11,6 +11,7 @@ import (
 type KnowledgeBaseRepo interface {
 	Save(ctx context.Context, file domain.KnowledgeBaseFile) error
 	GetInfo(ctx context.Context, platform, baseID, name string) (domain.KnowledgeBaseFile, error)
+	Delete(ctx context.Context, platform, baseID, name string) error
 }
 
 type repositoryBaseRepo struct {
@@ -46,3 +47,7 @@ func (r *repositoryBaseRepo) GetInfo(ctx context.Context, platform, baseID, name
 		Platform: file.Platform,
 	}, nil
 }
+
+func (r *repositoryBaseRepo) Delete(ctx context.Context, platform, baseID, name string) error {
+	return r.baseDao.Delete(ctx, platform, baseID, name)
+}
@@ -17,6 +17,7 @@ package repository
 import (
 	"context"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ekit/sqlx"
 	"github.com/ecodeclub/webook/internal/ai/internal/domain"
 	"github.com/ecodeclub/webook/internal/ai/internal/repository/dao"
@@ -24,6 +25,8 @@ import (
 
 type LLMLogRepo interface {
 	SaveLog(ctx context.Context, l domain.LLMRecord) (int64, error)
+	FindByUid(ctx context.Context, uid int64) ([]domain.LLMRecord, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 // 调用日志
@@ -42,6 +45,35 @@ func (g *llmLogDAO) SaveLog(ctx context.Context, l domain.LLMRecord) (int64, err
 	return g.logDao.Save(ctx, logEntity)
 }
 
+func (g *llmLogDAO) FindByUid(ctx context.Context, uid int64) ([]domain.LLMRecord, error) {
+	records, err := g.logDao.FindByUid(ctx, uid)
+	return slice.Map(records, func(idx int, src dao.LLMRecord) domain.LLMRecord {
+		return g.toDomain(src)
+	}), err
+}
+
+func (g *llmLogDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.logDao.DeleteByUid(ctx, uid)
+}
+
+func (g *llmLogDAO) toDomain(r dao.LLMRecord) domain.LLMRecord {
+	return domain.LLMRecord{
+		Id:             r.Id,
+		Tid:            r.Tid,
+		Uid:            r.Uid,
+		Biz:            r.Biz,
+		Tokens:         r.Tokens,
+		Amount:         r.Amount,
+		Input:          r.Input.Val,
+		Status:         domain.RecordStatus(r.Status),
+		KnowledgeId:    r.KnowledgeId,
+		PromptTemplate: r.PromptTemplate.String,
+		Answer:         r.Answer.String,
+		Ctime:          r.Ctime,
+		Utime:          r.Utime,
+	}
+}
+
 func (g *llmLogDAO) toEntity(r domain.LLMRecord) dao.LLMRecord {
 	return dao.LLMRecord{
 		Id:          r.Id,
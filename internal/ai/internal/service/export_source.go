@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+// exportSource 导出个人数据的时候读取调用 AI 的记录
+type exportSource struct {
+	svc RecordService
+}
+
+func NewExportSource(svc RecordService) exportx.Source {
+	return &exportSource{svc: svc}
+}
+
+func (s *exportSource) Name() string {
+	return "ai"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	return s.svc.UserRecords(ctx, uid)
+}
@@ -16,12 +16,16 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ecodeclub/webook/internal/ai/internal/domain"
 	"github.com/ecodeclub/webook/internal/ai/internal/repository"
 	"github.com/ecodeclub/webook/internal/ai/internal/service/llm/handler"
 )
 
+// ErrBizConfigNotFound 业务方还没有配置对应的 prompt
+var ErrBizConfigNotFound = repository.ErrConfigNotFound
+
 // HandlerBuilder 改为从数据库中读取
 type HandlerBuilder struct {
 	repo repository.ConfigRepository
@@ -38,7 +42,7 @@ func (b *HandlerBuilder) Next(next handler.Handler) handler.Handler {
 		// 读取配置
 		cfg, err := b.repo.GetConfig(ctx, req.Biz)
 		if err != nil {
-			return domain.LLMResponse{}, err
+			return domain.LLMResponse{}, fmt.Errorf("读取业务 %s 的配置失败 %w", req.Biz, err)
 		}
 		req.Config = cfg
 		return next.Handle(ctx, req)
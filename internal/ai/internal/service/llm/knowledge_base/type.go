@@ -12,4 +12,6 @@ import (
 type RepositoryBaseSvc interface {
 	// UploadFile 上传文件
 	UploadFile(ctx context.Context, file domain.KnowledgeBaseFile) error
+	// DeleteFile 删除文件，文件不存在的时候不会返回错误
+	DeleteFile(ctx context.Context, file domain.KnowledgeBaseFile) error
 }
@@ -92,6 +92,25 @@ func (r *KnowledgeBase) UploadFile(ctx context.Context, file domain.KnowledgeBas
 	return nil
 }
 
+// DeleteFile 内容下线之后，从知识库中移除
+func (r *KnowledgeBase) DeleteFile(ctx context.Context, file domain.KnowledgeBaseFile) error {
+	file.Platform = "zhipu"
+	f, err := r.repo.GetInfo(ctx, file.Platform, file.KnowledgeBaseID, file.Name)
+	switch {
+	case err == nil:
+	case errors.Is(err, dao.ErrBaseFileNotFound):
+		// 从来没有上传过
+		return nil
+	default:
+		return fmt.Errorf("查找文件失败 %w", err)
+	}
+	err = r.removeFile(ctx, f.FileID)
+	if err != nil {
+		return fmt.Errorf("智谱ai，删除文件失败 %w", err)
+	}
+	return r.repo.Delete(ctx, file.Platform, file.KnowledgeBaseID, file.Name)
+}
+
 // 删除
 func (r *KnowledgeBase) removeFile(ctx context.Context, docId string) error {
 	service := r.client.FileDelete(docId)
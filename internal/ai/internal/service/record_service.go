@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/webook/internal/ai/internal/domain"
+	"github.com/ecodeclub/webook/internal/ai/internal/repository"
+)
+
+// RecordService 用户调用 AI 的记录
+type RecordService interface {
+	// UserRecords 用户所有的调用记录，导出个人数据的时候使用
+	UserRecords(ctx context.Context, uid int64) ([]domain.LLMRecord, error)
+	// DeleteUserRecords 注销账号的时候删除调用记录和扣费记录
+	DeleteUserRecords(ctx context.Context, uid int64) error
+}
+
+func NewRecordService(logRepo repository.LLMLogRepo, creditRepo repository.LLMCreditLogRepo) RecordService {
+	return &recordSvc{
+		logRepo:    logRepo,
+		creditRepo: creditRepo,
+	}
+}
+
+type recordSvc struct {
+	logRepo    repository.LLMLogRepo
+	creditRepo repository.LLMCreditLogRepo
+}
+
+func (r *recordSvc) UserRecords(ctx context.Context, uid int64) ([]domain.LLMRecord, error) {
+	return r.logRepo.FindByUid(ctx, uid)
+}
+
+func (r *recordSvc) DeleteUserRecords(ctx context.Context, uid int64) error {
+	return errors.Join(r.logRepo.DeleteByUid(ctx, uid),
+		r.creditRepo.DeleteByUid(ctx, uid))
+}
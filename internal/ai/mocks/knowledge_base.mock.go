@@ -41,6 +41,44 @@ func (m *MockRepositoryBaseSvc) EXPECT() *MockRepositoryBaseSvcMockRecorder {
 	return m.recorder
 }
 
+// DeleteFile mocks base method.
+func (m *MockRepositoryBaseSvc) DeleteFile(ctx context.Context, file domain.KnowledgeBaseFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockRepositoryBaseSvcMockRecorder) DeleteFile(ctx, file any) *MockRepositoryBaseSvcDeleteFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockRepositoryBaseSvc)(nil).DeleteFile), ctx, file)
+	return &MockRepositoryBaseSvcDeleteFileCall{Call: call}
+}
+
+// MockRepositoryBaseSvcDeleteFileCall wrap *gomock.Call
+type MockRepositoryBaseSvcDeleteFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryBaseSvcDeleteFileCall) Return(arg0 error) *MockRepositoryBaseSvcDeleteFileCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryBaseSvcDeleteFileCall) Do(f func(context.Context, domain.KnowledgeBaseFile) error) *MockRepositoryBaseSvcDeleteFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryBaseSvcDeleteFileCall) DoAndReturn(f func(context.Context, domain.KnowledgeBaseFile) error) *MockRepositoryBaseSvcDeleteFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // UploadFile mocks base method.
 func (m *MockRepositoryBaseSvc) UploadFile(ctx context.Context, file domain.KnowledgeBaseFile) error {
 	m.ctrl.T.Helper()
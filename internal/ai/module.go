@@ -1,11 +1,19 @@
 package ai
 
-import "github.com/ecodeclub/webook/internal/ai/internal/event"
+import (
+	"github.com/ecodeclub/webook/internal/ai/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
 
 type Module struct {
 	Svc              LLMService
 	KnowledgeBaseSvc KnowledgeBaseService
+	RecordSvc        RecordService
 	Hdl              *LLMHandler
 	AdminHandler     *AdminHandler
 	C                *event.KnowledgeBaseConsumer
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	dc *event.UserDeletionConsumer
 }
@@ -2,6 +2,7 @@ package ai
 
 import (
 	"github.com/ecodeclub/webook/internal/ai/internal/domain"
+	"github.com/ecodeclub/webook/internal/ai/internal/service"
 	"github.com/ecodeclub/webook/internal/ai/internal/service/llm"
 	"github.com/ecodeclub/webook/internal/ai/internal/service/llm/knowledge_base"
 	"github.com/ecodeclub/webook/internal/ai/internal/web"
@@ -11,6 +12,7 @@ type LLMRequest = domain.LLMRequest
 type LLMResponse = domain.LLMResponse
 type LLMService = llm.Service
 type KnowledgeBaseService = knowledge_base.RepositoryBaseSvc
+type RecordService = service.RecordService
 type AdminHandler = web.AdminHandler
 type LLMHandler = web.Handler
 type KnowledgeBaseFile = domain.KnowledgeBaseFile
@@ -53,10 +53,13 @@ func InitModule(db *egorm.Component, creditSvc *credit.Module, q mq.MQ) (*Module
 		service.NewGeneralService,
 		service.NewJDService,
 		service.NewConfigService,
+		service.NewRecordService,
+		service.NewExportSource,
 		web.NewHandler,
 		web.NewAdminHandler,
 
 		initKnowledgeConsumer,
+		initUserDeletionConsumer,
 		wire.Struct(new(Module), "*"),
 		wire.FieldsOf(new(*credit.Module), "Svc"),
 	)
@@ -87,3 +90,12 @@ func initKnowledgeConsumer(svc knowledge_base.RepositoryBaseSvc, q mq.MQ) *event
 	c.Start(context.Background())
 	return c
 }
+
+func initUserDeletionConsumer(svc service.RecordService, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
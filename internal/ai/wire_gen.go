@@ -53,13 +53,19 @@ func InitModule(db *gorm.DB, creditSvc *credit.Module, q mq.MQ) (*Module, error)
 	webHandler := web.NewHandler(generalService, jdService)
 	configService := service.NewConfigService(configRepository)
 	adminHandler := web.NewAdminHandler(configService)
+	recordService := service.NewRecordService(llmLogRepo, llmCreditLogRepo)
 	knowledgeBaseConsumer := initKnowledgeConsumer(repositoryBaseSvc, q)
+	source := service.NewExportSource(recordService)
+	userDeletionConsumer := initUserDeletionConsumer(recordService, q)
 	module := &Module{
 		Svc:              llmService,
 		KnowledgeBaseSvc: repositoryBaseSvc,
+		RecordSvc:        recordService,
 		Hdl:              webHandler,
 		AdminHandler:     adminHandler,
 		C:                knowledgeBaseConsumer,
+		ExportSource:     source,
+		dc:               userDeletionConsumer,
 	}
 	return module, nil
 }
@@ -90,3 +96,12 @@ func initKnowledgeConsumer(svc knowledge_base.RepositoryBaseSvc, q mq.MQ) *event
 	c.Start(context.Background())
 	return c
 }
+
+func initUserDeletionConsumer(svc service.RecordService, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
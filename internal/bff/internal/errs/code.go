@@ -15,7 +15,9 @@
 package errs
 
 var (
-	SystemError = ErrorCode{Code: 503014, Msg: "系统错误"}
+	CollectionNotFound = ErrorCode{Code: 403014, Msg: "收藏夹不存在"}
+	InvalidHistory     = ErrorCode{Code: 403015, Msg: "不支持的浏览历史"}
+	SystemError        = ErrorCode{Code: 503014, Msg: "系统错误"}
 )
 
 type ErrorCode struct {
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -16,8 +17,11 @@ import (
 	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
 	"github.com/ecodeclub/webook/internal/interactive"
 	intrmocks "github.com/ecodeclub/webook/internal/interactive/mocks"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	quemocks "github.com/ecodeclub/webook/internal/question/mocks"
+	"github.com/ecodeclub/webook/internal/skill"
 	"github.com/ecodeclub/webook/internal/test"
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/econf"
@@ -33,7 +37,10 @@ type CollectionHandlerTestSuite struct {
 	server *egin.Component
 }
 
-const uid = 123
+const (
+	uid      = 123
+	otherUid = 456
+)
 
 func (c *CollectionHandlerTestSuite) SetupSuite() {
 	ctrl := gomock.NewController(c.T())
@@ -41,7 +48,19 @@ func (c *CollectionHandlerTestSuite) SetupSuite() {
 	queSetSvc := quemocks.NewMockQuestionSetService(ctrl)
 	examSvc := quemocks.NewMockExamineService(ctrl)
 	intrSvc := intrmocks.NewMockService(ctrl)
-	intrSvc.EXPECT().CollectionInfo(gomock.Any(), int64(uid), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uid int64, id int64, offset int, limit int) ([]interactive.CollectionRecord, error) {
+	// 收藏夹 1 是自己的，2 是别人分享的，其它的都没办法访问
+	owners := map[int64]int64{1: uid, 2: otherUid}
+	intrSvc.EXPECT().GetCollection(gomock.Any(), int64(uid), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uid int64, id int64, shareToken string) (interactive.Collection, error) {
+		owner, ok := owners[id]
+		if !ok {
+			return interactive.Collection{}, interactive.ErrCollectionNotFound
+		}
+		return interactive.Collection{Id: id, Uid: owner}, nil
+	}).AnyTimes()
+	intrSvc.EXPECT().CollectionInfo(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uid int64, id int64, offset int, limit int) ([]interactive.CollectionRecord, error) {
+		if owners[id] != uid {
+			return nil, errors.New("收藏记录应该按照收藏夹的主人查询")
+		}
 		return []interactive.CollectionRecord{
 			{
 				Biz:  web.CaseBiz,
@@ -152,7 +171,8 @@ func (c *CollectionHandlerTestSuite) SetupSuite() {
 		}).AnyTimes()
 	handler, _ := st.InitHandler(&interactive.Module{Svc: intrSvc},
 		&cases.Module{Svc: caseSvc, SetSvc: caseSetSvc, ExamineSvc: caseExamSvc},
-		&baguwen.Module{Svc: queSvc, SetSvc: queSetSvc, ExamSvc: examSvc})
+		&baguwen.Module{Svc: queSvc, SetSvc: queSetSvc, ExamSvc: examSvc},
+		&progress.Module{}, &project.Module{}, &skill.Module{})
 	econf.Set("server", map[string]any{"contextTimeout": "1s"})
 	server := egin.Load("server").Build()
 	server.Use(func(ctx *gin.Context) {
@@ -166,19 +186,7 @@ func (c *CollectionHandlerTestSuite) SetupSuite() {
 }
 
 func (c *CollectionHandlerTestSuite) Test_Handler() {
-	t := c.T()
-	req, err := http.NewRequest(http.MethodPost,
-		"/interactive/collection/records", iox.NewJSONReader(web.CollectionInfoReq{
-			ID:     1,
-			Offset: 0,
-			Limit:  10,
-		}))
-	req.Header.Set("content-type", "application/json")
-	require.NoError(t, err)
-	recorder := test.NewJSONResponseRecorder[[]web.CollectionRecord]()
-	c.server.ServeHTTP(recorder, req)
-	require.Equal(t, 200, recorder.Code)
-	assert.Equal(t, []web.CollectionRecord{
+	wantRecords := []web.CollectionRecord{
 		{
 			Case: web.Case{
 				ID:    1,
@@ -243,8 +251,45 @@ func (c *CollectionHandlerTestSuite) Test_Handler() {
 				},
 			},
 		},
-	}, recorder.MustScan().Data)
-
+	}
+	testCases := []struct {
+		name     string
+		req      web.CollectionInfoReq
+		wantCode int
+		wantResp test.Result[[]web.CollectionRecord]
+	}{
+		{
+			name:     "自己的收藏夹",
+			req:      web.CollectionInfoReq{ID: 1, Offset: 0, Limit: 10},
+			wantCode: 200,
+			wantResp: test.Result[[]web.CollectionRecord]{Data: wantRecords},
+		},
+		{
+			name:     "别人分享的收藏夹",
+			req:      web.CollectionInfoReq{ID: 2, ShareToken: "token", Offset: 0, Limit: 10},
+			wantCode: 200,
+			wantResp: test.Result[[]web.CollectionRecord]{Data: wantRecords},
+		},
+		{
+			name:     "没有权限访问的收藏夹",
+			req:      web.CollectionInfoReq{ID: 3, Offset: 0, Limit: 10},
+			wantCode: 200,
+			wantResp: test.Result[[]web.CollectionRecord]{Code: 403014, Msg: "收藏夹不存在"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		c.T().Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				"/interactive/collection/records", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[[]web.CollectionRecord]()
+			c.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
 }
 
 func TestCollectionHandler(t *testing.T) {
@@ -0,0 +1,252 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx/session"
+	st "github.com/ecodeclub/webook/internal/bff/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/bff/internal/web"
+	"github.com/ecodeclub/webook/internal/cases"
+	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/interactive"
+	intrmocks "github.com/ecodeclub/webook/internal/interactive/mocks"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
+	projmocks "github.com/ecodeclub/webook/internal/project/mocks"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	quemocks "github.com/ecodeclub/webook/internal/question/mocks"
+	"github.com/ecodeclub/webook/internal/skill"
+	"github.com/ecodeclub/webook/internal/test"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type HistoryHandlerTestSuite struct {
+	suite.Suite
+	server *egin.Component
+}
+
+func (s *HistoryHandlerTestSuite) SetupSuite() {
+	ctrl := gomock.NewController(s.T())
+	historySvc := intrmocks.NewMockHistoryService(ctrl)
+	historySvc.EXPECT().List(gomock.Any(), int64(uid), "", 0, 10).
+		Return([]interactive.ViewHistory{
+			{Uid: uid, Biz: "question", BizId: 1, Utime: 400},
+			{Uid: uid, Biz: "case", BizId: 2, Utime: 300},
+			{Uid: uid, Biz: "questionSet", BizId: 3, Utime: 200},
+			{Uid: uid, Biz: "project", BizId: 4, Utime: 100},
+			{Uid: uid, Biz: "project", BizId: 7, Utime: 50},
+		}, nil).AnyTimes()
+	historySvc.EXPECT().LastViewed(gomock.Any(), int64(uid), "question", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, uid int64, biz string, bizIds []int64) (interactive.ViewHistory, error) {
+			// 只看过 12
+			if slice.Contains(bizIds, 12) {
+				return interactive.ViewHistory{Uid: uid, Biz: biz, BizId: 12, Utime: 500}, nil
+			}
+			return interactive.ViewHistory{}, nil
+		}).AnyTimes()
+
+	queSvc := quemocks.NewMockService(ctrl)
+	queSvc.EXPECT().GetPubByIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]baguwen.Question, error) {
+			return slice.Map(ids, func(idx int, src int64) baguwen.Question {
+				return baguwen.Question{Id: src, Title: fmt.Sprintf("这是题目%d", src)}
+			}), nil
+		}).AnyTimes()
+	queExamSvc := quemocks.NewMockExamineService(ctrl)
+	queExamSvc.EXPECT().GetResults(gomock.Any(), int64(uid), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, uid int64, ids []int64) (map[int64]baguwen.ExamResult, error) {
+			res := make(map[int64]baguwen.ExamResult, len(ids))
+			for _, id := range ids {
+				res[id] = baguwen.ExamResult{Qid: id, Result: baguwen.ExamRes(id % 4)}
+			}
+			return res, nil
+		}).AnyTimes()
+	queSetSvc := quemocks.NewMockQuestionSetService(ctrl)
+	queSetSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]baguwen.QuestionSet, error) {
+			return slice.Map(ids, func(idx int, src int64) baguwen.QuestionSet {
+				return baguwen.QuestionSet{Id: src, Title: fmt.Sprintf("这是题集%d", src)}
+			}), nil
+		}).AnyTimes()
+	queSetSvc.EXPECT().GetByIDsWithQuestion(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]baguwen.QuestionSet, error) {
+			// 题集 3 有题目 11 和 12，题集 5 只有题目 13
+			questions := map[int64][]int64{3: {11, 12}, 5: {13}}
+			return slice.Map(ids, func(idx int, src int64) baguwen.QuestionSet {
+				return baguwen.QuestionSet{
+					Id: src,
+					Questions: slice.Map(questions[src], func(idx int, qid int64) baguwen.Question {
+						return baguwen.Question{Id: qid, Title: fmt.Sprintf("这是题目%d", qid)}
+					}),
+				}
+			}), nil
+		}).AnyTimes()
+
+	caseSvc := casemocks.NewMockService(ctrl)
+	caseSvc.EXPECT().GetPubByIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]cases.Case, error) {
+			return slice.Map(ids, func(idx int, src int64) cases.Case {
+				return cases.Case{Id: src, Title: fmt.Sprintf("这是案例%d", src)}
+			}), nil
+		}).AnyTimes()
+	caseExamSvc := casemocks.NewMockExamineService(ctrl)
+	caseExamSvc.EXPECT().GetResults(gomock.Any(), int64(uid), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, uid int64, ids []int64) (map[int64]cases.ExamineResult, error) {
+			res := make(map[int64]cases.ExamineResult, len(ids))
+			for _, id := range ids {
+				res[id] = cases.ExamineResult{Cid: id, Result: cases.ExamineResultEnum(id % 2)}
+			}
+			return res, nil
+		}).AnyTimes()
+
+	prjSvc := projmocks.NewMockService(ctrl)
+	prjSvc.EXPECT().Brief(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int64) (project.Project, error) {
+			// 项目 4 关联了题集 3，项目 6 没有关联题集，项目 7 已经下线了
+			if id == 7 {
+				return project.Project{}, project.ErrProjectNotFound
+			}
+			refs := map[int64]int64{4: 3}
+			return project.Project{
+				Id:             id,
+				Title:          fmt.Sprintf("这是项目%d", id),
+				RefQuestionSet: refs[id],
+			}, nil
+		}).AnyTimes()
+
+	handler, err := st.InitHandler(&interactive.Module{HistorySvc: historySvc},
+		&cases.Module{Svc: caseSvc, ExamineSvc: caseExamSvc},
+		&baguwen.Module{Svc: queSvc, SetSvc: queSetSvc, ExamSvc: queExamSvc},
+		&progress.Module{},
+		&project.Module{Svc: prjSvc},
+		&skill.Module{})
+	require.NoError(s.T(), err)
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: uid,
+		}))
+	})
+	handler.PrivateRoutes(server.Engine)
+	s.server = server
+}
+
+func (s *HistoryHandlerTestSuite) TestHistoryList() {
+	t := s.T()
+	req, err := http.NewRequest(http.MethodPost,
+		"/interactive/history/list", iox.NewJSONReader(web.HistoryListReq{Limit: 10}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[[]web.HistoryRecord]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, []web.HistoryRecord{
+		{
+			Biz:      "question",
+			BizId:    1,
+			Utime:    400,
+			Question: web.Question{ID: 1, Title: "这是题目1", ExamineResult: 1},
+		},
+		{
+			Biz:   "case",
+			BizId: 2,
+			Utime: 300,
+			Case:  web.Case{ID: 2, Title: "这是案例2"},
+		},
+		{
+			Biz:         "questionSet",
+			BizId:       3,
+			Utime:       200,
+			QuestionSet: web.QuestionSet{ID: 3, Title: "这是题集3"},
+		},
+		{
+			Biz:     "project",
+			BizId:   4,
+			Utime:   100,
+			Project: web.Project{ID: 4, Title: "这是项目4"},
+		},
+		{
+			Biz:   "project",
+			BizId: 7,
+			Utime: 50,
+		},
+	}, recorder.MustScan().Data)
+}
+
+func (s *HistoryHandlerTestSuite) TestHistoryPosition() {
+	testCases := []struct {
+		name    string
+		req     web.HistoryPositionReq
+		wantRes test.Result[web.HistoryPosition]
+	}{
+		{
+			name: "题集",
+			req:  web.HistoryPositionReq{Biz: "questionSet", BizId: 3},
+			wantRes: test.Result[web.HistoryPosition]{
+				Data: web.HistoryPosition{
+					Question: web.Question{ID: 12, Title: "这是题目12"},
+					Utime:    500,
+				},
+			},
+		},
+		{
+			name: "项目用关联的题集",
+			req:  web.HistoryPositionReq{Biz: "project", BizId: 4},
+			wantRes: test.Result[web.HistoryPosition]{
+				Data: web.HistoryPosition{
+					Question: web.Question{ID: 12, Title: "这是题目12"},
+					Utime:    500,
+				},
+			},
+		},
+		{
+			name: "题集里面的题目都没看过",
+			req:  web.HistoryPositionReq{Biz: "questionSet", BizId: 5},
+		},
+		{
+			name: "项目没有关联题集",
+			req:  web.HistoryPositionReq{Biz: "project", BizId: 6},
+		},
+		{
+			name: "项目已经下线",
+			req:  web.HistoryPositionReq{Biz: "project", BizId: 7},
+		},
+		{
+			name: "不支持的业务",
+			req:  web.HistoryPositionReq{Biz: "case", BizId: 2},
+			wantRes: test.Result[web.HistoryPosition]{
+				Code: 403015,
+				Msg:  "不支持的浏览历史",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.T().Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				"/interactive/history/position", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.HistoryPosition]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			assert.Equal(t, tc.wantRes, recorder.MustScan())
+		})
+	}
+}
+
+func TestHistoryHandler(t *testing.T) {
+	suite.Run(t, new(HistoryHandlerTestSuite))
+}
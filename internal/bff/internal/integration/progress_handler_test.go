@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx/session"
+	st "github.com/ecodeclub/webook/internal/bff/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/bff/internal/web"
+	"github.com/ecodeclub/webook/internal/cases"
+	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
+	progressmocks "github.com/ecodeclub/webook/internal/progress/mocks"
+	"github.com/ecodeclub/webook/internal/project"
+	projmocks "github.com/ecodeclub/webook/internal/project/mocks"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	quemocks "github.com/ecodeclub/webook/internal/question/mocks"
+	"github.com/ecodeclub/webook/internal/skill"
+	skillmocks "github.com/ecodeclub/webook/internal/skill/mocks"
+	"github.com/ecodeclub/webook/internal/test"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ProgressHandlerTestSuite struct {
+	suite.Suite
+	server *egin.Component
+}
+
+func (s *ProgressHandlerTestSuite) SetupSuite() {
+	ctrl := gomock.NewController(s.T())
+	progressSvc := progressmocks.NewMockService(ctrl)
+	progressSvc.EXPECT().List(gomock.Any(), int64(uid)).Return([]progress.Progress{
+		{Uid: uid, Biz: progress.BizQuestion, BasicCnt: 10, IntermediateCnt: 5, AdvancedCnt: 1},
+		{Uid: uid, Biz: progress.BizCase, BasicCnt: 3},
+		{Uid: uid, Biz: progress.BizQuestionSet, BizId: 1, BasicCnt: 2, IntermediateCnt: 1},
+		// 题集里面的题目被删减了，统计数据超过了总数
+		{Uid: uid, Biz: progress.BizQuestionSet, BizId: 2, BasicCnt: 5, IntermediateCnt: 3, AdvancedCnt: 1},
+		{Uid: uid, Biz: progress.BizCaseSet, BizId: 3, BasicCnt: 1},
+		{Uid: uid, Biz: progress.BizSkillLevel, BizId: 21, BasicCnt: 3, IntermediateCnt: 2},
+		{Uid: uid, Biz: progress.BizSkillLevel, BizId: 22, BasicCnt: 1},
+	}, nil).AnyTimes()
+
+	queSetSvc := quemocks.NewMockQuestionSetService(ctrl)
+	queSetSvc.EXPECT().GetByIDsWithQuestion(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]baguwen.QuestionSet, error) {
+			return slice.Map(ids, func(idx int, src int64) baguwen.QuestionSet {
+				return baguwen.QuestionSet{
+					Id:    src,
+					Title: fmt.Sprintf("这是题集%d", src),
+					Questions: slice.Map(make([]int64, src*2), func(idx int, _ int64) baguwen.Question {
+						return baguwen.Question{Id: int64(idx + 1)}
+					}),
+				}
+			}), nil
+		}).AnyTimes()
+
+	caseSetSvc := casemocks.NewMockCaseSetService(ctrl)
+	caseSetSvc.EXPECT().GetByIdsWithCases(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []int64) ([]cases.CaseSet, error) {
+			return slice.Map(ids, func(idx int, src int64) cases.CaseSet {
+				return cases.CaseSet{
+					ID:    src,
+					Title: fmt.Sprintf("这是案例集%d", src),
+					Cases: []cases.Case{{Id: 1}, {Id: 2}},
+				}
+			}), nil
+		}).AnyTimes()
+
+	skillSvc := skillmocks.NewMockSkillService(ctrl)
+	// 只返回有进度的等级，技能 5 的 basic 等级没有进度
+	skillSvc.EXPECT().LevelsByIDs(gomock.Any(), []int64{21, 22}).
+		Return([]skill.Skill{
+			{
+				ID:   5,
+				Name: "Redis",
+				Intermediate: skill.SkillLevel{
+					Id:        21,
+					Questions: []int64{1, 2, 3},
+					Cases:     []int64{1},
+				},
+				Advanced: skill.SkillLevel{
+					Id:        22,
+					Questions: []int64{4},
+				},
+			},
+		}, nil).AnyTimes()
+
+	prjSvc := projmocks.NewMockService(ctrl)
+	prjSvc.EXPECT().ListByRefQuestionSets(gomock.Any(), gomock.Any()).
+		Return([]project.Project{
+			{Id: 10, Title: "这是项目10", RefQuestionSet: 1},
+		}, nil).AnyTimes()
+
+	handler, err := st.InitHandler(&interactive.Module{},
+		&cases.Module{SetSvc: caseSetSvc},
+		&baguwen.Module{SetSvc: queSetSvc},
+		&progress.Module{Svc: progressSvc},
+		&project.Module{Svc: prjSvc},
+		&skill.Module{Svc: skillSvc})
+	require.NoError(s.T(), err)
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: uid,
+		}))
+	})
+	handler.PrivateRoutes(server.Engine)
+	s.server = server
+}
+
+func (s *ProgressHandlerTestSuite) TestDashboard() {
+	t := s.T()
+	req, err := http.NewRequest(http.MethodPost, "/progress/dashboard", nil)
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[web.Dashboard]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, web.Dashboard{
+		Question: web.Progress{BasicCnt: 10, IntermediateCnt: 5, AdvancedCnt: 1},
+		Case:     web.Progress{BasicCnt: 3},
+		QuestionSets: []web.SetProgress{
+			{
+				ID:       1,
+				Title:    "这是题集1",
+				Progress: web.Progress{Total: 2, BasicCnt: 2, IntermediateCnt: 1},
+			},
+			{
+				ID:       2,
+				Title:    "这是题集2",
+				Progress: web.Progress{Total: 4, BasicCnt: 4, IntermediateCnt: 3, AdvancedCnt: 1},
+			},
+		},
+		CaseSets: []web.SetProgress{
+			{
+				ID:       3,
+				Title:    "这是案例集3",
+				Progress: web.Progress{Total: 2, BasicCnt: 1},
+			},
+		},
+		Projects: []web.ProjectProgress{
+			{
+				ID:            10,
+				Title:         "这是项目10",
+				QuestionSetID: 1,
+				Progress:      web.Progress{Total: 2, BasicCnt: 2, IntermediateCnt: 1},
+			},
+		},
+		SkillLevels: []web.SkillLevelProgress{
+			{
+				ID:        21,
+				SkillID:   5,
+				SkillName: "Redis",
+				Level:     "intermediate",
+				Progress:  web.Progress{Total: 4, BasicCnt: 3, IntermediateCnt: 2},
+			},
+			{
+				ID:        22,
+				SkillID:   5,
+				SkillName: "Redis",
+				Level:     "advanced",
+				Progress:  web.Progress{Total: 1, BasicCnt: 1},
+			},
+		},
+	}, recorder.MustScan().Data)
+}
+
+func TestProgressHandler(t *testing.T) {
+	suite.Run(t, new(ProgressHandlerTestSuite))
+}
@@ -6,18 +6,27 @@ import (
 	"github.com/ecodeclub/webook/internal/bff/internal/web"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
 	"github.com/google/wire"
 )
 
 func InitHandler(intrModule *interactive.Module,
 	caseModule *cases.Module,
-	queSvc *baguwen.Module) (*web.Handler, error) {
+	queSvc *baguwen.Module,
+	progressModule *progress.Module,
+	prjModule *project.Module,
+	skillModule *skill.Module) (*web.Handler, error) {
 	wire.Build(
 		web.NewHandler,
-		wire.FieldsOf(new(*interactive.Module), "Svc"),
+		wire.FieldsOf(new(*interactive.Module), "Svc", "HistorySvc"),
 		wire.FieldsOf(new(*baguwen.Module), "Svc", "SetSvc", "ExamSvc"),
 		wire.FieldsOf(new(*cases.Module), "ExamineSvc", "Svc", "SetSvc"),
+		wire.FieldsOf(new(*progress.Module), "Svc"),
+		wire.FieldsOf(new(*project.Module), "Svc"),
+		wire.FieldsOf(new(*skill.Module), "Svc"),
 	)
 	return new(web.Handler), nil
 }
@@ -10,20 +10,27 @@ import (
 	"github.com/ecodeclub/webook/internal/bff/internal/web"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(intrModule *interactive.Module, caseModule *cases.Module, queSvc *baguwen.Module) (*web.Handler, error) {
+func InitHandler(intrModule *interactive.Module, caseModule *cases.Module, queSvc *baguwen.Module, progressModule *progress.Module, prjModule *project.Module, skillModule *skill.Module) (*web.Handler, error) {
 	service := intrModule.Svc
+	historyService := intrModule.HistorySvc
 	serviceService := caseModule.Svc
 	caseSetService := caseModule.SetSvc
 	examineService := caseModule.ExamineSvc
 	service2 := queSvc.Svc
 	questionSetService := queSvc.SetSvc
 	serviceExamineService := queSvc.ExamSvc
-	handler := web.NewHandler(service, serviceService, caseSetService, examineService, service2, questionSetService, serviceExamineService)
+	service3 := progressModule.Svc
+	service4 := prjModule.Svc
+	skillService := skillModule.Svc
+	handler := web.NewHandler(service, historyService, serviceService, caseSetService, examineService, service2, questionSetService, serviceExamineService, service3, service4, skillService)
 	return handler, nil
 }
 
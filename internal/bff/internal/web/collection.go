@@ -1,6 +1,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
@@ -15,13 +17,22 @@ const (
 	CaseSetBiz     = "caseSet"
 	QuestionBiz    = "question"
 	QuestionSetBiz = "questionSet"
+	ProjectBiz     = "project"
 )
 
 func (h *Handler) CollectionRecords(ctx *ginx.Context, req CollectionInfoReq, sess session.Session) (ginx.Result, error) {
 	uid := sess.Claims().Uid
 	recordCtx := ctx.Request.Context()
-	// 获取收藏记录
-	records, err := h.intrSvc.CollectionInfo(recordCtx, uid, req.ID, req.Offset, req.Limit)
+	// 别人分享的收藏夹只读，先确认能不能访问
+	collection, err := h.intrSvc.GetCollection(recordCtx, uid, req.ID, req.ShareToken)
+	if err != nil {
+		if errors.Is(err, interactive.ErrCollectionNotFound) {
+			return collectionNotFoundResult, nil
+		}
+		return systemErrorResult, err
+	}
+	// 获取收藏记录，收藏记录属于收藏夹的主人，测试结果还是当前用户自己的
+	records, err := h.intrSvc.CollectionInfo(recordCtx, collection.Uid, collection.Id, req.Offset, req.Limit)
 	if err != nil {
 		return systemErrorResult, err
 	}
@@ -4,41 +4,59 @@ import (
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
 	intrSvc     interactive.Service
+	historySvc  interactive.HistoryService
 	caseSvc     cases.Service
 	caseSetSvc  cases.SetService
 	caseExamSvc cases.ExamineService
 	queSvc      baguwen.Service
 	queSetSvc   baguwen.QuestionSetService
 	queExamSvc  baguwen.ExamService
+	progressSvc progress.Service
+	prjSvc      project.Service
+	skillSvc    skill.Service
 }
 
 func NewHandler(
 	intrSvc interactive.Service,
+	historySvc interactive.HistoryService,
 	caseSvc cases.Service,
 	caseSetSvc cases.SetService,
 	caseExamineSvc cases.ExamineService,
 	queSvc baguwen.Service,
 	queSetSvc baguwen.QuestionSetService,
 	queExamSvc baguwen.ExamService,
+	progressSvc progress.Service,
+	prjSvc project.Service,
+	skillSvc skill.Service,
 ) *Handler {
 	return &Handler{
 		intrSvc:     intrSvc,
+		historySvc:  historySvc,
 		caseSvc:     caseSvc,
 		queSvc:      queSvc,
 		queSetSvc:   queSetSvc,
 		queExamSvc:  queExamSvc,
 		caseSetSvc:  caseSetSvc,
 		caseExamSvc: caseExamineSvc,
+		progressSvc: progressSvc,
+		prjSvc:      prjSvc,
+		skillSvc:    skillSvc,
 	}
 }
 
 func (h *Handler) PrivateRoutes(server *gin.Engine) {
 	g := server.Group("/interactive")
 	g.POST("/collection/records", ginx.BS[CollectionInfoReq](h.CollectionRecords))
+	g.POST("/history/list", ginx.BS[HistoryListReq](h.HistoryList))
+	g.POST("/history/position", ginx.BS[HistoryPositionReq](h.HistoryPosition))
+	server.POST("/progress/dashboard", ginx.S(h.Dashboard))
 }
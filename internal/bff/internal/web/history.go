@@ -0,0 +1,199 @@
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/project"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"golang.org/x/sync/errgroup"
+)
+
+// HistoryList 最近浏览的记录，浏览历史只有 ID，这里补充标题和测试结果
+func (h *Handler) HistoryList(ctx *ginx.Context, req HistoryListReq, sess session.Session) (ginx.Result, error) {
+	uid := sess.Claims().Uid
+	reqCtx := ctx.Request.Context()
+	histories, err := h.historySvc.List(reqCtx, uid, req.Biz, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	var qids, cids, qsids, pids []int64
+	for _, history := range histories {
+		switch history.Biz {
+		case QuestionBiz:
+			qids = append(qids, history.BizId)
+		case CaseBiz:
+			cids = append(cids, history.BizId)
+		case QuestionSetBiz:
+			qsids = append(qsids, history.BizId)
+		case ProjectBiz:
+			pids = append(pids, history.BizId)
+		}
+	}
+	var (
+		eg             errgroup.Group
+		qm             map[int64]baguwen.Question
+		cm             map[int64]cases.Case
+		qsm            map[int64]baguwen.QuestionSet
+		queExamResMap  map[int64]baguwen.ExamResult
+		caseExamResMap map[int64]cases.ExamineResult
+	)
+	if len(qids) > 0 {
+		eg.Go(func() error {
+			qs, err1 := h.queSvc.GetPubByIDs(reqCtx, qids)
+			qm = slice.ToMap(qs, func(element baguwen.Question) int64 {
+				return element.Id
+			})
+			return err1
+		})
+		eg.Go(func() error {
+			var err1 error
+			queExamResMap, err1 = h.queExamSvc.GetResults(reqCtx, uid, qids)
+			return err1
+		})
+	}
+	if len(cids) > 0 {
+		eg.Go(func() error {
+			cs, err1 := h.caseSvc.GetPubByIDs(reqCtx, cids)
+			cm = slice.ToMap(cs, func(element cases.Case) int64 {
+				return element.Id
+			})
+			return err1
+		})
+		eg.Go(func() error {
+			var err1 error
+			caseExamResMap, err1 = h.caseExamSvc.GetResults(reqCtx, uid, cids)
+			return err1
+		})
+	}
+	if len(qsids) > 0 {
+		eg.Go(func() error {
+			qsets, err1 := h.queSetSvc.GetByIds(reqCtx, qsids)
+			qsm = slice.ToMap(qsets, func(element baguwen.QuestionSet) int64 {
+				return element.Id
+			})
+			return err1
+		})
+	}
+	// 项目没有批量查询的接口，浏览历史一页的数量不多，逐个查询
+	prjs := make([]project.Project, len(pids))
+	for idx, pid := range pids {
+		eg.Go(func() error {
+			prj, err1 := h.prjSvc.Brief(reqCtx, pid)
+			// 项目可能已经下线了，和其它找不到的内容一样，只返回浏览记录本身
+			if errors.Is(err1, project.ErrProjectNotFound) {
+				return nil
+			}
+			prjs[idx] = prj
+			return err1
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return systemErrorResult, err
+	}
+	pm := slice.ToMap(prjs, func(element project.Project) int64 {
+		return element.Id
+	})
+
+	res := slice.Map(histories, func(idx int, src interactive.ViewHistory) HistoryRecord {
+		record := HistoryRecord{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Utime: src.Utime,
+		}
+		switch src.Biz {
+		case QuestionBiz:
+			q := qm[src.BizId]
+			record.Question = Question{
+				ID:            q.Id,
+				Title:         q.Title,
+				ExamineResult: queExamResMap[src.BizId].Result.ToUint8(),
+			}
+		case CaseBiz:
+			ca := cm[src.BizId]
+			record.Case = Case{
+				ID:            ca.Id,
+				Title:         ca.Title,
+				ExamineResult: caseExamResMap[src.BizId].Result.ToUint8(),
+			}
+		case QuestionSetBiz:
+			qs := qsm[src.BizId]
+			record.QuestionSet = QuestionSet{
+				ID:    qs.Id,
+				Title: qs.Title,
+			}
+		case ProjectBiz:
+			prj := pm[src.BizId]
+			record.Project = Project{
+				ID:    prj.Id,
+				Title: prj.Title,
+			}
+		}
+		return record
+	})
+	return ginx.Result{
+		Data: res,
+	}, nil
+}
+
+// HistoryPosition 题集或者项目里面最后浏览的那一道题，用于继续上次的进度。
+// 项目里面的题目就是它关联的八股文题集里面的题目
+func (h *Handler) HistoryPosition(ctx *ginx.Context, req HistoryPositionReq, sess session.Session) (ginx.Result, error) {
+	uid := sess.Claims().Uid
+	reqCtx := ctx.Request.Context()
+	var qsid int64
+	switch req.Biz {
+	case QuestionSetBiz:
+		qsid = req.BizId
+	case ProjectBiz:
+		prj, err := h.prjSvc.Brief(reqCtx, req.BizId)
+		if errors.Is(err, project.ErrProjectNotFound) {
+			return ginx.Result{Data: HistoryPosition{}}, nil
+		}
+		if err != nil {
+			return systemErrorResult, err
+		}
+		qsid = prj.RefQuestionSet
+	default:
+		return invalidHistoryResult, nil
+	}
+	if qsid == 0 {
+		return ginx.Result{Data: HistoryPosition{}}, nil
+	}
+	qsets, err := h.queSetSvc.GetByIDsWithQuestion(reqCtx, []int64{qsid})
+	if err != nil {
+		return systemErrorResult, err
+	}
+	if len(qsets) == 0 {
+		return ginx.Result{Data: HistoryPosition{}}, nil
+	}
+	qs := qsets[0]
+	last, err := h.historySvc.LastViewed(reqCtx, uid, QuestionBiz, qs.Qids())
+	if err != nil {
+		return systemErrorResult, err
+	}
+	if last.BizId == 0 {
+		return ginx.Result{Data: HistoryPosition{}}, nil
+	}
+	examResMap, err := h.queExamSvc.GetResults(reqCtx, uid, []int64{last.BizId})
+	if err != nil {
+		return systemErrorResult, err
+	}
+	q, _ := slice.Find(qs.Questions, func(src baguwen.Question) bool {
+		return src.Id == last.BizId
+	})
+	return ginx.Result{
+		Data: HistoryPosition{
+			Question: Question{
+				ID:            q.Id,
+				Title:         q.Title,
+				ExamineResult: examResMap[q.Id].Result.ToUint8(),
+			},
+			Utime: last.Utime,
+		},
+	}, nil
+}
@@ -0,0 +1,141 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
+	"golang.org/x/sync/errgroup"
+)
+
+// Dashboard 用户在题集、案例集、技能等级和项目上的进度
+// 统计数据由 progress 模块根据测试结果增量维护，这里只补充标题和总数
+func (h *Handler) Dashboard(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	uid := sess.Claims().Uid
+	reqCtx := ctx.Request.Context()
+	progresses, err := h.progressSvc.List(reqCtx, uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+
+	var (
+		res        Dashboard
+		qsProgress = make(map[int64]progress.Progress, len(progresses))
+		csProgress = make(map[int64]progress.Progress, len(progresses))
+		slProgress = make(map[int64]progress.Progress, len(progresses))
+		qsids      []int64
+		csids      []int64
+		slids      []int64
+	)
+	for _, p := range progresses {
+		switch p.Biz {
+		case progress.BizQuestion:
+			res.Question = newProgress(p, 0)
+		case progress.BizCase:
+			res.Case = newProgress(p, 0)
+		case progress.BizQuestionSet:
+			qsProgress[p.BizId] = p
+			qsids = append(qsids, p.BizId)
+		case progress.BizCaseSet:
+			csProgress[p.BizId] = p
+			csids = append(csids, p.BizId)
+		case progress.BizSkillLevel:
+			slProgress[p.BizId] = p
+			slids = append(slids, p.BizId)
+		}
+	}
+
+	var (
+		eg    errgroup.Group
+		qsets []baguwen.QuestionSet
+		csets []cases.CaseSet
+		prjs  []project.Project
+		sks   []skill.Skill
+	)
+	eg.Go(func() error {
+		var err1 error
+		qsets, err1 = h.queSetSvc.GetByIDsWithQuestion(reqCtx, qsids)
+		return err1
+	})
+	eg.Go(func() error {
+		var err1 error
+		csets, err1 = h.caseSetSvc.GetByIdsWithCases(reqCtx, csids)
+		return err1
+	})
+	eg.Go(func() error {
+		var err1 error
+		prjs, err1 = h.prjSvc.ListByRefQuestionSets(reqCtx, qsids)
+		return err1
+	})
+	eg.Go(func() error {
+		var err1 error
+		sks, err1 = h.skillSvc.LevelsByIDs(reqCtx, slids)
+		return err1
+	})
+	if err = eg.Wait(); err != nil {
+		return systemErrorResult, err
+	}
+
+	res.QuestionSets = slice.Map(qsets, func(idx int, src baguwen.QuestionSet) SetProgress {
+		return SetProgress{
+			ID:       src.Id,
+			Title:    src.Title,
+			Progress: newProgress(qsProgress[src.Id], len(src.Questions)),
+		}
+	})
+	res.CaseSets = slice.Map(csets, func(idx int, src cases.CaseSet) SetProgress {
+		return SetProgress{
+			ID:       src.ID,
+			Title:    src.Title,
+			Progress: newProgress(csProgress[src.ID], len(src.Cases)),
+		}
+	})
+	qsTotal := slice.ToMapV(qsets, func(element baguwen.QuestionSet) (int64, int) {
+		return element.Id, len(element.Questions)
+	})
+	res.Projects = slice.Map(prjs, func(idx int, src project.Project) ProjectProgress {
+		return ProjectProgress{
+			ID:            src.Id,
+			Title:         src.Title,
+			QuestionSetID: src.RefQuestionSet,
+			Progress:      newProgress(qsProgress[src.RefQuestionSet], qsTotal[src.RefQuestionSet]),
+		}
+	})
+	for _, sk := range sks {
+		res.SkillLevels = append(res.SkillLevels, h.skillLevelProgress(sk, slProgress)...)
+	}
+	return ginx.Result{
+		Data: res,
+	}, nil
+}
+
+// skillLevelProgress LevelsByIDs 返回的 Skill 里面只有用户有进度的等级，其余等级的 ID 为 0
+func (h *Handler) skillLevelProgress(sk skill.Skill, slProgress map[int64]progress.Progress) []SkillLevelProgress {
+	levels := []struct {
+		name  string
+		level skill.SkillLevel
+	}{
+		{name: skill.LevelBasic, level: sk.Basic},
+		{name: skill.LevelIntermediate, level: sk.Intermediate},
+		{name: skill.LevelAdvanced, level: sk.Advanced},
+	}
+	res := make([]SkillLevelProgress, 0, len(levels))
+	for _, l := range levels {
+		p, ok := slProgress[l.level.Id]
+		if l.level.Id == 0 || !ok {
+			continue
+		}
+		res = append(res, SkillLevelProgress{
+			ID:        l.level.Id,
+			SkillID:   sk.ID,
+			SkillName: sk.Name,
+			Level:     l.name,
+			Progress:  newProgress(p, len(l.level.Questions)+len(l.level.Cases)),
+		})
+	}
+	return res
+}
@@ -10,4 +10,12 @@ var (
 		Code: errs.SystemError.Code,
 		Msg:  errs.SystemError.Msg,
 	}
+	collectionNotFoundResult = ginx.Result{
+		Code: errs.CollectionNotFound.Code,
+		Msg:  errs.CollectionNotFound.Msg,
+	}
+	invalidHistoryResult = ginx.Result{
+		Code: errs.InvalidHistory.Code,
+		Msg:  errs.InvalidHistory.Msg,
+	}
 )
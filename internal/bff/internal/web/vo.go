@@ -4,13 +4,16 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 )
 
 type CollectionInfoReq struct {
-	ID     int64 `json:"id"`
-	Offset int   `json:"offset"`
-	Limit  int   `json:"limit"`
+	ID int64 `json:"id"`
+	// ShareToken 查看别人只对持有链接的人可见的收藏夹时需要，ID 为 0 的时候按照它查找
+	ShareToken string `json:"shareToken"`
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
 }
 
 type CollectionRecord struct {
@@ -122,3 +125,96 @@ func setQuestionSet(record interactive.CollectionRecord, qsm map[int64]baguwen.Q
 		Questions: questions,
 	}
 }
+
+type HistoryListReq struct {
+	// Biz 为空的时候不区分业务
+	Biz    string `json:"biz"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+type HistoryRecord struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// Utime 最后一次浏览的时间
+	Utime       int64       `json:"utime"`
+	Case        Case        `json:"case,omitempty"`
+	Question    Question    `json:"question,omitempty"`
+	QuestionSet QuestionSet `json:"questionSet,omitempty"`
+	Project     Project     `json:"project,omitempty"`
+}
+
+type Project struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type HistoryPositionReq struct {
+	// Biz 只支持 questionSet 和 project
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+}
+
+// HistoryPosition 一道题都没有看过的时候，Question 是零值
+type HistoryPosition struct {
+	Question Question `json:"question"`
+	Utime    int64    `json:"utime"`
+}
+
+type Dashboard struct {
+	// 全部题目和全部案例，没有总数
+	Question     Progress             `json:"question"`
+	Case         Progress             `json:"case"`
+	QuestionSets []SetProgress        `json:"questionSets"`
+	CaseSets     []SetProgress        `json:"caseSets"`
+	Projects     []ProjectProgress    `json:"projects"`
+	SkillLevels  []SkillLevelProgress `json:"skillLevels"`
+}
+
+// Progress 达到各个等级的数量，达到高等级的同时也会计入低等级
+type Progress struct {
+	Total           int   `json:"total"`
+	BasicCnt        int64 `json:"basicCnt"`
+	IntermediateCnt int64 `json:"intermediateCnt"`
+	AdvancedCnt     int64 `json:"advancedCnt"`
+}
+
+func newProgress(p progress.Progress, total int) Progress {
+	res := Progress{
+		Total:           total,
+		BasicCnt:        p.BasicCnt,
+		IntermediateCnt: p.IntermediateCnt,
+		AdvancedCnt:     p.AdvancedCnt,
+	}
+	if total > 0 {
+		// 集合里面的内容被删减之后，统计数据可能会超过总数
+		res.BasicCnt = min(res.BasicCnt, int64(total))
+		res.IntermediateCnt = min(res.IntermediateCnt, int64(total))
+		res.AdvancedCnt = min(res.AdvancedCnt, int64(total))
+	}
+	return res
+}
+
+type SetProgress struct {
+	ID       int64    `json:"id"`
+	Title    string   `json:"title"`
+	Progress Progress `json:"progress"`
+}
+
+// SkillLevelProgress 技能某个等级的进度，总数是这个等级关联的题目和案例的数量
+type SkillLevelProgress struct {
+	ID        int64  `json:"id"`
+	SkillID   int64  `json:"skillId"`
+	SkillName string `json:"skillName"`
+	// basic, intermediate, advanced
+	Level    string   `json:"level"`
+	Progress Progress `json:"progress"`
+}
+
+// ProjectProgress 项目的进度就是它关联的八股文题集的进度
+type ProjectProgress struct {
+	ID            int64    `json:"id"`
+	Title         string   `json:"title"`
+	QuestionSetID int64    `json:"questionSetId"`
+	Progress      Progress `json:"progress"`
+}
@@ -6,18 +6,27 @@ import (
 	"github.com/ecodeclub/webook/internal/bff/internal/web"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
 	"github.com/google/wire"
 )
 
 func InitModule(intrModule *interactive.Module,
 	caseModule *cases.Module,
-	queModule *baguwen.Module) (*Module, error) {
+	queModule *baguwen.Module,
+	progressModule *progress.Module,
+	prjModule *project.Module,
+	skillModule *skill.Module) (*Module, error) {
 	wire.Build(
 		web.NewHandler,
 		wire.FieldsOf(new(*baguwen.Module), "Svc", "SetSvc", "ExamSvc"),
-		wire.FieldsOf(new(*interactive.Module), "Svc"),
+		wire.FieldsOf(new(*interactive.Module), "Svc", "HistorySvc"),
 		wire.FieldsOf(new(*cases.Module), "SetSvc", "Svc", "ExamineSvc"),
+		wire.FieldsOf(new(*progress.Module), "Svc"),
+		wire.FieldsOf(new(*project.Module), "Svc"),
+		wire.FieldsOf(new(*skill.Module), "Svc"),
 		wire.Struct(new(Module), "*"),
 	)
 	return new(Module), nil
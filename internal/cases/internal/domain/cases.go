@@ -30,6 +30,10 @@ type Case struct {
 	BizId    int64
 	Ctime    time.Time
 	Utime    time.Time
+
+	// 定时发布和定时下线的时间，毫秒数，0 代表没有设置
+	PublishAt   int64
+	UnpublishAt int64
 }
 
 type CaseStatus uint8
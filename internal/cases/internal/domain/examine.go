@@ -5,12 +5,19 @@ type ExamineCaseResult struct {
 	Result CaseResult
 	// 原始回答，源自 AI
 	RawResult string
+	// 命中的要点和遗漏的要点，只有按照评分标准评价的时候才有
+	HitPoints    []string
+	MissedPoints []string
+	// 命中的亮点
+	HitHighlights []string
 
 	// 使用的 token 数量
 	Tokens int64
 	// 花费的金额
 	Amount int64
 	Tid    string
+	// 测试时间，导出个人数据的时候才有
+	Ctime int64
 }
 
 type CaseResult uint8
@@ -19,10 +26,21 @@ func (r CaseResult) ToUint8() uint8 {
 	return uint8(r)
 }
 
+func (r CaseResult) Valid() bool {
+	return r <= ResultAdvanced
+}
+
 const (
 	// ResultFailed 完全没通过，或者完全没有考过，我们不需要区别这两种状态
 	ResultFailed CaseResult = iota
-	// ResultPassed 回答通过
-	// 注意案例这边只有符合或者不符合，没有级别的评判
-	ResultPassed
+	// ResultBasic 只回答出部分要点
+	ResultBasic
+	// ResultIntermediate 回答出了全部要点
+	ResultIntermediate
+	// ResultAdvanced 回答出了全部要点，并且有亮点
+	ResultAdvanced
 )
+
+// ResultPassed 早期案例只有通过和不通过，通过的记录等价于 ResultBasic，
+// 所以历史数据不需要迁移
+const ResultPassed = ResultBasic
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+)
+
+// Rubric 案例的评分标准
+// 要点来源于 Keywords，亮点来源于 Highlight，引导点 Guidance 只用来辅助 AI 理解
+type Rubric struct {
+	KeyPoints  []string
+	Highlights []string
+	Guidance   string
+	// BasicRatio 命中要点的比例达到这个值就算 ResultBasic
+	BasicRatio float64
+	// IntermediateRatio 命中要点的比例达到这个值就算 ResultIntermediate
+	IntermediateRatio float64
+	// AdvancedHighlights 在 ResultIntermediate 的基础上，命中多少个亮点算 ResultAdvanced
+	AdvancedHighlights int
+}
+
+func NewRubric(c Case) Rubric {
+	return Rubric{
+		KeyPoints:          splitPoints(c.Keywords),
+		Highlights:         splitPoints(c.Highlight),
+		Guidance:           c.Guidance,
+		BasicRatio:         0.5,
+		IntermediateRatio:  1,
+		AdvancedHighlights: 1,
+	}
+}
+
+// IsEmpty 没有要点的案例没办法按照评分标准评价
+func (r Rubric) IsEmpty() bool {
+	return len(r.KeyPoints) == 0
+}
+
+// Level 根据命中的要点数量和亮点数量计算级别
+func (r Rubric) Level(hitPoints, hitHighlights int) CaseResult {
+	if r.IsEmpty() {
+		return ResultFailed
+	}
+	ratio := float64(hitPoints) / float64(len(r.KeyPoints))
+	switch {
+	case ratio >= r.IntermediateRatio && hitHighlights >= r.AdvancedHighlights:
+		return ResultAdvanced
+	case ratio >= r.IntermediateRatio:
+		return ResultIntermediate
+	case ratio >= r.BasicRatio && hitPoints > 0:
+		return ResultBasic
+	default:
+		return ResultFailed
+	}
+}
+
+// splitPoints 要点和亮点都是运营按行或者按照分号录入的
+func splitPoints(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\n' || r == ';' || r == '；'
+	})
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			res = append(res, f)
+		}
+	}
+	return res
+}
@@ -1,6 +1,10 @@
 package errs
 
 var (
+	InvalidSchedule = ErrorCode{Code: 405001, Msg: "定时下线时间必须晚于定时发布时间"}
+	InvalidResult   = ErrorCode{Code: 405002, Msg: "测试结果不合法"}
+	ResultNotFound  = ErrorCode{Code: 405003, Msg: "还没有测试过这个案例"}
+
 	SystemError = ErrorCode{Code: 505001, Msg: "系统错误"}
 	// InsufficientCredits 这个不管说是客户端错误还是服务端错误，都有点勉强，所以随便用一个 5
 	InsufficientCredits = ErrorCode{Code: 505002, Msg: "积分不足"}
@@ -0,0 +1,79 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// UserMergeConsumer 合并账号之后，把源账号的案例测试结果转移到目标账号上
+type UserMergeConsumer struct {
+	svc      service.ExamineService
+	consumer mq.Consumer
+	logger   *elog.Component
+}
+
+func NewUserMergeConsumer(svc service.ExamineService, q mq.MQ) (*UserMergeConsumer, error) {
+	groupID := "cases"
+	consumer, err := q.Consumer(event.UserMergeEventName, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &UserMergeConsumer{
+		svc:      svc,
+		consumer: consumer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserMergeConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费合并账号事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserMergeConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt event.UserMergeEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.svc.MergeResults(ctx, evt.SourceUid, evt.TargetUid)
+	if err != nil {
+		return fmt.Errorf("合并账号失败 %w, merge %d, source %d, target %d",
+			err, evt.Id, evt.SourceUid, evt.TargetUid)
+	}
+	return nil
+}
+
+func (c *UserMergeConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
@@ -85,6 +85,8 @@ type KnowledgeBaseEvent struct {
 	// 用途
 	Type            string `json:"type"`
 	KnowledgeBaseID string `json:"knowledgeBaseID"`
+	// 操作类型，为空代表上传
+	Action string `json:"action"`
 }
 
 func NewKnowledgeBaseEvent(ca domain.Case) (KnowledgeBaseEvent, error) {
@@ -100,3 +102,14 @@ func NewKnowledgeBaseEvent(ca domain.Case) (KnowledgeBaseEvent, error) {
 		Type:  ai.RepositoryBaseTypeRetrieval,
 	}, nil
 }
+
+// NewDeleteKnowledgeBaseEvent 案例下线之后从知识库中删除
+func NewDeleteKnowledgeBaseEvent(cid int64) KnowledgeBaseEvent {
+	return KnowledgeBaseEvent{
+		Biz:    domain.BizCase,
+		BizID:  cid,
+		Name:   fmt.Sprintf("case_%d", cid),
+		Type:   ai.RepositoryBaseTypeRetrieval,
+		Action: KnowledgeBaseActionDelete,
+	}
+}
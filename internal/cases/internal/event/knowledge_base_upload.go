@@ -8,7 +8,11 @@ import (
 	"github.com/ecodeclub/mq-api"
 )
 
-const KnowledgeBaseUploadTopic = "knowledge_base_upload_topic"
+const (
+	KnowledgeBaseUploadTopic = "knowledge_base_upload_topic"
+	// KnowledgeBaseActionDelete 从知识库中删除
+	KnowledgeBaseActionDelete = "delete"
+)
 
 type KnowledgeBaseEventProducer interface {
 	Produce(ctx context.Context, evt KnowledgeBaseEvent) error
@@ -4,6 +4,7 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/ai"
 	aimocks "github.com/ecodeclub/webook/internal/ai/mocks"
+	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
 	eveMocks "github.com/ecodeclub/webook/internal/cases/internal/event/mocks"
 	"github.com/ecodeclub/webook/internal/cases/internal/integration/startup"
@@ -38,6 +40,7 @@ type ExamineHandlerTest struct {
 	server *egin.Component
 	db     *egorm.Component
 	dao    dao.ExamineDAO
+	svc    cases.ExamineService
 	ctrl   *gomock.Controller
 }
 
@@ -61,6 +64,7 @@ func (s *ExamineHandlerTest) SetupSuite() {
 		&member.Module{}, session.DefaultProvider(), &ai.Module{Svc: aiSvc})
 	require.NoError(s.T(), err)
 	hdl := module.ExamineHdl
+	s.svc = module.ExamineSvc
 	s.db = testioc.InitDB()
 	econf.Set("server", map[string]any{"contextTimeout": "1s"})
 	server := egin.Load("server").Build()
@@ -161,6 +165,7 @@ func (s *ExamineHandlerTest) TestExamine() {
 			wantCode: 200,
 			wantResp: test.Result[web.ExamineResult]{
 				Data: web.ExamineResult{
+					Cid:       1,
 					Result:    domain.ResultPassed.ToUint8(),
 					RawResult: "通过",
 					Amount:    uid,
@@ -229,6 +234,7 @@ func (s *ExamineHandlerTest) TestExamine() {
 			},
 			wantResp: test.Result[web.ExamineResult]{
 				Data: web.ExamineResult{
+					Cid:       2,
 					Result:    domain.ResultPassed.ToUint8(),
 					RawResult: "通过",
 					Amount:    uid,
@@ -253,6 +259,243 @@ func (s *ExamineHandlerTest) TestExamine() {
 	}
 }
 
+func (s *ExamineHandlerTest) TestCorrect() {
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+
+		req web.CorrectReq
+
+		wantCode int
+		wantResp test.Result[web.ExamineResult]
+	}{
+		{
+			name: "修改为高级",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.CaseResult{
+					Uid:    uid,
+					Cid:    1,
+					Result: domain.ResultFailed.ToUint8(),
+					Ctime:  123,
+					Utime:  123,
+				}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var caseRes dao.CaseResult
+				err := s.db.WithContext(ctx).
+					Where("cid = ? AND uid = ?", 1, uid).
+					First(&caseRes).Error
+				require.NoError(t, err)
+				assert.True(t, caseRes.Utime > 123)
+				assert.Equal(t, domain.ResultAdvanced.ToUint8(), caseRes.Result)
+			},
+			req: web.CorrectReq{
+				Cid:    1,
+				Result: domain.ResultAdvanced.ToUint8(),
+			},
+			wantCode: 200,
+			wantResp: test.Result[web.ExamineResult]{
+				Data: web.ExamineResult{
+					Cid:    1,
+					Result: domain.ResultAdvanced.ToUint8(),
+				},
+			},
+		},
+		{
+			name: "结果不合法",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.CaseResult{
+					Uid:    uid,
+					Cid:    1,
+					Result: domain.ResultFailed.ToUint8(),
+					Ctime:  123,
+					Utime:  123,
+				}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var caseRes dao.CaseResult
+				err := s.db.WithContext(ctx).
+					Where("cid = ? AND uid = ?", 1, uid).
+					First(&caseRes).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(123), caseRes.Utime)
+				assert.Equal(t, domain.ResultFailed.ToUint8(), caseRes.Result)
+			},
+			req: web.CorrectReq{
+				Cid:    1,
+				Result: 9,
+			},
+			wantCode: 200,
+			wantResp: test.Result[web.ExamineResult]{
+				Code: 405002,
+				Msg:  "测试结果不合法",
+			},
+		},
+		{
+			name:   "没有测试过",
+			before: func(t *testing.T) {},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var cnt int64
+				err := s.db.WithContext(ctx).Model(&dao.CaseResult{}).
+					Where("cid = ? AND uid = ?", 2, uid).
+					Count(&cnt).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), cnt)
+			},
+			req: web.CorrectReq{
+				Cid:    2,
+				Result: domain.ResultAdvanced.ToUint8(),
+			},
+			wantCode: 200,
+			wantResp: test.Result[web.ExamineResult]{
+				Code: 405003,
+				Msg:  "还没有测试过这个案例",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/cases/examine/correct", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.ExamineResult]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+		})
+	}
+}
+
+func (s *ExamineHandlerTest) TestExamineRubricFallback() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	err := s.db.Create(&dao.PublishCase{
+		Id:        3,
+		Title:     "测试案例3",
+		Content:   "测试内容3",
+		Keywords:  "幂等\n重试",
+		Highlight: "对账补偿",
+	}).Error
+	require.NoError(t, err)
+
+	// 没有配置 case_rubric_examine 的时候退化成 case_examine
+	aiSvc := aimocks.NewMockService(s.ctrl)
+	gomock.InOrder(
+		aiSvc.EXPECT().Invoke(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req ai.LLMRequest) (ai.LLMResponse, error) {
+				assert.Equal(t, "case_rubric_examine", req.Biz)
+				return ai.LLMResponse{}, fmt.Errorf("读取业务配置失败 %w", ai.ErrBizConfigNotFound)
+			}),
+		aiSvc.EXPECT().Invoke(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req ai.LLMRequest) (ai.LLMResponse, error) {
+				assert.Equal(t, "case_examine", req.Biz)
+				assert.Equal(t, []string{"测试案例3", "测试内容3", "测试一下"}, req.Input)
+				return ai.LLMResponse{Tokens: 10, Amount: 10, Answer: "通过"}, nil
+			}),
+	)
+	module, err := startup.InitExamModule(eveMocks.NewMockSyncEventProducer(s.ctrl), nil,
+		&interactive.Module{Svc: intrmocks.NewMockService(s.ctrl)},
+		&member.Module{}, session.DefaultProvider(), &ai.Module{Svc: aiSvc})
+	require.NoError(t, err)
+
+	res, err := module.ExamineSvc.Examine(ctx, uid, 3, "测试一下")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResultPassed, res.Result)
+	assert.Equal(t, "通过", res.RawResult)
+	result, err := s.svc.GetResult(ctx, uid, 3)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResultPassed, result)
+}
+
+func (s *ExamineHandlerTest) TestMergeResults() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	const sourceUid, targetUid = 2001, 2002
+	err := s.db.Create(&[]dao.CaseResult{
+		{Uid: sourceUid, Cid: 1, Result: domain.ResultAdvanced.ToUint8()},
+		{Uid: sourceUid, Cid: 2, Result: domain.ResultBasic.ToUint8()},
+		{Uid: sourceUid, Cid: 3, Result: domain.ResultBasic.ToUint8()},
+		{Uid: targetUid, Cid: 1, Result: domain.ResultBasic.ToUint8()},
+		{Uid: targetUid, Cid: 2, Result: domain.ResultIntermediate.ToUint8()},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.CaseExamineRecord{Uid: sourceUid, Cid: 1, Tid: "merge-tid"}).Error
+	require.NoError(t, err)
+
+	// 重复执行结果也是一样的
+	require.NoError(t, s.svc.MergeResults(ctx, sourceUid, targetUid))
+	require.NoError(t, s.svc.MergeResults(ctx, sourceUid, targetUid))
+
+	results, err := s.dao.GetResultByUidAndCids(ctx, sourceUid, []int64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = s.dao.GetResultByUidAndCids(ctx, targetUid, []int64{1, 2, 3})
+	require.NoError(t, err)
+	got := make(map[int64]uint8, len(results))
+	for _, res := range results {
+		got[res.Cid] = res.Result
+	}
+	assert.Equal(t, map[int64]uint8{
+		1: domain.ResultAdvanced.ToUint8(),
+		2: domain.ResultIntermediate.ToUint8(),
+		3: domain.ResultBasic.ToUint8(),
+	}, got)
+	var record dao.CaseExamineRecord
+	err = s.db.WithContext(ctx).Where("tid = ?", "merge-tid").First(&record).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(targetUid), record.Uid)
+}
+
+func (s *ExamineHandlerTest) TestDeleteResults() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	const deletedUid, otherUid = 2101, 2102
+	err := s.db.Create(&[]dao.CaseResult{
+		{Uid: deletedUid, Cid: 1, Result: domain.ResultAdvanced.ToUint8()},
+		{Uid: otherUid, Cid: 1, Result: domain.ResultBasic.ToUint8()},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.CaseExamineRecord{
+		{Uid: deletedUid, Cid: 1, Tid: "deletion-tid-1", Ctime: 123},
+		{Uid: otherUid, Cid: 1, Tid: "deletion-tid-2"},
+	}).Error
+	require.NoError(t, err)
+
+	records, err := s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ExamineCaseResult{
+		{Cid: 1, Tid: "deletion-tid-1", Ctime: 123},
+	}, records)
+
+	require.NoError(t, s.svc.DeleteResults(ctx, deletedUid))
+
+	records, err = s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	results, err := s.dao.GetResultByUidAndCids(ctx, deletedUid, []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = s.dao.GetResultByUidAndCids(ctx, otherUid, []int64{1})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
 func TestExamineHandler(t *testing.T) {
 	suite.Run(t, new(ExamineHandlerTest))
 }
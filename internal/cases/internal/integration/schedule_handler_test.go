@@ -0,0 +1,202 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/ai"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	eveMocks "github.com/ecodeclub/webook/internal/cases/internal/event/mocks"
+	"github.com/ecodeclub/webook/internal/cases/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/cases/internal/web"
+	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/ego-component/egorm"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ScheduleTestSuite struct {
+	suite.Suite
+	server *egin.Component
+	db     *egorm.Component
+	job    *cases.ScheduledPublishJob
+}
+
+func (s *ScheduleTestSuite) TearDownTest() {
+	err := s.db.Exec("TRUNCATE TABLE `cases`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `publish_cases`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *ScheduleTestSuite) SetupSuite() {
+	ctrl := gomock.NewController(s.T())
+	// 发布和下线之后会异步发送事件，这里不关心
+	producer := eveMocks.NewMockSyncEventProducer(ctrl)
+	producer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	knowledgeBaseProducer := eveMocks.NewMockKnowledgeBaseEventProducer(ctrl)
+	knowledgeBaseProducer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	module, err := startup.InitModule(producer,
+		knowledgeBaseProducer, &ai.Module{},
+		&member.Module{}, session.DefaultProvider(), &interactive.Module{})
+	require.NoError(s.T(), err)
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: uid,
+			Data: map[string]string{
+				"creator":   "true",
+				"memberDDL": strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10),
+			},
+		}))
+	})
+	module.AdminHandler.PrivateRoutes(server.Engine)
+	s.server = server
+	s.job = module.ScheduledPublishJob
+	s.db = testioc.InitDB()
+	err = dao.InitTables(s.db)
+	require.NoError(s.T(), err)
+}
+
+func (s *ScheduleTestSuite) TestSchedule() {
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		after    func(t *testing.T)
+		req      web.ScheduleReq
+		wantCode int
+		wantResp test.Result[any]
+	}{
+		{
+			name: "设置定时发布和定时下线",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Case{Id: 1, Title: "标题1"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var ca dao.Case
+				err := s.db.Where("id = ?", 1).First(&ca).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(1000), ca.PublishAt)
+				assert.Equal(t, int64(2000), ca.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Cid: 1, PublishAt: 1000, UnpublishAt: 2000},
+			wantCode: 200,
+			wantResp: test.Result[any]{},
+		},
+		{
+			name: "下线时间早于发布时间",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Case{Id: 2, Title: "标题2"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var ca dao.Case
+				err := s.db.Where("id = ?", 2).First(&ca).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), ca.PublishAt)
+				assert.Equal(t, int64(0), ca.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Cid: 2, PublishAt: 2000, UnpublishAt: 1000},
+			wantCode: 500,
+			wantResp: test.Result[any]{Code: 405001, Msg: "定时下线时间必须晚于定时发布时间"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/cases/schedule", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[any]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+		})
+	}
+}
+
+func (s *ScheduleTestSuite) TestScheduledPublishJob() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	now := time.Now()
+	past := now.Add(-time.Minute).UnixMilli()
+	future := now.Add(time.Hour).UnixMilli()
+	unpublished := domain.UnPublishedStatus.ToUint8()
+	published := domain.PublishedStatus.ToUint8()
+	cs := []dao.Case{
+		// 到期需要发布
+		{Id: 1, Title: "到期发布", Status: unpublished, PublishAt: past},
+		// 还没到期
+		{Id: 2, Title: "没有到期", Status: unpublished, PublishAt: future},
+		// 到期需要下线
+		{Id: 3, Title: "到期下线", Status: published, UnpublishAt: past},
+	}
+	err := s.db.Create(&cs).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.PublishCase{Id: 3, Title: "到期下线", Status: published}).Error
+	require.NoError(t, err)
+
+	err = s.job.Run(ctx)
+	require.NoError(t, err)
+
+	var res []dao.Case
+	err = s.db.Order("id ASC").Find(&res).Error
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, published, res[0].Status)
+	assert.Equal(t, int64(0), res[0].PublishAt)
+	assert.Equal(t, unpublished, res[1].Status)
+	assert.Equal(t, future, res[1].PublishAt)
+	assert.Equal(t, unpublished, res[2].Status)
+	assert.Equal(t, int64(0), res[2].UnpublishAt)
+
+	// 线上库只剩下到期发布的
+	var pubs []dao.PublishCase
+	err = s.db.Order("id ASC").Find(&pubs).Error
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, int64(1), pubs[0].Id)
+	assert.Equal(t, "到期发布", pubs[0].Title)
+}
+
+func TestSchedule(t *testing.T) {
+	suite.Run(t, new(ScheduleTestSuite))
+}
@@ -21,7 +21,9 @@ import (
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/job"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/ecodeclub/webook/internal/cases/internal/web"
@@ -44,12 +46,15 @@ func InitModule(
 		dao.NewGORMExamineDAO,
 		repository.NewCaseRepo,
 		repository.NewCaseSetRepo,
+		cache.NewPublishedCache,
 		repository.NewCachedExamineRepository,
+		event.NewExamineEventProducer,
 		event.NewInteractiveEventProducer,
 		service.NewService,
 		service.NewCaseSetService,
 		service.NewLLMExamineService,
 		initKnowledgeBaseSvc,
+		initScheduledPublishJob,
 		web.NewHandler,
 		web.NewAdminCaseSetHandler,
 		web.NewAdminCaseHandler,
@@ -57,7 +62,7 @@ func InitModule(
 		wire.FieldsOf(new(*interactive.Module), "Svc"),
 		wire.FieldsOf(new(*member.Module), "Svc"),
 		wire.FieldsOf(new(*ai.Module), "Svc", "KnowledgeBaseSvc"),
-		wire.Struct(new(cases.Module), "AdminHandler", "ExamineSvc", "Svc", "Hdl", "AdminSetHandler", "KnowledgeBaseHandler"),
+		wire.Struct(new(cases.Module), "AdminHandler", "ExamineSvc", "Svc", "Hdl", "AdminSetHandler", "KnowledgeBaseHandler", "ScheduledPublishJob"),
 	)
 	return new(cases.Module), nil
 }
@@ -76,12 +81,15 @@ func InitExamModule(
 		dao.NewGORMExamineDAO,
 		repository.NewCaseRepo,
 		repository.NewCaseSetRepo,
+		cache.NewPublishedCache,
 		repository.NewCachedExamineRepository,
+		event.NewExamineEventProducer,
 		event.NewInteractiveEventProducer,
 		service.NewCaseSetService,
 		service.NewService,
 		service.NewLLMExamineService,
 		initKnowledgeBaseSvc,
+		initScheduledPublishJob,
 		web.NewHandler,
 		web.NewAdminCaseSetHandler,
 		web.NewAdminCaseHandler,
@@ -96,6 +104,10 @@ func InitExamModule(
 	return new(cases.Module), nil
 }
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
 func initKnowledgeBaseSvc(svc ai.KnowledgeBaseService, caRepo repository.CaseRepo) service.KnowledgeBaseService {
 	return service.NewKnowledgeBaseService(caRepo, svc, "knowledge_id")
 }
@@ -11,7 +11,9 @@ import (
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/job"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/ecodeclub/webook/internal/cases/internal/web"
@@ -25,7 +27,9 @@ import (
 func InitModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer event.KnowledgeBaseEventProducer, aiModule *ai.Module, memberModule *member.Module, sp session.Provider, intrModule *interactive.Module) (*cases.Module, error) {
 	db := testioc.InitDB()
 	caseDAO := cases.InitCaseDAO(db)
-	caseRepo := repository.NewCaseRepo(caseDAO)
+	readThroughCache := testioc.InitReadThroughCache()
+	publishedCache := cache.NewPublishedCache(readThroughCache)
+	caseRepo := repository.NewCaseRepo(caseDAO, publishedCache)
 	mq := testioc.InitMQ()
 	interactiveEventProducer, err := event.NewInteractiveEventProducer(mq)
 	if err != nil {
@@ -36,17 +40,22 @@ func InitModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer even
 	examineDAO := dao.NewGORMExamineDAO(db)
 	examineRepository := repository.NewCachedExamineRepository(examineDAO)
 	llmService := aiModule.Svc
-	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService)
+	examineEventProducer, err := event.NewExamineEventProducer(mq)
+	if err != nil {
+		return nil, err
+	}
+	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService, examineEventProducer)
 	service2 := intrModule.Svc
 	service3 := memberModule.Svc
 	handler := web.NewHandler(serviceService, examineService, service2, service3, sp)
 	caseSetDAO := dao.NewCaseSetDAO(db)
-	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO)
+	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO, publishedCache)
 	caseSetService := service.NewCaseSetService(caseSetRepository, caseRepo, interactiveEventProducer)
 	adminCaseSetHandler := web.NewAdminCaseSetHandler(caseSetService)
 	repositoryBaseSvc := aiModule.KnowledgeBaseSvc
 	knowledgeBaseService := initKnowledgeBaseSvc(repositoryBaseSvc, caseRepo)
 	knowledgeBaseHandler := web.NewKnowledgeBaseHandler(knowledgeBaseService)
+	scheduledPublishJob := initScheduledPublishJob(serviceService)
 	module := &cases.Module{
 		AdminHandler:         adminCaseHandler,
 		ExamineSvc:           examineService,
@@ -54,6 +63,7 @@ func InitModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer even
 		Hdl:                  handler,
 		AdminSetHandler:      adminCaseSetHandler,
 		KnowledgeBaseHandler: knowledgeBaseHandler,
+		ScheduledPublishJob:  scheduledPublishJob,
 	}
 	return module, nil
 }
@@ -61,7 +71,9 @@ func InitModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer even
 func InitExamModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer event.KnowledgeBaseEventProducer, intrModule *interactive.Module, memberModule *member.Module, sp session.Provider, aiModule *ai.Module) (*cases.Module, error) {
 	db := testioc.InitDB()
 	caseDAO := cases.InitCaseDAO(db)
-	caseRepo := repository.NewCaseRepo(caseDAO)
+	readThroughCache := testioc.InitReadThroughCache()
+	publishedCache := cache.NewPublishedCache(readThroughCache)
+	caseRepo := repository.NewCaseRepo(caseDAO, publishedCache)
 	mq := testioc.InitMQ()
 	interactiveEventProducer, err := event.NewInteractiveEventProducer(mq)
 	if err != nil {
@@ -69,12 +81,16 @@ func InitExamModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer
 	}
 	serviceService := service.NewService(caseRepo, interactiveEventProducer, knowledgeBaseProducer, syncProducer)
 	caseSetDAO := dao.NewCaseSetDAO(db)
-	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO)
+	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO, publishedCache)
 	caseSetService := service.NewCaseSetService(caseSetRepository, caseRepo, interactiveEventProducer)
 	examineDAO := dao.NewGORMExamineDAO(db)
 	examineRepository := repository.NewCachedExamineRepository(examineDAO)
 	llmService := aiModule.Svc
-	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService)
+	examineEventProducer, err := event.NewExamineEventProducer(mq)
+	if err != nil {
+		return nil, err
+	}
+	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService, examineEventProducer)
 	service2 := intrModule.Svc
 	service3 := memberModule.Svc
 	handler := web.NewHandler(serviceService, examineService, service2, service3, sp)
@@ -85,6 +101,7 @@ func InitExamModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer
 	repositoryBaseSvc := aiModule.KnowledgeBaseSvc
 	knowledgeBaseService := initKnowledgeBaseSvc(repositoryBaseSvc, caseRepo)
 	knowledgeBaseHandler := web.NewKnowledgeBaseHandler(knowledgeBaseService)
+	scheduledPublishJob := initScheduledPublishJob(serviceService)
 	module := &cases.Module{
 		Svc:                  serviceService,
 		SetSvc:               caseSetService,
@@ -95,12 +112,17 @@ func InitExamModule(syncProducer event.SyncEventProducer, knowledgeBaseProducer
 		ExamineHdl:           examineHandler,
 		CsHdl:                caseSetHandler,
 		KnowledgeBaseHandler: knowledgeBaseHandler,
+		ScheduledPublishJob:  scheduledPublishJob,
 	}
 	return module, nil
 }
 
 // wire.go:
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
 func initKnowledgeBaseSvc(svc ai.KnowledgeBaseService, caRepo repository.CaseRepo) service.KnowledgeBaseService {
 	return service.NewKnowledgeBaseService(caRepo, svc, "knowledge_id")
 }
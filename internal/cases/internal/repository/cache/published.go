@@ -0,0 +1,91 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+)
+
+// PublishedCache 案例和案例集的读穿透缓存
+// 详情按照 id 缓存，列表页和案例集的 key 里面带上版本号，失效的时候删除版本号
+type PublishedCache interface {
+	GetCase(ctx context.Context, id int64,
+		load func(ctx context.Context) (domain.Case, error)) (domain.Case, error)
+	GetCaseList(ctx context.Context, offset, limit int,
+		load func(ctx context.Context) ([]domain.Case, error)) ([]domain.Case, error)
+	GetCaseSet(ctx context.Context, id int64,
+		load func(ctx context.Context) (domain.CaseSet, error)) (domain.CaseSet, error)
+	GetCaseSetList(ctx context.Context, biz string, offset, limit int,
+		load func(ctx context.Context) ([]domain.CaseSet, error)) ([]domain.CaseSet, error)
+
+	// InvalidateCase 案例修改、发布或者下线之后调用，案例集的缓存也会一起失效
+	InvalidateCase(ctx context.Context, id int64) error
+	// InvalidateCaseSet 案例集修改之后调用
+	InvalidateCaseSet(ctx context.Context) error
+}
+
+type publishedCache struct {
+	rc *cachex.ReadThroughCache
+}
+
+func NewPublishedCache(rc *cachex.ReadThroughCache) PublishedCache {
+	return &publishedCache{
+		rc: rc.WithNamespace("cases:pub:"),
+	}
+}
+
+func (p *publishedCache) GetCase(ctx context.Context, id int64,
+	load func(ctx context.Context) (domain.Case, error)) (domain.Case, error) {
+	return cachex.Get(ctx, p.rc, p.caseKey(id), load)
+}
+
+func (p *publishedCache) GetCaseList(ctx context.Context, offset, limit int,
+	load func(ctx context.Context) ([]domain.Case, error)) ([]domain.Case, error) {
+	key := fmt.Sprintf("list:%s:%d:%d", p.rc.Version(ctx, p.versionKey()), offset, limit)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) GetCaseSet(ctx context.Context, id int64,
+	load func(ctx context.Context) (domain.CaseSet, error)) (domain.CaseSet, error) {
+	key := fmt.Sprintf("set:%s:%d", p.rc.Version(ctx, p.versionKey()), id)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) GetCaseSetList(ctx context.Context, biz string, offset, limit int,
+	load func(ctx context.Context) ([]domain.CaseSet, error)) ([]domain.CaseSet, error) {
+	key := fmt.Sprintf("setList:%s:%s:%d:%d", p.rc.Version(ctx, p.versionKey()), biz, offset, limit)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) InvalidateCase(ctx context.Context, id int64) error {
+	return p.rc.Delete(ctx, p.caseKey(id), p.versionKey())
+}
+
+func (p *publishedCache) InvalidateCaseSet(ctx context.Context) error {
+	return p.rc.Delete(ctx, p.versionKey())
+}
+
+func (p *publishedCache) caseKey(id int64) string {
+	return fmt.Sprintf("detail:%d", id)
+}
+
+// versionKey 列表页和案例集共用一个版本号
+func (p *publishedCache) versionKey() string {
+	return "version"
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
+	"github.com/gotomicro/ego/core/elog"
 )
 
 type CaseSetRepository interface {
@@ -26,30 +28,41 @@ type CaseSetRepository interface {
 
 	ListByBiz(ctx context.Context, offset, limit int, biz string) ([]domain.CaseSet, error)
 	GetByBiz(ctx context.Context, biz string, bizId int64) (domain.CaseSet, error)
+	GetIDsByCid(ctx context.Context, cid int64) ([]int64, error)
 }
 
 type caseSetRepo struct {
-	dao dao.CaseSetDAO
+	dao      dao.CaseSetDAO
+	pubCache cache.PublishedCache
+	logger   *elog.Component
 }
 
 func (c *caseSetRepo) CountByBiz(ctx context.Context, biz string) (int64, error) {
 	return c.dao.CountByBiz(ctx, biz)
 }
 
-func NewCaseSetRepo(caseSetDao dao.CaseSetDAO) CaseSetRepository {
+func NewCaseSetRepo(caseSetDao dao.CaseSetDAO, pc cache.PublishedCache) CaseSetRepository {
 	return &caseSetRepo{
-		dao: caseSetDao,
+		dao:      caseSetDao,
+		pubCache: pc,
+		logger:   elog.DefaultLogger,
 	}
 }
 
 func (c *caseSetRepo) ListByBiz(ctx context.Context, offset, limit int, biz string) ([]domain.CaseSet, error) {
-	qs, err := c.dao.ListByBiz(ctx, offset, limit, biz)
-	if err != nil {
-		return nil, err
-	}
-	return slice.Map(qs, func(idx int, src dao.CaseSet) domain.CaseSet {
-		return c.toDomainCaseSet(src)
-	}), err
+	return c.pubCache.GetCaseSetList(ctx, biz, offset, limit, func(ctx context.Context) ([]domain.CaseSet, error) {
+		qs, err := c.dao.ListByBiz(ctx, offset, limit, biz)
+		if err != nil {
+			return nil, err
+		}
+		return slice.Map(qs, func(idx int, src dao.CaseSet) domain.CaseSet {
+			return c.toDomainCaseSet(src)
+		}), err
+	})
+}
+
+func (c *caseSetRepo) GetIDsByCid(ctx context.Context, cid int64) ([]int64, error) {
+	return c.dao.GetIDsByCid(ctx, cid)
 }
 
 func (c *caseSetRepo) GetByBiz(ctx context.Context, biz string, bizId int64) (domain.CaseSet, error) {
@@ -74,7 +87,12 @@ func (c *caseSetRepo) GetByBiz(ctx context.Context, biz string, bizId int64) (do
 }
 
 func (c *caseSetRepo) CreateCaseSet(ctx context.Context, set domain.CaseSet) (int64, error) {
-	return c.dao.Create(ctx, c.toEntityQuestionSet(set))
+	id, err := c.dao.Create(ctx, c.toEntityQuestionSet(set))
+	if err != nil {
+		return 0, err
+	}
+	c.invalidate(ctx)
+	return id, nil
 }
 
 func (c *caseSetRepo) UpdateCases(ctx context.Context, set domain.CaseSet) error {
@@ -82,10 +100,21 @@ func (c *caseSetRepo) UpdateCases(ctx context.Context, set domain.CaseSet) error
 	for i := range set.Cases {
 		cids = append(cids, set.Cases[i].Id)
 	}
-	return c.dao.UpdateCasesByID(ctx, set.ID, cids)
+	err := c.dao.UpdateCasesByID(ctx, set.ID, cids)
+	if err != nil {
+		return err
+	}
+	c.invalidate(ctx)
+	return nil
 }
 
 func (c *caseSetRepo) GetByID(ctx context.Context, id int64) (domain.CaseSet, error) {
+	return c.pubCache.GetCaseSet(ctx, id, func(ctx context.Context) (domain.CaseSet, error) {
+		return c.getByID(ctx, id)
+	})
+}
+
+func (c *caseSetRepo) getByID(ctx context.Context, id int64) (domain.CaseSet, error) {
 	set, err := c.dao.GetByID(ctx, id)
 	if err != nil {
 		return domain.CaseSet{}, err
@@ -132,7 +161,19 @@ func (c *caseSetRepo) List(ctx context.Context, offset int, limit int) ([]domain
 }
 
 func (c *caseSetRepo) UpdateNonZero(ctx context.Context, set domain.CaseSet) error {
-	return c.dao.UpdateNonZero(ctx, c.toEntityQuestionSet(set))
+	err := c.dao.UpdateNonZero(ctx, c.toEntityQuestionSet(set))
+	if err != nil {
+		return err
+	}
+	c.invalidate(ctx)
+	return nil
+}
+
+func (c *caseSetRepo) invalidate(ctx context.Context) {
+	err := c.pubCache.InvalidateCaseSet(ctx)
+	if err != nil {
+		c.logger.Error("让案例集缓存失效失败", elog.FieldErr(err))
+	}
 }
 
 func (c *caseSetRepo) GetByIDs(ctx context.Context, ids []int64) ([]domain.CaseSet, error) {
@@ -7,9 +7,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ecodeclub/ekit/slice"
+	"github.com/gotomicro/ego/core/elog"
 
 	"github.com/ecodeclub/ekit/sqlx"
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 )
 
@@ -27,13 +29,24 @@ type CaseRepo interface {
 	Total(ctx context.Context) (int64, error)
 	Save(ctx context.Context, ca domain.Case) (int64, error)
 	GetById(ctx context.Context, caseId int64) (domain.Case, error)
+	// Unpublish 下线，删除线上库的数据
+	Unpublish(ctx context.Context, caseId int64) error
+
+	UpdateSchedule(ctx context.Context, caseId int64, publishAt int64, unpublishAt int64) error
+	ClearPublishAt(ctx context.Context, caseId int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Case, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error)
 
 	// Exclude 分页接口，不含这些 id 的问题
 	Exclude(ctx context.Context, ids []int64, offset int, limit int) ([]domain.Case, int64, error)
 }
 
 type caseRepo struct {
-	caseDao dao.CaseDAO
+	caseDao  dao.CaseDAO
+	pubCache cache.PublishedCache
+	logger   *elog.Component
 }
 
 func (c *caseRepo) PubCount(ctx context.Context) (int64, error) {
@@ -68,23 +81,27 @@ func (c *caseRepo) Exclude(ctx context.Context, ids []int64, offset int, limit i
 }
 
 func (c *caseRepo) PubList(ctx context.Context, offset int, limit int) ([]domain.Case, error) {
-	caseList, err := c.caseDao.PublishCaseList(ctx, offset, limit)
-	if err != nil {
-		return nil, err
-	}
-	domainCases := make([]domain.Case, 0, len(caseList))
-	for _, ca := range caseList {
-		domainCases = append(domainCases, c.toDomain(dao.Case(ca)))
-	}
-	return domainCases, nil
+	return c.pubCache.GetCaseList(ctx, offset, limit, func(ctx context.Context) ([]domain.Case, error) {
+		caseList, err := c.caseDao.PublishCaseList(ctx, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		domainCases := make([]domain.Case, 0, len(caseList))
+		for _, ca := range caseList {
+			domainCases = append(domainCases, c.toDomain(dao.Case(ca)))
+		}
+		return domainCases, nil
+	})
 }
 
 func (c *caseRepo) GetPubByID(ctx context.Context, caseId int64) (domain.Case, error) {
-	caseInfo, err := c.caseDao.GetPublishCase(ctx, caseId)
-	if err != nil {
-		return domain.Case{}, err
-	}
-	return c.toDomain(dao.Case(caseInfo)), nil
+	return c.pubCache.GetCase(ctx, caseId, func(ctx context.Context) (domain.Case, error) {
+		caseInfo, err := c.caseDao.GetPublishCase(ctx, caseId)
+		if err != nil {
+			return domain.Case{}, err
+		}
+		return c.toDomain(dao.Case(caseInfo)), nil
+	})
 }
 
 func (c *caseRepo) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Case, error) {
@@ -96,7 +113,12 @@ func (c *caseRepo) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Case,
 
 func (c *caseRepo) Sync(ctx context.Context, ca domain.Case) (int64, error) {
 	caseModel := c.toEntity(ca)
-	return c.caseDao.Sync(ctx, caseModel)
+	id, err := c.caseDao.Sync(ctx, caseModel)
+	if err != nil {
+		return 0, err
+	}
+	c.invalidate(ctx, id)
+	return id, nil
 }
 
 func (c *caseRepo) List(ctx context.Context, offset int, limit int) ([]domain.Case, error) {
@@ -116,7 +138,13 @@ func (c *caseRepo) Total(ctx context.Context) (int64, error) {
 }
 
 func (c *caseRepo) Save(ctx context.Context, ca domain.Case) (int64, error) {
-	return c.caseDao.Save(ctx, c.toEntity(ca))
+	id, err := c.caseDao.Save(ctx, c.toEntity(ca))
+	if err != nil {
+		return 0, err
+	}
+	// 案例集详情里面的案例来自制作库，所以保存也要让缓存失效
+	c.invalidate(ctx, id)
+	return id, nil
 }
 
 func (c *caseRepo) GetById(ctx context.Context, caseId int64) (domain.Case, error) {
@@ -127,6 +155,56 @@ func (c *caseRepo) GetById(ctx context.Context, caseId int64) (domain.Case, erro
 	return c.toDomain(ca), err
 }
 
+func (c *caseRepo) Unpublish(ctx context.Context, caseId int64) error {
+	err := c.caseDao.Unpublish(ctx, caseId)
+	if err != nil {
+		return err
+	}
+	c.invalidate(ctx, caseId)
+	return nil
+}
+
+// invalidate 数据库已经修改成功了，缓存失效失败只记录日志，等缓存过期
+func (c *caseRepo) invalidate(ctx context.Context, caseId int64) {
+	err := c.pubCache.InvalidateCase(ctx, caseId)
+	if err != nil {
+		c.logger.Error("让案例缓存失效失败", elog.FieldErr(err), elog.Int64("cid", caseId))
+	}
+}
+
+func (c *caseRepo) UpdateSchedule(ctx context.Context, caseId int64, publishAt int64, unpublishAt int64) error {
+	return c.caseDao.UpdateSchedule(ctx, caseId, publishAt, unpublishAt)
+}
+
+func (c *caseRepo) ClearPublishAt(ctx context.Context, caseId int64, publishAt int64) error {
+	return c.caseDao.ClearPublishAt(ctx, caseId, publishAt)
+}
+
+func (c *caseRepo) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Case, error) {
+	cs, err := c.caseDao.ScheduledList(ctx, offset, limit)
+	return slice.Map(cs, func(idx int, src dao.Case) domain.Case {
+		return c.toDomain(src)
+	}), err
+}
+
+func (c *caseRepo) ScheduledCount(ctx context.Context) (int64, error) {
+	return c.caseDao.ScheduledCount(ctx)
+}
+
+func (c *caseRepo) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error) {
+	cs, err := c.caseDao.DuePublish(ctx, now, minId, limit)
+	return slice.Map(cs, func(idx int, src dao.Case) domain.Case {
+		return c.toDomain(src)
+	}), err
+}
+
+func (c *caseRepo) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error) {
+	cs, err := c.caseDao.DueUnpublish(ctx, now, minId, limit)
+	return slice.Map(cs, func(idx int, src dao.Case) domain.Case {
+		return c.toDomain(src)
+	}), err
+}
+
 func (c *caseRepo) toEntity(caseDomain domain.Case) dao.Case {
 	labels := sqlx.JsonColumn[[]string]{
 		Valid: len(caseDomain.Labels) > 0,
@@ -170,12 +248,15 @@ func (c *caseRepo) toDomain(caseDao dao.Case) domain.Case {
 		Utime:        time.UnixMilli(caseDao.Utime),
 		Ctime:        time.UnixMilli(caseDao.Ctime),
 		Status:       domain.CaseStatus(caseDao.Status),
+		PublishAt:    caseDao.PublishAt,
+		UnpublishAt:  caseDao.UnpublishAt,
 	}
 }
 
-func NewCaseRepo(caseDao dao.CaseDAO) CaseRepo {
+func NewCaseRepo(caseDao dao.CaseDAO, pc cache.PublishedCache) CaseRepo {
 	return &caseRepo{
-		caseDao: caseDao,
-		// 后续接入缓存
+		caseDao:  caseDao,
+		pubCache: pc,
+		logger:   elog.DefaultLogger,
 	}
 }
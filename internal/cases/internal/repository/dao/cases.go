@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/gorm/clause"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/ego-component/egorm"
 	"gorm.io/gorm"
 )
@@ -20,6 +21,20 @@ type CaseDAO interface {
 	Count(ctx context.Context) (int64, error)
 
 	Sync(ctx context.Context, c Case) (int64, error)
+	// Unpublish 删除线上库的数据，并且将制作库的状态修改为未发布
+	Unpublish(ctx context.Context, id int64) error
+
+	// 定时发布
+	UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	// ClearPublishAt 只有在定时发布时间没有被修改过的情况下才会清空
+	ClearPublishAt(ctx context.Context, id int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset, limit int) ([]Case, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	// DuePublish 到期需要发布的数据，按照 id 升序，只返回 id 大于 minId 的
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]Case, error)
+	// DueUnpublish 到期需要下线的数据，按照 id 升序，只返回 id 大于 minId 的
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]Case, error)
+
 	// 提供给同步到知识库用
 	Ids(ctx context.Context) ([]int64, error)
 	// 线上库
@@ -33,6 +48,7 @@ type CaseDAO interface {
 }
 
 type caseDAO struct {
+	*schedulex.GORMDAO[Case]
 	db            *egorm.Component
 	listColumns   []string
 	updateColumns []string
@@ -149,8 +165,25 @@ func (ca *caseDAO) GetPubByIDs(ctx context.Context, ids []int64) ([]PublishCase,
 	return c, err
 }
 
+func (ca *caseDAO) Unpublish(ctx context.Context, id int64) error {
+	return ca.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 手动下线的时候也要清空定时发布，避免定时任务又把它发布出去
+		err := tx.Model(&Case{}).Where("id = ?", id).Updates(map[string]any{
+			"status":       domain.UnPublishedStatus.ToUint8(),
+			"publish_at":   0,
+			"unpublish_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&PublishCase{}).Error
+	})
+}
+
 func NewCaseDao(db *egorm.Component) CaseDAO {
 	return &caseDAO{
+		GORMDAO:     schedulex.NewGORMDAO[Case](db),
 		db:          db,
 		listColumns: []string{"id", "labels", "status", "introduction", "title", "utime"},
 		updateColumns: []string{
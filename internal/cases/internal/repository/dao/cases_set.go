@@ -23,6 +23,8 @@ type CaseSetDAO interface {
 
 	ListByBiz(ctx context.Context, offset int, limit int, biz string) ([]CaseSet, error)
 	GetByBiz(ctx context.Context, biz string, bizId int64) (CaseSet, error)
+	// GetIDsByCid 包含了该案例的案例集
+	GetIDsByCid(ctx context.Context, cid int64) ([]int64, error)
 	GetRefCasesByIDs(ctx context.Context, ids []int64) ([]CaseSetCase, error)
 }
 
@@ -76,6 +78,13 @@ func (c *caseSetDAO) GetByID(ctx context.Context, id int64) (CaseSet, error) {
 	return cs, err
 }
 
+func (c *caseSetDAO) GetIDsByCid(ctx context.Context, cid int64) ([]int64, error) {
+	var res []int64
+	err := c.db.WithContext(ctx).Model(&CaseSetCase{}).
+		Where("cid = ?", cid).Pluck("cs_id", &res).Error
+	return res, err
+}
+
 func (c *caseSetDAO) GetCasesByID(ctx context.Context, id int64) ([]Case, error) {
 	var cids []int64
 	err := c.db.WithContext(ctx).
@@ -1,5 +1,7 @@
 package dao
 
+import "github.com/ecodeclub/ekit/sqlx"
+
 // CaseExamineRecord 业务层面上记录
 type CaseExamineRecord struct {
 	Id  int64
@@ -11,6 +13,10 @@ type CaseExamineRecord struct {
 	Result uint8
 	// 原始的 AI 回答
 	RawResult string
+	// 按照评分标准评价的时候，命中的要点、遗漏的要点和命中的亮点
+	HitPoints     sqlx.JsonColumn[[]string] `gorm:"type:text"`
+	MissedPoints  sqlx.JsonColumn[[]string] `gorm:"type:text"`
+	HitHighlights sqlx.JsonColumn[[]string] `gorm:"type:text"`
 	// 冗余字段，使用的 tokens 数量
 	Tokens int64
 	// 冗余字段，花费的金额
@@ -15,6 +15,14 @@ type ExamineDAO interface {
 	SaveResult(ctx context.Context, record CaseExamineRecord) error
 	GetResultByUidAndCid(ctx context.Context, uid int64, cid int64) (CaseResult, error)
 	GetResultByUidAndCids(ctx context.Context, uid int64, cids []int64) ([]CaseResult, error)
+	UpdateCaseResult(ctx context.Context, result CaseResult) error
+	// MergeResults 把 sourceUid 的测试记录和结果转移到 targetUid 上，两边都测试过的保留更好的结果，
+	// 返回 targetUid 上发生了变化的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]CaseResult, error)
+	// FindRecordsByUid 用户所有的测试记录，最早的在前面
+	FindRecordsByUid(ctx context.Context, uid int64) ([]CaseExamineRecord, error)
+	// DeleteByUid 删除用户的测试记录和结果
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type GORMExamineDAO struct {
@@ -62,3 +70,87 @@ func (dao *GORMExamineDAO) GetResultByUidAndCids(ctx context.Context, uid int64,
 	err := dao.db.WithContext(ctx).Where("uid = ? AND cid IN ?", uid, cids).Find(&res).Error
 	return res, err
 }
+
+// UpdateCaseResult 没有测试过的案例返回 ErrRecordNotFound
+func (dao *GORMExamineDAO) UpdateCaseResult(ctx context.Context, result CaseResult) error {
+	now := time.Now().UnixMilli()
+	res := dao.db.WithContext(ctx).Model(&CaseResult{}).
+		Where("uid = ? AND cid = ?", result.Uid, result.Cid).
+		Updates(map[string]any{
+			"result": result.Result,
+			"utime":  now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (dao *GORMExamineDAO) MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]CaseResult, error) {
+	var changed []CaseResult
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := tx.Model(&CaseExamineRecord{}).Where("uid = ?", sourceUid).
+			Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		var sources []CaseResult
+		err = tx.Where("uid = ?", sourceUid).Find(&sources).Error
+		if err != nil || len(sources) == 0 {
+			return err
+		}
+		cids := make([]int64, 0, len(sources))
+		for _, src := range sources {
+			cids = append(cids, src.Cid)
+		}
+		var targets []CaseResult
+		err = tx.Where("uid = ? AND cid IN ?", targetUid, cids).Find(&targets).Error
+		if err != nil {
+			return err
+		}
+		results := make(map[int64]uint8, len(targets))
+		for _, dst := range targets {
+			results[dst.Cid] = dst.Result
+		}
+		for _, src := range sources {
+			res, ok := results[src.Cid]
+			if ok && res >= src.Result {
+				continue
+			}
+			if ok {
+				err = tx.Model(&CaseResult{}).Where("uid = ? AND cid = ?", targetUid, src.Cid).
+					Updates(map[string]any{"result": src.Result, "utime": now}).Error
+			} else {
+				err = tx.Model(&CaseResult{}).Where("id = ?", src.Id).
+					Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+			}
+			if err != nil {
+				return err
+			}
+			changed = append(changed, CaseResult{Uid: targetUid, Cid: src.Cid, Result: src.Result})
+		}
+		// 转移走的已经不在 sourceUid 名下了，剩下的都是重复的
+		return tx.Where("uid = ?", sourceUid).Delete(&CaseResult{}).Error
+	})
+	return changed, err
+}
+
+func (dao *GORMExamineDAO) FindRecordsByUid(ctx context.Context, uid int64) ([]CaseExamineRecord, error) {
+	var res []CaseExamineRecord
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMExamineDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("uid = ?", uid).Delete(&CaseExamineRecord{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&CaseResult{}).Error
+	})
+}
@@ -26,8 +26,11 @@ type Case struct {
 	Status   uint8  `gorm:"type:tinyint(3);comment:0-未知 1-未发表 2-已发表"`
 	Biz      string `gorm:"type=varchar(256);index:biz;not null;default:'baguwen';"`
 	BizId    int64  `gorm:"index:biz;not null;default:0;"`
-	Ctime    int64
-	Utime    int64 `gorm:"index"`
+	// 定时发布和定时下线的时间，0 代表没有设置
+	PublishAt   int64 `gorm:"index;not null;default:0"`
+	UnpublishAt int64 `gorm:"index;not null;default:0"`
+	Ctime       int64
+	Utime       int64 `gorm:"index"`
 }
 
 func (Case) TableName() string {
@@ -60,7 +63,7 @@ type CaseSet struct {
 type CaseSetCase struct {
 	Id    int64 `gorm:"primaryKey,autoIncrement"`
 	CSID  int64 `gorm:"column:cs_id;uniqueIndex:csid_cid"`
-	CID   int64 `gorm:"column:cid;uniqueIndex:csid_cid"`
+	CID   int64 `gorm:"column:cid;uniqueIndex:csid_cid;index"`
 	Ctime int64
 	Utime int64 `gorm:"index"`
 }
@@ -8,15 +8,25 @@ import (
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 
 	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ekit/sqlx"
 )
 
 type ExamineRepository interface {
 	SaveResult(ctx context.Context, uid, cid int64, result domain.ExamineCaseResult) error
 	GetResultByUidAndQid(ctx context.Context, uid int64, cid int64) (domain.CaseResult, error)
 	GetResultsByIds(ctx context.Context, uid int64, ids []int64) ([]domain.ExamineCaseResult, error)
+	UpdateCaseResult(ctx context.Context, uid, cid int64, result domain.CaseResult) error
+	// MergeResults 返回 targetUid 上发生了变化的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]domain.ExamineCaseResult, error)
+	Records(ctx context.Context, uid int64) ([]domain.ExamineCaseResult, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
-var _ ExamineRepository = &CachedExamineRepository{}
+var (
+	_ ExamineRepository = &CachedExamineRepository{}
+
+	ErrResultNotFound = dao.ErrRecordNotFound
+)
 
 type CachedExamineRepository struct {
 	dao dao.ExamineDAO
@@ -48,12 +58,58 @@ func (repo *CachedExamineRepository) SaveResult(ctx context.Context, uid, cid in
 		Tid:       result.Tid,
 		Result:    result.Result.ToUint8(),
 		RawResult: result.RawResult,
-		Tokens:    result.Tokens,
-		Amount:    result.Amount,
+		HitPoints: sqlx.JsonColumn[[]string]{Val: result.HitPoints,
+			Valid: len(result.HitPoints) != 0},
+		MissedPoints: sqlx.JsonColumn[[]string]{Val: result.MissedPoints,
+			Valid: len(result.MissedPoints) != 0},
+		HitHighlights: sqlx.JsonColumn[[]string]{Val: result.HitHighlights,
+			Valid: len(result.HitHighlights) != 0},
+		Tokens: result.Tokens,
+		Amount: result.Amount,
 	})
 	return err
 }
 
+func (repo *CachedExamineRepository) UpdateCaseResult(ctx context.Context, uid, cid int64, result domain.CaseResult) error {
+	return repo.dao.UpdateCaseResult(ctx, dao.CaseResult{
+		Uid:    uid,
+		Cid:    cid,
+		Result: result.ToUint8(),
+	})
+}
+
+func (repo *CachedExamineRepository) MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]domain.ExamineCaseResult, error) {
+	res, err := repo.dao.MergeResults(ctx, sourceUid, targetUid)
+	return slice.Map(res, func(idx int, src dao.CaseResult) domain.ExamineCaseResult {
+		return domain.ExamineCaseResult{
+			Cid:    src.Cid,
+			Result: domain.CaseResult(src.Result),
+		}
+	}), err
+}
+
+func (repo *CachedExamineRepository) Records(ctx context.Context, uid int64) ([]domain.ExamineCaseResult, error) {
+	res, err := repo.dao.FindRecordsByUid(ctx, uid)
+	return slice.Map(res, func(idx int, src dao.CaseExamineRecord) domain.ExamineCaseResult {
+		return domain.ExamineCaseResult{
+			Cid:           src.Cid,
+			Result:        domain.CaseResult(src.Result),
+			RawResult:     src.RawResult,
+			HitPoints:     src.HitPoints.Val,
+			MissedPoints:  src.MissedPoints.Val,
+			HitHighlights: src.HitHighlights.Val,
+			Tokens:        src.Tokens,
+			Amount:        src.Amount,
+			Tid:           src.Tid,
+			Ctime:         src.Ctime,
+		}
+	}), err
+}
+
+func (repo *CachedExamineRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return repo.dao.DeleteByUid(ctx, uid)
+}
+
 func NewCachedExamineRepository(dao dao.ExamineDAO) ExamineRepository {
 	return &CachedExamineRepository{dao: dao}
 }
@@ -17,7 +17,7 @@ type CaseSetService interface {
 	Save(ctx context.Context, set domain.CaseSet) (int64, error)
 	UpdateCases(ctx context.Context, set domain.CaseSet) error
 	List(ctx context.Context, offset, limit int) ([]domain.CaseSet, int64, error)
-	Detail(ctx context.Context, id int64) (domain.CaseSet, error)
+	Detail(ctx context.Context, uid, id int64) (domain.CaseSet, error)
 	GetByIds(ctx context.Context, ids []int64) ([]domain.CaseSet, error)
 	// GetByIdsWithCases 会查询关联的 Case，但是目前只是发返回了 ID
 	GetByIdsWithCases(ctx context.Context, ids []int64) ([]domain.CaseSet, error)
@@ -26,6 +26,8 @@ type CaseSetService interface {
 	ListDefault(ctx context.Context, offset, limit int) (int64, []domain.CaseSet, error)
 	GetByBiz(ctx context.Context, biz string, bizId int64) (domain.CaseSet, error)
 	GetCandidates(ctx context.Context, id int64, offset int, limit int) ([]domain.Case, int64, error)
+	// GetIDsByCid 包含了该案例的案例集 id
+	GetIDsByCid(ctx context.Context, cid int64) ([]int64, error)
 }
 
 type caseSetSvc struct {
@@ -87,6 +89,10 @@ func (c *caseSetSvc) ListByBiz(ctx context.Context, offset, limit int, biz strin
 	return c.repo.ListByBiz(ctx, offset, limit, biz)
 }
 
+func (c *caseSetSvc) GetIDsByCid(ctx context.Context, cid int64) ([]int64, error) {
+	return c.repo.GetIDsByCid(ctx, cid)
+}
+
 func (c *caseSetSvc) GetByBiz(ctx context.Context, biz string, bizId int64) (domain.CaseSet, error) {
 	return c.repo.GetByBiz(ctx, biz, bizId)
 }
@@ -127,11 +133,11 @@ func (c *caseSetSvc) List(ctx context.Context, offset, limit int) ([]domain.Case
 	return sets, total, nil
 }
 
-func (c *caseSetSvc) Detail(ctx context.Context, id int64) (domain.CaseSet, error) {
+func (c *caseSetSvc) Detail(ctx context.Context, uid, id int64) (domain.CaseSet, error) {
 	res, err := c.repo.GetByID(ctx, id)
 	if err == nil {
 		// 同步异步的区别不大
-		err1 := c.producer.Produce(ctx, event.NewViewCntEvent(id, domain.BizCaseSet))
+		err1 := c.producer.Produce(ctx, event.NewViewCntEvent(id, domain.BizCaseSet, uid))
 		if err1 != nil {
 			c.logger.Error("更新观看计数失败",
 				elog.Int64("id", id), elog.FieldErr(err))
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/gotomicro/ego/core/elog"
 
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
@@ -22,9 +23,23 @@ type Service interface {
 	PubList(ctx context.Context, offset int, limit int) (int64, []domain.Case, error)
 	GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Case, error)
 	Detail(ctx context.Context, caseId int64) (domain.Case, error)
-	PubDetail(ctx context.Context, caseId int64) (domain.Case, error)
+	// PubDetail uid 是浏览者，没有登录的时候为 0
+	PubDetail(ctx context.Context, uid, caseId int64) (domain.Case, error)
+
+	// Unpublish 下线，只会删除线上库的数据，制作库的数据会保留
+	Unpublish(ctx context.Context, caseId int64) error
+	// Schedule 设置定时发布和定时下线的时间，毫秒数，0 代表取消
+	Schedule(ctx context.Context, caseId int64, publishAt int64, unpublishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Case, int64, error)
+	// DuePublish 和 DueUnpublish 给定时任务使用
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error)
+	// PublishScheduled 发布制作库中的数据，并且清空定时发布时间
+	PublishScheduled(ctx context.Context, ca domain.Case) error
 }
 
+var ErrInvalidSchedule = schedulex.ErrInvalidSchedule
+
 type service struct {
 	repo                  repository.CaseRepo
 	producer              event.SyncEventProducer
@@ -59,7 +74,74 @@ func (s *service) Publish(ctx context.Context, ca domain.Case) (int64, error) {
 			s.uploadCase(cctx, newCase)
 		}()
 	}
-	return id, nil
+	return id, err
+}
+
+func (s *service) Unpublish(ctx context.Context, caseId int64) error {
+	err := s.repo.Unpublish(ctx, caseId)
+	if err != nil {
+		return err
+	}
+	go func() {
+		cctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		// 线上库已经没有数据了，所以用制作库的数据同步，搜索那边只会返回已发布的数据
+		ca, cerr := s.repo.GetById(cctx, caseId)
+		if cerr == nil {
+			s.syncCase(cctx, ca)
+		}
+		evt := event.NewDeleteKnowledgeBaseEvent(caseId)
+		cerr = s.knowledgeBaseProducer.Produce(cctx, evt)
+		if cerr != nil {
+			s.logger.Error("发送从知识库删除案例的事件失败",
+				elog.FieldErr(cerr),
+				elog.Any("event", evt),
+			)
+		}
+	}()
+	return nil
+}
+
+func (s *service) Schedule(ctx context.Context, caseId int64, publishAt int64, unpublishAt int64) error {
+	if err := schedulex.Check(publishAt, unpublishAt); err != nil {
+		return err
+	}
+	return s.repo.UpdateSchedule(ctx, caseId, publishAt, unpublishAt)
+}
+
+func (s *service) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Case, int64, error) {
+	var (
+		eg    errgroup.Group
+		cas   []domain.Case
+		total int64
+	)
+	eg.Go(func() error {
+		var err error
+		cas, err = s.repo.ScheduledList(ctx, offset, limit)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		total, err = s.repo.ScheduledCount(ctx)
+		return err
+	})
+	return cas, total, eg.Wait()
+}
+
+func (s *service) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error) {
+	return s.repo.DuePublish(ctx, now, minId, limit)
+}
+
+func (s *service) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Case, error) {
+	return s.repo.DueUnpublish(ctx, now, minId, limit)
+}
+
+func (s *service) PublishScheduled(ctx context.Context, ca domain.Case) error {
+	_, err := s.Publish(ctx, ca)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearPublishAt(ctx, ca.Id, ca.PublishAt)
 }
 
 func (s *service) List(ctx context.Context, offset int, limit int) ([]domain.Case, int64, error) {
@@ -108,13 +190,13 @@ func (s *service) Detail(ctx context.Context, caseId int64) (domain.Case, error)
 	return s.repo.GetById(ctx, caseId)
 }
 
-func (s *service) PubDetail(ctx context.Context, caseId int64) (domain.Case, error) {
+func (s *service) PubDetail(ctx context.Context, uid, caseId int64) (domain.Case, error) {
 	res, err := s.repo.GetPubByID(ctx, caseId)
 	if err == nil {
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := s.intrProducer.Produce(newCtx, event.NewViewCntEvent(caseId, domain.BizCase))
+			err1 := s.intrProducer.Produce(newCtx, event.NewViewCntEvent(caseId, domain.BizCase, uid))
 			if err1 != nil {
 				if err1 != nil {
 					s.logger.Error("发送问题阅读计数消息到消息队列失败",
@@ -16,16 +16,26 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/cases/internal/event"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
 	"github.com/lithammer/shortuuid/v4"
 )
 
-var ErrInsufficientCredit = ai.ErrInsufficientCredit
+var (
+	ErrInsufficientCredit = ai.ErrInsufficientCredit
+	ErrInvalidResult      = errors.New("测试结果不合法")
+	// ErrResultNotFound 没有测试过的案例不能修正
+	ErrResultNotFound = repository.ErrResultNotFound
+)
 
 // ExamineService 测试服务
 //
@@ -36,8 +46,26 @@ type ExamineService interface {
 	Examine(ctx context.Context, uid, cid int64, input string) (domain.ExamineCaseResult, error)
 	GetResult(ctx context.Context, uid, cid int64) (domain.CaseResult, error)
 	GetResults(ctx context.Context, uid int64, ids []int64) (map[int64]domain.ExamineCaseResult, error)
+	// Correct 用户觉得 AI 的评价不准确，可以直接修正，只能修正测试过的案例
+	Correct(ctx context.Context, uid, cid int64, result domain.CaseResult) error
+	// MergeResults 合并账号的时候，把 sourceUid 的测试结果转移到 targetUid 上，两边都测试过的保留更好的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) error
+	// Records 用户所有的测试记录，导出个人数据的时候使用
+	Records(ctx context.Context, uid int64) ([]domain.ExamineCaseResult, error)
+	// DeleteResults 注销账号的时候删除测试记录和结果
+	DeleteResults(ctx context.Context, uid int64) error
 }
 
+const (
+	// bizCaseExamine 没有评分标准的案例，AI 只判断通过或者不通过
+	bizCaseExamine = "case_examine"
+	// bizCaseRubricExamine 按照评分标准评价，AI 需要给出命中的要点和亮点
+	bizCaseRubricExamine = "case_rubric_examine"
+
+	hitPointsPrefix     = "命中要点"
+	hitHighlightsPrefix = "命中亮点"
+)
+
 var _ ExamineService = &LLMExamineService{}
 
 // LLMExamineService 使用 LLM 进行评价的测试服务
@@ -45,6 +73,8 @@ type LLMExamineService struct {
 	caseRepo repository.CaseRepo
 	repo     repository.ExamineRepository
 	aiSvc    ai.LLMService
+	producer event.ExamineEventProducer
+	logger   *elog.Component
 }
 
 func (svc *LLMExamineService) GetResults(ctx context.Context, uid int64, ids []int64) (map[int64]domain.ExamineCaseResult, error) {
@@ -61,53 +91,183 @@ func (svc *LLMExamineService) GetResult(ctx context.Context, uid, qid int64) (do
 func (svc *LLMExamineService) Examine(ctx context.Context,
 	uid int64,
 	cid int64, input string) (domain.ExamineCaseResult, error) {
-	const biz = "case_examine"
-	// 实际上我们只需要 title，但是懒得写一个新的接口了
 	ca, err := svc.caseRepo.GetPubByID(ctx, cid)
 	if err != nil {
 		return domain.ExamineCaseResult{}, err
 	}
+	rubric := domain.NewRubric(ca)
 	tid := shortuuid.New()
 	aiReq := ai.LLMRequest{
 		Uid:   uid,
 		Tid:   tid,
-		Biz:   biz,
+		Biz:   bizCaseExamine,
 		Input: []string{ca.Title, ca.Content, input},
 	}
+	if !rubric.IsEmpty() {
+		aiReq.Biz = bizCaseRubricExamine
+		aiReq.Input = []string{ca.Title, ca.Content,
+			svc.numbered(rubric.KeyPoints),
+			svc.numbered(rubric.Highlights),
+			rubric.Guidance, input}
+	}
 	aiResp, err := svc.aiSvc.Invoke(ctx, aiReq)
+	if errors.Is(err, ai.ErrBizConfigNotFound) && aiReq.Biz == bizCaseRubricExamine {
+		// 还没有配置按照评分标准评价的 prompt，退化成只判断通过或者不通过
+		svc.logger.Warn("没有配置按照评分标准评价的 prompt",
+			elog.String("biz", bizCaseRubricExamine),
+			elog.Int64("cid", cid))
+		rubric = domain.Rubric{}
+		aiReq.Biz = bizCaseExamine
+		aiReq.Input = []string{ca.Title, ca.Content, input}
+		aiResp, err = svc.aiSvc.Invoke(ctx, aiReq)
+	}
 	if err != nil {
 		return domain.ExamineCaseResult{}, err
 	}
 	// 解析结果
-	parsedRes := svc.parseExamineResult(aiResp.Answer)
-	result := domain.ExamineCaseResult{
-		Result:    parsedRes,
-		RawResult: aiResp.Answer,
-		Tokens:    aiResp.Tokens,
-		Amount:    aiResp.Amount,
-		Tid:       tid,
-	}
+	result := svc.parseExamineResult(rubric, aiResp.Answer)
+	result.Cid = cid
+	result.RawResult = aiResp.Answer
+	result.Tokens = aiResp.Tokens
+	result.Amount = aiResp.Amount
+	result.Tid = tid
 	// 开始记录结果
 	err = svc.repo.SaveResult(ctx, uid, cid, result)
-	return result, err
+	if err != nil {
+		return result, err
+	}
+	svc.sendExamineEvent(ctx, uid, cid, result.Result)
+	return result, nil
+}
+
+func (svc *LLMExamineService) Correct(ctx context.Context, uid, cid int64, result domain.CaseResult) error {
+	if !result.Valid() {
+		return fmt.Errorf("%w %d", ErrInvalidResult, result)
+	}
+	err := svc.repo.UpdateCaseResult(ctx, uid, cid, result)
+	if err != nil {
+		return err
+	}
+	// 确实修改了才发送事件
+	svc.sendExamineEvent(ctx, uid, cid, result)
+	return nil
+}
+
+func (svc *LLMExamineService) MergeResults(ctx context.Context, sourceUid, targetUid int64) error {
+	results, err := svc.repo.MergeResults(ctx, sourceUid, targetUid)
+	if err != nil {
+		return err
+	}
+	// 学习进度是根据测试结果事件计算的，所以变化了的结果要重新发一遍
+	for _, res := range results {
+		svc.sendExamineEvent(ctx, targetUid, res.Cid, res.Result)
+	}
+	return nil
 }
 
-func (svc *LLMExamineService) parseExamineResult(answer string) domain.CaseResult {
+func (svc *LLMExamineService) Records(ctx context.Context, uid int64) ([]domain.ExamineCaseResult, error) {
+	return svc.repo.Records(ctx, uid)
+}
+
+func (svc *LLMExamineService) DeleteResults(ctx context.Context, uid int64) error {
+	return svc.repo.DeleteByUid(ctx, uid)
+}
+
+func (svc *LLMExamineService) sendExamineEvent(ctx context.Context, uid, cid int64, result domain.CaseResult) {
+	// 结果已经保存了，发送失败只记录日志
+	err := svc.producer.Produce(ctx, event.ExamineEvent{
+		Uid:    uid,
+		Biz:    domain.BizCase,
+		BizId:  cid,
+		Result: result.ToUint8(),
+	})
+	if err != nil {
+		svc.logger.Error("发送测试结果事件失败",
+			elog.FieldErr(err),
+			elog.Int64("uid", uid),
+			elog.Int64("cid", cid))
+	}
+}
+
+// numbered 给要点编号，AI 回答的时候用编号来表示命中了哪些要点
+func (svc *LLMExamineService) numbered(points []string) string {
+	var sb strings.Builder
+	for i, p := range points {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, p))
+	}
+	return sb.String()
+}
+
+// parseExamineResult 解析 AI 的回答
+// 没有评分标准的时候，AI 回答以“通过”开头就是通过；
+// 有评分标准的时候，AI 会在回答里面给出形如“命中要点：1,3”和“命中亮点：2”的行
+func (svc *LLMExamineService) parseExamineResult(rubric domain.Rubric, answer string) domain.ExamineCaseResult {
 	answer = strings.TrimSpace(answer)
-	if strings.HasPrefix(answer, "通过") {
-		return domain.ResultPassed
+	if rubric.IsEmpty() {
+		if strings.HasPrefix(answer, "通过") {
+			return domain.ExamineCaseResult{Result: domain.ResultPassed}
+		}
+		return domain.ExamineCaseResult{Result: domain.ResultFailed}
+	}
+	var hitPoints, hitHighlights map[int]struct{}
+	for _, line := range strings.Split(answer, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, hitPointsPrefix):
+			hitPoints = svc.parseIndexes(strings.TrimPrefix(line, hitPointsPrefix), len(rubric.KeyPoints))
+		case strings.HasPrefix(line, hitHighlightsPrefix):
+			hitHighlights = svc.parseIndexes(strings.TrimPrefix(line, hitHighlightsPrefix), len(rubric.Highlights))
+		}
+	}
+	res := domain.ExamineCaseResult{
+		HitPoints:     make([]string, 0, len(hitPoints)),
+		MissedPoints:  make([]string, 0, len(rubric.KeyPoints)-len(hitPoints)),
+		HitHighlights: make([]string, 0, len(hitHighlights)),
+	}
+	for i, p := range rubric.KeyPoints {
+		if _, ok := hitPoints[i]; ok {
+			res.HitPoints = append(res.HitPoints, p)
+		} else {
+			res.MissedPoints = append(res.MissedPoints, p)
+		}
+	}
+	for i, h := range rubric.Highlights {
+		if _, ok := hitHighlights[i]; ok {
+			res.HitHighlights = append(res.HitHighlights, h)
+		}
+	}
+	res.Result = rubric.Level(len(res.HitPoints), len(res.HitHighlights))
+	return res
+}
+
+// parseIndexes 解析“：1,3”这种编号列表，返回从 0 开始的下标，越界的编号直接忽略
+func (svc *LLMExamineService) parseIndexes(s string, total int) map[int]struct{} {
+	s = strings.TrimLeft(strings.TrimSpace(s), ":：")
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '，' || r == '、' || r == ' '
+	})
+	res := make(map[int]struct{}, len(fields))
+	for _, f := range fields {
+		idx, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || idx < 1 || idx > total {
+			continue
+		}
+		res[idx-1] = struct{}{}
 	}
-	return domain.ResultFailed
+	return res
 }
 
 func NewLLMExamineService(
 	caseRepo repository.CaseRepo,
 	repo repository.ExamineRepository,
 	aiSvc ai.LLMService,
+	producer event.ExamineEventProducer,
 ) ExamineService {
 	return &LLMExamineService{
 		caseRepo: caseRepo,
 		repo:     repo,
 		aiSvc:    aiSvc,
+		producer: producer,
+		logger:   elog.DefaultLogger,
 	}
 }
@@ -0,0 +1,119 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMExamineService_parseExamineResult(t *testing.T) {
+	rubric := domain.NewRubric(domain.Case{
+		Keywords:  "幂等\n重试；降级",
+		Highlight: "对账补偿",
+	})
+	testCases := []struct {
+		name      string
+		rubric    domain.Rubric
+		llmResult string
+		wantRes   domain.ExamineCaseResult
+	}{
+		{
+			name:      "没有评分标准-通过",
+			llmResult: "通过，回答得不错",
+			wantRes:   domain.ExamineCaseResult{Result: domain.ResultPassed},
+		},
+		{
+			name:      "没有评分标准-不通过",
+			llmResult: "不通过",
+			wantRes:   domain.ExamineCaseResult{Result: domain.ResultFailed},
+		},
+		{
+			name:   "没有命中",
+			rubric: rubric,
+			llmResult: `
+分析：候选人没有提到任何要点
+命中要点：
+命中亮点：`,
+			wantRes: domain.ExamineCaseResult{
+				Result:        domain.ResultFailed,
+				HitPoints:     []string{},
+				MissedPoints:  []string{"幂等", "重试", "降级"},
+				HitHighlights: []string{},
+			},
+		},
+		{
+			name:   "基础",
+			rubric: rubric,
+			llmResult: `
+命中要点：1，3
+命中亮点：`,
+			wantRes: domain.ExamineCaseResult{
+				Result:        domain.ResultBasic,
+				HitPoints:     []string{"幂等", "降级"},
+				MissedPoints:  []string{"重试"},
+				HitHighlights: []string{},
+			},
+		},
+		{
+			name:   "中级",
+			rubric: rubric,
+			llmResult: `
+命中要点：1,2,3
+命中亮点：`,
+			wantRes: domain.ExamineCaseResult{
+				Result:        domain.ResultIntermediate,
+				HitPoints:     []string{"幂等", "重试", "降级"},
+				MissedPoints:  []string{},
+				HitHighlights: []string{},
+			},
+		},
+		{
+			name:   "高级，忽略越界编号",
+			rubric: rubric,
+			llmResult: `
+命中要点: 1、2、3、5
+命中亮点: 1`,
+			wantRes: domain.ExamineCaseResult{
+				Result:        domain.ResultAdvanced,
+				HitPoints:     []string{"幂等", "重试", "降级"},
+				MissedPoints:  []string{},
+				HitHighlights: []string{"对账补偿"},
+			},
+		},
+		{
+			name:   "有亮点但是要点不全",
+			rubric: rubric,
+			llmResult: `
+命中要点：2
+命中亮点：1`,
+			wantRes: domain.ExamineCaseResult{
+				Result:        domain.ResultFailed,
+				HitPoints:     []string{"重试"},
+				MissedPoints:  []string{"幂等", "降级"},
+				HitHighlights: []string{"对账补偿"},
+			},
+		},
+	}
+	svc := &LLMExamineService{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := svc.parseExamineResult(tc.rubric, tc.llmResult)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+)
+
+// searchSource 搜索重建索引的时候读取全量的案例
+// 和同步消息一样使用制作库的数据，搜索那边会按照状态过滤
+type searchSource struct {
+	repo repository.CaseRepo
+}
+
+func NewSearchSource(repo repository.CaseRepo) searchx.Source {
+	return &searchSource{repo: repo}
+}
+
+func (s *searchSource) Biz() string {
+	return domain.BizCase
+}
+
+func (s *searchSource) Ids(ctx context.Context) ([]int64, error) {
+	return s.repo.Ids(ctx)
+}
+
+func (s *searchSource) Doc(ctx context.Context, id int64) (string, error) {
+	ca, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewCaseEvent(ca).Data, nil
+}
@@ -15,6 +15,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
@@ -38,6 +40,44 @@ func (h *AdminCaseHandler) PrivateRoutes(server *gin.Engine) {
 	server.POST("/cases/list", ginx.B[Page](h.List))
 	server.POST("/cases/detail", ginx.B[CaseId](h.Detail))
 	server.POST("/cases/publish", ginx.BS[SaveReq](h.Publish))
+	server.POST("/cases/unpublish", ginx.B[CaseId](h.Unpublish))
+	server.POST("/cases/schedule", ginx.B[ScheduleReq](h.Schedule))
+	server.POST("/cases/scheduled/list", ginx.B[Page](h.ScheduledList))
+}
+
+func (h *AdminCaseHandler) Unpublish(ctx *ginx.Context, req CaseId) (ginx.Result, error) {
+	err := h.svc.Unpublish(ctx, req.Cid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *AdminCaseHandler) Schedule(ctx *ginx.Context, req ScheduleReq) (ginx.Result, error) {
+	err := h.svc.Schedule(ctx, req.Cid, req.PublishAt, req.UnpublishAt)
+	switch {
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return invalidScheduleResult, err
+	case err != nil:
+		return systemErrorResult, err
+	default:
+		return ginx.Result{}, nil
+	}
+}
+
+func (h *AdminCaseHandler) ScheduledList(ctx *ginx.Context, req Page) (ginx.Result, error) {
+	data, cnt, err := h.svc.ScheduledList(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: CasesList{
+			Total: cnt,
+			Cases: slice.Map(data, func(idx int, ca domain.Case) Case {
+				return newCase(ca)
+			}),
+		},
+	}, nil
 }
 
 func (h *AdminCaseHandler) Save(ctx *ginx.Context,
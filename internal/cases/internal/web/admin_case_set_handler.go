@@ -22,7 +22,7 @@ func (a *AdminCaseSetHandler) PrivateRoutes(server *gin.Engine) {
 	g.POST("/save", ginx.BS[CaseSet](a.SaveCaseSet))
 	g.POST("/cases/save", ginx.B[UpdateCases](a.UpdateCases))
 	g.POST("/list", ginx.B[Page](a.ListCaseSets))
-	g.POST("/detail", ginx.B[CaseSetID](a.RetrieveCaseSetDetail))
+	g.POST("/detail", ginx.BS[CaseSetID](a.RetrieveCaseSetDetail))
 	g.POST("/candidate", ginx.B[CandidateReq](a.Candidate))
 
 }
@@ -90,8 +90,8 @@ func (a *AdminCaseSetHandler) ListCaseSets(ctx *ginx.Context, req Page) (ginx.Re
 	}, nil
 }
 
-func (a *AdminCaseSetHandler) RetrieveCaseSetDetail(ctx *ginx.Context, req CaseSetID) (ginx.Result, error) {
-	detail, err := a.svc.Detail(ctx, req.ID)
+func (a *AdminCaseSetHandler) RetrieveCaseSetDetail(ctx *ginx.Context, req CaseSetID, sess session.Session) (ginx.Result, error) {
+	detail, err := a.svc.Detail(ctx, sess.Claims().Uid, req.ID)
 	if err != nil {
 		return systemErrorResult, err
 	}
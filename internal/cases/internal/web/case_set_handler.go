@@ -95,7 +95,7 @@ func (h *CaseSetHandler) RetrieveCaseSetDetail(
 	ctx *ginx.Context,
 	req CaseSetID, sess session.Session) (ginx.Result, error) {
 
-	data, err := h.svc.Detail(ctx.Request.Context(), req.ID)
+	data, err := h.svc.Detail(ctx.Request.Context(), sess.Claims().Uid, req.ID)
 	if err != nil {
 		return systemErrorResult, err
 	}
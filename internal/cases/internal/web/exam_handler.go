@@ -5,6 +5,7 @@ import (
 
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/cases/internal/domain"
 	"github.com/ecodeclub/webook/internal/cases/internal/errs"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/gin-gonic/gin"
@@ -23,6 +24,8 @@ func NewExamineHandler(svc service.ExamineService) *ExamineHandler {
 func (h *ExamineHandler) MemberRoutes(server *gin.Engine) {
 	g := server.Group("/cases/examine")
 	g.POST("", ginx.BS(h.Examine))
+	// 觉得 AI 的评价不准确，那么可以调用这个接口来修正，这是直接暴露给用户使用的
+	g.POST("/correct", ginx.BS[CorrectReq](h.Correct))
 }
 
 func (h *ExamineHandler) Examine(ctx *ginx.Context, req ExamineReq, sess session.Session) (ginx.Result, error) {
@@ -42,3 +45,28 @@ func (h *ExamineHandler) Examine(ctx *ginx.Context, req ExamineReq, sess session
 		return systemErrorResult, err
 	}
 }
+
+// Correct 修改案例的测试结果
+func (h *ExamineHandler) Correct(ctx *ginx.Context, req CorrectReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.Correct(ctx, sess.Claims().Uid, req.Cid, domain.CaseResult(req.Result))
+	switch {
+	case errors.Is(err, service.ErrInvalidResult):
+		return ginx.Result{
+			Code: errs.InvalidResult.Code,
+			Msg:  errs.InvalidResult.Msg,
+		}, nil
+	case errors.Is(err, service.ErrResultNotFound):
+		return ginx.Result{
+			Code: errs.ResultNotFound.Code,
+			Msg:  errs.ResultNotFound.Msg,
+		}, nil
+	case err != nil:
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: newExamineResult(domain.ExamineCaseResult{
+			Cid:    req.Cid,
+			Result: domain.CaseResult(req.Result),
+		}),
+	}, nil
+}
@@ -119,7 +119,7 @@ func (h *Handler) PubDetail(ctx *ginx.Context, req CaseId) (ginx.Result, error)
 	)
 
 	var err error
-	detail, err = h.svc.PubDetail(ctx, req.Cid)
+	detail, err = h.svc.PubDetail(ctx, h.getUid(ctx), req.Cid)
 	if err != nil {
 		return systemErrorResult, err
 	}
@@ -169,6 +169,8 @@ func newCase(ca domain.Case) Case {
 		BizId:        ca.BizId,
 		Status:       ca.Status.ToUint8(),
 		Utime:        ca.Utime.UnixMilli(),
+		PublishAt:    ca.PublishAt,
+		UnpublishAt:  ca.UnpublishAt,
 	}
 }
 
@@ -24,4 +24,8 @@ var (
 		Code: errs.SystemError.Code,
 		Msg:  errs.SystemError.Msg,
 	}
+	invalidScheduleResult = ginx.Result{
+		Code: errs.InvalidSchedule.Code,
+		Msg:  errs.InvalidSchedule.Msg,
+	}
 )
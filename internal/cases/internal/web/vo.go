@@ -44,11 +44,22 @@ type Case struct {
 	ExamineResult uint8 `json:"examineResult"`
 
 	Permitted bool `json:"permitted,omitempty"`
+
+	// 定时发布和定时下线的时间，只有管理后台会用到
+	PublishAt   int64 `json:"publishAt,omitempty"`
+	UnpublishAt int64 `json:"unpublishAt,omitempty"`
 }
 
 type CaseId struct {
 	Cid int64 `json:"cid"`
 }
+
+// ScheduleReq 定时发布和定时下线，毫秒数，0 代表取消
+type ScheduleReq struct {
+	Cid         int64 `json:"cid"`
+	PublishAt   int64 `json:"publishAt"`
+	UnpublishAt int64 `json:"unpublishAt"`
+}
 type SaveReq struct {
 	Case Case `json:"case,omitempty"`
 }
@@ -73,6 +84,7 @@ func (c Case) toDomain() domain.Case {
 
 type Interactive struct {
 	CollectCnt int  `json:"collectCnt"`
+	CommentCnt int  `json:"commentCnt"`
 	LikeCnt    int  `json:"likeCnt"`
 	ViewCnt    int  `json:"viewCnt"`
 	Liked      bool `json:"liked"`
@@ -82,6 +94,7 @@ type Interactive struct {
 func newInteractive(intr interactive.Interactive) Interactive {
 	return Interactive{
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		ViewCnt:    intr.ViewCnt,
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
@@ -140,6 +153,10 @@ type ExamineResult struct {
 	Result uint8 `json:"result"`
 	// 原始回答，源自 AI
 	RawResult string `json:"rawResult"`
+	// 命中的要点、遗漏的要点和命中的亮点
+	HitPoints     []string `json:"hitPoints,omitempty"`
+	MissedPoints  []string `json:"missedPoints,omitempty"`
+	HitHighlights []string `json:"hitHighlights,omitempty"`
 
 	// 使用的 token 数量
 	Tokens int64 `json:"tokens"`
@@ -152,12 +169,20 @@ type ExamineReq struct {
 	Input string `json:"input"`
 }
 
+type CorrectReq struct {
+	Cid    int64 `json:"cid"`
+	Result uint8 `json:"result"`
+}
+
 func newExamineResult(r domain.ExamineCaseResult) ExamineResult {
 	return ExamineResult{
-		Cid:       r.Cid,
-		Result:    r.Result.ToUint8(),
-		RawResult: r.RawResult,
-		Amount:    r.Amount,
+		Cid:           r.Cid,
+		Result:        r.Result.ToUint8(),
+		RawResult:     r.RawResult,
+		HitPoints:     r.HitPoints,
+		MissedPoints:  r.MissedPoints,
+		HitHighlights: r.HitHighlights,
+		Amount:        r.Amount,
 	}
 }
 
@@ -41,18 +41,18 @@ func (m *MockCaseSetService) EXPECT() *MockCaseSetServiceMockRecorder {
 }
 
 // Detail mocks base method.
-func (m *MockCaseSetService) Detail(ctx context.Context, id int64) (domain.CaseSet, error) {
+func (m *MockCaseSetService) Detail(ctx context.Context, uid, id int64) (domain.CaseSet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Detail", ctx, id)
+	ret := m.ctrl.Call(m, "Detail", ctx, uid, id)
 	ret0, _ := ret[0].(domain.CaseSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Detail indicates an expected call of Detail.
-func (mr *MockCaseSetServiceMockRecorder) Detail(ctx, id any) *MockCaseSetServiceDetailCall {
+func (mr *MockCaseSetServiceMockRecorder) Detail(ctx, uid, id any) *MockCaseSetServiceDetailCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockCaseSetService)(nil).Detail), ctx, id)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockCaseSetService)(nil).Detail), ctx, uid, id)
 	return &MockCaseSetServiceDetailCall{Call: call}
 }
 
@@ -68,13 +68,13 @@ func (c *MockCaseSetServiceDetailCall) Return(arg0 domain.CaseSet, arg1 error) *
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockCaseSetServiceDetailCall) Do(f func(context.Context, int64) (domain.CaseSet, error)) *MockCaseSetServiceDetailCall {
+func (c *MockCaseSetServiceDetailCall) Do(f func(context.Context, int64, int64) (domain.CaseSet, error)) *MockCaseSetServiceDetailCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockCaseSetServiceDetailCall) DoAndReturn(f func(context.Context, int64) (domain.CaseSet, error)) *MockCaseSetServiceDetailCall {
+func (c *MockCaseSetServiceDetailCall) DoAndReturn(f func(context.Context, int64, int64) (domain.CaseSet, error)) *MockCaseSetServiceDetailCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -236,6 +236,45 @@ func (c *MockCaseSetServiceGetCandidatesCall) DoAndReturn(f func(context.Context
 	return c
 }
 
+// GetIDsByCid mocks base method.
+func (m *MockCaseSetService) GetIDsByCid(ctx context.Context, cid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByCid", ctx, cid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByCid indicates an expected call of GetIDsByCid.
+func (mr *MockCaseSetServiceMockRecorder) GetIDsByCid(ctx, cid any) *MockCaseSetServiceGetIDsByCidCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByCid", reflect.TypeOf((*MockCaseSetService)(nil).GetIDsByCid), ctx, cid)
+	return &MockCaseSetServiceGetIDsByCidCall{Call: call}
+}
+
+// MockCaseSetServiceGetIDsByCidCall wrap *gomock.Call
+type MockCaseSetServiceGetIDsByCidCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCaseSetServiceGetIDsByCidCall) Return(arg0 []int64, arg1 error) *MockCaseSetServiceGetIDsByCidCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCaseSetServiceGetIDsByCidCall) Do(f func(context.Context, int64) ([]int64, error)) *MockCaseSetServiceGetIDsByCidCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCaseSetServiceGetIDsByCidCall) DoAndReturn(f func(context.Context, int64) ([]int64, error)) *MockCaseSetServiceGetIDsByCidCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // List mocks base method.
 func (m *MockCaseSetService) List(ctx context.Context, offset, limit int) ([]domain.CaseSet, int64, error) {
 	m.ctrl.T.Helper()
@@ -3,9 +3,10 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=./examine.go -destination=../../mocks/examine.mock.go -package=quemocks -typed=true ExamineService
+//	mockgen -source=./examine.go -destination=../../mocks/examine.mock.go -package=casemocks -typed=true ExamineService
 //
-// Package quemocks is a generated GoMock package.
+
+// Package casemocks is a generated GoMock package.
 package casemocks
 
 import (
@@ -39,6 +40,82 @@ func (m *MockExamineService) EXPECT() *MockExamineServiceMockRecorder {
 	return m.recorder
 }
 
+// Correct mocks base method.
+func (m *MockExamineService) Correct(ctx context.Context, uid, cid int64, result domain.CaseResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", ctx, uid, cid, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockExamineServiceMockRecorder) Correct(ctx, uid, cid, result any) *ExamineServiceCorrectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockExamineService)(nil).Correct), ctx, uid, cid, result)
+	return &ExamineServiceCorrectCall{Call: call}
+}
+
+// ExamineServiceCorrectCall wrap *gomock.Call
+type ExamineServiceCorrectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ExamineServiceCorrectCall) Return(arg0 error) *ExamineServiceCorrectCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ExamineServiceCorrectCall) Do(f func(context.Context, int64, int64, domain.CaseResult) error) *ExamineServiceCorrectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ExamineServiceCorrectCall) DoAndReturn(f func(context.Context, int64, int64, domain.CaseResult) error) *ExamineServiceCorrectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteResults mocks base method.
+func (m *MockExamineService) DeleteResults(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResults", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResults indicates an expected call of DeleteResults.
+func (mr *MockExamineServiceMockRecorder) DeleteResults(ctx, uid any) *ExamineServiceDeleteResultsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResults", reflect.TypeOf((*MockExamineService)(nil).DeleteResults), ctx, uid)
+	return &ExamineServiceDeleteResultsCall{Call: call}
+}
+
+// ExamineServiceDeleteResultsCall wrap *gomock.Call
+type ExamineServiceDeleteResultsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ExamineServiceDeleteResultsCall) Return(arg0 error) *ExamineServiceDeleteResultsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ExamineServiceDeleteResultsCall) Do(f func(context.Context, int64) error) *ExamineServiceDeleteResultsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ExamineServiceDeleteResultsCall) DoAndReturn(f func(context.Context, int64) error) *ExamineServiceDeleteResultsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Examine mocks base method.
 func (m *MockExamineService) Examine(ctx context.Context, uid, cid int64, input string) (domain.ExamineCaseResult, error) {
 	m.ctrl.T.Helper()
@@ -155,3 +232,80 @@ func (c *ExamineServiceGetResultsCall) DoAndReturn(f func(context.Context, int64
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MergeResults mocks base method.
+func (m *MockExamineService) MergeResults(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeResults", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeResults indicates an expected call of MergeResults.
+func (mr *MockExamineServiceMockRecorder) MergeResults(ctx, sourceUid, targetUid any) *ExamineServiceMergeResultsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeResults", reflect.TypeOf((*MockExamineService)(nil).MergeResults), ctx, sourceUid, targetUid)
+	return &ExamineServiceMergeResultsCall{Call: call}
+}
+
+// ExamineServiceMergeResultsCall wrap *gomock.Call
+type ExamineServiceMergeResultsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ExamineServiceMergeResultsCall) Return(arg0 error) *ExamineServiceMergeResultsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ExamineServiceMergeResultsCall) Do(f func(context.Context, int64, int64) error) *ExamineServiceMergeResultsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ExamineServiceMergeResultsCall) DoAndReturn(f func(context.Context, int64, int64) error) *ExamineServiceMergeResultsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Records mocks base method.
+func (m *MockExamineService) Records(ctx context.Context, uid int64) ([]domain.ExamineCaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Records", ctx, uid)
+	ret0, _ := ret[0].([]domain.ExamineCaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Records indicates an expected call of Records.
+func (mr *MockExamineServiceMockRecorder) Records(ctx, uid any) *ExamineServiceRecordsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Records", reflect.TypeOf((*MockExamineService)(nil).Records), ctx, uid)
+	return &ExamineServiceRecordsCall{Call: call}
+}
+
+// ExamineServiceRecordsCall wrap *gomock.Call
+type ExamineServiceRecordsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ExamineServiceRecordsCall) Return(arg0 []domain.ExamineCaseResult, arg1 error) *ExamineServiceRecordsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ExamineServiceRecordsCall) Do(f func(context.Context, int64) ([]domain.ExamineCaseResult, error)) *ExamineServiceRecordsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ExamineServiceRecordsCall) DoAndReturn(f func(context.Context, int64) ([]domain.ExamineCaseResult, error)) *ExamineServiceRecordsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
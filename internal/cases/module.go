@@ -16,8 +16,12 @@ package cases
 
 import (
 	"github.com/ecodeclub/webook/internal/cases/internal/domain"
+	"github.com/ecodeclub/webook/internal/cases/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/cases/internal/job"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/ecodeclub/webook/internal/cases/internal/web"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 )
 
 type Module struct {
@@ -30,6 +34,14 @@ type Module struct {
 	ExamineHdl           *ExamineHandler
 	CsHdl                *CaseSetHandler
 	KnowledgeBaseHandler *KnowledgeBaseHandler
+	ScheduledPublishJob  *ScheduledPublishJob
+	// SearchSource 搜索重建索引的时候使用
+	SearchSource searchx.Source
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	mc *consumer.UserMergeConsumer
+	dc *consumer.UserDeletionConsumer
 }
 
 type Handler = web.Handler
@@ -45,3 +57,4 @@ type AdminCaseSetHandler = web.AdminCaseSetHandler
 type AdminCaseHandler = web.AdminCaseHandler
 type ExamineHandler = web.ExamineHandler
 type CaseSetHandler = web.CaseSetHandler
+type ScheduledPublishJob = job.ScheduledPublishJob
@@ -3,6 +3,7 @@
 package cases
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/ginx/session"
@@ -15,11 +16,15 @@ import (
 
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/cases/internal/job"
 
 	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/ecodeclub/webook/internal/cases/internal/web"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -30,13 +35,16 @@ func InitModule(db *egorm.Component,
 	aiModule *ai.Module,
 	memberModule *member.Module,
 	sp session.Provider,
-	q mq.MQ) (*Module, error) {
+	q mq.MQ,
+	rc *cachex.ReadThroughCache) (*Module, error) {
 	wire.Build(InitCaseDAO,
 		dao.NewCaseSetDAO,
 		dao.NewGORMExamineDAO,
 		repository.NewCaseRepo,
 		repository.NewCaseSetRepo,
+		cache.NewPublishedCache,
 		repository.NewCachedExamineRepository,
+		event.NewExamineEventProducer,
 		event.NewSyncEventProducer,
 		event.NewInteractiveEventProducer,
 		service.NewCaseSetService,
@@ -44,6 +52,11 @@ func InitModule(db *egorm.Component,
 		service.NewLLMExamineService,
 		InitKnowledgeBaseEvt,
 		InitKnowledgeBaseSvc,
+		initScheduledPublishJob,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewSearchSource,
+		service.NewExamineExportSource,
 		web.NewHandler,
 		web.NewAdminCaseSetHandler,
 		web.NewExamineHandler,
@@ -69,7 +82,30 @@ func InitTableOnce(db *gorm.DB) {
 	})
 }
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func InitCaseDAO(db *egorm.Component) dao.CaseDAO {
 	InitTableOnce(db)
 	return dao.NewCaseDao(db)
 }
+
+func initUserMergeConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserMergeConsumer {
+	c, err := consumer.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
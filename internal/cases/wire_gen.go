@@ -7,27 +7,33 @@
 package cases
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/cases/internal/event"
+	"github.com/ecodeclub/webook/internal/cases/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/cases/internal/job"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository"
+	"github.com/ecodeclub/webook/internal/cases/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/cases/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/cases/internal/service"
 	"github.com/ecodeclub/webook/internal/cases/internal/web"
 	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
 	"github.com/ego-component/egorm"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, intrModule *interactive.Module, aiModule *ai.Module, memberModule *member.Module, sp session.Provider, q mq.MQ) (*Module, error) {
+func InitModule(db *gorm.DB, intrModule *interactive.Module, aiModule *ai.Module, memberModule *member.Module, sp session.Provider, q mq.MQ, rc *cachex.ReadThroughCache) (*Module, error) {
 	caseDAO := InitCaseDAO(db)
-	caseRepo := repository.NewCaseRepo(caseDAO)
+	publishedCache := cache.NewPublishedCache(rc)
+	caseRepo := repository.NewCaseRepo(caseDAO, publishedCache)
 	interactiveEventProducer, err := event.NewInteractiveEventProducer(q)
 	if err != nil {
 		return nil, err
@@ -39,12 +45,16 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, aiModule *ai.Module
 	}
 	serviceService := service.NewService(caseRepo, interactiveEventProducer, knowledgeBaseEventProducer, syncEventProducer)
 	caseSetDAO := dao.NewCaseSetDAO(db)
-	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO)
+	caseSetRepository := repository.NewCaseSetRepo(caseSetDAO, publishedCache)
 	caseSetService := service.NewCaseSetService(caseSetRepository, caseRepo, interactiveEventProducer)
 	examineDAO := dao.NewGORMExamineDAO(db)
 	examineRepository := repository.NewCachedExamineRepository(examineDAO)
 	llmService := aiModule.Svc
-	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService)
+	examineEventProducer, err := event.NewExamineEventProducer(q)
+	if err != nil {
+		return nil, err
+	}
+	examineService := service.NewLLMExamineService(caseRepo, examineRepository, llmService, examineEventProducer)
 	service2 := intrModule.Svc
 	service3 := memberModule.Svc
 	handler := web.NewHandler(serviceService, examineService, service2, service3, sp)
@@ -55,6 +65,11 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, aiModule *ai.Module
 	repositoryBaseSvc := aiModule.KnowledgeBaseSvc
 	knowledgeBaseService := InitKnowledgeBaseSvc(repositoryBaseSvc, caseRepo)
 	knowledgeBaseHandler := web.NewKnowledgeBaseHandler(knowledgeBaseService)
+	scheduledPublishJob := initScheduledPublishJob(serviceService)
+	source := service.NewSearchSource(caseRepo)
+	exportxSource := service.NewExamineExportSource(examineService)
+	userMergeConsumer := initUserMergeConsumer(examineService, q)
+	userDeletionConsumer := initUserDeletionConsumer(examineService, q)
 	module := &Module{
 		Svc:                  serviceService,
 		SetSvc:               caseSetService,
@@ -65,6 +80,11 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, aiModule *ai.Module
 		ExamineHdl:           examineHandler,
 		CsHdl:                caseSetHandler,
 		KnowledgeBaseHandler: knowledgeBaseHandler,
+		ScheduledPublishJob:  scheduledPublishJob,
+		SearchSource:         source,
+		ExportSource:         exportxSource,
+		mc:                   userMergeConsumer,
+		dc:                   userDeletionConsumer,
 	}
 	return module, nil
 }
@@ -82,7 +102,30 @@ func InitTableOnce(db *gorm.DB) {
 	})
 }
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func InitCaseDAO(db *egorm.Component) dao.CaseDAO {
 	InitTableOnce(db)
 	return dao.NewCaseDao(db)
 }
+
+func initUserMergeConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserMergeConsumer {
+	c, err := consumer.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Comment 评论，只支持两层结构
+// 顶级评论直接挂在 (Biz, BizID) 上，其余的回复都挂在顶级评论下面
+type Comment struct {
+	ID      int64
+	Biz     string
+	BizID   int64
+	Uid     int64
+	Content string
+	// RootID 所属的顶级评论，顶级评论自身为 0
+	RootID int64
+	// ParentID 直接回复的评论，顶级评论为 0
+	ParentID int64
+	// ReplyToUid 被回复的人，用于前端展示 "A 回复 B"
+	ReplyToUid int64
+	Status     CommentStatus
+	// Pinned 置顶，只有顶级评论可以置顶
+	Pinned   bool
+	LikeCnt  int
+	ReplyCnt int
+	// Liked 当前用户是否点赞过
+	Liked bool
+	Ctime int64
+	Utime int64
+}
+
+func (c Comment) IsRoot() bool {
+	return c.RootID == 0
+}
+
+type CommentStatus uint8
+
+func (s CommentStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	CommentStatusUnknown CommentStatus = 0
+	// CommentStatusNormal 正常展示
+	CommentStatusNormal CommentStatus = 1
+	// CommentStatusHidden 被管理员隐藏
+	CommentStatusHidden CommentStatus = 2
+)
@@ -0,0 +1,73 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+)
+
+type InteractiveEventProducer mqx.Producer[InteractiveEvent]
+
+func NewInteractiveEventProducer(p mq.MQ) (InteractiveEventProducer, error) {
+	return mqx.NewGeneralProducer[InteractiveEvent](p, intrTopic)
+}
+
+const intrTopic = "interactive_events"
+
+type InteractiveEvent struct {
+	Biz   string `json:"biz,omitempty"`
+	BizId int64  `json:"bizId,omitempty"`
+	// 取值是
+	// like, collect, view, comment 四个
+	Action string `json:"action,omitempty"`
+	Uid    int64  `json:"uid,omitempty"`
+	// Delta 评论数的变化量，可以是负数
+	Delta int `json:"delta,omitempty"`
+}
+
+func NewCommentCntEvent(biz string, bizId int64, delta int) InteractiveEvent {
+	return InteractiveEvent{
+		Biz:    biz,
+		BizId:  bizId,
+		Action: "comment",
+		Delta:  delta,
+	}
+}
+
+const UserMergeEventName = "user_merge_events"
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+const (
+	UserDeletionEventName     = "user_deletion_events"
+	UserDeletionDoneEventName = "user_deletion_done_events"
+)
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
@@ -0,0 +1,508 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ekit/net/httpx/httptestx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/comment"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/comment/internal/errs"
+	"github.com/ecodeclub/webook/internal/comment/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/comment/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/comment/internal/web"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/ego-component/egorm"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const uid = 2048
+
+type HandlerTestSuite struct {
+	suite.Suite
+	server      *egin.Component
+	adminServer *egin.Component
+	db          *egorm.Component
+	dao         dao.CommentDAO
+	module      *comment.Module
+}
+
+func (s *HandlerTestSuite) SetupSuite() {
+	module := startup.InitModule(session.DefaultProvider())
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	setSession := func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid:  uid,
+			Data: map[string]string{"creator": "true"},
+		}))
+	}
+	server := egin.Load("server").Build()
+	server.Use(setSession)
+	module.Hdl.PublicRoutes(server.Engine)
+	module.Hdl.PrivateRoutes(server.Engine)
+	s.server = server
+
+	adminServer := egin.Load("server").Build()
+	adminServer.Use(setSession)
+	module.AdminHdl.PrivateRoutes(adminServer.Engine)
+	s.adminServer = adminServer
+
+	s.db = testioc.InitDB()
+	s.dao = dao.NewCommentDAO(s.db)
+	s.module = module
+}
+
+func (s *HandlerTestSuite) TearDownTest() {
+	err := s.db.Exec("TRUNCATE TABLE `comments`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `comment_likes`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandlerTestSuite) TestCreate() {
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		after    func(t *testing.T)
+		req      web.CreateReq
+		wantCode int
+		wantResp test.Result[int64]
+	}{
+		{
+			name:   "发表顶级评论",
+			before: func(t *testing.T) {},
+			after: func(t *testing.T) {
+				c, err := s.dao.FindById(context.Background(), 1)
+				require.NoError(t, err)
+				s.assertComment(t, dao.Comment{
+					ID:      1,
+					Biz:     "question",
+					BizID:   1,
+					Uid:     uid,
+					Content: "评论",
+					Status:  domain.CommentStatusNormal.ToUint8(),
+				}, c)
+			},
+			req: web.CreateReq{
+				Biz:     "question",
+				BizId:   1,
+				Content: " 评论 ",
+			},
+			wantCode: 200,
+			wantResp: test.Result[int64]{Data: 1},
+		},
+		{
+			name: "回复一条回复",
+			before: func(t *testing.T) {
+				s.createComment(t, dao.Comment{ID: 1, Biz: "case", BizID: 2, Uid: 1, ReplyCnt: 1})
+				s.createComment(t, dao.Comment{ID: 2, Biz: "case", BizID: 2, Uid: 2, RootID: 1, ParentID: 1})
+			},
+			after: func(t *testing.T) {
+				c, err := s.dao.FindById(context.Background(), 3)
+				require.NoError(t, err)
+				s.assertComment(t, dao.Comment{
+					ID:         3,
+					Biz:        "case",
+					BizID:      2,
+					Uid:        uid,
+					Content:    "回复",
+					RootID:     1,
+					ParentID:   2,
+					ReplyToUid: 2,
+					Status:     domain.CommentStatusNormal.ToUint8(),
+				}, c)
+				root, err := s.dao.FindById(context.Background(), 1)
+				require.NoError(t, err)
+				assert.Equal(t, 2, root.ReplyCnt)
+			},
+			req: web.CreateReq{
+				ParentId: 2,
+				Content:  "回复",
+			},
+			wantCode: 200,
+			wantResp: test.Result[int64]{Data: 3},
+		},
+		{
+			name: "回复被隐藏的评论",
+			before: func(t *testing.T) {
+				s.createComment(t, dao.Comment{
+					ID: 1, Biz: "case", BizID: 2, Uid: 1,
+					Status: domain.CommentStatusHidden.ToUint8(),
+				})
+			},
+			after: func(t *testing.T) {},
+			req: web.CreateReq{
+				ParentId: 1,
+				Content:  "回复",
+			},
+			wantCode: 500,
+			wantResp: test.Result[int64]{
+				Code: errs.CommentNotFound.Code,
+				Msg:  errs.CommentNotFound.Msg,
+			},
+		},
+		{
+			name:   "不支持评论的业务",
+			before: func(t *testing.T) {},
+			after:  func(t *testing.T) {},
+			req: web.CreateReq{
+				Biz:     "order",
+				BizId:   1,
+				Content: "评论",
+			},
+			wantCode: 500,
+			wantResp: test.Result[int64]{
+				Code: errs.InvalidComment.Code,
+				Msg:  errs.InvalidComment.Msg,
+			},
+		},
+		{
+			name:   "评论内容为空",
+			before: func(t *testing.T) {},
+			after:  func(t *testing.T) {},
+			req: web.CreateReq{
+				Biz:     "question",
+				BizId:   1,
+				Content: "  ",
+			},
+			wantCode: 500,
+			wantResp: test.Result[int64]{
+				Code: errs.InvalidComment.Code,
+				Msg:  errs.InvalidComment.Msg,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			recorder := doRequest[int64](t, s.server, "/comment/create", tc.req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+			s.TearDownTest()
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestEdit() {
+	s.createComment(s.T(), dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: uid})
+	s.createComment(s.T(), dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1})
+
+	recorder := doRequest[any](s.T(), s.server, "/comment/edit", web.EditReq{Id: 1, Content: "修改之后"})
+	require.Equal(s.T(), 200, recorder.Code)
+	c, err := s.dao.FindById(context.Background(), 1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "修改之后", c.Content)
+
+	// 不能修改别人的评论
+	recorder = doRequest[any](s.T(), s.server, "/comment/edit", web.EditReq{Id: 2, Content: "修改之后"})
+	require.Equal(s.T(), 500, recorder.Code)
+	assert.Equal(s.T(), errs.CommentNotFound.Code, recorder.MustScan().Code)
+
+	// 被隐藏的评论不能修改
+	s.createComment(s.T(), dao.Comment{ID: 3, Biz: "question", BizID: 1, Uid: uid, Content: "隐藏",
+		Status: domain.CommentStatusHidden.ToUint8()})
+	recorder = doRequest[any](s.T(), s.server, "/comment/edit", web.EditReq{Id: 3, Content: "修改之后"})
+	require.Equal(s.T(), 500, recorder.Code)
+	assert.Equal(s.T(), errs.CommentNotFound.Code, recorder.MustScan().Code)
+
+	// 顶级评论被隐藏了，下面的回复也不能修改
+	s.createComment(s.T(), dao.Comment{ID: 4, Biz: "question", BizID: 1, Uid: 1,
+		Status: domain.CommentStatusHidden.ToUint8()})
+	s.createComment(s.T(), dao.Comment{ID: 5, Biz: "question", BizID: 1, Uid: uid, RootID: 4, ParentID: 4, Content: "回复"})
+	recorder = doRequest[any](s.T(), s.server, "/comment/edit", web.EditReq{Id: 5, Content: "修改之后"})
+	require.Equal(s.T(), 500, recorder.Code)
+	assert.Equal(s.T(), errs.CommentNotFound.Code, recorder.MustScan().Code)
+	c, err = s.dao.FindById(context.Background(), 5)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "回复", c.Content)
+}
+
+func (s *HandlerTestSuite) TestDelete() {
+	t := s.T()
+	s.createComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: uid, ReplyCnt: 2})
+	s.createComment(t, dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1, RootID: 1, ParentID: 1})
+	s.createComment(t, dao.Comment{ID: 3, Biz: "question", BizID: 1, Uid: uid, RootID: 1, ParentID: 2})
+	s.createComment(t, dao.Comment{ID: 4, Biz: "question", BizID: 1, Uid: 1})
+	s.createComment(t, dao.Comment{ID: 5, Biz: "question", BizID: 1, Uid: 1, RootID: 4, ParentID: 4})
+
+	// 删除回复，顶级评论的回复数减少
+	recorder := doRequest[any](t, s.server, "/comment/delete", web.IdReq{Id: 3})
+	require.Equal(t, 200, recorder.Code)
+	_, err := s.dao.FindById(context.Background(), 3)
+	assert.ErrorIs(t, err, dao.ErrRecordNotFound)
+	root, err := s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, root.ReplyCnt)
+
+	// 删除顶级评论，回复一起删除
+	recorder = doRequest[any](t, s.server, "/comment/delete", web.IdReq{Id: 1})
+	require.Equal(t, 200, recorder.Code)
+	var cnt int64
+	err = s.db.Model(&dao.Comment{}).Where("id IN ?", []int64{1, 2}).Count(&cnt).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), cnt)
+
+	// 不能删除别人的评论
+	recorder = doRequest[any](t, s.server, "/comment/delete", web.IdReq{Id: 4})
+	require.Equal(t, 500, recorder.Code)
+	_, err = s.dao.FindById(context.Background(), 5)
+	require.NoError(t, err)
+}
+
+func (s *HandlerTestSuite) TestLikeToggle() {
+	t := s.T()
+	s.createComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: 1})
+
+	recorder := doRequest[any](t, s.server, "/comment/like/toggle", web.IdReq{Id: 1})
+	require.Equal(t, 200, recorder.Code)
+	c, err := s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.LikeCnt)
+
+	recorder = doRequest[any](t, s.server, "/comment/like/toggle", web.IdReq{Id: 1})
+	require.Equal(t, 200, recorder.Code)
+	c, err = s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.LikeCnt)
+
+	recorder = doRequest[any](t, s.server, "/comment/like/toggle", web.IdReq{Id: 100})
+	require.Equal(t, 500, recorder.Code)
+}
+
+func (s *HandlerTestSuite) TestList() {
+	t := s.T()
+	for idx := 1; idx <= 10; idx++ {
+		c := dao.Comment{
+			ID:      int64(idx),
+			Biz:     "project",
+			BizID:   1,
+			Uid:     int64(idx),
+			Content: fmt.Sprintf("评论 %d", idx),
+		}
+		switch idx {
+		case 1:
+			c.Pinned = true
+		case 2:
+			c.Status = domain.CommentStatusHidden.ToUint8()
+		case 3:
+			c.Biz = "case"
+		}
+		s.createComment(t, c)
+	}
+	s.createComment(t, dao.Comment{ID: 11, Biz: "project", BizID: 1, Uid: 1, RootID: 10, ParentID: 10})
+	s.createComment(t, dao.Comment{ID: 12, Biz: "project", BizID: 1, Uid: 1, RootID: 10, ParentID: 11})
+	err := s.db.Create(&dao.CommentLike{Uid: uid, Cid: 9}).Error
+	require.NoError(t, err)
+
+	ids := func(cs []web.Comment) []int64 {
+		res := make([]int64, 0, len(cs))
+		for _, c := range cs {
+			res = append(res, c.Id)
+		}
+		return res
+	}
+
+	// 第一页，带上置顶的评论
+	recorder := doRequest[web.CommentList](t, s.server, "/comment/list", web.ListReq{
+		Biz: "project", BizId: 1, Limit: 3,
+	})
+	require.Equal(t, 200, recorder.Code)
+	res := recorder.MustScan().Data
+	assert.Equal(t, []int64{1}, ids(res.Pinned))
+	assert.Equal(t, []int64{10, 9, 8}, ids(res.List))
+	assert.True(t, res.List[1].Liked)
+
+	// 第二页，跳过隐藏的和其他业务的评论
+	recorder = doRequest[web.CommentList](t, s.server, "/comment/list", web.ListReq{
+		Biz: "project", BizId: 1, MaxId: 8, Limit: 10,
+	})
+	require.Equal(t, 200, recorder.Code)
+	res = recorder.MustScan().Data
+	assert.Empty(t, res.Pinned)
+	assert.Equal(t, []int64{7, 6, 5, 4}, ids(res.List))
+
+	recorder = doRequest[web.CommentList](t, s.server, "/comment/replies", web.RepliesReq{
+		RootId: 10, MinId: 11, Limit: 10,
+	})
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, []int64{12}, ids(recorder.MustScan().Data.List))
+
+	// 顶级评论被隐藏了，回复也不返回
+	s.createComment(t, dao.Comment{ID: 13, Biz: "project", BizID: 1, Uid: 1, RootID: 2, ParentID: 2})
+	recorder = doRequest[web.CommentList](t, s.server, "/comment/replies", web.RepliesReq{
+		RootId: 2, Limit: 10,
+	})
+	require.Equal(t, 500, recorder.Code)
+	assert.Equal(t, errs.CommentNotFound.Code, recorder.MustScan().Code)
+}
+
+func (s *HandlerTestSuite) TestAdmin() {
+	t := s.T()
+	s.createComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: 1, ReplyCnt: 1})
+	s.createComment(t, dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1, RootID: 1, ParentID: 1})
+	s.createComment(t, dao.Comment{ID: 3, Biz: "case", BizID: 1, Uid: 1})
+
+	// 隐藏回复
+	recorder := doRequest[any](t, s.adminServer, "/comment/hide", web.IdReq{Id: 2})
+	require.Equal(t, 200, recorder.Code)
+	c, err := s.dao.FindById(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CommentStatusHidden.ToUint8(), c.Status)
+	root, err := s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, root.ReplyCnt)
+
+	// 恢复回复
+	recorder = doRequest[any](t, s.adminServer, "/comment/restore", web.IdReq{Id: 2})
+	require.Equal(t, 200, recorder.Code)
+	root, err = s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, root.ReplyCnt)
+
+	// 回复不能置顶
+	recorder = doRequest[any](t, s.adminServer, "/comment/pin", web.PinReq{Id: 2, Pinned: true})
+	require.Equal(t, 500, recorder.Code)
+	recorder = doRequest[any](t, s.adminServer, "/comment/pin", web.PinReq{Id: 1, Pinned: true})
+	require.Equal(t, 200, recorder.Code)
+	root, err = s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, root.Pinned)
+
+	adminRecorder := doRequest[web.CommentList](t, s.adminServer, "/comment/list", web.AdminListReq{
+		Biz: "question", BizId: 1, Limit: 10,
+	})
+	require.Equal(t, 200, adminRecorder.Code)
+	res := adminRecorder.MustScan().Data
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, 2, len(res.List))
+}
+
+func (s *HandlerTestSuite) TestMergeUser() {
+	t := s.T()
+	const sourceUid, targetUid = 201, 202
+	s.createComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: sourceUid, LikeCnt: 2})
+	s.createComment(t, dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1, RootID: 1, ParentID: 1, ReplyToUid: sourceUid, LikeCnt: 1})
+	// 两个账号都点赞过评论 1，只有源账号点赞过评论 2
+	err := s.db.Create(&[]dao.CommentLike{
+		{Uid: sourceUid, Cid: 1, Ctime: 123, Utime: 123},
+		{Uid: targetUid, Cid: 1, Ctime: 123, Utime: 123},
+		{Uid: sourceUid, Cid: 2, Ctime: 123, Utime: 123},
+	}).Error
+	require.NoError(t, err)
+
+	err = s.dao.MergeUser(context.Background(), sourceUid, targetUid)
+	require.NoError(t, err)
+
+	c, err := s.dao.FindById(context.Background(), 1)
+	require.NoError(t, err)
+	s.assertComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: targetUid, Status: 1, LikeCnt: 1}, c)
+	c, err = s.dao.FindById(context.Background(), 2)
+	require.NoError(t, err)
+	s.assertComment(t, dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1, RootID: 1, ParentID: 1,
+		ReplyToUid: targetUid, Status: 1, LikeCnt: 1}, c)
+	var likes []dao.CommentLike
+	err = s.db.Order("cid").Find(&likes).Error
+	require.NoError(t, err)
+	require.Len(t, likes, 2)
+	assert.Equal(t, []int64{targetUid, targetUid}, []int64{likes[0].Uid, likes[1].Uid})
+	assert.Equal(t, []int64{1, 2}, []int64{likes[0].Cid, likes[1].Cid})
+}
+
+func (s *HandlerTestSuite) TestDeleteUserData() {
+	t := s.T()
+	const deletedUid = 301
+	// 自己的顶级评论，下面有别人的回复，会一起删掉
+	s.createComment(t, dao.Comment{ID: 1, Biz: "question", BizID: 1, Uid: deletedUid, ReplyCnt: 1})
+	s.createComment(t, dao.Comment{ID: 2, Biz: "question", BizID: 1, Uid: 1, RootID: 1, ParentID: 1, ReplyToUid: deletedUid})
+	// 别人的顶级评论，下面有自己的回复，还点赞过
+	s.createComment(t, dao.Comment{ID: 3, Biz: "question", BizID: 1, Uid: 1, LikeCnt: 1, ReplyCnt: 1})
+	s.createComment(t, dao.Comment{ID: 4, Biz: "question", BizID: 1, Uid: deletedUid, RootID: 3, ParentID: 3, ReplyToUid: 1})
+	err := s.db.Create(&dao.CommentLike{Uid: deletedUid, Cid: 3, Ctime: 123, Utime: 123}).Error
+	require.NoError(t, err)
+
+	data, err := s.module.ExportSource.Export(context.Background(), deletedUid)
+	require.NoError(t, err)
+	val, err := json.Marshal(data)
+	require.NoError(t, err)
+	var exported struct {
+		Comments  []domain.Comment
+		LikedCids []int64
+	}
+	require.NoError(t, json.Unmarshal(val, &exported))
+	assert.Equal(t, []int64{1, 4}, slice.Map(exported.Comments, func(idx int, src domain.Comment) int64 {
+		return src.ID
+	}))
+	assert.Equal(t, []int64{3}, exported.LikedCids)
+
+	err = s.module.Svc.DeleteUserData(context.Background(), deletedUid)
+	require.NoError(t, err)
+	var cs []dao.Comment
+	err = s.db.Order("id").Find(&cs).Error
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	s.assertComment(t, dao.Comment{ID: 3, Biz: "question", BizID: 1, Uid: 1, Status: 1}, cs[0])
+	var cnt int64
+	err = s.db.Model(&dao.CommentLike{}).Count(&cnt).Error
+	require.NoError(t, err)
+	assert.Zero(t, cnt)
+}
+
+func (s *HandlerTestSuite) createComment(t *testing.T, c dao.Comment) {
+	if c.Status == 0 {
+		c.Status = domain.CommentStatusNormal.ToUint8()
+	}
+	c.Ctime = 123
+	c.Utime = 123
+	err := s.db.Create(&c).Error
+	require.NoError(t, err)
+}
+
+func (s *HandlerTestSuite) assertComment(t *testing.T, expect dao.Comment, actual dao.Comment) {
+	require.True(t, actual.Ctime != 0)
+	require.True(t, actual.Utime != 0)
+	actual.Ctime = 0
+	actual.Utime = 0
+	assert.Equal(t, expect, actual)
+}
+
+func doRequest[T any](t *testing.T, server *egin.Component, path string, body any) *httptestx.JSONResponseRecorder[test.Result[T]] {
+	req, err := http.NewRequest(http.MethodPost, path, iox.NewJSONReader(body))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[T]()
+	server.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCommentHandler(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
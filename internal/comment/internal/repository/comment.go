@@ -0,0 +1,184 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/comment/internal/repository/dao"
+)
+
+var ErrRecordNotFound = dao.ErrRecordNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	UpdateContent(ctx context.Context, uid, id int64, content string) error
+	Delete(ctx context.Context, uid, id int64) (domain.Comment, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	List(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]domain.Comment, error)
+	PinnedList(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error)
+	Replies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error)
+
+	LikeToggle(ctx context.Context, uid, cid int64) error
+	// LikedMap 用户点赞过的评论
+	LikedMap(ctx context.Context, uid int64, cids []int64) (map[int64]struct{}, error)
+
+	UpdateStatus(ctx context.Context, id int64, status domain.CommentStatus) (domain.Comment, error)
+	UpdatePinned(ctx context.Context, id int64, pinned bool) error
+	AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.Comment, error)
+	AdminCount(ctx context.Context, biz string, bizId int64) (int64, error)
+	MergeUser(ctx context.Context, sourceUid, targetUid int64) error
+	FindByUid(ctx context.Context, uid int64) ([]domain.Comment, error)
+	FindLikedCids(ctx context.Context, uid int64) ([]int64, error)
+	DeleteLikes(ctx context.Context, uid int64) error
+}
+
+type commentRepository struct {
+	dao dao.CommentDAO
+}
+
+func NewCommentRepository(d dao.CommentDAO) CommentRepository {
+	return &commentRepository{
+		dao: d,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	return r.dao.Create(ctx, r.toEntity(c))
+}
+
+func (r *commentRepository) UpdateContent(ctx context.Context, uid, id int64, content string) error {
+	return r.dao.UpdateContent(ctx, uid, id, content)
+}
+
+func (r *commentRepository) Delete(ctx context.Context, uid, id int64) (domain.Comment, error) {
+	c, err := r.dao.Delete(ctx, uid, id)
+	return r.toDomain(c), err
+}
+
+func (r *commentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := r.dao.FindById(ctx, id)
+	return r.toDomain(c), err
+}
+
+func (r *commentRepository) List(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	cs, err := r.dao.List(ctx, biz, bizId, maxId, limit)
+	return r.toDomains(cs), err
+}
+
+func (r *commentRepository) PinnedList(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error) {
+	cs, err := r.dao.PinnedList(ctx, biz, bizId)
+	return r.toDomains(cs), err
+}
+
+func (r *commentRepository) Replies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error) {
+	cs, err := r.dao.Replies(ctx, rootId, minId, limit)
+	return r.toDomains(cs), err
+}
+
+func (r *commentRepository) LikeToggle(ctx context.Context, uid, cid int64) error {
+	return r.dao.LikeToggle(ctx, uid, cid)
+}
+
+func (r *commentRepository) LikedMap(ctx context.Context, uid int64, cids []int64) (map[int64]struct{}, error) {
+	likes, err := r.dao.GetUserLikes(ctx, uid, cids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]struct{}, len(likes))
+	for _, like := range likes {
+		res[like.Cid] = struct{}{}
+	}
+	return res, nil
+}
+
+func (r *commentRepository) UpdateStatus(ctx context.Context, id int64, status domain.CommentStatus) (domain.Comment, error) {
+	c, err := r.dao.UpdateStatus(ctx, id, status.ToUint8())
+	return r.toDomain(c), err
+}
+
+func (r *commentRepository) UpdatePinned(ctx context.Context, id int64, pinned bool) error {
+	return r.dao.UpdatePinned(ctx, id, pinned)
+}
+
+func (r *commentRepository) AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.Comment, error) {
+	cs, err := r.dao.AdminList(ctx, biz, bizId, offset, limit)
+	return r.toDomains(cs), err
+}
+
+func (r *commentRepository) AdminCount(ctx context.Context, biz string, bizId int64) (int64, error) {
+	return r.dao.AdminCount(ctx, biz, bizId)
+}
+
+func (r *commentRepository) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	return r.dao.MergeUser(ctx, sourceUid, targetUid)
+}
+
+func (r *commentRepository) FindByUid(ctx context.Context, uid int64) ([]domain.Comment, error) {
+	cs, err := r.dao.FindByUid(ctx, uid)
+	return r.toDomains(cs), err
+}
+
+func (r *commentRepository) FindLikedCids(ctx context.Context, uid int64) ([]int64, error) {
+	return r.dao.FindLikedCids(ctx, uid)
+}
+
+func (r *commentRepository) DeleteLikes(ctx context.Context, uid int64) error {
+	return r.dao.DeleteLikes(ctx, uid)
+}
+
+func (r *commentRepository) toDomains(cs []dao.Comment) []domain.Comment {
+	return slice.Map(cs, func(idx int, src dao.Comment) domain.Comment {
+		return r.toDomain(src)
+	})
+}
+
+func (r *commentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		ID:         c.ID,
+		Biz:        c.Biz,
+		BizID:      c.BizID,
+		Uid:        c.Uid,
+		Content:    c.Content,
+		RootID:     c.RootID,
+		ParentID:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Status:     domain.CommentStatus(c.Status),
+		Pinned:     c.Pinned,
+		LikeCnt:    c.LikeCnt,
+		ReplyCnt:   c.ReplyCnt,
+		Ctime:      c.Ctime,
+		Utime:      c.Utime,
+	}
+}
+
+func (r *commentRepository) toEntity(c domain.Comment) dao.Comment {
+	return dao.Comment{
+		ID:         c.ID,
+		Biz:        c.Biz,
+		BizID:      c.BizID,
+		Uid:        c.Uid,
+		Content:    c.Content,
+		RootID:     c.RootID,
+		ParentID:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Status:     c.Status.ToUint8(),
+		Pinned:     c.Pinned,
+		LikeCnt:    c.LikeCnt,
+		ReplyCnt:   c.ReplyCnt,
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type CommentDAO interface {
+	// Create 创建评论，如果是回复，会同时增加顶级评论的回复数
+	Create(ctx context.Context, c Comment) (int64, error)
+	// UpdateContent 只能修改自己的正常状态的评论
+	UpdateContent(ctx context.Context, uid, id int64, content string) error
+	// Delete 只能删除自己的评论，删除顶级评论会把下面的回复一起删掉
+	// 返回被删除的评论
+	Delete(ctx context.Context, uid, id int64) (Comment, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// List 未置顶的正常顶级评论，按照 id 倒序，maxId 为 0 的时候从最新的开始
+	List(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]Comment, error)
+	// PinnedList 置顶的正常顶级评论
+	PinnedList(ctx context.Context, biz string, bizId int64) ([]Comment, error)
+	// Replies 顶级评论下的正常回复，按照 id 升序
+	Replies(ctx context.Context, rootId int64, minId int64, limit int) ([]Comment, error)
+
+	LikeToggle(ctx context.Context, uid, cid int64) error
+	GetUserLikes(ctx context.Context, uid int64, cids []int64) ([]CommentLike, error)
+
+	// UpdateStatus 返回的是修改之前的评论，隐藏或者恢复回复的时候会同步修改顶级评论的回复数
+	UpdateStatus(ctx context.Context, id int64, status uint8) (Comment, error)
+	// UpdatePinned 只有顶级评论可以置顶
+	UpdatePinned(ctx context.Context, id int64, pinned bool) error
+	// AdminList 管理后台使用，包含所有状态的评论，biz 为空的时候不过滤
+	AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) ([]Comment, error)
+	AdminCount(ctx context.Context, biz string, bizId int64) (int64, error)
+
+	// MergeUser 把源账号的评论和点赞转到目标账号上，两边都点赞过的评论只算一次
+	MergeUser(ctx context.Context, sourceUid, targetUid int64) error
+	// FindByUid 用户自己的所有评论，包含被隐藏的，按照 id 升序
+	FindByUid(ctx context.Context, uid int64) ([]Comment, error)
+	// FindLikedCids 用户点赞过的评论
+	FindLikedCids(ctx context.Context, uid int64) ([]int64, error)
+	// DeleteLikes 删除用户所有的点赞，同时减少对应评论的点赞数
+	DeleteLikes(ctx context.Context, uid int64) error
+}
+
+type GORMCommentDAO struct {
+	db *egorm.Component
+}
+
+func NewCommentDAO(db *egorm.Component) CommentDAO {
+	return &GORMCommentDAO{
+		db: db,
+	}
+}
+
+func (g *GORMCommentDAO) Create(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&c).Error
+		if err != nil || c.RootID == 0 {
+			return err
+		}
+		return g.incrReplyCnt(tx, c.RootID, 1, now)
+	})
+	return c.ID, err
+}
+
+func (g *GORMCommentDAO) incrReplyCnt(tx *gorm.DB, rootId int64, delta int, now int64) error {
+	return tx.Model(&Comment{}).
+		Where("id = ?", rootId).
+		Updates(map[string]any{
+			"reply_cnt": gorm.Expr("GREATEST(`reply_cnt` + ?, 0)", delta),
+			"utime":     now,
+		}).Error
+}
+
+func (g *GORMCommentDAO) UpdateContent(ctx context.Context, uid, id int64, content string) error {
+	res := g.db.WithContext(ctx).Model(&Comment{}).
+		Where("id = ? AND uid = ? AND status = ?", id, uid, domain.CommentStatusNormal.ToUint8()).
+		Updates(map[string]any{
+			"content": content,
+			"utime":   time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected < 1 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (g *GORMCommentDAO) Delete(ctx context.Context, uid, id int64) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND uid = ?", id, uid).First(&c).Error
+		if err != nil {
+			return err
+		}
+		cids := []int64{id}
+		if c.RootID == 0 {
+			var replyIds []int64
+			err = tx.Model(&Comment{}).Where("root_id = ?", id).Pluck("id", &replyIds).Error
+			if err != nil {
+				return err
+			}
+			cids = append(cids, replyIds...)
+		} else if c.Status == domain.CommentStatusNormal.ToUint8() {
+			err = g.incrReplyCnt(tx, c.RootID, -1, time.Now().UnixMilli())
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Where("id IN ?", cids).Delete(&Comment{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("cid IN ?", cids).Delete(&CommentLike{}).Error
+	})
+	return c, err
+}
+
+func (g *GORMCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GORMCommentDAO) List(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	db := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0 AND status = ? AND pinned = ?",
+			biz, bizId, domain.CommentStatusNormal.ToUint8(), false)
+	if maxId > 0 {
+		db = db.Where("id < ?", maxId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) PinnedList(ctx context.Context, biz string, bizId int64) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0 AND status = ? AND pinned = ?",
+			biz, bizId, domain.CommentStatusNormal.ToUint8(), true).
+		Order("id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) Replies(ctx context.Context, rootId int64, minId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("root_id = ? AND status = ? AND id > ?",
+			rootId, domain.CommentStatusNormal.ToUint8(), minId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) LikeToggle(ctx context.Context, uid, cid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND status = ?", cid, domain.CommentStatusNormal.ToUint8()).
+			First(&Comment{}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		res := tx.Where("uid = ? AND cid = ?", uid, cid).Delete(&CommentLike{})
+		if res.Error != nil {
+			return res.Error
+		}
+		delta := -1
+		if res.RowsAffected < 1 {
+			// 没有点赞过，那么就是点赞
+			delta = 1
+			err = tx.Create(&CommentLike{
+				Uid:   uid,
+				Cid:   cid,
+				Ctime: now,
+				Utime: now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Comment{}).
+			Where("id = ?", cid).
+			Updates(map[string]any{
+				"like_cnt": gorm.Expr("GREATEST(`like_cnt` + ?, 0)", delta),
+				"utime":    now,
+			}).Error
+	})
+}
+
+func (g *GORMCommentDAO) GetUserLikes(ctx context.Context, uid int64, cids []int64) ([]CommentLike, error) {
+	var res []CommentLike
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND cid IN ?", uid, cids).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) UpdateStatus(ctx context.Context, id int64, status uint8) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).First(&c).Error
+		if err != nil || c.Status == status {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Model(&Comment{}).Where("id = ?", id).
+			Updates(map[string]any{
+				"status": status,
+				"utime":  now,
+			}).Error
+		if err != nil || c.RootID == 0 {
+			return err
+		}
+		delta := 1
+		if status != domain.CommentStatusNormal.ToUint8() {
+			delta = -1
+		}
+		return g.incrReplyCnt(tx, c.RootID, delta, now)
+	})
+	return c, err
+}
+
+func (g *GORMCommentDAO) UpdatePinned(ctx context.Context, id int64, pinned bool) error {
+	res := g.db.WithContext(ctx).Model(&Comment{}).
+		Where("id = ? AND root_id = 0", id).
+		Updates(map[string]any{
+			"pinned": pinned,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected < 1 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (g *GORMCommentDAO) AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.adminQuery(ctx, biz, bizId).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) AdminCount(ctx context.Context, biz string, bizId int64) (int64, error) {
+	var res int64
+	err := g.adminQuery(ctx, biz, bizId).Model(&Comment{}).Count(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) adminQuery(ctx context.Context, biz string, bizId int64) *gorm.DB {
+	db := g.db.WithContext(ctx)
+	if biz != "" {
+		db = db.Where("biz = ? AND biz_id = ?", biz, bizId)
+	}
+	return db
+}
+
+func (g *GORMCommentDAO) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := tx.Model(&Comment{}).Where("uid = ?", sourceUid).
+			Updates(map[string]any{
+				"uid":   targetUid,
+				"utime": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Comment{}).Where("reply_to_uid = ?", sourceUid).
+			Updates(map[string]any{
+				"reply_to_uid": targetUid,
+				"utime":        now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		// 目标账号已经点赞过的会因为唯一索引冲突被忽略，留在源账号上的就是重复的，删掉的时候要减少点赞数
+		err = tx.Exec("UPDATE IGNORE `comment_likes` SET `uid` = ?, `utime` = ? WHERE `uid` = ?",
+			targetUid, now, sourceUid).Error
+		if err != nil {
+			return err
+		}
+		var likes []CommentLike
+		err = tx.Where("uid = ?", sourceUid).Find(&likes).Error
+		if err != nil || len(likes) == 0 {
+			return err
+		}
+		cids := slice.Map(likes, func(idx int, src CommentLike) int64 {
+			return src.Cid
+		})
+		err = tx.Where("uid = ?", sourceUid).Delete(&CommentLike{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Comment{}).Where("id IN ?", cids).
+			Updates(map[string]any{
+				"like_cnt": gorm.Expr("GREATEST(`like_cnt` - 1, 0)"),
+				"utime":    now,
+			}).Error
+	})
+}
+
+func (g *GORMCommentDAO) FindByUid(ctx context.Context, uid int64) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) FindLikedCids(ctx context.Context, uid int64) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(&CommentLike{}).
+		Where("uid = ?", uid).Order("id ASC").Pluck("cid", &res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) DeleteLikes(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cids []int64
+		err := tx.Model(&CommentLike{}).Where("uid = ?", uid).Pluck("cid", &cids).Error
+		if err != nil || len(cids) == 0 {
+			return err
+		}
+		err = tx.Where("uid = ?", uid).Delete(&CommentLike{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Comment{}).Where("id IN ?", cids).
+			Updates(map[string]any{
+				"like_cnt": gorm.Expr("GREATEST(`like_cnt` - 1, 0)"),
+				"utime":    time.Now().UnixMilli(),
+			}).Error
+	})
+}
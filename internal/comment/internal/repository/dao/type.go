@@ -0,0 +1,43 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+type Comment struct {
+	ID    int64  `gorm:"primaryKey,autoIncrement"`
+	Biz   string `gorm:"type:varchar(128);not null;index:biz_biz_id_root_id"`
+	BizID int64  `gorm:"not null;index:biz_biz_id_root_id"`
+	// RootID 顶级评论的 RootID 是 0
+	RootID     int64  `gorm:"not null;default:0;index:biz_biz_id_root_id"`
+	ParentID   int64  `gorm:"not null;default:0"`
+	Uid        int64  `gorm:"not null;index"`
+	ReplyToUid int64  `gorm:"not null;default:0"`
+	Content    string `gorm:"type:text"`
+	// Status 1-正常 2-隐藏
+	Status   uint8 `gorm:"type:tinyint(3);not null;default:1"`
+	Pinned   bool  `gorm:"not null;default:false"`
+	LikeCnt  int   `gorm:"not null;default:0"`
+	ReplyCnt int   `gorm:"not null;default:0"`
+	Ctime    int64
+	Utime    int64
+}
+
+// CommentLike 评论的点赞明细
+type CommentLike struct {
+	ID    int64 `gorm:"primaryKey,autoIncrement"`
+	Uid   int64 `gorm:"uniqueIndex:uid_cid"`
+	Cid   int64 `gorm:"uniqueIndex:uid_cid;index"`
+	Ctime int64
+	Utime int64
+}
@@ -0,0 +1,359 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/comment/internal/event"
+	"github.com/ecodeclub/webook/internal/comment/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	// ErrCommentNotFound 评论不存在，或者不是本人的评论，或者已经被隐藏
+	ErrCommentNotFound = errors.New("评论不存在")
+	// ErrInvalidComment 评论内容为空、过长或者评论的业务不支持
+	ErrInvalidComment = errors.New("评论内容不合法")
+)
+
+// maxContentLen 评论内容的最大字符数
+const maxContentLen = 1000
+
+// supportedBizs 目前只有题目、案例和项目支持评论
+var supportedBizs = map[string]struct{}{
+	"question": {},
+	"case":     {},
+	"project":  {},
+}
+
+type Service interface {
+	// Create 发表评论，ParentID 不为 0 的时候就是回复
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Edit 修改自己的评论，被隐藏的评论，或者顶级评论被隐藏的回复都不能修改
+	Edit(ctx context.Context, uid, id int64, content string) error
+	// Delete 删除自己的评论，顶级评论下面的回复会一起删除
+	Delete(ctx context.Context, uid, id int64) error
+	// List 游标分页，maxId 为上一页最后一条评论的 id，uid 用于判断是否点赞过
+	List(ctx context.Context, biz string, bizId int64, uid int64, maxId int64, limit int) ([]domain.Comment, error)
+	PinnedList(ctx context.Context, biz string, bizId int64, uid int64) ([]domain.Comment, error)
+	// Replies 游标分页，minId 为上一页最后一条回复的 id
+	// 顶级评论被隐藏的时候，下面的回复也不会返回
+	Replies(ctx context.Context, rootId int64, uid int64, minId int64, limit int) ([]domain.Comment, error)
+	// LikeToggle 如果点赞过，就取消点赞，如果没点赞过，就点赞
+	LikeToggle(ctx context.Context, uid, cid int64) error
+
+	// Hide 和 Restore 给管理员审核评论使用
+	Hide(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	Pin(ctx context.Context, id int64, pinned bool) error
+	AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) (int64, []domain.Comment, error)
+
+	// Merge 把源账号的评论和点赞转到目标账号上
+	Merge(ctx context.Context, sourceUid, targetUid int64) error
+	// FindByUid 用户自己的所有评论，包含被隐藏的
+	FindByUid(ctx context.Context, uid int64) ([]domain.Comment, error)
+	// FindLikedCids 用户点赞过的评论
+	FindLikedCids(ctx context.Context, uid int64) ([]int64, error)
+	// DeleteUserData 注销账号的时候删除用户的评论和点赞
+	DeleteUserData(ctx context.Context, uid int64) error
+}
+
+type commentService struct {
+	repo         repository.CommentRepository
+	intrProducer event.InteractiveEventProducer
+	logger       *elog.Component
+}
+
+func NewService(repo repository.CommentRepository, intrProducer event.InteractiveEventProducer) Service {
+	return &commentService{
+		repo:         repo,
+		intrProducer: intrProducer,
+		logger:       elog.DefaultLogger,
+	}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	c.Content = strings.TrimSpace(c.Content)
+	if !s.isValidContent(c.Content) {
+		return 0, ErrInvalidComment
+	}
+	c.Status = domain.CommentStatusNormal
+	c.Pinned = false
+	c.RootID = 0
+	c.ReplyToUid = 0
+	if c.ParentID > 0 {
+		parent, err := s.findNormal(ctx, c.ParentID)
+		if err != nil {
+			return 0, err
+		}
+		c.Biz = parent.Biz
+		c.BizID = parent.BizID
+		c.ReplyToUid = parent.Uid
+		c.RootID = parent.ID
+		if !parent.IsRoot() {
+			// 回复的是回复，那么顶级评论也必须是正常的
+			c.RootID = parent.RootID
+			_, err = s.findNormal(ctx, c.RootID)
+			if err != nil {
+				return 0, err
+			}
+		}
+	} else if _, ok := supportedBizs[c.Biz]; !ok || c.BizID <= 0 {
+		return 0, ErrInvalidComment
+	}
+	id, err := s.repo.Create(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.sendCommentCntEvent(c.Biz, c.BizID, 1)
+	return id, nil
+}
+
+func (s *commentService) findNormal(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := s.repo.FindById(ctx, id)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if c.Status != domain.CommentStatusNormal {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return c, nil
+}
+
+func (s *commentService) isValidContent(content string) bool {
+	return content != "" && utf8.RuneCountInString(content) <= maxContentLen
+}
+
+func (s *commentService) Edit(ctx context.Context, uid, id int64, content string) error {
+	content = strings.TrimSpace(content)
+	if !s.isValidContent(content) {
+		return ErrInvalidComment
+	}
+	c, err := s.findNormal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCommentNotFound
+	}
+	if !c.IsRoot() {
+		_, err = s.findNormal(ctx, c.RootID)
+		if err != nil {
+			return err
+		}
+	}
+	err = s.repo.UpdateContent(ctx, uid, id, content)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (s *commentService) Delete(ctx context.Context, uid, id int64) error {
+	c, err := s.repo.Delete(ctx, uid, id)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCommentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.sendCommentCntEvent(c.Biz, c.BizID, -s.visibleCnt(ctx, c))
+	return nil
+}
+
+// visibleCnt 计算这条评论在评论数中占了多少
+// 被隐藏的评论不计数，顶级评论被隐藏的时候下面的回复也不计数
+func (s *commentService) visibleCnt(ctx context.Context, c domain.Comment) int {
+	if c.Status != domain.CommentStatusNormal {
+		return 0
+	}
+	if c.IsRoot() {
+		return 1 + c.ReplyCnt
+	}
+	root, err := s.repo.FindById(ctx, c.RootID)
+	if err != nil || root.Status != domain.CommentStatusNormal {
+		return 0
+	}
+	return 1
+}
+
+func (s *commentService) List(ctx context.Context, biz string, bizId int64, uid int64, maxId int64, limit int) ([]domain.Comment, error) {
+	cs, err := s.repo.List(ctx, biz, bizId, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.fillLiked(ctx, uid, cs)
+}
+
+func (s *commentService) PinnedList(ctx context.Context, biz string, bizId int64, uid int64) ([]domain.Comment, error) {
+	cs, err := s.repo.PinnedList(ctx, biz, bizId)
+	if err != nil {
+		return nil, err
+	}
+	return s.fillLiked(ctx, uid, cs)
+}
+
+func (s *commentService) Replies(ctx context.Context, rootId int64, uid int64, minId int64, limit int) ([]domain.Comment, error) {
+	_, err := s.findNormal(ctx, rootId)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.repo.Replies(ctx, rootId, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.fillLiked(ctx, uid, cs)
+}
+
+func (s *commentService) fillLiked(ctx context.Context, uid int64, cs []domain.Comment) ([]domain.Comment, error) {
+	// 没有登录
+	if uid <= 0 || len(cs) == 0 {
+		return cs, nil
+	}
+	likedMap, err := s.repo.LikedMap(ctx, uid, slice.Map(cs, func(idx int, src domain.Comment) int64 {
+		return src.ID
+	}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range cs {
+		_, cs[i].Liked = likedMap[cs[i].ID]
+	}
+	return cs, nil
+}
+
+func (s *commentService) LikeToggle(ctx context.Context, uid, cid int64) error {
+	err := s.repo.LikeToggle(ctx, uid, cid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (s *commentService) Hide(ctx context.Context, id int64) error {
+	old, err := s.updateStatus(ctx, id, domain.CommentStatusHidden)
+	if err != nil || old.Status == domain.CommentStatusHidden {
+		return err
+	}
+	s.sendCommentCntEvent(old.Biz, old.BizID, -s.visibleCnt(ctx, old))
+	return nil
+}
+
+func (s *commentService) Restore(ctx context.Context, id int64) error {
+	old, err := s.updateStatus(ctx, id, domain.CommentStatusNormal)
+	if err != nil || old.Status == domain.CommentStatusNormal {
+		return err
+	}
+	old.Status = domain.CommentStatusNormal
+	s.sendCommentCntEvent(old.Biz, old.BizID, s.visibleCnt(ctx, old))
+	return nil
+}
+
+// updateStatus 返回的是修改之前的评论
+func (s *commentService) updateStatus(ctx context.Context, id int64, status domain.CommentStatus) (domain.Comment, error) {
+	old, err := s.repo.UpdateStatus(ctx, id, status)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return old, err
+}
+
+func (s *commentService) Pin(ctx context.Context, id int64, pinned bool) error {
+	err := s.repo.UpdatePinned(ctx, id, pinned)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (s *commentService) AdminList(ctx context.Context, biz string, bizId int64, offset, limit int) (int64, []domain.Comment, error) {
+	var eg errgroup.Group
+	var total int64
+	var cs []domain.Comment
+	eg.Go(func() error {
+		var eerr error
+		cs, eerr = s.repo.AdminList(ctx, biz, bizId, offset, limit)
+		return eerr
+	})
+	eg.Go(func() error {
+		var eerr error
+		total, eerr = s.repo.AdminCount(ctx, biz, bizId)
+		return eerr
+	})
+	err := eg.Wait()
+	return total, cs, err
+}
+
+func (s *commentService) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	return s.repo.MergeUser(ctx, sourceUid, targetUid)
+}
+
+func (s *commentService) FindByUid(ctx context.Context, uid int64) ([]domain.Comment, error) {
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *commentService) FindLikedCids(ctx context.Context, uid int64) ([]int64, error) {
+	return s.repo.FindLikedCids(ctx, uid)
+}
+
+func (s *commentService) DeleteUserData(ctx context.Context, uid int64) error {
+	err := s.repo.DeleteLikes(ctx, uid)
+	if err != nil {
+		return err
+	}
+	cs, err := s.repo.FindByUid(ctx, uid)
+	if err != nil {
+		return err
+	}
+	// 一条一条删，这样顶级评论的回复数和评论数都能修正过来。
+	// 顶级评论排在前面，它下面自己的回复已经一起删掉了，所以会找不到
+	for _, c := range cs {
+		err = s.Delete(ctx, uid, c.ID)
+		if err != nil && !errors.Is(err, ErrCommentNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendCommentCntEvent 评论数交给 interactive 模块维护，发送失败只记录日志
+func (s *commentService) sendCommentCntEvent(biz string, bizId int64, delta int) {
+	if delta == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		defer cancel()
+		err := s.intrProducer.Produce(ctx, event.NewCommentCntEvent(biz, bizId, delta))
+		if err != nil {
+			s.logger.Error("发送评论计数消息到消息队列失败",
+				elog.FieldErr(err),
+				elog.String("biz", biz),
+				elog.Int64("bizId", bizId),
+				elog.Int("delta", delta))
+		}
+	}()
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+type exportData struct {
+	Comments []domain.Comment
+	// LikedCids 点赞过的评论
+	LikedCids []int64
+}
+
+// exportSource 导出个人数据的时候读取发表过的评论和点赞
+type exportSource struct {
+	svc Service
+}
+
+func NewExportSource(svc Service) exportx.Source {
+	return &exportSource{svc: svc}
+}
+
+func (s *exportSource) Name() string {
+	return "comment"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	cs, err := s.svc.FindByUid(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	cids, err := s.svc.FindLikedCids(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return exportData{Comments: cs, LikedCids: cids}, nil
+}
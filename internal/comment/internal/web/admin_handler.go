@@ -0,0 +1,82 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/comment/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 评论审核
+type AdminHandler struct {
+	svc service.Service
+}
+
+func NewAdminHandler(svc service.Service) *AdminHandler {
+	return &AdminHandler{
+		svc: svc,
+	}
+}
+
+func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
+	g := server.Group("/comment")
+	g.POST("/list", ginx.B[AdminListReq](h.List))
+	g.POST("/hide", ginx.B[IdReq](h.Hide))
+	g.POST("/restore", ginx.B[IdReq](h.Restore))
+	g.POST("/pin", ginx.B[PinReq](h.Pin))
+}
+
+func (h *AdminHandler) List(ctx *ginx.Context, req AdminListReq) (ginx.Result, error) {
+	total, cs, err := h.svc.AdminList(ctx, req.Biz, req.BizId, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: CommentList{
+			Total: total,
+			List: slice.Map(cs, func(idx int, src domain.Comment) Comment {
+				return newComment(src)
+			}),
+		},
+	}, nil
+}
+
+func (h *AdminHandler) Hide(ctx *ginx.Context, req IdReq) (ginx.Result, error) {
+	return h.result(h.svc.Hide(ctx, req.Id))
+}
+
+func (h *AdminHandler) Restore(ctx *ginx.Context, req IdReq) (ginx.Result, error) {
+	return h.result(h.svc.Restore(ctx, req.Id))
+}
+
+func (h *AdminHandler) Pin(ctx *ginx.Context, req PinReq) (ginx.Result, error) {
+	return h.result(h.svc.Pin(ctx, req.Id, req.Pinned))
+}
+
+func (h *AdminHandler) result(err error) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{}, nil
+	case errors.Is(err, service.ErrCommentNotFound):
+		return commentNotFoundResult, err
+	default:
+		return systemErrorResult, err
+	}
+}
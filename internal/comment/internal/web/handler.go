@@ -0,0 +1,163 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/comment/internal/domain"
+	"github.com/ecodeclub/webook/internal/comment/internal/service"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ ginx.Handler = &Handler{}
+
+type Handler struct {
+	svc service.Service
+	sp  session.Provider
+}
+
+func NewHandler(svc service.Service, sp session.Provider) *Handler {
+	return &Handler{
+		svc: svc,
+		sp:  sp,
+	}
+}
+
+func (h *Handler) PublicRoutes(server *gin.Engine) {
+	g := server.Group("/comment")
+	g.POST("/list", ginx.B[ListReq](h.List))
+	g.POST("/replies", ginx.B[RepliesReq](h.Replies))
+}
+
+func (h *Handler) PrivateRoutes(server *gin.Engine) {
+	g := server.Group("/comment")
+	g.POST("/create", ginx.BS[CreateReq](h.Create))
+	g.POST("/edit", ginx.BS[EditReq](h.Edit))
+	g.POST("/delete", ginx.BS[IdReq](h.Delete))
+	g.POST("/like/toggle", ginx.BS[IdReq](h.LikeToggle))
+}
+
+// getUid 评论列表不需要登录，没有登录的时候返回 0
+func (h *Handler) getUid(ctx *ginx.Context) int64 {
+	sess, err := h.sp.Get(ctx)
+	if err != nil {
+		return 0
+	}
+	return sess.Claims().Uid
+}
+
+func (h *Handler) List(ctx *ginx.Context, req ListReq) (ginx.Result, error) {
+	uid := h.getUid(ctx)
+	var (
+		eg     errgroup.Group
+		pinned []domain.Comment
+		cs     []domain.Comment
+	)
+	if req.MaxId == 0 {
+		eg.Go(func() error {
+			var err error
+			pinned, err = h.svc.PinnedList(ctx, req.Biz, req.BizId, uid)
+			return err
+		})
+	}
+	eg.Go(func() error {
+		var err error
+		cs, err = h.svc.List(ctx, req.Biz, req.BizId, uid, req.MaxId, validLimit(req.Limit))
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: CommentList{
+			Pinned: slice.Map(pinned, func(idx int, src domain.Comment) Comment {
+				return newComment(src)
+			}),
+			List: slice.Map(cs, func(idx int, src domain.Comment) Comment {
+				return newComment(src)
+			}),
+		},
+	}, nil
+}
+
+func (h *Handler) Replies(ctx *ginx.Context, req RepliesReq) (ginx.Result, error) {
+	cs, err := h.svc.Replies(ctx, req.RootId, h.getUid(ctx), req.MinId, validLimit(req.Limit))
+	if err != nil {
+		return h.errorResult(err), err
+	}
+	return ginx.Result{
+		Data: CommentList{
+			List: slice.Map(cs, func(idx int, src domain.Comment) Comment {
+				return newComment(src)
+			}),
+		},
+	}, nil
+}
+
+func (h *Handler) Create(ctx *ginx.Context, req CreateReq, sess session.Session) (ginx.Result, error) {
+	id, err := h.svc.Create(ctx, domain.Comment{
+		Biz:      req.Biz,
+		BizID:    req.BizId,
+		Uid:      sess.Claims().Uid,
+		ParentID: req.ParentId,
+		Content:  req.Content,
+	})
+	if err != nil {
+		return h.errorResult(err), err
+	}
+	return ginx.Result{
+		Data: id,
+	}, nil
+}
+
+func (h *Handler) Edit(ctx *ginx.Context, req EditReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.Edit(ctx, sess.Claims().Uid, req.Id, req.Content)
+	if err != nil {
+		return h.errorResult(err), err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) Delete(ctx *ginx.Context, req IdReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.Delete(ctx, sess.Claims().Uid, req.Id)
+	if err != nil {
+		return h.errorResult(err), err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) LikeToggle(ctx *ginx.Context, req IdReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.LikeToggle(ctx, sess.Claims().Uid, req.Id)
+	if err != nil {
+		return h.errorResult(err), err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) errorResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound):
+		return commentNotFoundResult
+	case errors.Is(err, service.ErrInvalidComment):
+		return invalidCommentResult
+	default:
+		return systemErrorResult
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import "github.com/ecodeclub/webook/internal/comment/internal/domain"
+
+// defaultLimit 和 maxLimit 用于约束游标分页的大小
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type CreateReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// ParentId 不为 0 的时候就是回复，这时候 Biz 和 BizId 以被回复的评论为准
+	ParentId int64  `json:"parentId"`
+	Content  string `json:"content"`
+}
+
+type EditReq struct {
+	Id      int64  `json:"id"`
+	Content string `json:"content"`
+}
+
+type IdReq struct {
+	Id int64 `json:"id"`
+}
+
+type PinReq struct {
+	Id     int64 `json:"id"`
+	Pinned bool  `json:"pinned"`
+}
+
+type ListReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// MaxId 上一页最后一条评论的 id，第一页传 0
+	MaxId int64 `json:"maxId"`
+	Limit int   `json:"limit"`
+}
+
+type RepliesReq struct {
+	RootId int64 `json:"rootId"`
+	// MinId 上一页最后一条回复的 id，第一页传 0
+	MinId int64 `json:"minId"`
+	Limit int   `json:"limit"`
+}
+
+type AdminListReq struct {
+	// Biz 为空的时候查询全部评论
+	Biz    string `json:"biz"`
+	BizId  int64  `json:"bizId"`
+	Offset int    `json:"offset,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+func validLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
+
+type Comment struct {
+	Id         int64  `json:"id"`
+	Biz        string `json:"biz"`
+	BizId      int64  `json:"bizId"`
+	Uid        int64  `json:"uid"`
+	Content    string `json:"content"`
+	RootId     int64  `json:"rootId"`
+	ParentId   int64  `json:"parentId"`
+	ReplyToUid int64  `json:"replyToUid"`
+	Status     uint8  `json:"status"`
+	Pinned     bool   `json:"pinned"`
+	LikeCnt    int    `json:"likeCnt"`
+	ReplyCnt   int    `json:"replyCnt"`
+	Liked      bool   `json:"liked"`
+	Ctime      int64  `json:"ctime"`
+	Utime      int64  `json:"utime"`
+}
+
+func newComment(c domain.Comment) Comment {
+	return Comment{
+		Id:         c.ID,
+		Biz:        c.Biz,
+		BizId:      c.BizID,
+		Uid:        c.Uid,
+		Content:    c.Content,
+		RootId:     c.RootID,
+		ParentId:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Status:     c.Status.ToUint8(),
+		Pinned:     c.Pinned,
+		LikeCnt:    c.LikeCnt,
+		ReplyCnt:   c.ReplyCnt,
+		Liked:      c.Liked,
+		Ctime:      c.Ctime,
+		Utime:      c.Utime,
+	}
+}
+
+type CommentList struct {
+	// Pinned 置顶的评论，只有第一页会返回
+	Pinned []Comment `json:"pinned,omitempty"`
+	List   []Comment `json:"list"`
+	// Total 只有管理后台会返回
+	Total int64 `json:"total,omitempty"`
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wireinject
+
+package comment
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/comment/internal/event"
+	"github.com/ecodeclub/webook/internal/comment/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/comment/internal/repository"
+	"github.com/ecodeclub/webook/internal/comment/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/comment/internal/service"
+	"github.com/ecodeclub/webook/internal/comment/internal/web"
+	"github.com/ego-component/egorm"
+	"github.com/google/wire"
+)
+
+func InitModule(db *egorm.Component, q mq.MQ, sp session.Provider) *Module {
+	wire.Build(
+		InitTablesOnce,
+		initIntrProducer,
+		repository.NewCommentRepository,
+		service.NewService,
+		web.NewHandler,
+		web.NewAdminHandler,
+		service.NewExportSource,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		wire.Struct(new(Module), "*"),
+	)
+	return new(Module)
+}
+
+var once = &sync.Once{}
+
+func InitTablesOnce(db *egorm.Component) dao.CommentDAO {
+	once.Do(func() {
+		_ = dao.InitTables(db)
+	})
+	return dao.NewCommentDAO(db)
+}
+
+func initIntrProducer(q mq.MQ) event.InteractiveEventProducer {
+	producer, err := event.NewInteractiveEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
+
+func initUserMergeConsumer(svc service.Service, q mq.MQ) *consumer.UserMergeConsumer {
+	c, err := consumer.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
@@ -29,4 +29,21 @@ type CreditLog struct {
 	Biz          string
 	BizId        int64
 	Desc         string
+	// ExpireAt 只有增加积分的时候才有用，为 0 的时候按照来源的默认有效期计算
+	ExpireAt int64
+}
+
+// CreditLot 积分批次，每一次增加积分都对应一个批次。
+// 引入批次之前就有的积分没有批次，永不过期
+type CreditLot struct {
+	ID  int64
+	Uid int64
+	// Biz 和 BizId 是积分的来源，和增加积分的流水一致
+	Biz       string
+	BizId     int64
+	Amount    uint64
+	Remaining uint64
+	// ExpireAt 为 0 表示永不过期
+	ExpireAt int64
+	Ctime    int64
 }
@@ -0,0 +1,79 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+type LedgerReportStatus uint8
+
+const (
+	LedgerReportStatusUnknown LedgerReportStatus = iota
+	LedgerReportStatusRunning
+	LedgerReportStatusDone
+	LedgerReportStatusFailed
+)
+
+// LedgerReport 一次对账的结果
+type LedgerReport struct {
+	ID     int64
+	Status LedgerReportStatus
+	// Checked 核对了多少个积分账户
+	Checked int64
+	// Mismatched 其中有多少个账户的余额和流水对不上
+	Mismatched int64
+	Ctime      int64
+	Utime      int64
+}
+
+type LedgerMismatchStatus uint8
+
+const (
+	LedgerMismatchStatusUnknown LedgerMismatchStatus = iota
+	// LedgerMismatchStatusPending 等待管理员处理
+	LedgerMismatchStatusPending
+	// LedgerMismatchStatusCompensated 已经写入补偿流水
+	LedgerMismatchStatusCompensated
+	// LedgerMismatchStatusIgnored 管理员确认不需要处理
+	LedgerMismatchStatusIgnored
+)
+
+// LedgerMismatch 积分账户的余额和根据流水重新算出来的余额不一致
+type LedgerMismatch struct {
+	ID       int64
+	ReportID int64
+	Uid      int64
+	// TotalAmount 和 LockedTotalAmount 是对账时账户上记录的余额
+	TotalAmount       uint64
+	LockedTotalAmount uint64
+	// ExpectedTotalAmount 和 ExpectedLockedTotalAmount 是根据流水算出来的余额
+	ExpectedTotalAmount       int64
+	ExpectedLockedTotalAmount int64
+	// LogIDs 流水中记录的变动后余额和前面的流水接不上的那些流水，
+	// 为空说明流水本身是连贯的，是账户余额被绕过流水修改了
+	LogIDs []int64
+	Status LedgerMismatchStatus
+	// Operator 处理这条记录的管理员
+	Operator int64
+	Ctime    int64
+	Utime    int64
+}
+
+// Diff 账户可用余额比流水多出来的部分，补偿流水的变动数量就是它
+func (m LedgerMismatch) Diff() int64 {
+	return int64(m.TotalAmount) - m.ExpectedTotalAmount
+}
+
+// LockedMatched 预扣的积分对得上，预扣对不上的时候没办法用一条补偿流水修正
+func (m LedgerMismatch) LockedMatched() bool {
+	return int64(m.LockedTotalAmount) == m.ExpectedLockedTotalAmount
+}
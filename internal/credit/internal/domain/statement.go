@@ -0,0 +1,77 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Direction 积分变动的方向
+type Direction uint8
+
+const (
+	// DirectionAll 不限方向
+	DirectionAll Direction = iota
+	// DirectionIncrease 增加积分
+	DirectionIncrease
+	// DirectionDecrease 扣减积分，包含预扣和过期
+	DirectionDecrease
+)
+
+func (d Direction) Valid() bool {
+	return d <= DirectionDecrease
+}
+
+type StatementEntryStatus uint8
+
+const (
+	StatementEntryStatusUnknown StatementEntryStatus = iota
+	// StatementEntryStatusDone 已经生效
+	StatementEntryStatusDone
+	// StatementEntryStatusLocked 预扣中，还没有确认
+	StatementEntryStatusLocked
+)
+
+// StatementQuery 积分明细的查询条件，按照流水 ID 倒序翻页
+type StatementQuery struct {
+	Uid int64
+	// Cursor 上一页最后一条流水的 ID，为 0 的时候从最新的开始
+	Cursor int64
+	Limit  int
+	// Bizs 只看这些来源的流水，为空的时候不限
+	Bizs      []string
+	Direction Direction
+	// StartTime 和 EndTime 是流水的创建时间，左闭右开，为 0 的时候不限
+	StartTime int64
+	EndTime   int64
+}
+
+// StatementEntry 积分明细中的一条，也就是一条没有失效的积分流水
+type StatementEntry struct {
+	ID           int64
+	Biz          string
+	BizId        int64
+	Key          string
+	Desc         string
+	ChangeAmount int64
+	// Balance 变动之后的可用积分
+	Balance uint64
+	Status  StatementEntryStatus
+	Ctime   int64
+}
+
+// MonthlySummary 一个自然月内已经生效的积分变动汇总
+type MonthlySummary struct {
+	// Month 格式为 2006-01
+	Month    string
+	Increase uint64
+	Decrease uint64
+}
@@ -15,6 +15,11 @@
 package errs
 
 var (
+	InvalidStatementQuery = ErrorCode{Code: 410001, Msg: "积分明细查询条件非法"}
+	LedgerMismatchHandled = ErrorCode{Code: 410002, Msg: "对账差异已经处理过了"}
+	LedgerChanged         = ErrorCode{Code: 410003, Msg: "积分账户在对账之后发生了变化，请重新对账"}
+	LockedCreditsMismatch = ErrorCode{Code: 410004, Msg: "预扣积分对不上，不能自动补偿"}
+
 	SystemError = ErrorCode{Code: 510001, Msg: "系统错误"}
 )
 
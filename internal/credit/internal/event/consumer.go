@@ -78,6 +78,7 @@ func (c *CreditIncreaseConsumer) Consume(ctx context.Context) error {
 				Biz:          evt.Biz,
 				BizId:        evt.BizId,
 				Desc:         evt.Action,
+				ExpireAt:     evt.ExpireAt,
 			},
 		},
 	})
@@ -14,7 +14,12 @@
 
 package event
 
-const creditIncreaseEvents = "credit_increase_events"
+const (
+	creditIncreaseEvents   = "credit_increase_events"
+	userMergeEvents        = "user_merge_events"
+	userDeletionEvents     = "user_deletion_events"
+	userDeletionDoneEvents = "user_deletion_done_events"
+)
 
 type CreditIncreaseEvent struct {
 	Key    string `json:"key"`
@@ -23,4 +28,24 @@ type CreditIncreaseEvent struct {
 	Biz    string `json:"biz"`    // user        order
 	BizId  int64  `json:"biz_id"` // user_id=B   order_id
 	Action string `json:"action"` // 邀请注册     购买商品
+	// ExpireAt 积分的过期时间，不传的时候按照 Biz 对应的默认有效期计算
+	ExpireAt int64 `json:"expire_at,omitempty"`
+}
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
 }
@@ -0,0 +1,89 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// UserDeletionConsumer 注销账号之后，删除积分账户和积分流水
+type UserDeletionConsumer struct {
+	svc      service.Service
+	consumer mq.Consumer
+	producer *mqx.GeneralProducer[UserDeletionDoneEvent]
+	logger   *elog.Component
+}
+
+func NewUserDeletionConsumer(svc service.Service, q mq.MQ) (*UserDeletionConsumer, error) {
+	groupID := "credit"
+	consumer, err := q.Consumer(userDeletionEvents, groupID)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := mqx.NewGeneralProducer[UserDeletionDoneEvent](q, userDeletionDoneEvents)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDeletionConsumer{
+		svc:      svc,
+		consumer: consumer,
+		producer: producer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserDeletionConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费注销账号事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserDeletionConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt UserDeletionEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.svc.DeleteUserData(ctx, evt.Uid)
+	if err != nil {
+		return fmt.Errorf("清理注销账号的数据失败 %w, uid %d", err, evt.Uid)
+	}
+	// 告诉隐私模块这边清理完了，发送失败的话隐私模块会重新通知
+	err = c.producer.Produce(ctx, UserDeletionDoneEvent{Uid: evt.Uid, Module: "credit"})
+	if err != nil {
+		return fmt.Errorf("发送注销账号确认事件失败 %w, uid %d", err, evt.Uid)
+	}
+	return nil
+}
+
+func (c *UserDeletionConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
@@ -24,14 +24,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/credit/internal/domain"
 	"github.com/ecodeclub/webook/internal/credit/internal/event"
 	"github.com/ecodeclub/webook/internal/credit/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/credit/internal/job"
+	"github.com/ecodeclub/webook/internal/credit/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/credit/internal/service"
 	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
 	"github.com/ecodeclub/webook/internal/test"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 	"github.com/ego-component/egorm"
@@ -41,6 +46,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 )
 
 const testUID = int64(230919)
@@ -51,10 +57,12 @@ func TestCreditModule(t *testing.T) {
 
 type ModuleTestSuite struct {
 	suite.Suite
-	server *egin.Component
-	db     *egorm.Component
-	mq     mq.MQ
-	svc    service.Service
+	server      *egin.Component
+	adminServer *egin.Component
+	db          *egorm.Component
+	mq          mq.MQ
+	svc         service.Service
+	ledgerSvc   service.LedgerService
 }
 
 func (s *ModuleTestSuite) SetupTest() {
@@ -70,7 +78,25 @@ func (s *ModuleTestSuite) SetupTest() {
 	})
 	handler.PrivateRoutes(server.Engine)
 
+	adminServer := egin.Load("server").Build()
+	adminServer.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: 1,
+		}))
+	})
+	s.ledgerSvc = startup.InitLedgerService()
+	ctrl := gomock.NewController(s.T())
+	rbacSvc := permissionmocks.NewMockRBACService(ctrl)
+	rbacSvc.EXPECT().Check(gomock.Any(), int64(1), "credit", "ledger").
+		Return(true, nil).AnyTimes()
+	rbacSvc.EXPECT().Check(gomock.Any(), int64(1), "credit", "statement").
+		Return(true, nil).AnyTimes()
+	startup.InitAdminHandler(s.svc, &permission.Module{RBACSvc: rbacSvc}).PrivateRoutes(adminServer.Engine)
+	startup.InitLedgerHandler(s.ledgerSvc, &permission.Module{RBACSvc: rbacSvc}).
+		PrivateRoutes(adminServer.Engine)
+
 	s.server = server
+	s.adminServer = adminServer
 	s.mq = testioc.InitMQ()
 	s.db = testioc.InitDB()
 
@@ -81,6 +107,14 @@ func (s *ModuleTestSuite) TearDownSuite() {
 	s.NoError(err)
 	err = s.db.Exec("DROP TABLE `credit_logs`").Error
 	s.NoError(err)
+	err = s.db.Exec("DROP TABLE `credit_lots`").Error
+	s.NoError(err)
+	err = s.db.Exec("DROP TABLE `credit_lot_deductions`").Error
+	s.NoError(err)
+	err = s.db.Exec("DROP TABLE `credit_ledger_reports`").Error
+	s.NoError(err)
+	err = s.db.Exec("DROP TABLE `credit_ledger_mismatches`").Error
+	s.NoError(err)
 }
 
 func (s *ModuleTestSuite) TearDownTest() {
@@ -88,6 +122,14 @@ func (s *ModuleTestSuite) TearDownTest() {
 	s.NoError(err)
 	err = s.db.Exec("TRUNCATE TABLE `credit_logs`").Error
 	s.NoError(err)
+	err = s.db.Exec("TRUNCATE TABLE `credit_lots`").Error
+	s.NoError(err)
+	err = s.db.Exec("TRUNCATE TABLE `credit_lot_deductions`").Error
+	s.NoError(err)
+	err = s.db.Exec("TRUNCATE TABLE `credit_ledger_reports`").Error
+	s.NoError(err)
+	err = s.db.Exec("TRUNCATE TABLE `credit_ledger_mismatches`").Error
+	s.NoError(err)
 }
 
 func (s *ModuleTestSuite) TestConsumer_ConsumeCreditIncreaseEvent() {
@@ -342,6 +384,148 @@ func (s *ModuleTestSuite) newCreditIncreaseEventMessage(t *testing.T, evt event.
 	return &mq.Message{Value: marshal}
 }
 
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserMergeEvent() {
+	t := s.T()
+	producer, err := s.mq.Producer("user_merge_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserMergeConsumer(s.svc, s.mq)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, consumer.Stop(context.Background()))
+	})
+
+	sourceUid, targetUid := int64(6101), int64(6102)
+	add := func(uid int64, key string, amount int64) {
+		err := s.svc.AddCredits(context.Background(), domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{Key: key, ChangeAmount: amount, Biz: "user", BizId: 1, Desc: "注册"},
+			},
+		})
+		require.NoError(t, err)
+	}
+	add(sourceUid, "key-6101", 300)
+	add(targetUid, "key-6102", 100)
+	// 锁定的积分留在源账号上
+	tid, err := s.svc.TryDeductCredits(context.Background(), domain.Credit{
+		Uid: sourceUid,
+		Logs: []domain.CreditLog{
+			{Key: "key-6101-lock", ChangeAmount: 50, Biz: "order", BizId: 2, Desc: "购买商品"},
+		},
+	})
+	require.NoError(t, err)
+
+	msg := s.newUserMergeEventMessage(t, event.UserMergeEvent{Id: 11, SourceUid: sourceUid, TargetUid: targetUid})
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	// 模拟重试
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	source, err := s.svc.GetCreditsByUID(context.Background(), sourceUid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), source.TotalAmount)
+	require.Equal(t, uint64(50), source.LockedTotalAmount)
+	s.requireCreditLogs(t, []domain.CreditLog{
+		{Key: "key-6101", Uid: sourceUid, ChangeAmount: 300, Biz: "user", BizId: 1, Desc: "注册"},
+		{Key: "key-6101-lock", Uid: sourceUid, ChangeAmount: -50, Biz: "order", BizId: 2, Desc: "购买商品"},
+		{Key: "user-merge-11-out", Uid: sourceUid, ChangeAmount: -250, Biz: "user", BizId: 11, Desc: "合并到其它账号"},
+	}, source.Logs)
+
+	target, err := s.svc.GetCreditsByUID(context.Background(), targetUid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(350), target.TotalAmount)
+	s.requireCreditLogs(t, []domain.CreditLog{
+		{Key: "key-6102", Uid: targetUid, ChangeAmount: 100, Biz: "user", BizId: 1, Desc: "注册"},
+		{Key: "user-merge-11-in", Uid: targetUid, ChangeAmount: 250, Biz: "user", BizId: 11, Desc: "合并其它账号"},
+	}, target.Logs)
+
+	// 合并之后取消预扣，退回来的积分转到目标账号上
+	require.NoError(t, s.svc.CancelDeductCredits(context.Background(), sourceUid, tid))
+	source, err = s.svc.GetCreditsByUID(context.Background(), sourceUid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), source.TotalAmount)
+	require.Equal(t, uint64(0), source.LockedTotalAmount)
+	lockKey := fmt.Sprintf("user-merge-11-lock-%d", tid)
+	s.requireCreditLogs(t, []domain.CreditLog{
+		{Key: "key-6101", Uid: sourceUid, ChangeAmount: 300, Biz: "user", BizId: 1, Desc: "注册"},
+		{Key: "user-merge-11-out", Uid: sourceUid, ChangeAmount: -250, Biz: "user", BizId: 11, Desc: "合并到其它账号"},
+		{Key: lockKey + "-out", Uid: sourceUid, ChangeAmount: -50, Biz: "user", BizId: 11, Desc: "合并到其它账号"},
+	}, source.Logs)
+
+	target, err = s.svc.GetCreditsByUID(context.Background(), targetUid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(400), target.TotalAmount)
+	s.requireCreditLogs(t, []domain.CreditLog{
+		{Key: "key-6102", Uid: targetUid, ChangeAmount: 100, Biz: "user", BizId: 1, Desc: "注册"},
+		{Key: "user-merge-11-in", Uid: targetUid, ChangeAmount: 250, Biz: "user", BizId: 11, Desc: "合并其它账号"},
+		{Key: lockKey + "-in", Uid: targetUid, ChangeAmount: 50, Biz: "user", BizId: 11, Desc: "合并其它账号"},
+	}, target.Logs)
+}
+
+func (s *ModuleTestSuite) newUserMergeEventMessage(t *testing.T, evt event.UserMergeEvent) *mq.Message {
+	t.Helper()
+	marshal, err := json.Marshal(evt)
+	require.NoError(t, err)
+	return &mq.Message{Value: marshal}
+}
+
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserDeletionEvent() {
+	t := s.T()
+	producer, err := s.mq.Producer("user_deletion_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserDeletionConsumer(s.svc, s.mq)
+	require.NoError(t, err)
+	doneConsumer, err := s.mq.Consumer("user_deletion_done_events", "credit_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, consumer.Stop(context.Background()))
+		require.NoError(t, doneConsumer.Close())
+	})
+
+	uid, otherUid := int64(6201), int64(6202)
+	for _, u := range []int64{uid, otherUid} {
+		err = s.svc.AddCredits(context.Background(), domain.Credit{
+			Uid: u,
+			Logs: []domain.CreditLog{
+				{Key: fmt.Sprintf("key-%d", u), ChangeAmount: 100, Biz: "user", BizId: 1, Desc: "注册"},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	marshal, err := json.Marshal(event.UserDeletionEvent{Uid: uid})
+	require.NoError(t, err)
+	_, err = producer.Produce(context.Background(), &mq.Message{Value: marshal})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	var cnt int64
+	err = s.db.Table("credit_logs").Where("uid = ?", uid).Count(&cnt).Error
+	require.NoError(t, err)
+	assert.Zero(t, cnt)
+	c, err := s.svc.GetCreditsByUID(context.Background(), uid)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Credit{Uid: uid}, c)
+
+	// 其它用户不受影响
+	other, err := s.svc.GetCreditsByUID(context.Background(), otherUid)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), other.TotalAmount)
+
+	// 清理完了要告诉隐私模块
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := doneConsumer.Consume(ctx)
+	require.NoError(t, err)
+	var done event.UserDeletionDoneEvent
+	err = json.Unmarshal(msg.Value, &done)
+	require.NoError(t, err)
+	assert.Equal(t, event.UserDeletionDoneEvent{Uid: uid, Module: "credit"}, done)
+}
+
 func (s *ModuleTestSuite) TestService_AddCredits_Concurrent() {
 	t := s.T()
 
@@ -1909,3 +2093,544 @@ func (s *ModuleTestSuite) TestJob_CloseExpiredLockedCreditLogs() {
 		})
 	}
 }
+
+func (s *ModuleTestSuite) TestService_Lots_DeductInFIFO() {
+	t := s.T()
+	uid := int64(300100)
+	now := time.Now()
+
+	addCredits := func(key string, amount int64, bizId int64, expireAt int64) {
+		err := s.svc.AddCredits(context.Background(), domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{
+					Key:          key,
+					ChangeAmount: amount,
+					Biz:          "order",
+					BizId:        bizId,
+					Desc:         "购买积分",
+					ExpireAt:     expireAt,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+	// 永不过期的最后扣，越早过期的越先扣
+	addCredits("key-lot-1", 100, 1, 0)
+	addCredits("key-lot-2", 50, 2, now.Add(48*time.Hour).UnixMilli())
+	addCredits("key-lot-3", 30, 3, now.Add(24*time.Hour).UnixMilli())
+
+	tryDeduct := func(key string, amount int64) int64 {
+		id, err := s.svc.TryDeductCredits(context.Background(), domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{
+					Key:          key,
+					ChangeAmount: amount,
+					Biz:          "order",
+					BizId:        9,
+					Desc:         "购买商品",
+				},
+			},
+		})
+		require.NoError(t, err)
+		return id
+	}
+	requireRemaining := func(expected map[int64]uint64) {
+		lots, err := s.svc.Lots(context.Background(), uid)
+		require.NoError(t, err)
+		actual := make(map[int64]uint64, len(lots))
+		for _, lot := range lots {
+			actual[lot.BizId] = lot.Remaining
+		}
+		require.Equal(t, expected, actual)
+	}
+
+	// 取消预扣之后恢复到原本的批次
+	tid := tryDeduct("key-lot-try-1", 60)
+	requireRemaining(map[int64]uint64{1: 100, 2: 20})
+	require.NoError(t, s.svc.CancelDeductCredits(context.Background(), uid, tid))
+	requireRemaining(map[int64]uint64{1: 100, 2: 50, 3: 30})
+
+	// 确认之后批次不再变化
+	tid = tryDeduct("key-lot-try-2", 100)
+	require.NoError(t, s.svc.ConfirmDeductCredits(context.Background(), uid, tid))
+	requireRemaining(map[int64]uint64{1: 80})
+
+	c, err := s.svc.GetCreditsByUID(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(80), c.TotalAmount)
+	require.Equal(t, uint64(0), c.LockedTotalAmount)
+}
+
+func (s *ModuleTestSuite) TestService_Lots_LegacyCredits() {
+	t := s.T()
+	uid := int64(300200)
+	now := time.Now().UnixMilli()
+
+	// 引入批次之前的余额，没有对应的批次
+	err := s.db.Create(&dao.Credit{
+		Uid:          uid,
+		TotalCredits: 100,
+		Version:      1,
+		Ctime:        now,
+		Utime:        now,
+	}).Error
+	require.NoError(t, err)
+
+	err = s.svc.AddCredits(context.Background(), domain.Credit{
+		Uid: uid,
+		Logs: []domain.CreditLog{
+			{
+				Key:          "key-legacy-1",
+				ChangeAmount: 50,
+				Biz:          "order",
+				BizId:        1,
+				Desc:         "购买积分",
+				ExpireAt:     time.Now().Add(time.Hour).UnixMilli(),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// 先扣完批次，不够的部分从旧余额里扣
+	tid, err := s.svc.TryDeductCredits(context.Background(), domain.Credit{
+		Uid: uid,
+		Logs: []domain.CreditLog{
+			{
+				Key:          "key-legacy-try",
+				ChangeAmount: 120,
+				Biz:          "order",
+				BizId:        9,
+				Desc:         "购买商品",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.svc.ConfirmDeductCredits(context.Background(), uid, tid))
+
+	lots, err := s.svc.Lots(context.Background(), uid)
+	require.NoError(t, err)
+	require.Empty(t, lots)
+
+	c, err := s.svc.GetCreditsByUID(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), c.TotalAmount)
+}
+
+func (s *ModuleTestSuite) TestJob_ExpireCreditLots() {
+	t := s.T()
+	total := 15
+	expireAt := time.Now().Add(-time.Minute).UnixMilli()
+
+	for idx := 0; idx < total; idx++ {
+		uid := int64(300300 + idx)
+		for _, lc := range []struct {
+			bizId    int64
+			expireAt int64
+		}{
+			{bizId: 1, expireAt: expireAt},
+			{bizId: 2, expireAt: 0},
+		} {
+			err := s.svc.AddCredits(context.Background(), domain.Credit{
+				Uid: uid,
+				Logs: []domain.CreditLog{
+					{
+						Key:          fmt.Sprintf("key-expire-%d-%d", uid, lc.bizId),
+						ChangeAmount: 100,
+						Biz:          "order",
+						BizId:        lc.bizId,
+						Desc:         "购买积分",
+						ExpireAt:     lc.expireAt,
+					},
+				},
+			})
+			require.NoError(t, err)
+		}
+		// 过期批次先被扣掉一部分
+		_, err := s.svc.TryDeductCredits(context.Background(), domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{
+					Key:          fmt.Sprintf("key-expire-try-%d", uid),
+					ChangeAmount: 30,
+					Biz:          "order",
+					BizId:        9,
+					Desc:         "购买商品",
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	j := job.NewExpireCreditLotsJob(s.svc, 10)
+	require.NoError(t, j.Run(context.Background()))
+	// 重复执行不会重复作废
+	require.NoError(t, j.Run(context.Background()))
+
+	for idx := 0; idx < total; idx++ {
+		uid := int64(300300 + idx)
+		c, err := s.svc.GetCreditsByUID(context.Background(), uid)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), c.TotalAmount)
+		require.Equal(t, uint64(30), c.LockedTotalAmount)
+
+		var expireLogs []domain.CreditLog
+		for _, l := range c.Logs {
+			if l.Biz == "credit" {
+				expireLogs = append(expireLogs, l)
+			}
+		}
+		require.Len(t, expireLogs, 1)
+		require.Equal(t, int64(-70), expireLogs[0].ChangeAmount)
+
+		lots, err := s.svc.Lots(context.Background(), uid)
+		require.NoError(t, err)
+		require.Len(t, lots, 1)
+		require.Equal(t, int64(2), lots[0].BizId)
+		require.Equal(t, uint64(100), lots[0].Remaining)
+	}
+}
+
+func (s *ModuleTestSuite) TestHandler_Lots() {
+	t := s.T()
+	now := time.Now()
+	expiring := now.Add(24 * time.Hour).UnixMilli()
+	later := now.Add(90 * 24 * time.Hour).UnixMilli()
+
+	for idx, expireAt := range []int64{later, expiring, 0} {
+		err := s.svc.AddCredits(context.Background(), domain.Credit{
+			Uid: testUID,
+			Logs: []domain.CreditLog{
+				{
+					Key:          fmt.Sprintf("key-lots-%d", idx),
+					ChangeAmount: 100,
+					Biz:          "order",
+					BizId:        int64(idx),
+					Desc:         "购买积分",
+					ExpireAt:     expireAt,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost,
+		"/credit/lots", nil)
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[web.CreditLots]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+
+	res := recorder.MustScan().Data
+	require.Equal(t, uint64(100), res.ExpiringAmount)
+	require.NotZero(t, res.ExpiringBefore)
+	require.Len(t, res.Lots, 3)
+	for i := range res.Lots {
+		require.NotZero(t, res.Lots[i].Id)
+		require.NotZero(t, res.Lots[i].Ctime)
+		res.Lots[i].Id, res.Lots[i].Ctime = 0, 0
+	}
+	require.Equal(t, []web.CreditLot{
+		{Biz: "order", BizId: 1, Amount: 100, Remaining: 100, ExpireAt: expiring},
+		{Biz: "order", BizId: 0, Amount: 100, Remaining: 100, ExpireAt: later},
+		{Biz: "order", BizId: 2, Amount: 100, Remaining: 100},
+	}, res.Lots)
+}
+
+func (s *ModuleTestSuite) TestHandler_Statement() {
+	t := s.T()
+
+	jan := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local).UnixMilli()
+	feb := time.Date(2024, 2, 15, 12, 0, 0, 0, time.Local).UnixMilli()
+	logs := []dao.CreditLog{
+		{Key: "key-st-1", Biz: "user", BizId: 1, Desc: "邀请注册", CreditChange: 100, CreditBalance: 100, Status: dao.CreditLogStatusActive, Ctime: jan},
+		{Key: "SN-st-1", Biz: "order", BizId: 11, Desc: "购买面试", CreditChange: -30, CreditBalance: 70, Status: dao.CreditLogStatusActive, Ctime: jan},
+		{Key: "key-st-2", Biz: "ai-llm", BizId: 21, Desc: "ai-llm服务", CreditChange: -20, CreditBalance: 50, Status: dao.CreditLogStatusActive, Ctime: feb},
+		{Key: "SN-st-2", Biz: "order", BizId: 12, Desc: "购买项目", CreditChange: -40, CreditBalance: 10, Status: dao.CreditLogStatusInactive, Ctime: feb},
+		{Key: "SN-st-3", Biz: "order", BizId: 13, Desc: "购买项目", CreditChange: -10, CreditBalance: 40, Status: dao.CreditLogStatusLocked, Ctime: feb},
+	}
+	for i := range logs {
+		logs[i].Uid = testUID
+		logs[i].Utime = logs[i].Ctime
+	}
+	require.NoError(t, s.db.Create(&logs).Error)
+	// 其他用户的流水
+	other := dao.CreditLog{
+		Key: "key-st-other", Uid: testUID + 1, Biz: "user", BizId: 1, Desc: "邀请注册",
+		CreditChange: 100, CreditBalance: 100, Status: dao.CreditLogStatusActive, Ctime: feb, Utime: feb,
+	}
+	require.NoError(t, s.db.Create(&other).Error)
+
+	entry := func(l dao.CreditLog) web.StatementEntry {
+		res := web.StatementEntry{
+			Id:           l.Id,
+			Biz:          l.Biz,
+			BizId:        l.BizId,
+			Desc:         l.Desc,
+			ChangeAmount: l.CreditChange,
+			Balance:      l.CreditBalance,
+			Status:       l.Status,
+			Ctime:        l.Ctime,
+		}
+		switch l.Biz {
+		case "order":
+			res.Link = &web.Link{Type: "order", Id: l.BizId, SN: l.Key}
+		case "ai-llm":
+			res.Link = &web.Link{Type: "ai", Id: l.BizId}
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name     string
+		server   *egin.Component
+		path     string
+		req      any
+		wantResp test.Result[web.Statement]
+	}{
+		{
+			name:   "第一页",
+			server: s.server,
+			path:   "/credit/statement",
+			req:    web.StatementReq{Limit: 2},
+			wantResp: test.Result[web.Statement]{
+				Data: web.Statement{
+					Entries:    []web.StatementEntry{entry(logs[4]), entry(logs[2])},
+					NextCursor: logs[2].Id,
+					HasMore:    true,
+				},
+			},
+		},
+		{
+			name:   "第二页_跳过失效流水",
+			server: s.server,
+			path:   "/credit/statement",
+			req:    web.StatementReq{Limit: 2, Cursor: logs[2].Id},
+			wantResp: test.Result[web.Statement]{
+				Data: web.Statement{
+					Entries:    []web.StatementEntry{entry(logs[1]), entry(logs[0])},
+					NextCursor: logs[0].Id,
+					HasMore:    true,
+				},
+			},
+		},
+		{
+			name:   "按来源和方向过滤",
+			server: s.server,
+			path:   "/credit/statement",
+			req:    web.StatementReq{Limit: 10, Bizs: []string{"order", "user"}, Direction: 2},
+			wantResp: test.Result[web.Statement]{
+				Data: web.Statement{
+					Entries:    []web.StatementEntry{entry(logs[4]), entry(logs[1])},
+					NextCursor: logs[1].Id,
+				},
+			},
+		},
+		{
+			name:   "按时间过滤",
+			server: s.server,
+			path:   "/credit/statement",
+			req:    web.StatementReq{Limit: 10, StartTime: jan, EndTime: feb},
+			wantResp: test.Result[web.Statement]{
+				Data: web.Statement{
+					Entries:    []web.StatementEntry{entry(logs[1]), entry(logs[0])},
+					NextCursor: logs[0].Id,
+				},
+			},
+		},
+		{
+			name:   "管理员查看任意用户",
+			server: s.adminServer,
+			path:   "/credit/statement",
+			req:    web.AdminStatementReq{Uid: testUID + 1, StatementReq: web.StatementReq{Limit: 10}},
+			wantResp: test.Result[web.Statement]{
+				Data: web.Statement{
+					Entries:    []web.StatementEntry{entry(other)},
+					NextCursor: other.Id,
+				},
+			},
+		},
+		{
+			name:   "每页条数非法",
+			server: s.server,
+			path:   "/credit/statement",
+			req:    web.StatementReq{Limit: 1000},
+			wantResp: test.Result[web.Statement]{
+				Code: 410001,
+				Msg:  "积分明细查询条件非法",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				tc.path, iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Statement]()
+			tc.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			require.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
+
+	t.Run("按月汇总", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost,
+			"/credit/statement/summary", iox.NewJSONReader(web.StatementReq{}))
+		req.Header.Set("content-type", "application/json")
+		require.NoError(t, err)
+		recorder := test.NewJSONResponseRecorder[[]web.MonthlySummary]()
+		s.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		// 预扣中和已经失效的流水不计入汇总
+		require.Equal(t, []web.MonthlySummary{
+			{Month: "2024-02", Decrease: 20},
+			{Month: "2024-01", Increase: 100, Decrease: 30},
+		}, recorder.MustScan().Data)
+	})
+}
+
+func (s *ModuleTestSuite) TestJob_VerifyCreditLedger() {
+	t := s.T()
+	ctx := context.Background()
+
+	addCredits := func(uid int64, key string, amount int64) {
+		err := s.svc.AddCredits(ctx, domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{Key: key, ChangeAmount: amount, Biz: "user", BizId: uid, Desc: "邀请注册"},
+			},
+		})
+		require.NoError(t, err)
+	}
+	tryDeduct := func(uid int64, key string, amount int64) int64 {
+		id, err := s.svc.TryDeductCredits(ctx, domain.Credit{
+			Uid: uid,
+			Logs: []domain.CreditLog{
+				{Key: key, ChangeAmount: amount, Biz: "order", BizId: uid, Desc: "购买商品"},
+			},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	// 正常的账户，预扣取消之后又有新的流水，不应该被认为接不上
+	for idx := 0; idx < 5; idx++ {
+		uid := int64(300400 + idx)
+		addCredits(uid, fmt.Sprintf("key-ledger-%d-1", uid), 100)
+		tid := tryDeduct(uid, fmt.Sprintf("key-ledger-%d-2", uid), 30)
+		tryDeduct(uid, fmt.Sprintf("key-ledger-%d-3", uid), 20)
+		require.NoError(t, s.svc.CancelDeductCredits(ctx, uid, tid))
+		addCredits(uid, fmt.Sprintf("key-ledger-%d-4", uid), 10)
+	}
+
+	// 绕过流水直接改了余额
+	balanceUid := int64(300500)
+	addCredits(balanceUid, "key-ledger-balance", 100)
+	err := s.db.Model(&dao.Credit{}).Where("uid = ?", balanceUid).
+		Update("total_credits", 150).Error
+	require.NoError(t, err)
+
+	// 流水记错了
+	logUid := int64(300501)
+	addCredits(logUid, "key-ledger-log-1", 100)
+	addCredits(logUid, "key-ledger-log-2", 50)
+	var badLog dao.CreditLog
+	require.NoError(t, s.db.Where("`key` = ?", "key-ledger-log-2").First(&badLog).Error)
+	err = s.db.Model(&dao.CreditLog{}).Where("id = ?", badLog.Id).
+		Update("credit_change", 40).Error
+	require.NoError(t, err)
+
+	// 预扣对不上
+	lockedUid := int64(300502)
+	addCredits(lockedUid, "key-ledger-locked-1", 100)
+	tryDeduct(lockedUid, "key-ledger-locked-2", 30)
+	err = s.db.Model(&dao.Credit{}).Where("uid = ?", lockedUid).
+		Update("locked_total_credits", 0).Error
+	require.NoError(t, err)
+
+	// 批次比账户少，需要翻页
+	require.NoError(t, job.NewVerifyCreditLedgerJob(s.ledgerSvc, 3).Run(ctx))
+
+	reports, total, err := s.ledgerSvc.Reports(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, domain.LedgerReportStatusDone, reports[0].Status)
+	require.Equal(t, int64(8), reports[0].Checked)
+	require.Equal(t, int64(3), reports[0].Mismatched)
+
+	req, err := http.NewRequest(http.MethodPost,
+		"/credit/ledger/mismatches", iox.NewJSONReader(web.MismatchListReq{ReportId: reports[0].ID, Limit: 10}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[web.LedgerMismatchList]()
+	s.adminServer.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	list := recorder.MustScan().Data
+	require.Equal(t, int64(3), list.Total)
+	mismatches := make(map[int64]web.LedgerMismatch, len(list.Mismatches))
+	for _, m := range list.Mismatches {
+		require.NotZero(t, m.Id)
+		require.NotZero(t, m.Ctime)
+		require.Equal(t, uint8(domain.LedgerMismatchStatusPending), m.Status)
+		mismatches[m.Uid] = m
+	}
+	require.Equal(t, uint64(150), mismatches[balanceUid].TotalAmount)
+	require.Equal(t, int64(100), mismatches[balanceUid].ExpectedTotalAmount)
+	require.Empty(t, mismatches[balanceUid].LogIds)
+	require.Equal(t, uint64(150), mismatches[logUid].TotalAmount)
+	require.Equal(t, int64(140), mismatches[logUid].ExpectedTotalAmount)
+	require.Equal(t, []int64{badLog.Id}, mismatches[logUid].LogIds)
+	require.Equal(t, uint64(0), mismatches[lockedUid].LockedTotalAmount)
+	require.Equal(t, int64(30), mismatches[lockedUid].ExpectedLockedTotalAmount)
+
+	compensate := func(path string, id int64) test.Result[any] {
+		req, err := http.NewRequest(http.MethodPost, path, iox.NewJSONReader(web.MismatchIDReq{Id: id}))
+		req.Header.Set("content-type", "application/json")
+		require.NoError(t, err)
+		recorder := test.NewJSONResponseRecorder[any]()
+		s.adminServer.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan()
+	}
+
+	// 补偿之后流水和余额对上，余额不变
+	res := compensate("/credit/ledger/mismatches/compensate", mismatches[balanceUid].Id)
+	require.Equal(t, "OK", res.Msg)
+	c, err := s.svc.GetCreditsByUID(ctx, balanceUid)
+	require.NoError(t, err)
+	require.Equal(t, uint64(150), c.TotalAmount)
+	s.requireCreditLogs(t, []domain.CreditLog{
+		{Key: "key-ledger-balance", Uid: balanceUid, ChangeAmount: 100, Biz: "user", BizId: balanceUid, Desc: "邀请注册"},
+		{Key: fmt.Sprintf("credit-ledger-%d", mismatches[balanceUid].Id), Uid: balanceUid, ChangeAmount: 50, Biz: "credit", BizId: mismatches[balanceUid].Id, Desc: "对账补偿"},
+	}, c.Logs)
+	// 重复处理
+	res = compensate("/credit/ledger/mismatches/compensate", mismatches[balanceUid].Id)
+	require.Equal(t, 410002, res.Code)
+
+	// 对账之后账户有变化
+	addCredits(logUid, "key-ledger-log-3", 10)
+	res = compensate("/credit/ledger/mismatches/compensate", mismatches[logUid].Id)
+	require.Equal(t, 410003, res.Code)
+
+	// 预扣对不上的只能忽略
+	res = compensate("/credit/ledger/mismatches/compensate", mismatches[lockedUid].Id)
+	require.Equal(t, 410004, res.Code)
+	res = compensate("/credit/ledger/mismatches/ignore", mismatches[lockedUid].Id)
+	require.Equal(t, "OK", res.Msg)
+
+	// 再对一次账，补偿过的账户不再有问题
+	report, err := s.ledgerSvc.Verify(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), report.Checked)
+	require.Equal(t, int64(2), report.Mismatched)
+	ms, _, err := s.ledgerSvc.Mismatches(ctx, report.ID, 0, 10)
+	require.NoError(t, err)
+	uids := slice.Map(ms, func(idx int, src domain.LedgerMismatch) int64 {
+		return src.Uid
+	})
+	require.ElementsMatch(t, []int64{logUid, lockedUid}, uids)
+}
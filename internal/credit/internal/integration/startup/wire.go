@@ -18,6 +18,9 @@ package startup
 
 import (
 	"github.com/ecodeclub/webook/internal/credit"
+	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 	"github.com/google/wire"
 )
@@ -27,6 +30,22 @@ func InitService() credit.Service {
 	return nil
 }
 
+func InitLedgerService() credit.LedgerService {
+	wire.Build(testioc.BaseSet, credit.InitLedgerService)
+	return nil
+}
+
+func InitLedgerHandler(svc credit.LedgerService, permModule *permission.Module) *credit.LedgerHandler {
+	wire.Build(wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
+		web.NewLedgerHandler)
+	return nil
+}
+
 func InitHandler(svc credit.Service) *credit.Handler {
 	return credit.InitHandler(svc)
 }
+
+func InitAdminHandler(svc credit.Service, permModule *permission.Module) *credit.AdminHandler {
+	return web.NewAdminHandler(svc, middleware.NewCheckRBACMiddlewareBuilder(permModule.RBACSvc))
+}
@@ -9,6 +9,9 @@ package startup
 import (
 	"github.com/ecodeclub/webook/internal/credit"
 	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 )
 
@@ -20,8 +23,25 @@ func InitService() service.Service {
 	return serviceService
 }
 
+func InitLedgerService() service.LedgerService {
+	db := testioc.InitDB()
+	ledgerService := credit.InitLedgerService(db)
+	return ledgerService
+}
+
+func InitLedgerHandler(svc service.LedgerService, permModule *permission.Module) *web.LedgerHandler {
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	ledgerHandler := web.NewLedgerHandler(svc, checkRBACMiddlewareBuilder)
+	return ledgerHandler
+}
+
 // wire.go:
 
 func InitHandler(svc credit.Service) *credit.Handler {
 	return credit.InitHandler(svc)
 }
+
+func InitAdminHandler(svc credit.Service, permModule *permission.Module) *credit.AdminHandler {
+	return web.NewAdminHandler(svc, middleware.NewCheckRBACMiddlewareBuilder(permModule.RBACSvc))
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*ExpireCreditLotsJob)(nil)
+
+// ExpireCreditLotsJob 作废已经过期的积分批次
+type ExpireCreditLotsJob struct {
+	svc   service.Service
+	limit int
+}
+
+func NewExpireCreditLotsJob(svc service.Service, limit int) *ExpireCreditLotsJob {
+	return &ExpireCreditLotsJob{
+		svc:   svc,
+		limit: limit,
+	}
+}
+
+func (e *ExpireCreditLotsJob) Name() string {
+	return "ExpireCreditLotsJob"
+}
+
+func (e *ExpireCreditLotsJob) Run(ctx context.Context) error {
+	for {
+		cnt, err := e.svc.ExpireLots(ctx, e.limit)
+		if err != nil {
+			return err
+		}
+		if cnt < e.limit {
+			return nil
+		}
+	}
+}
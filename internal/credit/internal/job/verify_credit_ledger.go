@@ -0,0 +1,58 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*VerifyCreditLedgerJob)(nil)
+
+// VerifyCreditLedgerJob 核对积分账户的余额和流水，对不上的记下来交给管理员处理
+type VerifyCreditLedgerJob struct {
+	svc       service.LedgerService
+	batchSize int
+	logger    *elog.Component
+}
+
+func NewVerifyCreditLedgerJob(svc service.LedgerService, batchSize int) *VerifyCreditLedgerJob {
+	return &VerifyCreditLedgerJob{
+		svc:       svc,
+		batchSize: batchSize,
+		logger:    elog.DefaultLogger,
+	}
+}
+
+func (v *VerifyCreditLedgerJob) Name() string {
+	return "VerifyCreditLedgerJob"
+}
+
+func (v *VerifyCreditLedgerJob) Run(ctx context.Context) error {
+	report, err := v.svc.Verify(ctx, v.batchSize)
+	if err != nil {
+		return err
+	}
+	if report.Mismatched > 0 {
+		v.logger.Error("积分账户的余额和流水对不上",
+			elog.Int64("report", report.ID),
+			elog.Int64("checked", report.Checked),
+			elog.Int64("mismatched", report.Mismatched))
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ego-component/egorm"
 	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -35,14 +36,27 @@ var (
 )
 
 type CreditDAO interface {
-	Upsert(ctx context.Context, l CreditLog) error
+	// Upsert 增加积分，同时创建一个积分批次，expireAt 为 0 的批次永不过期
+	Upsert(ctx context.Context, l CreditLog, expireAt int64) error
 	FindCreditByUID(ctx context.Context, uid int64) (Credit, error)
 	FindCreditLogsByUID(ctx context.Context, uid int64) ([]CreditLog, error)
+	// FindStatementLogs 按照 ID 倒序分页查询没有失效的流水
+	FindStatementLogs(ctx context.Context, q StatementQuery) ([]CreditLog, error)
+	// MonthlySummaries 按月汇总已经生效的流水，q 中的 Cursor 和 Limit 不起作用
+	MonthlySummaries(ctx context.Context, q StatementQuery) ([]MonthlySummary, error)
 	CreateCreditLockLog(ctx context.Context, l CreditLog) (int64, error)
 	ConfirmCreditLockLog(ctx context.Context, uid, tid int64) error
 	CancelCreditLockLog(ctx context.Context, uid, tid int64) error
 	FindExpiredLockedCreditLogs(ctx context.Context, offset int, limit int, ctime int64) ([]CreditLog, error)
 	TotalExpiredLockedCreditLogs(ctx context.Context, ctime int64) (int64, error)
+	Merge(ctx context.Context, mergeId, sourceUid, targetUid int64) error
+	DeleteByUid(ctx context.Context, uid int64) error
+	// FindLotsByUID 还有剩余积分的批次，按照扣减的顺序排列
+	FindLotsByUID(ctx context.Context, uid int64) ([]CreditLot, error)
+	// FindExpiredLots 已经过期但是还有剩余积分的批次
+	FindExpiredLots(ctx context.Context, now int64, limit int) ([]CreditLot, error)
+	// ExpireLot 作废批次剩余的积分，并且记一条流水
+	ExpireLot(ctx context.Context, lotId, now int64) error
 }
 
 type creditDAO struct {
@@ -53,10 +67,10 @@ func NewCreditGORMDAO(db *egorm.Component) CreditDAO {
 	return &creditDAO{db: db}
 }
 
-func (g *creditDAO) Upsert(ctx context.Context, l CreditLog) error {
+func (g *creditDAO) Upsert(ctx context.Context, l CreditLog, expireAt int64) error {
 	for {
 		err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-			return g.upsert(tx, l)
+			return g.upsert(tx, l, &CreditLot{ExpireAt: expireAt})
 		})
 		if errors.Is(err, ErrCreateCreditConflict) || errors.Is(err, ErrUpdateCreditConflict) {
 			continue
@@ -65,7 +79,8 @@ func (g *creditDAO) Upsert(ctx context.Context, l CreditLog) error {
 	}
 }
 
-func (g *creditDAO) upsert(tx *gorm.DB, l CreditLog) error {
+// upsert lot 为 nil 的时候不创建批次，比如说合并账号的时候批次是直接转移过去的
+func (g *creditDAO) upsert(tx *gorm.DB, l CreditLog, lot *CreditLot) error {
 	now := time.Now().UnixMilli()
 	uid := l.Uid
 	amount := uint64(l.CreditChange)
@@ -109,7 +124,106 @@ func (g *creditDAO) upsert(tx *gorm.DB, l CreditLog) error {
 		}
 		return err
 	}
-	return nil
+	if lot == nil {
+		return nil
+	}
+	lot.Uid = uid
+	lot.LogId = l.Id
+	lot.Biz = l.Biz
+	lot.BizId = l.BizId
+	lot.Amount = amount
+	lot.Remaining = amount
+	lot.Ctime = now
+	lot.Utime = now
+	return tx.Create(lot).Error
+}
+
+// Merge 把源账号的可用积分全部转到目标账号上，两边各记一条流水，流水的 key 用合并记录的 ID 来去重。
+// 积分批次也一起转过去，保留原本的过期时间。锁定的积分还在等确认或者取消，留在源账号上，
+// 之后取消的话再由 CancelCreditLockLog 转到目标账号上
+func (g *creditDAO) Merge(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	for {
+		err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return g.merge(tx, mergeId, sourceUid, targetUid)
+		})
+		if errors.Is(err, ErrCreateCreditConflict) || errors.Is(err, ErrUpdateCreditConflict) {
+			continue
+		}
+		return err
+	}
+}
+
+func (g *creditDAO) merge(tx *gorm.DB, mergeId, sourceUid, targetUid int64) error {
+	var c Credit
+	err := tx.First(&c, "uid = ?", sourceUid).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("user-merge-%d", mergeId)
+	var cnt int64
+	err = tx.Model(&CreditLog{}).Where("`key` = ?", key+"-out").Count(&cnt).Error
+	if err != nil {
+		return err
+	}
+	if cnt > 0 || (c.TotalCredits == 0 && c.LockedTotalCredits == 0) {
+		// 已经转移过了，或者没有积分。
+		// 只有锁定的积分的时候也要记流水，取消预扣的时候靠它找到目标账号
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	amount := c.TotalCredits
+	res := tx.Model(&Credit{}).
+		Where("uid = ? AND Version = ?", sourceUid, c.Version).
+		Updates(map[string]any{
+			"TotalCredits": 0,
+			"Utime":        now,
+			"Version":      c.Version + 1,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("更新积分主记录失败: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w", ErrUpdateCreditConflict)
+	}
+	out := CreditLog{
+		Key:           key + "-out",
+		Uid:           sourceUid,
+		Biz:           "user",
+		BizId:         mergeId,
+		Desc:          "合并到其它账号",
+		CreditChange:  -int64(amount),
+		CreditBalance: 0,
+		Status:        CreditLogStatusActive,
+		Ctime:         now,
+		Utime:         now,
+	}
+	if err = tx.Create(&out).Error; err != nil {
+		if g.isMySQLUniqueIndexError(err) {
+			return fmt.Errorf("%w", ErrDuplicatedCreditLog)
+		}
+		return err
+	}
+	err = tx.Model(&CreditLot{}).
+		Where("uid = ? AND remaining > 0", sourceUid).
+		Updates(map[string]any{
+			"uid":   targetUid,
+			"utime": now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	return g.upsert(tx, CreditLog{
+		Key:          key + "-in",
+		Uid:          targetUid,
+		Biz:          "user",
+		BizId:        mergeId,
+		Desc:         "合并其它账号",
+		CreditChange: int64(amount),
+		Status:       CreditLogStatusActive,
+	}, nil)
 }
 
 func (g *creditDAO) isMySQLUniqueIndexError(err error) bool {
@@ -138,6 +252,71 @@ func (g *creditDAO) FindCreditLogsByUID(ctx context.Context, uid int64) ([]Credi
 	return res, err
 }
 
+func (g *creditDAO) FindStatementLogs(ctx context.Context, q StatementQuery) ([]CreditLog, error) {
+	var res []CreditLog
+	db := g.statementCond(g.db.WithContext(ctx), q).
+		Where("status != ?", CreditLogStatusInactive)
+	if q.Cursor > 0 {
+		db = db.Where("id < ?", q.Cursor)
+	}
+	err := db.Order("id DESC").Limit(q.Limit).Find(&res).Error
+	return res, err
+}
+
+func (g *creditDAO) MonthlySummaries(ctx context.Context, q StatementQuery) ([]MonthlySummary, error) {
+	var res []MonthlySummary
+	// 月份按照数据库的时区来划分
+	err := g.statementCond(g.db.WithContext(ctx).Model(&CreditLog{}), q).
+		Where("status = ?", CreditLogStatusActive).
+		Select("DATE_FORMAT(FROM_UNIXTIME(ctime DIV 1000), '%Y-%m') AS month, " +
+			"SUM(CASE WHEN credit_change > 0 THEN credit_change ELSE 0 END) AS increase, " +
+			"SUM(CASE WHEN credit_change < 0 THEN -credit_change ELSE 0 END) AS decrease").
+		Group("month").
+		Order("month DESC").
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *creditDAO) statementCond(db *gorm.DB, q StatementQuery) *gorm.DB {
+	db = db.Where("uid = ?", q.Uid)
+	if len(q.Bizs) > 0 {
+		db = db.Where("biz IN ?", q.Bizs)
+	}
+	switch q.Direction {
+	case StatementDirectionIncrease:
+		db = db.Where("credit_change > 0")
+	case StatementDirectionDecrease:
+		db = db.Where("credit_change < 0")
+	}
+	if q.StartTime > 0 {
+		db = db.Where("ctime >= ?", q.StartTime)
+	}
+	if q.EndTime > 0 {
+		db = db.Where("ctime < ?", q.EndTime)
+	}
+	return db
+}
+
+// DeleteByUid 删除积分主记录、批次和全部流水，注销账号的时候使用
+func (g *creditDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("log_id IN (?)", tx.Model(&CreditLog{}).Select("id").Where("uid = ?", uid)).
+			Delete(&CreditLotDeduction{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("uid = ?", uid).Delete(&CreditLot{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("uid = ?", uid).Delete(&CreditLog{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&Credit{}).Error
+	})
+}
+
 // CreateCreditLockLog 创建积分预扣记录
 func (g *creditDAO) CreateCreditLockLog(ctx context.Context, l CreditLog) (int64, error) {
 	var lid int64
@@ -208,7 +387,65 @@ func (g *creditDAO) createCreditLockLog(tx *gorm.DB, l CreditLog) (int64, error)
 		}
 		return 0, err
 	}
-	return l.Id, nil
+	return l.Id, g.deductLots(tx, l.Uid, l.Id, amount, now)
+}
+
+// deductLots 按照过期时间先进先出从批次里面扣积分，永不过期的批次排在最后。
+// 批次不够扣的部分是引入批次之前就有的积分，这部分积分没有批次，也就不需要记录
+func (g *creditDAO) deductLots(tx *gorm.DB, uid, logId int64, amount uint64, now int64) error {
+	var lots []CreditLot
+	err := tx.Where("uid = ? AND remaining > 0", uid).
+		Order("expire_at = 0, expire_at, id").
+		Find(&lots).Error
+	if err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		if amount == 0 {
+			break
+		}
+		take := min(amount, lot.Remaining)
+		err = tx.Model(&CreditLot{}).Where("id = ?", lot.Id).
+			Updates(map[string]any{
+				"remaining": gorm.Expr("remaining - ?", take),
+				"utime":     now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&CreditLotDeduction{
+			LogId:  logId,
+			LotId:  lot.Id,
+			Amount: take,
+			Ctime:  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		amount -= take
+	}
+	return nil
+}
+
+// restoreLots 取消预扣的时候把积分退回原来的批次，只退属于 uid 的批次。
+// 账号合并过的情况由 forwardCanceled 先把批次转到目标账号上再退
+func (g *creditDAO) restoreLots(tx *gorm.DB, uid, logId int64, now int64) error {
+	var ds []CreditLotDeduction
+	err := tx.Where("log_id = ?", logId).Find(&ds).Error
+	if err != nil {
+		return err
+	}
+	for _, d := range ds {
+		err = tx.Model(&CreditLot{}).Where("id = ? AND uid = ?", d.LotId, uid).
+			Updates(map[string]any{
+				"remaining": gorm.Expr("remaining + ?", d.Amount),
+				"utime":     now,
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Where("log_id = ?", logId).Delete(&CreditLotDeduction{}).Error
 }
 
 func (g *creditDAO) getCreditLogIDByKey(tx *gorm.DB, key string) (int64, error) {
@@ -309,6 +546,215 @@ func (g *creditDAO) updateCreditLockLog(tx *gorm.DB, uid, tid int64, srcStatus,
 	if res.RowsAffected == 0 {
 		return fmt.Errorf("%w", ErrUpdateCreditConflict)
 	}
+	if dstStatus != CreditLogStatusInactive {
+		return nil
+	}
+	mergeId, targetUid, err := g.mergedInto(tx, uid)
+	if err != nil {
+		return err
+	}
+	if targetUid == 0 {
+		return g.restoreLots(tx, uid, tid, now)
+	}
+	return g.forwardCanceled(tx, mergeId, uid, targetUid, tid, c, uint64(0-cl.CreditChange), now)
+}
+
+// mergedInto 查找账号合并到了哪个账号上，没有合并过的时候 targetUid 为 0。
+// 目标账号后面又合并到了别的账号上的话，一直找到最后的那个账号
+func (g *creditDAO) mergedInto(tx *gorm.DB, uid int64) (mergeId int64, targetUid int64, err error) {
+	visited := map[int64]struct{}{uid: {}}
+	for cur := uid; ; {
+		var out CreditLog
+		err = tx.Where("uid = ? AND biz = ? AND `key` = CONCAT('user-merge-', biz_id, '-out')", cur, "user").
+			Order("id DESC").First(&out).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mergeId, targetUid, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		var in CreditLog
+		err = tx.First(&in, "`key` = ?", fmt.Sprintf("user-merge-%d-in", out.BizId)).Error
+		if err != nil {
+			return 0, 0, err
+		}
+		if _, ok := visited[in.Uid]; ok {
+			// 互相合并过，停在当前找到的账号上
+			return mergeId, targetUid, nil
+		}
+		if mergeId == 0 {
+			mergeId = out.BizId
+		}
+		visited[in.Uid] = struct{}{}
+		targetUid, cur = in.Uid, in.Uid
+	}
+}
+
+// forwardCanceled 账号合并之后才取消的预扣，退回来的积分和批次接着转到目标账号上，
+// 不然这部分积分就留在了已经不能登录的源账号上。c 是已经退回了积分的源账号积分主记录
+func (g *creditDAO) forwardCanceled(tx *gorm.DB, mergeId, sourceUid, targetUid, tid int64,
+	c Credit, amount uint64, now int64) error {
+	var lotIds []int64
+	err := tx.Model(&CreditLotDeduction{}).Where("log_id = ?", tid).Pluck("lot_id", &lotIds).Error
+	if err != nil {
+		return err
+	}
+	if len(lotIds) > 0 {
+		// 已经转移过去的批次和当时扣完了没转移的批次都归目标账号
+		err = tx.Model(&CreditLot{}).Where("id IN ?", lotIds).
+			Updates(map[string]any{
+				"uid":   targetUid,
+				"utime": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
+	if err = g.restoreLots(tx, targetUid, tid, now); err != nil {
+		return err
+	}
+	res := tx.Model(&Credit{}).
+		Where("uid = ? AND Version = ?", sourceUid, c.Version).
+		Updates(map[string]any{
+			"TotalCredits": c.TotalCredits - amount,
+			"Utime":        now,
+			"Version":      c.Version + 1,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("更新积分主记录失败: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w", ErrUpdateCreditConflict)
+	}
+	key := fmt.Sprintf("user-merge-%d-lock-%d", mergeId, tid)
+	out := CreditLog{
+		Key:           key + "-out",
+		Uid:           sourceUid,
+		Biz:           "user",
+		BizId:         mergeId,
+		Desc:          "合并到其它账号",
+		CreditChange:  -int64(amount),
+		CreditBalance: c.TotalCredits - amount,
+		Status:        CreditLogStatusActive,
+		Ctime:         now,
+		Utime:         now,
+	}
+	if err = tx.Create(&out).Error; err != nil {
+		if g.isMySQLUniqueIndexError(err) {
+			return fmt.Errorf("%w", ErrDuplicatedCreditLog)
+		}
+		return err
+	}
+	return g.upsert(tx, CreditLog{
+		Key:          key + "-in",
+		Uid:          targetUid,
+		Biz:          "user",
+		BizId:        mergeId,
+		Desc:         "合并其它账号",
+		CreditChange: int64(amount),
+		Status:       CreditLogStatusActive,
+	}, nil)
+}
+
+func (g *creditDAO) FindLotsByUID(ctx context.Context, uid int64) ([]CreditLot, error) {
+	var res []CreditLot
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND remaining > 0", uid).
+		Order("expire_at = 0, expire_at, id").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *creditDAO) FindExpiredLots(ctx context.Context, now int64, limit int) ([]CreditLot, error) {
+	var res []CreditLot
+	err := g.db.WithContext(ctx).
+		Where("expire_at > 0 AND expire_at <= ? AND remaining > 0", now).
+		Order("expire_at").Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *creditDAO) ExpireLot(ctx context.Context, lotId, now int64) error {
+	for {
+		err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return g.expireLot(tx, lotId, now)
+		})
+		if errors.Is(err, ErrUpdateCreditConflict) {
+			continue
+		}
+		if errors.Is(err, ErrDuplicatedCreditLog) {
+			// 已经处理过了
+			return nil
+		}
+		return err
+	}
+}
+
+func (g *creditDAO) expireLot(tx *gorm.DB, lotId, now int64) error {
+	var lot CreditLot
+	if err := tx.First(&lot, "id = ?", lotId).Error; err != nil {
+		return err
+	}
+	// 和预扣积分一样，先锁积分主记录再锁批次，避免死锁
+	var c Credit
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&c, "uid = ?", lot.Uid).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lot, "id = ?", lotId).Error
+	if err != nil {
+		return err
+	}
+	if lot.Uid != c.Uid {
+		// 批次刚好被合并到了其它账号，重试
+		return fmt.Errorf("%w", ErrUpdateCreditConflict)
+	}
+	if lot.Remaining == 0 || lot.ExpireAt == 0 || lot.ExpireAt > now {
+		return nil
+	}
+	amount := min(lot.Remaining, c.TotalCredits)
+	res := tx.Model(&Credit{}).
+		Where("uid = ? AND Version = ?", c.Uid, c.Version).
+		Updates(map[string]any{
+			"TotalCredits": c.TotalCredits - amount,
+			"Utime":        now,
+			"Version":      c.Version + 1,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("更新积分主记录失败: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w", ErrUpdateCreditConflict)
+	}
+	expired := lot.ExpiredAmount + lot.Remaining
+	err = tx.Model(&CreditLot{}).Where("id = ?", lot.Id).
+		Updates(map[string]any{
+			"remaining":      0,
+			"expired_amount": expired,
+			"utime":          now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	// 取消预扣的积分可能会退回到已经过期的批次上，所以一个批次可能过期多次，key 里面带上累计过期的数量
+	l := CreditLog{
+		Key:           fmt.Sprintf("credit-lot-expire-%d-%d", lot.Id, expired),
+		Uid:           c.Uid,
+		Biz:           "credit",
+		BizId:         lot.Id,
+		Desc:          "积分过期",
+		CreditChange:  -int64(amount),
+		CreditBalance: c.TotalCredits - amount,
+		Status:        CreditLogStatusActive,
+		Ctime:         now,
+		Utime:         now,
+	}
+	if err = tx.Create(&l).Error; err != nil {
+		if g.isMySQLUniqueIndexError(err) {
+			return fmt.Errorf("%w", ErrDuplicatedCreditLog)
+		}
+		return err
+	}
 	return nil
 }
 
@@ -328,6 +774,29 @@ func (g *creditDAO) TotalExpiredLockedCreditLogs(ctx context.Context, ctime int6
 	return res, err
 }
 
+const (
+	StatementDirectionAll uint8 = iota
+	StatementDirectionIncrease
+	StatementDirectionDecrease
+)
+
+// StatementQuery 积分明细的查询条件
+type StatementQuery struct {
+	Uid       int64
+	Cursor    int64
+	Limit     int
+	Bizs      []string
+	Direction uint8
+	StartTime int64
+	EndTime   int64
+}
+
+type MonthlySummary struct {
+	Month    string
+	Increase uint64
+	Decrease uint64
+}
+
 const (
 	CreditLogStatusActive   uint8 = 1
 	CreditLogStatusLocked   uint8 = 2
@@ -357,3 +826,27 @@ type CreditLog struct {
 	Ctime         int64
 	Utime         int64
 }
+
+// CreditLot 积分批次，每一次增加积分对应一个批次，扣减的时候按照过期时间先进先出
+type CreditLot struct {
+	Id            int64  `gorm:"primaryKey;autoIncrement;comment:积分批次自增ID"`
+	Uid           int64  `gorm:"not null;index:idx_user_id;comment:用户ID"`
+	LogId         int64  `gorm:"not null;uniqueIndex:unq_log_id;comment:增加积分的流水ID"`
+	Biz           string `gorm:"type:varchar(256);not null;comment:积分来源,和流水的业务类型一致"`
+	BizId         int64  `gorm:"not null;comment:业务ID"`
+	Amount        uint64 `gorm:"not null;comment:发放的积分数量"`
+	Remaining     uint64 `gorm:"not null;comment:剩余可用的积分数量,预扣的积分已经扣掉了"`
+	ExpiredAmount uint64 `gorm:"not null;default:0;comment:累计过期的积分数量"`
+	ExpireAt      int64  `gorm:"not null;default:0;index:idx_expire_at;comment:过期时间,0表示永不过期"`
+	Ctime         int64
+	Utime         int64
+}
+
+// CreditLotDeduction 预扣积分的时候从哪些批次扣了多少，取消预扣的时候按照这个退回去
+type CreditLotDeduction struct {
+	Id     int64  `gorm:"primaryKey;autoIncrement;comment:批次扣减记录自增ID"`
+	LogId  int64  `gorm:"not null;index:idx_log_id;comment:扣减积分的流水ID"`
+	LotId  int64  `gorm:"not null;comment:积分批次ID"`
+	Amount uint64 `gorm:"not null;comment:从这个批次扣减的积分数量"`
+	Ctime  int64
+}
@@ -17,5 +17,6 @@ package dao
 import "github.com/ego-component/egorm"
 
 func InitTables(db *egorm.Component) error {
-	return db.AutoMigrate(&Credit{}, &CreditLog{})
+	return db.AutoMigrate(&Credit{}, &CreditLog{}, &CreditLot{}, &CreditLotDeduction{},
+		&CreditLedgerReport{}, &CreditLedgerMismatch{})
 }
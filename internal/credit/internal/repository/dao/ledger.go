@@ -0,0 +1,330 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ekit/sqlx"
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLedgerChanged         = errors.New("对账之后积分账户又发生了变化")
+	ErrLedgerMismatchHandled = errors.New("对账差异已经处理过了")
+)
+
+const (
+	LedgerReportStatusRunning uint8 = 1
+	LedgerReportStatusDone    uint8 = 2
+	LedgerReportStatusFailed  uint8 = 3
+)
+
+const (
+	LedgerMismatchStatusPending     uint8 = 1
+	LedgerMismatchStatusCompensated uint8 = 2
+	LedgerMismatchStatusIgnored     uint8 = 3
+)
+
+type LedgerDAO interface {
+	CreateReport(ctx context.Context, r CreditLedgerReport) (int64, error)
+	UpdateReport(ctx context.Context, r CreditLedgerReport) error
+	FindReports(ctx context.Context, offset, limit int) ([]CreditLedgerReport, error)
+	CountReports(ctx context.Context) (int64, error)
+	// Snapshot 在同一个一致性快照里读取 ID 大于 cursor 的一批积分账户和对应的流水汇总，
+	// 对不上的账户会带上全部流水
+	Snapshot(ctx context.Context, cursor int64, limit int) ([]LedgerSnapshot, error)
+	CreateMismatches(ctx context.Context, ms []CreditLedgerMismatch) error
+	FindMismatches(ctx context.Context, reportId int64, offset, limit int) ([]CreditLedgerMismatch, error)
+	CountMismatches(ctx context.Context, reportId int64) (int64, error)
+	FindMismatchByID(ctx context.Context, id int64) (CreditLedgerMismatch, error)
+	// Compensate 写一条补偿流水让流水和账户余额对上，账户在对账之后有变化的时候返回 ErrLedgerChanged
+	Compensate(ctx context.Context, id, operator int64) error
+	Ignore(ctx context.Context, id, operator int64) error
+}
+
+type GORMLedgerDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMLedgerDAO(db *egorm.Component) LedgerDAO {
+	return &GORMLedgerDAO{db: db}
+}
+
+func (d *GORMLedgerDAO) CreateReport(ctx context.Context, r CreditLedgerReport) (int64, error) {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	err := d.db.WithContext(ctx).Create(&r).Error
+	return r.Id, err
+}
+
+func (d *GORMLedgerDAO) UpdateReport(ctx context.Context, r CreditLedgerReport) error {
+	return d.db.WithContext(ctx).Model(&CreditLedgerReport{}).
+		Where("id = ?", r.Id).
+		Updates(map[string]any{
+			"status":     r.Status,
+			"checked":    r.Checked,
+			"mismatched": r.Mismatched,
+			"utime":      time.Now().UnixMilli(),
+		}).Error
+}
+
+func (d *GORMLedgerDAO) FindReports(ctx context.Context, offset, limit int) ([]CreditLedgerReport, error) {
+	var res []CreditLedgerReport
+	err := d.db.WithContext(ctx).Order("id DESC").
+		Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMLedgerDAO) CountReports(ctx context.Context) (int64, error) {
+	var res int64
+	err := d.db.WithContext(ctx).Model(&CreditLedgerReport{}).Count(&res).Error
+	return res, err
+}
+
+func (d *GORMLedgerDAO) Snapshot(ctx context.Context, cursor int64, limit int) ([]LedgerSnapshot, error) {
+	var res []LedgerSnapshot
+	// 可重复读的只读事务，第一次读的时候建立快照，后面读到的积分账户和流水是同一时刻的
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cs []Credit
+		err := tx.Where("id > ?", cursor).Order("id").Limit(limit).Find(&cs).Error
+		if err != nil || len(cs) == 0 {
+			return err
+		}
+		sums, err := d.sumLogs(tx, slice.Map(cs, func(idx int, src Credit) int64 {
+			return src.Uid
+		}))
+		if err != nil {
+			return err
+		}
+		res = make([]LedgerSnapshot, 0, len(cs))
+		idx := make(map[int64]int, len(cs))
+		for _, c := range cs {
+			s := sums[c.Uid]
+			if int64(c.TotalCredits) != s.Total || int64(c.LockedTotalCredits) != s.Locked {
+				idx[c.Uid] = len(res)
+			}
+			res = append(res, LedgerSnapshot{
+				Credit:                     c,
+				ExpectedTotalCredits:       s.Total,
+				ExpectedLockedTotalCredits: s.Locked,
+			})
+		}
+		if len(idx) == 0 {
+			return nil
+		}
+		var logs []CreditLog
+		err = tx.Where("uid IN ?", mapKeys(idx)).Order("id").Find(&logs).Error
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			i := idx[l.Uid]
+			res[i].Logs = append(res[i].Logs, l)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	return res, err
+}
+
+// sumLogs 按照流水算出来的可用积分和预扣积分。
+// 生效的和预扣中的流水都已经从可用积分里面扣掉了，预扣中的流水同时记在预扣积分上
+func (d *GORMLedgerDAO) sumLogs(tx *gorm.DB, uids []int64) (map[int64]ledgerSum, error) {
+	var sums []ledgerSum
+	err := tx.Model(&CreditLog{}).
+		Select("uid, "+
+			"SUM(CASE WHEN status IN ? THEN credit_change ELSE 0 END) AS total, "+
+			"-SUM(CASE WHEN status = ? THEN credit_change ELSE 0 END) AS locked",
+			[]uint8{CreditLogStatusActive, CreditLogStatusLocked}, CreditLogStatusLocked).
+		Where("uid IN ?", uids).
+		Group("uid").
+		Scan(&sums).Error
+	res := make(map[int64]ledgerSum, len(sums))
+	for _, s := range sums {
+		res[s.Uid] = s
+	}
+	return res, err
+}
+
+func mapKeys(m map[int64]int) []int64 {
+	res := make([]int64, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
+
+func (d *GORMLedgerDAO) CreateMismatches(ctx context.Context, ms []CreditLedgerMismatch) error {
+	if len(ms) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range ms {
+		ms[i].Ctime = now
+		ms[i].Utime = now
+	}
+	return d.db.WithContext(ctx).Create(&ms).Error
+}
+
+func (d *GORMLedgerDAO) FindMismatches(ctx context.Context, reportId int64, offset, limit int) ([]CreditLedgerMismatch, error) {
+	var res []CreditLedgerMismatch
+	err := d.db.WithContext(ctx).Where("report_id = ?", reportId).
+		Order("id").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMLedgerDAO) CountMismatches(ctx context.Context, reportId int64) (int64, error) {
+	var res int64
+	err := d.db.WithContext(ctx).Model(&CreditLedgerMismatch{}).
+		Where("report_id = ?", reportId).Count(&res).Error
+	return res, err
+}
+
+func (d *GORMLedgerDAO) FindMismatchByID(ctx context.Context, id int64) (CreditLedgerMismatch, error) {
+	var res CreditLedgerMismatch
+	err := d.db.WithContext(ctx).First(&res, "id = ?", id).Error
+	return res, err
+}
+
+func (d *GORMLedgerDAO) Compensate(ctx context.Context, id, operator int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		m, err := d.lockPendingMismatch(tx, id)
+		if err != nil {
+			return err
+		}
+		// 先锁住积分账户，其它修改余额的事务都会等在这里，重新汇总的流水才是准确的
+		var c Credit
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&c, "uid = ?", m.Uid).Error
+		if err != nil {
+			return err
+		}
+		sums, err := d.sumLogs(tx, []int64{m.Uid})
+		if err != nil {
+			return err
+		}
+		s := sums[m.Uid]
+		if c.TotalCredits != m.TotalCredits || c.LockedTotalCredits != m.LockedTotalCredits ||
+			s.Total != m.ExpectedTotalCredits || s.Locked != m.ExpectedLockedTotalCredits {
+			return fmt.Errorf("%w, uid %d", ErrLedgerChanged, m.Uid)
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Create(&CreditLog{
+			Key:           fmt.Sprintf("credit-ledger-%d", m.Id),
+			Uid:           m.Uid,
+			Biz:           "credit",
+			BizId:         m.Id,
+			Desc:          "对账补偿",
+			CreditChange:  int64(c.TotalCredits) - s.Total,
+			CreditBalance: c.TotalCredits,
+			Status:        CreditLogStatusActive,
+			Ctime:         now,
+			Utime:         now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// 余额没变，但是要让并发的乐观锁更新重试
+		err = tx.Model(&Credit{}).Where("id = ?", c.Id).
+			Updates(map[string]any{
+				"Version": c.Version + 1,
+				"Utime":   now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return d.updateMismatchStatus(tx, m.Id, operator, LedgerMismatchStatusCompensated, now)
+	})
+}
+
+func (d *GORMLedgerDAO) Ignore(ctx context.Context, id, operator int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := d.lockPendingMismatch(tx, id)
+		if err != nil {
+			return err
+		}
+		return d.updateMismatchStatus(tx, id, operator, LedgerMismatchStatusIgnored, time.Now().UnixMilli())
+	})
+}
+
+func (d *GORMLedgerDAO) lockPendingMismatch(tx *gorm.DB, id int64) (CreditLedgerMismatch, error) {
+	var m CreditLedgerMismatch
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, "id = ?", id).Error
+	if err != nil {
+		return CreditLedgerMismatch{}, err
+	}
+	if m.Status != LedgerMismatchStatusPending {
+		return CreditLedgerMismatch{}, fmt.Errorf("%w, id %d", ErrLedgerMismatchHandled, id)
+	}
+	return m, nil
+}
+
+func (d *GORMLedgerDAO) updateMismatchStatus(tx *gorm.DB, id, operator int64, status uint8, now int64) error {
+	return tx.Model(&CreditLedgerMismatch{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"status":   status,
+			"operator": operator,
+			"utime":    now,
+		}).Error
+}
+
+type ledgerSum struct {
+	Uid    int64
+	Total  int64
+	Locked int64
+}
+
+// LedgerSnapshot 同一个快照里的积分账户和根据流水算出来的余额
+type LedgerSnapshot struct {
+	Credit                     Credit
+	ExpectedTotalCredits       int64
+	ExpectedLockedTotalCredits int64
+	// Logs 只有对不上的账户才有，按照 ID 升序
+	Logs []CreditLog
+}
+
+// CreditLedgerReport 一次对账
+type CreditLedgerReport struct {
+	Id         int64 `gorm:"primaryKey;autoIncrement;comment:对账自增ID"`
+	Status     uint8 `gorm:"type:tinyint unsigned;not null;comment:对账状态 1=进行中, 2=已完成, 3=失败"`
+	Checked    int64 `gorm:"not null;default:0;comment:核对的积分账户数量"`
+	Mismatched int64 `gorm:"not null;default:0;comment:对不上的积分账户数量"`
+	Ctime      int64
+	Utime      int64
+}
+
+// CreditLedgerMismatch 对账时发现的余额和流水对不上的积分账户
+type CreditLedgerMismatch struct {
+	Id                         int64                    `gorm:"primaryKey;autoIncrement;comment:对账差异自增ID"`
+	ReportId                   int64                    `gorm:"not null;index:idx_report_id;comment:对账ID"`
+	Uid                        int64                    `gorm:"not null;index:idx_user_id;comment:用户ID"`
+	TotalCredits               uint64                   `gorm:"not null;comment:账户上的可用积分"`
+	LockedTotalCredits         uint64                   `gorm:"not null;comment:账户上的预扣积分"`
+	ExpectedTotalCredits       int64                    `gorm:"not null;comment:根据流水算出来的可用积分"`
+	ExpectedLockedTotalCredits int64                    `gorm:"not null;comment:根据流水算出来的预扣积分"`
+	LogIds                     sqlx.JsonColumn[[]int64] `gorm:"type:text;comment:接不上的流水ID"`
+	Status                     uint8                    `gorm:"type:tinyint unsigned;not null;comment:处理状态 1=待处理, 2=已补偿, 3=已忽略"`
+	Operator                   int64                    `gorm:"not null;default:0;comment:处理的管理员"`
+	Ctime                      int64
+	Utime                      int64
+}
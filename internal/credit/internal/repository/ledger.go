@@ -0,0 +1,195 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ekit/sqlx"
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
+	"github.com/ecodeclub/webook/internal/credit/internal/repository/dao"
+)
+
+var (
+	ErrLedgerChanged         = dao.ErrLedgerChanged
+	ErrLedgerMismatchHandled = dao.ErrLedgerMismatchHandled
+)
+
+// LedgerSnapshot 同一时刻的积分账户和根据流水算出来的余额
+type LedgerSnapshot struct {
+	// CreditID 积分账户的 ID，作为下一批的游标
+	CreditID                  int64
+	Uid                       int64
+	TotalAmount               uint64
+	LockedTotalAmount         uint64
+	ExpectedTotalAmount       int64
+	ExpectedLockedTotalAmount int64
+	// Logs 只有对不上的账户才有，按照 ID 升序，包含已经失效的流水
+	Logs []LedgerLog
+}
+
+// LedgerLog 核对流水连贯性需要的字段
+type LedgerLog struct {
+	ID           int64
+	ChangeAmount int64
+	Balance      uint64
+	// Inactive 预扣之后又取消了，Utime 就是取消的时间
+	Inactive bool
+	Ctime    int64
+	Utime    int64
+}
+
+type LedgerRepository interface {
+	CreateReport(ctx context.Context, r domain.LedgerReport) (int64, error)
+	UpdateReport(ctx context.Context, r domain.LedgerReport) error
+	FindReports(ctx context.Context, offset, limit int) ([]domain.LedgerReport, int64, error)
+	Snapshot(ctx context.Context, cursor int64, limit int) ([]LedgerSnapshot, error)
+	CreateMismatches(ctx context.Context, ms []domain.LedgerMismatch) error
+	FindMismatches(ctx context.Context, reportId int64, offset, limit int) ([]domain.LedgerMismatch, int64, error)
+	FindMismatchByID(ctx context.Context, id int64) (domain.LedgerMismatch, error)
+	Compensate(ctx context.Context, id, operator int64) error
+	Ignore(ctx context.Context, id, operator int64) error
+}
+
+type ledgerRepository struct {
+	dao dao.LedgerDAO
+}
+
+func NewLedgerRepository(dao dao.LedgerDAO) LedgerRepository {
+	return &ledgerRepository{dao: dao}
+}
+
+func (r *ledgerRepository) CreateReport(ctx context.Context, report domain.LedgerReport) (int64, error) {
+	return r.dao.CreateReport(ctx, r.toReportEntity(report))
+}
+
+func (r *ledgerRepository) UpdateReport(ctx context.Context, report domain.LedgerReport) error {
+	return r.dao.UpdateReport(ctx, r.toReportEntity(report))
+}
+
+func (r *ledgerRepository) FindReports(ctx context.Context, offset, limit int) ([]domain.LedgerReport, int64, error) {
+	reports, err := r.dao.FindReports(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.dao.CountReports(ctx)
+	return slice.Map(reports, func(idx int, src dao.CreditLedgerReport) domain.LedgerReport {
+		return r.toReportDomain(src)
+	}), total, err
+}
+
+func (r *ledgerRepository) Snapshot(ctx context.Context, cursor int64, limit int) ([]LedgerSnapshot, error) {
+	snapshots, err := r.dao.Snapshot(ctx, cursor, limit)
+	return slice.Map(snapshots, func(idx int, src dao.LedgerSnapshot) LedgerSnapshot {
+		return LedgerSnapshot{
+			CreditID:                  src.Credit.Id,
+			Uid:                       src.Credit.Uid,
+			TotalAmount:               src.Credit.TotalCredits,
+			LockedTotalAmount:         src.Credit.LockedTotalCredits,
+			ExpectedTotalAmount:       src.ExpectedTotalCredits,
+			ExpectedLockedTotalAmount: src.ExpectedLockedTotalCredits,
+			Logs: slice.Map(src.Logs, func(idx int, src dao.CreditLog) LedgerLog {
+				return LedgerLog{
+					ID:           src.Id,
+					ChangeAmount: src.CreditChange,
+					Balance:      src.CreditBalance,
+					Inactive:     src.Status == dao.CreditLogStatusInactive,
+					Ctime:        src.Ctime,
+					Utime:        src.Utime,
+				}
+			}),
+		}
+	}), err
+}
+
+func (r *ledgerRepository) CreateMismatches(ctx context.Context, ms []domain.LedgerMismatch) error {
+	return r.dao.CreateMismatches(ctx, slice.Map(ms, func(idx int, src domain.LedgerMismatch) dao.CreditLedgerMismatch {
+		return dao.CreditLedgerMismatch{
+			ReportId:                   src.ReportID,
+			Uid:                        src.Uid,
+			TotalCredits:               src.TotalAmount,
+			LockedTotalCredits:         src.LockedTotalAmount,
+			ExpectedTotalCredits:       src.ExpectedTotalAmount,
+			ExpectedLockedTotalCredits: src.ExpectedLockedTotalAmount,
+			LogIds: sqlx.JsonColumn[[]int64]{
+				Val:   src.LogIDs,
+				Valid: len(src.LogIDs) > 0,
+			},
+			Status: uint8(src.Status),
+		}
+	}))
+}
+
+func (r *ledgerRepository) FindMismatches(ctx context.Context, reportId int64, offset, limit int) ([]domain.LedgerMismatch, int64, error) {
+	ms, err := r.dao.FindMismatches(ctx, reportId, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.dao.CountMismatches(ctx, reportId)
+	return slice.Map(ms, func(idx int, src dao.CreditLedgerMismatch) domain.LedgerMismatch {
+		return r.toMismatchDomain(src)
+	}), total, err
+}
+
+func (r *ledgerRepository) FindMismatchByID(ctx context.Context, id int64) (domain.LedgerMismatch, error) {
+	m, err := r.dao.FindMismatchByID(ctx, id)
+	return r.toMismatchDomain(m), err
+}
+
+func (r *ledgerRepository) Compensate(ctx context.Context, id, operator int64) error {
+	return r.dao.Compensate(ctx, id, operator)
+}
+
+func (r *ledgerRepository) Ignore(ctx context.Context, id, operator int64) error {
+	return r.dao.Ignore(ctx, id, operator)
+}
+
+func (r *ledgerRepository) toReportEntity(report domain.LedgerReport) dao.CreditLedgerReport {
+	return dao.CreditLedgerReport{
+		Id:         report.ID,
+		Status:     uint8(report.Status),
+		Checked:    report.Checked,
+		Mismatched: report.Mismatched,
+	}
+}
+
+func (r *ledgerRepository) toReportDomain(report dao.CreditLedgerReport) domain.LedgerReport {
+	return domain.LedgerReport{
+		ID:         report.Id,
+		Status:     domain.LedgerReportStatus(report.Status),
+		Checked:    report.Checked,
+		Mismatched: report.Mismatched,
+		Ctime:      report.Ctime,
+		Utime:      report.Utime,
+	}
+}
+
+func (r *ledgerRepository) toMismatchDomain(m dao.CreditLedgerMismatch) domain.LedgerMismatch {
+	return domain.LedgerMismatch{
+		ID:                        m.Id,
+		ReportID:                  m.ReportId,
+		Uid:                       m.Uid,
+		TotalAmount:               m.TotalCredits,
+		LockedTotalAmount:         m.LockedTotalCredits,
+		ExpectedTotalAmount:       m.ExpectedTotalCredits,
+		ExpectedLockedTotalAmount: m.ExpectedLockedTotalCredits,
+		LogIDs:                    m.LogIds.Val,
+		Status:                    domain.LedgerMismatchStatus(m.Status),
+		Operator:                  m.Operator,
+		Ctime:                     m.Ctime,
+		Utime:                     m.Utime,
+	}
+}
@@ -36,6 +36,13 @@ type CreditRepository interface {
 	CancelDeductCredits(ctx context.Context, uid, tid int64) error
 	FindExpiredLockedCreditLogs(ctx context.Context, offset int, limit int, ctime int64) ([]domain.CreditLog, error)
 	TotalExpiredLockedCreditLogs(ctx context.Context, ctime int64) (int64, error)
+	MergeCredits(ctx context.Context, mergeId, sourceUid, targetUid int64) error
+	DeleteByUid(ctx context.Context, uid int64) error
+	FindLotsByUID(ctx context.Context, uid int64) ([]domain.CreditLot, error)
+	FindExpiredLots(ctx context.Context, now int64, limit int) ([]domain.CreditLot, error)
+	ExpireLot(ctx context.Context, lotId, now int64) error
+	FindStatement(ctx context.Context, q domain.StatementQuery) ([]domain.StatementEntry, error)
+	MonthlySummaries(ctx context.Context, q domain.StatementQuery) ([]domain.MonthlySummary, error)
 }
 
 type creditRepository struct {
@@ -48,10 +55,18 @@ func NewCreditRepository(dao dao.CreditDAO) CreditRepository {
 
 func (r *creditRepository) AddCredits(ctx context.Context, credit domain.Credit) error {
 	cl := r.toCreditLogsEntity(credit)
-	err := r.dao.Upsert(ctx, cl[0])
+	err := r.dao.Upsert(ctx, cl[0], credit.Logs[0].ExpireAt)
 	return err
 }
 
+func (r *creditRepository) MergeCredits(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	return r.dao.Merge(ctx, mergeId, sourceUid, targetUid)
+}
+
+func (r *creditRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return r.dao.DeleteByUid(ctx, uid)
+}
+
 func (r *creditRepository) toCreditLogsEntity(c domain.Credit) []dao.CreditLog {
 	return slice.Map(c.Logs, func(idx int, src domain.CreditLog) dao.CreditLog {
 		return dao.CreditLog{
@@ -97,6 +112,47 @@ func (r *creditRepository) toDomainCreditLog(logs []dao.CreditLog) []domain.Cred
 	})
 }
 
+func (r *creditRepository) FindStatement(ctx context.Context, q domain.StatementQuery) ([]domain.StatementEntry, error) {
+	logs, err := r.dao.FindStatementLogs(ctx, r.toStatementQueryEntity(q))
+	return slice.Map(logs, func(idx int, src dao.CreditLog) domain.StatementEntry {
+		return domain.StatementEntry{
+			ID:           src.Id,
+			Biz:          src.Biz,
+			BizId:        src.BizId,
+			Key:          src.Key,
+			Desc:         src.Desc,
+			ChangeAmount: src.CreditChange,
+			Balance:      src.CreditBalance,
+			// 失效的流水已经过滤掉了，剩下的状态和 dao 中的取值一致
+			Status: domain.StatementEntryStatus(src.Status),
+			Ctime:  src.Ctime,
+		}
+	}), err
+}
+
+func (r *creditRepository) MonthlySummaries(ctx context.Context, q domain.StatementQuery) ([]domain.MonthlySummary, error) {
+	res, err := r.dao.MonthlySummaries(ctx, r.toStatementQueryEntity(q))
+	return slice.Map(res, func(idx int, src dao.MonthlySummary) domain.MonthlySummary {
+		return domain.MonthlySummary{
+			Month:    src.Month,
+			Increase: src.Increase,
+			Decrease: src.Decrease,
+		}
+	}), err
+}
+
+func (r *creditRepository) toStatementQueryEntity(q domain.StatementQuery) dao.StatementQuery {
+	return dao.StatementQuery{
+		Uid:       q.Uid,
+		Cursor:    q.Cursor,
+		Limit:     q.Limit,
+		Bizs:      q.Bizs,
+		Direction: uint8(q.Direction),
+		StartTime: q.StartTime,
+		EndTime:   q.EndTime,
+	}
+}
+
 func (r *creditRepository) TryDeductCredits(ctx context.Context, credit domain.Credit) (int64, error) {
 	cl := r.toCreditLogsEntity(credit)
 	id, err := r.dao.CreateCreditLockLog(ctx, cl[0])
@@ -116,6 +172,35 @@ func (r *creditRepository) FindExpiredLockedCreditLogs(ctx context.Context, offs
 	return r.toDomainCreditLog(cs), err
 }
 
+func (r *creditRepository) FindLotsByUID(ctx context.Context, uid int64) ([]domain.CreditLot, error) {
+	lots, err := r.dao.FindLotsByUID(ctx, uid)
+	return r.toDomainCreditLots(lots), err
+}
+
+func (r *creditRepository) FindExpiredLots(ctx context.Context, now int64, limit int) ([]domain.CreditLot, error) {
+	lots, err := r.dao.FindExpiredLots(ctx, now, limit)
+	return r.toDomainCreditLots(lots), err
+}
+
+func (r *creditRepository) ExpireLot(ctx context.Context, lotId, now int64) error {
+	return r.dao.ExpireLot(ctx, lotId, now)
+}
+
+func (r *creditRepository) toDomainCreditLots(lots []dao.CreditLot) []domain.CreditLot {
+	return slice.Map(lots, func(idx int, src dao.CreditLot) domain.CreditLot {
+		return domain.CreditLot{
+			ID:        src.Id,
+			Uid:       src.Uid,
+			Biz:       src.Biz,
+			BizId:     src.BizId,
+			Amount:    src.Amount,
+			Remaining: src.Remaining,
+			ExpireAt:  src.ExpireAt,
+			Ctime:     src.Ctime,
+		}
+	})
+}
+
 func (r *creditRepository) TotalExpiredLockedCreditLogs(ctx context.Context, ctime int64) (int64, error) {
 	return r.dao.TotalExpiredLockedCreditLogs(ctx, ctime)
 }
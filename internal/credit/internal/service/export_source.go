@@ -0,0 +1,49 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+// exportSource 导出个人数据的时候读取积分余额、积分流水和还没用完的积分批次
+type exportSource struct {
+	svc Service
+}
+
+func NewExportSource(svc Service) exportx.Source {
+	return &exportSource{svc: svc}
+}
+
+func (s *exportSource) Name() string {
+	return "credit"
+}
+
+type exportData struct {
+	Credit domain.Credit
+	Lots   []domain.CreditLot
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	c, err := s.svc.GetCreditsByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	lots, err := s.svc.Lots(ctx, uid)
+	return exportData{Credit: c, Lots: lots}, err
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
+	"github.com/ecodeclub/webook/internal/credit/internal/repository"
+)
+
+var (
+	ErrLedgerChanged         = repository.ErrLedgerChanged
+	ErrLedgerMismatchHandled = repository.ErrLedgerMismatchHandled
+	ErrLockedCreditsMismatch = errors.New("预扣积分和流水对不上")
+)
+
+// LedgerService 核对积分账户的余额和流水是否一致
+type LedgerService interface {
+	// Verify 分批核对全部积分账户，每一批都在同一个一致性快照里读取账户和流水，返回这一次的对账报告
+	Verify(ctx context.Context, batchSize int) (domain.LedgerReport, error)
+	Reports(ctx context.Context, offset, limit int) ([]domain.LedgerReport, int64, error)
+	Mismatches(ctx context.Context, reportId int64, offset, limit int) ([]domain.LedgerMismatch, int64, error)
+	// Compensate 管理员确认账户余额没有问题之后，写一条补偿流水让流水和余额对上。
+	// 预扣积分对不上的没办法补偿，账户在对账之后发生变化的需要重新对账
+	Compensate(ctx context.Context, id, operator int64) error
+	// Ignore 管理员确认不需要处理
+	Ignore(ctx context.Context, id, operator int64) error
+}
+
+type ledgerService struct {
+	repo repository.LedgerRepository
+}
+
+func NewLedgerService(repo repository.LedgerRepository) LedgerService {
+	return &ledgerService{repo: repo}
+}
+
+func (s *ledgerService) Verify(ctx context.Context, batchSize int) (domain.LedgerReport, error) {
+	report := domain.LedgerReport{Status: domain.LedgerReportStatusRunning}
+	id, err := s.repo.CreateReport(ctx, report)
+	if err != nil {
+		return domain.LedgerReport{}, err
+	}
+	report.ID = id
+	err = s.verify(ctx, &report, batchSize)
+	if err != nil {
+		report.Status = domain.LedgerReportStatusFailed
+		if er := s.repo.UpdateReport(ctx, report); er != nil {
+			err = errors.Join(err, er)
+		}
+		return report, err
+	}
+	report.Status = domain.LedgerReportStatusDone
+	return report, s.repo.UpdateReport(ctx, report)
+}
+
+func (s *ledgerService) verify(ctx context.Context, report *domain.LedgerReport, batchSize int) error {
+	var cursor int64
+	for {
+		snapshots, err := s.repo.Snapshot(ctx, cursor, batchSize)
+		if err != nil {
+			return fmt.Errorf("读取积分账户快照失败 %w, cursor %d", err, cursor)
+		}
+		var ms []domain.LedgerMismatch
+		for _, snap := range snapshots {
+			if int64(snap.TotalAmount) == snap.ExpectedTotalAmount &&
+				int64(snap.LockedTotalAmount) == snap.ExpectedLockedTotalAmount {
+				continue
+			}
+			ms = append(ms, domain.LedgerMismatch{
+				ReportID:                  report.ID,
+				Uid:                       snap.Uid,
+				TotalAmount:               snap.TotalAmount,
+				LockedTotalAmount:         snap.LockedTotalAmount,
+				ExpectedTotalAmount:       snap.ExpectedTotalAmount,
+				ExpectedLockedTotalAmount: snap.ExpectedLockedTotalAmount,
+				LogIDs:                    brokenLogs(snap.Logs),
+				Status:                    domain.LedgerMismatchStatusPending,
+			})
+		}
+		err = s.repo.CreateMismatches(ctx, ms)
+		if err != nil {
+			return err
+		}
+		report.Checked += int64(len(snapshots))
+		report.Mismatched += int64(len(ms))
+		if len(snapshots) < batchSize {
+			return nil
+		}
+		// 更新进度，方便在后台看到执行到哪里了
+		err = s.repo.UpdateReport(ctx, *report)
+		if err != nil {
+			return err
+		}
+		cursor = snapshots[len(snapshots)-1].CreditID
+	}
+}
+
+// brokenLogs 找出记录的变动后余额和前面的流水接不上的流水。
+// 按照 ID 的顺序重放流水，每条流水在创建的时候加上自己的变动，
+// 取消了的预扣在取消的时候，也就是 Utime，再把扣掉的积分加回来
+func brokenLogs(logs []repository.LedgerLog) []int64 {
+	var res []int64
+	var balance int64
+	// 已经取消但是还没有加回来的预扣
+	var cancels []repository.LedgerLog
+	for _, l := range logs {
+		var restored int64
+		restored, cancels = restore(cancels, func(c repository.LedgerLog) bool {
+			return c.Utime < l.Ctime
+		})
+		balance += restored
+		if balance+l.ChangeAmount != int64(l.Balance) {
+			// 同一毫秒里面取消的预扣，没办法确定先后顺序，加回来再试一下
+			var tied int64
+			var left []repository.LedgerLog
+			tied, left = restore(cancels, func(c repository.LedgerLog) bool {
+				return c.Utime == l.Ctime
+			})
+			if tied != 0 && balance+tied+l.ChangeAmount == int64(l.Balance) {
+				cancels = left
+			} else {
+				res = append(res, l.ID)
+			}
+		}
+		// 以流水上记录的余额为准继续往后核对，一处错误不会牵连后面的流水
+		balance = int64(l.Balance)
+		if l.Inactive {
+			cancels = append(cancels, l)
+		}
+	}
+	return res
+}
+
+// restore 把满足条件的取消了的预扣加回来，返回加回来的积分和剩下的预扣
+func restore(cancels []repository.LedgerLog, match func(c repository.LedgerLog) bool) (int64, []repository.LedgerLog) {
+	var res int64
+	left := cancels[:0:0]
+	for _, c := range cancels {
+		if match(c) {
+			res -= c.ChangeAmount
+			continue
+		}
+		left = append(left, c)
+	}
+	return res, left
+}
+
+func (s *ledgerService) Reports(ctx context.Context, offset, limit int) ([]domain.LedgerReport, int64, error) {
+	return s.repo.FindReports(ctx, offset, limit)
+}
+
+func (s *ledgerService) Mismatches(ctx context.Context, reportId int64, offset, limit int) ([]domain.LedgerMismatch, int64, error) {
+	return s.repo.FindMismatches(ctx, reportId, offset, limit)
+}
+
+func (s *ledgerService) Compensate(ctx context.Context, id, operator int64) error {
+	m, err := s.repo.FindMismatchByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !m.LockedMatched() {
+		return fmt.Errorf("%w, id %d", ErrLockedCreditsMismatch, id)
+	}
+	return s.repo.Compensate(ctx, id, operator)
+}
+
+func (s *ledgerService) Ignore(ctx context.Context, id, operator int64) error {
+	return s.repo.Ignore(ctx, id, operator)
+}
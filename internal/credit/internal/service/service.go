@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ecodeclub/webook/internal/credit/internal/domain"
 	"github.com/ecodeclub/webook/internal/credit/internal/repository"
@@ -25,12 +26,16 @@ import (
 )
 
 var (
-	ErrCreditNotEnough     = repository.ErrCreditNotEnough
-	ErrDuplicatedCreditLog = repository.ErrDuplicatedCreditLog
-	ErrInvalidCreditLog    = errors.New("积分流水信息非法")
-	ErrRecordNotFound      = repository.ErrRecordNotFound
+	ErrCreditNotEnough       = repository.ErrCreditNotEnough
+	ErrDuplicatedCreditLog   = repository.ErrDuplicatedCreditLog
+	ErrInvalidCreditLog      = errors.New("积分流水信息非法")
+	ErrRecordNotFound        = repository.ErrRecordNotFound
+	ErrInvalidStatementQuery = errors.New("积分明细查询条件非法")
 )
 
+// maxStatementLimit 积分明细一页最多多少条
+const maxStatementLimit = 100
+
 //go:generate mockgen -source=./service.go -destination=../../mocks/credit.mock.go -package=creditmocks -typed Service
 type Service interface {
 	AddCredits(ctx context.Context, credit domain.Credit) error
@@ -39,23 +44,91 @@ type Service interface {
 	ConfirmDeductCredits(ctx context.Context, uid, tid int64) error
 	CancelDeductCredits(ctx context.Context, uid, tid int64) error
 	FindExpiredLockedCreditLogs(ctx context.Context, offset int, limit int, ctime int64) ([]domain.CreditLog, int64, error)
+	// MergeCredits 合并账号的时候把源账号的可用积分转到目标账号，mergeId 是合并记录的 ID，用于去重
+	MergeCredits(ctx context.Context, mergeId, sourceUid, targetUid int64) error
+	// DeleteUserData 注销账号的时候删除积分账户和全部流水，没有兑换的积分也一并作废
+	DeleteUserData(ctx context.Context, uid int64) error
+	// Lots 还有剩余积分的批次，按照扣减的顺序排列，也就是快过期的排在前面
+	Lots(ctx context.Context, uid int64) ([]domain.CreditLot, error)
+	// ExpireLots 作废一批已经过期的批次，返回处理了多少个
+	ExpireLots(ctx context.Context, limit int) (int, error)
+	// Statement 积分明细，按照流水 ID 倒序，用最后一条的 ID 作为下一页的 Cursor
+	Statement(ctx context.Context, q domain.StatementQuery) ([]domain.StatementEntry, error)
+	// MonthlySummaries 按月汇总符合条件的已经生效的积分变动，最近的月份排在前面
+	MonthlySummaries(ctx context.Context, q domain.StatementQuery) ([]domain.MonthlySummary, error)
 }
 
 type service struct {
 	repo repository.CreditRepository
+	// validities 不同来源的积分的默认有效期，key 是 Biz，没有配置的永不过期
+	validities map[string]time.Duration
 }
 
-func NewCreditService(repo repository.CreditRepository) Service {
-	return &service{repo: repo}
+func NewCreditService(repo repository.CreditRepository, validities map[string]time.Duration) Service {
+	return &service{repo: repo, validities: validities}
 }
 
 func (s *service) AddCredits(ctx context.Context, credit domain.Credit) error {
 	if len(credit.Logs) != 1 {
 		return fmt.Errorf("%w", ErrInvalidCreditLog)
 	}
+	if credit.Logs[0].ExpireAt == 0 {
+		if validity := s.validities[credit.Logs[0].Biz]; validity > 0 {
+			credit.Logs[0].ExpireAt = time.Now().Add(validity).UnixMilli()
+		}
+	}
 	return s.repo.AddCredits(ctx, credit)
 }
 
+func (s *service) Lots(ctx context.Context, uid int64) ([]domain.CreditLot, error) {
+	return s.repo.FindLotsByUID(ctx, uid)
+}
+
+func (s *service) ExpireLots(ctx context.Context, limit int) (int, error) {
+	now := time.Now().UnixMilli()
+	lots, err := s.repo.FindExpiredLots(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, lot := range lots {
+		err = s.repo.ExpireLot(ctx, lot.ID, now)
+		if err != nil {
+			return 0, fmt.Errorf("作废过期的积分批次失败 %w, lot %d", err, lot.ID)
+		}
+	}
+	return len(lots), nil
+}
+
+func (s *service) Statement(ctx context.Context, q domain.StatementQuery) ([]domain.StatementEntry, error) {
+	if err := s.checkStatementQuery(q); err != nil {
+		return nil, err
+	}
+	if q.Limit <= 0 || q.Limit > maxStatementLimit {
+		return nil, fmt.Errorf("%w: limit %d", ErrInvalidStatementQuery, q.Limit)
+	}
+	return s.repo.FindStatement(ctx, q)
+}
+
+func (s *service) MonthlySummaries(ctx context.Context, q domain.StatementQuery) ([]domain.MonthlySummary, error) {
+	if err := s.checkStatementQuery(q); err != nil {
+		return nil, err
+	}
+	return s.repo.MonthlySummaries(ctx, q)
+}
+
+func (s *service) checkStatementQuery(q domain.StatementQuery) error {
+	if q.Uid <= 0 {
+		return fmt.Errorf("%w: uid %d", ErrInvalidStatementQuery, q.Uid)
+	}
+	if !q.Direction.Valid() {
+		return fmt.Errorf("%w: direction %d", ErrInvalidStatementQuery, q.Direction)
+	}
+	if q.StartTime > 0 && q.EndTime > 0 && q.StartTime >= q.EndTime {
+		return fmt.Errorf("%w: 开始时间 %d 不早于结束时间 %d", ErrInvalidStatementQuery, q.StartTime, q.EndTime)
+	}
+	return nil
+}
+
 func (s *service) GetCreditsByUID(ctx context.Context, uid int64) (domain.Credit, error) {
 	c, err := s.repo.GetCreditByUID(ctx, uid)
 	if errors.Is(err, ErrRecordNotFound) {
@@ -83,6 +156,14 @@ func (s *service) CancelDeductCredits(ctx context.Context, uid, tid int64) error
 	return s.repo.CancelDeductCredits(ctx, uid, tid)
 }
 
+func (s *service) MergeCredits(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	return s.repo.MergeCredits(ctx, mergeId, sourceUid, targetUid)
+}
+
+func (s *service) DeleteUserData(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
+
 func (s *service) FindExpiredLockedCreditLogs(ctx context.Context, offset int, limit int, ctime int64) ([]domain.CreditLog, int64, error) {
 	var (
 		eg    errgroup.Group
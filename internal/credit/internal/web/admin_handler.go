@@ -0,0 +1,47 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 管理后台查看任意用户的积分明细，用于处理积分相关的客诉
+type AdminHandler struct {
+	svc  service.Service
+	rbac *middleware.CheckRBACMiddlewareBuilder
+}
+
+func NewAdminHandler(svc service.Service, rbac *middleware.CheckRBACMiddlewareBuilder) *AdminHandler {
+	return &AdminHandler{svc: svc, rbac: rbac}
+}
+
+func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
+	// 任意用户的积分明细属于个人数据，需要 (credit, statement) 权限
+	g := server.Group("/credit", h.rbac.Build("credit", "statement"))
+	g.POST("/statement", ginx.B[AdminStatementReq](h.Statement))
+	g.POST("/statement/summary", ginx.B[AdminStatementReq](h.MonthlySummaries))
+}
+
+func (h *AdminHandler) Statement(ctx *ginx.Context, req AdminStatementReq) (ginx.Result, error) {
+	return statement(ctx, h.svc, req.Uid, req.StatementReq)
+}
+
+func (h *AdminHandler) MonthlySummaries(ctx *ginx.Context, req AdminStatementReq) (ginx.Result, error) {
+	return monthlySummaries(ctx, h.svc, req.Uid, req.StatementReq)
+}
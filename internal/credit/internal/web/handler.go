@@ -15,12 +15,20 @@
 package web
 
 import (
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
 	"github.com/ecodeclub/webook/internal/credit/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
+// expiringWindow 多久之内过期的积分算作即将过期
+const expiringWindow = 30 * 24 * time.Hour
+
 type Handler struct {
 	svc service.Service
 }
@@ -32,6 +40,48 @@ func NewHandler(svc service.Service) *Handler {
 func (h *Handler) PrivateRoutes(server *gin.Engine) {
 	g := server.Group("/credit")
 	g.POST("/detail", ginx.S(h.QueryCredits))
+	g.POST("/lots", ginx.S(h.Lots))
+	g.POST("/statement", ginx.BS[StatementReq](h.Statement))
+	g.POST("/statement/summary", ginx.BS[StatementReq](h.MonthlySummaries))
+}
+
+// Statement 积分明细，每一条都带上来源，方便跳转到对应的订单或者 AI 调用
+func (h *Handler) Statement(ctx *ginx.Context, req StatementReq, sess session.Session) (ginx.Result, error) {
+	return statement(ctx, h.svc, sess.Claims().Uid, req)
+}
+
+func (h *Handler) MonthlySummaries(ctx *ginx.Context, req StatementReq, sess session.Session) (ginx.Result, error) {
+	return monthlySummaries(ctx, h.svc, sess.Claims().Uid, req)
+}
+
+func statement(ctx *ginx.Context, svc service.Service, uid int64, req StatementReq) (ginx.Result, error) {
+	entries, err := svc.Statement(ctx.Request.Context(), req.toDomain(uid))
+	switch {
+	case err == nil:
+		return ginx.Result{Data: newStatement(entries, req.Limit)}, nil
+	case errors.Is(err, service.ErrInvalidStatementQuery):
+		return invalidStatementQueryResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
+
+func monthlySummaries(ctx *ginx.Context, svc service.Service, uid int64, req StatementReq) (ginx.Result, error) {
+	res, err := svc.MonthlySummaries(ctx.Request.Context(), req.toDomain(uid))
+	switch {
+	case err == nil:
+		return ginx.Result{Data: slice.Map(res, func(idx int, src domain.MonthlySummary) MonthlySummary {
+			return MonthlySummary{
+				Month:    src.Month,
+				Increase: src.Increase,
+				Decrease: src.Decrease,
+			}
+		})}, nil
+	case errors.Is(err, service.ErrInvalidStatementQuery):
+		return invalidStatementQueryResult, nil
+	default:
+		return systemErrorResult, err
+	}
 }
 
 func (h *Handler) QueryCredits(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
@@ -42,3 +92,22 @@ func (h *Handler) QueryCredits(ctx *ginx.Context, sess session.Session) (ginx.Re
 		},
 	}, nil
 }
+
+func (h *Handler) Lots(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	lots, err := h.svc.Lots(ctx.Request.Context(), sess.Claims().Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	res := CreditLots{
+		Lots: slice.Map(lots, func(idx int, src domain.CreditLot) CreditLot {
+			return newCreditLot(src)
+		}),
+		ExpiringBefore: time.Now().Add(expiringWindow).UnixMilli(),
+	}
+	for _, lot := range lots {
+		if lot.ExpireAt > 0 && lot.ExpireAt <= res.ExpiringBefore {
+			res.ExpiringAmount += lot.Remaining
+		}
+	}
+	return ginx.Result{Data: res}, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
+	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// LedgerHandler 管理后台查看对账报告，处理余额和流水对不上的积分账户
+type LedgerHandler struct {
+	svc  service.LedgerService
+	rbac *middleware.CheckRBACMiddlewareBuilder
+}
+
+func NewLedgerHandler(svc service.LedgerService, rbac *middleware.CheckRBACMiddlewareBuilder) *LedgerHandler {
+	return &LedgerHandler{svc: svc, rbac: rbac}
+}
+
+func (h *LedgerHandler) PrivateRoutes(server *gin.Engine) {
+	g := server.Group("/credit/ledger")
+	g.POST("/reports", ginx.B[Page](h.Reports))
+	g.POST("/mismatches", ginx.B[MismatchListReq](h.Mismatches))
+	// 补偿会改动积分流水，需要 (credit, ledger) 权限
+	manage := h.rbac.Build("credit", "ledger")
+	g.POST("/mismatches/compensate", manage, ginx.BS[MismatchIDReq](h.Compensate))
+	g.POST("/mismatches/ignore", manage, ginx.BS[MismatchIDReq](h.Ignore))
+}
+
+func (h *LedgerHandler) Reports(ctx *ginx.Context, req Page) (ginx.Result, error) {
+	reports, total, err := h.svc.Reports(ctx.Request.Context(), req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: LedgerReportList{
+		Reports: slice.Map(reports, func(idx int, src domain.LedgerReport) LedgerReport {
+			return newLedgerReport(src)
+		}),
+		Total: total,
+	}}, nil
+}
+
+func (h *LedgerHandler) Mismatches(ctx *ginx.Context, req MismatchListReq) (ginx.Result, error) {
+	ms, total, err := h.svc.Mismatches(ctx.Request.Context(), req.ReportId, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: LedgerMismatchList{
+		Mismatches: slice.Map(ms, func(idx int, src domain.LedgerMismatch) LedgerMismatch {
+			return newLedgerMismatch(src)
+		}),
+		Total: total,
+	}}, nil
+}
+
+func (h *LedgerHandler) Compensate(ctx *ginx.Context, req MismatchIDReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.Compensate(ctx.Request.Context(), req.Id, sess.Claims().Uid)
+	return h.handleResult(err)
+}
+
+func (h *LedgerHandler) Ignore(ctx *ginx.Context, req MismatchIDReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.Ignore(ctx.Request.Context(), req.Id, sess.Claims().Uid)
+	return h.handleResult(err)
+}
+
+func (h *LedgerHandler) handleResult(err error) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case errors.Is(err, service.ErrLedgerMismatchHandled):
+		return ledgerMismatchHandledResult, nil
+	case errors.Is(err, service.ErrLedgerChanged):
+		return ledgerChangedResult, nil
+	case errors.Is(err, service.ErrLockedCreditsMismatch):
+		return lockedCreditsMismatchResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
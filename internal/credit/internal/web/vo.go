@@ -14,7 +14,229 @@
 
 package web
 
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/credit/internal/domain"
+)
+
 type Credit struct {
 	// 可用积分余额
 	Amount uint64 `json:"amount"`
 }
+
+type CreditLots struct {
+	// Lots 还有剩余积分的批次，快过期的排在前面
+	Lots []CreditLot `json:"lots"`
+	// ExpiringAmount 在 ExpiringBefore 之前就会过期的积分
+	ExpiringAmount uint64 `json:"expiringAmount"`
+	ExpiringBefore int64  `json:"expiringBefore"`
+}
+
+type CreditLot struct {
+	Id int64 `json:"id"`
+	// Biz 积分来源，比如说 user 是邀请奖励，order 是购买
+	Biz       string `json:"biz"`
+	BizId     int64  `json:"bizId"`
+	Amount    uint64 `json:"amount"`
+	Remaining uint64 `json:"remaining"`
+	// ExpireAt 为 0 表示永不过期
+	ExpireAt int64 `json:"expireAt"`
+	Ctime    int64 `json:"ctime"`
+}
+
+func newCreditLot(lot domain.CreditLot) CreditLot {
+	return CreditLot{
+		Id:        lot.ID,
+		Biz:       lot.Biz,
+		BizId:     lot.BizId,
+		Amount:    lot.Amount,
+		Remaining: lot.Remaining,
+		ExpireAt:  lot.ExpireAt,
+		Ctime:     lot.Ctime,
+	}
+}
+
+type StatementReq struct {
+	// Cursor 上一页返回的 NextCursor，第一页传 0
+	Cursor int64 `json:"cursor"`
+	Limit  int   `json:"limit"`
+	// Bizs 积分来源，例如 ai-llm, order, user，为空的时候不限
+	Bizs []string `json:"bizs"`
+	// Direction 0 不限，1 增加，2 扣减
+	Direction uint8 `json:"direction"`
+	// StartTime 和 EndTime 单位毫秒，左闭右开，为 0 的时候不限
+	StartTime int64 `json:"startTime"`
+	EndTime   int64 `json:"endTime"`
+}
+
+func (r StatementReq) toDomain(uid int64) domain.StatementQuery {
+	return domain.StatementQuery{
+		Uid:       uid,
+		Cursor:    r.Cursor,
+		Limit:     r.Limit,
+		Bizs:      r.Bizs,
+		Direction: domain.Direction(r.Direction),
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+	}
+}
+
+type AdminStatementReq struct {
+	Uid int64 `json:"uid"`
+	StatementReq
+}
+
+type Statement struct {
+	Entries []StatementEntry `json:"entries"`
+	// NextCursor 下一页的 Cursor，HasMore 为 false 的时候没有意义
+	NextCursor int64 `json:"nextCursor"`
+	HasMore    bool  `json:"hasMore"`
+}
+
+func newStatement(entries []domain.StatementEntry, limit int) Statement {
+	res := Statement{
+		Entries: slice.Map(entries, func(idx int, src domain.StatementEntry) StatementEntry {
+			return newStatementEntry(src)
+		}),
+		HasMore: len(entries) == limit,
+	}
+	if len(entries) > 0 {
+		res.NextCursor = entries[len(entries)-1].ID
+	}
+	return res
+}
+
+type StatementEntry struct {
+	Id    int64  `json:"id"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	Desc  string `json:"desc"`
+	// ChangeAmount 正数为增加，负数为扣减
+	ChangeAmount int64  `json:"changeAmount"`
+	Balance      uint64 `json:"balance"`
+	// Status 1 已生效，2 预扣中
+	Status uint8 `json:"status"`
+	Ctime  int64 `json:"ctime"`
+	// Link 积分来源是订单或者 AI 调用的时候才有
+	Link *Link `json:"link,omitempty"`
+}
+
+// Link 前端根据 Type 跳转到对应的详情页
+type Link struct {
+	// Type 目前只有 order 和 ai
+	Type string `json:"type"`
+	Id   int64  `json:"id"`
+	// SN 订单号，订单详情是按照订单号查询的，积分支付的流水才有
+	SN string `json:"sn,omitempty"`
+}
+
+func newStatementEntry(e domain.StatementEntry) StatementEntry {
+	res := StatementEntry{
+		Id:           e.ID,
+		Biz:          e.Biz,
+		BizId:        e.BizId,
+		Desc:         e.Desc,
+		ChangeAmount: e.ChangeAmount,
+		Balance:      e.Balance,
+		Status:       uint8(e.Status),
+		Ctime:        e.Ctime,
+	}
+	switch e.Biz {
+	case "order":
+		res.Link = &Link{Type: "order", Id: e.BizId}
+		// 积分支付的时候用订单号作为流水的去重 key
+		if e.ChangeAmount < 0 {
+			res.Link.SN = e.Key
+		}
+	case "ai-llm":
+		res.Link = &Link{Type: "ai", Id: e.BizId}
+	}
+	return res
+}
+
+type MonthlySummary struct {
+	// Month 格式为 2006-01
+	Month    string `json:"month"`
+	Increase uint64 `json:"increase"`
+	Decrease uint64 `json:"decrease"`
+}
+
+type Page struct {
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+}
+
+type LedgerReportList struct {
+	Reports []LedgerReport `json:"reports"`
+	Total   int64          `json:"total"`
+}
+
+type LedgerReport struct {
+	Id int64 `json:"id"`
+	// Status 1 进行中，2 已完成，3 失败
+	Status     uint8 `json:"status"`
+	Checked    int64 `json:"checked"`
+	Mismatched int64 `json:"mismatched"`
+	Ctime      int64 `json:"ctime"`
+	Utime      int64 `json:"utime"`
+}
+
+func newLedgerReport(r domain.LedgerReport) LedgerReport {
+	return LedgerReport{
+		Id:         r.ID,
+		Status:     uint8(r.Status),
+		Checked:    r.Checked,
+		Mismatched: r.Mismatched,
+		Ctime:      r.Ctime,
+		Utime:      r.Utime,
+	}
+}
+
+type MismatchListReq struct {
+	ReportId int64 `json:"reportId"`
+	Offset   int   `json:"offset,omitempty"`
+	Limit    int   `json:"limit,omitempty"`
+}
+
+type MismatchIDReq struct {
+	Id int64 `json:"id"`
+}
+
+type LedgerMismatchList struct {
+	Mismatches []LedgerMismatch `json:"mismatches"`
+	Total      int64            `json:"total"`
+}
+
+type LedgerMismatch struct {
+	Id                        int64  `json:"id"`
+	ReportId                  int64  `json:"reportId"`
+	Uid                       int64  `json:"uid"`
+	TotalAmount               uint64 `json:"totalAmount"`
+	LockedTotalAmount         uint64 `json:"lockedTotalAmount"`
+	ExpectedTotalAmount       int64  `json:"expectedTotalAmount"`
+	ExpectedLockedTotalAmount int64  `json:"expectedLockedTotalAmount"`
+	// LogIds 和前面的流水接不上的流水
+	LogIds []int64 `json:"logIds"`
+	// Status 1 待处理，2 已补偿，3 已忽略
+	Status   uint8 `json:"status"`
+	Operator int64 `json:"operator"`
+	Ctime    int64 `json:"ctime"`
+	Utime    int64 `json:"utime"`
+}
+
+func newLedgerMismatch(m domain.LedgerMismatch) LedgerMismatch {
+	return LedgerMismatch{
+		Id:                        m.ID,
+		ReportId:                  m.ReportID,
+		Uid:                       m.Uid,
+		TotalAmount:               m.TotalAmount,
+		LockedTotalAmount:         m.LockedTotalAmount,
+		ExpectedTotalAmount:       m.ExpectedTotalAmount,
+		ExpectedLockedTotalAmount: m.ExpectedLockedTotalAmount,
+		LogIds:                    m.LogIDs,
+		Status:                    uint8(m.Status),
+		Operator:                  m.Operator,
+		Ctime:                     m.Ctime,
+		Utime:                     m.Utime,
+	}
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./service.go -destination=../../mocks/credit.mock.go -package=creditmocks -typed Service
 //
+
 // Package creditmocks is a generated GoMock package.
 package creditmocks
 
@@ -153,6 +154,83 @@ func (c *ServiceConfirmDeductCreditsCall) DoAndReturn(f func(context.Context, in
 	return c
 }
 
+// DeleteUserData mocks base method.
+func (m *MockService) DeleteUserData(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserData", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserData indicates an expected call of DeleteUserData.
+func (mr *MockServiceMockRecorder) DeleteUserData(ctx, uid any) *ServiceDeleteUserDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserData", reflect.TypeOf((*MockService)(nil).DeleteUserData), ctx, uid)
+	return &ServiceDeleteUserDataCall{Call: call}
+}
+
+// ServiceDeleteUserDataCall wrap *gomock.Call
+type ServiceDeleteUserDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceDeleteUserDataCall) Return(arg0 error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceDeleteUserDataCall) Do(f func(context.Context, int64) error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceDeleteUserDataCall) DoAndReturn(f func(context.Context, int64) error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ExpireLots mocks base method.
+func (m *MockService) ExpireLots(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireLots", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireLots indicates an expected call of ExpireLots.
+func (mr *MockServiceMockRecorder) ExpireLots(ctx, limit any) *ServiceExpireLotsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireLots", reflect.TypeOf((*MockService)(nil).ExpireLots), ctx, limit)
+	return &ServiceExpireLotsCall{Call: call}
+}
+
+// ServiceExpireLotsCall wrap *gomock.Call
+type ServiceExpireLotsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceExpireLotsCall) Return(arg0 int, arg1 error) *ServiceExpireLotsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceExpireLotsCall) Do(f func(context.Context, int) (int, error)) *ServiceExpireLotsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceExpireLotsCall) DoAndReturn(f func(context.Context, int) (int, error)) *ServiceExpireLotsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // FindExpiredLockedCreditLogs mocks base method.
 func (m *MockService) FindExpiredLockedCreditLogs(ctx context.Context, offset, limit int, ctime int64) ([]domain.CreditLog, int64, error) {
 	m.ctrl.T.Helper()
@@ -232,6 +310,161 @@ func (c *ServiceGetCreditsByUIDCall) DoAndReturn(f func(context.Context, int64)
 	return c
 }
 
+// Lots mocks base method.
+func (m *MockService) Lots(ctx context.Context, uid int64) ([]domain.CreditLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lots", ctx, uid)
+	ret0, _ := ret[0].([]domain.CreditLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lots indicates an expected call of Lots.
+func (mr *MockServiceMockRecorder) Lots(ctx, uid any) *ServiceLotsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lots", reflect.TypeOf((*MockService)(nil).Lots), ctx, uid)
+	return &ServiceLotsCall{Call: call}
+}
+
+// ServiceLotsCall wrap *gomock.Call
+type ServiceLotsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceLotsCall) Return(arg0 []domain.CreditLot, arg1 error) *ServiceLotsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceLotsCall) Do(f func(context.Context, int64) ([]domain.CreditLot, error)) *ServiceLotsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceLotsCall) DoAndReturn(f func(context.Context, int64) ([]domain.CreditLot, error)) *ServiceLotsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MergeCredits mocks base method.
+func (m *MockService) MergeCredits(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCredits", ctx, mergeId, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCredits indicates an expected call of MergeCredits.
+func (mr *MockServiceMockRecorder) MergeCredits(ctx, mergeId, sourceUid, targetUid any) *ServiceMergeCreditsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCredits", reflect.TypeOf((*MockService)(nil).MergeCredits), ctx, mergeId, sourceUid, targetUid)
+	return &ServiceMergeCreditsCall{Call: call}
+}
+
+// ServiceMergeCreditsCall wrap *gomock.Call
+type ServiceMergeCreditsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceMergeCreditsCall) Return(arg0 error) *ServiceMergeCreditsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceMergeCreditsCall) Do(f func(context.Context, int64, int64, int64) error) *ServiceMergeCreditsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceMergeCreditsCall) DoAndReturn(f func(context.Context, int64, int64, int64) error) *ServiceMergeCreditsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MonthlySummaries mocks base method.
+func (m *MockService) MonthlySummaries(ctx context.Context, q domain.StatementQuery) ([]domain.MonthlySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonthlySummaries", ctx, q)
+	ret0, _ := ret[0].([]domain.MonthlySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonthlySummaries indicates an expected call of MonthlySummaries.
+func (mr *MockServiceMockRecorder) MonthlySummaries(ctx, q any) *ServiceMonthlySummariesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonthlySummaries", reflect.TypeOf((*MockService)(nil).MonthlySummaries), ctx, q)
+	return &ServiceMonthlySummariesCall{Call: call}
+}
+
+// ServiceMonthlySummariesCall wrap *gomock.Call
+type ServiceMonthlySummariesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceMonthlySummariesCall) Return(arg0 []domain.MonthlySummary, arg1 error) *ServiceMonthlySummariesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceMonthlySummariesCall) Do(f func(context.Context, domain.StatementQuery) ([]domain.MonthlySummary, error)) *ServiceMonthlySummariesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceMonthlySummariesCall) DoAndReturn(f func(context.Context, domain.StatementQuery) ([]domain.MonthlySummary, error)) *ServiceMonthlySummariesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Statement mocks base method.
+func (m *MockService) Statement(ctx context.Context, q domain.StatementQuery) ([]domain.StatementEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statement", ctx, q)
+	ret0, _ := ret[0].([]domain.StatementEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Statement indicates an expected call of Statement.
+func (mr *MockServiceMockRecorder) Statement(ctx, q any) *ServiceStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statement", reflect.TypeOf((*MockService)(nil).Statement), ctx, q)
+	return &ServiceStatementCall{Call: call}
+}
+
+// ServiceStatementCall wrap *gomock.Call
+type ServiceStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceStatementCall) Return(arg0 []domain.StatementEntry, arg1 error) *ServiceStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceStatementCall) Do(f func(context.Context, domain.StatementQuery) ([]domain.StatementEntry, error)) *ServiceStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceStatementCall) DoAndReturn(f func(context.Context, domain.StatementQuery) ([]domain.StatementEntry, error)) *ServiceStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // TryDeductCredits mocks base method.
 func (m *MockService) TryDeductCredits(ctx context.Context, credit domain.Credit) (int64, error) {
 	m.ctrl.T.Helper()
@@ -17,11 +17,19 @@ package credit
 import (
 	"github.com/ecodeclub/webook/internal/credit/internal/event"
 	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
 )
 
 type Module struct {
 	Hdl                          *web.Handler
+	AdminHdl                     *web.AdminHandler
+	LedgerHdl                    *web.LedgerHandler
 	Svc                          Service
 	c                            *event.CreditIncreaseConsumer
+	mc                           *event.UserMergeConsumer
+	dc                           *event.UserDeletionConsumer
+	ExportSource                 exportx.Source
 	CloseTimeoutLockedCreditsJob *CloseTimeoutLockedCreditsJob
+	ExpireCreditLotsJob          *ExpireCreditLotsJob
+	VerifyCreditLedgerJob        *VerifyCreditLedgerJob
 }
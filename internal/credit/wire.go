@@ -19,6 +19,7 @@ package credit
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/mq-api"
@@ -29,8 +30,11 @@ import (
 	"github.com/ecodeclub/webook/internal/credit/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/credit/internal/service"
 	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
+	"github.com/gotomicro/ego/core/econf"
 )
 
 type (
@@ -38,16 +42,31 @@ type (
 	CreditLog                    = domain.CreditLog
 	Service                      = service.Service
 	Handler                      = web.Handler
+	AdminHandler                 = web.AdminHandler
+	LedgerHandler                = web.LedgerHandler
+	LedgerService                = service.LedgerService
 	CloseTimeoutLockedCreditsJob = job.CloseTimeoutLockedCreditsJob
+	ExpireCreditLotsJob          = job.ExpireCreditLotsJob
+	VerifyCreditLedgerJob        = job.VerifyCreditLedgerJob
 )
 
-func InitModule(db *egorm.Component, q mq.MQ, e ecache.Cache) (*Module, error) {
+func InitModule(db *egorm.Component, q mq.MQ, e ecache.Cache, permModule *permission.Module) (*Module, error) {
 	wire.Build(wire.Struct(
 		new(Module), "*"),
 		InitService,
 		InitHandler,
+		web.NewAdminHandler,
+		InitLedgerService,
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
+		web.NewLedgerHandler,
 		initCreditConsumer,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewExportSource,
 		initCloseTimeoutLockedCreditsJob,
+		initExpireCreditLotsJob,
+		initVerifyCreditLedgerJob,
 	)
 	return new(Module), nil
 }
@@ -62,11 +81,28 @@ func InitService(db *egorm.Component) Service {
 		_ = dao.InitTables(db)
 		d := dao.NewCreditGORMDAO(db)
 		r := repository.NewCreditRepository(d)
-		svc = service.NewCreditService(r)
+		svc = service.NewCreditService(r, initValidities())
 	})
 	return svc
 }
 
+// initValidities 不同来源的积分的有效期，没有配置的时候积分永不过期，和引入批次之前的行为一致
+func initValidities() map[string]time.Duration {
+	var res map[string]time.Duration
+	if econf.Get("credit.validities") == nil {
+		return res
+	}
+	err := econf.UnmarshalKey("credit.validities", &res)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func InitLedgerService(db *egorm.Component) LedgerService {
+	return service.NewLedgerService(repository.NewLedgerRepository(dao.NewGORMLedgerDAO(db)))
+}
+
 func InitHandler(srv service.Service) *Handler {
 	return web.NewHandler(svc)
 }
@@ -80,9 +116,35 @@ func initCreditConsumer(svc service.Service, q mq.MQ) *event.CreditIncreaseConsu
 	return c
 }
 
+func initUserMergeConsumer(svc service.Service, q mq.MQ) *event.UserMergeConsumer {
+	c, err := event.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
 func initCloseTimeoutLockedCreditsJob(svc service.Service) *CloseTimeoutLockedCreditsJob {
 	minutes := int64(30)
 	seconds := int64(10)
 	limit := 100
 	return job.NewCloseTimeoutLockedCreditsJob(svc, minutes, seconds, limit)
 }
+
+func initExpireCreditLotsJob(svc service.Service) *ExpireCreditLotsJob {
+	return job.NewExpireCreditLotsJob(svc, 100)
+}
+
+func initVerifyCreditLedgerJob(svc LedgerService) *VerifyCreditLedgerJob {
+	return job.NewVerifyCreditLedgerJob(svc, 100)
+}
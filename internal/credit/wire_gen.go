@@ -9,6 +9,7 @@ package credit
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/mq-api"
@@ -18,23 +19,44 @@ import (
 	"github.com/ecodeclub/webook/internal/credit/internal/repository"
 	"github.com/ecodeclub/webook/internal/credit/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/credit/internal/service"
+	service2 "github.com/ecodeclub/webook/internal/credit/internal/service"
 	"github.com/ecodeclub/webook/internal/credit/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ego-component/egorm"
+	"github.com/gotomicro/ego/core/econf"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, q mq.MQ, e ecache.Cache) (*Module, error) {
+func InitModule(db *gorm.DB, q mq.MQ, e ecache.Cache, permModule *permission.Module) (*Module, error) {
 	service := InitService(db)
 	handler := InitHandler(service)
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	adminHandler := web.NewAdminHandler(service, checkRBACMiddlewareBuilder)
+	ledgerService := InitLedgerService(db)
+	ledgerHandler := web.NewLedgerHandler(ledgerService, checkRBACMiddlewareBuilder)
 	creditIncreaseConsumer := initCreditConsumer(service, q)
+	userMergeConsumer := initUserMergeConsumer(service, q)
+	userDeletionConsumer := initUserDeletionConsumer(service, q)
+	source := service2.NewExportSource(service)
 	closeTimeoutLockedCreditsJob := initCloseTimeoutLockedCreditsJob(service)
+	expireCreditLotsJob := initExpireCreditLotsJob(service)
+	verifyCreditLedgerJob := initVerifyCreditLedgerJob(ledgerService)
 	module := &Module{
 		Hdl:                          handler,
+		AdminHdl:                     adminHandler,
+		LedgerHdl:                    ledgerHandler,
 		Svc:                          service,
 		c:                            creditIncreaseConsumer,
+		mc:                           userMergeConsumer,
+		dc:                           userDeletionConsumer,
+		ExportSource:                 source,
 		CloseTimeoutLockedCreditsJob: closeTimeoutLockedCreditsJob,
+		ExpireCreditLotsJob:          expireCreditLotsJob,
+		VerifyCreditLedgerJob:        verifyCreditLedgerJob,
 	}
 	return module, nil
 }
@@ -46,7 +68,12 @@ type (
 	CreditLog                    = domain.CreditLog
 	Service                      = service.Service
 	Handler                      = web.Handler
+	AdminHandler                 = web.AdminHandler
+	LedgerHandler                = web.LedgerHandler
+	LedgerService                = service.LedgerService
 	CloseTimeoutLockedCreditsJob = job.CloseTimeoutLockedCreditsJob
+	ExpireCreditLotsJob          = job.ExpireCreditLotsJob
+	VerifyCreditLedgerJob        = job.VerifyCreditLedgerJob
 )
 
 var (
@@ -59,11 +86,28 @@ func InitService(db *egorm.Component) Service {
 		_ = dao.InitTables(db)
 		d := dao.NewCreditGORMDAO(db)
 		r := repository.NewCreditRepository(d)
-		svc = service.NewCreditService(r)
+		svc = service.NewCreditService(r, initValidities())
 	})
 	return svc
 }
 
+// initValidities 不同来源的积分的有效期，没有配置的时候积分永不过期，和引入批次之前的行为一致
+func initValidities() map[string]time.Duration {
+	var res map[string]time.Duration
+	if econf.Get("credit.validities") == nil {
+		return res
+	}
+	err := econf.UnmarshalKey("credit.validities", &res)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func InitLedgerService(db *egorm.Component) LedgerService {
+	return service.NewLedgerService(repository.NewLedgerRepository(dao.NewGORMLedgerDAO(db)))
+}
+
 func InitHandler(srv service.Service) *Handler {
 	return web.NewHandler(svc)
 }
@@ -77,9 +121,35 @@ func initCreditConsumer(svc2 service.Service, q mq.MQ) *event.CreditIncreaseCons
 	return c
 }
 
+func initUserMergeConsumer(svc2 service.Service, q mq.MQ) *event.UserMergeConsumer {
+	c, err := event.NewUserMergeConsumer(svc2, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc2 service.Service, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc2, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
 func initCloseTimeoutLockedCreditsJob(svc2 service.Service) *CloseTimeoutLockedCreditsJob {
 	minutes := int64(30)
 	seconds := int64(10)
 	limit := 100
 	return job.NewCloseTimeoutLockedCreditsJob(svc2, minutes, seconds, limit)
 }
+
+func initExpireCreditLotsJob(svc2 service.Service) *ExpireCreditLotsJob {
+	return job.NewExpireCreditLotsJob(svc2, 100)
+}
+
+func initVerifyCreditLedgerJob(svc2 LedgerService) *VerifyCreditLedgerJob {
+	return job.NewVerifyCreditLedgerJob(svc2, 100)
+}
@@ -24,3 +24,19 @@ type CreditIncreaseEvent struct {
 	BizId  int64  `json:"biz_id"` // user_id=B   order_id
 	Action string `json:"action"` // 邀请注册     购买商品
 }
+
+const (
+	UserDeletionEventName     = "user_deletion_events"
+	UserDeletionDoneEventName = "user_deletion_done_events"
+)
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
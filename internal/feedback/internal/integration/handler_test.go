@@ -26,6 +26,8 @@ import (
 	"github.com/ecodeclub/webook/internal/feedback/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/feedback/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/feedback/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ecodeclub/ekit/iox"
@@ -68,14 +70,16 @@ func (s *HandlerTestSuite) TearDownTest() {
 func (s *HandlerTestSuite) SetupSuite() {
 	s.ctrl = gomock.NewController(s.T())
 	s.producer = evtmocks.NewMockIncreaseCreditsEventProducer(s.ctrl)
-	handler, err := startup.InitHandler(s.producer)
+	rbacSvc := permissionmocks.NewMockRBACService(s.ctrl)
+	rbacSvc.EXPECT().Check(gomock.Any(), int64(uid), "feedback", "manage").
+		Return(true, nil).AnyTimes()
+	handler, err := startup.InitHandler(s.producer, &permission.Module{RBACSvc: rbacSvc})
 	require.NoError(s.T(), err)
 	econf.Set("server", map[string]any{"contextTimeout": "1s"})
 	server := egin.Load("server").Build()
 	server.Use(func(ctx *gin.Context) {
 		ctx.Set("_session", session.NewMemorySession(session.Claims{
-			Uid:  uid,
-			Data: map[string]string{"creator": "true"},
+			Uid: uid,
 		}))
 	})
 	handler.MemberRoutes(server.Engine)
@@ -398,6 +402,31 @@ func (s *HandlerTestSuite) TestPendingCount() {
 }
 
 // assertFeedBack 不比较 id
+func (s *HandlerTestSuite) TestDeleteByUid() {
+	t := s.T()
+	ctx := context.Background()
+	const deletedUid, otherUid = 3001, 3002
+	for _, fb := range []dao.Feedback{
+		{Biz: "question", BizID: 1, UID: deletedUid, Content: "deleted"},
+		{Biz: "question", BizID: 1, UID: otherUid, Content: "other"},
+	} {
+		require.NoError(t, s.dao.Create(ctx, fb))
+	}
+
+	fbs, err := s.dao.FindByUid(ctx, deletedUid)
+	require.NoError(t, err)
+	require.Len(t, fbs, 1)
+	assert.Equal(t, "deleted", fbs[0].Content)
+
+	require.NoError(t, s.dao.DeleteByUid(ctx, deletedUid))
+	fbs, err = s.dao.FindByUid(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, fbs)
+	fbs, err = s.dao.FindByUid(ctx, otherUid)
+	require.NoError(t, err)
+	assert.Len(t, fbs, 1)
+}
+
 func (s *HandlerTestSuite) assertFeedBack(t *testing.T, expect dao.Feedback, feedBack dao.Feedback) {
 	assert.True(t, feedBack.ID > 0)
 	assert.True(t, feedBack.Ctime > 0)
@@ -20,11 +20,16 @@ import (
 	"github.com/ecodeclub/webook/internal/feedback"
 	"github.com/ecodeclub/webook/internal/feedback/internal/event"
 	"github.com/ecodeclub/webook/internal/feedback/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 	"github.com/google/wire"
 )
 
-func InitHandler(p event.IncreaseCreditsEventProducer) (*web.Handler, error) {
-	wire.Build(testioc.BaseSet, feedback.InitService, web.NewHandler)
+func InitHandler(p event.IncreaseCreditsEventProducer, permModule *permission.Module) (*web.Handler, error) {
+	wire.Build(testioc.BaseSet, feedback.InitService,
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
+		web.NewHandler)
 	return new(web.Handler), nil
 }
@@ -10,14 +10,18 @@ import (
 	"github.com/ecodeclub/webook/internal/feedback"
 	"github.com/ecodeclub/webook/internal/feedback/internal/event"
 	"github.com/ecodeclub/webook/internal/feedback/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(p event.IncreaseCreditsEventProducer) (*web.Handler, error) {
+func InitHandler(p event.IncreaseCreditsEventProducer, permModule *permission.Module) (*web.Handler, error) {
 	db := testioc.InitDB()
 	service := feedback.InitService(db, p)
-	handler := web.NewHandler(service)
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	handler := web.NewHandler(service, checkRBACMiddlewareBuilder)
 	return handler, nil
 }
@@ -32,6 +32,10 @@ type FeedbackDAO interface {
 	UpdateStatus(ctx context.Context, id int64, status int32) error
 	// Create 添加
 	Create(ctx context.Context, feedback Feedback) error
+	// FindByUid 用户提交的所有反馈
+	FindByUid(ctx context.Context, uid int64) ([]Feedback, error)
+	// DeleteByUid 删除用户提交的所有反馈
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 type feedBackDAO struct {
 	db *egorm.Component
@@ -82,6 +86,16 @@ func (f *feedBackDAO) Create(ctx context.Context, feedback Feedback) error {
 	return f.db.WithContext(ctx).Create(&feedback).Error
 }
 
+func (f *feedBackDAO) FindByUid(ctx context.Context, uid int64) ([]Feedback, error) {
+	var res []Feedback
+	err := f.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (f *feedBackDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return f.db.WithContext(ctx).Where("uid = ?", uid).Delete(&Feedback{}).Error
+}
+
 type Feedback struct {
 	ID      int64  `gorm:"primaryKey,autoIncrement"`
 	BizID   int64  `gorm:"column:biz_id;type:int;comment:业务ID;not null;index:idx_biz_biz_id;default:0"`
@@ -33,6 +33,8 @@ type FeedbackRepository interface {
 	UpdateStatus(ctx context.Context, id int64, status domain.FeedbackStatus) error
 	// Create C端: 添加
 	Create(ctx context.Context, feedback domain.Feedback) error
+	FindByUid(ctx context.Context, uid int64) ([]domain.Feedback, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type feedbackRepository struct {
@@ -74,6 +76,22 @@ func (f *feedbackRepository) Create(ctx context.Context, feedback domain.Feedbac
 	return f.dao.Create(ctx, f.toEntity(feedback))
 }
 
+func (f *feedbackRepository) FindByUid(ctx context.Context, uid int64) ([]domain.Feedback, error) {
+	fbs, err := f.dao.FindByUid(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	ans := make([]domain.Feedback, 0, len(fbs))
+	for _, fb := range fbs {
+		ans = append(ans, f.toDomain(fb))
+	}
+	return ans, nil
+}
+
+func (f *feedbackRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return f.dao.DeleteByUid(ctx, uid)
+}
+
 func (f *feedbackRepository) toDomain(fb dao.Feedback) domain.Feedback {
 	return domain.Feedback{
 		ID:      fb.ID,
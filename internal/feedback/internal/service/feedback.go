@@ -36,6 +36,10 @@ type Service interface {
 	UpdateStatus(ctx context.Context, domainFeedback domain.Feedback) error
 	// Create c端 添加
 	Create(ctx context.Context, feedback domain.Feedback) error
+	// UserFeedbacks 用户提交的所有反馈，导出个人数据的时候使用
+	UserFeedbacks(ctx context.Context, uid int64) ([]domain.Feedback, error)
+	// DeleteByUid 注销账号的时候删除用户提交的所有反馈
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type service struct {
@@ -95,3 +99,11 @@ func (s *service) Create(ctx context.Context, feedback domain.Feedback) error {
 func (s *service) List(ctx context.Context, offset, limit int) ([]domain.Feedback, error) {
 	return s.repo.List(ctx, offset, limit)
 }
+
+func (s *service) UserFeedbacks(ctx context.Context, uid int64) ([]domain.Feedback, error) {
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *service) DeleteByUid(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
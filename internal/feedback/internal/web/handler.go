@@ -15,37 +15,39 @@
 package web
 
 import (
-	"fmt"
-	"net/http"
-
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/feedback/internal/domain"
 	"github.com/ecodeclub/webook/internal/feedback/internal/service"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/elog"
 )
 
 type Handler struct {
 	svc    service.Service
+	rbac   *middleware.CheckRBACMiddlewareBuilder
 	logger *elog.Component
 }
 
-func NewHandler(svc service.Service) *Handler {
+func NewHandler(svc service.Service, rbac *middleware.CheckRBACMiddlewareBuilder) *Handler {
 	return &Handler{
 		svc:    svc,
+		rbac:   rbac,
 		logger: elog.DefaultLogger,
 	}
 }
 
 func (h *Handler) MemberRoutes(server *gin.Engine) {
+	// 处理反馈需要 (feedback, manage) 权限
+	manage := h.rbac.Build("feedback", "manage")
 	// 列表 根据交互来, 先是未处理，然后是通过，最后是拒绝
-	server.POST("/feedback/list", ginx.S(h.Permission), ginx.B(h.List))
+	server.POST("/feedback/list", manage, ginx.B(h.List))
 	// 未处理的个数
-	server.GET("/feedback/pending-count", ginx.S(h.Permission), ginx.W(h.PendingCount))
-	server.POST("/feedback/detail", ginx.S(h.Permission), ginx.B(h.Detail))
-	server.POST("/feedback/update-status", ginx.S(h.Permission),
+	server.GET("/feedback/pending-count", manage, ginx.W(h.PendingCount))
+	server.POST("/feedback/detail", manage, ginx.B(h.Detail))
+	server.POST("/feedback/update-status", manage,
 		ginx.B[UpdateStatusReq](h.UpdateStatus))
 	server.POST("/feedback/create", ginx.BS[CreateReq](h.Create))
 }
@@ -103,11 +105,3 @@ func (h *Handler) Create(ctx *ginx.Context, req CreateReq, sess session.Session)
 	}
 	return ginx.Result{}, err
 }
-
-func (h *Handler) Permission(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
-	if sess.Claims().Get("creator").StringOrDefault("") != "true" {
-		ctx.AbortWithStatus(http.StatusInternalServerError)
-		return ginx.Result{}, fmt.Errorf("非法访问创作中心 uid: %d", sess.Claims().Uid)
-	}
-	return ginx.Result{}, ginx.ErrNoResponse
-}
@@ -0,0 +1,28 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"github.com/ecodeclub/webook/internal/feedback/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+type Module struct {
+	Hdl *Handler
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	dc *consumer.UserDeletionConsumer
+}
@@ -17,27 +17,36 @@
 package feedback
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/feedback/internal/event"
+	"github.com/ecodeclub/webook/internal/feedback/internal/event/consumer"
 	"github.com/ecodeclub/webook/internal/feedback/internal/repository"
 	"github.com/ecodeclub/webook/internal/feedback/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/feedback/internal/service"
 	"github.com/ecodeclub/webook/internal/feedback/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
 
-func InitHandler(db *egorm.Component, q mq.MQ) (*Handler, error) {
+func InitModule(db *egorm.Component, q mq.MQ, permModule *permission.Module) (*Module, error) {
 	wire.Build(
 		event.NewIncreaseCreditsEventProducer,
 		InitService,
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
 		web.NewHandler,
+		service.NewExportSource,
+		initUserDeletionConsumer,
+		wire.Struct(new(Module), "*"),
 	)
-	return new(Handler), nil
+	return new(Module), nil
 }
 
 func InitService(db *egorm.Component, p event.IncreaseCreditsEventProducer) service.Service {
@@ -62,4 +71,13 @@ func initFeedbackDAO(db *gorm.DB) dao.FeedbackDAO {
 	return d
 }
 
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
 type Handler = web.Handler
@@ -7,27 +7,40 @@
 package feedback
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/feedback/internal/event"
+	"github.com/ecodeclub/webook/internal/feedback/internal/event/consumer"
 	"github.com/ecodeclub/webook/internal/feedback/internal/repository"
 	"github.com/ecodeclub/webook/internal/feedback/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/feedback/internal/service"
 	"github.com/ecodeclub/webook/internal/feedback/internal/web"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(db *gorm.DB, q mq.MQ) (*web.Handler, error) {
+func InitModule(db *gorm.DB, q mq.MQ, permModule *permission.Module) (*Module, error) {
 	increaseCreditsEventProducer, err := event.NewIncreaseCreditsEventProducer(q)
 	if err != nil {
 		return nil, err
 	}
-	service := InitService(db, increaseCreditsEventProducer)
-	handler := web.NewHandler(service)
-	return handler, nil
+	serviceService := InitService(db, increaseCreditsEventProducer)
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	handler := web.NewHandler(serviceService, checkRBACMiddlewareBuilder)
+	source := service.NewExportSource(serviceService)
+	userDeletionConsumer := initUserDeletionConsumer(serviceService, q)
+	module := &Module{
+		Hdl:          handler,
+		ExportSource: source,
+		dc:           userDeletionConsumer,
+	}
+	return module, nil
 }
 
 func InitService(db *gorm.DB, p event.IncreaseCreditsEventProducer) service.Service {
@@ -52,4 +65,13 @@ func initFeedbackDAO(db *gorm.DB) dao.FeedbackDAO {
 	return d
 }
 
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
 type Handler = web.Handler
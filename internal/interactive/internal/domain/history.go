@@ -0,0 +1,24 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// ViewHistory 用户最近浏览过的资源，同一个资源只保留最后一次
+type ViewHistory struct {
+	Uid   int64
+	Biz   string
+	BizId int64
+	// Utime 最后一次浏览的时间，毫秒
+	Utime int64
+}
@@ -20,6 +20,7 @@ type Interactive struct {
 	ViewCnt    int
 	LikeCnt    int
 	CollectCnt int
+	CommentCnt int
 	Liked      bool
 	Collected  bool
 }
@@ -27,10 +28,47 @@ type Interactive struct {
 type Collection struct {
 	Id int64
 	// 用户 ID
-	Uid  int64
-	Name string
+	Uid         int64
+	Name        string
+	Visibility  CollectionVisibility
+	ShareToken  string
+	FollowerCnt int64
+	// Followed 当前用户是否关注了这个收藏夹
+	Followed bool
 }
 
+// Accessible 收藏夹的主人总是可以访问，公开的收藏夹谁都可以访问，
+// 只对持有链接的人可见的收藏夹需要分享链接里面的凭证
+func (c Collection) Accessible(uid int64, shareToken string) bool {
+	switch {
+	case c.Uid == uid:
+		return true
+	case c.Visibility == CollectionVisibilityPublic:
+		return true
+	case c.Visibility == CollectionVisibilityLink:
+		return shareToken != "" && shareToken == c.ShareToken
+	default:
+		return false
+	}
+}
+
+type CollectionVisibility uint8
+
+func (v CollectionVisibility) ToUint8() uint8 {
+	return uint8(v)
+}
+
+func (v CollectionVisibility) Valid() bool {
+	return v <= CollectionVisibilityPublic
+}
+
+const (
+	CollectionVisibilityPrivate CollectionVisibility = 0
+	// CollectionVisibilityLink 只有拿到分享链接的人可见
+	CollectionVisibilityLink   CollectionVisibility = 1
+	CollectionVisibilityPublic CollectionVisibility = 2
+)
+
 type CollectionRecord struct {
 	Id int64
 	// 用于分发的
@@ -40,3 +78,26 @@ type CollectionRecord struct {
 	Question    int64
 	QuestionSet int64
 }
+
+// Like 点赞记录
+type Like struct {
+	Biz   string
+	BizId int64
+	Ctime int64
+}
+
+// View 一次浏览，Uid 为 0 代表没有登录
+type View struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Ctime 浏览发生的时间，毫秒，为 0 的时候使用处理的时间
+	Ctime int64
+}
+
+// ViewCnt 某个资源还没有写入数据库的浏览数
+type ViewCnt struct {
+	Biz   string
+	BizId int64
+	Cnt   int64
+}
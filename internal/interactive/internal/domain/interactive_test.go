@@ -0,0 +1,68 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection_Accessible(t *testing.T) {
+	testCases := []struct {
+		name       string
+		c          Collection
+		uid        int64
+		shareToken string
+		want       bool
+	}{
+		{
+			name: "自己的私有收藏夹",
+			c:    Collection{Uid: 1, ShareToken: "tk"},
+			uid:  1,
+			want: true,
+		},
+		{
+			name:       "别人的私有收藏夹_凭证也没用",
+			c:          Collection{Uid: 1, ShareToken: "tk"},
+			uid:        2,
+			shareToken: "tk",
+		},
+		{
+			name:       "持有链接可见_凭证正确",
+			c:          Collection{Uid: 1, Visibility: CollectionVisibilityLink, ShareToken: "tk"},
+			uid:        2,
+			shareToken: "tk",
+			want:       true,
+		},
+		{
+			name: "持有链接可见_没有凭证",
+			c:    Collection{Uid: 1, Visibility: CollectionVisibilityLink},
+			uid:  2,
+		},
+		{
+			name: "公开",
+			c:    Collection{Uid: 1, Visibility: CollectionVisibilityPublic},
+			uid:  2,
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.c.Accessible(tc.uid, tc.shareToken))
+		})
+	}
+}
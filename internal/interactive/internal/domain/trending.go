@@ -0,0 +1,120 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	ActionView    = "view"
+	ActionLike    = "like"
+	ActionCollect = "collect"
+	ActionComment = "comment"
+)
+
+// Period 排行榜的周期
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodWeekly  Period = "weekly"
+	PeriodAllTime Period = "all"
+)
+
+// Periods 所有的排行榜周期
+var Periods = []Period{PeriodDaily, PeriodWeekly, PeriodAllTime}
+
+func (p Period) Valid() bool {
+	_, ok := decays[p]
+	return ok
+}
+
+// Decay 分数按照半衰期衰减，为了避免不停地改写所有的分数，采用的是前向衰减：
+// 越晚发生的互动，加的分越多，窗口内的排序和按照当前时间衰减是一样的。
+// 分数记在按照 Window 切分的桶里面，新的桶换算到旧的桶只需要乘以 CarryOver
+type Decay struct {
+	// Window 桶的大小，为 0 的时候代表不衰减，也不切分
+	Window   time.Duration
+	HalfLife time.Duration
+}
+
+var decays = map[Period]Decay{
+	PeriodDaily:   {Window: 24 * time.Hour, HalfLife: 6 * time.Hour},
+	PeriodWeekly:  {Window: 7 * 24 * time.Hour, HalfLife: 24 * time.Hour},
+	PeriodAllTime: {},
+}
+
+func (p Period) Decay() Decay {
+	return decays[p]
+}
+
+// Bucket 时间 t 落在哪一个桶
+func (d Decay) Bucket(t time.Time) int64 {
+	if d.Window <= 0 {
+		return 0
+	}
+	return t.UnixMilli() / d.Window.Milliseconds()
+}
+
+// Score 在 t 时刻发生的权重为 weight 的互动，在它所在的桶里面应该加多少分
+func (d Decay) Score(weight float64, t time.Time) float64 {
+	if d.Window <= 0 {
+		return weight
+	}
+	elapsed := t.UnixMilli() - d.Bucket(t)*d.Window.Milliseconds()
+	return weight * math.Exp2(float64(elapsed)/float64(d.HalfLife.Milliseconds()))
+}
+
+// CarryOver 上一个桶的分数换算到当前桶的时候要乘的系数
+func (d Decay) CarryOver() float64 {
+	if d.Window <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(d.Window) / float64(d.HalfLife))
+}
+
+// ActionWeight 不同的互动对热度的贡献不一样
+func ActionWeight(action string) float64 {
+	switch action {
+	case ActionView:
+		return 1
+	case ActionLike:
+		return 3
+	case ActionComment:
+		return 4
+	case ActionCollect:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// Engagement 一次计入热度的互动，Uid 为 0 代表没有登录或者没办法区分用户
+type Engagement struct {
+	Biz    string
+	BizId  int64
+	Uid    int64
+	Action string
+	// Cnt 互动的次数，评论一次可能会变化多条
+	Cnt int64
+}
+
+type TrendingItem struct {
+	Biz   string
+	BizId int64
+	Score float64
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecay(t *testing.T) {
+	decay := Decay{Window: 24 * time.Hour, HalfLife: 6 * time.Hour}
+	start := time.UnixMilli(decay.Bucket(time.Now()) * decay.Window.Milliseconds())
+	testCases := []struct {
+		name       string
+		t          time.Time
+		wantBucket int64
+		wantScore  float64
+	}{
+		{
+			name:       "桶的开始",
+			t:          start,
+			wantBucket: decay.Bucket(start),
+			wantScore:  2,
+		},
+		{
+			name:       "过了一个半衰期",
+			t:          start.Add(6 * time.Hour),
+			wantBucket: decay.Bucket(start),
+			wantScore:  4,
+		},
+		{
+			name:       "下一个桶",
+			t:          start.Add(24 * time.Hour),
+			wantBucket: decay.Bucket(start) + 1,
+			wantScore:  2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantBucket, decay.Bucket(tc.t))
+			assert.InDelta(t, tc.wantScore, decay.Score(2, tc.t), 1e-9)
+		})
+	}
+	// 下一个桶开始的分数换算回来，和上一个桶结束的时候一样
+	assert.InDelta(t, decay.Score(2, start.Add(24*time.Hour-time.Millisecond)),
+		decay.Score(2, start.Add(24*time.Hour))/decay.CarryOver(), 1e-3)
+
+	allTime := PeriodAllTime.Decay()
+	assert.Equal(t, int64(0), allTime.Bucket(start))
+	assert.Equal(t, float64(2), allTime.Score(2, start.Add(time.Hour)))
+	assert.Equal(t, float64(1), allTime.CarryOver())
+}
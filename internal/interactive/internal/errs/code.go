@@ -15,7 +15,11 @@
 package errs
 
 var (
-	SystemError = ErrorCode{Code: 503001, Msg: "系统错误"}
+	InvalidTrending    = ErrorCode{Code: 403001, Msg: "不支持的排行榜"}
+	CollectionNotFound = ErrorCode{Code: 403002, Msg: "收藏夹不存在"}
+	InvalidCollection  = ErrorCode{Code: 403003, Msg: "收藏夹参数错误"}
+	InvalidHistory     = ErrorCode{Code: 403004, Msg: "不支持的浏览历史"}
+	SystemError        = ErrorCode{Code: 503001, Msg: "系统错误"}
 )
 
 type ErrorCode struct {
@@ -19,36 +19,55 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
 	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/gotomicro/ego/core/elog"
 )
 
 const topic = "interactive_events"
 
+const (
+	actionView = "view"
+	// defaultBatchSize 一次最多处理多少条消息
+	defaultBatchSize = 100
+	// defaultBatchWait 取到第一条消息之后最多再等多久凑一批
+	defaultBatchWait = 100 * time.Millisecond
+)
+
 type Consumer struct {
 	handlerMap map[string]handleFunc
 	consumer   mq.Consumer
 	svc        service.Service
+	historySvc service.HistoryService
 	logger     *elog.Component
+	batchSize  int
+	batchWait  time.Duration
+	cancel     context.CancelFunc
+	done       chan struct{}
 }
 
-func NewSyncConsumer(svc service.Service, q mq.MQ) (*Consumer, error) {
+func NewSyncConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) (*Consumer, error) {
 	groupID := "interactive_group"
 	consumer, err := q.Consumer(topic, groupID)
 	if err != nil {
 		return nil, err
 	}
 	c := &Consumer{
-		consumer: consumer,
-		svc:      svc,
-		logger:   elog.DefaultLogger,
+		consumer:   consumer,
+		svc:        svc,
+		historySvc: historySvc,
+		logger:     elog.DefaultLogger,
+		batchSize:  defaultBatchSize,
+		batchWait:  defaultBatchWait,
 	}
+	// 浏览事件量最大，不走这里，而是整批一起处理
 	handlerMap := map[string]handleFunc{
 		"like":    c.likeHandle,
 		"collect": c.collectHandle,
-		"view":    c.viewHandle,
+		"comment": c.commentHandle,
 	}
 	c.handlerMap = handlerMap
 	return c, nil
@@ -59,42 +78,98 @@ func (c *Consumer) likeHandle(ctx context.Context, svc service.Service, evt Even
 func (c *Consumer) collectHandle(ctx context.Context, svc service.Service, evt Event) error {
 	return svc.CollectToggle(ctx, evt.Biz, evt.BizId, evt.Uid)
 }
-func (c *Consumer) viewHandle(ctx context.Context, svc service.Service, evt Event) error {
-	return svc.IncrReadCnt(ctx, evt.Biz, evt.BizId)
+func (c *Consumer) commentHandle(ctx context.Context, svc service.Service, evt Event) error {
+	return svc.IncrCommentCnt(ctx, evt.Biz, evt.BizId, evt.Delta)
 }
 
+// Consume 取一批消息，其它事件逐条处理，浏览事件合并成一次调用
 func (c *Consumer) Consume(ctx context.Context) error {
-	msg, err := c.consumer.Consume(ctx)
-	if err != nil {
-		return fmt.Errorf("获取消息失败: %w", err)
+	msgs, err := c.fetch(ctx)
+	if len(msgs) == 0 {
+		return err
 	}
-
-	var evt Event
-	err = json.Unmarshal(msg.Value, &evt)
+	// 取消息的时候 ctx 可能已经被取消了，但是取到的消息还是要处理完
+	ctx = context.WithoutCancel(ctx)
+	views := make([]domain.View, 0, len(msgs))
+	for _, msg := range msgs {
+		var evt Event
+		err = json.Unmarshal(msg.Value, &evt)
+		if err != nil {
+			c.logger.Error("解析消息失败", elog.FieldErr(err), elog.String("msg", string(msg.Value)))
+			continue
+		}
+		if evt.Action == actionView {
+			views = append(views, domain.View{Biz: evt.Biz, BizId: evt.BizId, Uid: evt.Uid, Ctime: evt.Ctime})
+			continue
+		}
+		handler, ok := c.handlerMap[evt.Action]
+		if !ok {
+			c.logger.Error("未找到相关业务的处理方法", elog.Any("interactive_event", evt))
+			continue
+		}
+		err = handler(ctx, c.svc, evt)
+		if err != nil {
+			c.logger.Error("同步消息失败", elog.FieldErr(err), elog.Any("interactive_event", evt))
+		}
+	}
+	if len(views) == 0 {
+		return nil
+	}
+	var errs []error
+	err = c.svc.RecordViews(ctx, views)
 	if err != nil {
-		return fmt.Errorf("解析消息失败: %w", err)
+		errs = append(errs, fmt.Errorf("记录浏览失败: %w", err))
 	}
-	handler, ok := c.handlerMap[evt.Action]
-	if !ok {
-		return errors.New("未找到相关业务的处理方法")
+	// 浏览历史和浏览数互不影响
+	err = c.historySvc.Record(ctx, views)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("记录浏览历史失败: %w", err))
 	}
-	err = handler(ctx, c.svc, evt)
+	return errors.Join(errs...)
+}
+
+// fetch 阻塞到有第一条消息，然后在 batchWait 内尽量凑满一批
+func (c *Consumer) fetch(ctx context.Context) ([]*mq.Message, error) {
+	msg, err := c.consumer.Consume(ctx)
 	if err != nil {
-		c.logger.Error("同步消息失败", elog.Any("interactive_event", evt))
+		return nil, fmt.Errorf("获取消息失败: %w", err)
 	}
-	return err
+	msgs := []*mq.Message{msg}
+	bctx, cancel := context.WithTimeout(ctx, c.batchWait)
+	defer cancel()
+	for len(msgs) < c.batchSize {
+		msg, err = c.consumer.Consume(bctx)
+		if err != nil {
+			break
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
 }
 
 func (c *Consumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
 	go func() {
-		for {
+		defer close(c.done)
+		for ctx.Err() == nil {
 			err := c.Consume(ctx)
-			if err != nil {
+			if err != nil && !errors.Is(err, context.Canceled) {
 				c.logger.Error("同步事件失败", elog.FieldErr(err))
 			}
 		}
 	}()
 }
-func (c *Consumer) Stop(_ context.Context) error {
+
+// Stop 不再取新的消息，等手上的这一批处理完再关闭
+func (c *Consumer) Stop(ctx context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 	return c.consumer.Close()
 }
@@ -24,8 +24,12 @@ type Event struct {
 	Biz   string `json:"biz,omitempty"`
 	BizId int64  `json:"bizId,omitempty"`
 	// 取值是
-	// like, collect, view 三个
+	// like, collect, view, comment 四个
 	Action string `json:"action,omitempty"`
 	Uid    int64  `json:"uid,omitempty"`
+	// Delta 评论数的变化量，只有 comment 才会使用
+	Delta int `json:"delta,omitempty"`
+	// Ctime 浏览发生的时间，毫秒，只有 view 才会使用
+	Ctime int64 `json:"ctime,omitempty"`
 }
 type handleFunc func(ctx context.Context, svc service.Service, evt Event) error
@@ -0,0 +1,92 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/interactive/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+const userMergeEvents = "user_merge_events"
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+// UserMergeConsumer 合并账号之后，把源账号的点赞、收藏和浏览历史转移到目标账号上
+type UserMergeConsumer struct {
+	svc        service.Service
+	historySvc service.HistoryService
+	consumer   mq.Consumer
+	logger     *elog.Component
+}
+
+func NewUserMergeConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) (*UserMergeConsumer, error) {
+	groupID := "interactive"
+	consumer, err := q.Consumer(userMergeEvents, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &UserMergeConsumer{
+		svc:        svc,
+		historySvc: historySvc,
+		consumer:   consumer,
+		logger:     elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserMergeConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费合并账号事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserMergeConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt UserMergeEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	// 两边互不影响，都是可以重复执行的
+	err = errors.Join(c.svc.MergeUser(ctx, evt.SourceUid, evt.TargetUid),
+		c.historySvc.Merge(ctx, evt.SourceUid, evt.TargetUid))
+	if err != nil {
+		return fmt.Errorf("合并账号失败 %w, merge %d, source %d, target %d",
+			err, evt.Id, evt.SourceUid, evt.TargetUid)
+	}
+	return nil
+}
+
+func (c *UserMergeConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
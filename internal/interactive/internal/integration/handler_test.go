@@ -18,6 +18,7 @@ package integration
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -38,28 +39,37 @@ import (
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/interactive/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/ecodeclub/webook/internal/interactive/internal/web"
 	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
 	"github.com/ecodeclub/webook/internal/test"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 	"github.com/ego-component/egorm"
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/econf"
 	"github.com/gotomicro/ego/server/egin"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
-const uid = 1234
+const (
+	uid      = 1234
+	otherUid = 5678
+)
 
 type InteractiveTestSuite struct {
 	suite.Suite
-	server   *egin.Component
-	producer mq.Producer
-	db       *egorm.Component
-	intrDAO  dao.InteractiveDAO
-	svc      interactive.Service
+	server     *egin.Component
+	producer   mq.Producer
+	db         *egorm.Component
+	intrDAO    dao.InteractiveDAO
+	svc        interactive.Service
+	historySvc interactive.HistoryService
+	rankJob    *interactive.RankTrendingJob
+	rdb        redis.Cmdable
 }
 
 func (i *InteractiveTestSuite) TearDownTest() {
@@ -71,6 +81,10 @@ func (i *InteractiveTestSuite) TearDownTest() {
 	require.NoError(i.T(), err)
 	err = i.db.Exec("TRUNCATE TABLE `collections`").Error
 	require.NoError(i.T(), err)
+	err = i.db.Exec("TRUNCATE TABLE `collection_follows`").Error
+	require.NoError(i.T(), err)
+	err = i.db.Exec("TRUNCATE TABLE `view_histories`").Error
+	require.NoError(i.T(), err)
 }
 
 func (i *InteractiveTestSuite) SetupSuite() {
@@ -80,6 +94,9 @@ func (i *InteractiveTestSuite) SetupSuite() {
 	server := egin.Load("server").Build()
 	handler := module.Hdl
 	i.svc = module.Svc
+	i.historySvc = module.HistorySvc
+	i.rankJob = module.RankTrendingJob
+	i.rdb = testioc.InitRedis()
 	server.Use(func(ctx *gin.Context) {
 		ctx.Set("_session", session.NewMemorySession(session.Claims{
 			Uid: uid,
@@ -89,6 +106,7 @@ func (i *InteractiveTestSuite) SetupSuite() {
 			},
 		}))
 	})
+	handler.PublicRoutes(server.Engine)
 	handler.PrivateRoutes(server.Engine)
 	server.Use(middleware.NewCheckMembershipMiddlewareBuilder(nil).Build())
 	i.server = server
@@ -446,6 +464,9 @@ func (i *InteractiveTestSuite) Test_Event() {
 				Uid:    33,
 			},
 			after: func(t *testing.T) {
+				// 浏览数由定时任务批量写入数据库
+				_, err := i.svc.FlushViewCnts(context.Background(), 100)
+				require.NoError(t, err)
 				intr, err := i.intrDAO.Get(context.Background(), "label", 3)
 				require.NoError(t, err)
 				i.assertInteractive(dao.Interactive{
@@ -471,6 +492,135 @@ func (i *InteractiveTestSuite) Test_Event() {
 	}
 }
 
+func (i *InteractiveTestSuite) Test_ViewEventDedup() {
+	t := i.T()
+	// 和其它模块一样，通过 mqx 发送浏览事件，经过消费者批量处理之后再落库
+	producer, err := mqx.NewGeneralProducer[event.Event](testioc.InitMQ(), "interactive_events")
+	require.NoError(t, err)
+	// 去重的记录在 redis 里面，每次运行用不同的用户，避免受上一次运行的影响
+	viewer := time.Now().UnixNano()
+	evts := []event.Event{
+		{Biz: "label", BizId: 4, Action: "view", Uid: viewer},
+		{Biz: "label", BizId: 4, Action: "view", Uid: viewer},
+		{Biz: "label", BizId: 4, Action: "view", Uid: viewer},
+		{Biz: "label", BizId: 4, Action: "view", Uid: viewer + 1},
+		// 没有登录的浏览
+		{Biz: "label", BizId: 4, Action: "view"},
+	}
+	for _, evt := range evts {
+		err = producer.Produce(context.Background(), evt)
+		require.NoError(t, err)
+	}
+	time.Sleep(10 * time.Second)
+	_, err = i.svc.FlushViewCnts(context.Background(), 100)
+	require.NoError(t, err)
+	intr, err := i.intrDAO.Get(context.Background(), "label", 4)
+	require.NoError(t, err)
+	i.assertInteractive(dao.Interactive{
+		Biz:     "label",
+		BizId:   4,
+		ViewCnt: 3,
+	}, intr)
+}
+
+func (i *InteractiveTestSuite) Test_Trending() {
+	t := i.T()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	keys, err := i.rdb.Keys(ctx, "webook:interactive:trending:*").Result()
+	require.NoError(t, err)
+	if len(keys) > 0 {
+		require.NoError(t, i.rdb.Del(ctx, keys...).Err())
+	}
+
+	// 题目 1 被点赞，取消之后又点赞，只算一次，3 分
+	for j := 0; j < 3; j++ {
+		err = i.svc.LikeToggle(ctx, "question", 1, uid)
+		require.NoError(t, err)
+	}
+	// 题目 2 被四个不同的用户浏览，同一个用户重复浏览只算一次，4 分
+	views := []domain.View{{Biz: "question", BizId: 2, Uid: uid}, {Biz: "question", BizId: 2, Uid: uid}}
+	for j := int64(1); j <= 3; j++ {
+		views = append(views, domain.View{Biz: "question", BizId: 2, Uid: j})
+	}
+	// 其它业务的互动不影响题目的排行榜，不参与排行的业务直接忽略
+	views = append(views,
+		domain.View{Biz: "case", BizId: 1, Uid: uid},
+		domain.View{Biz: "product", BizId: 1, Uid: uid})
+	err = i.svc.RecordViews(ctx, views)
+	require.NoError(t, err)
+	// 题目 3 被收藏，5 分
+	err = i.svc.CollectToggle(ctx, "question", 3, uid)
+	require.NoError(t, err)
+
+	err = i.rankJob.Run(ctx)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		req      web.TrendingReq
+		wantCode int
+		wantIds  []int64
+	}{
+		{
+			name:     "默认是日榜",
+			req:      web.TrendingReq{Biz: "question"},
+			wantCode: 200,
+			wantIds:  []int64{3, 2, 1},
+		},
+		{
+			name:     "周榜",
+			req:      web.TrendingReq{Biz: "question", Period: "weekly", Limit: 2},
+			wantCode: 200,
+			wantIds:  []int64{3, 2},
+		},
+		{
+			name:     "总榜",
+			req:      web.TrendingReq{Biz: "question", Period: "all"},
+			wantCode: 200,
+			wantIds:  []int64{3, 2, 1},
+		},
+		{
+			name:     "案例",
+			req:      web.TrendingReq{Biz: "case", Period: "all"},
+			wantCode: 200,
+			wantIds:  []int64{1},
+		},
+		{
+			name:     "不支持的业务",
+			req:      web.TrendingReq{Biz: "product"},
+			wantCode: 200,
+		},
+		{
+			name:     "不支持的周期",
+			req:      web.TrendingReq{Biz: "question", Period: "monthly"},
+			wantCode: 200,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				"/interactive/trending", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.TrendingResp]()
+			i.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			res := recorder.MustScan()
+			if tc.wantIds == nil {
+				assert.Equal(t, 403001, res.Code)
+				return
+			}
+			ids := make([]int64, 0, len(res.Data.Items))
+			for _, item := range res.Data.Items {
+				ids = append(ids, item.BizId)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
 func (i *InteractiveTestSuite) TestCollection_Save() {
 	testcases := []struct {
 		name     string
@@ -536,6 +686,61 @@ func (i *InteractiveTestSuite) TestCollection_Save() {
 			},
 			wantCode: 200,
 		},
+		{
+			name: "公开_生成分享凭证",
+			req: web.Collection{
+				Id:         3,
+				Name:       "公开的收藏夹",
+				Visibility: 2,
+			},
+			before: func(t *testing.T) {
+				err := i.db.WithContext(context.Background()).Create(&dao.Collection{
+					Id:    3,
+					Uid:   uid,
+					Name:  "私有的收藏夹",
+					Ctime: 123,
+					Utime: 123,
+				}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T, id int64) {
+				var collection dao.Collection
+				err := i.db.WithContext(context.Background()).
+					Where("id = ?", id).First(&collection).Error
+				require.NoError(t, err)
+				assert.Equal(t, "公开的收藏夹", collection.Name)
+				assert.Equal(t, uint8(2), collection.Visibility)
+				assert.True(t, collection.ShareToken.Valid)
+				assert.NotEmpty(t, collection.ShareToken.String)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "不能修改别人的收藏夹",
+			req: web.Collection{
+				Id:   4,
+				Name: "改名",
+			},
+			before: func(t *testing.T) {
+				err := i.db.WithContext(context.Background()).Create(&dao.Collection{
+					Id:    4,
+					Uid:   otherUid,
+					Name:  "别人的收藏夹",
+					Ctime: 123,
+					Utime: 123,
+				}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T, id int64) {
+				assert.Equal(t, int64(0), id)
+				var collection dao.Collection
+				err := i.db.WithContext(context.Background()).
+					Where("id = ?", 4).First(&collection).Error
+				require.NoError(t, err)
+				assert.Equal(t, "别人的收藏夹", collection.Name)
+			},
+			wantCode: 200,
+		},
 	}
 	for _, tc := range testcases {
 		i.T().Run(tc.name, func(t *testing.T) {
@@ -694,10 +899,12 @@ func (i *InteractiveTestSuite) TestCollection_List() {
 			wantVal: []web.Collection{
 				{
 					Id:   2,
+					Uid:  uid,
 					Name: "2",
 				},
 				{
 					Id:   1,
+					Uid:  uid,
 					Name: "1",
 				},
 			},
@@ -710,18 +917,22 @@ func (i *InteractiveTestSuite) TestCollection_List() {
 			wantVal: []web.Collection{
 				{
 					Id:   4,
+					Uid:  uid,
 					Name: "4",
 				},
 				{
 					Id:   3,
+					Uid:  uid,
 					Name: "3",
 				},
 				{
 					Id:   2,
+					Uid:  uid,
 					Name: "2",
 				},
 				{
 					Id:   1,
+					Uid:  uid,
 					Name: "1",
 				},
 			},
@@ -744,6 +955,318 @@ func (i *InteractiveTestSuite) TestCollection_List() {
 	}
 }
 
+func (i *InteractiveTestSuite) TestCollection_Share() {
+	i.initSharedCollections()
+	testcases := []struct {
+		name     string
+		req      web.CollectionReq
+		wantCode int
+		wantResp test.Result[web.Collection]
+	}{
+		{
+			name:     "自己的收藏夹_可以看到分享凭证",
+			req:      web.CollectionReq{Id: 104},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Data: web.Collection{
+				Id: 104, Uid: uid, Name: "我的收藏夹", Visibility: 1, ShareToken: "tk104",
+			}},
+		},
+		{
+			name:     "别人的私有收藏夹",
+			req:      web.CollectionReq{Id: 101},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Code: 403002, Msg: "收藏夹不存在"},
+		},
+		{
+			name:     "持有链接可见_没有凭证",
+			req:      web.CollectionReq{Id: 102},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Code: 403002, Msg: "收藏夹不存在"},
+		},
+		{
+			name:     "持有链接可见_凭证不对",
+			req:      web.CollectionReq{Id: 102, ShareToken: "tk103"},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Code: 403002, Msg: "收藏夹不存在"},
+		},
+		{
+			name:     "持有链接可见_有凭证",
+			req:      web.CollectionReq{Id: 102, ShareToken: "tk102"},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Data: web.Collection{
+				Id: 102, Uid: otherUid, Name: "链接", Visibility: 1, FollowerCnt: 3,
+			}},
+		},
+		{
+			name:     "只用凭证查找",
+			req:      web.CollectionReq{ShareToken: "tk102"},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Data: web.Collection{
+				Id: 102, Uid: otherUid, Name: "链接", Visibility: 1, FollowerCnt: 3,
+			}},
+		},
+		{
+			name:     "私有收藏夹的凭证不能用",
+			req:      web.CollectionReq{ShareToken: "tk101"},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Code: 403002, Msg: "收藏夹不存在"},
+		},
+		{
+			name:     "公开的收藏夹_不需要凭证",
+			req:      web.CollectionReq{Id: 103},
+			wantCode: 200,
+			wantResp: test.Result[web.Collection]{Data: web.Collection{
+				Id: 103, Uid: otherUid, Name: "公开", Visibility: 2,
+			}},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		i.T().Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				"/interactive/collection/detail", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Collection]()
+			i.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
+}
+
+func (i *InteractiveTestSuite) TestCollection_Follow() {
+	t := i.T()
+	i.initSharedCollections()
+	follow := func(req web.CollectionReq) int {
+		httpReq, err := http.NewRequest(http.MethodPost,
+			"/interactive/collection/follow", iox.NewJSONReader(req))
+		httpReq.Header.Set("content-type", "application/json")
+		require.NoError(t, err)
+		recorder := test.NewJSONResponseRecorder[any]()
+		i.server.ServeHTTP(recorder, httpReq)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan().Code
+	}
+	followerCnt := func(cid int64) int64 {
+		var c dao.Collection
+		err := i.db.Where("id = ?", cid).First(&c).Error
+		require.NoError(t, err)
+		return c.FollowerCnt
+	}
+
+	// 重复关注只算一次
+	assert.Equal(t, 0, follow(web.CollectionReq{Id: 102, ShareToken: "tk102"}))
+	assert.Equal(t, 0, follow(web.CollectionReq{Id: 102, ShareToken: "tk102"}))
+	assert.Equal(t, int64(4), followerCnt(102))
+	assert.Equal(t, 0, follow(web.CollectionReq{Id: 103}))
+	// 没有权限的和自己的都不能关注
+	assert.Equal(t, 403002, follow(web.CollectionReq{Id: 101}))
+	assert.Equal(t, 403002, follow(web.CollectionReq{Id: 102}))
+	assert.Equal(t, 403003, follow(web.CollectionReq{Id: 104}))
+
+	followed := func() []web.Collection {
+		req, err := http.NewRequest(http.MethodPost,
+			"/interactive/collection/followed", iox.NewJSONReader(web.Page{Limit: 10}))
+		req.Header.Set("content-type", "application/json")
+		require.NoError(t, err)
+		recorder := test.NewJSONResponseRecorder[[]web.Collection]()
+		i.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan().Data
+	}
+	// 关注列表里面只有公开的收藏夹，只对持有链接的人可见的不会出现
+	assert.Equal(t, []web.Collection{
+		{Id: 103, Uid: otherUid, Name: "公开", Visibility: 2, FollowerCnt: 1, Followed: true},
+	}, followed())
+	// 公开的收藏夹改成私有之后，关注列表里面就看不到了
+	err := i.db.Model(&dao.Collection{}).Where("id = ?", 103).Update("visibility", 0).Error
+	require.NoError(t, err)
+	assert.Empty(t, followed())
+
+	req, err := http.NewRequest(http.MethodPost,
+		"/interactive/collection/unfollow", iox.NewJSONReader(web.IdReq{Id: 102}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[[]web.Collection]()
+	i.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, int64(3), followerCnt(102))
+	_, err = i.intrDAO.GetCollectionFollow(context.Background(), uid, 102)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+}
+
+func (i *InteractiveTestSuite) TestCollection_RotateShareToken() {
+	t := i.T()
+	i.initSharedCollections()
+	rotate := func(id int64) test.Result[string] {
+		req, err := http.NewRequest(http.MethodPost,
+			"/interactive/collection/share/rotate", iox.NewJSONReader(web.IdReq{Id: id}))
+		req.Header.Set("content-type", "application/json")
+		require.NoError(t, err)
+		recorder := test.NewJSONResponseRecorder[string]()
+		i.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan()
+	}
+
+	res := rotate(104)
+	assert.Equal(t, 0, res.Code)
+	assert.NotEmpty(t, res.Data)
+	assert.NotEqual(t, "tk104", res.Data)
+	var c dao.Collection
+	err := i.db.Where("id = ?", 104).First(&c).Error
+	require.NoError(t, err)
+	assert.Equal(t, res.Data, c.ShareToken.String)
+	// 旧的凭证不能再用
+	_, err = i.svc.GetCollection(context.Background(), otherUid, 0, "tk104")
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+
+	// 别人的收藏夹不能换凭证
+	assert.Equal(t, 403002, rotate(102).Code)
+
+	// 改成私有之后凭证作废，也不能再换凭证
+	_, err = i.svc.SaveCollection(context.Background(), domain.Collection{Id: 104, Uid: uid, Name: "我的收藏夹"})
+	require.NoError(t, err)
+	err = i.db.Where("id = ?", 104).First(&c).Error
+	require.NoError(t, err)
+	assert.False(t, c.ShareToken.Valid)
+	assert.Equal(t, 403003, rotate(104).Code)
+}
+
+func (i *InteractiveTestSuite) TestCollection_Copy() {
+	t := i.T()
+	i.initSharedCollections()
+	// 自己已经收藏过案例 2，复制的时候保持在原来的收藏夹里面
+	err := i.svc.CollectToggle(context.Background(), "case", 2, uid)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost,
+		"/interactive/collection/copy", iox.NewJSONReader(web.CopyCollectionReq{
+			Id: 102, ShareToken: "tk102", Name: "复制过来的",
+		}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[int64]()
+	i.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	id := recorder.MustScan().Data
+	require.True(t, id > 0)
+
+	c, err := i.intrDAO.GetCollection(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(uid), c.Uid)
+	assert.Equal(t, "复制过来的", c.Name)
+	assert.Equal(t, uint8(0), c.Visibility)
+	records, err := i.intrDAO.CollectionInfo(context.Background(), id)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "question", records[0].Biz)
+	assert.Equal(t, int64(1), records[0].BizId)
+	assert.Equal(t, int64(uid), records[0].Uid)
+	intr, err := i.intrDAO.Get(context.Background(), "question", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, intr.CollectCnt)
+	intr, err = i.intrDAO.Get(context.Background(), "case", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, intr.CollectCnt)
+
+	// 没有权限的不能复制
+	req, err = http.NewRequest(http.MethodPost,
+		"/interactive/collection/copy", iox.NewJSONReader(web.CopyCollectionReq{Id: 101}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder = test.NewJSONResponseRecorder[int64]()
+	i.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, 403002, recorder.MustScan().Code)
+}
+
+// initSharedCollections 101 到 103 是别人的私有、持有链接可见和公开的收藏夹，104 是自己的
+func (i *InteractiveTestSuite) initSharedCollections() {
+	collections := []dao.Collection{
+		{Id: 101, Uid: otherUid, Name: "私有", ShareToken: sql.NullString{String: "tk101", Valid: true}},
+		{Id: 102, Uid: otherUid, Name: "链接", Visibility: 1, FollowerCnt: 3, ShareToken: sql.NullString{String: "tk102", Valid: true}},
+		{Id: 103, Uid: otherUid, Name: "公开", Visibility: 2, ShareToken: sql.NullString{String: "tk103", Valid: true}},
+		{Id: 104, Uid: uid, Name: "我的收藏夹", Visibility: 1, ShareToken: sql.NullString{String: "tk104", Valid: true}},
+	}
+	err := i.db.Create(&collections).Error
+	require.NoError(i.T(), err)
+	records := []dao.UserCollectionBiz{
+		{Uid: otherUid, Biz: "question", BizId: 1, Cid: 102},
+		{Uid: otherUid, Biz: "case", BizId: 2, Cid: 102},
+	}
+	err = i.db.Create(&records).Error
+	require.NoError(i.T(), err)
+	err = i.db.Create(&[]dao.Interactive{
+		{Biz: "question", BizId: 1, CollectCnt: 1},
+		{Biz: "case", BizId: 2, CollectCnt: 1},
+	}).Error
+	require.NoError(i.T(), err)
+}
+
+func (i *InteractiveTestSuite) Test_ClearHistory() {
+	testCases := []struct {
+		name     string
+		req      web.ClearHistoryReq
+		wantCode int
+		wantRes  test.Result[any]
+		wantBizs []string
+	}{
+		{
+			name:     "清空某个业务",
+			req:      web.ClearHistoryReq{Biz: "question"},
+			wantCode: 200,
+			wantBizs: []string{"case", "case"},
+		},
+		{
+			name:     "清空所有业务",
+			req:      web.ClearHistoryReq{},
+			wantCode: 200,
+		},
+		{
+			name:     "不支持的业务",
+			req:      web.ClearHistoryReq{Biz: "label"},
+			wantCode: 200,
+			wantRes:  test.Result[any]{Code: 403004, Msg: "不支持的浏览历史"},
+			wantBizs: []string{"case", "question", "case"},
+		},
+	}
+	for _, tc := range testCases {
+		i.T().Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			err := i.historySvc.Record(ctx, []domain.View{
+				{Biz: "case", BizId: 1, Uid: uid},
+				{Biz: "question", BizId: 1, Uid: uid},
+				{Biz: "case", BizId: 2, Uid: uid},
+				{Biz: "case", BizId: 1, Uid: otherUid},
+			})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost,
+				"/interactive/history/clear", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[any]()
+			i.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantRes, recorder.MustScan())
+			histories, err := i.historySvc.List(ctx, uid, "", 0, 10)
+			require.NoError(t, err)
+			var bizs []string
+			for _, h := range histories {
+				bizs = append(bizs, h.Biz)
+			}
+			assert.Equal(t, tc.wantBizs, bizs)
+			// 别人的浏览历史不受影响
+			others, err := i.historySvc.List(ctx, otherUid, "", 0, 10)
+			require.NoError(t, err)
+			assert.Len(t, others, 1)
+			err = i.db.Exec("TRUNCATE TABLE `view_histories`").Error
+			require.NoError(t, err)
+		})
+	}
+}
+
 func (i *InteractiveTestSuite) TestCollection_Move() {
 	testcases := []struct {
 		name     string
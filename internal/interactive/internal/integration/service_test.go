@@ -22,59 +22,193 @@ import (
 	"time"
 
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
-	"github.com/ecodeclub/webook/internal/interactive/internal/web"
 	"github.com/stretchr/testify/require"
 )
 
 func (i *InteractiveTestSuite) Test_View() {
+	// 去重的记录在 redis 里面，每次运行用不同的用户，避免受上一次运行的影响
+	uid := time.Now().UnixNano()
 	testcases := []struct {
-		name   string
-		before func(t *testing.T)
-		after  func(t *testing.T)
-		req    web.CollectReq
+		name    string
+		before  func(t *testing.T)
+		views   []domain.View
+		bizId   int64
+		wantCnt int
 	}{
 		{
-			name: "用户首次浏览资源，资源浏览计数加1",
-			before: func(t *testing.T) {
-
+			name:   "用户首次浏览资源，资源浏览计数加1",
+			before: func(t *testing.T) {},
+			views: []domain.View{
+				{Biz: "order", BizId: 3, Uid: uid},
 			},
-			after: func(t *testing.T) {
-				intr, err := i.intrDAO.Get(context.Background(), "order", 3)
+			bizId:   3,
+			wantCnt: 1,
+		},
+		{
+			name: "已有浏览数，资源浏览计数加1",
+			before: func(t *testing.T) {
+				err := i.intrDAO.IncrViewCnt(context.Background(), "order", 4)
 				require.NoError(t, err)
-				i.assertInteractive(dao.Interactive{
-					Biz:     "order",
-					BizId:   3,
-					ViewCnt: 1,
-				}, intr)
 			},
-			req: web.CollectReq{
-				BizId: 3,
-				Biz:   "order",
+			views: []domain.View{
+				{Biz: "order", BizId: 4, Uid: uid},
+			},
+			bizId:   4,
+			wantCnt: 2,
+		},
+		{
+			name:   "同一个用户重复浏览，只算一次",
+			before: func(t *testing.T) {},
+			views: []domain.View{
+				{Biz: "order", BizId: 5, Uid: uid},
+				{Biz: "order", BizId: 5, Uid: uid},
+				{Biz: "order", BizId: 5, Uid: uid},
+			},
+			bizId:   5,
+			wantCnt: 1,
+		},
+		{
+			name:   "不同用户和没有登录的浏览都算",
+			before: func(t *testing.T) {},
+			views: []domain.View{
+				{Biz: "order", BizId: 6, Uid: uid},
+				{Biz: "order", BizId: 6, Uid: uid + 1},
+				{Biz: "order", BizId: 6},
+				{Biz: "order", BizId: 6},
 			},
+			bizId:   6,
+			wantCnt: 4,
+		},
+	}
+	for _, tc := range testcases {
+		i.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+			defer cancel()
+			err := i.svc.RecordViews(ctx, tc.views)
+			require.NoError(t, err)
+			_, err = i.svc.FlushViewCnts(ctx, 2)
+			require.NoError(t, err)
+			intr, err := i.intrDAO.Get(ctx, "order", tc.bizId)
+			require.NoError(t, err)
+			i.assertInteractive(dao.Interactive{
+				Biz:     "order",
+				BizId:   tc.bizId,
+				ViewCnt: tc.wantCnt,
+			}, intr)
+		})
+	}
+}
+
+func (i *InteractiveTestSuite) Test_History() {
+	t := i.T()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	bizIds := func(histories []domain.ViewHistory) []int64 {
+		res := make([]int64, 0, len(histories))
+		for _, h := range histories {
+			res = append(res, h.BizId)
+		}
+		return res
+	}
+
+	err := i.historySvc.Record(ctx, []domain.View{
+		{Biz: "question", BizId: 1, Uid: uid},
+		{Biz: "question", BizId: 2, Uid: uid},
+		{Biz: "case", BizId: 1, Uid: uid},
+		// 重复浏览只保留最后一次
+		{Biz: "question", BizId: 1, Uid: uid},
+		// 没有登录的和不记录历史的业务都忽略
+		{Biz: "question", BizId: 3},
+		{Biz: "label", BizId: 1, Uid: uid},
+	})
+	require.NoError(t, err)
+	histories, err := i.historySvc.List(ctx, uid, "question", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, bizIds(histories))
+	histories, err = i.historySvc.List(ctx, uid, "", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"question", "case", "question"}, []string{
+		histories[0].Biz, histories[1].Biz, histories[2].Biz,
+	})
+
+	// 浏览时间以消息里面带的为准，积压之后才处理的旧浏览不会排到前面
+	err = i.historySvc.Record(ctx, []domain.View{
+		{Biz: "case", BizId: 2, Uid: uid, Ctime: histories[2].Utime - 1},
+	})
+	require.NoError(t, err)
+	histories, err = i.historySvc.List(ctx, uid, "case", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, bizIds(histories))
+
+	last, err := i.historySvc.LastViewed(ctx, uid, "question", []int64{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), last.BizId)
+	last, err = i.historySvc.LastViewed(ctx, uid, "question", []int64{3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), last.BizId)
+
+	// 超过容量之后只保留最近的
+	views := make([]domain.View, 0, service.HistoryCapacity)
+	for idx := 0; idx < service.HistoryCapacity; idx++ {
+		views = append(views, domain.View{Biz: "question", BizId: int64(100 + idx), Uid: uid})
+	}
+	err = i.historySvc.Record(ctx, views)
+	require.NoError(t, err)
+	histories, err = i.historySvc.List(ctx, uid, "question", 0, service.HistoryCapacity+10)
+	require.NoError(t, err)
+	assert.Len(t, histories, service.HistoryCapacity)
+	assert.Equal(t, int64(100+service.HistoryCapacity-1), histories[0].BizId)
+	assert.NotContains(t, bizIds(histories), int64(1))
+	// 其它业务不受影响
+	histories, err = i.historySvc.List(ctx, uid, "case", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, bizIds(histories))
+}
+
+func (i *InteractiveTestSuite) Test_CommentCnt() {
+	testcases := []struct {
+		name    string
+		before  func(t *testing.T)
+		biz     string
+		bizId   int64
+		delta   int
+		wantCnt int
+	}{
+		{
+			name:    "首次评论，评论数加1",
+			before:  func(t *testing.T) {},
+			biz:     "question",
+			bizId:   1,
+			delta:   1,
+			wantCnt: 1,
 		},
 		{
-			name: "用户重复浏览资源，资源浏览计数加1",
+			name: "删除带回复的评论，评论数减少",
 			before: func(t *testing.T) {
-				err := i.intrDAO.IncrViewCnt(context.Background(), "order", 4)
+				err := i.intrDAO.IncrCommentCnt(context.Background(), "question", 2, 5)
 				require.NoError(t, err)
 			},
-			after: func(t *testing.T) {
-				intr, err := i.intrDAO.Get(context.Background(), "order", 4)
+			biz:     "question",
+			bizId:   2,
+			delta:   -3,
+			wantCnt: 2,
+		},
+		{
+			name: "评论数不会变成负数",
+			before: func(t *testing.T) {
+				err := i.intrDAO.IncrCommentCnt(context.Background(), "question", 3, 1)
 				require.NoError(t, err)
-				i.assertInteractive(dao.Interactive{
-					Biz:     "order",
-					BizId:   4,
-					ViewCnt: 2,
-				}, intr)
-			},
-			req: web.CollectReq{
-				BizId: 4,
-				Biz:   "order",
 			},
+			biz:     "question",
+			bizId:   3,
+			delta:   -2,
+			wantCnt: 0,
 		},
 	}
 	for _, tc := range testcases {
@@ -82,9 +216,11 @@ func (i *InteractiveTestSuite) Test_View() {
 			tc.before(t)
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err := i.svc.IncrReadCnt(ctx, tc.req.Biz, tc.req.BizId)
+			err := i.svc.IncrCommentCnt(ctx, tc.biz, tc.bizId, tc.delta)
+			require.NoError(t, err)
+			intr, err := i.intrDAO.Get(ctx, tc.biz, tc.bizId)
 			require.NoError(t, err)
-			tc.after(t)
+			assert.Equal(t, tc.wantCnt, intr.CommentCnt)
 		})
 	}
 }
@@ -337,3 +473,161 @@ func (i *InteractiveTestSuite) TestCollection_Info() {
 	}
 
 }
+
+func (i *InteractiveTestSuite) Test_MergeUser() {
+	t := i.T()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	const (
+		sourceUid = 9101
+		targetUid = 9102
+		thirdUid  = 9103
+	)
+	err := i.db.Create(&[]dao.Collection{
+		{Id: 201, Uid: sourceUid, Name: "默认", Visibility: 2},
+		{Id: 202, Uid: sourceUid, Name: "公开", Visibility: 2},
+		{Id: 203, Uid: targetUid, Name: "默认", Visibility: 2},
+	}).Error
+	require.NoError(t, err)
+	for _, f := range []dao.CollectionFollow{
+		{Uid: thirdUid, Cid: 201},
+		{Uid: thirdUid, Cid: 202},
+		{Uid: thirdUid, Cid: 203},
+		// 合并之后就变成关注自己的收藏夹了
+		{Uid: targetUid, Cid: 202},
+	} {
+		require.NoError(t, i.intrDAO.FollowCollection(ctx, f.Uid, f.Cid))
+	}
+	for _, cb := range []dao.UserCollectionBiz{
+		{Uid: sourceUid, Biz: "question", BizId: 1, Cid: 201},
+		{Uid: sourceUid, Biz: "question", BizId: 2, Cid: 202},
+		{Uid: targetUid, Biz: "question", BizId: 1, Cid: 203},
+	} {
+		require.NoError(t, i.intrDAO.CollectToggle(ctx, cb))
+		require.NoError(t, i.intrDAO.LikeToggle(ctx, cb.Biz, cb.BizId, cb.Uid))
+	}
+	err = i.historySvc.Record(ctx, []domain.View{
+		{Biz: "question", BizId: 7, Uid: targetUid},
+		{Biz: "question", BizId: 5, Uid: targetUid},
+	})
+	require.NoError(t, err)
+	// 错开浏览时间
+	time.Sleep(10 * time.Millisecond)
+	err = i.historySvc.Record(ctx, []domain.View{
+		{Biz: "question", BizId: 5, Uid: sourceUid},
+		{Biz: "question", BizId: 6, Uid: sourceUid},
+	})
+	require.NoError(t, err)
+
+	// 重复执行结果也是一样的
+	for j := 0; j < 2; j++ {
+		require.NoError(t, i.svc.MergeUser(ctx, sourceUid, targetUid))
+		require.NoError(t, i.historySvc.Merge(ctx, sourceUid, targetUid))
+	}
+
+	var cs []dao.Collection
+	err = i.db.Order("id").Find(&cs, "id IN ?", []int64{201, 202, 203}).Error
+	require.NoError(t, err)
+	require.Len(t, cs, 2)
+	assert.Equal(t, []int64{202, 203}, []int64{cs[0].Id, cs[1].Id})
+	assert.Equal(t, []int64{targetUid, targetUid}, []int64{cs[0].Uid, cs[1].Uid})
+	assert.Equal(t, []int64{1, 1}, []int64{cs[0].FollowerCnt, cs[1].FollowerCnt})
+
+	var cbs []dao.UserCollectionBiz
+	err = i.db.Order("biz_id").Find(&cbs, "uid IN ?", []int64{sourceUid, targetUid}).Error
+	require.NoError(t, err)
+	require.Len(t, cbs, 2)
+	assert.Equal(t, []int64{targetUid, targetUid}, []int64{cbs[0].Uid, cbs[1].Uid})
+	assert.Equal(t, []int64{203, 202}, []int64{cbs[0].Cid, cbs[1].Cid})
+
+	var likeCnt int64
+	err = i.db.Model(&dao.UserLikeBiz{}).Where("uid = ?", sourceUid).Count(&likeCnt).Error
+	require.NoError(t, err)
+	assert.Zero(t, likeCnt)
+	for _, bizId := range []int64{1, 2} {
+		intr, err := i.intrDAO.Get(ctx, "question", bizId)
+		require.NoError(t, err)
+		assert.Equal(t, 1, intr.LikeCnt)
+		assert.Equal(t, 1, intr.CollectCnt)
+		liked, err := i.intrDAO.GetLikeInfo(ctx, "question", bizId, targetUid)
+		require.NoError(t, err)
+		assert.Equal(t, int64(targetUid), liked.Uid)
+	}
+
+	histories, err := i.historySvc.List(ctx, targetUid, "question", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, histories, 3)
+	assert.Equal(t, []int64{6, 5, 7}, []int64{histories[0].BizId, histories[1].BizId, histories[2].BizId})
+	histories, err = i.historySvc.List(ctx, sourceUid, "", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+}
+
+func (i *InteractiveTestSuite) Test_DeleteUser() {
+	t := i.T()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	const (
+		deletedUid = 9201
+		otherUid   = 9202
+	)
+	err := i.db.Create(&[]dao.Collection{
+		{Id: 301, Uid: deletedUid, Name: "默认", Visibility: 2},
+		{Id: 302, Uid: otherUid, Name: "默认", Visibility: 2},
+	}).Error
+	require.NoError(t, err)
+	for _, f := range []dao.CollectionFollow{
+		{Uid: otherUid, Cid: 301},
+		{Uid: deletedUid, Cid: 302},
+	} {
+		require.NoError(t, i.intrDAO.FollowCollection(ctx, f.Uid, f.Cid))
+	}
+	for _, cb := range []dao.UserCollectionBiz{
+		{Uid: deletedUid, Biz: "question", BizId: 1, Cid: 301},
+		{Uid: otherUid, Biz: "question", BizId: 1, Cid: 302},
+	} {
+		require.NoError(t, i.intrDAO.CollectToggle(ctx, cb))
+		require.NoError(t, i.intrDAO.LikeToggle(ctx, cb.Biz, cb.BizId, cb.Uid))
+	}
+	err = i.historySvc.Record(ctx, []domain.View{
+		{Biz: "question", BizId: 1, Uid: deletedUid},
+		{Biz: "question", BizId: 1, Uid: otherUid},
+	})
+	require.NoError(t, err)
+
+	// 重复执行结果也是一样的
+	for j := 0; j < 2; j++ {
+		require.NoError(t, i.svc.DeleteUser(ctx, deletedUid))
+		require.NoError(t, i.historySvc.Clear(ctx, deletedUid, ""))
+	}
+
+	var cs []dao.Collection
+	err = i.db.Find(&cs, "id IN ?", []int64{301, 302}).Error
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	assert.Equal(t, int64(302), cs[0].Id)
+	assert.Zero(t, cs[0].FollowerCnt)
+
+	var followCnt int64
+	err = i.db.Model(&dao.CollectionFollow{}).Count(&followCnt).Error
+	require.NoError(t, err)
+	assert.Zero(t, followCnt)
+
+	intr, err := i.intrDAO.Get(ctx, "question", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, intr.LikeCnt)
+	assert.Equal(t, 1, intr.CollectCnt)
+	likes, err := i.svc.UserLikes(ctx, deletedUid, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, likes)
+	likes, err = i.svc.UserLikes(ctx, otherUid, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, likes, 1)
+
+	histories, err := i.historySvc.List(ctx, deletedUid, "", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+	histories, err = i.historySvc.List(ctx, otherUid, "", 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, histories, 1)
+}
@@ -23,6 +23,6 @@ import (
 )
 
 func InitModule() (*interactive.Module, error) {
-	wire.Build(testioc.BaseSet, interactive.InitModule)
+	wire.Build(testioc.BaseSet, testioc.InitRedis, interactive.InitModule)
 	return new(interactive.Module), nil
 }
@@ -16,7 +16,9 @@ import (
 func InitModule() (*interactive.Module, error) {
 	db := testioc.InitDB()
 	mq := testioc.InitMQ()
-	module, err := interactive.InitModule(db, mq)
+	cache := testioc.InitCache()
+	cmdable := testioc.InitRedis()
+	module, err := interactive.InitModule(db, mq, cache, cmdable)
 	if err != nil {
 		return nil, err
 	}
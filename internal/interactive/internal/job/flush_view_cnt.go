@@ -0,0 +1,52 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*FlushViewCntJob)(nil)
+
+// FlushViewCntJob 定期把缓存里面累加的浏览数批量写入数据库
+type FlushViewCntJob struct {
+	svc       service.Service
+	batchSize int
+	logger    *elog.Component
+}
+
+func NewFlushViewCntJob(svc service.Service, batchSize int) *FlushViewCntJob {
+	return &FlushViewCntJob{
+		svc:       svc,
+		batchSize: batchSize,
+		logger:    elog.DefaultLogger,
+	}
+}
+
+func (f *FlushViewCntJob) Name() string {
+	return "FlushViewCntJob"
+}
+
+func (f *FlushViewCntJob) Run(ctx context.Context) error {
+	cnt, err := f.svc.FlushViewCnts(ctx, f.batchSize)
+	if cnt > 0 {
+		f.logger.Debug("浏览数写入数据库", elog.Int("cnt", cnt))
+	}
+	return err
+}
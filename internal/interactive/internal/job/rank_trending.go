@@ -0,0 +1,41 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/service"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*RankTrendingJob)(nil)
+
+// RankTrendingJob 定期重新计算排行榜
+type RankTrendingJob struct {
+	svc service.TrendingService
+}
+
+func NewRankTrendingJob(svc service.TrendingService) *RankTrendingJob {
+	return &RankTrendingJob{svc: svc}
+}
+
+func (r *RankTrendingJob) Name() string {
+	return "RankTrendingJob"
+}
+
+func (r *RankTrendingJob) Run(ctx context.Context) error {
+	return r.svc.Rank(ctx)
+}
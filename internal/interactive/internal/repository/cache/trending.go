@@ -0,0 +1,128 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// TrendingCache 热度记在 redis 的有序集合里面，ecache 不支持有序集合，所以直接用 redis
+type TrendingCache interface {
+	// MarkScored 同一个用户对同一个资源的同一种互动，在去重窗口内只加一次分，第一次返回 true
+	MarkScored(ctx context.Context, e domain.Engagement) (bool, error)
+	// IncrScore 给所有周期当前的桶加分
+	IncrScore(ctx context.Context, biz string, bizId int64, weight float64, t time.Time) error
+	// Rank 合并当前和上一个桶，只保留前 n 个作为排行榜
+	Rank(ctx context.Context, biz string, period domain.Period, t time.Time, n int) error
+	// TopN 排行榜里面的前 n 个
+	TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error)
+}
+
+type TrendingRedisCache struct {
+	cmd redis.Cmdable
+	// window 去重窗口
+	window time.Duration
+}
+
+func NewTrendingRedisCache(cmd redis.Cmdable, window time.Duration) TrendingCache {
+	return &TrendingRedisCache{
+		cmd:    cmd,
+		window: window,
+	}
+}
+
+func (t *TrendingRedisCache) MarkScored(ctx context.Context, e domain.Engagement) (bool, error) {
+	key := t.key(fmt.Sprintf("dedup:%s:%s:%d:%d", e.Action, e.Biz, e.BizId, e.Uid))
+	return t.cmd.SetNX(ctx, key, 1, t.window).Result()
+}
+
+func (t *TrendingRedisCache) IncrScore(ctx context.Context, biz string, bizId int64, weight float64, now time.Time) error {
+	member := strconv.FormatInt(bizId, 10)
+	pipe := t.cmd.Pipeline()
+	for _, period := range domain.Periods {
+		decay := period.Decay()
+		key := t.bucketKey(biz, period, decay.Bucket(now))
+		pipe.ZIncrBy(ctx, key, decay.Score(weight, now), member)
+		if decay.Window > 0 {
+			// 排行榜只会用到当前和上一个桶
+			pipe.Expire(ctx, key, 2*decay.Window+time.Hour)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (t *TrendingRedisCache) Rank(ctx context.Context, biz string, period domain.Period, now time.Time, n int) error {
+	decay := period.Decay()
+	bucket := decay.Bucket(now)
+	store := &redis.ZStore{
+		Keys:    []string{t.bucketKey(biz, period, bucket)},
+		Weights: []float64{1},
+	}
+	if decay.Window > 0 {
+		store.Keys = append(store.Keys, t.bucketKey(biz, period, bucket-1))
+		store.Weights = append(store.Weights, decay.CarryOver())
+	}
+	// 先算到临时的 key 里面再改名，读排行榜的时候不会读到还没有裁剪的结果
+	tmp := t.rankKey(biz, period) + ":tmp"
+	pipe := t.cmd.TxPipeline()
+	union := pipe.ZUnionStore(ctx, tmp, store)
+	pipe.ZRemRangeByRank(ctx, tmp, 0, int64(-n-1))
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if union.Val() == 0 {
+		// 两个桶都是空的，说明这段时间都没有互动，排行榜也应该是空的
+		return t.cmd.Del(ctx, t.rankKey(biz, period)).Err()
+	}
+	return t.cmd.Rename(ctx, tmp, t.rankKey(biz, period)).Err()
+}
+
+func (t *TrendingRedisCache) TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error) {
+	zs, err := t.cmd.ZRevRangeWithScores(ctx, t.rankKey(biz, period), 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.TrendingItem, 0, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		bizId, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("排行榜的成员格式不对 %v: %w", z.Member, err)
+		}
+		res = append(res, domain.TrendingItem{Biz: biz, BizId: bizId, Score: z.Score})
+	}
+	return res, nil
+}
+
+func (t *TrendingRedisCache) bucketKey(biz string, period domain.Period, bucket int64) string {
+	return t.key(fmt.Sprintf("score:%s:%s:%d", biz, period, bucket))
+}
+
+func (t *TrendingRedisCache) rankKey(biz string, period domain.Period) string {
+	return t.key(fmt.Sprintf("rank:%s:%s", biz, period))
+}
+
+// key 和 ecache 里面的 key 保持一样的前缀
+func (t *TrendingRedisCache) key(k string) string {
+	return "webook:interactive:trending:" + k
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// ViewCache 浏览数先在缓存里面去重和累加，再批量写入数据库
+// 取出计数需要原子地读取并删除，ecache 不支持，所以直接用 redis
+type ViewCache interface {
+	// MarkViewed 标记用户浏览过，去重窗口内第一次浏览返回 true
+	MarkViewed(ctx context.Context, view domain.View) (bool, error)
+	// IncrViewCnt 累加还没有写入数据库的浏览数
+	IncrViewCnt(ctx context.Context, biz string, bizId int64, delta int64) error
+	// PopViewCnts 取出最多 limit 个资源累加的浏览数，取出之后删除缓存里面的计数
+	PopViewCnts(ctx context.Context, limit int) ([]domain.ViewCnt, error)
+}
+
+type ViewRedisCache struct {
+	cmd redis.Cmdable
+	// window 去重窗口，同一个用户在窗口内重复浏览同一个资源只算一次
+	window time.Duration
+}
+
+func NewViewRedisCache(cmd redis.Cmdable, window time.Duration) ViewCache {
+	return &ViewRedisCache{
+		cmd:    cmd,
+		window: window,
+	}
+}
+
+func (v *ViewRedisCache) MarkViewed(ctx context.Context, view domain.View) (bool, error) {
+	key := v.key(fmt.Sprintf("dedup:%s:%d:%d", view.Biz, view.BizId, view.Uid))
+	return v.cmd.SetNX(ctx, key, 1, v.window).Result()
+}
+
+// IncrViewCnt 计数从 0 开始累加的时候，说明这个资源还不在待写入的列表里面，需要放进去
+func (v *ViewRedisCache) IncrViewCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	cnt, err := v.cmd.IncrBy(ctx, v.cntKey(biz, bizId), delta).Result()
+	if err != nil {
+		return err
+	}
+	if cnt != delta {
+		return nil
+	}
+	return v.cmd.LPush(ctx, v.pendingKey(), v.member(biz, bizId)).Err()
+}
+
+func (v *ViewRedisCache) PopViewCnts(ctx context.Context, limit int) ([]domain.ViewCnt, error) {
+	res := make([]domain.ViewCnt, 0, limit)
+	for len(res) < limit {
+		member, err := v.cmd.LPop(ctx, v.pendingKey()).Result()
+		if errors.Is(err, redis.Nil) {
+			break
+		}
+		if err != nil {
+			return res, err
+		}
+		biz, bizId, err := v.parseMember(member)
+		if err != nil {
+			return res, err
+		}
+		// GetDel 原子地读取并删除，之后的浏览会从 0 开始累加，重新放进待写入的列表，
+		// 所以不会丢失，也不会给每个浏览过的资源都留下一个永不过期的计数
+		cnt, err := v.cmd.GetDel(ctx, v.cntKey(biz, bizId)).Int64()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			// 放回去等下一次，不然这个资源后面的浏览数就再也不会被写入数据库了
+			pushErr := v.cmd.LPush(ctx, v.pendingKey(), member).Err()
+			return res, errors.Join(err, pushErr)
+		}
+		if cnt > 0 {
+			res = append(res, domain.ViewCnt{Biz: biz, BizId: bizId, Cnt: cnt})
+		}
+	}
+	return res, nil
+}
+
+func (v *ViewRedisCache) cntKey(biz string, bizId int64) string {
+	return v.key(fmt.Sprintf("cnt:%s:%d", biz, bizId))
+}
+
+func (v *ViewRedisCache) pendingKey() string {
+	return v.key("pending")
+}
+
+func (v *ViewRedisCache) key(k string) string {
+	return "webook:interactive:view:" + k
+}
+
+func (v *ViewRedisCache) member(biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d", biz, bizId)
+}
+
+func (v *ViewRedisCache) parseMember(member string) (string, int64, error) {
+	idx := strings.LastIndexByte(member, ':')
+	if idx < 0 {
+		return "", 0, fmt.Errorf("待写入的浏览数格式不对 %s", member)
+	}
+	bizId, err := strconv.ParseInt(member[idx+1:], 10, 64)
+	return member[:idx], bizId, err
+}
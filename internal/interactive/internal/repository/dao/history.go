@@ -0,0 +1,120 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ViewHistoryDAO interface {
+	// Upsert 已经浏览过的资源只更新浏览时间，乱序到达的旧消息不会把时间改回去
+	Upsert(ctx context.Context, histories []ViewHistory) error
+	// Trim 每个用户在每个业务下面只保留最近浏览的 capacity 条
+	Trim(ctx context.Context, uid int64, biz string, capacity int) error
+	// List biz 为空的时候不区分业务
+	List(ctx context.Context, uid int64, biz string, offset, limit int) ([]ViewHistory, error)
+	// FindByBizIds 按照浏览时间倒序
+	FindByBizIds(ctx context.Context, uid int64, biz string, bizIds []int64) ([]ViewHistory, error)
+	// Delete biz 为空的时候删除所有业务的浏览记录
+	Delete(ctx context.Context, uid int64, biz string) error
+	// Merge 把 sourceUid 的浏览记录转移到 targetUid 上，两边都浏览过的保留最后一次的浏览时间
+	Merge(ctx context.Context, sourceUid, targetUid int64) error
+}
+
+type GORMViewHistoryDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMViewHistoryDAO(db *egorm.Component) ViewHistoryDAO {
+	return &GORMViewHistoryDAO{db: db}
+}
+
+func (g *GORMViewHistoryDAO) Upsert(ctx context.Context, histories []ViewHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"utime": gorm.Expr("GREATEST(`utime`, VALUES(`utime`))"),
+		}),
+	}).Create(&histories).Error
+}
+
+func (g *GORMViewHistoryDAO) Trim(ctx context.Context, uid int64, biz string, capacity int) error {
+	var oldest ViewHistory
+	// 第 capacity + 1 条以及更早的都要删掉
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND biz = ?", uid, biz).
+		Order("utime DESC, id DESC").
+		Offset(capacity).
+		First(&oldest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND (utime < ? OR (utime = ? AND id <= ?))",
+			uid, biz, oldest.Utime, oldest.Utime, oldest.Id).
+		Delete(&ViewHistory{}).Error
+}
+
+func (g *GORMViewHistoryDAO) List(ctx context.Context, uid int64, biz string, offset, limit int) ([]ViewHistory, error) {
+	var res []ViewHistory
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if biz != "" {
+		db = db.Where("biz = ?", biz)
+	}
+	err := db.Order("utime DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMViewHistoryDAO) FindByBizIds(ctx context.Context, uid int64, biz string, bizIds []int64) ([]ViewHistory, error) {
+	var res []ViewHistory
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id IN ?", uid, biz, bizIds).
+		Order("utime DESC, id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMViewHistoryDAO) Delete(ctx context.Context, uid int64, biz string) error {
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if biz != "" {
+		db = db.Where("biz = ?", biz)
+	}
+	return db.Delete(&ViewHistory{}).Error
+}
+
+func (g *GORMViewHistoryDAO) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("INSERT INTO `view_histories` (`uid`, `biz`, `biz_id`, `utime`, `ctime`) "+
+			"SELECT ?, `biz`, `biz_id`, `utime`, `ctime` FROM `view_histories` WHERE `uid` = ? "+
+			"ON DUPLICATE KEY UPDATE `utime` = GREATEST(`view_histories`.`utime`, VALUES(`utime`))",
+			targetUid, sourceUid).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", sourceUid).Delete(&ViewHistory{}).Error
+	})
+}
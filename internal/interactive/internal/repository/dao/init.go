@@ -17,6 +17,6 @@ package dao
 import "github.com/ego-component/egorm"
 
 func InitTables(db *egorm.Component) error {
-	err := db.AutoMigrate(&Collection{}, &Interactive{}, &UserLikeBiz{}, &UserCollectionBiz{})
+	err := db.AutoMigrate(&Collection{}, &CollectionFollow{}, &Interactive{}, &UserLikeBiz{}, &UserCollectionBiz{}, &ViewHistory{})
 	return err
 }
@@ -29,6 +29,9 @@ var ErrDeleteOtherCollection = errors.New("删除非本人的收藏夹")
 
 type InteractiveDAO interface {
 	IncrViewCnt(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrViewCnt 一条语句累加多个资源的浏览数，只用到 Biz、BizId 和 ViewCnt
+	BatchIncrViewCnt(ctx context.Context, intrs []Interactive) error
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error
 	LikeToggle(ctx context.Context, biz string, id int64, uid int64) error
 	CollectToggle(ctx context.Context, cb UserCollectionBiz) error
 	GetLikeInfo(ctx context.Context,
@@ -53,6 +56,23 @@ type InteractiveDAO interface {
 	CollectionInfo(ctx context.Context, collectionId int64) ([]UserCollectionBiz, error)
 	// 收藏转移
 	MoveCollection(ctx context.Context, biz string, bizid, uid, collectionId int64) error
+	GetCollection(ctx context.Context, id int64) (Collection, error)
+	GetCollectionByShareToken(ctx context.Context, token string) (Collection, error)
+	// FollowCollection 重复关注不会报错，也不会重复计数
+	FollowCollection(ctx context.Context, uid, collectionId int64) error
+	UnfollowCollection(ctx context.Context, uid, collectionId int64) error
+	GetCollectionFollow(ctx context.Context, uid, collectionId int64) (CollectionFollow, error)
+	// FollowedCollections 关注的收藏夹，只返回可见性是 visibility 的
+	FollowedCollections(ctx context.Context, uid int64, visibility uint8, offset, limit int) ([]Collection, error)
+	// CopyCollection 把 srcId 收藏夹的内容复制到新建的 dst 收藏夹，已经收藏过的内容保持不动
+	CopyCollection(ctx context.Context, srcId int64, dst Collection) (int64, error)
+	// MergeUser 把 sourceUid 的点赞、收藏夹、收藏和关注都转移到 targetUid 上，
+	// 同名的收藏夹合并成一个，两个账号都点赞或者收藏过的只保留一份，计数也相应减少
+	MergeUser(ctx context.Context, sourceUid, targetUid int64) error
+	// DeleteUser 删除用户的点赞、收藏、收藏夹和关注，计数也相应减少
+	DeleteUser(ctx context.Context, uid int64) error
+	// FindUserLikes 用户的点赞记录，最近的在前面
+	FindUserLikes(ctx context.Context, uid int64, offset, limit int) ([]UserLikeBiz, error)
 	// 减少计数
 	DecrCollectCount(ctx context.Context, biz string, bizid int64) error
 }
@@ -104,8 +124,10 @@ func (g *GORMInteractiveDAO) SaveCollection(ctx context.Context, collection Coll
 				},
 			},
 			DoUpdates: clause.Assignments(map[string]any{
-				"name":  collection.Name,
-				"utime": collection.Utime,
+				"name":        collection.Name,
+				"visibility":  collection.Visibility,
+				"share_token": collection.ShareToken,
+				"utime":       collection.Utime,
 			}),
 		},
 	).Create(&collection).Error
@@ -125,11 +147,326 @@ func (g *GORMInteractiveDAO) DeleteCollection(ctx context.Context, uid, collecti
 		if res.RowsAffected < 1 {
 			return fmt.Errorf("%w", ErrDeleteOtherCollection)
 		}
+		err := tx.Where("cid = ?", collectionId).Delete(&CollectionFollow{}).Error
+		if err != nil {
+			return err
+		}
 		// 删除收藏内容
 		return tx.Model(&UserCollectionBiz{}).Where("cid = ? AND uid = ?", collectionId, uid).Delete(&UserCollectionBiz{}).Error
 	})
 }
 
+func (g *GORMInteractiveDAO) GetCollection(ctx context.Context, id int64) (Collection, error) {
+	var c Collection
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GORMInteractiveDAO) GetCollectionByShareToken(ctx context.Context, token string) (Collection, error) {
+	var c Collection
+	err := g.db.WithContext(ctx).Where("share_token = ?", token).First(&c).Error
+	return c, err
+}
+
+func (g *GORMInteractiveDAO) FollowCollection(ctx context.Context, uid, collectionId int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&CollectionFollow{
+			Uid:   uid,
+			Cid:   collectionId,
+			Utime: now,
+			Ctime: now,
+		})
+		if res.Error != nil || res.RowsAffected < 1 {
+			return res.Error
+		}
+		return tx.Model(&Collection{}).Where("id = ?", collectionId).
+			Updates(map[string]any{
+				"follower_cnt": gorm.Expr("`follower_cnt` + 1"),
+				"utime":        now,
+			}).Error
+	})
+}
+
+func (g *GORMInteractiveDAO) UnfollowCollection(ctx context.Context, uid, collectionId int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid = ? AND cid = ?", uid, collectionId).Delete(&CollectionFollow{})
+		if res.Error != nil || res.RowsAffected < 1 {
+			return res.Error
+		}
+		return tx.Model(&Collection{}).Where("id = ? AND follower_cnt > 0", collectionId).
+			Updates(map[string]any{
+				"follower_cnt": gorm.Expr("`follower_cnt` - 1"),
+				"utime":        now,
+			}).Error
+	})
+}
+
+func (g *GORMInteractiveDAO) GetCollectionFollow(ctx context.Context, uid, collectionId int64) (CollectionFollow, error) {
+	var f CollectionFollow
+	err := g.db.WithContext(ctx).Where("uid = ? AND cid = ?", uid, collectionId).First(&f).Error
+	return f, err
+}
+
+func (g *GORMInteractiveDAO) FollowedCollections(ctx context.Context, uid int64, visibility uint8, offset, limit int) ([]Collection, error) {
+	var collections []Collection
+	err := g.db.WithContext(ctx).
+		Model(&Collection{}).
+		Select("collections.*").
+		Joins("JOIN collection_follows ON collection_follows.cid = collections.id").
+		Where("collection_follows.uid = ? AND collections.visibility = ?", uid, visibility).
+		Order("collection_follows.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&collections).Error
+	return collections, err
+}
+
+func (g *GORMInteractiveDAO) CopyCollection(ctx context.Context, srcId int64, dst Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	dst.Ctime = now
+	dst.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&dst).Error
+		if err != nil {
+			return err
+		}
+		var records []UserCollectionBiz
+		err = tx.Where("cid = ?", srcId).Order("id").Find(&records).Error
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			// 同一个资源只能收藏在一个收藏夹里面，已经收藏过的就跳过
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserCollectionBiz{
+				Uid:   dst.Uid,
+				Biz:   r.Biz,
+				BizId: r.BizId,
+				Cid:   dst.Id,
+				Utime: now,
+				Ctime: now,
+			})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected < 1 {
+				continue
+			}
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]any{
+					"collect_cnt": gorm.Expr("`collect_cnt` + 1"),
+					"utime":       now,
+				}),
+			}).Create(&Interactive{
+				Biz:        r.Biz,
+				BizId:      r.BizId,
+				CollectCnt: 1,
+				Ctime:      now,
+				Utime:      now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return dst.Id, err
+}
+
+func (g *GORMInteractiveDAO) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := g.mergeCollections(tx, sourceUid, targetUid, now)
+		if err != nil {
+			return err
+		}
+		// 目标账号已经有的会因为唯一索引冲突被忽略，留在源账号上的就是重复的，删掉的时候要减少计数
+		err = tx.Exec("UPDATE IGNORE `user_collection_bizs` SET `uid` = ?, `utime` = ? WHERE `uid` = ?",
+			targetUid, now, sourceUid).Error
+		if err != nil {
+			return err
+		}
+		var collects []UserCollectionBiz
+		err = tx.Where("uid = ?", sourceUid).Find(&collects).Error
+		if err != nil {
+			return err
+		}
+		for _, cb := range collects {
+			err = g.deleteCollectionInfo(tx, cb.Biz, cb.BizId, sourceUid)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Exec("UPDATE IGNORE `user_like_bizs` SET `uid` = ?, `utime` = ? WHERE `uid` = ?",
+			targetUid, now, sourceUid).Error
+		if err != nil {
+			return err
+		}
+		var likes []UserLikeBiz
+		err = tx.Where("uid = ?", sourceUid).Find(&likes).Error
+		if err != nil {
+			return err
+		}
+		for _, l := range likes {
+			err = g.deleteLikeInfo(tx, l.Biz, l.BizId, sourceUid)
+			if err != nil {
+				return err
+			}
+		}
+		return g.mergeFollows(tx, sourceUid, targetUid, now)
+	})
+}
+
+func (g *GORMInteractiveDAO) DeleteUser(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var collects []UserCollectionBiz
+		err := tx.Where("uid = ?", uid).Find(&collects).Error
+		if err != nil {
+			return err
+		}
+		for _, cb := range collects {
+			err = g.deleteCollectionInfo(tx, cb.Biz, cb.BizId, uid)
+			if err != nil {
+				return err
+			}
+		}
+		var likes []UserLikeBiz
+		err = tx.Where("uid = ?", uid).Find(&likes).Error
+		if err != nil {
+			return err
+		}
+		for _, l := range likes {
+			err = g.deleteLikeInfo(tx, l.Biz, l.BizId, uid)
+			if err != nil {
+				return err
+			}
+		}
+		return g.deleteFollows(tx, uid)
+	})
+}
+
+// deleteFollows 删除用户关注的收藏夹，以及别人对这个用户的收藏夹的关注，最后删除收藏夹本身
+func (g *GORMInteractiveDAO) deleteFollows(tx *gorm.DB, uid int64) error {
+	var cids []int64
+	err := tx.Model(&CollectionFollow{}).Where("uid = ?", uid).Pluck("cid", &cids).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("uid = ?", uid).Delete(&CollectionFollow{}).Error
+	if err != nil {
+		return err
+	}
+	owned := tx.Model(&Collection{}).Select("id").Where("uid = ?", uid)
+	err = tx.Where("cid IN (?)", owned).Delete(&CollectionFollow{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("uid = ?", uid).Delete(&Collection{}).Error
+	if err != nil || len(cids) == 0 {
+		return err
+	}
+	return tx.Model(&Collection{}).Where("id IN ?", cids).
+		Updates(map[string]any{
+			"follower_cnt": tx.Model(&CollectionFollow{}).Select("COUNT(*)").Where("cid = `collections`.`id`"),
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GORMInteractiveDAO) FindUserLikes(ctx context.Context, uid int64, offset, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// mergeCollections 同名的收藏夹把内容和关注者并到目标账号的收藏夹里面，其余的直接转移
+func (g *GORMInteractiveDAO) mergeCollections(tx *gorm.DB, sourceUid, targetUid, now int64) error {
+	var sources []Collection
+	err := tx.Where("uid = ?", sourceUid).Find(&sources).Error
+	if err != nil || len(sources) == 0 {
+		return err
+	}
+	names := make([]string, 0, len(sources))
+	for _, c := range sources {
+		names = append(names, c.Name)
+	}
+	var targets []Collection
+	err = tx.Where("uid = ? AND name IN ?", targetUid, names).Find(&targets).Error
+	if err != nil {
+		return err
+	}
+	targetIds := make(map[string]int64, len(targets))
+	for _, c := range targets {
+		targetIds[c.Name] = c.Id
+	}
+	for _, c := range sources {
+		cid, ok := targetIds[c.Name]
+		if !ok {
+			continue
+		}
+		err = tx.Model(&UserCollectionBiz{}).Where("cid = ?", c.Id).
+			Updates(map[string]any{"cid": cid, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("UPDATE IGNORE `collection_follows` SET `cid` = ?, `utime` = ? WHERE `cid` = ?",
+			cid, now, c.Id).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("cid = ?", c.Id).Delete(&CollectionFollow{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Delete(&Collection{}, c.Id).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Model(&Collection{}).Where("uid = ?", sourceUid).
+		Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+}
+
+// mergeFollows 转移关注，重复的关注和关注自己收藏夹的都要删掉，最后重新计算关注数
+func (g *GORMInteractiveDAO) mergeFollows(tx *gorm.DB, sourceUid, targetUid, now int64) error {
+	err := tx.Exec("UPDATE IGNORE `collection_follows` SET `uid` = ?, `utime` = ? WHERE `uid` = ?",
+		targetUid, now, sourceUid).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("uid = ?", sourceUid).Delete(&CollectionFollow{}).Error
+	if err != nil {
+		return err
+	}
+	owned := tx.Model(&Collection{}).Select("id").Where("uid = ?", targetUid)
+	err = tx.Where("uid = ? AND cid IN (?)", targetUid, owned).Delete(&CollectionFollow{}).Error
+	if err != nil {
+		return err
+	}
+	// 受影响的收藏夹不多，直接按照明细重新计算
+	var cids []int64
+	err = tx.Model(&CollectionFollow{}).Distinct("cid").Where("uid = ?", targetUid).Pluck("cid", &cids).Error
+	if err != nil {
+		return err
+	}
+	var ownedIds []int64
+	err = tx.Model(&Collection{}).Where("uid = ?", targetUid).Pluck("id", &ownedIds).Error
+	if err != nil {
+		return err
+	}
+	cids = append(cids, ownedIds...)
+	if len(cids) == 0 {
+		return nil
+	}
+	return tx.Model(&Collection{}).Where("id IN ?", cids).
+		Updates(map[string]any{
+			"follower_cnt": tx.Model(&CollectionFollow{}).Select("COUNT(*)").Where("cid = `collections`.`id`"),
+			"utime":        now,
+		}).Error
+}
+
 func (g *GORMInteractiveDAO) CollectionList(ctx context.Context, uid int64, offset, limit int) ([]Collection, error) {
 	var collections []Collection
 	err := g.db.WithContext(ctx).
@@ -302,6 +639,37 @@ func (g *GORMInteractiveDAO) IncrViewCnt(ctx context.Context, biz string, bizId
 	}).Error
 }
 
+func (g *GORMInteractiveDAO) BatchIncrViewCnt(ctx context.Context, intrs []Interactive) error {
+	now := time.Now().UnixMilli()
+	for idx := range intrs {
+		intrs[idx].Ctime = now
+		intrs[idx].Utime = now
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"view_cnt": gorm.Expr("`view_cnt` + VALUES(`view_cnt`)"),
+			"utime":    now,
+		}),
+	}).Create(&intrs).Error
+}
+
+func (g *GORMInteractiveDAO) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			// 避免消息乱序导致计数变成负数
+			"comment_cnt": gorm.Expr("GREATEST(`comment_cnt` + ?, 0)", delta),
+			"utime":       now,
+		}),
+	}).Create(&Interactive{
+		Biz:        biz,
+		BizId:      bizId,
+		CommentCnt: max(delta, 0),
+		Ctime:      now,
+		Utime:      now,
+	}).Error
+}
+
 func (g *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, id int64, uid int64) (UserLikeBiz, error) {
 	var res UserLikeBiz
 	err := g.db.WithContext(ctx).
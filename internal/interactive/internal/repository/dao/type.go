@@ -14,6 +14,8 @@
 
 package dao
 
+import "database/sql"
+
 // Interactive 汇总表
 type Interactive struct {
 	Id         int64  `gorm:"primaryKey,autoIncrement"`
@@ -22,6 +24,7 @@ type Interactive struct {
 	ViewCnt    int
 	LikeCnt    int
 	CollectCnt int
+	CommentCnt int
 	Utime      int64
 	Ctime      int64
 }
@@ -52,8 +55,33 @@ type UserCollectionBiz struct {
 type Collection struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// 在 Uid 和 Name 上创建唯一索引，确保用户不会创建同名收藏夹
-	Uid   int64  `gorm:"uniqueIndex:uid_name"`
-	Name  string `gorm:"type:varchar(256);uniqueIndex:uid_name"`
+	Uid  int64  `gorm:"uniqueIndex:uid_name"`
+	Name string `gorm:"type:varchar(256);uniqueIndex:uid_name"`
+	// Visibility 0 私有，1 拿到分享链接的人可见，2 公开
+	Visibility uint8 `gorm:"not null;default:0"`
+	// ShareToken 分享链接里面的凭证，没有分享过的是 NULL
+	ShareToken  sql.NullString `gorm:"type:varchar(64);unique"`
+	FollowerCnt int64
+	Utime       int64
+	Ctime       int64
+}
+
+// CollectionFollow 关注收藏夹的明细表
+type CollectionFollow struct {
+	Id    int64 `gorm:"primaryKey,autoIncrement"`
+	Uid   int64 `gorm:"uniqueIndex:uid_cid"`
+	Cid   int64 `gorm:"uniqueIndex:uid_cid;index"`
 	Utime int64
 	Ctime int64
 }
+
+// ViewHistory 浏览记录，同一个用户同一个资源只有一条
+type ViewHistory struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_id;index:uid_biz_utime"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_id;index:uid_biz_utime"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_id"`
+	// Utime 最后一次浏览的时间
+	Utime int64 `gorm:"index:uid_biz_utime"`
+	Ctime int64
+}
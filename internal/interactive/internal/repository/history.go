@@ -0,0 +1,85 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
+)
+
+type HistoryRepository interface {
+	Save(ctx context.Context, histories []domain.ViewHistory) error
+	Trim(ctx context.Context, uid int64, biz string, capacity int) error
+	List(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.ViewHistory, error)
+	FindByBizIds(ctx context.Context, uid int64, biz string, bizIds []int64) ([]domain.ViewHistory, error)
+	Clear(ctx context.Context, uid int64, biz string) error
+	Merge(ctx context.Context, sourceUid, targetUid int64) error
+}
+
+type historyRepository struct {
+	dao dao.ViewHistoryDAO
+}
+
+func NewHistoryRepository(d dao.ViewHistoryDAO) HistoryRepository {
+	return &historyRepository{dao: d}
+}
+
+func (h *historyRepository) Save(ctx context.Context, histories []domain.ViewHistory) error {
+	now := time.Now().UnixMilli()
+	return h.dao.Upsert(ctx, slice.Map(histories, func(idx int, src domain.ViewHistory) dao.ViewHistory {
+		return dao.ViewHistory{
+			Uid:   src.Uid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Utime: src.Utime,
+			Ctime: now,
+		}
+	}))
+}
+
+func (h *historyRepository) Trim(ctx context.Context, uid int64, biz string, capacity int) error {
+	return h.dao.Trim(ctx, uid, biz, capacity)
+}
+
+func (h *historyRepository) List(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.ViewHistory, error) {
+	res, err := h.dao.List(ctx, uid, biz, offset, limit)
+	return slice.Map(res, h.toDomain), err
+}
+
+func (h *historyRepository) FindByBizIds(ctx context.Context, uid int64, biz string, bizIds []int64) ([]domain.ViewHistory, error) {
+	res, err := h.dao.FindByBizIds(ctx, uid, biz, bizIds)
+	return slice.Map(res, h.toDomain), err
+}
+
+func (h *historyRepository) Clear(ctx context.Context, uid int64, biz string) error {
+	return h.dao.Delete(ctx, uid, biz)
+}
+
+func (h *historyRepository) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	return h.dao.Merge(ctx, sourceUid, targetUid)
+}
+
+func (h *historyRepository) toDomain(idx int, src dao.ViewHistory) domain.ViewHistory {
+	return domain.ViewHistory{
+		Uid:   src.Uid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Utime: src.Utime,
+	}
+}
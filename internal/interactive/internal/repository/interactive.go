@@ -16,14 +16,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"time"
 
+	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/gotomicro/ego/core/elog"
 
 	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
 )
 
@@ -32,13 +35,21 @@ const (
 	CaseSetBiz     = "caseSet"
 	QuestionBiz    = "question"
 	QuestionSetBiz = "questionSet"
+	ProjectBiz     = "project"
 )
 
 var defaultTimeout = 1 * time.Second
 var ErrRecordNotFound = dao.ErrRecordNotFound
 
 type InteractiveRepository interface {
-	IncrViewCnt(ctx context.Context, biz string, bizId int64) error
+	// MarkViewed 去重窗口内第一次浏览返回 true
+	MarkViewed(ctx context.Context, view domain.View) (bool, error)
+	// IncrViewCnt 浏览数先累加在缓存里面，由 FlushViewCnts 批量写入数据库
+	IncrViewCnt(ctx context.Context, biz string, bizId int64, delta int64) error
+	// FlushViewCnts 把缓存里面最多 limit 个资源的浏览数写入数据库，返回写入的资源数
+	FlushViewCnts(ctx context.Context, limit int) (int, error)
+	// IncrCommentCnt delta 可以是负数，代表评论被删除或者被隐藏
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error
 	LikeToggle(ctx context.Context, biz string, id int64, uid int64) error
 	CollectToggle(ctx context.Context, biz string, id int64, uid int64) error
 	Get(ctx context.Context, biz string, id int64) (domain.Interactive, error)
@@ -56,10 +67,22 @@ type InteractiveRepository interface {
 	CollectionInfo(ctx context.Context, uid, collectionId int64, offset, limit int) ([]domain.CollectionRecord, error)
 	// MoveCollection 转移收藏夹
 	MoveCollection(ctx context.Context, biz string, bizid, uid, collectionId int64) error
+	GetCollection(ctx context.Context, id int64) (domain.Collection, error)
+	GetCollectionByShareToken(ctx context.Context, token string) (domain.Collection, error)
+	FollowCollection(ctx context.Context, uid, collectionId int64) error
+	UnfollowCollection(ctx context.Context, uid, collectionId int64) error
+	Followed(ctx context.Context, uid, collectionId int64) (bool, error)
+	FollowedCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	// CopyCollection 复制一份 srcId 收藏夹的内容到新的收藏夹 dst，返回新收藏夹的 ID
+	CopyCollection(ctx context.Context, srcId int64, dst domain.Collection) (int64, error)
+	MergeUser(ctx context.Context, sourceUid, targetUid int64) error
+	DeleteUser(ctx context.Context, uid int64) error
+	UserLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.Like, error)
 }
 
 type interactiveRepository struct {
 	interactiveDao dao.InteractiveDAO
+	viewCache      cache.ViewCache
 	logger         *elog.Component
 }
 
@@ -112,8 +135,126 @@ func (i *interactiveRepository) CollectionInfo(ctx context.Context, uid, collect
 	return records, nil
 }
 
-func (i *interactiveRepository) IncrViewCnt(ctx context.Context, biz string, bizId int64) error {
-	return i.interactiveDao.IncrViewCnt(ctx, biz, bizId)
+func (i *interactiveRepository) GetCollection(ctx context.Context, id int64) (domain.Collection, error) {
+	c, err := i.interactiveDao.GetCollection(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return i.collectionToDomain(c), nil
+}
+
+func (i *interactiveRepository) GetCollectionByShareToken(ctx context.Context, token string) (domain.Collection, error) {
+	c, err := i.interactiveDao.GetCollectionByShareToken(ctx, token)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return i.collectionToDomain(c), nil
+}
+
+func (i *interactiveRepository) FollowCollection(ctx context.Context, uid, collectionId int64) error {
+	return i.interactiveDao.FollowCollection(ctx, uid, collectionId)
+}
+
+func (i *interactiveRepository) UnfollowCollection(ctx context.Context, uid, collectionId int64) error {
+	return i.interactiveDao.UnfollowCollection(ctx, uid, collectionId)
+}
+
+func (i *interactiveRepository) Followed(ctx context.Context, uid, collectionId int64) (bool, error) {
+	_, err := i.interactiveDao.GetCollectionFollow(ctx, uid, collectionId)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, dao.ErrRecordNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (i *interactiveRepository) FollowedCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	// 只对持有链接的人可见的收藏夹，主人换了凭证之后关注的人就不应该再看到，所以只返回公开的
+	clist, err := i.interactiveDao.FollowedCollections(ctx, uid,
+		domain.CollectionVisibilityPublic.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(clist, func(idx int, src dao.Collection) domain.Collection {
+		c := i.collectionToDomain(src)
+		c.Followed = true
+		return c
+	}), nil
+}
+
+func (i *interactiveRepository) CopyCollection(ctx context.Context, srcId int64, dst domain.Collection) (int64, error) {
+	return i.interactiveDao.CopyCollection(ctx, srcId, i.collectionToEntity(dst))
+}
+
+func (i *interactiveRepository) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	return i.interactiveDao.MergeUser(ctx, sourceUid, targetUid)
+}
+
+func (i *interactiveRepository) DeleteUser(ctx context.Context, uid int64) error {
+	return i.interactiveDao.DeleteUser(ctx, uid)
+}
+
+func (i *interactiveRepository) UserLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.Like, error) {
+	likes, err := i.interactiveDao.FindUserLikes(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao.UserLikeBiz) domain.Like {
+		return domain.Like{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: src.Ctime,
+		}
+	}), nil
+}
+
+func (i *interactiveRepository) MarkViewed(ctx context.Context, view domain.View) (bool, error) {
+	return i.viewCache.MarkViewed(ctx, view)
+}
+
+func (i *interactiveRepository) IncrViewCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return i.viewCache.IncrViewCnt(ctx, biz, bizId, delta)
+}
+
+func (i *interactiveRepository) FlushViewCnts(ctx context.Context, limit int) (int, error) {
+	cnts, err := i.viewCache.PopViewCnts(ctx, limit)
+	if len(cnts) == 0 {
+		return 0, err
+	}
+	intrs := make([]dao.Interactive, 0, len(cnts))
+	for _, cnt := range cnts {
+		intrs = append(intrs, dao.Interactive{
+			Biz:     cnt.Biz,
+			BizId:   cnt.BizId,
+			ViewCnt: int(cnt.Cnt),
+		})
+	}
+	dbErr := i.interactiveDao.BatchIncrViewCnt(ctx, intrs)
+	if dbErr != nil {
+		// 已经从缓存里面取出来了，写数据库失败要放回去，等下一次再写
+		i.restoreViewCnts(cnts)
+		return 0, errors.Join(err, dbErr)
+	}
+	return len(cnts), err
+}
+
+func (i *interactiveRepository) restoreViewCnts(cnts []domain.ViewCnt) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	for _, cnt := range cnts {
+		err := i.viewCache.IncrViewCnt(ctx, cnt.Biz, cnt.BizId, cnt.Cnt)
+		if err != nil {
+			i.logger.Error("浏览数放回缓存失败", elog.FieldErr(err),
+				elog.String("biz", cnt.Biz), elog.Int64("bizId", cnt.BizId), elog.Int64("cnt", cnt.Cnt))
+		}
+	}
+}
+
+func (i *interactiveRepository) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error {
+	return i.interactiveDao.IncrCommentCnt(ctx, biz, bizId, delta)
 }
 
 func (i *interactiveRepository) Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error) {
@@ -216,9 +357,10 @@ func (i *interactiveRepository) GetByIds(ctx context.Context, biz string, uid in
 	return list, nil
 }
 
-func NewCachedInteractiveRepository(interactiveDao dao.InteractiveDAO) InteractiveRepository {
+func NewCachedInteractiveRepository(interactiveDao dao.InteractiveDAO, viewCache cache.ViewCache) InteractiveRepository {
 	return &interactiveRepository{
 		interactiveDao: interactiveDao,
+		viewCache:      viewCache,
 		logger:         elog.DefaultLogger,
 	}
 }
@@ -230,22 +372,32 @@ func (i *interactiveRepository) toDomain(ie dao.Interactive) domain.Interactive
 		LikeCnt:    ie.LikeCnt,
 		CollectCnt: ie.CollectCnt,
 		ViewCnt:    ie.ViewCnt,
+		CommentCnt: ie.CommentCnt,
 	}
 }
 
 func (i *interactiveRepository) collectionToDomain(collectionDao dao.Collection) domain.Collection {
 	return domain.Collection{
-		Id:   collectionDao.Id,
-		Uid:  collectionDao.Uid,
-		Name: collectionDao.Name,
+		Id:          collectionDao.Id,
+		Uid:         collectionDao.Uid,
+		Name:        collectionDao.Name,
+		Visibility:  domain.CollectionVisibility(collectionDao.Visibility),
+		ShareToken:  collectionDao.ShareToken.String,
+		FollowerCnt: collectionDao.FollowerCnt,
 	}
 }
 
 func (i *interactiveRepository) collectionToEntity(ie domain.Collection) dao.Collection {
 	return dao.Collection{
-		Id:   ie.Id,
-		Uid:  ie.Uid,
-		Name: ie.Name,
+		Id:         ie.Id,
+		Uid:        ie.Uid,
+		Name:       ie.Name,
+		Visibility: ie.Visibility.ToUint8(),
+		ShareToken: sql.NullString{
+			String: ie.ShareToken,
+			Valid:  ie.ShareToken != "",
+		},
+		FollowerCnt: ie.FollowerCnt,
 	}
 }
 
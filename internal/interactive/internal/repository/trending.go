@@ -0,0 +1,90 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository/cache"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// localExpiration 本地缓存的排行榜只用来兜底，所以可以保留很久
+const localExpiration = 24 * time.Hour
+
+type TrendingRepository interface {
+	MarkScored(ctx context.Context, e domain.Engagement) (bool, error)
+	IncrScore(ctx context.Context, biz string, bizId int64, weight float64, t time.Time) error
+	Rank(ctx context.Context, biz string, period domain.Period, t time.Time) error
+	// TopN redis 不可用的时候，返回本地缓存的上一次结果
+	TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error)
+}
+
+type trendingRepository struct {
+	cache cache.TrendingCache
+	// local 本地缓存，只用来兜底
+	local ecache.Cache
+	// capacity 排行榜保留多少个
+	capacity int
+	logger   *elog.Component
+}
+
+func NewTrendingRepository(c cache.TrendingCache, local ecache.Cache, capacity int) TrendingRepository {
+	return &trendingRepository{
+		cache:    c,
+		local:    local,
+		capacity: capacity,
+		logger:   elog.DefaultLogger,
+	}
+}
+
+func (t *trendingRepository) MarkScored(ctx context.Context, e domain.Engagement) (bool, error) {
+	return t.cache.MarkScored(ctx, e)
+}
+
+func (t *trendingRepository) IncrScore(ctx context.Context, biz string, bizId int64, weight float64, now time.Time) error {
+	return t.cache.IncrScore(ctx, biz, bizId, weight, now)
+}
+
+func (t *trendingRepository) Rank(ctx context.Context, biz string, period domain.Period, now time.Time) error {
+	return t.cache.Rank(ctx, biz, period, now, t.capacity)
+}
+
+func (t *trendingRepository) TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error) {
+	key := fmt.Sprintf("%s:%s", biz, period)
+	// 总是取整个排行榜，这样本地缓存可以满足任意的 n
+	items, err := t.cache.TopN(ctx, biz, period, t.capacity)
+	if err != nil {
+		val := t.local.Get(ctx, key)
+		cached, ok := val.Val.([]domain.TrendingItem)
+		if val.Err != nil || !ok {
+			return nil, err
+		}
+		t.logger.Warn("读取排行榜失败，使用本地缓存", elog.FieldErr(err),
+			elog.String("biz", biz), elog.String("period", string(period)))
+		items = cached
+	} else {
+		// 过期时间设置得比较长，redis 长时间不可用的时候，旧的排行榜也比没有好
+		_ = t.local.Set(ctx, key, items, localExpiration)
+	}
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items, nil
+}
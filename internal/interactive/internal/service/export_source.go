@@ -0,0 +1,110 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+// exportBatchSize 导出的时候分批读取，避免一次查询太多数据
+const exportBatchSize = 100
+
+type exportCollection struct {
+	domain.Collection
+	Records []domain.CollectionRecord
+}
+
+type exportData struct {
+	Likes []domain.Like
+	// Collections 第一个是默认收藏夹
+	Collections         []exportCollection
+	FollowedCollections []domain.Collection
+	Histories           []domain.ViewHistory
+}
+
+// exportSource 导出个人数据的时候读取点赞、收藏夹、收藏、关注的收藏夹和浏览历史
+type exportSource struct {
+	svc        Service
+	historySvc HistoryService
+}
+
+func NewExportSource(svc Service, historySvc HistoryService) exportx.Source {
+	return &exportSource{svc: svc, historySvc: historySvc}
+}
+
+func (s *exportSource) Name() string {
+	return "interactive"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	var (
+		res exportData
+		err error
+	)
+	res.Likes, err = readAll(func(offset, limit int) ([]domain.Like, error) {
+		return s.svc.UserLikes(ctx, uid, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	collections, err := readAll(func(offset, limit int) ([]domain.Collection, error) {
+		return s.svc.CollectionList(ctx, uid, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// id 为 0 的是默认收藏夹
+	collections = append([]domain.Collection{{Uid: uid}}, collections...)
+	res.Collections = make([]exportCollection, 0, len(collections))
+	for _, c := range collections {
+		records, err := readAll(func(offset, limit int) ([]domain.CollectionRecord, error) {
+			return s.svc.CollectionInfo(ctx, uid, c.Id, offset, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		res.Collections = append(res.Collections, exportCollection{Collection: c, Records: records})
+	}
+	res.FollowedCollections, err = readAll(func(offset, limit int) ([]domain.Collection, error) {
+		return s.svc.FollowedCollections(ctx, uid, offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Histories, err = readAll(func(offset, limit int) ([]domain.ViewHistory, error) {
+		return s.historySvc.List(ctx, uid, "", offset, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func readAll[T any](find func(offset, limit int) ([]T, error)) ([]T, error) {
+	var res []T
+	for offset := 0; ; offset += exportBatchSize {
+		batch, err := find(offset, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, batch...)
+		if len(batch) < exportBatchSize {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+)
+
+// HistoryCapacity 每个用户在每个业务下面保留多少条浏览记录
+const HistoryCapacity = 200
+
+// HistoryBizs 记录浏览历史的业务
+var HistoryBizs = []string{
+	repository.QuestionBiz,
+	repository.CaseBiz,
+	repository.QuestionSetBiz,
+	repository.ProjectBiz,
+}
+
+//go:generate mockgen -source=./history.go -destination=../../mocks/history.mock.go -package=intrmocks -typed HistoryService
+type HistoryService interface {
+	// Record 记录登录用户的浏览，同一个资源只保留最后一次
+	Record(ctx context.Context, views []domain.View) error
+	// List 最近浏览的在前面，biz 为空的时候不区分业务
+	List(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.ViewHistory, error)
+	// Clear biz 为空的时候清空所有业务的浏览历史
+	Clear(ctx context.Context, uid int64, biz string) error
+	// LastViewed bizIds 里面最后浏览的那一个，一个都没有浏览过的时候返回的 BizId 为 0
+	LastViewed(ctx context.Context, uid int64, biz string, bizIds []int64) (domain.ViewHistory, error)
+	// Merge 合并账号的时候，把 sourceUid 的浏览历史并到 targetUid 上，超出容量的旧记录会被裁掉
+	Merge(ctx context.Context, sourceUid, targetUid int64) error
+}
+
+type historyService struct {
+	repo repository.HistoryRepository
+}
+
+func NewHistoryService(repo repository.HistoryRepository) HistoryService {
+	return &historyService{repo: repo}
+}
+
+func (h *historyService) Record(ctx context.Context, views []domain.View) error {
+	type userBiz struct {
+		uid int64
+		biz string
+	}
+	// 浏览时间以消息里面带的为准，消息积压的时候也不会把浏览时间往后推。
+	// 旧的消息没有带时间，按照消息的顺序各错开一毫秒，这样连着看的几道题也能分出先后
+	start := time.Now().UnixMilli() - int64(len(views))
+	histories := make([]domain.ViewHistory, 0, len(views))
+	latest := make(map[domain.ViewHistory]int, len(views))
+	var users []userBiz
+	for idx, view := range views {
+		if view.Uid <= 0 || !slice.Contains(HistoryBizs, view.Biz) {
+			continue
+		}
+		key := domain.ViewHistory{Uid: view.Uid, Biz: view.Biz, BizId: view.BizId}
+		utime := view.Ctime
+		if utime <= 0 {
+			utime = start + int64(idx) + 1
+		}
+		if pos, ok := latest[key]; ok {
+			histories[pos].Utime = max(histories[pos].Utime, utime)
+			continue
+		}
+		latest[key] = len(histories)
+		key.Utime = utime
+		histories = append(histories, key)
+		ub := userBiz{uid: view.Uid, biz: view.Biz}
+		if !slice.Contains(users, ub) {
+			users = append(users, ub)
+		}
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+	err := h.repo.Save(ctx, histories)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ub := range users {
+		err = h.repo.Trim(ctx, ub.uid, ub.biz, HistoryCapacity)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("裁剪浏览历史失败 uid %d, biz %s: %w", ub.uid, ub.biz, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *historyService) List(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.ViewHistory, error) {
+	return h.repo.List(ctx, uid, biz, offset, limit)
+}
+
+func (h *historyService) Clear(ctx context.Context, uid int64, biz string) error {
+	return h.repo.Clear(ctx, uid, biz)
+}
+
+func (h *historyService) LastViewed(ctx context.Context, uid int64, biz string, bizIds []int64) (domain.ViewHistory, error) {
+	if len(bizIds) == 0 {
+		return domain.ViewHistory{}, nil
+	}
+	histories, err := h.repo.FindByBizIds(ctx, uid, biz, bizIds)
+	if err != nil || len(histories) == 0 {
+		return domain.ViewHistory{}, err
+	}
+	return histories[0], nil
+}
+
+func (h *historyService) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	err := h.repo.Merge(ctx, sourceUid, targetUid)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, biz := range HistoryBizs {
+		err = h.repo.Trim(ctx, targetUid, biz, HistoryCapacity)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("裁剪浏览历史失败 uid %d, biz %s: %w", targetUid, biz, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -17,15 +17,24 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/lithammer/shortuuid/v4"
 	"golang.org/x/sync/errgroup"
 )
 
 //go:generate mockgen -source=./interactive.go -destination=../../mocks/interactive.mock.go -package=intrmocks -typed InteractiveService
 type Service interface {
-	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// RecordViews 记录一批浏览，同一个用户在去重窗口内重复浏览同一个资源只算一次，
+	// 浏览数先累加在缓存里面，由 FlushViewCnts 定期批量写入数据库，所以是最终一致的
+	RecordViews(ctx context.Context, views []domain.View) error
+	// FlushViewCnts 把缓存里面累加的浏览数全部写入数据库，每批 batchSize 个资源，返回写入的资源数
+	FlushViewCnts(ctx context.Context, batchSize int) (int, error)
+	// IncrCommentCnt 增加评论数，delta 为负数的时候就是减少
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error
 	// LikeToggle 如果点赞过，就取消点赞，如果没点赞过，就点赞
 	LikeToggle(c context.Context, biz string, id int64, uid int64) error
 	// CollectToggle 如果收藏过，就取消收藏，如果没收藏过，就收藏
@@ -33,8 +42,10 @@ type Service interface {
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, uid int64, ids []int64) (map[int64]domain.Interactive, error)
 
-	// SaveCollection 修改收藏夹
+	// SaveCollection 修改收藏夹，改成私有的时候会作废分享凭证
 	SaveCollection(ctx context.Context, collection domain.Collection) (int64, error)
+	// RotateShareToken 重新生成分享凭证，之前分享出去的链接都会失效，返回新的凭证
+	RotateShareToken(ctx context.Context, uid, id int64) (string, error)
 	// DeleteCollection 删除收藏夹
 	DeleteCollection(ctx context.Context, uid, id int64) error
 	// CollectionList 收藏夹列表
@@ -43,15 +54,41 @@ type Service interface {
 	CollectionInfo(ctx context.Context, uid, id int64, offset, limit int) ([]domain.CollectionRecord, error)
 	// MoveToCollection 将收藏内容转移到另一个收藏夹，前一个id是收藏记录的，collectionId收藏夹id
 	MoveToCollection(ctx context.Context, biz string, bizid, uid, collectionId int64) error
+	// GetCollection 查看收藏夹，id 为 0 的时候按照分享凭证查找。
+	// 不是自己的收藏夹，必须是公开的，或者持有分享凭证，否则返回 ErrCollectionNotFound
+	GetCollection(ctx context.Context, uid, id int64, shareToken string) (domain.Collection, error)
+	// FollowCollection 关注别人的收藏夹，访问规则和 GetCollection 一样
+	FollowCollection(ctx context.Context, uid, id int64, shareToken string) error
+	UnfollowCollection(ctx context.Context, uid, id int64) error
+	// FollowedCollections 关注的收藏夹，主人不再公开之后就看不到了
+	FollowedCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	// CopyCollection 把别人的收藏夹复制一份成为自己的，name 为空的时候沿用原来的名字，返回新收藏夹的 ID
+	CopyCollection(ctx context.Context, uid, id int64, shareToken, name string) (int64, error)
+	// MergeUser 合并账号的时候，把 sourceUid 的点赞、收藏夹、收藏和关注都转移到 targetUid 上
+	MergeUser(ctx context.Context, sourceUid, targetUid int64) error
+	// DeleteUser 注销账号的时候，删除点赞、收藏夹、收藏和关注，别人对这个用户的收藏夹的关注也一起删掉
+	DeleteUser(ctx context.Context, uid int64) error
+	// UserLikes 用户点赞过的资源，最近的在前面
+	UserLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.Like, error)
 }
 
+var (
+	ErrCollectionNotFound  = errors.New("收藏夹不存在或者没有权限访问")
+	ErrFollowOwnCollection = errors.New("不能关注自己的收藏夹")
+	ErrPrivateCollection   = errors.New("私有的收藏夹不能分享")
+)
+
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo        repository.InteractiveRepository
+	trendingSvc TrendingService
+	logger      *elog.Component
 }
 
-func NewService(repo repository.InteractiveRepository) Service {
+func NewService(repo repository.InteractiveRepository, trendingSvc TrendingService) Service {
 	return &interactiveService{
-		repo: repo,
+		repo:        repo,
+		trendingSvc: trendingSvc,
+		logger:      elog.DefaultLogger,
 	}
 }
 func (i *interactiveService) CollectionInfo(ctx context.Context, uid, id int64, offset, limit int) ([]domain.CollectionRecord, error) {
@@ -59,9 +96,117 @@ func (i *interactiveService) CollectionInfo(ctx context.Context, uid, id int64,
 }
 
 func (i *interactiveService) SaveCollection(ctx context.Context, collection domain.Collection) (int64, error) {
+	if collection.Id > 0 {
+		old, err := i.repo.GetCollection(ctx, collection.Id)
+		if errors.Is(err, repository.ErrRecordNotFound) || (err == nil && old.Uid != collection.Uid) {
+			return 0, ErrCollectionNotFound
+		}
+		if err != nil {
+			return 0, err
+		}
+		collection.ShareToken = old.ShareToken
+	}
+	// 改成私有的时候作废凭证，再次公开的时候重新生成，之前分享出去的链接不能再用
+	if collection.Visibility == domain.CollectionVisibilityPrivate {
+		collection.ShareToken = ""
+	} else if collection.ShareToken == "" {
+		collection.ShareToken = shortuuid.New()
+	}
 	return i.repo.SaveCollection(ctx, collection)
 }
 
+func (i *interactiveService) RotateShareToken(ctx context.Context, uid, id int64) (string, error) {
+	c, err := i.repo.GetCollection(ctx, id)
+	if errors.Is(err, repository.ErrRecordNotFound) || (err == nil && c.Uid != uid) {
+		return "", ErrCollectionNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if c.Visibility == domain.CollectionVisibilityPrivate {
+		return "", ErrPrivateCollection
+	}
+	c.ShareToken = shortuuid.New()
+	_, err = i.repo.SaveCollection(ctx, c)
+	return c.ShareToken, err
+}
+
+func (i *interactiveService) GetCollection(ctx context.Context, uid, id int64, shareToken string) (domain.Collection, error) {
+	var (
+		c   domain.Collection
+		err error
+	)
+	if id > 0 {
+		c, err = i.repo.GetCollection(ctx, id)
+	} else if shareToken != "" {
+		c, err = i.repo.GetCollectionByShareToken(ctx, shareToken)
+	} else {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	if !c.Accessible(uid, shareToken) {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	if c.Uid == uid {
+		return c, nil
+	}
+	// 分享凭证只有主人能看到，不然公开的收藏夹改成只对持有链接的人可见之后，别人依旧可以访问
+	c.ShareToken = ""
+	c.Followed, err = i.repo.Followed(ctx, uid, c.Id)
+	return c, err
+}
+
+func (i *interactiveService) FollowCollection(ctx context.Context, uid, id int64, shareToken string) error {
+	c, err := i.GetCollection(ctx, uid, id, shareToken)
+	if err != nil {
+		return err
+	}
+	if c.Uid == uid {
+		return ErrFollowOwnCollection
+	}
+	return i.repo.FollowCollection(ctx, uid, c.Id)
+}
+
+func (i *interactiveService) UnfollowCollection(ctx context.Context, uid, id int64) error {
+	return i.repo.UnfollowCollection(ctx, uid, id)
+}
+
+func (i *interactiveService) FollowedCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	collections, err := i.repo.FollowedCollections(ctx, uid, offset, limit)
+	for idx := range collections {
+		collections[idx].ShareToken = ""
+	}
+	return collections, err
+}
+
+func (i *interactiveService) CopyCollection(ctx context.Context, uid, id int64, shareToken, name string) (int64, error) {
+	c, err := i.GetCollection(ctx, uid, id, shareToken)
+	if err != nil {
+		return 0, err
+	}
+	if name == "" {
+		name = c.Name
+	}
+	return i.repo.CopyCollection(ctx, c.Id, domain.Collection{Uid: uid, Name: name})
+}
+
+func (i *interactiveService) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	return i.repo.MergeUser(ctx, sourceUid, targetUid)
+}
+
+func (i *interactiveService) DeleteUser(ctx context.Context, uid int64) error {
+	return i.repo.DeleteUser(ctx, uid)
+}
+
+func (i *interactiveService) UserLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.Like, error) {
+	return i.repo.UserLikes(ctx, uid, offset, limit)
+}
+
 func (i *interactiveService) DeleteCollection(ctx context.Context, uid, id int64) error {
 	return i.repo.DeleteCollection(ctx, uid, id)
 }
@@ -74,16 +219,91 @@ func (i *interactiveService) MoveToCollection(ctx context.Context, biz string, b
 	return i.repo.MoveCollection(ctx, biz, bizid, uid, collectionId)
 }
 
-func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
-	return i.repo.IncrViewCnt(ctx, biz, bizId)
+func (i *interactiveService) RecordViews(ctx context.Context, views []domain.View) error {
+	type bizKey struct {
+		biz   string
+		bizId int64
+	}
+	cnts := make(map[bizKey]int64, len(views))
+	engagements := make([]domain.Engagement, 0, len(views))
+	for _, view := range views {
+		// 没有登录的没办法去重
+		if view.Uid > 0 {
+			first, err := i.repo.MarkViewed(ctx, view)
+			// 去重失败的时候宁可多算一次，也不要丢掉
+			if err == nil && !first {
+				continue
+			}
+		}
+		cnts[bizKey{biz: view.Biz, bizId: view.BizId}]++
+		engagements = append(engagements, domain.Engagement{
+			Biz: view.Biz, BizId: view.BizId, Uid: view.Uid, Action: domain.ActionView, Cnt: 1,
+		})
+	}
+	i.recordTrending(ctx, engagements...)
+	var errs []error
+	for key, cnt := range cnts {
+		err := i.repo.IncrViewCnt(ctx, key.biz, key.bizId, cnt)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("累加浏览数失败 biz %s, bizId %d: %w", key.biz, key.bizId, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (i *interactiveService) FlushViewCnts(ctx context.Context, batchSize int) (int, error) {
+	var total int
+	for {
+		cnt, err := i.repo.FlushViewCnts(ctx, batchSize)
+		total += cnt
+		if err != nil || cnt < batchSize {
+			return total, err
+		}
+	}
+}
+
+func (i *interactiveService) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error {
+	err := i.repo.IncrCommentCnt(ctx, biz, bizId, delta)
+	if err == nil && delta > 0 {
+		i.recordTrending(ctx, domain.Engagement{Biz: biz, BizId: bizId, Action: domain.ActionComment, Cnt: int64(delta)})
+	}
+	return err
 }
 
 func (i *interactiveService) LikeToggle(c context.Context, biz string, id int64, uid int64) error {
-	return i.repo.LikeToggle(c, biz, id, uid)
+	err := i.repo.LikeToggle(c, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	// 取消点赞不影响热度
+	liked, err := i.repo.Liked(c, biz, id, uid)
+	if err == nil && liked {
+		i.recordTrending(c, domain.Engagement{Biz: biz, BizId: id, Uid: uid, Action: domain.ActionLike, Cnt: 1})
+	}
+	return nil
 }
 
 func (i *interactiveService) CollectToggle(ctx context.Context, biz string, bizId, uid int64) error {
-	return i.repo.CollectToggle(ctx, biz, bizId, uid)
+	err := i.repo.CollectToggle(ctx, biz, bizId, uid)
+	if err != nil {
+		return err
+	}
+	collected, err := i.repo.Collected(ctx, biz, bizId, uid)
+	if err == nil && collected {
+		i.recordTrending(ctx, domain.Engagement{Biz: biz, BizId: bizId, Uid: uid, Action: domain.ActionCollect, Cnt: 1})
+	}
+	return nil
+}
+
+// recordTrending 热度只是用来排行的，失败了不影响互动本身
+func (i *interactiveService) recordTrending(ctx context.Context, engagements ...domain.Engagement) {
+	if len(engagements) == 0 {
+		return
+	}
+	err := i.trendingSvc.Record(ctx, engagements)
+	if err != nil {
+		i.logger.Error("记录热度失败", elog.FieldErr(err))
+	}
 }
 
 func (i *interactiveService) Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error) {
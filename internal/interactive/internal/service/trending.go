@@ -0,0 +1,103 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+)
+
+// TrendingCapacity 每个排行榜保留多少个资源
+const TrendingCapacity = 100
+
+// TrendingBizs 参与排行的业务
+var TrendingBizs = []string{
+	repository.QuestionBiz,
+	repository.CaseBiz,
+	repository.QuestionSetBiz,
+	repository.ProjectBiz,
+}
+
+type TrendingService interface {
+	// Record 互动计入热度，同一个用户在去重窗口内对同一个资源的同一种互动只算一次
+	Record(ctx context.Context, engagements []domain.Engagement) error
+	// Rank 重新计算所有业务所有周期的排行榜
+	Rank(ctx context.Context) error
+	// TopN 排行榜的前 n 个，排行榜由 Rank 定期计算，所以会有延迟
+	TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error)
+}
+
+type trendingService struct {
+	repo repository.TrendingRepository
+}
+
+func NewTrendingService(repo repository.TrendingRepository) TrendingService {
+	return &trendingService{repo: repo}
+}
+
+func (t *trendingService) Record(ctx context.Context, engagements []domain.Engagement) error {
+	type bizKey struct {
+		biz   string
+		bizId int64
+	}
+	weights := make(map[bizKey]float64, len(engagements))
+	for _, e := range engagements {
+		weight := domain.ActionWeight(e.Action)
+		if weight <= 0 || e.Cnt <= 0 || !slice.Contains(TrendingBizs, e.Biz) {
+			continue
+		}
+		if e.Uid > 0 {
+			first, err := t.repo.MarkScored(ctx, e)
+			// 和浏览数一样，去重失败的时候宁可多算
+			if err == nil && !first {
+				continue
+			}
+		}
+		weights[bizKey{biz: e.Biz, bizId: e.BizId}] += weight * float64(e.Cnt)
+	}
+	now := time.Now()
+	var errs []error
+	for key, weight := range weights {
+		err := t.repo.IncrScore(ctx, key.biz, key.bizId, weight, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("累加热度失败 biz %s, bizId %d: %w", key.biz, key.bizId, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *trendingService) Rank(ctx context.Context) error {
+	now := time.Now()
+	var errs []error
+	for _, biz := range TrendingBizs {
+		for _, period := range domain.Periods {
+			err := t.repo.Rank(ctx, biz, period, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("计算排行榜失败 biz %s, period %s: %w", biz, period, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *trendingService) TopN(ctx context.Context, biz string, period domain.Period, n int) ([]domain.TrendingItem, error) {
+	return t.repo.TopN(ctx, biz, period, n)
+}
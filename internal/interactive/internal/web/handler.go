@@ -15,6 +15,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
@@ -26,12 +28,16 @@ import (
 var _ ginx.Handler = &Handler{}
 
 type Handler struct {
-	svc service.Service
+	svc         service.Service
+	trendingSvc service.TrendingService
+	historySvc  service.HistoryService
 }
 
-func NewHandler(svc service.Service) *Handler {
+func NewHandler(svc service.Service, trendingSvc service.TrendingService, historySvc service.HistoryService) *Handler {
 	return &Handler{
-		svc: svc,
+		svc:         svc,
+		trendingSvc: trendingSvc,
+		historySvc:  historySvc,
 	}
 }
 
@@ -45,12 +51,46 @@ func (h *Handler) PrivateRoutes(server *gin.Engine) {
 	g.POST("/collection/list", ginx.BS[Page](h.CollectionList))
 	g.POST("/collection/delete", ginx.BS[IdReq](h.CollectionDelete))
 	g.POST("/collection/move", ginx.BS[MoveCollectionReq](h.MoveCollection))
+	g.POST("/collection/detail", ginx.BS[CollectionReq](h.CollectionDetail))
+	g.POST("/collection/follow", ginx.BS[CollectionReq](h.FollowCollection))
+	g.POST("/collection/unfollow", ginx.BS[IdReq](h.UnfollowCollection))
+	g.POST("/collection/followed", ginx.BS[Page](h.FollowedCollections))
+	g.POST("/collection/copy", ginx.BS[CopyCollectionReq](h.CopyCollection))
+	g.POST("/collection/share/rotate", ginx.BS[IdReq](h.RotateShareToken))
 
 	g.POST("/like/toggle", ginx.BS[LikeReq](h.Like))
+	// 浏览历史的列表需要聚合标题等信息，在 bff 里面
+	g.POST("/history/clear", ginx.BS[ClearHistoryReq](h.ClearHistory))
 }
 
 func (h *Handler) PublicRoutes(server *gin.Engine) {
+	server.POST("/interactive/trending", ginx.B[TrendingReq](h.Trending))
+}
 
+// Trending 排行榜，只返回资源的 ID，详情由前端再去对应的业务查询
+func (h *Handler) Trending(ctx *ginx.Context, req TrendingReq) (ginx.Result, error) {
+	period := domain.Period(req.Period)
+	if period == "" {
+		period = domain.PeriodDaily
+	}
+	if !period.Valid() || !slice.Contains(service.TrendingBizs, req.Biz) {
+		return invalidTrendingResult, nil
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > service.TrendingCapacity {
+		limit = service.TrendingCapacity
+	}
+	items, err := h.trendingSvc.TopN(ctx.Request.Context(), req.Biz, period, limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: TrendingResp{
+			Items: slice.Map(items, func(idx int, src domain.TrendingItem) TrendingItem {
+				return TrendingItem{BizId: src.BizId, Score: src.Score}
+			}),
+		},
+	}, nil
 }
 
 func (h *Handler) Collect(ctx *ginx.Context, req CollectReq, sess session.Session) (ginx.Result, error) {
@@ -74,13 +114,18 @@ func (h *Handler) Like(ctx *ginx.Context, req LikeReq, sess session.Session) (gi
 func (h *Handler) CollectionSave(ctx *ginx.Context, req Collection, sess session.Session) (ginx.Result, error) {
 	// 把 ID 返回回来
 	uid := sess.Claims().Uid
+	visibility := domain.CollectionVisibility(req.Visibility)
+	if !visibility.Valid() {
+		return invalidCollectionResult, nil
+	}
 	id, err := h.svc.SaveCollection(ctx, domain.Collection{
-		Id:   req.Id,
-		Name: req.Name,
-		Uid:  uid,
+		Id:         req.Id,
+		Name:       req.Name,
+		Uid:        uid,
+		Visibility: visibility,
 	})
 	if err != nil {
-		return systemErrorResult, err
+		return h.collectionErrResult(err)
 	}
 	return ginx.Result{
 		Data: id,
@@ -96,14 +141,83 @@ func (h *Handler) CollectionList(ctx *ginx.Context, req Page, sess session.Sessi
 	}
 	return ginx.Result{
 		Data: slice.Map(collections, func(idx int, src domain.Collection) Collection {
-			return Collection{
-				Id:   src.Id,
-				Name: src.Name,
-			}
+			return newCollection(src)
+		}),
+	}, nil
+}
+
+// CollectionDetail 查看收藏夹本身，里面的内容通过 BFF 的 /interactive/collection/records 获取
+func (h *Handler) CollectionDetail(ctx *ginx.Context, req CollectionReq, sess session.Session) (ginx.Result, error) {
+	c, err := h.svc.GetCollection(ctx.Request.Context(), sess.Claims().Uid, req.Id, req.ShareToken)
+	if err != nil {
+		return h.collectionErrResult(err)
+	}
+	return ginx.Result{
+		Data: newCollection(c),
+	}, nil
+}
+
+func (h *Handler) FollowCollection(ctx *ginx.Context, req CollectionReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.FollowCollection(ctx.Request.Context(), sess.Claims().Uid, req.Id, req.ShareToken)
+	if err != nil {
+		return h.collectionErrResult(err)
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) UnfollowCollection(ctx *ginx.Context, req IdReq, sess session.Session) (ginx.Result, error) {
+	err := h.svc.UnfollowCollection(ctx.Request.Context(), sess.Claims().Uid, req.Id)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) FollowedCollections(ctx *ginx.Context, req Page, sess session.Session) (ginx.Result, error) {
+	collections, err := h.svc.FollowedCollections(ctx.Request.Context(), sess.Claims().Uid, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: slice.Map(collections, func(idx int, src domain.Collection) Collection {
+			return newCollection(src)
 		}),
 	}, nil
 }
 
+// CopyCollection 复制别人的收藏夹，已经收藏在自己其它收藏夹里面的内容不会被移动
+func (h *Handler) CopyCollection(ctx *ginx.Context, req CopyCollectionReq, sess session.Session) (ginx.Result, error) {
+	id, err := h.svc.CopyCollection(ctx.Request.Context(), sess.Claims().Uid, req.Id, req.ShareToken, req.Name)
+	if err != nil {
+		return h.collectionErrResult(err)
+	}
+	return ginx.Result{
+		Data: id,
+	}, nil
+}
+
+// RotateShareToken 重新生成分享凭证，用于链接泄露之后让旧的链接失效
+func (h *Handler) RotateShareToken(ctx *ginx.Context, req IdReq, sess session.Session) (ginx.Result, error) {
+	token, err := h.svc.RotateShareToken(ctx.Request.Context(), sess.Claims().Uid, req.Id)
+	if err != nil {
+		return h.collectionErrResult(err)
+	}
+	return ginx.Result{
+		Data: token,
+	}, nil
+}
+
+func (h *Handler) collectionErrResult(err error) (ginx.Result, error) {
+	switch {
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return collectionNotFoundResult, nil
+	case errors.Is(err, service.ErrFollowOwnCollection), errors.Is(err, service.ErrPrivateCollection):
+		return invalidCollectionResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
+
 func (h *Handler) CollectionDelete(ctx *ginx.Context, req IdReq, sess session.Session) (ginx.Result, error) {
 	// 删除这个 id 的 collection
 	// 要注意， Uid 必须是这个人。也就是说 A 用户不能删了 B 用户的收藏夹
@@ -125,3 +239,14 @@ func (h *Handler) MoveCollection(ctx *ginx.Context, req MoveCollectionReq, sess
 	}
 	return ginx.Result{}, nil
 }
+
+func (h *Handler) ClearHistory(ctx *ginx.Context, req ClearHistoryReq, sess session.Session) (ginx.Result, error) {
+	if req.Biz != "" && !slice.Contains(service.HistoryBizs, req.Biz) {
+		return invalidHistoryResult, nil
+	}
+	err := h.historySvc.Clear(ctx.Request.Context(), sess.Claims().Uid, req.Biz)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{}, nil
+}
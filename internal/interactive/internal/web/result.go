@@ -24,4 +24,20 @@ var (
 		Code: errs.SystemError.Code,
 		Msg:  errs.SystemError.Msg,
 	}
+	invalidTrendingResult = ginx.Result{
+		Code: errs.InvalidTrending.Code,
+		Msg:  errs.InvalidTrending.Msg,
+	}
+	collectionNotFoundResult = ginx.Result{
+		Code: errs.CollectionNotFound.Code,
+		Msg:  errs.CollectionNotFound.Msg,
+	}
+	invalidCollectionResult = ginx.Result{
+		Code: errs.InvalidCollection.Code,
+		Msg:  errs.InvalidCollection.Msg,
+	}
+	invalidHistoryResult = ginx.Result{
+		Code: errs.InvalidHistory.Code,
+		Msg:  errs.InvalidHistory.Msg,
+	}
 )
@@ -14,6 +14,8 @@
 
 package web
 
+import "github.com/ecodeclub/webook/internal/interactive/internal/domain"
+
 type CollectReq struct {
 	Biz   string `json:"biz"`
 	BizId int64  `json:"bizId"`
@@ -24,6 +26,39 @@ type Collection struct {
 	// 如果传递了这个参数，那么就是更新，如果没有则是插入
 	Id   int64  `json:"id"`
 	Name string `json:"name"`
+	// Visibility 0 私有，1 拿到分享链接的人可见，2 公开
+	Visibility uint8 `json:"visibility"`
+	// 下面的字段只在返回的时候有
+	// ShareToken 只有收藏夹的主人才能看到
+	ShareToken  string `json:"shareToken,omitempty"`
+	Uid         int64  `json:"uid,omitempty"`
+	FollowerCnt int64  `json:"followerCnt"`
+	Followed    bool   `json:"followed"`
+}
+
+func newCollection(c domain.Collection) Collection {
+	return Collection{
+		Id:          c.Id,
+		Name:        c.Name,
+		Visibility:  c.Visibility.ToUint8(),
+		ShareToken:  c.ShareToken,
+		Uid:         c.Uid,
+		FollowerCnt: c.FollowerCnt,
+		Followed:    c.Followed,
+	}
+}
+
+// CollectionReq Id 和 ShareToken 至少传一个，访问别人只对持有链接的人可见的收藏夹必须传 ShareToken
+type CollectionReq struct {
+	Id         int64  `json:"id"`
+	ShareToken string `json:"shareToken"`
+}
+
+type CopyCollectionReq struct {
+	Id         int64  `json:"id"`
+	ShareToken string `json:"shareToken"`
+	// Name 新收藏夹的名字，为空的时候沿用原来的名字
+	Name string `json:"name"`
 }
 
 type LikeReq struct {
@@ -83,3 +118,25 @@ type MoveCollectionReq struct {
 	BizId int64  `json:"bizId"`
 	Cid   int64  `json:"cid"`
 }
+
+type TrendingReq struct {
+	// Biz 目前支持 question, case, questionSet, project
+	Biz string `json:"biz"`
+	// Period 取值是 daily, weekly, all，默认是 daily
+	Period string `json:"period"`
+	Limit  int    `json:"limit"`
+}
+
+type TrendingItem struct {
+	BizId int64   `json:"bizId"`
+	Score float64 `json:"score"`
+}
+
+type TrendingResp struct {
+	Items []TrendingItem `json:"items"`
+}
+
+type ClearHistoryReq struct {
+	// Biz 为空的时候清空所有的浏览历史
+	Biz string `json:"biz"`
+}
@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./history.go -destination=../../mocks/history.mock.go -package=intrmocks -typed HistoryService
+//
+
+// Package intrmocks is a generated GoMock package.
+package intrmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryService is a mock of HistoryService interface.
+type MockHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryServiceMockRecorder
+}
+
+// MockHistoryServiceMockRecorder is the mock recorder for MockHistoryService.
+type MockHistoryServiceMockRecorder struct {
+	mock *MockHistoryService
+}
+
+// NewMockHistoryService creates a new mock instance.
+func NewMockHistoryService(ctrl *gomock.Controller) *MockHistoryService {
+	mock := &MockHistoryService{ctrl: ctrl}
+	mock.recorder = &MockHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService) EXPECT() *MockHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockHistoryService) Clear(ctx context.Context, uid int64, biz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid, biz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockHistoryServiceMockRecorder) Clear(ctx, uid, biz any) *MockHistoryServiceClearCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockHistoryService)(nil).Clear), ctx, uid, biz)
+	return &MockHistoryServiceClearCall{Call: call}
+}
+
+// MockHistoryServiceClearCall wrap *gomock.Call
+type MockHistoryServiceClearCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryServiceClearCall) Return(arg0 error) *MockHistoryServiceClearCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryServiceClearCall) Do(f func(context.Context, int64, string) error) *MockHistoryServiceClearCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryServiceClearCall) DoAndReturn(f func(context.Context, int64, string) error) *MockHistoryServiceClearCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LastViewed mocks base method.
+func (m *MockHistoryService) LastViewed(ctx context.Context, uid int64, biz string, bizIds []int64) (domain.ViewHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastViewed", ctx, uid, biz, bizIds)
+	ret0, _ := ret[0].(domain.ViewHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastViewed indicates an expected call of LastViewed.
+func (mr *MockHistoryServiceMockRecorder) LastViewed(ctx, uid, biz, bizIds any) *MockHistoryServiceLastViewedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastViewed", reflect.TypeOf((*MockHistoryService)(nil).LastViewed), ctx, uid, biz, bizIds)
+	return &MockHistoryServiceLastViewedCall{Call: call}
+}
+
+// MockHistoryServiceLastViewedCall wrap *gomock.Call
+type MockHistoryServiceLastViewedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryServiceLastViewedCall) Return(arg0 domain.ViewHistory, arg1 error) *MockHistoryServiceLastViewedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryServiceLastViewedCall) Do(f func(context.Context, int64, string, []int64) (domain.ViewHistory, error)) *MockHistoryServiceLastViewedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryServiceLastViewedCall) DoAndReturn(f func(context.Context, int64, string, []int64) (domain.ViewHistory, error)) *MockHistoryServiceLastViewedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockHistoryService) List(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.ViewHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, biz, offset, limit)
+	ret0, _ := ret[0].([]domain.ViewHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryServiceMockRecorder) List(ctx, uid, biz, offset, limit any) *MockHistoryServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryService)(nil).List), ctx, uid, biz, offset, limit)
+	return &MockHistoryServiceListCall{Call: call}
+}
+
+// MockHistoryServiceListCall wrap *gomock.Call
+type MockHistoryServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryServiceListCall) Return(arg0 []domain.ViewHistory, arg1 error) *MockHistoryServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryServiceListCall) Do(f func(context.Context, int64, string, int, int) ([]domain.ViewHistory, error)) *MockHistoryServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryServiceListCall) DoAndReturn(f func(context.Context, int64, string, int, int) ([]domain.ViewHistory, error)) *MockHistoryServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Merge mocks base method.
+func (m *MockHistoryService) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockHistoryServiceMockRecorder) Merge(ctx, sourceUid, targetUid any) *MockHistoryServiceMergeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockHistoryService)(nil).Merge), ctx, sourceUid, targetUid)
+	return &MockHistoryServiceMergeCall{Call: call}
+}
+
+// MockHistoryServiceMergeCall wrap *gomock.Call
+type MockHistoryServiceMergeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryServiceMergeCall) Return(arg0 error) *MockHistoryServiceMergeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryServiceMergeCall) Do(f func(context.Context, int64, int64) error) *MockHistoryServiceMergeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryServiceMergeCall) DoAndReturn(f func(context.Context, int64, int64) error) *MockHistoryServiceMergeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Record mocks base method.
+func (m *MockHistoryService) Record(ctx context.Context, views []domain.View) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockHistoryServiceMockRecorder) Record(ctx, views any) *MockHistoryServiceRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockHistoryService)(nil).Record), ctx, views)
+	return &MockHistoryServiceRecordCall{Call: call}
+}
+
+// MockHistoryServiceRecordCall wrap *gomock.Call
+type MockHistoryServiceRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHistoryServiceRecordCall) Return(arg0 error) *MockHistoryServiceRecordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHistoryServiceRecordCall) Do(f func(context.Context, []domain.View) error) *MockHistoryServiceRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHistoryServiceRecordCall) DoAndReturn(f func(context.Context, []domain.View) error) *MockHistoryServiceRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -156,6 +156,45 @@ func (c *MockServiceCollectionListCall) DoAndReturn(f func(context.Context, int6
 	return c
 }
 
+// CopyCollection mocks base method.
+func (m *MockService) CopyCollection(ctx context.Context, uid, id int64, shareToken, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyCollection", ctx, uid, id, shareToken, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyCollection indicates an expected call of CopyCollection.
+func (mr *MockServiceMockRecorder) CopyCollection(ctx, uid, id, shareToken, name any) *MockServiceCopyCollectionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyCollection", reflect.TypeOf((*MockService)(nil).CopyCollection), ctx, uid, id, shareToken, name)
+	return &MockServiceCopyCollectionCall{Call: call}
+}
+
+// MockServiceCopyCollectionCall wrap *gomock.Call
+type MockServiceCopyCollectionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCopyCollectionCall) Return(arg0 int64, arg1 error) *MockServiceCopyCollectionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCopyCollectionCall) Do(f func(context.Context, int64, int64, string, string) (int64, error)) *MockServiceCopyCollectionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCopyCollectionCall) DoAndReturn(f func(context.Context, int64, int64, string, string) (int64, error)) *MockServiceCopyCollectionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteCollection mocks base method.
 func (m *MockService) DeleteCollection(ctx context.Context, uid, id int64) error {
 	m.ctrl.T.Helper()
@@ -194,6 +233,160 @@ func (c *MockServiceDeleteCollectionCall) DoAndReturn(f func(context.Context, in
 	return c
 }
 
+// DeleteUser mocks base method.
+func (m *MockService) DeleteUser(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockServiceMockRecorder) DeleteUser(ctx, uid any) *MockServiceDeleteUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockService)(nil).DeleteUser), ctx, uid)
+	return &MockServiceDeleteUserCall{Call: call}
+}
+
+// MockServiceDeleteUserCall wrap *gomock.Call
+type MockServiceDeleteUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDeleteUserCall) Return(arg0 error) *MockServiceDeleteUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDeleteUserCall) Do(f func(context.Context, int64) error) *MockServiceDeleteUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDeleteUserCall) DoAndReturn(f func(context.Context, int64) error) *MockServiceDeleteUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FlushViewCnts mocks base method.
+func (m *MockService) FlushViewCnts(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushViewCnts", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushViewCnts indicates an expected call of FlushViewCnts.
+func (mr *MockServiceMockRecorder) FlushViewCnts(ctx, batchSize any) *MockServiceFlushViewCntsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushViewCnts", reflect.TypeOf((*MockService)(nil).FlushViewCnts), ctx, batchSize)
+	return &MockServiceFlushViewCntsCall{Call: call}
+}
+
+// MockServiceFlushViewCntsCall wrap *gomock.Call
+type MockServiceFlushViewCntsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceFlushViewCntsCall) Return(arg0 int, arg1 error) *MockServiceFlushViewCntsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceFlushViewCntsCall) Do(f func(context.Context, int) (int, error)) *MockServiceFlushViewCntsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceFlushViewCntsCall) DoAndReturn(f func(context.Context, int) (int, error)) *MockServiceFlushViewCntsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FollowCollection mocks base method.
+func (m *MockService) FollowCollection(ctx context.Context, uid, id int64, shareToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowCollection", ctx, uid, id, shareToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowCollection indicates an expected call of FollowCollection.
+func (mr *MockServiceMockRecorder) FollowCollection(ctx, uid, id, shareToken any) *MockServiceFollowCollectionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowCollection", reflect.TypeOf((*MockService)(nil).FollowCollection), ctx, uid, id, shareToken)
+	return &MockServiceFollowCollectionCall{Call: call}
+}
+
+// MockServiceFollowCollectionCall wrap *gomock.Call
+type MockServiceFollowCollectionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceFollowCollectionCall) Return(arg0 error) *MockServiceFollowCollectionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceFollowCollectionCall) Do(f func(context.Context, int64, int64, string) error) *MockServiceFollowCollectionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceFollowCollectionCall) DoAndReturn(f func(context.Context, int64, int64, string) error) *MockServiceFollowCollectionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FollowedCollections mocks base method.
+func (m *MockService) FollowedCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowedCollections", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowedCollections indicates an expected call of FollowedCollections.
+func (mr *MockServiceMockRecorder) FollowedCollections(ctx, uid, offset, limit any) *MockServiceFollowedCollectionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowedCollections", reflect.TypeOf((*MockService)(nil).FollowedCollections), ctx, uid, offset, limit)
+	return &MockServiceFollowedCollectionsCall{Call: call}
+}
+
+// MockServiceFollowedCollectionsCall wrap *gomock.Call
+type MockServiceFollowedCollectionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceFollowedCollectionsCall) Return(arg0 []domain.Collection, arg1 error) *MockServiceFollowedCollectionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceFollowedCollectionsCall) Do(f func(context.Context, int64, int, int) ([]domain.Collection, error)) *MockServiceFollowedCollectionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceFollowedCollectionsCall) DoAndReturn(f func(context.Context, int64, int, int) ([]domain.Collection, error)) *MockServiceFollowedCollectionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Get mocks base method.
 func (m *MockService) Get(ctx context.Context, biz string, id, uid int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -272,40 +465,79 @@ func (c *MockServiceGetByIdsCall) DoAndReturn(f func(context.Context, string, in
 	return c
 }
 
-// IncrReadCnt mocks base method.
-func (m *MockService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+// GetCollection mocks base method.
+func (m *MockService) GetCollection(ctx context.Context, uid, id int64, shareToken string) (domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", ctx, uid, id, shareToken)
+	ret0, _ := ret[0].(domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockServiceMockRecorder) GetCollection(ctx, uid, id, shareToken any) *MockServiceGetCollectionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockService)(nil).GetCollection), ctx, uid, id, shareToken)
+	return &MockServiceGetCollectionCall{Call: call}
+}
+
+// MockServiceGetCollectionCall wrap *gomock.Call
+type MockServiceGetCollectionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetCollectionCall) Return(arg0 domain.Collection, arg1 error) *MockServiceGetCollectionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetCollectionCall) Do(f func(context.Context, int64, int64, string) (domain.Collection, error)) *MockServiceGetCollectionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetCollectionCall) DoAndReturn(f func(context.Context, int64, int64, string) (domain.Collection, error)) *MockServiceGetCollectionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// IncrCommentCnt mocks base method.
+func (m *MockService) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret := m.ctrl.Call(m, "IncrCommentCnt", ctx, biz, bizId, delta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IncrReadCnt indicates an expected call of IncrReadCnt.
-func (mr *MockServiceMockRecorder) IncrReadCnt(ctx, biz, bizId any) *MockServiceIncrReadCntCall {
+// IncrCommentCnt indicates an expected call of IncrCommentCnt.
+func (mr *MockServiceMockRecorder) IncrCommentCnt(ctx, biz, bizId, delta any) *MockServiceIncrCommentCntCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockService)(nil).IncrReadCnt), ctx, biz, bizId)
-	return &MockServiceIncrReadCntCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCnt", reflect.TypeOf((*MockService)(nil).IncrCommentCnt), ctx, biz, bizId, delta)
+	return &MockServiceIncrCommentCntCall{Call: call}
 }
 
-// MockServiceIncrReadCntCall wrap *gomock.Call
-type MockServiceIncrReadCntCall struct {
+// MockServiceIncrCommentCntCall wrap *gomock.Call
+type MockServiceIncrCommentCntCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceIncrReadCntCall) Return(arg0 error) *MockServiceIncrReadCntCall {
+func (c *MockServiceIncrCommentCntCall) Return(arg0 error) *MockServiceIncrCommentCntCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceIncrReadCntCall) Do(f func(context.Context, string, int64) error) *MockServiceIncrReadCntCall {
+func (c *MockServiceIncrCommentCntCall) Do(f func(context.Context, string, int64, int) error) *MockServiceIncrCommentCntCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceIncrReadCntCall) DoAndReturn(f func(context.Context, string, int64) error) *MockServiceIncrReadCntCall {
+func (c *MockServiceIncrCommentCntCall) DoAndReturn(f func(context.Context, string, int64, int) error) *MockServiceIncrCommentCntCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -348,6 +580,44 @@ func (c_2 *MockServiceLikeToggleCall) DoAndReturn(f func(context.Context, string
 	return c_2
 }
 
+// MergeUser mocks base method.
+func (m *MockService) MergeUser(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUser", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockServiceMockRecorder) MergeUser(ctx, sourceUid, targetUid any) *MockServiceMergeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockService)(nil).MergeUser), ctx, sourceUid, targetUid)
+	return &MockServiceMergeUserCall{Call: call}
+}
+
+// MockServiceMergeUserCall wrap *gomock.Call
+type MockServiceMergeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceMergeUserCall) Return(arg0 error) *MockServiceMergeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceMergeUserCall) Do(f func(context.Context, int64, int64) error) *MockServiceMergeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceMergeUserCall) DoAndReturn(f func(context.Context, int64, int64) error) *MockServiceMergeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MoveToCollection mocks base method.
 func (m *MockService) MoveToCollection(ctx context.Context, biz string, bizid, uid, collectionId int64) error {
 	m.ctrl.T.Helper()
@@ -386,6 +656,83 @@ func (c *MockServiceMoveToCollectionCall) DoAndReturn(f func(context.Context, st
 	return c
 }
 
+// RecordViews mocks base method.
+func (m *MockService) RecordViews(ctx context.Context, views []domain.View) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordViews", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordViews indicates an expected call of RecordViews.
+func (mr *MockServiceMockRecorder) RecordViews(ctx, views any) *MockServiceRecordViewsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordViews", reflect.TypeOf((*MockService)(nil).RecordViews), ctx, views)
+	return &MockServiceRecordViewsCall{Call: call}
+}
+
+// MockServiceRecordViewsCall wrap *gomock.Call
+type MockServiceRecordViewsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRecordViewsCall) Return(arg0 error) *MockServiceRecordViewsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRecordViewsCall) Do(f func(context.Context, []domain.View) error) *MockServiceRecordViewsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRecordViewsCall) DoAndReturn(f func(context.Context, []domain.View) error) *MockServiceRecordViewsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RotateShareToken mocks base method.
+func (m *MockService) RotateShareToken(ctx context.Context, uid, id int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateShareToken", ctx, uid, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateShareToken indicates an expected call of RotateShareToken.
+func (mr *MockServiceMockRecorder) RotateShareToken(ctx, uid, id any) *MockServiceRotateShareTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateShareToken", reflect.TypeOf((*MockService)(nil).RotateShareToken), ctx, uid, id)
+	return &MockServiceRotateShareTokenCall{Call: call}
+}
+
+// MockServiceRotateShareTokenCall wrap *gomock.Call
+type MockServiceRotateShareTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRotateShareTokenCall) Return(arg0 string, arg1 error) *MockServiceRotateShareTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRotateShareTokenCall) Do(f func(context.Context, int64, int64) (string, error)) *MockServiceRotateShareTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRotateShareTokenCall) DoAndReturn(f func(context.Context, int64, int64) (string, error)) *MockServiceRotateShareTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SaveCollection mocks base method.
 func (m *MockService) SaveCollection(ctx context.Context, collection domain.Collection) (int64, error) {
 	m.ctrl.T.Helper()
@@ -424,3 +771,80 @@ func (c *MockServiceSaveCollectionCall) DoAndReturn(f func(context.Context, doma
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// UnfollowCollection mocks base method.
+func (m *MockService) UnfollowCollection(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowCollection", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowCollection indicates an expected call of UnfollowCollection.
+func (mr *MockServiceMockRecorder) UnfollowCollection(ctx, uid, id any) *MockServiceUnfollowCollectionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowCollection", reflect.TypeOf((*MockService)(nil).UnfollowCollection), ctx, uid, id)
+	return &MockServiceUnfollowCollectionCall{Call: call}
+}
+
+// MockServiceUnfollowCollectionCall wrap *gomock.Call
+type MockServiceUnfollowCollectionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceUnfollowCollectionCall) Return(arg0 error) *MockServiceUnfollowCollectionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceUnfollowCollectionCall) Do(f func(context.Context, int64, int64) error) *MockServiceUnfollowCollectionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceUnfollowCollectionCall) DoAndReturn(f func(context.Context, int64, int64) error) *MockServiceUnfollowCollectionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UserLikes mocks base method.
+func (m *MockService) UserLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserLikes", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLikes indicates an expected call of UserLikes.
+func (mr *MockServiceMockRecorder) UserLikes(ctx, uid, offset, limit any) *MockServiceUserLikesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLikes", reflect.TypeOf((*MockService)(nil).UserLikes), ctx, uid, offset, limit)
+	return &MockServiceUserLikesCall{Call: call}
+}
+
+// MockServiceUserLikesCall wrap *gomock.Call
+type MockServiceUserLikesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceUserLikesCall) Return(arg0 []domain.Like, arg1 error) *MockServiceUserLikesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceUserLikesCall) Do(f func(context.Context, int64, int, int) ([]domain.Like, error)) *MockServiceUserLikesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceUserLikesCall) DoAndReturn(f func(context.Context, int64, int, int) ([]domain.Like, error)) *MockServiceUserLikesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -14,10 +14,30 @@
 
 package interactive
 
-import "github.com/ecodeclub/webook/internal/interactive/internal/event"
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/interactive/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
 
 type Module struct {
-	Svc Service
-	c   *event.Consumer
-	Hdl *Handler
+	Svc             Service
+	HistorySvc      HistoryService
+	c               *event.Consumer
+	mc              *event.UserMergeConsumer
+	dc              *event.UserDeletionConsumer
+	Hdl             *Handler
+	FlushViewCntJob *FlushViewCntJob
+	RankTrendingJob *RankTrendingJob
+	ExportSource    exportx.Source
+}
+
+// Stop 进程退出之前调用，先处理完已经取到的事件，再把缓存里面累加的浏览数全部写入数据库
+func (m *Module) Stop(ctx context.Context) error {
+	err := m.c.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	return m.FlushViewCntJob.Run(ctx)
 }
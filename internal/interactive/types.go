@@ -16,14 +16,27 @@ package interactive
 
 import (
 	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
+	"github.com/ecodeclub/webook/internal/interactive/internal/job"
 	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/ecodeclub/webook/internal/interactive/internal/web"
 )
 
 type Handler = web.Handler
 
+type FlushViewCntJob = job.FlushViewCntJob
+
+type RankTrendingJob = job.RankTrendingJob
+
 type Service = service.Service
 
+type HistoryService = service.HistoryService
+
 type Interactive = domain.Interactive
 
 type CollectionRecord = domain.CollectionRecord
+
+type Collection = domain.Collection
+
+type ViewHistory = domain.ViewHistory
+
+var ErrCollectionNotFound = service.ErrCollectionNotFound
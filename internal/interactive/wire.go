@@ -19,29 +19,53 @@ package interactive
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/ecache/memory/lru"
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
 	"github.com/ecodeclub/webook/internal/interactive/internal/event"
+	"github.com/ecodeclub/webook/internal/interactive/internal/job"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/ecodeclub/webook/internal/interactive/internal/web"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 )
 
 var HandlerSet = wire.NewSet(
 	InitTablesOnce,
+	initViewCache,
 	repository.NewCachedInteractiveRepository,
+	initTrendingRepository,
+	service.NewTrendingService,
 	service.NewService,
+	dao.NewGORMViewHistoryDAO,
+	repository.NewHistoryRepository,
+	service.NewHistoryService,
 	web.NewHandler)
 
-func InitModule(db *egorm.Component, q mq.MQ) (*Module, error) {
+func InitModule(db *egorm.Component, q mq.MQ, ec ecache.Cache, cmd redis.Cmdable) (*Module, error) {
 	wire.Build(
 		InitTablesOnce,
+		initViewCache,
 		repository.NewCachedInteractiveRepository,
+		initTrendingRepository,
+		service.NewTrendingService,
 		service.NewService,
+		dao.NewGORMViewHistoryDAO,
+		repository.NewHistoryRepository,
+		service.NewHistoryService,
 		initConsumer,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewExportSource,
+		initFlushViewCntJob,
+		job.NewRankTrendingJob,
 		web.NewHandler,
 		wire.Struct(new(Module), "*"),
 	)
@@ -57,8 +81,43 @@ func InitTablesOnce(db *egorm.Component) dao.InteractiveDAO {
 	return dao.NewInteractiveDAO(db)
 }
 
-func initConsumer(svc service.Service, q mq.MQ) *event.Consumer {
-	consumer, err := event.NewSyncConsumer(svc, q)
+// initTrendingRepository 同一个用户一天之内对同一个资源的同一种互动只算一次热度
+func initTrendingRepository(cmd redis.Cmdable) repository.TrendingRepository {
+	c := cache.NewTrendingRedisCache(cmd, 24*time.Hour)
+	local := lru.NewCache(len(service.TrendingBizs) * len(domain.Periods))
+	return repository.NewTrendingRepository(c, local, service.TrendingCapacity)
+}
+
+// initViewCache 同一个用户半小时内重复浏览只算一次
+func initViewCache(cmd redis.Cmdable) cache.ViewCache {
+	return cache.NewViewRedisCache(cmd, 30*time.Minute)
+}
+
+func initFlushViewCntJob(svc service.Service) *FlushViewCntJob {
+	batchSize := 100
+	return job.NewFlushViewCntJob(svc, batchSize)
+}
+
+func initConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.Consumer {
+	consumer, err := event.NewSyncConsumer(svc, historySvc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
+func initUserMergeConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.UserMergeConsumer {
+	consumer, err := event.NewUserMergeConsumer(svc, historySvc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
+func initUserDeletionConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.UserDeletionConsumer {
+	consumer, err := event.NewUserDeletionConsumer(svc, historySvc, q)
 	if err != nil {
 		panic(err)
 	}
@@ -9,30 +9,54 @@ package interactive
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/ecache/memory/lru"
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/interactive/internal/domain"
 	"github.com/ecodeclub/webook/internal/interactive/internal/event"
+	"github.com/ecodeclub/webook/internal/interactive/internal/job"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository"
+	"github.com/ecodeclub/webook/internal/interactive/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/interactive/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/interactive/internal/service"
 	"github.com/ecodeclub/webook/internal/interactive/internal/web"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, q mq.MQ) (*Module, error) {
+func InitModule(db *gorm.DB, q mq.MQ, ec ecache.Cache, cmd redis.Cmdable) (*Module, error) {
 	interactiveDAO := InitTablesOnce(db)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO)
-	serviceService := service.NewService(interactiveRepository)
-	consumer := initConsumer(serviceService, q)
-	handler := web.NewHandler(serviceService)
+	viewCache := initViewCache(cmd)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, viewCache)
+	trendingRepository := initTrendingRepository(cmd)
+	trendingService := service.NewTrendingService(trendingRepository)
+	serviceService := service.NewService(interactiveRepository, trendingService)
+	viewHistoryDAO := dao.NewGORMViewHistoryDAO(db)
+	historyRepository := repository.NewHistoryRepository(viewHistoryDAO)
+	historyService := service.NewHistoryService(historyRepository)
+	consumer := initConsumer(serviceService, historyService, q)
+	userMergeConsumer := initUserMergeConsumer(serviceService, historyService, q)
+	userDeletionConsumer := initUserDeletionConsumer(serviceService, historyService, q)
+	source := service.NewExportSource(serviceService, historyService)
+	handler := web.NewHandler(serviceService, trendingService, historyService)
+	flushViewCntJob := initFlushViewCntJob(serviceService)
+	rankTrendingJob := job.NewRankTrendingJob(trendingService)
 	module := &Module{
-		Svc: serviceService,
-		c:   consumer,
-		Hdl: handler,
+		Svc:             serviceService,
+		HistorySvc:      historyService,
+		c:               consumer,
+		mc:              userMergeConsumer,
+		dc:              userDeletionConsumer,
+		ExportSource:    source,
+		Hdl:             handler,
+		FlushViewCntJob: flushViewCntJob,
+		RankTrendingJob: rankTrendingJob,
 	}
 	return module, nil
 }
@@ -40,7 +64,7 @@ func InitModule(db *gorm.DB, q mq.MQ) (*Module, error) {
 // wire.go:
 
 var HandlerSet = wire.NewSet(
-	InitTablesOnce, repository.NewCachedInteractiveRepository, service.NewService, web.NewHandler,
+	InitTablesOnce, initViewCache, repository.NewCachedInteractiveRepository, initTrendingRepository, service.NewTrendingService, service.NewService, dao.NewGORMViewHistoryDAO, repository.NewHistoryRepository, service.NewHistoryService, web.NewHandler,
 )
 
 var once = &sync.Once{}
@@ -52,8 +76,43 @@ func InitTablesOnce(db *egorm.Component) dao.InteractiveDAO {
 	return dao.NewInteractiveDAO(db)
 }
 
-func initConsumer(svc service.Service, q mq.MQ) *event.Consumer {
-	consumer, err := event.NewSyncConsumer(svc, q)
+// initTrendingRepository 同一个用户一天之内对同一个资源的同一种互动只算一次热度
+func initTrendingRepository(cmd redis.Cmdable) repository.TrendingRepository {
+	c := cache.NewTrendingRedisCache(cmd, 24*time.Hour)
+	local := lru.NewCache(len(service.TrendingBizs) * len(domain.Periods))
+	return repository.NewTrendingRepository(c, local, service.TrendingCapacity)
+}
+
+// initViewCache 同一个用户半小时内重复浏览只算一次
+func initViewCache(cmd redis.Cmdable) cache.ViewCache {
+	return cache.NewViewRedisCache(cmd, 30*time.Minute)
+}
+
+func initFlushViewCntJob(svc service.Service) *FlushViewCntJob {
+	batchSize := 100
+	return job.NewFlushViewCntJob(svc, batchSize)
+}
+
+func initConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.Consumer {
+	consumer, err := event.NewSyncConsumer(svc, historySvc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
+func initUserMergeConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.UserMergeConsumer {
+	consumer, err := event.NewUserMergeConsumer(svc, historySvc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
+func initUserDeletionConsumer(svc service.Service, historySvc service.HistoryService, q mq.MQ) *event.UserDeletionConsumer {
+	consumer, err := event.NewUserDeletionConsumer(svc, historySvc, q)
 	if err != nil {
 		panic(err)
 	}
@@ -14,7 +14,12 @@
 
 package event
 
-const memberUpdateEvents = "member_update_events"
+const (
+	memberUpdateEvents     = "member_update_events"
+	userMergeEvents        = "user_merge_events"
+	userDeletionEvents     = "user_deletion_events"
+	userDeletionDoneEvents = "user_deletion_done_events"
+)
 
 type MemberEvent struct {
 	Key    string `json:"key"`
@@ -24,3 +29,21 @@ type MemberEvent struct {
 	BizId  int64  `json:"biz_id"` // user_id=A order_id
 	Action string `json:"action"` // 首次注册   购买会员
 }
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
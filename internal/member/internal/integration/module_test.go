@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/member/internal/domain"
 	"github.com/ecodeclub/webook/internal/member/internal/event"
@@ -388,6 +389,96 @@ func (s *ModuleTestSuite) TestService_ActivateMembership_Concurrent() {
 
 }
 
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserMergeEvent() {
+	t := s.T()
+	producer, err := s.mq.Producer("user_merge_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserMergeConsumer(s.svc, s.mq)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, consumer.Stop(context.Background()))
+	})
+
+	sourceUid, targetUid := int64(20101), int64(20102)
+	activate := func(uid int64, days uint64) {
+		err := s.svc.ActivateMembership(context.Background(), domain.Member{
+			Uid: uid,
+			Records: []domain.MemberRecord{
+				{Key: fmt.Sprintf("member-key-%d", uid), Days: days, Biz: "user", BizId: uid, Desc: "新注册用户"},
+			},
+		})
+		require.NoError(t, err)
+	}
+	activate(sourceUid, 30)
+	activate(targetUid, 10)
+	before, err := s.svc.GetMembershipInfo(context.Background(), targetUid)
+	require.NoError(t, err)
+
+	marshal, err := json.Marshal(event.UserMergeEvent{Id: 12, SourceUid: sourceUid, TargetUid: targetUid})
+	require.NoError(t, err)
+	msg := &mq.Message{Value: marshal}
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	// 模拟重试
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	source, err := s.svc.GetMembershipInfo(context.Background(), sourceUid)
+	require.NoError(t, err)
+	require.True(t, source.EndAt <= time.Now().UnixMilli())
+	require.Empty(t, source.Records)
+
+	target, err := s.svc.GetMembershipInfo(context.Background(), targetUid)
+	require.NoError(t, err)
+	require.Len(t, target.Records, 3)
+	merged, ok := slice.Find(target.Records, func(src domain.MemberRecord) bool {
+		return src.Key == "user-merge-12"
+	})
+	require.True(t, ok)
+	// 不足一天的按照一天算
+	require.Contains(t, []uint64{30, 31}, merged.Days)
+	require.Equal(t, before.EndAt+int64(merged.Days)*int64(24*time.Hour/time.Millisecond), target.EndAt)
+}
+
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserDeletionEvent() {
+	t := s.T()
+	producer, err := s.mq.Producer("user_deletion_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserDeletionConsumer(s.svc, s.mq)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, consumer.Stop(context.Background()))
+	})
+
+	uid, otherUid := int64(20201), int64(20202)
+	for _, u := range []int64{uid, otherUid} {
+		err = s.svc.ActivateMembership(context.Background(), domain.Member{
+			Uid: u,
+			Records: []domain.MemberRecord{
+				{Key: fmt.Sprintf("member-key-%d", u), Days: 30, Biz: "user", BizId: u, Desc: "新注册用户"},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	marshal, err := json.Marshal(event.UserDeletionEvent{Uid: uid})
+	require.NoError(t, err)
+	_, err = producer.Produce(context.Background(), &mq.Message{Value: marshal})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	m, err := s.svc.GetMembershipInfo(context.Background(), uid)
+	require.NoError(t, err)
+	require.Zero(t, m.EndAt)
+	require.Empty(t, m.Records)
+
+	other, err := s.svc.GetMembershipInfo(context.Background(), otherUid)
+	require.NoError(t, err)
+	require.Len(t, other.Records, 1)
+}
+
 func (s *ModuleTestSuite) TestService_GetMembershipInfo() {
 	t := s.T()
 
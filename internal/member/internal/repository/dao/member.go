@@ -35,6 +35,9 @@ type MemberDAO interface {
 	FindMemberByUID(ctx context.Context, uid int64) (Member, error)
 	FindMemberRecordsByUID(ctx context.Context, uid int64) ([]MemberRecord, error)
 	Upsert(ctx context.Context, d Member, r MemberRecord) error
+	// Merge 源账号剩下的会员天数加到目标账号上，r 是目标账号新增的会员记录，天数由 Merge 计算
+	Merge(ctx context.Context, sourceUid, targetUid int64, r MemberRecord) error
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type memberGROMDAO struct {
@@ -136,6 +139,67 @@ func (g *memberGROMDAO) upsert(tx *egorm.Component, d Member, r MemberRecord) er
 	return nil
 }
 
+func (g *memberGROMDAO) Merge(ctx context.Context, sourceUid, targetUid int64, r MemberRecord) error {
+	for {
+		err := g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+			return g.merge(tx, sourceUid, targetUid, r)
+		})
+		if errors.Is(err, ErrCreateMemberConflict) || errors.Is(err, ErrUpdateMemberConflict) {
+			continue
+		}
+		return err
+	}
+}
+
+func (g *memberGROMDAO) merge(tx *egorm.Component, sourceUid, targetUid int64, r MemberRecord) error {
+	var source Member
+	err := tx.First(&source, "uid = ?", sourceUid).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().UnixMilli()
+	// 已经过期的，或者已经转移过的，就不需要再转移天数了
+	if source.EndAt > now {
+		const day = int64(24 * time.Hour / time.Millisecond)
+		// 不足一天的按照一天算
+		r.Days = uint64((source.EndAt - now + day - 1) / day)
+		res := tx.Model(&Member{}).
+			Where("uid = ? AND version = ?", sourceUid, source.Version).
+			Updates(map[string]any{
+				"end_at":  now,
+				"version": source.Version + 1,
+				"utime":   now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w", ErrUpdateMemberConflict)
+		}
+		err = g.upsert(tx, Member{Uid: targetUid}, r)
+		if err != nil {
+			return err
+		}
+	}
+	// 会员记录也一起转移，目标账号能看到完整的开通历史
+	return tx.Model(&MemberRecord{}).Where("uid = ?", sourceUid).
+		Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+}
+
+// DeleteByUid 删除会员主记录和全部会员记录，注销账号的时候使用
+func (g *memberGROMDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+		err := tx.Where("uid = ?", uid).Delete(&MemberRecord{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&Member{}).Error
+	})
+}
+
 func (g *memberGROMDAO) endAt(startAt time.Time, days uint64) int64 {
 	return startAt.Add(time.Hour * 24 * time.Duration(days)).UnixMilli()
 }
@@ -29,6 +29,8 @@ var (
 type MemberRepository interface {
 	FindByUID(ctx context.Context, uid int64) (domain.Member, error)
 	Upsert(ctx context.Context, member domain.Member) error
+	Merge(ctx context.Context, sourceUid, targetUid int64, record domain.MemberRecord) error
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 func NewMemberRepository(d dao.MemberDAO) MemberRepository {
@@ -74,6 +76,20 @@ func (m *memberRepository) Upsert(ctx context.Context, member domain.Member) err
 	return m.dao.Upsert(ctx, d, r)
 }
 
+func (m *memberRepository) Merge(ctx context.Context, sourceUid, targetUid int64, record domain.MemberRecord) error {
+	return m.dao.Merge(ctx, sourceUid, targetUid, dao.MemberRecord{
+		Key:   record.Key,
+		Uid:   targetUid,
+		Biz:   record.Biz,
+		BizId: record.BizId,
+		Desc:  record.Desc,
+	})
+}
+
+func (m *memberRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return m.dao.DeleteByUid(ctx, uid)
+}
+
 func (m *memberRepository) toEntity(d domain.Member) (dao.Member, dao.MemberRecord) {
 	member := dao.Member{
 		Uid:   d.Uid,
@@ -16,6 +16,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ecodeclub/webook/internal/member/internal/domain"
 	"github.com/ecodeclub/webook/internal/member/internal/repository"
@@ -29,6 +30,10 @@ var (
 type Service interface {
 	GetMembershipInfo(ctx context.Context, uid int64) (domain.Member, error)
 	ActivateMembership(ctx context.Context, member domain.Member) error
+	// MergeMembership 合并账号的时候把源账号剩下的会员天数加到目标账号上，mergeId 是合并记录的 ID
+	MergeMembership(ctx context.Context, mergeId, sourceUid, targetUid int64) error
+	// DeleteUserData 注销账号的时候删除会员信息和开通记录
+	DeleteUserData(ctx context.Context, uid int64) error
 }
 
 type service struct {
@@ -46,3 +51,16 @@ func (s *service) GetMembershipInfo(ctx context.Context, uid int64) (domain.Memb
 func (s *service) ActivateMembership(ctx context.Context, member domain.Member) error {
 	return s.repo.Upsert(ctx, member)
 }
+
+func (s *service) MergeMembership(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	return s.repo.Merge(ctx, sourceUid, targetUid, domain.MemberRecord{
+		Key:   fmt.Sprintf("user-merge-%d", mergeId),
+		Biz:   "user",
+		BizId: mergeId,
+		Desc:  "合并其它账号",
+	})
+}
+
+func (s *service) DeleteUserData(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
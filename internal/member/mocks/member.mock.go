@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./service.go -package=membermocks --destination=../../mocks/member.mock.go -typed Service
 //
+
 // Package membermocks is a generated GoMock package.
 package membermocks
 
@@ -77,6 +78,44 @@ func (c *ServiceActivateMembershipCall) DoAndReturn(f func(context.Context, doma
 	return c
 }
 
+// DeleteUserData mocks base method.
+func (m *MockService) DeleteUserData(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserData", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserData indicates an expected call of DeleteUserData.
+func (mr *MockServiceMockRecorder) DeleteUserData(ctx, uid any) *ServiceDeleteUserDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserData", reflect.TypeOf((*MockService)(nil).DeleteUserData), ctx, uid)
+	return &ServiceDeleteUserDataCall{Call: call}
+}
+
+// ServiceDeleteUserDataCall wrap *gomock.Call
+type ServiceDeleteUserDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceDeleteUserDataCall) Return(arg0 error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceDeleteUserDataCall) Do(f func(context.Context, int64) error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceDeleteUserDataCall) DoAndReturn(f func(context.Context, int64) error) *ServiceDeleteUserDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetMembershipInfo mocks base method.
 func (m *MockService) GetMembershipInfo(ctx context.Context, uid int64) (domain.Member, error) {
 	m.ctrl.T.Helper()
@@ -115,3 +154,41 @@ func (c *ServiceGetMembershipInfoCall) DoAndReturn(f func(context.Context, int64
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MergeMembership mocks base method.
+func (m *MockService) MergeMembership(ctx context.Context, mergeId, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeMembership", ctx, mergeId, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeMembership indicates an expected call of MergeMembership.
+func (mr *MockServiceMockRecorder) MergeMembership(ctx, mergeId, sourceUid, targetUid any) *ServiceMergeMembershipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeMembership", reflect.TypeOf((*MockService)(nil).MergeMembership), ctx, mergeId, sourceUid, targetUid)
+	return &ServiceMergeMembershipCall{Call: call}
+}
+
+// ServiceMergeMembershipCall wrap *gomock.Call
+type ServiceMergeMembershipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceMergeMembershipCall) Return(arg0 error) *ServiceMergeMembershipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceMergeMembershipCall) Do(f func(context.Context, int64, int64, int64) error) *ServiceMergeMembershipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceMergeMembershipCall) DoAndReturn(f func(context.Context, int64, int64, int64) error) *ServiceMergeMembershipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -14,9 +14,15 @@
 
 package member
 
-import "github.com/ecodeclub/webook/internal/member/internal/event"
+import (
+	"github.com/ecodeclub/webook/internal/member/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
 
 type Module struct {
-	Svc Service
-	cc  *event.MemberEventConsumer
+	Svc          Service
+	cc           *event.MemberEventConsumer
+	mc           *event.UserMergeConsumer
+	dc           *event.UserDeletionConsumer
+	ExportSource exportx.Source
 }
@@ -38,6 +38,9 @@ func InitModule(db *egorm.Component, q mq.MQ) (*Module, error) {
 		new(Module), "*"),
 		InitService,
 		initMemberConsumer,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewExportSource,
 	)
 	return new(Module), nil
 }
@@ -65,3 +68,21 @@ func initMemberConsumer(svc service.Service, q mq.MQ) *event.MemberEventConsumer
 	c.Start(context.Background())
 	return c
 }
+
+func initUserMergeConsumer(svc service.Service, q mq.MQ) *event.UserMergeConsumer {
+	c, err := event.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
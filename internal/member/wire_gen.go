@@ -16,6 +16,7 @@ import (
 	"github.com/ecodeclub/webook/internal/member/internal/repository"
 	"github.com/ecodeclub/webook/internal/member/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/member/internal/service"
+	service2 "github.com/ecodeclub/webook/internal/member/internal/service"
 	"github.com/ego-component/egorm"
 	"gorm.io/gorm"
 )
@@ -25,9 +26,15 @@ import (
 func InitModule(db *gorm.DB, q mq.MQ) (*Module, error) {
 	service := InitService(db, q)
 	memberEventConsumer := initMemberConsumer(service, q)
+	userMergeConsumer := initUserMergeConsumer(service, q)
+	userDeletionConsumer := initUserDeletionConsumer(service, q)
+	source := service2.NewExportSource(service)
 	module := &Module{
-		Svc: service,
-		cc:  memberEventConsumer,
+		Svc:          service,
+		cc:           memberEventConsumer,
+		mc:           userMergeConsumer,
+		dc:           userDeletionConsumer,
+		ExportSource: source,
 	}
 	return module, nil
 }
@@ -61,3 +68,21 @@ func initMemberConsumer(svc2 service.Service, q mq.MQ) *event.MemberEventConsume
 	c.Start(context.Background())
 	return c
 }
+
+func initUserMergeConsumer(svc2 service.Service, q mq.MQ) *event.UserMergeConsumer {
+	c, err := event.NewUserMergeConsumer(svc2, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc2 service.Service, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc2, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
@@ -17,6 +17,7 @@ package event
 const (
 	paymentEventName = "payment_events"
 	orderEventName   = "order_events"
+	userMergeEvents  = "user_merge_events"
 )
 
 type PaymentEvent struct {
@@ -36,3 +37,10 @@ type SPU struct {
 	Category0 string `json:"category0"`
 	Category1 string `json:"category1"`
 }
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
@@ -2330,6 +2330,43 @@ func (s *OrderModuleTestSuite) newPaymentEvent(t *testing.T, evt event.PaymentEv
 	return &mq.Message{Value: marshal}
 }
 
+func (s *OrderModuleTestSuite) TestUserMergeConsumer_Consume() {
+	t := s.T()
+	sourceUid, targetUid := int64(8101), int64(8102)
+	for i, buyer := range []int64{sourceUid, sourceUid, targetUid} {
+		_, err := s.dao.CreateOrder(context.Background(), dao.Order{
+			SN:      fmt.Sprintf("orderSN-UserMerge-%d", i),
+			BuyerId: buyer,
+			Status:  domain.StatusSuccess.ToUint8(),
+		}, []dao.OrderItem{
+			s.newOrderItemDAO(0, int64(i+1)),
+		})
+		require.NoError(t, err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	marshal, err := json.Marshal(event.UserMergeEvent{Id: 1, SourceUid: sourceUid, TargetUid: targetUid})
+	require.NoError(t, err)
+	mockConsumer := mocks.NewMockConsumer(ctrl)
+	mockConsumer.EXPECT().Consume(gomock.Any()).Return(&mq.Message{Value: marshal}, nil).Times(2)
+	mockMQ := mocks.NewMockMQ(ctrl)
+	mockMQ.EXPECT().Consumer(gomock.Any(), gomock.Any()).Return(mockConsumer, nil)
+	consumer, err := event.NewUserMergeConsumer(s.svc, mockMQ)
+	require.NoError(t, err)
+
+	// 重复消费也不会出问题
+	require.NoError(t, consumer.Consume(context.Background()))
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	cnt, err := s.dao.CountOrdersByUID(context.Background(), sourceUid, 0)
+	require.NoError(t, err)
+	assert.Zero(t, cnt)
+	cnt, err = s.dao.CountOrdersByUID(context.Background(), targetUid, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cnt)
+}
+
 func (s *OrderModuleTestSuite) TestJob_CloseTimeoutOrders() {
 	t := s.T()
 
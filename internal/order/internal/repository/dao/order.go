@@ -37,6 +37,7 @@ type OrderDAO interface {
 	FindTimeoutOrders(ctx context.Context, offset, limit int, ctime int64) ([]Order, error)
 	CountTimeoutOrders(ctx context.Context, ctime int64) (int64, error)
 	SetOrdersTimeoutClosed(ctx context.Context, orderIDs []int64, ctime int64) error
+	SetOrdersBuyer(ctx context.Context, sourceUid, targetUid int64) error
 }
 
 func NewOrderGORMDAO(db *egorm.Component) OrderDAO {
@@ -149,6 +150,15 @@ func (g *gormOrderDAO) SetOrdersTimeoutClosed(ctx context.Context, orderIDs []in
 		}).Error
 }
 
+func (g *gormOrderDAO) SetOrdersBuyer(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Model(&Order{}).
+		Where("buyer_id = ?", sourceUid).
+		Updates(map[string]any{
+			"buyer_id": targetUid,
+			"utime":    time.Now().UnixMilli(),
+		}).Error
+}
+
 type Order struct {
 	Id               int64          `gorm:"primaryKey;autoIncrement;comment:订单自增ID"`
 	SN               string         `gorm:"type:varchar(255);not null;uniqueIndex:uniq_order_sn;comment:订单序列号"`
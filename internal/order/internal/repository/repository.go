@@ -36,6 +36,7 @@ type OrderRepository interface {
 	FindTimeoutOrders(ctx context.Context, offset, limit int, ctime int64) ([]domain.Order, error)
 	TotalTimeoutOrders(ctx context.Context, ctime int64) (int64, error)
 	CloseTimeoutOrders(ctx context.Context, orderIDs []int64, ctime int64) error
+	MergeOrders(ctx context.Context, sourceUid, targetUid int64) error
 }
 
 func NewRepository(d dao.OrderDAO) OrderRepository {
@@ -208,6 +209,10 @@ func (o *orderRepository) TotalTimeoutOrders(ctx context.Context, ctime int64) (
 	return o.dao.CountTimeoutOrders(ctx, ctime)
 }
 
+func (o *orderRepository) MergeOrders(ctx context.Context, sourceUid, targetUid int64) error {
+	return o.dao.SetOrdersBuyer(ctx, sourceUid, targetUid)
+}
+
 func (o *orderRepository) CloseTimeoutOrders(ctx context.Context, orderIDs []int64, ctime int64) error {
 	return o.dao.SetOrdersTimeoutClosed(ctx, orderIDs, ctime)
 }
@@ -42,6 +42,8 @@ type Service interface {
 	FindTimeoutOrders(ctx context.Context, offset, limit int, ctime int64) ([]domain.Order, int64, error)
 	// CloseTimeoutOrders 关闭过期订单 job调用
 	CloseTimeoutOrders(ctx context.Context, orderIDs []int64, ctime int64) error
+	// MergeOrders 把 sourceUid 的订单都转移到 targetUid 上 event调用
+	MergeOrders(ctx context.Context, sourceUid, targetUid int64) error
 }
 
 func NewService(repo repository.OrderRepository) Service {
@@ -116,6 +118,10 @@ func (s *service) FindTimeoutOrders(ctx context.Context, offset, limit int, ctim
 	return os, total, eg.Wait()
 }
 
+func (s *service) MergeOrders(ctx context.Context, sourceUid, targetUid int64) error {
+	return s.repo.MergeOrders(ctx, sourceUid, targetUid)
+}
+
 func (s *service) CloseTimeoutOrders(ctx context.Context, orderIDs []int64, ctime int64) error {
 	return s.repo.CloseTimeoutOrders(ctx, orderIDs, ctime)
 }
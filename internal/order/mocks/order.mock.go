@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./service.go -package=ordermocks -destination=../../mocks/order.mock.go -typed Service
 //
+
 // Package ordermocks is a generated GoMock package.
 package ordermocks
 
@@ -311,6 +312,44 @@ func (c *ServiceFindUserVisibleOrdersByUIDCall) DoAndReturn(f func(context.Conte
 	return c
 }
 
+// MergeOrders mocks base method.
+func (m *MockService) MergeOrders(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeOrders", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeOrders indicates an expected call of MergeOrders.
+func (mr *MockServiceMockRecorder) MergeOrders(ctx, sourceUid, targetUid any) *ServiceMergeOrdersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeOrders", reflect.TypeOf((*MockService)(nil).MergeOrders), ctx, sourceUid, targetUid)
+	return &ServiceMergeOrdersCall{Call: call}
+}
+
+// ServiceMergeOrdersCall wrap *gomock.Call
+type ServiceMergeOrdersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceMergeOrdersCall) Return(arg0 error) *ServiceMergeOrdersCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceMergeOrdersCall) Do(f func(context.Context, int64, int64) error) *ServiceMergeOrdersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceMergeOrdersCall) DoAndReturn(f func(context.Context, int64, int64) error) *ServiceMergeOrdersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SucceedOrder mocks base method.
 func (m *MockService) SucceedOrder(ctx context.Context, uid int64, orderSN string) error {
 	m.ctrl.T.Helper()
@@ -16,11 +16,15 @@ package order
 
 import (
 	"github.com/ecodeclub/webook/internal/order/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
 )
 
 type Module struct {
 	Hdl                   *Handler
 	c                     *event.PaymentConsumer
+	mc                    *event.UserMergeConsumer
 	Svc                   Service
 	CloseTimeoutOrdersJob *CloseTimeoutOrdersJob
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
 }
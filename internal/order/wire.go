@@ -64,7 +64,9 @@ func InitModule(db *egorm.Component, cache ecache.Cache, q mq.MQ, pm *payment.Mo
 		InitHandler,
 		event.NewOrderEventProducer,
 		initCompleteOrderConsumer,
+		initUserMergeConsumer,
 		initCloseExpiredOrdersJob,
+		service.NewExportSource,
 	)
 	return new(Module), nil
 }
@@ -103,6 +105,15 @@ func initCompleteOrderConsumer(svc service.Service, p event.OrderEventProducer,
 	return consumer
 }
 
+func initUserMergeConsumer(svc service.Service, q mq.MQ) *event.UserMergeConsumer {
+	consumer, err := event.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
 func initCloseExpiredOrdersJob(svc service.Service) *CloseTimeoutOrdersJob {
 	minutes := int64(30)
 	seconds := int64(10)
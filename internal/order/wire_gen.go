@@ -19,6 +19,7 @@ import (
 	"github.com/ecodeclub/webook/internal/order/internal/repository"
 	"github.com/ecodeclub/webook/internal/order/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/order/internal/service"
+	service2 "github.com/ecodeclub/webook/internal/order/internal/service"
 	"github.com/ecodeclub/webook/internal/order/internal/web"
 	"github.com/ecodeclub/webook/internal/payment"
 	"github.com/ecodeclub/webook/internal/pkg/sequencenumber"
@@ -36,12 +37,16 @@ func InitModule(db *gorm.DB, cache ecache.Cache, q mq.MQ, pm *payment.Module, pp
 		return nil, err
 	}
 	paymentConsumer := initCompleteOrderConsumer(service, orderEventProducer, q)
+	userMergeConsumer := initUserMergeConsumer(service, q)
 	closeTimeoutOrdersJob := initCloseExpiredOrdersJob(service)
+	source := service2.NewExportSource(service)
 	module := &Module{
 		Hdl:                   handler,
 		c:                     paymentConsumer,
+		mc:                    userMergeConsumer,
 		Svc:                   service,
 		CloseTimeoutOrdersJob: closeTimeoutOrdersJob,
+		ExportSource:          source,
 	}
 	return module, nil
 }
@@ -100,6 +105,15 @@ func initCompleteOrderConsumer(svc2 service.Service, p event.OrderEventProducer,
 	return consumer
 }
 
+func initUserMergeConsumer(svc2 service.Service, q mq.MQ) *event.UserMergeConsumer {
+	consumer, err := event.NewUserMergeConsumer(svc2, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
 func initCloseExpiredOrdersJob(svc2 service.Service) *CloseTimeoutOrdersJob {
 	minutes := int64(30)
 	seconds := int64(10)
@@ -1910,6 +1910,42 @@ func (s *PaymentModuleTestSuite) TestService_FindTimeoutPayments() {
 
 }
 
+func (s *PaymentModuleTestSuite) TestService_FindPaymentsByPayerID() {
+	t := s.T()
+	svc := startup.InitService(nil, &credit.Module{}, nil)
+	const payerID, otherPayerID = 500001, 500002
+	for i, uid := range []int64{payerID, payerID, otherPayerID} {
+		orderID := int64(500000 + i)
+		_, err := svc.CreatePayment(context.Background(), domain.Payment{
+			OrderID:          orderID,
+			OrderSN:          fmt.Sprintf("order-payer-%d", orderID),
+			PayerID:          uid,
+			OrderDescription: "季会员 * 1",
+			TotalAmount:      30000,
+			Records: []domain.PaymentRecord{
+				{
+					Description: "季会员 * 1",
+					Channel:     domain.ChannelTypeCredit,
+					Amount:      30000,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	pmts, err := svc.FindPaymentsByPayerID(context.Background(), payerID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, pmts, 2)
+	for i, p := range pmts {
+		require.Equal(t, int64(payerID), p.PayerID)
+		require.Equal(t, fmt.Sprintf("order-payer-%d", 500000+i), p.OrderSN)
+		require.Len(t, p.Records, 1)
+	}
+	pmts, err = svc.FindPaymentsByPayerID(context.Background(), payerID, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, pmts, 1)
+}
+
 func (s *PaymentModuleTestSuite) TestService_CloseTimeoutPayment() {
 	t := s.T()
 
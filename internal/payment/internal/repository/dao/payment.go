@@ -33,6 +33,8 @@ type PaymentDAO interface {
 	FindPaymentByOrderSN(ctx context.Context, orderSN string) (Payment, []PaymentRecord, error)
 	FindTimeoutPayments(ctx context.Context, offset int, limit int, ctime int64) ([]Payment, error)
 	CountTimeoutPayments(ctx context.Context, ctime int64) (int64, error)
+	// FindPaymentsByPayerID 分页查找用户的支付记录，最早的在前面
+	FindPaymentsByPayerID(ctx context.Context, payerID int64, offset int, limit int) ([]Payment, error)
 }
 
 type PaymentGORMDAO struct {
@@ -131,6 +133,13 @@ func (g *PaymentGORMDAO) CountTimeoutPayments(ctx context.Context, ctime int64)
 	return res, err
 }
 
+func (g *PaymentGORMDAO) FindPaymentsByPayerID(ctx context.Context, payerID int64, offset int, limit int) ([]Payment, error) {
+	var res []Payment
+	err := g.db.WithContext(ctx).Where("payer_id = ?", payerID).
+		Order("id").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
 type Payment struct {
 	Id               int64          `gorm:"primaryKey;autoIncrement;comment:支付自增ID"`
 	SN               string         `gorm:"type:varchar(255);not null;uniqueIndex:uniq_payment_sn;comment:支付序列号"`
@@ -30,6 +30,7 @@ type PaymentRepository interface {
 	FindPaymentByOrderSN(ctx context.Context, orderSN string) (domain.Payment, error)
 	FindTimeoutPayments(ctx context.Context, offset int, limit int, ctime int64) ([]domain.Payment, error)
 	TotalTimeoutPayments(ctx context.Context, ctime int64) (int64, error)
+	FindPaymentsByPayerID(ctx context.Context, payerID int64, offset int, limit int) ([]domain.Payment, error)
 }
 
 func NewPaymentRepository(d dao.PaymentDAO) PaymentRepository {
@@ -137,3 +138,19 @@ func (p *paymentRepository) FindTimeoutPayments(ctx context.Context, offset int,
 func (p *paymentRepository) TotalTimeoutPayments(ctx context.Context, ctime int64) (int64, error) {
 	return p.dao.CountTimeoutPayments(ctx, ctime)
 }
+
+func (p *paymentRepository) FindPaymentsByPayerID(ctx context.Context, payerID int64, offset int, limit int) ([]domain.Payment, error) {
+	pmts, err := p.dao.FindPaymentsByPayerID(ctx, payerID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	pp := make([]domain.Payment, 0, len(pmts))
+	for _, pmt := range pmts {
+		pmtDomain, err := p.FindPaymentByID(ctx, pmt.Id)
+		if err != nil {
+			return nil, err
+		}
+		pp = append(pp, pmtDomain)
+	}
+	return pp, nil
+}
@@ -51,6 +51,9 @@ type Service interface {
 	HandleCreditCallback(ctx context.Context, pmt domain.Payment) error
 	// SetPaymentStatusPaidFailed 将支付标记为失败并发送相应事件 recon模块使用
 	SetPaymentStatusPaidFailed(ctx context.Context, pmt *domain.Payment) error
+
+	// FindPaymentsByPayerID 分页查找用户的支付记录，导出个人数据的时候使用
+	FindPaymentsByPayerID(ctx context.Context, uid int64, offset, limit int) ([]domain.Payment, error)
 }
 
 func NewService(wechatSvc *wechat.NativePaymentService,
@@ -428,3 +431,7 @@ func (s *service) SetPaymentStatusPaidFailed(ctx context.Context, pmt *domain.Pa
 	}
 	return s.repo.UpdatePayment(ctx, *pmt)
 }
+
+func (s *service) FindPaymentsByPayerID(ctx context.Context, uid int64, offset, limit int) ([]domain.Payment, error) {
+	return s.repo.FindPaymentsByPayerID(ctx, uid, offset, limit)
+}
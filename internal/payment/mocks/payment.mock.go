@@ -5,6 +5,7 @@
 //
 //	mockgen -source=service.go -package=paymentmocks -destination=../../mocks/payment.mock.go -typed Service
 //
+
 // Package paymentmocks is a generated GoMock package.
 package paymentmocks
 
@@ -156,6 +157,45 @@ func (c *ServiceFindPaymentByIDCall) DoAndReturn(f func(context.Context, int64)
 	return c
 }
 
+// FindPaymentsByPayerID mocks base method.
+func (m *MockService) FindPaymentsByPayerID(ctx context.Context, uid int64, offset, limit int) ([]domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaymentsByPayerID", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaymentsByPayerID indicates an expected call of FindPaymentsByPayerID.
+func (mr *MockServiceMockRecorder) FindPaymentsByPayerID(ctx, uid, offset, limit any) *ServiceFindPaymentsByPayerIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaymentsByPayerID", reflect.TypeOf((*MockService)(nil).FindPaymentsByPayerID), ctx, uid, offset, limit)
+	return &ServiceFindPaymentsByPayerIDCall{Call: call}
+}
+
+// ServiceFindPaymentsByPayerIDCall wrap *gomock.Call
+type ServiceFindPaymentsByPayerIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceFindPaymentsByPayerIDCall) Return(arg0 []domain.Payment, arg1 error) *ServiceFindPaymentsByPayerIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceFindPaymentsByPayerIDCall) Do(f func(context.Context, int64, int, int) ([]domain.Payment, error)) *ServiceFindPaymentsByPayerIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceFindPaymentsByPayerIDCall) DoAndReturn(f func(context.Context, int64, int, int) ([]domain.Payment, error)) *ServiceFindPaymentsByPayerIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // FindTimeoutPayments mocks base method.
 func (m *MockService) FindTimeoutPayments(ctx context.Context, offset, limit int, ctime int64) ([]domain.Payment, int64, error) {
 	m.ctrl.T.Helper()
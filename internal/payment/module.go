@@ -14,8 +14,12 @@
 
 package payment
 
+import "github.com/ecodeclub/webook/internal/pkg/exportx"
+
 type Module struct {
 	Hdl                *Handler
 	Svc                Service
 	SyncWechatOrderJob *SyncWechatOrderJob
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
 }
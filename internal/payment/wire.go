@@ -75,6 +75,7 @@ func InitModule(db *egorm.Component,
 		event.NewPaymentEventProducer,
 		web.NewHandler,
 		service.NewService,
+		service.NewExportSource,
 		repository.NewPaymentRepository,
 		sequencenumber.NewGenerator,
 		initSyncWechatOrderJob,
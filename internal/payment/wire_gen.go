@@ -48,10 +48,12 @@ func InitModule(db *gorm.DB, mq2 mq.MQ, c ecache.Cache, cm *credit.Module) (*Mod
 	service2 := service.NewService(nativePaymentService, serviceService, generator, paymentRepository, paymentEventProducer)
 	webHandler := web.NewHandler(notifyHandler, service2)
 	syncWechatOrderJob := initSyncWechatOrderJob(service2)
+	source := service.NewExportSource(service2)
 	module := &Module{
 		Hdl:                webHandler,
 		Svc:                service2,
 		SyncWechatOrderJob: syncWechatOrderJob,
+		ExportSource:       source,
 	}
 	return module, nil
 }
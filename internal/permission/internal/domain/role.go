@@ -0,0 +1,42 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Wildcard 用在 RolePermission 的 Module 或者 Action 上，表示任意模块或者任意操作
+const Wildcard = "*"
+
+// SuperAdminRoleID 内置的超级管理员角色，拥有所有权限，不能修改也不能删除
+const SuperAdminRoleID int64 = 1
+
+// Role 角色是一组权限的集合，用户通过角色拿到权限
+type Role struct {
+	Id          int64
+	Name        string
+	Desc        string
+	Permissions []RolePermission
+	Ctime       int64
+	Utime       int64
+}
+
+// RolePermission 对某个模块的某种操作的权限，例如 (feedback, manage)
+type RolePermission struct {
+	Module string
+	Action string
+}
+
+func (p RolePermission) Match(module, action string) bool {
+	return (p.Module == Wildcard || p.Module == module) &&
+		(p.Action == Wildcard || p.Action == action)
+}
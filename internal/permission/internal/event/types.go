@@ -19,7 +19,10 @@ import (
 )
 
 const (
-	PermissionEventName = "permission_events"
+	PermissionEventName    = "permission_events"
+	userMergeEvents        = "user_merge_events"
+	userDeletionEvents     = "user_deletion_events"
+	userDeletionDoneEvents = "user_deletion_done_events"
 )
 
 type PermissionEvent struct {
@@ -41,3 +44,21 @@ func (p PermissionEvent) toDomain() []domain.Permission {
 	}
 	return r
 }
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
@@ -43,9 +43,10 @@ func TestPermissionModule(t *testing.T) {
 
 type ModuleTestSuite struct {
 	suite.Suite
-	db   *egorm.Component
-	mq   mq.MQ
-	repo repository.PermissionRepository
+	db       *egorm.Component
+	mq       mq.MQ
+	repo     repository.PermissionRepository
+	roleRepo repository.RoleRepository
 }
 
 func (s *ModuleTestSuite) SetupSuite() {
@@ -53,17 +54,25 @@ func (s *ModuleTestSuite) SetupSuite() {
 	s.mq = testioc.InitMQ()
 	s.NoError(dao.InitTables(s.db))
 	s.repo = repository.NewPermissionRepository(dao.NewPermissionGORMDAO(s.db))
+	s.roleRepo = repository.NewRoleRepository(dao.NewRoleGORMDAO(s.db))
 
 }
 
 func (s *ModuleTestSuite) TearDownSuite() {
-	err := s.db.Exec("DROP TABLE `personal_permissions`").Error
+	err := s.db.Exec("DROP TABLE `personal_permissions`, `roles`, `role_permissions`, `user_roles`").Error
 	s.NoError(err)
 }
 
 func (s *ModuleTestSuite) TearDownTest() {
 	err := s.db.Exec("TRUNCATE TABLE `personal_permissions`").Error
 	s.NoError(err)
+	err = s.db.Exec("TRUNCATE TABLE `user_roles`").Error
+	s.NoError(err)
+	// 保留内置的超级管理员角色
+	err = s.db.Exec("DELETE FROM `role_permissions` WHERE `role_id` > ?", domain.SuperAdminRoleID).Error
+	s.NoError(err)
+	err = s.db.Exec("DELETE FROM `roles` WHERE `id` > ?", domain.SuperAdminRoleID).Error
+	s.NoError(err)
 }
 
 func (s *ModuleTestSuite) TestConsumer_ConsumePermissionEvent() {
@@ -435,3 +444,132 @@ func (s *ModuleTestSuite) TestService_FindPersonalPermissions() {
 	}
 
 }
+
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserMergeEvent() {
+	t := s.T()
+	sourceUid, targetUid := int64(79080201), int64(79080202)
+	err := s.repo.CreatePersonalPermission(context.Background(), []domain.Permission{
+		{Uid: sourceUid, Biz: "project", BizID: 1, Desc: "购买project"},
+		{Uid: sourceUid, Biz: "project", BizID: 2, Desc: "兑换project"},
+		{Uid: targetUid, Biz: "project", BizID: 2, Desc: "购买project"},
+	})
+	require.NoError(t, err)
+	rbacSvc := service.NewRBACService(s.roleRepo)
+	err = rbacSvc.AssignRoles(context.Background(), sourceUid, domain.SuperAdminRoleID)
+	require.NoError(t, err)
+
+	producer, err := s.mq.Producer("user_merge_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserMergeConsumer(service.NewPermissionService(s.repo), rbacSvc, s.mq)
+	require.NoError(t, err)
+	marshal, err := json.Marshal(event.UserMergeEvent{Id: 1, SourceUid: sourceUid, TargetUid: targetUid})
+	require.NoError(t, err)
+	msg := &mq.Message{Value: marshal}
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	// 模拟重试
+	_, err = producer.Produce(context.Background(), msg)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	ps, err := s.repo.FindPersonalPermissions(context.Background(), sourceUid)
+	require.NoError(t, err)
+	require.Empty(t, ps)
+	ps, err = s.repo.FindPersonalPermissions(context.Background(), targetUid)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []domain.Permission{
+		{Uid: targetUid, Biz: "project", BizID: 1, Desc: "购买project"},
+		{Uid: targetUid, Biz: "project", BizID: 2, Desc: "购买project"},
+	}, ps)
+	roles, err := rbacSvc.UserRoles(context.Background(), sourceUid)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+	ok, err := rbacSvc.Check(context.Background(), targetUid, "feedback", "manage")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, consumer.Stop(context.Background()))
+}
+
+func (s *ModuleTestSuite) TestConsumer_ConsumeUserDeletionEvent() {
+	t := s.T()
+	uid := int64(79080401)
+	err := s.repo.CreatePersonalPermission(context.Background(), []domain.Permission{
+		{Uid: uid, Biz: "project", BizID: 1, Desc: "购买project"},
+	})
+	require.NoError(t, err)
+	rbacSvc := service.NewRBACService(s.roleRepo)
+	err = rbacSvc.AssignRoles(context.Background(), uid, domain.SuperAdminRoleID)
+	require.NoError(t, err)
+
+	producer, err := s.mq.Producer("user_deletion_events")
+	require.NoError(t, err)
+	consumer, err := event.NewUserDeletionConsumer(service.NewPermissionService(s.repo), rbacSvc, s.mq)
+	require.NoError(t, err)
+	marshal, err := json.Marshal(event.UserDeletionEvent{Uid: uid})
+	require.NoError(t, err)
+	_, err = producer.Produce(context.Background(), &mq.Message{Value: marshal})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Consume(context.Background()))
+
+	ps, err := s.repo.FindPersonalPermissions(context.Background(), uid)
+	require.NoError(t, err)
+	require.Empty(t, ps)
+	roles, err := rbacSvc.UserRoles(context.Background(), uid)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+	require.NoError(t, consumer.Stop(context.Background()))
+}
+
+func (s *ModuleTestSuite) TestRBACService() {
+	t := s.T()
+	ctx := context.Background()
+	const uid = 79080301
+	svc := service.NewRBACService(s.roleRepo)
+
+	_, err := svc.SaveRole(ctx, domain.Role{Id: domain.SuperAdminRoleID, Name: "改名"})
+	require.ErrorIs(t, err, service.ErrBuiltinRole)
+	require.ErrorIs(t, svc.DeleteRole(ctx, domain.SuperAdminRoleID), service.ErrBuiltinRole)
+	_, err = svc.SaveRole(ctx, domain.Role{Name: "运营",
+		Permissions: []domain.RolePermission{{Module: "feedback"}}})
+	require.ErrorIs(t, err, service.ErrInvalidRole)
+
+	id, err := svc.SaveRole(ctx, domain.Role{Name: "运营", Desc: "处理反馈",
+		Permissions: []domain.RolePermission{{Module: "feedback", Action: "*"}}})
+	require.NoError(t, err)
+	require.NoError(t, svc.AssignRoles(ctx, uid, id))
+	// 重复分配会被忽略
+	require.NoError(t, svc.AssignRoles(ctx, uid, id))
+
+	check := func(module, action string, want bool) {
+		ok, err := svc.Check(ctx, uid, module, action)
+		require.NoError(t, err)
+		require.Equal(t, want, ok, "%s:%s", module, action)
+	}
+	check("feedback", "manage", true)
+	check("skill", "edit", false)
+
+	// 更新的时候整个替换掉权限列表
+	_, err = svc.SaveRole(ctx, domain.Role{Id: id, Name: "运营", Desc: "处理反馈和技能",
+		Permissions: []domain.RolePermission{{Module: "skill", Action: "edit"}}})
+	require.NoError(t, err)
+	check("feedback", "manage", false)
+	check("skill", "edit", true)
+
+	roles, err := svc.ListRoles(ctx)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	require.Equal(t, []domain.RolePermission{{Module: "*", Action: "*"}}, roles[0].Permissions)
+	require.Equal(t, "处理反馈和技能", roles[1].Desc)
+
+	require.NoError(t, svc.DeleteRole(ctx, id))
+	roles, err = svc.UserRoles(ctx, uid)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+	check("skill", "edit", false)
+
+	require.NoError(t, svc.AssignRoles(ctx, uid, domain.SuperAdminRoleID))
+	check("skill", "edit", true)
+	require.NoError(t, svc.RevokeRole(ctx, uid, domain.SuperAdminRoleID))
+	check("skill", "edit", false)
+}
@@ -14,8 +14,38 @@
 
 package dao
 
-import "github.com/ego-component/egorm"
+import (
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm/clause"
+)
 
 func InitTables(db *egorm.Component) error {
-	return db.AutoMigrate(&PersonalPermission{})
+	err := db.AutoMigrate(&PersonalPermission{}, &Role{}, &RolePermission{}, &UserRole{})
+	if err != nil {
+		return err
+	}
+	return initSuperAdminRole(db)
+}
+
+// initSuperAdminRole 内置的超级管理员角色，拥有所有模块的所有权限
+func initSuperAdminRole(db *egorm.Component) error {
+	now := time.Now().UnixMilli()
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Role{
+		Id:    1,
+		Name:  "超级管理员",
+		Desc:  "拥有所有权限",
+		Ctime: now,
+		Utime: now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&RolePermission{
+		RoleId: 1,
+		Module: "*",
+		Action: "*",
+		Ctime:  now,
+	}).Error
 }
@@ -25,6 +25,9 @@ type PermissionDAO interface {
 	CreatePersonalPermission(ctx context.Context, ps []PersonalPermission) error
 	CountPersonalPermission(ctx context.Context, p PersonalPermission) (int64, error)
 	FindPersonalPermissions(ctx context.Context, uid int64) ([]PersonalPermission, error)
+	// MergePersonalPermissions 把 sourceUid 的个人权限转移到 targetUid 上，两边都有的保留 targetUid 的
+	MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error
+	DeletePersonalPermissions(ctx context.Context, uid int64) error
 }
 
 type gormPermissionDAO struct {
@@ -62,6 +65,22 @@ func (g *gormPermissionDAO) FindPersonalPermissions(ctx context.Context, uid int
 	return res, err
 }
 
+func (g *gormPermissionDAO) MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+		// 目标账号已经有的权限会因为唯一索引冲突被忽略，留在源账号上的就是重复的
+		err := tx.Exec("UPDATE IGNORE `personal_permissions` SET `uid` = ?, `utime` = ? WHERE `uid` = ?",
+			targetUid, time.Now().UnixMilli(), sourceUid).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", sourceUid).Delete(&PersonalPermission{}).Error
+	})
+}
+
+func (g *gormPermissionDAO) DeletePersonalPermissions(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&PersonalPermission{}).Error
+}
+
 type PersonalPermission struct {
 	Id    int64  `gorm:"primaryKey;autoIncrement;comment:个人权限自增ID"`
 	Uid   int64  `gorm:"not null;uniqueIndex:uniq_uid_biz_biz_id;comment:用户ID"`
@@ -0,0 +1,172 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleDAO interface {
+	// SaveRole id 为 0 的时候新建，否则更新，权限列表会整个被替换掉
+	SaveRole(ctx context.Context, r Role, ps []RolePermission) (int64, error)
+	// DeleteRole 同时删除角色的权限和用户的角色
+	DeleteRole(ctx context.Context, id int64) error
+	FindRoles(ctx context.Context) ([]Role, error)
+	FindRolesByUid(ctx context.Context, uid int64) ([]Role, error)
+	FindRolePermissions(ctx context.Context, roleIDs []int64) ([]RolePermission, error)
+	// AddUserRoles 用户已经有的角色会被忽略
+	AddUserRoles(ctx context.Context, uid int64, roleIDs []int64) error
+	DeleteUserRole(ctx context.Context, uid, roleID int64) error
+	// MergeUserRoles 把 sourceUid 的角色转移到 targetUid 上
+	MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error
+	DeleteUserRoles(ctx context.Context, uid int64) error
+}
+
+type gormRoleDAO struct {
+	db *egorm.Component
+}
+
+func NewRoleGORMDAO(db *egorm.Component) RoleDAO {
+	return &gormRoleDAO{db: db}
+}
+
+func (g *gormRoleDAO) SaveRole(ctx context.Context, r Role, ps []RolePermission) (int64, error) {
+	now := time.Now().UnixMilli()
+	r.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+		var err error
+		if r.Id == 0 {
+			r.Ctime = now
+			err = tx.Create(&r).Error
+		} else {
+			err = tx.Model(&Role{}).Where("id = ?", r.Id).Updates(map[string]any{
+				"name":  r.Name,
+				"desc":  r.Desc,
+				"utime": now,
+			}).Error
+		}
+		if err != nil {
+			return err
+		}
+		err = tx.Where("role_id = ?", r.Id).Delete(&RolePermission{}).Error
+		if err != nil || len(ps) == 0 {
+			return err
+		}
+		for i := range ps {
+			ps[i].RoleId = r.Id
+			ps[i].Ctime = now
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ps).Error
+	})
+	return r.Id, err
+}
+
+func (g *gormRoleDAO) DeleteRole(ctx context.Context, id int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+		err := tx.Where("role_id = ?", id).Delete(&UserRole{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("role_id = ?", id).Delete(&RolePermission{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Role{}).Error
+	})
+}
+
+func (g *gormRoleDAO) FindRoles(ctx context.Context) ([]Role, error) {
+	var res []Role
+	err := g.db.WithContext(ctx).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *gormRoleDAO) FindRolesByUid(ctx context.Context, uid int64) ([]Role, error) {
+	var res []Role
+	err := g.db.WithContext(ctx).
+		Where("id IN (?)", g.db.Model(&UserRole{}).Select("role_id").Where("uid = ?", uid)).
+		Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *gormRoleDAO) FindRolePermissions(ctx context.Context, roleIDs []int64) ([]RolePermission, error) {
+	var res []RolePermission
+	if len(roleIDs) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Where("role_id IN ?", roleIDs).
+		Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *gormRoleDAO) AddUserRoles(ctx context.Context, uid int64, roleIDs []int64) error {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	urs := make([]UserRole, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		urs = append(urs, UserRole{Uid: uid, RoleId: id, Ctime: now})
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&urs).Error
+}
+
+func (g *gormRoleDAO) DeleteUserRole(ctx context.Context, uid, roleID int64) error {
+	return g.db.WithContext(ctx).Where("uid = ? AND role_id = ?", uid, roleID).
+		Delete(&UserRole{}).Error
+}
+
+func (g *gormRoleDAO) DeleteUserRoles(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&UserRole{}).Error
+}
+
+func (g *gormRoleDAO) MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *egorm.Component) error {
+		// 和个人权限一样，目标账号已经有的角色会因为唯一索引冲突被忽略
+		err := tx.Exec("UPDATE IGNORE `user_roles` SET `uid` = ? WHERE `uid` = ?",
+			targetUid, sourceUid).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", sourceUid).Delete(&UserRole{}).Error
+	})
+}
+
+type Role struct {
+	Id    int64  `gorm:"primaryKey;autoIncrement;comment:角色自增ID"`
+	Name  string `gorm:"type:varchar(64);not null;uniqueIndex;comment:角色名称"`
+	Desc  string `gorm:"type:varchar(256);not null;comment:角色描述"`
+	Ctime int64
+	Utime int64
+}
+
+type RolePermission struct {
+	Id     int64  `gorm:"primaryKey;autoIncrement;comment:角色权限自增ID"`
+	RoleId int64  `gorm:"not null;uniqueIndex:uniq_role_id_module_action;comment:角色ID"`
+	Module string `gorm:"type:varchar(64);not null;uniqueIndex:uniq_role_id_module_action;comment:模块名称, * 表示所有模块"`
+	Action string `gorm:"type:varchar(64);not null;uniqueIndex:uniq_role_id_module_action;comment:操作名称, * 表示所有操作"`
+	Ctime  int64
+}
+
+type UserRole struct {
+	Id     int64 `gorm:"primaryKey;autoIncrement;comment:用户角色自增ID"`
+	Uid    int64 `gorm:"not null;uniqueIndex:uniq_uid_role_id;comment:用户ID"`
+	RoleId int64 `gorm:"not null;uniqueIndex:uniq_uid_role_id;index;comment:角色ID"`
+	Ctime  int64
+}
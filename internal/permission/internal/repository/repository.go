@@ -26,6 +26,8 @@ type PermissionRepository interface {
 	CreatePersonalPermission(ctx context.Context, ps []domain.Permission) error
 	HasPersonalPermission(ctx context.Context, p domain.Permission) (bool, error)
 	FindPersonalPermissions(ctx context.Context, uid int64) ([]domain.Permission, error)
+	MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error
+	DeletePersonalPermissions(ctx context.Context, uid int64) error
 }
 
 type permissionRepository struct {
@@ -67,6 +69,14 @@ func (r *permissionRepository) FindPersonalPermissions(ctx context.Context, uid
 	}), err
 }
 
+func (r *permissionRepository) MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error {
+	return r.dao.MergePersonalPermissions(ctx, sourceUid, targetUid)
+}
+
+func (r *permissionRepository) DeletePersonalPermissions(ctx context.Context, uid int64) error {
+	return r.dao.DeletePersonalPermissions(ctx, uid)
+}
+
 func (r *permissionRepository) toDomain(p dao.PersonalPermission) domain.Permission {
 	return domain.Permission{
 		Uid:   p.Uid,
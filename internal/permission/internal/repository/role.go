@@ -0,0 +1,116 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ecodeclub/ekit/mapx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/permission/internal/domain"
+	"github.com/ecodeclub/webook/internal/permission/internal/repository/dao"
+)
+
+type RoleRepository interface {
+	SaveRole(ctx context.Context, r domain.Role) (int64, error)
+	DeleteRole(ctx context.Context, id int64) error
+	FindRoles(ctx context.Context) ([]domain.Role, error)
+	FindRolesByUid(ctx context.Context, uid int64) ([]domain.Role, error)
+	AddUserRoles(ctx context.Context, uid int64, roleIDs []int64) error
+	DeleteUserRole(ctx context.Context, uid, roleID int64) error
+	MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error
+	DeleteUserRoles(ctx context.Context, uid int64) error
+}
+
+type roleRepository struct {
+	dao dao.RoleDAO
+}
+
+func NewRoleRepository(dao dao.RoleDAO) RoleRepository {
+	return &roleRepository{dao: dao}
+}
+
+func (r *roleRepository) SaveRole(ctx context.Context, role domain.Role) (int64, error) {
+	ps := slice.Map(role.Permissions, func(idx int, src domain.RolePermission) dao.RolePermission {
+		return dao.RolePermission{Module: src.Module, Action: src.Action}
+	})
+	return r.dao.SaveRole(ctx, dao.Role{
+		Id:   role.Id,
+		Name: role.Name,
+		Desc: role.Desc,
+	}, ps)
+}
+
+func (r *roleRepository) DeleteRole(ctx context.Context, id int64) error {
+	return r.dao.DeleteRole(ctx, id)
+}
+
+func (r *roleRepository) FindRoles(ctx context.Context) ([]domain.Role, error) {
+	roles, err := r.dao.FindRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withPermissions(ctx, roles)
+}
+
+func (r *roleRepository) FindRolesByUid(ctx context.Context, uid int64) ([]domain.Role, error) {
+	roles, err := r.dao.FindRolesByUid(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return r.withPermissions(ctx, roles)
+}
+
+func (r *roleRepository) AddUserRoles(ctx context.Context, uid int64, roleIDs []int64) error {
+	return r.dao.AddUserRoles(ctx, uid, roleIDs)
+}
+
+func (r *roleRepository) DeleteUserRole(ctx context.Context, uid, roleID int64) error {
+	return r.dao.DeleteUserRole(ctx, uid, roleID)
+}
+
+func (r *roleRepository) MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error {
+	return r.dao.MergeUserRoles(ctx, sourceUid, targetUid)
+}
+
+func (r *roleRepository) DeleteUserRoles(ctx context.Context, uid int64) error {
+	return r.dao.DeleteUserRoles(ctx, uid)
+}
+
+// withPermissions 一次查出所有角色的权限
+func (r *roleRepository) withPermissions(ctx context.Context, roles []dao.Role) ([]domain.Role, error) {
+	ids := slice.Map(roles, func(idx int, src dao.Role) int64 {
+		return src.Id
+	})
+	ps, err := r.dao.FindRolePermissions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	psMap := mapx.NewMultiBuiltinMap[int64, domain.RolePermission](len(roles))
+	for _, p := range ps {
+		_ = psMap.Put(p.RoleId, domain.RolePermission{Module: p.Module, Action: p.Action})
+	}
+	return slice.Map(roles, func(idx int, src dao.Role) domain.Role {
+		rps, _ := psMap.Get(src.Id)
+		return domain.Role{
+			Id:          src.Id,
+			Name:        src.Name,
+			Desc:        src.Desc,
+			Permissions: rps,
+			Ctime:       src.Ctime,
+			Utime:       src.Utime,
+		}
+	}), nil
+}
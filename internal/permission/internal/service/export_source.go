@@ -0,0 +1,53 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/permission/internal/domain"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
+
+// exportSource 导出个人数据的时候读取个人权限和角色
+type exportSource struct {
+	svc     Service
+	rbacSvc RBACService
+}
+
+func NewExportSource(svc Service, rbacSvc RBACService) exportx.Source {
+	return &exportSource{svc: svc, rbacSvc: rbacSvc}
+}
+
+func (s *exportSource) Name() string {
+	return "permission"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	perms, err := s.svc.FindPersonalPermissions(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.rbacSvc.UserRoles(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return exportData{Permissions: perms, Roles: roles}, nil
+}
+
+type exportData struct {
+	Permissions map[string][]domain.Permission
+	Roles       []domain.Role
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/webook/internal/permission/internal/domain"
+	"github.com/ecodeclub/webook/internal/permission/internal/repository"
+)
+
+var (
+	ErrBuiltinRole = errors.New("内置角色不能修改")
+	ErrInvalidRole = errors.New("角色参数错误")
+)
+
+// RBACService 管理后台和创作中心的权限，和按照 biz 授予的个人权限是两回事
+//
+//go:generate mockgen -source=rbac.go -package=permissionmocks -destination=../../mocks/rbac.mock.go -typed RBACService
+type RBACService interface {
+	// SaveRole id 为 0 的时候新建，否则更新
+	SaveRole(ctx context.Context, r domain.Role) (int64, error)
+	DeleteRole(ctx context.Context, id int64) error
+	ListRoles(ctx context.Context) ([]domain.Role, error)
+	UserRoles(ctx context.Context, uid int64) ([]domain.Role, error)
+	AssignRoles(ctx context.Context, uid int64, roleIDs ...int64) error
+	RevokeRole(ctx context.Context, uid, roleID int64) error
+	// Check 用户是否可以对 module 执行 action
+	Check(ctx context.Context, uid int64, module, action string) (bool, error)
+	// MergeUserRoles 合并账号的时候，把 sourceUid 的角色转移到 targetUid 上
+	MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error
+	// DeleteUserRoles 注销账号的时候收回全部角色
+	DeleteUserRoles(ctx context.Context, uid int64) error
+}
+
+type rbacService struct {
+	repo repository.RoleRepository
+}
+
+func NewRBACService(repo repository.RoleRepository) RBACService {
+	return &rbacService{repo: repo}
+}
+
+func (s *rbacService) SaveRole(ctx context.Context, r domain.Role) (int64, error) {
+	if r.Id == domain.SuperAdminRoleID {
+		return 0, ErrBuiltinRole
+	}
+	if r.Name == "" {
+		return 0, ErrInvalidRole
+	}
+	for _, p := range r.Permissions {
+		if p.Module == "" || p.Action == "" {
+			return 0, ErrInvalidRole
+		}
+	}
+	return s.repo.SaveRole(ctx, r)
+}
+
+func (s *rbacService) DeleteRole(ctx context.Context, id int64) error {
+	if id == domain.SuperAdminRoleID {
+		return ErrBuiltinRole
+	}
+	return s.repo.DeleteRole(ctx, id)
+}
+
+func (s *rbacService) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	return s.repo.FindRoles(ctx)
+}
+
+func (s *rbacService) UserRoles(ctx context.Context, uid int64) ([]domain.Role, error) {
+	return s.repo.FindRolesByUid(ctx, uid)
+}
+
+func (s *rbacService) AssignRoles(ctx context.Context, uid int64, roleIDs ...int64) error {
+	return s.repo.AddUserRoles(ctx, uid, roleIDs)
+}
+
+func (s *rbacService) RevokeRole(ctx context.Context, uid, roleID int64) error {
+	return s.repo.DeleteUserRole(ctx, uid, roleID)
+}
+
+func (s *rbacService) Check(ctx context.Context, uid int64, module, action string) (bool, error) {
+	roles, err := s.repo.FindRolesByUid(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if p.Match(module, action) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *rbacService) MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error {
+	return s.repo.MergeUserRoles(ctx, sourceUid, targetUid)
+}
+
+func (s *rbacService) DeleteUserRoles(ctx context.Context, uid int64) error {
+	return s.repo.DeleteUserRoles(ctx, uid)
+}
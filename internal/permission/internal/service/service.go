@@ -26,6 +26,10 @@ type Service interface {
 	CreatePersonalPermission(ctx context.Context, ps []domain.Permission) error
 	HasPermission(ctx context.Context, p domain.Permission) (bool, error)
 	FindPersonalPermissions(ctx context.Context, uid int64) (map[string][]domain.Permission, error)
+	// MergePersonalPermissions 合并账号的时候，把 sourceUid 的个人权限转移到 targetUid 上
+	MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error
+	// DeletePersonalPermissions 注销账号的时候删除全部个人权限
+	DeletePersonalPermissions(ctx context.Context, uid int64) error
 }
 
 type permissionService struct {
@@ -55,3 +59,11 @@ func (s *permissionService) FindPersonalPermissions(ctx context.Context, uid int
 	}
 	return res, err
 }
+
+func (s *permissionService) MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error {
+	return s.repo.MergePersonalPermissions(ctx, sourceUid, targetUid)
+}
+
+func (s *permissionService) DeletePersonalPermissions(ctx context.Context, uid int64) error {
+	return s.repo.DeletePersonalPermissions(ctx, uid)
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/webook/internal/permission/internal/domain"
+	"github.com/ecodeclub/webook/internal/permission/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 管理角色，以及给用户分配角色
+type AdminHandler struct {
+	svc service.RBACService
+}
+
+func NewAdminHandler(svc service.RBACService) *AdminHandler {
+	return &AdminHandler{svc: svc}
+}
+
+// PrivateRoutes 角色和授权管理需要额外的权限，由调用方在 server 上加校验，
+// permission 模块本身不能依赖 RBAC 中间件
+func (h *AdminHandler) PrivateRoutes(server gin.IRouter) {
+	g := server.Group("/permission")
+	g.POST("/roles/save", ginx.B[Role](h.SaveRole))
+	g.POST("/roles/list", ginx.W(h.ListRoles))
+	g.POST("/roles/delete", ginx.B[IdReq](h.DeleteRole))
+	g.POST("/users/roles", ginx.B[UidReq](h.UserRoles))
+	g.POST("/users/roles/assign", ginx.B[AssignRolesReq](h.AssignRoles))
+	g.POST("/users/roles/revoke", ginx.B[RevokeRoleReq](h.RevokeRole))
+}
+
+func (h *AdminHandler) SaveRole(ctx *ginx.Context, req Role) (ginx.Result, error) {
+	id, err := h.svc.SaveRole(ctx, req.toDomain())
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{Data: id}, nil
+}
+
+func (h *AdminHandler) ListRoles(ctx *ginx.Context) (ginx.Result, error) {
+	roles, err := h.svc.ListRoles(ctx)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: h.toRoleList(roles)}, nil
+}
+
+func (h *AdminHandler) DeleteRole(ctx *ginx.Context, req IdReq) (ginx.Result, error) {
+	err := h.svc.DeleteRole(ctx, req.Id)
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) UserRoles(ctx *ginx.Context, req UidReq) (ginx.Result, error) {
+	roles, err := h.svc.UserRoles(ctx, req.Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: h.toRoleList(roles)}, nil
+}
+
+func (h *AdminHandler) AssignRoles(ctx *ginx.Context, req AssignRolesReq) (ginx.Result, error) {
+	err := h.svc.AssignRoles(ctx, req.Uid, req.RoleIds...)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) RevokeRole(ctx *ginx.Context, req RevokeRoleReq) (ginx.Result, error) {
+	err := h.svc.RevokeRole(ctx, req.Uid, req.RoleId)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) toRoleList(roles []domain.Role) RoleList {
+	return RoleList{
+		Roles: slice.Map(roles, func(idx int, src domain.Role) Role {
+			return newRole(src)
+		}),
+	}
+}
+
+func (h *AdminHandler) errResult(err error) (ginx.Result, error) {
+	switch {
+	case errors.Is(err, service.ErrBuiltinRole):
+		return builtinRoleResult, nil
+	case errors.Is(err, service.ErrInvalidRole):
+		return invalidRoleResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/permission/internal/domain"
+)
+
+type Role struct {
+	Id          int64            `json:"id,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Desc        string           `json:"desc,omitempty"`
+	Permissions []RolePermission `json:"permissions,omitempty"`
+	Ctime       int64            `json:"ctime,omitempty"`
+	Utime       int64            `json:"utime,omitempty"`
+}
+
+func newRole(r domain.Role) Role {
+	return Role{
+		Id:   r.Id,
+		Name: r.Name,
+		Desc: r.Desc,
+		Permissions: slice.Map(r.Permissions, func(idx int, src domain.RolePermission) RolePermission {
+			return RolePermission{Module: src.Module, Action: src.Action}
+		}),
+		Ctime: r.Ctime,
+		Utime: r.Utime,
+	}
+}
+
+func (r Role) toDomain() domain.Role {
+	return domain.Role{
+		Id:   r.Id,
+		Name: r.Name,
+		Desc: r.Desc,
+		Permissions: slice.Map(r.Permissions, func(idx int, src RolePermission) domain.RolePermission {
+			return domain.RolePermission{Module: src.Module, Action: src.Action}
+		}),
+	}
+}
+
+// RolePermission Module 和 Action 都可以用 * 表示任意
+type RolePermission struct {
+	Module string `json:"module"`
+	Action string `json:"action"`
+}
+
+type RoleList struct {
+	Roles []Role `json:"roles"`
+}
+
+type IdReq struct {
+	Id int64 `json:"id"`
+}
+
+type UidReq struct {
+	Uid int64 `json:"uid"`
+}
+
+type AssignRolesReq struct {
+	Uid     int64   `json:"uid"`
+	RoleIds []int64 `json:"roleIds"`
+}
+
+type RevokeRoleReq struct {
+	Uid    int64 `json:"uid"`
+	RoleId int64 `json:"roleId"`
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=service.go -package=permissionmocks -destination=../../mocks/permission.mock.go -typed Service
 //
+
 // Package permissionmocks is a generated GoMock package.
 package permissionmocks
 
@@ -77,6 +78,44 @@ func (c *ServiceCreatePersonalPermissionCall) DoAndReturn(f func(context.Context
 	return c
 }
 
+// DeletePersonalPermissions mocks base method.
+func (m *MockService) DeletePersonalPermissions(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersonalPermissions", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersonalPermissions indicates an expected call of DeletePersonalPermissions.
+func (mr *MockServiceMockRecorder) DeletePersonalPermissions(ctx, uid any) *ServiceDeletePersonalPermissionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersonalPermissions", reflect.TypeOf((*MockService)(nil).DeletePersonalPermissions), ctx, uid)
+	return &ServiceDeletePersonalPermissionsCall{Call: call}
+}
+
+// ServiceDeletePersonalPermissionsCall wrap *gomock.Call
+type ServiceDeletePersonalPermissionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceDeletePersonalPermissionsCall) Return(arg0 error) *ServiceDeletePersonalPermissionsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceDeletePersonalPermissionsCall) Do(f func(context.Context, int64) error) *ServiceDeletePersonalPermissionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceDeletePersonalPermissionsCall) DoAndReturn(f func(context.Context, int64) error) *ServiceDeletePersonalPermissionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // FindPersonalPermissions mocks base method.
 func (m *MockService) FindPersonalPermissions(ctx context.Context, uid int64) (map[string][]domain.Permission, error) {
 	m.ctrl.T.Helper()
@@ -154,3 +193,41 @@ func (c *ServiceHasPermissionCall) DoAndReturn(f func(context.Context, domain.Pe
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MergePersonalPermissions mocks base method.
+func (m *MockService) MergePersonalPermissions(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePersonalPermissions", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePersonalPermissions indicates an expected call of MergePersonalPermissions.
+func (mr *MockServiceMockRecorder) MergePersonalPermissions(ctx, sourceUid, targetUid any) *ServiceMergePersonalPermissionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePersonalPermissions", reflect.TypeOf((*MockService)(nil).MergePersonalPermissions), ctx, sourceUid, targetUid)
+	return &ServiceMergePersonalPermissionsCall{Call: call}
+}
+
+// ServiceMergePersonalPermissionsCall wrap *gomock.Call
+type ServiceMergePersonalPermissionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceMergePersonalPermissionsCall) Return(arg0 error) *ServiceMergePersonalPermissionsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceMergePersonalPermissionsCall) Do(f func(context.Context, int64, int64) error) *ServiceMergePersonalPermissionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceMergePersonalPermissionsCall) DoAndReturn(f func(context.Context, int64, int64) error) *ServiceMergePersonalPermissionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
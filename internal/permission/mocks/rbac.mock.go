@@ -0,0 +1,392 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rbac.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rbac.go -package=permissionmocks -destination=../../mocks/rbac.mock.go -typed RBACService
+//
+
+// Package permissionmocks is a generated GoMock package.
+package permissionmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/permission/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRBACService is a mock of RBACService interface.
+type MockRBACService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRBACServiceMockRecorder
+}
+
+// MockRBACServiceMockRecorder is the mock recorder for MockRBACService.
+type MockRBACServiceMockRecorder struct {
+	mock *MockRBACService
+}
+
+// NewMockRBACService creates a new mock instance.
+func NewMockRBACService(ctrl *gomock.Controller) *MockRBACService {
+	mock := &MockRBACService{ctrl: ctrl}
+	mock.recorder = &MockRBACServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRBACService) EXPECT() *MockRBACServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignRoles mocks base method.
+func (m *MockRBACService) AssignRoles(ctx context.Context, uid int64, roleIDs ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, uid}
+	for _, a := range roleIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AssignRoles", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRoles indicates an expected call of AssignRoles.
+func (mr *MockRBACServiceMockRecorder) AssignRoles(ctx, uid any, roleIDs ...any) *RBACServiceAssignRolesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, uid}, roleIDs...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoles", reflect.TypeOf((*MockRBACService)(nil).AssignRoles), varargs...)
+	return &RBACServiceAssignRolesCall{Call: call}
+}
+
+// RBACServiceAssignRolesCall wrap *gomock.Call
+type RBACServiceAssignRolesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceAssignRolesCall) Return(arg0 error) *RBACServiceAssignRolesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceAssignRolesCall) Do(f func(context.Context, int64, ...int64) error) *RBACServiceAssignRolesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceAssignRolesCall) DoAndReturn(f func(context.Context, int64, ...int64) error) *RBACServiceAssignRolesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Check mocks base method.
+func (m *MockRBACService) Check(ctx context.Context, uid int64, module, action string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, uid, module, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockRBACServiceMockRecorder) Check(ctx, uid, module, action any) *RBACServiceCheckCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockRBACService)(nil).Check), ctx, uid, module, action)
+	return &RBACServiceCheckCall{Call: call}
+}
+
+// RBACServiceCheckCall wrap *gomock.Call
+type RBACServiceCheckCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceCheckCall) Return(arg0 bool, arg1 error) *RBACServiceCheckCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceCheckCall) Do(f func(context.Context, int64, string, string) (bool, error)) *RBACServiceCheckCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceCheckCall) DoAndReturn(f func(context.Context, int64, string, string) (bool, error)) *RBACServiceCheckCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRole mocks base method.
+func (m *MockRBACService) DeleteRole(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRBACServiceMockRecorder) DeleteRole(ctx, id any) *RBACServiceDeleteRoleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRBACService)(nil).DeleteRole), ctx, id)
+	return &RBACServiceDeleteRoleCall{Call: call}
+}
+
+// RBACServiceDeleteRoleCall wrap *gomock.Call
+type RBACServiceDeleteRoleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceDeleteRoleCall) Return(arg0 error) *RBACServiceDeleteRoleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceDeleteRoleCall) Do(f func(context.Context, int64) error) *RBACServiceDeleteRoleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceDeleteRoleCall) DoAndReturn(f func(context.Context, int64) error) *RBACServiceDeleteRoleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteUserRoles mocks base method.
+func (m *MockRBACService) DeleteUserRoles(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRoles", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRoles indicates an expected call of DeleteUserRoles.
+func (mr *MockRBACServiceMockRecorder) DeleteUserRoles(ctx, uid any) *RBACServiceDeleteUserRolesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRoles", reflect.TypeOf((*MockRBACService)(nil).DeleteUserRoles), ctx, uid)
+	return &RBACServiceDeleteUserRolesCall{Call: call}
+}
+
+// RBACServiceDeleteUserRolesCall wrap *gomock.Call
+type RBACServiceDeleteUserRolesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceDeleteUserRolesCall) Return(arg0 error) *RBACServiceDeleteUserRolesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceDeleteUserRolesCall) Do(f func(context.Context, int64) error) *RBACServiceDeleteUserRolesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceDeleteUserRolesCall) DoAndReturn(f func(context.Context, int64) error) *RBACServiceDeleteUserRolesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRoles mocks base method.
+func (m *MockRBACService) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRBACServiceMockRecorder) ListRoles(ctx any) *RBACServiceListRolesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRBACService)(nil).ListRoles), ctx)
+	return &RBACServiceListRolesCall{Call: call}
+}
+
+// RBACServiceListRolesCall wrap *gomock.Call
+type RBACServiceListRolesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceListRolesCall) Return(arg0 []domain.Role, arg1 error) *RBACServiceListRolesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceListRolesCall) Do(f func(context.Context) ([]domain.Role, error)) *RBACServiceListRolesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceListRolesCall) DoAndReturn(f func(context.Context) ([]domain.Role, error)) *RBACServiceListRolesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MergeUserRoles mocks base method.
+func (m *MockRBACService) MergeUserRoles(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUserRoles", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeUserRoles indicates an expected call of MergeUserRoles.
+func (mr *MockRBACServiceMockRecorder) MergeUserRoles(ctx, sourceUid, targetUid any) *RBACServiceMergeUserRolesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUserRoles", reflect.TypeOf((*MockRBACService)(nil).MergeUserRoles), ctx, sourceUid, targetUid)
+	return &RBACServiceMergeUserRolesCall{Call: call}
+}
+
+// RBACServiceMergeUserRolesCall wrap *gomock.Call
+type RBACServiceMergeUserRolesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceMergeUserRolesCall) Return(arg0 error) *RBACServiceMergeUserRolesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceMergeUserRolesCall) Do(f func(context.Context, int64, int64) error) *RBACServiceMergeUserRolesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceMergeUserRolesCall) DoAndReturn(f func(context.Context, int64, int64) error) *RBACServiceMergeUserRolesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeRole mocks base method.
+func (m *MockRBACService) RevokeRole(ctx context.Context, uid, roleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, uid, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRBACServiceMockRecorder) RevokeRole(ctx, uid, roleID any) *RBACServiceRevokeRoleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRBACService)(nil).RevokeRole), ctx, uid, roleID)
+	return &RBACServiceRevokeRoleCall{Call: call}
+}
+
+// RBACServiceRevokeRoleCall wrap *gomock.Call
+type RBACServiceRevokeRoleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceRevokeRoleCall) Return(arg0 error) *RBACServiceRevokeRoleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceRevokeRoleCall) Do(f func(context.Context, int64, int64) error) *RBACServiceRevokeRoleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceRevokeRoleCall) DoAndReturn(f func(context.Context, int64, int64) error) *RBACServiceRevokeRoleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveRole mocks base method.
+func (m *MockRBACService) SaveRole(ctx context.Context, r domain.Role) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRole", ctx, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRole indicates an expected call of SaveRole.
+func (mr *MockRBACServiceMockRecorder) SaveRole(ctx, r any) *RBACServiceSaveRoleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRole", reflect.TypeOf((*MockRBACService)(nil).SaveRole), ctx, r)
+	return &RBACServiceSaveRoleCall{Call: call}
+}
+
+// RBACServiceSaveRoleCall wrap *gomock.Call
+type RBACServiceSaveRoleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceSaveRoleCall) Return(arg0 int64, arg1 error) *RBACServiceSaveRoleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceSaveRoleCall) Do(f func(context.Context, domain.Role) (int64, error)) *RBACServiceSaveRoleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceSaveRoleCall) DoAndReturn(f func(context.Context, domain.Role) (int64, error)) *RBACServiceSaveRoleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UserRoles mocks base method.
+func (m *MockRBACService) UserRoles(ctx context.Context, uid int64) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRoles", ctx, uid)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserRoles indicates an expected call of UserRoles.
+func (mr *MockRBACServiceMockRecorder) UserRoles(ctx, uid any) *RBACServiceUserRolesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRoles", reflect.TypeOf((*MockRBACService)(nil).UserRoles), ctx, uid)
+	return &RBACServiceUserRolesCall{Call: call}
+}
+
+// RBACServiceUserRolesCall wrap *gomock.Call
+type RBACServiceUserRolesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RBACServiceUserRolesCall) Return(arg0 []domain.Role, arg1 error) *RBACServiceUserRolesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RBACServiceUserRolesCall) Do(f func(context.Context, int64) ([]domain.Role, error)) *RBACServiceUserRolesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RBACServiceUserRolesCall) DoAndReturn(f func(context.Context, int64) ([]domain.Role, error)) *RBACServiceUserRolesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -14,9 +14,18 @@
 
 package permission
 
-import "github.com/ecodeclub/webook/internal/permission/internal/event"
+import (
+	"github.com/ecodeclub/webook/internal/permission/internal/event"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+)
 
 type Module struct {
 	Svc Service
-	c   *event.PermissionEventConsumer
+	// RBACSvc 管理后台和创作中心的角色权限
+	RBACSvc      RBACService
+	AdminHdl     *AdminHandler
+	c            *event.PermissionEventConsumer
+	mc           *event.UserMergeConsumer
+	dc           *event.UserDeletionConsumer
+	ExportSource exportx.Source
 }
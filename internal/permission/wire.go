@@ -26,22 +26,37 @@ import (
 	"github.com/ecodeclub/webook/internal/permission/internal/repository"
 	"github.com/ecodeclub/webook/internal/permission/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/permission/internal/service"
+	"github.com/ecodeclub/webook/internal/permission/internal/web"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
 
 type (
-	Service    = service.Service
-	Permission = domain.Permission
+	Service        = service.Service
+	Permission     = domain.Permission
+	RBACService    = service.RBACService
+	Role           = domain.Role
+	RolePermission = domain.RolePermission
+	AdminHandler   = web.AdminHandler
 )
 
+// SuperAdminRoleID 内置的超级管理员角色
+const SuperAdminRoleID = domain.SuperAdminRoleID
+
 func InitModule(db *egorm.Component, q mq.MQ) (*Module, error) {
 	wire.Build(
 		initDAO,
 		repository.NewPermissionRepository,
 		service.NewPermissionService,
+		initRoleDAO,
+		repository.NewRoleRepository,
+		service.NewRBACService,
+		web.NewAdminHandler,
 		initConsumer,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewExportSource,
 		wire.Struct(new(Module), "*"),
 	)
 	return nil, nil
@@ -56,15 +71,44 @@ func initConsumer(svc service.Service, mq mq.MQ) *event.PermissionEventConsumer
 	return res
 }
 
+func initUserMergeConsumer(svc service.Service, rbacSvc service.RBACService, q mq.MQ) *event.UserMergeConsumer {
+	res, err := event.NewUserMergeConsumer(svc, rbacSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	res.Start(context.Background())
+	return res
+}
+
+func initUserDeletionConsumer(svc service.Service, rbacSvc service.RBACService, q mq.MQ) *event.UserDeletionConsumer {
+	res, err := event.NewUserDeletionConsumer(svc, rbacSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	res.Start(context.Background())
+	return res
+}
+
 var (
 	once          = &sync.Once{}
 	permissionDAO dao.PermissionDAO
+	roleDAO       dao.RoleDAO
 )
 
 func initDAO(db *gorm.DB) dao.PermissionDAO {
+	initTablesOnce(db)
+	return permissionDAO
+}
+
+func initRoleDAO(db *gorm.DB) dao.RoleDAO {
+	initTablesOnce(db)
+	return roleDAO
+}
+
+func initTablesOnce(db *gorm.DB) {
 	once.Do(func() {
 		_ = dao.InitTables(db)
 		permissionDAO = dao.NewPermissionGORMDAO(db)
+		roleDAO = dao.NewRoleGORMDAO(db)
 	})
-	return permissionDAO
 }
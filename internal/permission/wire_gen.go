@@ -16,6 +16,7 @@ import (
 	"github.com/ecodeclub/webook/internal/permission/internal/repository"
 	"github.com/ecodeclub/webook/internal/permission/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/permission/internal/service"
+	"github.com/ecodeclub/webook/internal/permission/internal/web"
 	"gorm.io/gorm"
 )
 
@@ -25,10 +26,22 @@ func InitModule(db *gorm.DB, q mq.MQ) (*Module, error) {
 	daoPermissionDAO := initDAO(db)
 	permissionRepository := repository.NewPermissionRepository(daoPermissionDAO)
 	serviceService := service.NewPermissionService(permissionRepository)
+	daoRoleDAO := initRoleDAO(db)
+	roleRepository := repository.NewRoleRepository(daoRoleDAO)
+	rbacService := service.NewRBACService(roleRepository)
+	adminHandler := web.NewAdminHandler(rbacService)
 	permissionEventConsumer := initConsumer(serviceService, q)
+	userMergeConsumer := initUserMergeConsumer(serviceService, rbacService, q)
+	userDeletionConsumer := initUserDeletionConsumer(serviceService, rbacService, q)
+	source := service.NewExportSource(serviceService, rbacService)
 	module := &Module{
-		Svc: serviceService,
-		c:   permissionEventConsumer,
+		Svc:          serviceService,
+		RBACSvc:      rbacService,
+		AdminHdl:     adminHandler,
+		c:            permissionEventConsumer,
+		mc:           userMergeConsumer,
+		dc:           userDeletionConsumer,
+		ExportSource: source,
 	}
 	return module, nil
 }
@@ -36,10 +49,17 @@ func InitModule(db *gorm.DB, q mq.MQ) (*Module, error) {
 // wire.go:
 
 type (
-	Service    = service.Service
-	Permission = domain.Permission
+	Service        = service.Service
+	Permission     = domain.Permission
+	RBACService    = service.RBACService
+	Role           = domain.Role
+	RolePermission = domain.RolePermission
+	AdminHandler   = web.AdminHandler
 )
 
+// SuperAdminRoleID 内置的超级管理员角色
+const SuperAdminRoleID = domain.SuperAdminRoleID
+
 func initConsumer(svc service.Service, mq2 mq.MQ) *event.PermissionEventConsumer {
 	res, err := event.NewPermissionEventConsumer(svc, mq2)
 	if err != nil {
@@ -49,15 +69,44 @@ func initConsumer(svc service.Service, mq2 mq.MQ) *event.PermissionEventConsumer
 	return res
 }
 
+func initUserMergeConsumer(svc service.Service, rbacSvc service.RBACService, q mq.MQ) *event.UserMergeConsumer {
+	res, err := event.NewUserMergeConsumer(svc, rbacSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	res.Start(context.Background())
+	return res
+}
+
+func initUserDeletionConsumer(svc service.Service, rbacSvc service.RBACService, q mq.MQ) *event.UserDeletionConsumer {
+	res, err := event.NewUserDeletionConsumer(svc, rbacSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	res.Start(context.Background())
+	return res
+}
+
 var (
 	once          = &sync.Once{}
 	permissionDAO dao.PermissionDAO
+	roleDAO       dao.RoleDAO
 )
 
 func initDAO(db *gorm.DB) dao.PermissionDAO {
+	initTablesOnce(db)
+	return permissionDAO
+}
+
+func initRoleDAO(db *gorm.DB) dao.RoleDAO {
+	initTablesOnce(db)
+	return roleDAO
+}
+
+func initTablesOnce(db *gorm.DB) {
 	once.Do(func() {
 		_ = dao.InitTables(db)
 		permissionDAO = dao.NewPermissionGORMDAO(db)
+		roleDAO = dao.NewRoleGORMDAO(db)
 	})
-	return permissionDAO
 }
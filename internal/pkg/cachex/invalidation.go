@@ -0,0 +1,128 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+const invalidationTopic = "cache_invalidation_events"
+
+type InvalidationEvent struct {
+	Keys []string `json:"keys"`
+}
+
+var _ Cache = &InvalidationCache{}
+
+// InvalidationCache 在删除缓存之后广播失效消息，
+// 让其它节点也删除各自的本地缓存
+type InvalidationCache struct {
+	Cache
+	producer mqx.Producer[InvalidationEvent]
+	logger   *elog.Component
+}
+
+func NewInvalidationCache(c Cache, q mq.MQ) (*InvalidationCache, error) {
+	producer, err := mqx.NewGeneralProducer[InvalidationEvent](q, invalidationTopic)
+	if err != nil {
+		return nil, err
+	}
+	return &InvalidationCache{
+		Cache:    c,
+		producer: producer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (i *InvalidationCache) Delete(ctx context.Context, key ...string) (int64, error) {
+	cnt, err := i.Cache.Delete(ctx, key...)
+	if err != nil {
+		return cnt, err
+	}
+	err = i.producer.Produce(ctx, InvalidationEvent{Keys: key})
+	if err != nil {
+		// 其它节点的本地缓存会在过期之后自动失效
+		i.logger.Error("发送缓存失效消息失败", elog.FieldErr(err), elog.Any("keys", key))
+	}
+	return cnt, nil
+}
+
+// InvalidationConsumer 收到失效消息之后删除本地缓存
+// 每个节点都需要收到全部的消息，所以每个节点使用不同的消费者组
+// 消费者组的名字要在重启之后保持不变，不然 broker 上会留下越来越多没人使用的消费者组
+type InvalidationConsumer struct {
+	consumer mq.Consumer
+	local    Cache
+	logger   *elog.Component
+}
+
+// NewInvalidationConsumer instanceID 是节点的唯一标识，为空的时候使用主机名
+func NewInvalidationConsumer(local Cache, q mq.MQ, instanceID string) (*InvalidationConsumer, error) {
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("获取主机名失败: %w", err)
+		}
+		instanceID = hostname
+	}
+	groupID := fmt.Sprintf("cache_invalidation_%s", instanceID)
+	consumer, err := q.Consumer(invalidationTopic, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &InvalidationConsumer{
+		consumer: consumer,
+		local:    local,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *InvalidationConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt InvalidationEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	if len(evt.Keys) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = c.local.Delete(ctx, evt.Keys...)
+	return err
+}
+
+func (c *InvalidationConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("删除本地缓存失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"golang.org/x/sync/singleflight"
+)
+
+// ReadThroughCache 读穿透缓存，缓存未命中的时候通过 singleflight 加载数据
+// 数据以 JSON 的形式存储，所以本地缓存和 Redis 的行为是一致的
+type ReadThroughCache struct {
+	cache      Cache
+	namespace  string
+	expiration time.Duration
+	group      *singleflight.Group
+	logger     *elog.Component
+}
+
+func NewReadThroughCache(c Cache, expiration time.Duration) *ReadThroughCache {
+	return &ReadThroughCache{
+		cache:      c,
+		expiration: expiration,
+		group:      &singleflight.Group{},
+		logger:     elog.DefaultLogger,
+	}
+}
+
+// WithNamespace 返回一个共享底层缓存，但是 key 带有前缀的 ReadThroughCache
+func (r *ReadThroughCache) WithNamespace(namespace string) *ReadThroughCache {
+	res := *r
+	res.namespace = r.namespace + namespace
+	return &res
+}
+
+func (r *ReadThroughCache) Delete(ctx context.Context, keys ...string) error {
+	fullKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fullKeys = append(fullKeys, r.namespace+key)
+	}
+	_, err := r.cache.Delete(ctx, fullKeys...)
+	return err
+}
+
+// Version 返回 key 对应的版本号，不存在的时候生成一个新的版本号
+// 列表页之类没法逐个删除的缓存，可以把版本号拼接到 key 里面，删除版本号就相当于全部失效
+func (r *ReadThroughCache) Version(ctx context.Context, key string) string {
+	key = r.namespace + key
+	val := r.cache.Get(ctx, key)
+	if val.Err == nil {
+		if version, err := val.String(); err == nil {
+			return version
+		}
+	}
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	err := r.cache.Set(ctx, key, version, r.expiration)
+	if err != nil {
+		r.logger.Error("设置缓存版本号失败", elog.FieldErr(err), elog.String("key", key))
+	}
+	return version
+}
+
+// Get 因为 Go 的方法不支持泛型，所以这里是一个函数
+func Get[T any](ctx context.Context, r *ReadThroughCache, key string,
+	load func(ctx context.Context) (T, error)) (T, error) {
+	key = r.namespace + key
+	val := r.cache.Get(ctx, key)
+	if val.Err == nil {
+		var res T
+		str, err := val.String()
+		if err == nil && json.Unmarshal([]byte(str), &res) == nil {
+			return res, nil
+		}
+	}
+	res, err, _ := r.group.Do(key, func() (any, error) {
+		data, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r.set(ctx, key, data)
+		return data, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return res.(T), nil
+}
+
+func (r *ReadThroughCache) set(ctx context.Context, key string, data any) {
+	bs, err := json.Marshal(data)
+	if err == nil {
+		err = r.cache.Set(ctx, key, string(bs), r.expiration)
+	}
+	if err != nil {
+		// 缓存写失败不影响业务，下一次再从数据库加载
+		r.logger.Error("回写缓存失败", elog.FieldErr(err), elog.String("key", key))
+	}
+}
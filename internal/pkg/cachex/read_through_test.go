@@ -0,0 +1,150 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ecache/memory/lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testData struct {
+	Id    int64
+	Title string
+}
+
+func TestReadThroughCache_Get(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T, c Cache)
+		load     func(ctx context.Context) (testData, error)
+		wantRes  testData
+		wantErr  error
+		wantLoad bool
+	}{
+		{
+			name:   "缓存未命中，加载数据",
+			before: func(t *testing.T, c Cache) {},
+			load: func(ctx context.Context) (testData, error) {
+				return testData{Id: 1, Title: "从数据库加载"}, nil
+			},
+			wantRes:  testData{Id: 1, Title: "从数据库加载"},
+			wantLoad: true,
+		},
+		{
+			name: "缓存命中",
+			before: func(t *testing.T, c Cache) {
+				err := c.Set(context.Background(), "test:key", `{"Id":1,"Title":"缓存"}`, time.Minute)
+				require.NoError(t, err)
+			},
+			load: func(ctx context.Context) (testData, error) {
+				return testData{Id: 1, Title: "从数据库加载"}, nil
+			},
+			wantRes: testData{Id: 1, Title: "缓存"},
+		},
+		{
+			name: "缓存数据格式不对，重新加载",
+			before: func(t *testing.T, c Cache) {
+				err := c.Set(context.Background(), "test:key", `abc`, time.Minute)
+				require.NoError(t, err)
+			},
+			load: func(ctx context.Context) (testData, error) {
+				return testData{Id: 1, Title: "从数据库加载"}, nil
+			},
+			wantRes:  testData{Id: 1, Title: "从数据库加载"},
+			wantLoad: true,
+		},
+		{
+			name:   "加载数据失败",
+			before: func(t *testing.T, c Cache) {},
+			load: func(ctx context.Context) (testData, error) {
+				return testData{}, errors.New("mock db error")
+			},
+			wantErr:  errors.New("mock db error"),
+			wantLoad: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := lru.NewCache(10)
+			tc.before(t, c)
+			rc := NewReadThroughCache(c, time.Minute).WithNamespace("test:")
+			loaded := false
+			res, err := Get[testData](context.Background(), rc, "key", func(ctx context.Context) (testData, error) {
+				loaded = true
+				return tc.load(ctx)
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLoad, loaded)
+			if err != nil {
+				_, err = c.Get(context.Background(), "test:key").String()
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, tc.wantRes, res)
+			// 再查一次，一定命中缓存
+			res, err = Get[testData](context.Background(), rc, "key", func(ctx context.Context) (testData, error) {
+				return testData{}, errors.New("不应该再次加载")
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestReadThroughCache_Singleflight(t *testing.T) {
+	rc := NewReadThroughCache(lru.NewCache(10), time.Minute)
+	var cnt int64
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			res, err := Get[[]testData](context.Background(), rc, "list", func(ctx context.Context) ([]testData, error) {
+				atomic.AddInt64(&cnt, 1)
+				time.Sleep(time.Millisecond * 100)
+				return []testData{{Id: 1}, {Id: 2}}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []testData{{Id: 1}, {Id: 2}}, res)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	assert.Equal(t, int64(1), atomic.LoadInt64(&cnt))
+}
+
+func TestReadThroughCache_Version(t *testing.T) {
+	rc := NewReadThroughCache(lru.NewCache(10), time.Minute).WithNamespace("test:")
+	ctx := context.Background()
+	v1 := rc.Version(ctx, "version")
+	assert.NotEmpty(t, v1)
+	assert.Equal(t, v1, rc.Version(ctx, "version"))
+
+	err := rc.Delete(ctx, "version")
+	require.NoError(t, err)
+	// 保证时间戳不一样
+	time.Sleep(time.Millisecond)
+	assert.NotEqual(t, v1, rc.Version(ctx, "version"))
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ecache"
+)
+
+var _ Cache = &TwoTierCache{}
+
+// TwoTierCache 先查本地缓存，再查远端缓存
+// 本地缓存的过期时间一般设置得比较短，用来兜底没有收到失效消息的情况
+type TwoTierCache struct {
+	local           Cache
+	remote          Cache
+	localExpiration time.Duration
+}
+
+func NewTwoTierCache(local, remote Cache, localExpiration time.Duration) *TwoTierCache {
+	return &TwoTierCache{
+		local:           local,
+		remote:          remote,
+		localExpiration: localExpiration,
+	}
+}
+
+func (t *TwoTierCache) Get(ctx context.Context, key string) ecache.Value {
+	val := t.local.Get(ctx, key)
+	if val.Err == nil {
+		return val
+	}
+	val = t.remote.Get(ctx, key)
+	if val.Err == nil {
+		// 回写本地缓存失败也没关系，下一次再查远端
+		_ = t.local.Set(ctx, key, val.Val, t.localExpiration)
+	}
+	return val
+}
+
+func (t *TwoTierCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	err := t.remote.Set(ctx, key, val, expiration)
+	if err != nil {
+		return err
+	}
+	return t.local.Set(ctx, key, val, min(expiration, t.localExpiration))
+}
+
+func (t *TwoTierCache) Delete(ctx context.Context, key ...string) (int64, error) {
+	// 先删除远端，避免本地删除之后又从远端加载回旧数据
+	cnt, err := t.remote.Delete(ctx, key...)
+	if err != nil {
+		return cnt, err
+	}
+	return t.local.Delete(ctx, key...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ecache/memory/lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoTierCache(t *testing.T) {
+	ctx := context.Background()
+	local, remote := lru.NewCache(10), lru.NewCache(10)
+	c := NewTwoTierCache(local, remote, time.Minute)
+
+	// 本地没有的时候从远端加载，并且回写本地
+	err := remote.Set(ctx, "key1", "val1", time.Minute)
+	require.NoError(t, err)
+	val, err := c.Get(ctx, "key1").String()
+	require.NoError(t, err)
+	assert.Equal(t, "val1", val)
+	val, err = local.Get(ctx, "key1").String()
+	require.NoError(t, err)
+	assert.Equal(t, "val1", val)
+
+	// 写两层
+	err = c.Set(ctx, "key2", "val2", time.Minute)
+	require.NoError(t, err)
+	val, err = remote.Get(ctx, "key2").String()
+	require.NoError(t, err)
+	assert.Equal(t, "val2", val)
+	val, err = local.Get(ctx, "key2").String()
+	require.NoError(t, err)
+	assert.Equal(t, "val2", val)
+
+	// 删两层
+	_, err = c.Delete(ctx, "key1", "key2")
+	require.NoError(t, err)
+	for _, key := range []string{"key1", "key2"} {
+		assert.True(t, local.Get(ctx, key).KeyNotFound())
+		assert.True(t, remote.Get(ctx, key).KeyNotFound())
+		assert.True(t, c.Get(ctx, key).KeyNotFound())
+	}
+}
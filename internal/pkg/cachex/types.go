@@ -0,0 +1,61 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachex
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/ekit"
+)
+
+// Cache 读穿透缓存需要用到的方法，ecache.Cache 满足这个接口
+type Cache interface {
+	Get(ctx context.Context, key string) ecache.Value
+	Set(ctx context.Context, key string, val any, expiration time.Duration) error
+	Delete(ctx context.Context, key ...string) (int64, error)
+}
+
+const (
+	// TypeLocal 只使用本地缓存
+	TypeLocal = "local"
+	// TypeRedis 只使用 Redis
+	TypeRedis = "redis"
+	// TypeTwoTier 本地缓存 + Redis
+	TypeTwoTier = "twoTier"
+	// TypeNone 关闭缓存，每次都从数据库加载
+	TypeNone = "none"
+)
+
+var errKeyNotExist = errors.New("key 不存在")
+
+var _ Cache = NopCache{}
+
+// NopCache 什么也不缓存
+type NopCache struct{}
+
+func (NopCache) Get(_ context.Context, _ string) ecache.Value {
+	return ecache.Value{AnyValue: ekit.AnyValue{Err: errKeyNotExist}}
+}
+
+func (NopCache) Set(_ context.Context, _ string, _ any, _ time.Duration) error {
+	return nil
+}
+
+func (NopCache) Delete(_ context.Context, _ ...string) (int64, error) {
+	return 0, nil
+}
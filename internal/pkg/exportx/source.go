@@ -0,0 +1,26 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportx
+
+import "context"
+
+// Source 持有用户个人数据的模块，例如积分、订单等
+// 导出个人数据的时候，从每个 Source 里面读取这个用户的全部数据
+type Source interface {
+	// Name 模块名字，同时也是导出压缩包里面的文件名
+	Name() string
+	// Export 用户在这个模块里面的全部数据，会被序列化成 JSON，没有数据的时候返回 nil
+	Export(ctx context.Context, uid int64) (any, error)
+}
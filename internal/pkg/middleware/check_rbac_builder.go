@@ -0,0 +1,69 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// CheckRBACMiddlewareBuilder 校验用户的角色有没有对某个模块执行某种操作的权限，
+// 用在管理后台和创作中心这种只有部分人能够访问的地方
+type CheckRBACMiddlewareBuilder struct {
+	svc    permission.RBACService
+	logger *elog.Component
+	sp     session.Provider
+}
+
+func NewCheckRBACMiddlewareBuilder(svc permission.RBACService) *CheckRBACMiddlewareBuilder {
+	return &CheckRBACMiddlewareBuilder{
+		svc:    svc,
+		logger: elog.DefaultLogger,
+	}
+}
+
+// Build 每个路由声明自己需要的 (module, action)，例如 Build("feedback", "manage")
+func (c *CheckRBACMiddlewareBuilder) Build(module, action string) gin.HandlerFunc {
+	if c.sp == nil {
+		c.sp = session.DefaultProvider()
+	}
+	return func(ctx *gin.Context) {
+		gctx := &ginx.Context{Context: ctx}
+		sess, err := c.sp.Get(gctx)
+		if err != nil {
+			gctx.AbortWithStatus(http.StatusForbidden)
+			c.logger.Debug("用户未登录", elog.FieldErr(err))
+			return
+		}
+		uid := sess.Claims().Uid
+		ok, err := c.svc.Check(ctx.Request.Context(), uid, module, action)
+		if err != nil {
+			gctx.AbortWithStatus(http.StatusForbidden)
+			c.logger.Error("查询用户权限失败", elog.Int64("uid", uid), elog.FieldErr(err))
+			return
+		}
+		if !ok {
+			gctx.AbortWithStatus(http.StatusForbidden)
+			c.logger.Warn("用户无权限", elog.Int64("uid", uid),
+				elog.String("module", module), elog.String("action", action))
+			return
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
+	sessmocks "github.com/ecodeclub/webook/internal/test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckRBAC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (permission.RBACService, session.Provider)
+		wantCode int
+	}{
+		{
+			name: "未登录",
+			mock: func(ctrl *gomock.Controller) (permission.RBACService, session.Provider) {
+				provider := sessmocks.NewMockProvider(ctrl)
+				provider.EXPECT().Get(gomock.Any()).Return(nil, errors.New("mock no jwt"))
+				return nil, provider
+			},
+			wantCode: 403,
+		},
+		{
+			name: "有权限",
+			mock: func(ctrl *gomock.Controller) (permission.RBACService, session.Provider) {
+				svc := permissionmocks.NewMockRBACService(ctrl)
+				svc.EXPECT().Check(gomock.Any(), int64(2796), "feedback", "manage").Return(true, nil)
+				return svc, newRBACMockProvider(ctrl, 2796)
+			},
+			wantCode: 200,
+		},
+		{
+			name: "没有权限",
+			mock: func(ctrl *gomock.Controller) (permission.RBACService, session.Provider) {
+				svc := permissionmocks.NewMockRBACService(ctrl)
+				svc.EXPECT().Check(gomock.Any(), int64(2797), "feedback", "manage").Return(false, nil)
+				return svc, newRBACMockProvider(ctrl, 2797)
+			},
+			wantCode: 403,
+		},
+		{
+			name: "查询权限失败",
+			mock: func(ctrl *gomock.Controller) (permission.RBACService, session.Provider) {
+				svc := permissionmocks.NewMockRBACService(ctrl)
+				svc.EXPECT().Check(gomock.Any(), int64(2798), "feedback", "manage").
+					Return(false, errors.New("mock error"))
+				return svc, newRBACMockProvider(ctrl, 2798)
+			},
+			wantCode: 403,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc, p := tc.mock(ctrl)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/feedback/list", nil)
+			builder := NewCheckRBACMiddlewareBuilder(svc)
+			builder.sp = p
+			hdl := builder.Build("feedback", "manage")
+			hdl(c)
+			assert.Equal(t, tc.wantCode, c.Writer.Status())
+		})
+	}
+}
+
+func newRBACMockProvider(ctrl *gomock.Controller, uid int64) session.Provider {
+	mockSession := sessmocks.NewMockSession(ctrl)
+	mockSession.EXPECT().Claims().Return(session.Claims{Uid: uid}).AnyTimes()
+	provider := sessmocks.NewMockProvider(ctrl)
+	provider.EXPECT().Get(gomock.Any()).Return(mockSession, nil)
+	return provider
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulex
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+)
+
+// GORMDAO 定时发布和定时下线在制作库上的操作
+// T 是制作库的表，要求有 id、publish_at、unpublish_at 和 utime 这几个列
+// 各个模块的 DAO 组合它就可以了
+type GORMDAO[T any] struct {
+	db *egorm.Component
+}
+
+func NewGORMDAO[T any](db *egorm.Component) *GORMDAO[T] {
+	return &GORMDAO[T]{db: db}
+}
+
+func (dao *GORMDAO[T]) UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error {
+	return dao.db.WithContext(ctx).Model(new(T)).
+		Where("id = ?", id).Updates(map[string]any{
+		"publish_at":   publishAt,
+		"unpublish_at": unpublishAt,
+		"utime":        time.Now().UnixMilli(),
+	}).Error
+}
+
+// ClearPublishAt 只有在定时发布时间没有被修改过的情况下才会清空
+func (dao *GORMDAO[T]) ClearPublishAt(ctx context.Context, id int64, publishAt int64) error {
+	return dao.db.WithContext(ctx).Model(new(T)).
+		Where("id = ? AND publish_at = ?", id, publishAt).
+		Update("publish_at", 0).Error
+}
+
+func (dao *GORMDAO[T]) ScheduledList(ctx context.Context, offset int, limit int) ([]T, error) {
+	var res []T
+	err := dao.db.WithContext(ctx).
+		Where("publish_at > 0 OR unpublish_at > 0").
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMDAO[T]) ScheduledCount(ctx context.Context) (int64, error) {
+	var res int64
+	err := dao.db.WithContext(ctx).Model(new(T)).
+		Where("publish_at > 0 OR unpublish_at > 0").
+		Count(&res).Error
+	return res, err
+}
+
+// DuePublish 到期需要发布的数据，按照 id 升序，只返回 id 大于 minId 的
+func (dao *GORMDAO[T]) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]T, error) {
+	var res []T
+	err := dao.db.WithContext(ctx).
+		Where("publish_at > 0 AND publish_at <= ? AND id > ?", now, minId).
+		Order("id ASC").Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// DueUnpublish 到期需要下线的数据，按照 id 升序，只返回 id 大于 minId 的
+func (dao *GORMDAO[T]) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]T, error) {
+	var res []T
+	err := dao.db.WithContext(ctx).
+		Where("unpublish_at > 0 AND unpublish_at <= ? AND id > ?", now, minId).
+		Order("id ASC").Limit(limit).
+		Find(&res).Error
+	return res, err
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+// Service 定时任务需要的接口，T 是各个模块的领域对象
+type Service[T any] interface {
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]T, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]T, error)
+	// PublishScheduled 发布制作库中的数据，并且清空定时发布时间
+	PublishScheduled(ctx context.Context, t T) error
+	Unpublish(ctx context.Context, id int64) error
+}
+
+var _ ecron.NamedJob = (*Job[any])(nil)
+
+// Job 处理到期的定时发布和定时下线
+// 先发布后下线，所以同时到期的情况下，最终是下线的状态
+type Job[T any] struct {
+	name string
+	// biz 用在日志里面，例如问题、案例
+	biz    string
+	svc    Service[T]
+	id     func(t T) int64
+	limit  int
+	logger *elog.Component
+}
+
+func NewJob[T any](name, biz string, svc Service[T], id func(t T) int64, limit int) *Job[T] {
+	return &Job[T]{
+		name:   name,
+		biz:    biz,
+		svc:    svc,
+		id:     id,
+		limit:  limit,
+		logger: elog.DefaultLogger,
+	}
+}
+
+func (j *Job[T]) Name() string {
+	return j.name
+}
+
+func (j *Job[T]) Run(ctx context.Context) error {
+	now := time.Now().UnixMilli()
+	err := j.run(ctx, now, j.svc.DuePublish, j.svc.PublishScheduled, "发布")
+	if err != nil {
+		return err
+	}
+	return j.run(ctx, now, j.svc.DueUnpublish, func(ctx context.Context, t T) error {
+		return j.svc.Unpublish(ctx, j.id(t))
+	}, "下线")
+}
+
+func (j *Job[T]) run(ctx context.Context, now int64,
+	due func(ctx context.Context, now int64, minId int64, limit int) ([]T, error),
+	apply func(ctx context.Context, t T) error,
+	action string) error {
+	// 单条失败不影响别的数据，下一次运行的时候会重试
+	// 按照 id 往后翻页，避免失败的数据导致死循环
+	var minId int64
+	for {
+		ts, err := due(ctx, now, minId, j.limit)
+		if err != nil {
+			return fmt.Errorf("查询到期需要%s的%s失败: %w", action, j.biz, err)
+		}
+		for _, t := range ts {
+			minId = j.id(t)
+			err = apply(ctx, t)
+			if err != nil {
+				j.logger.Error(fmt.Sprintf("定时%s%s失败", action, j.biz),
+					elog.Int64("id", minId), elog.FieldErr(err))
+			}
+		}
+		if len(ts) < j.limit {
+			return nil
+		}
+	}
+}
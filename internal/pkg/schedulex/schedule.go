@@ -0,0 +1,28 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulex
+
+import "errors"
+
+// ErrInvalidSchedule 同时设置了定时发布和定时下线的时候，下线时间必须晚于发布时间
+var ErrInvalidSchedule = errors.New("定时下线时间必须晚于定时发布时间")
+
+// Check 校验定时发布和定时下线的时间，毫秒数，0 代表没有设置
+func Check(publishAt, unpublishAt int64) error {
+	if publishAt > 0 && unpublishAt > 0 && unpublishAt <= publishAt {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
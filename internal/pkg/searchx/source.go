@@ -0,0 +1,28 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchx
+
+import "context"
+
+// Source 搜索数据的权威来源，例如题目、案例等模块
+// 重建索引和检查索引是否一致的时候，从这里读取全量的数据
+type Source interface {
+	// Biz 和同步到搜索的消息里面的 biz 一致
+	Biz() string
+	// Ids 全部数据的 id
+	Ids(ctx context.Context) ([]int64, error)
+	// Doc 和同步到搜索的消息里面的 data 一致
+	Doc(ctx context.Context, id int64) (string, error)
+}
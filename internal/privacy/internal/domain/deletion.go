@@ -0,0 +1,50 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Deletion 注销账号的申请，一个用户只有一条
+// 冷静期内用户可以撤销，冷静期过了之后各个模块才会真的清理数据
+type Deletion struct {
+	Uid    int64
+	Status DeletionStatus
+	// ExecuteAt 冷静期结束的时间，毫秒。
+	// 通知了各个模块之后就是下一次检查有没有全部确认的时间
+	ExecuteAt int64
+	// Attempts 通知了各个模块多少次
+	Attempts int
+	Ctime    int64
+	Utime    int64
+}
+
+type DeletionStatus uint8
+
+func (s DeletionStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	// DeletionStatusUnknown 没有申请过
+	DeletionStatusUnknown DeletionStatus = 0
+	// DeletionStatusPending 冷静期中
+	DeletionStatusPending DeletionStatus = 1
+	// DeletionStatusCancelled 用户在冷静期内撤销了
+	DeletionStatusCancelled DeletionStatus = 2
+	// DeletionStatusDone 各个模块都确认清理完数据了
+	DeletionStatusDone DeletionStatus = 3
+	// DeletionStatusExecuting 已经通知各个模块清理数据，等待它们确认
+	DeletionStatusExecuting DeletionStatus = 4
+	// DeletionStatusFailed 重试了很多次还是有模块没有确认，需要人工处理
+	DeletionStatusFailed DeletionStatus = 5
+)
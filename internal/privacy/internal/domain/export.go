@@ -0,0 +1,46 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// ExportTask 导出个人数据的任务，异步执行，完成之后用户可以下载压缩包
+type ExportTask struct {
+	ID     int64
+	Uid    int64
+	Status ExportStatus
+	// Archive 压缩包，只有下载的时候才会读出来
+	Archive []byte
+	// ExpireAt 过了这个时间就不能下载了
+	ExpireAt int64
+	Ctime    int64
+	Utime    int64
+}
+
+type ExportStatus uint8
+
+func (s ExportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ExportStatusUnknown ExportStatus = 0
+	// ExportStatusPending 等待定时任务处理
+	ExportStatusPending ExportStatus = 1
+	// ExportStatusSuccess 可以下载了
+	ExportStatusSuccess ExportStatus = 2
+	// ExportStatusFailed 某个模块导出失败了，用户需要重新发起
+	ExportStatusFailed ExportStatus = 3
+	// ExportStatusExpired 压缩包已经下载过或者过期了，已经删掉，用户需要重新发起
+	ExportStatusExpired ExportStatus = 4
+)
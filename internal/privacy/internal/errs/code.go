@@ -0,0 +1,30 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errs
+
+var (
+	ExportNotFound   = ErrorCode{Code: 419001, Msg: "导出任务不存在"}
+	ExportNotReady   = ErrorCode{Code: 419002, Msg: "导出任务还没有完成"}
+	DeletionNotFound = ErrorCode{Code: 419003, Msg: "没有处于冷静期的注销申请"}
+	AccountDeleted   = ErrorCode{Code: 419004, Msg: "账号已经注销"}
+	ExportExpired    = ErrorCode{Code: 419005, Msg: "导出的数据已经下载过或者过期了，请重新导出"}
+
+	SystemError = ErrorCode{Code: 519001, Msg: "系统错误"}
+)
+
+type ErrorCode struct {
+	Code int
+	Msg  string
+}
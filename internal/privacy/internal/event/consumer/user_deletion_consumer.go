@@ -0,0 +1,86 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event"
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// UserDeletionConsumer 注销账号的时候清理本模块的导出任务。
+// 同一个模块，直接记录确认，不需要再发一次确认事件
+type UserDeletionConsumer struct {
+	exportSvc   service.ExportService
+	deletionSvc service.DeletionService
+	consumer    mq.Consumer
+	logger      *elog.Component
+}
+
+func NewUserDeletionConsumer(exportSvc service.ExportService,
+	deletionSvc service.DeletionService, q mq.MQ) (*UserDeletionConsumer, error) {
+	groupID := "privacy"
+	consumer, err := q.Consumer(event.UserDeletionEventName, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDeletionConsumer{
+		exportSvc:   exportSvc,
+		deletionSvc: deletionSvc,
+		consumer:    consumer,
+		logger:      elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserDeletionConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费注销账号事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserDeletionConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt event.UserDeletionEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.exportSvc.DeleteUserData(ctx, evt.Uid)
+	if err != nil {
+		return fmt.Errorf("清理注销账号的数据失败 %w, uid %d", err, evt.Uid)
+	}
+	err = c.deletionSvc.Confirm(ctx, evt.Uid, "privacy")
+	if err != nil {
+		return fmt.Errorf("记录注销账号确认失败 %w, uid %d", err, evt.Uid)
+	}
+	return nil
+}
+
+func (c *UserDeletionConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event"
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// UserDeletionDoneConsumer 记录各个模块清理完数据的确认
+type UserDeletionDoneConsumer struct {
+	svc      service.DeletionService
+	consumer mq.Consumer
+	logger   *elog.Component
+}
+
+func NewUserDeletionDoneConsumer(svc service.DeletionService, q mq.MQ) (*UserDeletionDoneConsumer, error) {
+	groupID := "privacy"
+	consumer, err := q.Consumer(event.UserDeletionDoneEventName, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDeletionDoneConsumer{
+		svc:      svc,
+		consumer: consumer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *UserDeletionDoneConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费注销账号确认事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *UserDeletionDoneConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt event.UserDeletionDoneEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	// 这里失败了也没关系，没有确认的模块会在下一次检查的时候重新通知
+	err = c.svc.Confirm(ctx, evt.Uid, evt.Module)
+	if err != nil {
+		return fmt.Errorf("记录注销账号确认失败 %w, uid %d, module %s", err, evt.Uid, evt.Module)
+	}
+	return nil
+}
+
+func (c *UserDeletionDoneConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
@@ -0,0 +1,347 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ekit/net/httpx/httptestx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/ecodeclub/webook/internal/privacy"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/errs"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event"
+	"github.com/ecodeclub/webook/internal/privacy/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/privacy/internal/web"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/ego-component/egorm"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const uid = 2048
+
+type HandlerTestSuite struct {
+	suite.Suite
+	server *egin.Component
+	db     *egorm.Component
+	module *privacy.Module
+}
+
+func (s *HandlerTestSuite) SetupSuite() {
+	econf.Set("privacy", map[string]any{
+		"export": map[string]any{
+			"ttl": "1h",
+		},
+		"deletion": map[string]any{
+			"coolingOff":    "1h",
+			"modules":       []string{"user", "credit"},
+			"retryInterval": "1h",
+			"maxAttempts":   2,
+		},
+	})
+	s.module = startup.InitModule([]exportx.Source{
+		&fakeSource{name: "profile"},
+		&fakeSource{name: "credit"},
+	})
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: uid,
+		}))
+	})
+	s.module.Hdl.PrivateRoutes(server.Engine)
+	s.server = server
+	s.db = testioc.InitDB()
+}
+
+func (s *HandlerTestSuite) TearDownTest() {
+	err := s.db.Exec("TRUNCATE TABLE `export_tasks`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `account_deletions`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `account_deletion_confirmations`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandlerTestSuite) TestExport() {
+	t := s.T()
+	recorder := doRequest[int64](t, s.server, "/privacy/export/create", nil)
+	require.Equal(t, 200, recorder.Code)
+	id := recorder.MustScan().Data
+	require.True(t, id > 0)
+
+	// 还没有处理的时候重复发起，返回同一个任务
+	recorder = doRequest[int64](t, s.server, "/privacy/export/create", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, id, recorder.MustScan().Data)
+
+	download := s.download(t, id)
+	require.Equal(t, 200, download.Code)
+	assert.Equal(t, errs.ExportNotReady.Code, s.scanResult(t, download).Code)
+
+	err := s.module.ExportJob.Run(context.Background())
+	require.NoError(t, err)
+
+	listRecorder := doRequest[[]web.ExportTask](t, s.server, "/privacy/export/list", nil)
+	require.Equal(t, 200, listRecorder.Code)
+	tasks := listRecorder.MustScan().Data
+	require.Len(t, tasks, 1)
+	assert.Equal(t, domain.ExportStatusSuccess.ToUint8(), tasks[0].Status)
+	// 保留一个小时
+	assert.InDelta(t, time.Now().Add(time.Hour).UnixMilli(), tasks[0].ExpireAt, float64(time.Minute.Milliseconds()))
+
+	download = s.download(t, id)
+	require.Equal(t, 200, download.Code)
+	assert.Equal(t, "application/zip", download.Header().Get("Content-Type"))
+	body := download.Body.Bytes()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	files := make(map[string]map[string]any, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		val, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+		var data map[string]any
+		require.NoError(t, json.Unmarshal(val, &data))
+		files[f.Name] = data
+	}
+	assert.Equal(t, map[string]map[string]any{
+		"profile.json": {"source": "profile", "uid": float64(uid)},
+		"credit.json":  {"source": "credit", "uid": float64(uid)},
+	}, files)
+
+	// 下载过一次之后压缩包就删掉了
+	download = s.download(t, id)
+	require.Equal(t, 200, download.Code)
+	assert.Equal(t, errs.ExportExpired.Code, s.scanResult(t, download).Code)
+	var task dao.ExportTask
+	err = s.db.Where("id = ?", id).First(&task).Error
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusExpired.ToUint8(), task.Status)
+	assert.Empty(t, task.Archive)
+
+	// 别人的任务不能下载
+	other := dao.ExportTask{Uid: uid + 1, Status: domain.ExportStatusSuccess.ToUint8(), Archive: []byte("zip")}
+	err = s.db.Create(&other).Error
+	require.NoError(t, err)
+	download = s.download(t, other.Id)
+	require.Equal(t, 200, download.Code)
+	assert.Equal(t, errs.ExportNotFound.Code, s.scanResult(t, download).Code)
+}
+
+func (s *HandlerTestSuite) TestCleanExpiredExport() {
+	t := s.T()
+	now := time.Now()
+	expired := dao.ExportTask{Uid: uid, Status: domain.ExportStatusSuccess.ToUint8(),
+		Archive: []byte("zip"), ExpireAt: now.Add(-time.Minute).UnixMilli()}
+	valid := dao.ExportTask{Uid: uid, Status: domain.ExportStatusSuccess.ToUint8(),
+		Archive: []byte("zip"), ExpireAt: now.Add(time.Hour).UnixMilli()}
+	err := s.db.Create(&expired).Error
+	require.NoError(t, err)
+	err = s.db.Create(&valid).Error
+	require.NoError(t, err)
+
+	// 清理任务还没有跑的时候，过期的也不能下载
+	download := s.download(t, expired.Id)
+	require.Equal(t, 200, download.Code)
+	assert.Equal(t, errs.ExportExpired.Code, s.scanResult(t, download).Code)
+
+	err = s.module.CleanExportJob.Run(context.Background())
+	require.NoError(t, err)
+	var tasks []dao.ExportTask
+	err = s.db.Order("id").Find(&tasks).Error
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, domain.ExportStatusExpired.ToUint8(), tasks[0].Status)
+	assert.Empty(t, tasks[0].Archive)
+	assert.Equal(t, domain.ExportStatusSuccess.ToUint8(), tasks[1].Status)
+	assert.Equal(t, []byte("zip"), tasks[1].Archive)
+}
+
+func (s *HandlerTestSuite) TestDeletion() {
+	t := s.T()
+	recorder := doRequest[web.Deletion](t, s.server, "/privacy/deletion/status", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, domain.DeletionStatusUnknown.ToUint8(), recorder.MustScan().Data.Status)
+
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/request", nil)
+	require.Equal(t, 200, recorder.Code)
+	d := recorder.MustScan().Data
+	assert.Equal(t, domain.DeletionStatusPending.ToUint8(), d.Status)
+	// 冷静期是一个小时
+	assert.InDelta(t, time.Now().Add(time.Hour).UnixMilli(), d.ExecuteAt, float64(time.Minute.Milliseconds()))
+
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/cancel", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, 0, recorder.MustScan().Code)
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/cancel", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, errs.DeletionNotFound.Code, recorder.MustScan().Code)
+
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/request", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, domain.DeletionStatusPending.ToUint8(), recorder.MustScan().Data.Status)
+	exportRecorder := doRequest[int64](t, s.server, "/privacy/export/create", nil)
+	require.Equal(t, 200, exportRecorder.Code)
+
+	// 冷静期内执行任务，什么也不会发生
+	err := s.module.AccountDeletionJob.Run(context.Background())
+	require.NoError(t, err)
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/status", nil)
+	assert.Equal(t, domain.DeletionStatusPending.ToUint8(), recorder.MustScan().Data.Status)
+
+	// 模拟冷静期结束
+	s.expireDeletion(t)
+	consumer, err := testioc.InitMQ().Consumer(event.UserDeletionEventName, "privacy_test")
+	require.NoError(t, err)
+	err = s.module.AccountDeletionJob.Run(context.Background())
+	require.NoError(t, err)
+
+	// 通知了之后要等各个模块确认
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/status", nil)
+	assert.Equal(t, domain.DeletionStatusExecuting.ToUint8(), recorder.MustScan().Data.Status)
+	evt := s.consumeEvent(t, consumer)
+	assert.Equal(t, event.UserDeletionEvent{Uid: uid}, evt)
+	// 本模块会删掉导出任务
+	require.Eventually(t, func() bool {
+		var cnt int64
+		err := s.db.Model(&dao.ExportTask{}).Where("uid = ?", uid).Count(&cnt).Error
+		return err == nil && cnt == 0
+	}, 10*time.Second, 100*time.Millisecond)
+	err = s.module.DeletionSvc.Confirm(context.Background(), uid, "user")
+	require.NoError(t, err)
+	// 重复确认
+	err = s.module.DeletionSvc.Confirm(context.Background(), uid, "user")
+	require.NoError(t, err)
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/status", nil)
+	assert.Equal(t, domain.DeletionStatusExecuting.ToUint8(), recorder.MustScan().Data.Status)
+
+	// 到了检查时间 credit 还没有确认，重新通知
+	s.expireDeletion(t)
+	err = s.module.AccountDeletionJob.Run(context.Background())
+	require.NoError(t, err)
+	evt = s.consumeEvent(t, consumer)
+	assert.Equal(t, event.UserDeletionEvent{Uid: uid}, evt)
+	var ad dao.AccountDeletion
+	err = s.db.Where("uid = ?", uid).First(&ad).Error
+	require.NoError(t, err)
+	assert.Equal(t, 2, ad.Attempts)
+	assert.Equal(t, domain.DeletionStatusExecuting.ToUint8(), ad.Status)
+
+	// 通知次数用完了，等人工处理
+	s.expireDeletion(t)
+	err = s.module.AccountDeletionJob.Run(context.Background())
+	require.NoError(t, err)
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/status", nil)
+	assert.Equal(t, domain.DeletionStatusFailed.ToUint8(), recorder.MustScan().Data.Status)
+
+	// 迟到的确认通过消息队列过来，所有模块都确认了就算完成
+	producer, err := mqx.NewGeneralProducer[event.UserDeletionDoneEvent](testioc.InitMQ(), event.UserDeletionDoneEventName)
+	require.NoError(t, err)
+	err = producer.Produce(context.Background(), event.UserDeletionDoneEvent{Uid: uid, Module: "credit"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		d, err := s.module.DeletionSvc.Status(context.Background(), uid)
+		return err == nil && d.Status == domain.DeletionStatusDone
+	}, 10*time.Second, 100*time.Millisecond)
+
+	recorder = doRequest[web.Deletion](t, s.server, "/privacy/deletion/request", nil)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, errs.AccountDeleted.Code, recorder.MustScan().Code)
+}
+
+// expireDeletion 模拟到了执行或者检查的时间
+func (s *HandlerTestSuite) expireDeletion(t *testing.T) {
+	err := s.db.Model(&dao.AccountDeletion{}).Where("uid = ?", uid).
+		Update("execute_at", time.Now().Add(-time.Minute).UnixMilli()).Error
+	require.NoError(t, err)
+}
+
+func (s *HandlerTestSuite) download(t *testing.T, id int64) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/privacy/export/download?id=%d", id), nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	s.server.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *HandlerTestSuite) scanResult(t *testing.T, recorder *httptest.ResponseRecorder) test.Result[any] {
+	var res test.Result[any]
+	err := json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	return res
+}
+
+func (s *HandlerTestSuite) consumeEvent(t *testing.T, consumer mq.Consumer) event.UserDeletionEvent {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := consumer.Consume(ctx)
+	require.NoError(t, err)
+	var evt event.UserDeletionEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	require.NoError(t, err)
+	return evt
+}
+
+// fakeSource 导出的时候原样返回 uid，方便断言
+type fakeSource struct {
+	name string
+}
+
+func (f *fakeSource) Name() string {
+	return f.name
+}
+
+func (f *fakeSource) Export(ctx context.Context, uid int64) (any, error) {
+	return map[string]any{"source": f.name, "uid": uid}, nil
+}
+
+func doRequest[T any](t *testing.T, server *egin.Component, path string, body any) *httptestx.JSONResponseRecorder[test.Result[T]] {
+	req, err := http.NewRequest(http.MethodPost, path, iox.NewJSONReader(body))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[T]()
+	server.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestPrivacyHandler(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
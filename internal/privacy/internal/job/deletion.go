@@ -0,0 +1,50 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*AccountDeletionJob)(nil)
+
+// AccountDeletionJob 冷静期结束之后，通知各个模块清理注销账号的数据
+type AccountDeletionJob struct {
+	svc   service.DeletionService
+	limit int
+}
+
+func NewAccountDeletionJob(svc service.DeletionService, limit int) *AccountDeletionJob {
+	return &AccountDeletionJob{svc: svc, limit: limit}
+}
+
+func (j *AccountDeletionJob) Name() string {
+	return "AccountDeletionJob"
+}
+
+func (j *AccountDeletionJob) Run(ctx context.Context) error {
+	for {
+		cnt, err := j.svc.ExecuteDue(ctx, j.limit)
+		if err != nil {
+			return err
+		}
+		if cnt < j.limit {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/gotomicro/ego/task/ecron"
+)
+
+var _ ecron.NamedJob = (*ExportJob)(nil)
+
+// ExportJob 处理用户发起的导出个人数据任务
+type ExportJob struct {
+	svc   service.ExportService
+	limit int
+}
+
+func NewExportJob(svc service.ExportService, limit int) *ExportJob {
+	return &ExportJob{svc: svc, limit: limit}
+}
+
+func (j *ExportJob) Name() string {
+	return "ExportJob"
+}
+
+func (j *ExportJob) Run(ctx context.Context) error {
+	for {
+		cnt, err := j.svc.ProcessPending(ctx, j.limit)
+		if err != nil {
+			return err
+		}
+		if cnt < j.limit {
+			return nil
+		}
+	}
+}
+
+var _ ecron.NamedJob = (*CleanExportJob)(nil)
+
+// CleanExportJob 删掉已经过期的导出压缩包
+type CleanExportJob struct {
+	svc   service.ExportService
+	limit int
+}
+
+func NewCleanExportJob(svc service.ExportService, limit int) *CleanExportJob {
+	return &CleanExportJob{svc: svc, limit: limit}
+}
+
+func (j *CleanExportJob) Name() string {
+	return "CleanExportJob"
+}
+
+func (j *CleanExportJob) Run(ctx context.Context) error {
+	for {
+		cnt, err := j.svc.CleanExpired(ctx, j.limit)
+		if err != nil {
+			return err
+		}
+		if cnt < j.limit {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeletionDAO interface {
+	// Upsert 没有申请过就插入，申请过就重新进入冷静期
+	Upsert(ctx context.Context, d AccountDeletion) error
+	FindByUid(ctx context.Context, uid int64) (AccountDeletion, error)
+	// UpdateStatus 只有状态是 from 的时候才会更新，返回是否更新成功
+	UpdateStatus(ctx context.Context, uid int64, from, to uint8) (bool, error)
+	// MarkExecuting 只有状态是 from 的时候才会更新，通知次数加一，并且设置下一次检查的时间
+	MarkExecuting(ctx context.Context, uid int64, from uint8, nextCheckAt int64) (bool, error)
+	// FindDue 找出冷静期已经结束，或者到了检查时间的申请
+	FindDue(ctx context.Context, now int64, limit int) ([]AccountDeletion, error)
+	// Confirm 记录模块已经清理完了，重复确认不会报错
+	Confirm(ctx context.Context, uid int64, module string) error
+	FindConfirmedModules(ctx context.Context, uid int64) ([]string, error)
+}
+
+type GORMDeletionDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMDeletionDAO(db *egorm.Component) DeletionDAO {
+	return &GORMDeletionDAO{db: db}
+}
+
+func (d *GORMDeletionDAO) Upsert(ctx context.Context, ad AccountDeletion) error {
+	now := time.Now().UnixMilli()
+	ad.Ctime, ad.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"status":     ad.Status,
+			"execute_at": ad.ExecuteAt,
+			"attempts":   0,
+			"utime":      now,
+		}),
+	}).Create(&ad).Error
+}
+
+func (d *GORMDeletionDAO) FindByUid(ctx context.Context, uid int64) (AccountDeletion, error) {
+	var res AccountDeletion
+	err := d.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
+
+func (d *GORMDeletionDAO) UpdateStatus(ctx context.Context, uid int64, from, to uint8) (bool, error) {
+	res := d.db.WithContext(ctx).Model(&AccountDeletion{}).
+		Where("uid = ? AND status = ?", uid, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (d *GORMDeletionDAO) MarkExecuting(ctx context.Context, uid int64, from uint8, nextCheckAt int64) (bool, error) {
+	res := d.db.WithContext(ctx).Model(&AccountDeletion{}).
+		Where("uid = ? AND status = ?", uid, from).
+		Updates(map[string]any{
+			"status":     4,
+			"execute_at": nextCheckAt,
+			"attempts":   gorm.Expr("`attempts` + 1"),
+			"utime":      time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (d *GORMDeletionDAO) FindDue(ctx context.Context, now int64, limit int) ([]AccountDeletion, error) {
+	var res []AccountDeletion
+	err := d.db.WithContext(ctx).
+		Where("status IN ? AND execute_at <= ?", []uint8{1, 4}, now).
+		Order("execute_at").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMDeletionDAO) Confirm(ctx context.Context, uid int64, module string) error {
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&AccountDeletionConfirmation{
+			Uid:    uid,
+			Module: module,
+			Ctime:  time.Now().UnixMilli(),
+		}).Error
+}
+
+func (d *GORMDeletionDAO) FindConfirmedModules(ctx context.Context, uid int64) ([]string, error) {
+	var res []string
+	err := d.db.WithContext(ctx).Model(&AccountDeletionConfirmation{}).
+		Where("uid = ?", uid).Pluck("module", &res).Error
+	return res, err
+}
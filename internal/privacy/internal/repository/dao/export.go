@@ -0,0 +1,110 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type ExportDAO interface {
+	Create(ctx context.Context, t ExportTask) (int64, error)
+	// FindByUid 不会读取压缩包
+	FindByUid(ctx context.Context, uid int64) ([]ExportTask, error)
+	// FindPending 不会读取压缩包
+	FindPending(ctx context.Context, limit int) ([]ExportTask, error)
+	FindById(ctx context.Context, id int64) (ExportTask, error)
+	UpdateResult(ctx context.Context, id int64, status uint8, archive []byte, expireAt int64) error
+	// Invalidate 删掉压缩包，把成功的任务标记为已失效
+	Invalidate(ctx context.Context, id int64) error
+	// InvalidateExpired 删掉一批已经过期的压缩包，返回处理了多少个
+	InvalidateExpired(ctx context.Context, now int64, limit int) (int, error)
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+type GORMExportDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMExportDAO(db *egorm.Component) ExportDAO {
+	return &GORMExportDAO{db: db}
+}
+
+func (d *GORMExportDAO) Create(ctx context.Context, t ExportTask) (int64, error) {
+	now := time.Now().UnixMilli()
+	t.Ctime, t.Utime = now, now
+	err := d.db.WithContext(ctx).Create(&t).Error
+	return t.Id, err
+}
+
+func (d *GORMExportDAO) FindByUid(ctx context.Context, uid int64) ([]ExportTask, error) {
+	var res []ExportTask
+	err := d.db.WithContext(ctx).Omit("archive").
+		Where("uid = ?", uid).Order("id DESC").Find(&res).Error
+	return res, err
+}
+
+func (d *GORMExportDAO) FindPending(ctx context.Context, limit int) ([]ExportTask, error) {
+	var res []ExportTask
+	err := d.db.WithContext(ctx).Omit("archive").
+		Where("status = ?", 1).Order("id").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMExportDAO) FindById(ctx context.Context, id int64) (ExportTask, error) {
+	var t ExportTask
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&t).Error
+	return t, err
+}
+
+func (d *GORMExportDAO) UpdateResult(ctx context.Context, id int64, status uint8, archive []byte, expireAt int64) error {
+	return d.db.WithContext(ctx).Model(&ExportTask{}).Where("id = ?", id).Updates(map[string]any{
+		"status":    status,
+		"archive":   archive,
+		"expire_at": expireAt,
+		"utime":     time.Now().UnixMilli(),
+	}).Error
+}
+
+func (d *GORMExportDAO) Invalidate(ctx context.Context, id int64) error {
+	return d.invalidate(d.db.WithContext(ctx).Where("id = ?", id))
+}
+
+func (d *GORMExportDAO) InvalidateExpired(ctx context.Context, now int64, limit int) (int, error) {
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&ExportTask{}).
+		Where("status = ? AND expire_at <= ?", 2, now).
+		Order("id").Limit(limit).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return len(ids), d.invalidate(d.db.WithContext(ctx).Where("id IN ?", ids))
+}
+
+// invalidate 只处理成功的任务，避免和正在写结果的定时任务互相覆盖
+func (d *GORMExportDAO) invalidate(db *gorm.DB) error {
+	return db.Model(&ExportTask{}).Where("status = ?", 2).Updates(map[string]any{
+		"status":  4,
+		"archive": nil,
+		"utime":   time.Now().UnixMilli(),
+	}).Error
+}
+
+func (d *GORMExportDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return d.db.WithContext(ctx).Where("uid = ?", uid).Delete(&ExportTask{}).Error
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+type ExportTask struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"not null;index"`
+	// Status 1-待处理 2-成功 3-失败 4-已失效
+	Status  uint8  `gorm:"type:tinyint(3);not null;default:1;index"`
+	Archive []byte `gorm:"type:longblob"`
+	// ExpireAt 压缩包的过期时间，过期或者下载过之后就删掉压缩包
+	ExpireAt int64 `gorm:"not null;default:0;index"`
+	Ctime    int64
+	Utime    int64
+}
+
+// AccountDeletion 注销账号的申请，一个用户只有一条，重新申请的时候覆盖
+type AccountDeletion struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"not null;uniqueIndex"`
+	// Status 1-冷静期 2-已撤销 3-已执行 4-执行中 5-失败
+	Status    uint8 `gorm:"type:tinyint(3);not null;default:1;index:status_execute_at"`
+	ExecuteAt int64 `gorm:"not null;index:status_execute_at"`
+	Attempts  int   `gorm:"not null;default:0"`
+	Ctime     int64
+	Utime     int64
+}
+
+// AccountDeletionConfirmation 各个模块清理完注销账号的数据之后的确认记录
+type AccountDeletionConfirmation struct {
+	Id     int64  `gorm:"primaryKey,autoIncrement"`
+	Uid    int64  `gorm:"not null;uniqueIndex:uid_module"`
+	Module string `gorm:"type:varchar(64);not null;uniqueIndex:uid_module"`
+	Ctime  int64
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository/dao"
+)
+
+type DeletionRepository interface {
+	Save(ctx context.Context, d domain.Deletion) error
+	// FindByUid 没有申请过的时候返回 DeletionStatusUnknown
+	FindByUid(ctx context.Context, uid int64) (domain.Deletion, error)
+	UpdateStatus(ctx context.Context, uid int64, from, to domain.DeletionStatus) (bool, error)
+	// MarkExecuting 改成 DeletionStatusExecuting，通知次数加一
+	MarkExecuting(ctx context.Context, uid int64, from domain.DeletionStatus, nextCheckAt int64) (bool, error)
+	FindDue(ctx context.Context, now int64, limit int) ([]domain.Deletion, error)
+	Confirm(ctx context.Context, uid int64, module string) error
+	FindConfirmedModules(ctx context.Context, uid int64) ([]string, error)
+}
+
+type deletionRepository struct {
+	dao dao.DeletionDAO
+}
+
+func NewDeletionRepository(d dao.DeletionDAO) DeletionRepository {
+	return &deletionRepository{dao: d}
+}
+
+func (r *deletionRepository) Save(ctx context.Context, d domain.Deletion) error {
+	return r.dao.Upsert(ctx, dao.AccountDeletion{
+		Uid:       d.Uid,
+		Status:    d.Status.ToUint8(),
+		ExecuteAt: d.ExecuteAt,
+	})
+}
+
+func (r *deletionRepository) FindByUid(ctx context.Context, uid int64) (domain.Deletion, error) {
+	d, err := r.dao.FindByUid(ctx, uid)
+	if errors.Is(err, dao.ErrRecordNotFound) {
+		return domain.Deletion{Uid: uid}, nil
+	}
+	return r.toDomain(d), err
+}
+
+func (r *deletionRepository) UpdateStatus(ctx context.Context, uid int64, from, to domain.DeletionStatus) (bool, error) {
+	return r.dao.UpdateStatus(ctx, uid, from.ToUint8(), to.ToUint8())
+}
+
+func (r *deletionRepository) MarkExecuting(ctx context.Context, uid int64, from domain.DeletionStatus, nextCheckAt int64) (bool, error) {
+	return r.dao.MarkExecuting(ctx, uid, from.ToUint8(), nextCheckAt)
+}
+
+func (r *deletionRepository) Confirm(ctx context.Context, uid int64, module string) error {
+	return r.dao.Confirm(ctx, uid, module)
+}
+
+func (r *deletionRepository) FindConfirmedModules(ctx context.Context, uid int64) ([]string, error) {
+	return r.dao.FindConfirmedModules(ctx, uid)
+}
+
+func (r *deletionRepository) FindDue(ctx context.Context, now int64, limit int) ([]domain.Deletion, error) {
+	ds, err := r.dao.FindDue(ctx, now, limit)
+	return slice.Map(ds, func(idx int, src dao.AccountDeletion) domain.Deletion {
+		return r.toDomain(src)
+	}), err
+}
+
+func (r *deletionRepository) toDomain(d dao.AccountDeletion) domain.Deletion {
+	return domain.Deletion{
+		Uid:       d.Uid,
+		Status:    domain.DeletionStatus(d.Status),
+		ExecuteAt: d.ExecuteAt,
+		Attempts:  d.Attempts,
+		Ctime:     d.Ctime,
+		Utime:     d.Utime,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository/dao"
+)
+
+type ExportRepository interface {
+	Create(ctx context.Context, uid int64) (int64, error)
+	FindByUid(ctx context.Context, uid int64) ([]domain.ExportTask, error)
+	FindPending(ctx context.Context, limit int) ([]domain.ExportTask, error)
+	FindById(ctx context.Context, id int64) (domain.ExportTask, error)
+	UpdateResult(ctx context.Context, id int64, status domain.ExportStatus, archive []byte, expireAt int64) error
+	Invalidate(ctx context.Context, id int64) error
+	InvalidateExpired(ctx context.Context, now int64, limit int) (int, error)
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+type exportRepository struct {
+	dao dao.ExportDAO
+}
+
+func NewExportRepository(d dao.ExportDAO) ExportRepository {
+	return &exportRepository{dao: d}
+}
+
+func (r *exportRepository) Create(ctx context.Context, uid int64) (int64, error) {
+	return r.dao.Create(ctx, dao.ExportTask{
+		Uid:    uid,
+		Status: domain.ExportStatusPending.ToUint8(),
+	})
+}
+
+func (r *exportRepository) FindByUid(ctx context.Context, uid int64) ([]domain.ExportTask, error) {
+	ts, err := r.dao.FindByUid(ctx, uid)
+	return slice.Map(ts, func(idx int, src dao.ExportTask) domain.ExportTask {
+		return r.toDomain(src)
+	}), err
+}
+
+func (r *exportRepository) FindPending(ctx context.Context, limit int) ([]domain.ExportTask, error) {
+	ts, err := r.dao.FindPending(ctx, limit)
+	return slice.Map(ts, func(idx int, src dao.ExportTask) domain.ExportTask {
+		return r.toDomain(src)
+	}), err
+}
+
+func (r *exportRepository) FindById(ctx context.Context, id int64) (domain.ExportTask, error) {
+	t, err := r.dao.FindById(ctx, id)
+	return r.toDomain(t), err
+}
+
+func (r *exportRepository) UpdateResult(ctx context.Context, id int64, status domain.ExportStatus, archive []byte, expireAt int64) error {
+	return r.dao.UpdateResult(ctx, id, status.ToUint8(), archive, expireAt)
+}
+
+func (r *exportRepository) Invalidate(ctx context.Context, id int64) error {
+	return r.dao.Invalidate(ctx, id)
+}
+
+func (r *exportRepository) InvalidateExpired(ctx context.Context, now int64, limit int) (int, error) {
+	return r.dao.InvalidateExpired(ctx, now, limit)
+}
+
+func (r *exportRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return r.dao.DeleteByUid(ctx, uid)
+}
+
+func (r *exportRepository) toDomain(t dao.ExportTask) domain.ExportTask {
+	return domain.ExportTask{
+		ID:       t.Id,
+		Uid:      t.Uid,
+		Status:   domain.ExportStatus(t.Status),
+		Archive:  t.Archive,
+		ExpireAt: t.ExpireAt,
+		Ctime:    t.Ctime,
+		Utime:    t.Utime,
+	}
+}
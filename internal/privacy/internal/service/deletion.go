@@ -0,0 +1,192 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+var (
+	ErrDeletionNotFound = errors.New("没有处于冷静期的注销申请")
+	ErrAccountDeleted   = errors.New("账号已经注销")
+)
+
+type DeletionService interface {
+	// Request 申请注销账号，进入冷静期。已经在冷静期的时候不会重新计时
+	Request(ctx context.Context, uid int64) (domain.Deletion, error)
+	// Cancel 冷静期内撤销申请
+	Cancel(ctx context.Context, uid int64) error
+	Status(ctx context.Context, uid int64) (domain.Deletion, error)
+	// ExecuteDue 找出一批冷静期已经结束的申请，通知各个模块清理数据；
+	// 以及到了检查时间还有模块没有确认的申请，重新通知，返回处理了多少个
+	ExecuteDue(ctx context.Context, limit int) (int, error)
+	// Confirm 模块清理完数据之后确认，所有模块都确认了才算注销完成
+	Confirm(ctx context.Context, uid int64, module string) error
+}
+
+// DeletionConfig 注销账号的配置
+type DeletionConfig struct {
+	// CoolingOff 冷静期，冷静期内可以撤销
+	CoolingOff time.Duration `yaml:"coolingOff"`
+	// Modules 需要确认清理完数据的模块
+	Modules []string `yaml:"modules"`
+	// RetryInterval 通知了之后过了这么久还有模块没有确认，就重新通知
+	RetryInterval time.Duration `yaml:"retryInterval"`
+	// MaxAttempts 最多通知几次，超过了就改成 DeletionStatusFailed 等人工处理
+	MaxAttempts int `yaml:"maxAttempts"`
+}
+
+type deletionService struct {
+	repo     repository.DeletionRepository
+	producer event.UserDeletionEventProducer
+	cfg      DeletionConfig
+	logger   *elog.Component
+}
+
+func NewDeletionService(repo repository.DeletionRepository,
+	producer event.UserDeletionEventProducer,
+	cfg DeletionConfig) DeletionService {
+	return &deletionService{
+		repo:     repo,
+		producer: producer,
+		cfg:      cfg,
+		logger:   elog.DefaultLogger,
+	}
+}
+
+func (s *deletionService) Request(ctx context.Context, uid int64) (domain.Deletion, error) {
+	d, err := s.repo.FindByUid(ctx, uid)
+	if err != nil {
+		return domain.Deletion{}, err
+	}
+	switch d.Status {
+	case domain.DeletionStatusDone, domain.DeletionStatusExecuting, domain.DeletionStatusFailed:
+		return domain.Deletion{}, fmt.Errorf("%w, uid %d", ErrAccountDeleted, uid)
+	case domain.DeletionStatusPending:
+		return d, nil
+	}
+	d = domain.Deletion{
+		Uid:       uid,
+		Status:    domain.DeletionStatusPending,
+		ExecuteAt: time.Now().Add(s.cfg.CoolingOff).UnixMilli(),
+	}
+	err = s.repo.Save(ctx, d)
+	if err != nil {
+		return domain.Deletion{}, err
+	}
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *deletionService) Cancel(ctx context.Context, uid int64) error {
+	ok, err := s.repo.UpdateStatus(ctx, uid, domain.DeletionStatusPending, domain.DeletionStatusCancelled)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w, uid %d", ErrDeletionNotFound, uid)
+	}
+	return nil
+}
+
+func (s *deletionService) Status(ctx context.Context, uid int64) (domain.Deletion, error) {
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *deletionService) ExecuteDue(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	ds, err := s.repo.FindDue(ctx, now.UnixMilli(), limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range ds {
+		err = s.execute(ctx, d, now)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(ds), nil
+}
+
+func (s *deletionService) execute(ctx context.Context, d domain.Deletion, now time.Time) error {
+	if d.Status == domain.DeletionStatusExecuting {
+		missing, err := s.missingModules(ctx, d.Uid)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			// 确认都到了，但是当时没能改状态
+			_, err = s.repo.UpdateStatus(ctx, d.Uid, domain.DeletionStatusExecuting, domain.DeletionStatusDone)
+			return err
+		}
+		if d.Attempts >= s.cfg.MaxAttempts {
+			s.logger.Error("注销账号之后一直有模块没有清理完数据，需要人工处理",
+				elog.Int64("uid", d.Uid),
+				elog.Any("modules", missing))
+			_, err = s.repo.UpdateStatus(ctx, d.Uid, domain.DeletionStatusExecuting, domain.DeletionStatusFailed)
+			return err
+		}
+	}
+	// 先改状态再发消息，这样用户刚好在这个时候撤销的话就不会发消息。
+	// 发送失败也不用改回去，到了检查时间会重新发送
+	ok, err := s.repo.MarkExecuting(ctx, d.Uid, d.Status, now.Add(s.cfg.RetryInterval).UnixMilli())
+	if err != nil || !ok {
+		return err
+	}
+	// 重新通知的时候已经确认了的模块也会收到，各个模块清理数据都是可以重复执行的
+	err = s.producer.Produce(ctx, event.UserDeletionEvent{Uid: d.Uid})
+	if err != nil {
+		return fmt.Errorf("发送注销账号事件失败 %w, uid %d", err, d.Uid)
+	}
+	return nil
+}
+
+func (s *deletionService) Confirm(ctx context.Context, uid int64, module string) error {
+	err := s.repo.Confirm(ctx, uid, module)
+	if err != nil {
+		return err
+	}
+	missing, err := s.missingModules(ctx, uid)
+	if err != nil || len(missing) > 0 {
+		return err
+	}
+	// 人工处理之后，迟到的确认也可以让失败的申请完成
+	_, err = s.repo.UpdateStatus(ctx, uid, domain.DeletionStatusExecuting, domain.DeletionStatusDone)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.UpdateStatus(ctx, uid, domain.DeletionStatusFailed, domain.DeletionStatusDone)
+	return err
+}
+
+// missingModules 还没有确认清理完数据的模块
+func (s *deletionService) missingModules(ctx context.Context, uid int64) ([]string, error) {
+	confirmed, err := s.repo.FindConfirmedModules(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.FilterDelete(slices.Clone(s.cfg.Modules), func(idx int, src string) bool {
+		return slices.Contains(confirmed, src)
+	}), nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+var (
+	ErrExportNotFound = errors.New("导出任务不存在")
+	ErrExportNotReady = errors.New("导出任务还没有完成")
+	ErrExportExpired  = errors.New("导出的数据已经失效")
+)
+
+type ExportService interface {
+	// Create 发起导出，已经有待处理的任务的时候直接返回那个任务
+	Create(ctx context.Context, uid int64) (int64, error)
+	List(ctx context.Context, uid int64) ([]domain.ExportTask, error)
+	// Download 只有自己的、已经完成并且没有过期的任务才能下载，
+	// 下载一次之后就删掉压缩包，需要的话重新发起
+	Download(ctx context.Context, uid, id int64) (domain.ExportTask, error)
+	// ProcessPending 处理一批待处理的任务，返回处理了多少个。
+	// 某个任务失败只会把任务标记为失败，不会中断后面的任务
+	ProcessPending(ctx context.Context, limit int) (int, error)
+	// CleanExpired 删掉一批已经过期的压缩包，返回处理了多少个
+	CleanExpired(ctx context.Context, limit int) (int, error)
+	// DeleteUserData 注销账号的时候删掉用户的所有导出任务
+	DeleteUserData(ctx context.Context, uid int64) error
+}
+
+// ExportConfig 导出个人数据的配置
+type ExportConfig struct {
+	// TTL 导出完成之后压缩包保留多久
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type exportService struct {
+	repo    repository.ExportRepository
+	sources []exportx.Source
+	cfg     ExportConfig
+	logger  *elog.Component
+}
+
+func NewExportService(repo repository.ExportRepository,
+	sources []exportx.Source,
+	cfg ExportConfig) ExportService {
+	return &exportService{
+		repo:    repo,
+		sources: sources,
+		cfg:     cfg,
+		logger:  elog.DefaultLogger,
+	}
+}
+
+func (s *exportService) Create(ctx context.Context, uid int64) (int64, error) {
+	ts, err := s.repo.FindByUid(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range ts {
+		if t.Status == domain.ExportStatusPending {
+			return t.ID, nil
+		}
+	}
+	return s.repo.Create(ctx, uid)
+}
+
+func (s *exportService) List(ctx context.Context, uid int64) ([]domain.ExportTask, error) {
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *exportService) Download(ctx context.Context, uid, id int64) (domain.ExportTask, error) {
+	t, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return domain.ExportTask{}, err
+	}
+	// 别人的任务也当作不存在
+	if t.Uid != uid {
+		return domain.ExportTask{}, fmt.Errorf("%w, id %d, uid %d", ErrExportNotFound, id, uid)
+	}
+	// 清理过期压缩包的定时任务可能还没跑到，这里也要检查过期时间
+	if t.Status == domain.ExportStatusExpired ||
+		t.Status == domain.ExportStatusSuccess && t.ExpireAt <= time.Now().UnixMilli() {
+		return domain.ExportTask{}, fmt.Errorf("%w, id %d", ErrExportExpired, id)
+	}
+	if t.Status != domain.ExportStatusSuccess {
+		return domain.ExportTask{}, fmt.Errorf("%w, id %d", ErrExportNotReady, id)
+	}
+	err = s.repo.Invalidate(ctx, id)
+	if err != nil {
+		return domain.ExportTask{}, err
+	}
+	return t, nil
+}
+
+func (s *exportService) CleanExpired(ctx context.Context, limit int) (int, error) {
+	return s.repo.InvalidateExpired(ctx, time.Now().UnixMilli(), limit)
+}
+
+func (s *exportService) DeleteUserData(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
+
+func (s *exportService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	ts, err := s.repo.FindPending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range ts {
+		status := domain.ExportStatusSuccess
+		expireAt := time.Now().Add(s.cfg.TTL).UnixMilli()
+		archive, err1 := s.archive(ctx, t.Uid)
+		if err1 != nil {
+			status, archive, expireAt = domain.ExportStatusFailed, nil, 0
+			s.logger.Error("导出个人数据失败", elog.FieldErr(err1),
+				elog.Int64("id", t.ID), elog.Int64("uid", t.Uid))
+		}
+		err = s.repo.UpdateResult(ctx, t.ID, status, archive, expireAt)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(ts), nil
+}
+
+// archive 每个模块一个 JSON 文件，打成一个 zip 包
+func (s *exportService) archive(ctx context.Context, uid int64) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, src := range s.sources {
+		data, err := src.Export(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("%w, source %s", err, src.Name())
+		}
+		val, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.Create(src.Name() + ".json")
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := zw.Close()
+	return buf.Bytes(), err
+}
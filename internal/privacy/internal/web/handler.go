@@ -0,0 +1,132 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/privacy/internal/domain"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+var _ ginx.Handler = &Handler{}
+
+type Handler struct {
+	exportSvc   service.ExportService
+	deletionSvc service.DeletionService
+}
+
+func NewHandler(exportSvc service.ExportService, deletionSvc service.DeletionService) *Handler {
+	return &Handler{
+		exportSvc:   exportSvc,
+		deletionSvc: deletionSvc,
+	}
+}
+
+func (h *Handler) PublicRoutes(server *gin.Engine) {}
+
+func (h *Handler) PrivateRoutes(server *gin.Engine) {
+	g := server.Group("/privacy")
+	g.POST("/export/create", ginx.S(h.CreateExport))
+	g.POST("/export/list", ginx.S(h.ListExports))
+	// 直接返回压缩包，所以用 GET，id 放在查询参数里面
+	g.GET("/export/download", ginx.S(h.Download))
+	g.POST("/deletion/request", ginx.S(h.RequestDeletion))
+	g.POST("/deletion/cancel", ginx.S(h.CancelDeletion))
+	g.POST("/deletion/status", ginx.S(h.DeletionStatus))
+}
+
+func (h *Handler) CreateExport(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	id, err := h.exportSvc.Create(ctx, sess.Claims().Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: id}, nil
+}
+
+func (h *Handler) ListExports(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	ts, err := h.exportSvc.List(ctx, sess.Claims().Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: slice.Map(ts, func(idx int, src domain.ExportTask) ExportTask {
+			return newExportTask(src)
+		}),
+	}, nil
+}
+
+func (h *Handler) Download(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	id, err := ctx.Query("id").AsInt64()
+	if err != nil {
+		return exportNotFoundResult, nil
+	}
+	t, err := h.exportSvc.Download(ctx, sess.Claims().Uid, id)
+	if err != nil {
+		return h.errorResult(err)
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="webook-export-%d.zip"`, t.ID))
+	ctx.Data(http.StatusOK, "application/zip", t.Archive)
+	return ginx.Result{}, ginx.ErrNoResponse
+}
+
+func (h *Handler) RequestDeletion(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	d, err := h.deletionSvc.Request(ctx, sess.Claims().Uid)
+	if err != nil {
+		return h.errorResult(err)
+	}
+	return ginx.Result{Data: newDeletion(d)}, nil
+}
+
+func (h *Handler) CancelDeletion(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	err := h.deletionSvc.Cancel(ctx, sess.Claims().Uid)
+	if err != nil {
+		return h.errorResult(err)
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *Handler) DeletionStatus(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	d, err := h.deletionSvc.Status(ctx, sess.Claims().Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: newDeletion(d)}, nil
+}
+
+// errorResult 业务上的错误用错误码告诉前端，不需要返回 error
+func (h *Handler) errorResult(err error) (ginx.Result, error) {
+	switch {
+	case errors.Is(err, service.ErrExportNotFound), errors.Is(err, dao.ErrRecordNotFound):
+		return exportNotFoundResult, nil
+	case errors.Is(err, service.ErrExportNotReady):
+		return exportNotReadyResult, nil
+	case errors.Is(err, service.ErrExportExpired):
+		return exportExpiredResult, nil
+	case errors.Is(err, service.ErrDeletionNotFound):
+		return deletionNotFoundResult, nil
+	case errors.Is(err, service.ErrAccountDeleted):
+		return accountDeletedResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import "github.com/ecodeclub/webook/internal/privacy/internal/domain"
+
+type ExportTask struct {
+	Id int64 `json:"id"`
+	// Status 1-处理中 2-可以下载 3-失败 4-已失效
+	Status uint8 `json:"status"`
+	// ExpireAt 可以下载的时候才有，过了这个时间就不能下载了
+	ExpireAt int64 `json:"expireAt"`
+	Ctime    int64 `json:"ctime"`
+	Utime    int64 `json:"utime"`
+}
+
+func newExportTask(t domain.ExportTask) ExportTask {
+	return ExportTask{
+		Id:       t.ID,
+		Status:   t.Status.ToUint8(),
+		ExpireAt: t.ExpireAt,
+		Ctime:    t.Ctime,
+		Utime:    t.Utime,
+	}
+}
+
+type Deletion struct {
+	// Status 0-没有申请过 1-冷静期 2-已撤销 3-已注销 4-注销中 5-注销失败
+	Status    uint8 `json:"status"`
+	ExecuteAt int64 `json:"executeAt"`
+	Ctime     int64 `json:"ctime"`
+}
+
+func newDeletion(d domain.Deletion) Deletion {
+	return Deletion{
+		Status:    d.Status.ToUint8(),
+		ExecuteAt: d.ExecuteAt,
+		Ctime:     d.Ctime,
+	}
+}
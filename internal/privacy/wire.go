@@ -0,0 +1,123 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wireinject
+
+package privacy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event"
+	"github.com/ecodeclub/webook/internal/privacy/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/privacy/internal/job"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository"
+	"github.com/ecodeclub/webook/internal/privacy/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/privacy/internal/service"
+	"github.com/ecodeclub/webook/internal/privacy/internal/web"
+	"github.com/ego-component/egorm"
+	"github.com/google/wire"
+	"github.com/gotomicro/ego/core/econf"
+)
+
+func InitModule(db *egorm.Component, q mq.MQ, sources []exportx.Source) *Module {
+	wire.Build(
+		InitTablesOnce,
+		dao.NewGORMDeletionDAO,
+		repository.NewExportRepository,
+		repository.NewDeletionRepository,
+		initUserDeletionEventProducer,
+		initExportService,
+		initDeletionService,
+		web.NewHandler,
+		initExportJob,
+		initCleanExportJob,
+		initAccountDeletionJob,
+		initUserDeletionConsumer,
+		initUserDeletionDoneConsumer,
+		wire.Struct(new(Module), "*"),
+	)
+	return new(Module)
+}
+
+var once = &sync.Once{}
+
+func InitTablesOnce(db *egorm.Component) dao.ExportDAO {
+	once.Do(func() {
+		_ = dao.InitTables(db)
+	})
+	return dao.NewGORMExportDAO(db)
+}
+
+func initUserDeletionEventProducer(q mq.MQ) event.UserDeletionEventProducer {
+	producer, err := event.NewUserDeletionEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
+
+func initDeletionService(repo repository.DeletionRepository,
+	producer event.UserDeletionEventProducer) service.DeletionService {
+	var cfg service.DeletionConfig
+	err := econf.UnmarshalKey("privacy.deletion", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewDeletionService(repo, producer, cfg)
+}
+
+func initExportService(repo repository.ExportRepository, sources []exportx.Source) service.ExportService {
+	var cfg service.ExportConfig
+	err := econf.UnmarshalKey("privacy.export", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewExportService(repo, sources, cfg)
+}
+
+func initUserDeletionConsumer(exportSvc service.ExportService,
+	deletionSvc service.DeletionService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(exportSvc, deletionSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionDoneConsumer(svc service.DeletionService, q mq.MQ) *consumer.UserDeletionDoneConsumer {
+	c, err := consumer.NewUserDeletionDoneConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+// initExportJob 压缩包比较大，一批少处理一点
+func initExportJob(svc service.ExportService) *job.ExportJob {
+	return job.NewExportJob(svc, 10)
+}
+
+func initCleanExportJob(svc service.ExportService) *job.CleanExportJob {
+	return job.NewCleanExportJob(svc, 100)
+}
+
+func initAccountDeletionJob(svc service.DeletionService) *job.AccountDeletionJob {
+	return job.NewAccountDeletionJob(svc, 100)
+}
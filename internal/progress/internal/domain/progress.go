@@ -0,0 +1,89 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+const (
+	BizQuestion    = "question"
+	BizCase        = "case"
+	BizQuestionSet = "questionSet"
+	BizCaseSet     = "caseSet"
+	BizSkillLevel  = "skillLevel"
+)
+
+// 测试结果的等级，题目和案例使用同一套等级
+// 技能等级里面题目和案例会按照这套等级一起统计
+const (
+	ResultFailed uint8 = iota
+	ResultBasic
+	ResultIntermediate
+	ResultAdvanced
+)
+
+// Record 用户在某道题目或者某个案例上最新的测试结果
+type Record struct {
+	Uid    int64
+	Biz    string
+	BizId  int64
+	Result uint8
+}
+
+// Progress 用户在某个题集、案例集或者技能等级上的进度
+// 技能等级同时关联题目和案例，所以它的进度里面题目和案例都会计入
+// Biz 为 question 或者 case 的时候，BizId 为 0，代表的是全部题目或者全部案例
+type Progress struct {
+	Uid   int64
+	Biz   string
+	BizId int64
+	// 达到对应等级的数量，达到高等级的同时也会计入低等级
+	// 例如一道题目达到了 ResultIntermediate，那么 BasicCnt 和 IntermediateCnt 都会计入
+	BasicCnt        int64
+	IntermediateCnt int64
+	AdvancedCnt     int64
+	Utime           time.Time
+}
+
+// Ref 测试结果需要计入的统计
+type Ref struct {
+	Biz   string
+	BizId int64
+}
+
+// LevelDelta 测试结果变化之后，各个等级数量的变化
+type LevelDelta struct {
+	Basic        int64
+	Intermediate int64
+	Advanced     int64
+}
+
+func NewLevelDelta(oldRes, newRes uint8) LevelDelta {
+	return LevelDelta{
+		Basic:        reached(newRes, ResultBasic) - reached(oldRes, ResultBasic),
+		Intermediate: reached(newRes, ResultIntermediate) - reached(oldRes, ResultIntermediate),
+		Advanced:     reached(newRes, ResultAdvanced) - reached(oldRes, ResultAdvanced),
+	}
+}
+
+func (d LevelDelta) IsZero() bool {
+	return d.Basic == 0 && d.Intermediate == 0 && d.Advanced == 0
+}
+
+func reached(res, level uint8) int64 {
+	if res >= level {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLevelDelta(t *testing.T) {
+	testCases := []struct {
+		name   string
+		oldRes uint8
+		newRes uint8
+		want   LevelDelta
+	}{
+		{
+			name:   "第一次通过",
+			oldRes: ResultFailed,
+			newRes: ResultBasic,
+			want:   LevelDelta{Basic: 1},
+		},
+		{
+			name:   "直接达到最高等级",
+			oldRes: ResultFailed,
+			newRes: ResultAdvanced,
+			want:   LevelDelta{Basic: 1, Intermediate: 1, Advanced: 1},
+		},
+		{
+			name:   "等级提升",
+			oldRes: ResultBasic,
+			newRes: ResultIntermediate,
+			want:   LevelDelta{Intermediate: 1},
+		},
+		{
+			name:   "等级下降",
+			oldRes: ResultAdvanced,
+			newRes: ResultBasic,
+			want:   LevelDelta{Intermediate: -1, Advanced: -1},
+		},
+		{
+			name:   "没有变化",
+			oldRes: ResultIntermediate,
+			newRes: ResultIntermediate,
+			want:   LevelDelta{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewLevelDelta(tc.oldRes, tc.newRes)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.oldRes == tc.newRes, got.IsZero())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ecodeclub/webook/internal/progress/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+const examineTopic = "examine_events"
+
+// ExamineEvent 由 question 和 cases 模块在测试结果变化的时候发送
+type ExamineEvent struct {
+	Uid    int64  `json:"uid,omitempty"`
+	Biz    string `json:"biz,omitempty"`
+	BizId  int64  `json:"bizId,omitempty"`
+	Result uint8  `json:"result,omitempty"`
+}
+
+type ExamineConsumer struct {
+	consumer mq.Consumer
+	svc      service.Service
+	logger   *elog.Component
+}
+
+func NewExamineConsumer(svc service.Service, q mq.MQ) (*ExamineConsumer, error) {
+	const groupID = "progress"
+	consumer, err := q.Consumer(examineTopic, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &ExamineConsumer{
+		consumer: consumer,
+		svc:      svc,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *ExamineConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt ExamineEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.svc.Save(ctx, domain.Record{
+		Uid:    evt.Uid,
+		Biz:    evt.Biz,
+		BizId:  evt.BizId,
+		Result: evt.Result,
+	})
+	if err != nil {
+		c.logger.Error("更新学习进度失败", elog.Any("examine_event", evt))
+	}
+	return err
+}
+
+func (c *ExamineConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费测试结果事件失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
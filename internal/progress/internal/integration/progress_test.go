@@ -0,0 +1,237 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/cases"
+	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/ecodeclub/webook/internal/progress"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ecodeclub/webook/internal/progress/internal/event"
+	"github.com/ecodeclub/webook/internal/progress/internal/integration/startup"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	quemocks "github.com/ecodeclub/webook/internal/question/mocks"
+	"github.com/ecodeclub/webook/internal/skill"
+	skillmocks "github.com/ecodeclub/webook/internal/skill/mocks"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/ego-component/egorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ProgressTestSuite struct {
+	suite.Suite
+	db  *egorm.Component
+	svc progress.Service
+}
+
+func (s *ProgressTestSuite) SetupSuite() {
+	ctrl := gomock.NewController(s.T())
+	queSetSvc := quemocks.NewMockQuestionSetService(ctrl)
+	// 题目 1 在题集 1 和 2 里面，其余的题目不在任何题集里面
+	queSetSvc.EXPECT().GetIDsByQid(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, qid int64) ([]int64, error) {
+			if qid == 1 {
+				return []int64{1, 2}, nil
+			}
+			return nil, nil
+		}).AnyTimes()
+	caseSetSvc := casemocks.NewMockCaseSetService(ctrl)
+	caseSetSvc.EXPECT().GetIDsByCid(gomock.Any(), gomock.Any()).
+		Return([]int64{3}, nil).AnyTimes()
+	skillSvc := skillmocks.NewMockSkillService(ctrl)
+	// 题目 1 和案例 1 都在技能等级 10 里面
+	skillSvc.EXPECT().LevelIDsByRef(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, rtype string, rid int64) ([]int64, error) {
+			if rid == 1 && (rtype == skill.RefTypeQuestion || rtype == skill.RefTypeCase) {
+				return []int64{10}, nil
+			}
+			return nil, nil
+		}).AnyTimes()
+
+	module, err := startup.InitModule(&baguwen.Module{SetSvc: queSetSvc},
+		&cases.Module{SetSvc: caseSetSvc}, &skill.Module{Svc: skillSvc})
+	require.NoError(s.T(), err)
+	s.svc = module.Svc
+	s.db = testioc.InitDB()
+}
+
+func (s *ProgressTestSuite) TearDownTest() {
+	err := s.db.Exec("TRUNCATE TABLE `progress_records`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `progress`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *ProgressTestSuite) TestSave() {
+	t := s.T()
+	const uid = 123
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	records := []domain.Record{
+		// 题目 1 第一次就达到了 intermediate
+		{Uid: uid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultIntermediate},
+		// 重复的结果不会重复计数
+		{Uid: uid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultIntermediate},
+		// 题目 2 先 advanced 后来被修正为 basic
+		{Uid: uid, Biz: domain.BizQuestion, BizId: 2, Result: domain.ResultAdvanced},
+		{Uid: uid, Biz: domain.BizQuestion, BizId: 2, Result: domain.ResultBasic},
+		// 没通过不计数
+		{Uid: uid, Biz: domain.BizQuestion, BizId: 3, Result: domain.ResultFailed},
+		{Uid: uid, Biz: domain.BizCase, BizId: 1, Result: domain.ResultBasic},
+		// 别的用户
+		{Uid: uid + 1, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultAdvanced},
+	}
+	for _, r := range records {
+		err := s.svc.Save(ctx, r)
+		require.NoError(t, err)
+	}
+
+	res, err := s.svc.List(ctx, uid)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []progress.Progress{
+		{Uid: uid, Biz: domain.BizQuestion, BasicCnt: 2, IntermediateCnt: 1},
+		{Uid: uid, Biz: domain.BizQuestionSet, BizId: 1, BasicCnt: 1, IntermediateCnt: 1},
+		{Uid: uid, Biz: domain.BizQuestionSet, BizId: 2, BasicCnt: 1, IntermediateCnt: 1},
+		{Uid: uid, Biz: domain.BizCase, BasicCnt: 1},
+		{Uid: uid, Biz: domain.BizCaseSet, BizId: 3, BasicCnt: 1},
+		// 题目和案例都会计入技能等级
+		{Uid: uid, Biz: domain.BizSkillLevel, BizId: 10, BasicCnt: 2, IntermediateCnt: 1},
+	}, s.withoutUtime(res))
+
+	// 题目 1 后面没通过
+	err = s.svc.Save(ctx, domain.Record{Uid: uid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultFailed})
+	require.NoError(t, err)
+	res, err = s.svc.List(ctx, uid)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []progress.Progress{
+		{Uid: uid, Biz: domain.BizQuestion, BasicCnt: 1},
+		{Uid: uid, Biz: domain.BizQuestionSet, BizId: 1},
+		{Uid: uid, Biz: domain.BizQuestionSet, BizId: 2},
+		{Uid: uid, Biz: domain.BizCase, BasicCnt: 1},
+		{Uid: uid, Biz: domain.BizCaseSet, BizId: 3, BasicCnt: 1},
+		{Uid: uid, Biz: domain.BizSkillLevel, BizId: 10, BasicCnt: 1},
+	}, s.withoutUtime(res))
+}
+
+func (s *ProgressTestSuite) TestConsume() {
+	t := s.T()
+	const uid = 456
+	producer, err := mqx.NewGeneralProducer[event.ExamineEvent](testioc.InitMQ(), "examine_events")
+	require.NoError(t, err)
+	err = producer.Produce(context.Background(), event.ExamineEvent{
+		Uid:    uid,
+		Biz:    domain.BizQuestion,
+		BizId:  1,
+		Result: domain.ResultAdvanced,
+	})
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := s.svc.List(context.Background(), uid)
+		require.NoError(c, err)
+		assert.ElementsMatch(c, []progress.Progress{
+			{Uid: uid, Biz: domain.BizQuestion, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+			{Uid: uid, Biz: domain.BizQuestionSet, BizId: 1, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+			{Uid: uid, Biz: domain.BizQuestionSet, BizId: 2, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+			{Uid: uid, Biz: domain.BizSkillLevel, BizId: 10, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+		}, s.withoutUtime(res))
+	}, time.Second*5, time.Millisecond*100)
+}
+
+func (s *ProgressTestSuite) TestMerge() {
+	t := s.T()
+	const sourceUid, targetUid = 789, 790
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	records := []domain.Record{
+		// 两边都测试过题目 1，源账号的结果更好
+		{Uid: sourceUid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultAdvanced},
+		{Uid: targetUid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultBasic},
+		// 两边都测试过案例 1，目标账号的结果更好
+		{Uid: sourceUid, Biz: domain.BizCase, BizId: 1, Result: domain.ResultBasic},
+		{Uid: targetUid, Biz: domain.BizCase, BizId: 1, Result: domain.ResultIntermediate},
+		// 只有源账号测试过题目 2
+		{Uid: sourceUid, Biz: domain.BizQuestion, BizId: 2, Result: domain.ResultBasic},
+	}
+	for _, r := range records {
+		err := s.svc.Save(ctx, r)
+		require.NoError(t, err)
+	}
+
+	err := s.svc.Merge(ctx, sourceUid, targetUid)
+	require.NoError(t, err)
+	// 重复合并没有影响
+	err = s.svc.Merge(ctx, sourceUid, targetUid)
+	require.NoError(t, err)
+
+	res, err := s.svc.List(ctx, sourceUid)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	res, err = s.svc.List(ctx, targetUid)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []progress.Progress{
+		{Uid: targetUid, Biz: domain.BizQuestion, BasicCnt: 2, IntermediateCnt: 1, AdvancedCnt: 1},
+		{Uid: targetUid, Biz: domain.BizQuestionSet, BizId: 1, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+		{Uid: targetUid, Biz: domain.BizQuestionSet, BizId: 2, BasicCnt: 1, IntermediateCnt: 1, AdvancedCnt: 1},
+		{Uid: targetUid, Biz: domain.BizCase, BasicCnt: 1, IntermediateCnt: 1},
+		{Uid: targetUid, Biz: domain.BizCaseSet, BizId: 3, BasicCnt: 1, IntermediateCnt: 1},
+		{Uid: targetUid, Biz: domain.BizSkillLevel, BizId: 10, BasicCnt: 2, IntermediateCnt: 2, AdvancedCnt: 1},
+	}, s.withoutUtime(res))
+}
+
+func (s *ProgressTestSuite) TestDeleteUserData() {
+	t := s.T()
+	const deletedUid = 791
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	err := s.svc.Save(ctx, domain.Record{Uid: deletedUid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultBasic})
+	require.NoError(t, err)
+	records, err := s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Record{
+		{Uid: deletedUid, Biz: domain.BizQuestion, BizId: 1, Result: domain.ResultBasic},
+	}, records)
+
+	err = s.svc.DeleteUserData(ctx, deletedUid)
+	require.NoError(t, err)
+	res, err := s.svc.List(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	records, err = s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func (s *ProgressTestSuite) withoutUtime(ps []progress.Progress) []progress.Progress {
+	for i := range ps {
+		assert.True(s.T(), ps[i].Utime.UnixMilli() > 0)
+		ps[i].Utime = time.Time{}
+	}
+	return ps
+}
+
+func TestProgress(t *testing.T) {
+	suite.Run(t, new(ProgressTestSuite))
+}
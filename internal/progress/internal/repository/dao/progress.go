@@ -0,0 +1,147 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProgressDAO interface {
+	// SaveRecord 保存最新的测试结果，并且根据结果的变化更新 refs 对应的统计数据
+	// 结果没有变化的时候什么也不做
+	SaveRecord(ctx context.Context, r ProgressRecord, refs []domain.Ref) error
+	// MergeRecord 和 SaveRecord 一样，但是只有结果比原来的好的时候才会更新
+	MergeRecord(ctx context.Context, r ProgressRecord, refs []domain.Ref) error
+	FindByUid(ctx context.Context, uid int64) ([]Progress, error)
+	FindRecordsByUid(ctx context.Context, uid int64) ([]ProgressRecord, error)
+	// DeleteByUid 删除用户全部的测试结果和统计数据
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+var _ ProgressDAO = &GORMProgressDAO{}
+
+type GORMProgressDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMProgressDAO(db *egorm.Component) ProgressDAO {
+	return &GORMProgressDAO{db: db}
+}
+
+func (dao *GORMProgressDAO) SaveRecord(ctx context.Context, r ProgressRecord, refs []domain.Ref) error {
+	return dao.save(ctx, r, refs, false)
+}
+
+func (dao *GORMProgressDAO) MergeRecord(ctx context.Context, r ProgressRecord, refs []domain.Ref) error {
+	return dao.save(ctx, r, refs, true)
+}
+
+// save onlyBetter 为 true 的时候，新结果不比原来的好就什么也不做
+func (dao *GORMProgressDAO) save(ctx context.Context, r ProgressRecord, refs []domain.Ref, onlyBetter bool) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先确保记录存在，没有测试过和没通过是一样的，所以初始结果是 ResultFailed
+		// 这样后面就可以用行锁保证并发更新同一条记录的时候，统计数据不会算错
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ProgressRecord{
+			Uid:    r.Uid,
+			Biz:    r.Biz,
+			BizId:  r.BizId,
+			Result: domain.ResultFailed,
+			Ctime:  now,
+			Utime:  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		var old ProgressRecord
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND biz = ? AND biz_id = ?", r.Uid, r.Biz, r.BizId).
+			First(&old).Error
+		if err != nil {
+			return err
+		}
+		if onlyBetter && r.Result <= old.Result {
+			return nil
+		}
+		delta := domain.NewLevelDelta(old.Result, r.Result)
+		if delta.IsZero() {
+			return nil
+		}
+		err = tx.Model(&ProgressRecord{}).Where("id = ?", old.Id).
+			Updates(map[string]any{
+				"result": r.Result,
+				"utime":  now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			err = dao.incr(tx, r.Uid, ref, delta, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *GORMProgressDAO) incr(tx *gorm.DB, uid int64, ref domain.Ref, delta domain.LevelDelta, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"basic_cnt":        gorm.Expr("GREATEST(`basic_cnt` + ?, 0)", delta.Basic),
+			"intermediate_cnt": gorm.Expr("GREATEST(`intermediate_cnt` + ?, 0)", delta.Intermediate),
+			"advanced_cnt":     gorm.Expr("GREATEST(`advanced_cnt` + ?, 0)", delta.Advanced),
+			"utime":            now,
+		}),
+	}).Create(&Progress{
+		Uid:             uid,
+		Biz:             ref.Biz,
+		BizId:           ref.BizId,
+		BasicCnt:        max(delta.Basic, 0),
+		IntermediateCnt: max(delta.Intermediate, 0),
+		AdvancedCnt:     max(delta.Advanced, 0),
+		Ctime:           now,
+		Utime:           now,
+	}).Error
+}
+
+func (dao *GORMProgressDAO) FindByUid(ctx context.Context, uid int64) ([]Progress, error) {
+	var res []Progress
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("utime DESC").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMProgressDAO) FindRecordsByUid(ctx context.Context, uid int64) ([]ProgressRecord, error) {
+	var res []ProgressRecord
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMProgressDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("uid = ?", uid).Delete(&ProgressRecord{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&Progress{}).Error
+	})
+}
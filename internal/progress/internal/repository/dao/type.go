@@ -0,0 +1,44 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+// ProgressRecord 用户在某道题目或者某个案例上最新的测试结果
+// 用来计算结果变化之后，统计数据应该怎么变
+type ProgressRecord struct {
+	Id     int64  `gorm:"primaryKey,autoIncrement"`
+	Uid    int64  `gorm:"uniqueIndex:uid_biz_bizId"`
+	Biz    string `gorm:"type:varchar(64);uniqueIndex:uid_biz_bizId"`
+	BizId  int64  `gorm:"uniqueIndex:uid_biz_bizId"`
+	Result uint8
+	Ctime  int64
+	Utime  int64
+}
+
+// Progress 用户在某个题集、案例集上的统计数据
+type Progress struct {
+	Id              int64  `gorm:"primaryKey,autoIncrement"`
+	Uid             int64  `gorm:"uniqueIndex:uid_biz_bizId"`
+	Biz             string `gorm:"type:varchar(64);uniqueIndex:uid_biz_bizId"`
+	BizId           int64  `gorm:"uniqueIndex:uid_biz_bizId"`
+	BasicCnt        int64
+	IntermediateCnt int64
+	AdvancedCnt     int64
+	Ctime           int64
+	Utime           int64
+}
+
+func (Progress) TableName() string {
+	return "progress"
+}
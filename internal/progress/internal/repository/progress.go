@@ -0,0 +1,95 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ecodeclub/webook/internal/progress/internal/repository/dao"
+)
+
+type ProgressRepository interface {
+	SaveRecord(ctx context.Context, r domain.Record, refs []domain.Ref) error
+	MergeRecord(ctx context.Context, r domain.Record, refs []domain.Ref) error
+	FindByUid(ctx context.Context, uid int64) ([]domain.Progress, error)
+	FindRecordsByUid(ctx context.Context, uid int64) ([]domain.Record, error)
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+var _ ProgressRepository = &progressRepository{}
+
+type progressRepository struct {
+	dao dao.ProgressDAO
+}
+
+func NewProgressRepository(d dao.ProgressDAO) ProgressRepository {
+	return &progressRepository{dao: d}
+}
+
+func (repo *progressRepository) SaveRecord(ctx context.Context, r domain.Record, refs []domain.Ref) error {
+	return repo.dao.SaveRecord(ctx, dao.ProgressRecord{
+		Uid:    r.Uid,
+		Biz:    r.Biz,
+		BizId:  r.BizId,
+		Result: r.Result,
+	}, refs)
+}
+
+func (repo *progressRepository) MergeRecord(ctx context.Context, r domain.Record, refs []domain.Ref) error {
+	return repo.dao.MergeRecord(ctx, dao.ProgressRecord{
+		Uid:    r.Uid,
+		Biz:    r.Biz,
+		BizId:  r.BizId,
+		Result: r.Result,
+	}, refs)
+}
+
+func (repo *progressRepository) FindRecordsByUid(ctx context.Context, uid int64) ([]domain.Record, error) {
+	res, err := repo.dao.FindRecordsByUid(ctx, uid)
+	return slice.Map(res, func(idx int, src dao.ProgressRecord) domain.Record {
+		return domain.Record{
+			Uid:    src.Uid,
+			Biz:    src.Biz,
+			BizId:  src.BizId,
+			Result: src.Result,
+		}
+	}), err
+}
+
+func (repo *progressRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return repo.dao.DeleteByUid(ctx, uid)
+}
+
+func (repo *progressRepository) FindByUid(ctx context.Context, uid int64) ([]domain.Progress, error) {
+	res, err := repo.dao.FindByUid(ctx, uid)
+	return slice.Map(res, func(idx int, src dao.Progress) domain.Progress {
+		return repo.toDomain(src)
+	}), err
+}
+
+func (repo *progressRepository) toDomain(p dao.Progress) domain.Progress {
+	return domain.Progress{
+		Uid:             p.Uid,
+		Biz:             p.Biz,
+		BizId:           p.BizId,
+		BasicCnt:        p.BasicCnt,
+		IntermediateCnt: p.IntermediateCnt,
+		AdvancedCnt:     p.AdvancedCnt,
+		Utime:           time.UnixMilli(p.Utime),
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+)
+
+type exportData struct {
+	Progresses []domain.Progress
+	Records    []domain.Record
+}
+
+// exportSource 导出个人数据的时候读取测试结果和进度
+type exportSource struct {
+	svc Service
+}
+
+func NewExportSource(svc Service) exportx.Source {
+	return &exportSource{svc: svc}
+}
+
+func (s *exportSource) Name() string {
+	return "progress"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	progresses, err := s.svc.List(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.svc.Records(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return exportData{Progresses: progresses, Records: records}, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ecodeclub/webook/internal/progress/internal/repository"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
+)
+
+// Service 用户的学习进度
+//
+//go:generate mockgen -source=./progress.go -destination=../../mocks/progress.mock.go -package=progressmocks -typed=true Service
+type Service interface {
+	// Save 保存最新的测试结果
+	// 题目会计入全部题目和包含它的题集，案例会计入全部案例和包含它的案例集
+	// 两者都会计入关联了它们的技能等级
+	Save(ctx context.Context, r domain.Record) error
+	// List 用户全部的进度
+	List(ctx context.Context, uid int64) ([]domain.Progress, error)
+	// Merge 把源账号的测试结果合并到目标账号上，两边都测试过的保留更好的结果，
+	// 统计数据按照目标账号结果的变化重新计入，之后删除源账号的数据
+	Merge(ctx context.Context, sourceUid, targetUid int64) error
+	// Records 用户全部的测试结果
+	Records(ctx context.Context, uid int64) ([]domain.Record, error)
+	// DeleteUserData 注销账号的时候删除用户的测试结果和进度
+	DeleteUserData(ctx context.Context, uid int64) error
+}
+
+var _ Service = &service{}
+
+type service struct {
+	repo       repository.ProgressRepository
+	queSetSvc  baguwen.QuestionSetService
+	caseSetSvc cases.SetService
+	skillSvc   skill.Service
+}
+
+func NewService(repo repository.ProgressRepository,
+	queSetSvc baguwen.QuestionSetService,
+	caseSetSvc cases.SetService,
+	skillSvc skill.Service) Service {
+	return &service{
+		repo:       repo,
+		queSetSvc:  queSetSvc,
+		caseSetSvc: caseSetSvc,
+		skillSvc:   skillSvc,
+	}
+}
+
+func (s *service) Save(ctx context.Context, r domain.Record) error {
+	refs, err := s.refs(ctx, r)
+	if err != nil {
+		return err
+	}
+	return s.repo.SaveRecord(ctx, r, refs)
+}
+
+// refs 题集、案例集和技能等级里面的内容是会变的，这里用的是保存测试结果时候的关系
+func (s *service) refs(ctx context.Context, r domain.Record) ([]domain.Ref, error) {
+	var (
+		setBiz  string
+		setIds  []int64
+		refType string
+		err     error
+	)
+	switch r.Biz {
+	case domain.BizQuestion:
+		setBiz = domain.BizQuestionSet
+		refType = skill.RefTypeQuestion
+		setIds, err = s.queSetSvc.GetIDsByQid(ctx, r.BizId)
+	case domain.BizCase:
+		setBiz = domain.BizCaseSet
+		refType = skill.RefTypeCase
+		setIds, err = s.caseSetSvc.GetIDsByCid(ctx, r.BizId)
+	default:
+		return nil, fmt.Errorf("未知的业务 %s", r.Biz)
+	}
+	if err != nil {
+		return nil, err
+	}
+	levelIds, err := s.skillSvc.LevelIDsByRef(ctx, refType, r.BizId)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]domain.Ref, 0, len(setIds)+len(levelIds)+1)
+	// BizId 为 0 代表全部
+	refs = append(refs, domain.Ref{Biz: r.Biz})
+	refs = append(refs, slice.Map(setIds, func(idx int, src int64) domain.Ref {
+		return domain.Ref{Biz: setBiz, BizId: src}
+	})...)
+	refs = append(refs, slice.Map(levelIds, func(idx int, src int64) domain.Ref {
+		return domain.Ref{Biz: domain.BizSkillLevel, BizId: src}
+	})...)
+	return refs, nil
+}
+
+func (s *service) List(ctx context.Context, uid int64) ([]domain.Progress, error) {
+	return s.repo.FindByUid(ctx, uid)
+}
+
+func (s *service) Records(ctx context.Context, uid int64) ([]domain.Record, error) {
+	return s.repo.FindRecordsByUid(ctx, uid)
+}
+
+func (s *service) DeleteUserData(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
+
+func (s *service) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	records, err := s.repo.FindRecordsByUid(ctx, sourceUid)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		r.Uid = targetUid
+		refs, err := s.refs(ctx, r)
+		if err != nil {
+			return err
+		}
+		// 重复合并的时候目标账号的结果已经不比源账号的差了，什么也不会做
+		err = s.repo.MergeRecord(ctx, r, refs)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.DeleteByUid(ctx, sourceUid)
+}
@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./progress.go -destination=../../mocks/progress.mock.go -package=progressmocks -typed=true Service
+//
+
+// Package progressmocks is a generated GoMock package.
+package progressmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/progress/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserData mocks base method.
+func (m *MockService) DeleteUserData(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserData", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserData indicates an expected call of DeleteUserData.
+func (mr *MockServiceMockRecorder) DeleteUserData(ctx, uid any) *MockServiceDeleteUserDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserData", reflect.TypeOf((*MockService)(nil).DeleteUserData), ctx, uid)
+	return &MockServiceDeleteUserDataCall{Call: call}
+}
+
+// MockServiceDeleteUserDataCall wrap *gomock.Call
+type MockServiceDeleteUserDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDeleteUserDataCall) Return(arg0 error) *MockServiceDeleteUserDataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDeleteUserDataCall) Do(f func(context.Context, int64) error) *MockServiceDeleteUserDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDeleteUserDataCall) DoAndReturn(f func(context.Context, int64) error) *MockServiceDeleteUserDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, uid int64) ([]domain.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid)
+	ret0, _ := ret[0].([]domain.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, uid any) *MockServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, uid)
+	return &MockServiceListCall{Call: call}
+}
+
+// MockServiceListCall wrap *gomock.Call
+type MockServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceListCall) Return(arg0 []domain.Progress, arg1 error) *MockServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceListCall) Do(f func(context.Context, int64) ([]domain.Progress, error)) *MockServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceListCall) DoAndReturn(f func(context.Context, int64) ([]domain.Progress, error)) *MockServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Merge mocks base method.
+func (m *MockService) Merge(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockServiceMockRecorder) Merge(ctx, sourceUid, targetUid any) *MockServiceMergeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockService)(nil).Merge), ctx, sourceUid, targetUid)
+	return &MockServiceMergeCall{Call: call}
+}
+
+// MockServiceMergeCall wrap *gomock.Call
+type MockServiceMergeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceMergeCall) Return(arg0 error) *MockServiceMergeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceMergeCall) Do(f func(context.Context, int64, int64) error) *MockServiceMergeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceMergeCall) DoAndReturn(f func(context.Context, int64, int64) error) *MockServiceMergeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Records mocks base method.
+func (m *MockService) Records(ctx context.Context, uid int64) ([]domain.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Records", ctx, uid)
+	ret0, _ := ret[0].([]domain.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Records indicates an expected call of Records.
+func (mr *MockServiceMockRecorder) Records(ctx, uid any) *MockServiceRecordsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Records", reflect.TypeOf((*MockService)(nil).Records), ctx, uid)
+	return &MockServiceRecordsCall{Call: call}
+}
+
+// MockServiceRecordsCall wrap *gomock.Call
+type MockServiceRecordsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRecordsCall) Return(arg0 []domain.Record, arg1 error) *MockServiceRecordsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRecordsCall) Do(f func(context.Context, int64) ([]domain.Record, error)) *MockServiceRecordsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRecordsCall) DoAndReturn(f func(context.Context, int64) ([]domain.Record, error)) *MockServiceRecordsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockService) Save(ctx context.Context, r domain.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockServiceMockRecorder) Save(ctx, r any) *MockServiceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockService)(nil).Save), ctx, r)
+	return &MockServiceSaveCall{Call: call}
+}
+
+// MockServiceSaveCall wrap *gomock.Call
+type MockServiceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceSaveCall) Return(arg0 error) *MockServiceSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceSaveCall) Do(f func(context.Context, domain.Record) error) *MockServiceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceSaveCall) DoAndReturn(f func(context.Context, domain.Record) error) *MockServiceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/progress/internal/domain"
+	"github.com/ecodeclub/webook/internal/progress/internal/event"
+	"github.com/ecodeclub/webook/internal/progress/internal/service"
+)
+
+type Service = service.Service
+type Progress = domain.Progress
+
+const (
+	BizQuestion    = domain.BizQuestion
+	BizCase        = domain.BizCase
+	BizQuestionSet = domain.BizQuestionSet
+	BizCaseSet     = domain.BizCaseSet
+	BizSkillLevel  = domain.BizSkillLevel
+)
+
+type Module struct {
+	Svc Service
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+	c            *event.ExamineConsumer
+	mc           *event.UserMergeConsumer
+	dc           *event.UserDeletionConsumer
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wireinject
+
+package progress
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/progress/internal/event"
+	"github.com/ecodeclub/webook/internal/progress/internal/repository"
+	"github.com/ecodeclub/webook/internal/progress/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/progress/internal/service"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/skill"
+	"github.com/ego-component/egorm"
+	"github.com/google/wire"
+)
+
+func InitModule(db *egorm.Component,
+	q mq.MQ,
+	queModule *baguwen.Module,
+	caseModule *cases.Module,
+	skillModule *skill.Module) (*Module, error) {
+	wire.Build(
+		InitProgressDAO,
+		repository.NewProgressRepository,
+		service.NewService,
+		service.NewExportSource,
+		initConsumer,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		wire.FieldsOf(new(*baguwen.Module), "SetSvc"),
+		wire.FieldsOf(new(*cases.Module), "SetSvc"),
+		wire.FieldsOf(new(*skill.Module), "Svc"),
+		wire.Struct(new(Module), "*"),
+	)
+	return new(Module), nil
+}
+
+var daoOnce = sync.Once{}
+
+func InitProgressDAO(db *egorm.Component) dao.ProgressDAO {
+	daoOnce.Do(func() {
+		err := dao.InitTables(db)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return dao.NewGORMProgressDAO(db)
+}
+
+func initConsumer(svc service.Service, q mq.MQ) *event.ExamineConsumer {
+	consumer, err := event.NewExamineConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+	return consumer
+}
+
+func initUserMergeConsumer(svc service.Service, q mq.MQ) *event.UserMergeConsumer {
+	c, err := event.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.Service, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
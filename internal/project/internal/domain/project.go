@@ -49,6 +49,10 @@ type Project struct {
 	ProductSPU string
 	// 作为兑换码售卖的 SPU
 	CodeSPU string
+
+	// 定时发布和定时下线的时间，毫秒数，0 代表没有设置
+	PublishAt   int64
+	UnpublishAt int64
 }
 
 type ProjectStatus uint8
@@ -59,9 +59,18 @@ type SyncProjectToSearchEvent struct {
 	Biz   string `json:"biz"`
 	BizID int64  `json:"bizID"`
 	// Data 是 project 利用 json 格式序列化出来的。
+	// 为空代表项目已经被删除了
 	Data string `json:"data"`
 }
 
+// NewDeleteProjectFromSearchEvent 项目被删除之后，搜索那边也删掉
+func NewDeleteProjectFromSearchEvent(id int64) SyncProjectToSearchEvent {
+	return SyncProjectToSearchEvent{
+		Biz:   "project",
+		BizID: id,
+	}
+}
+
 func NewSyncProjectToSearchEvent(p domain.Project) SyncProjectToSearchEvent {
 	prj := Project{
 		Id:     p.Id,
@@ -53,6 +53,7 @@ import (
 type AdminProjectTestSuite struct {
 	suite.Suite
 	hdl    *project.AdminHandler
+	job    *project.ScheduledPublishJob
 	server *egin.Component
 
 	db          *egorm.Component
@@ -70,6 +71,7 @@ func (s *AdminProjectTestSuite) SetupSuite() {
 	m, err := startup.InitModule(intrModule, permModule, session.DefaultProvider())
 	require.NoError(s.T(), err)
 	s.hdl = m.AdminHdl
+	s.job = m.ScheduledPublishJob
 
 	econf.Set("server", map[string]any{"contextTimeout": "10s"})
 	server := egin.Load("server").Build()
@@ -932,6 +934,112 @@ func (s *AdminProjectTestSuite) mockDiff(pid, id int64) dao.ProjectDifficulty {
 	}
 }
 
+func (s *AdminProjectTestSuite) TestSchedule() {
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		after    func(t *testing.T)
+		req      web.ScheduleReq
+		wantCode int
+		wantResp test.Result[any]
+	}{
+		{
+			name: "设置定时发布和定时下线",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Project{Id: 1, Title: "标题1"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var prj dao.Project
+				err := s.db.Where("id = ?", 1).First(&prj).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(1000), prj.PublishAt)
+				assert.Equal(t, int64(2000), prj.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Id: 1, PublishAt: 1000, UnpublishAt: 2000},
+			wantCode: 200,
+			wantResp: test.Result[any]{},
+		},
+		{
+			name: "下线时间早于发布时间",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Project{Id: 2, Title: "标题2"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var prj dao.Project
+				err := s.db.Where("id = ?", 2).First(&prj).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), prj.PublishAt)
+				assert.Equal(t, int64(0), prj.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Id: 2, PublishAt: 2000, UnpublishAt: 1000},
+			wantCode: 500,
+			wantResp: test.Result[any]{Code: 411001, Msg: "定时下线时间必须晚于定时发布时间"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/project/schedule", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[any]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+		})
+	}
+}
+
+func (s *AdminProjectTestSuite) TestScheduledPublishJob() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	now := time.Now()
+	past := now.Add(-time.Minute).UnixMilli()
+	future := now.Add(time.Hour).UnixMilli()
+	unpublished := domain.ProjectStatusUnpublished.ToUint8()
+	published := domain.ProjectStatusPublished.ToUint8()
+	prjs := []dao.Project{
+		// 到期需要发布
+		{Id: 1, Title: "到期发布", Status: unpublished, PublishAt: past},
+		// 还没到期
+		{Id: 2, Title: "没有到期", Status: unpublished, PublishAt: future},
+		// 到期需要下线
+		{Id: 3, Title: "到期下线", Status: published, UnpublishAt: past},
+	}
+	err := s.db.Create(&prjs).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.PubProject{Id: 3, Title: "到期下线", Status: published}).Error
+	require.NoError(t, err)
+
+	err = s.job.Run(ctx)
+	require.NoError(t, err)
+
+	var res []dao.Project
+	err = s.db.Order("id ASC").Find(&res).Error
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, published, res[0].Status)
+	assert.Equal(t, int64(0), res[0].PublishAt)
+	assert.Equal(t, unpublished, res[1].Status)
+	assert.Equal(t, future, res[1].PublishAt)
+	assert.Equal(t, unpublished, res[2].Status)
+	assert.Equal(t, int64(0), res[2].UnpublishAt)
+
+	// 线上库只剩下到期发布的
+	var pubs []dao.PubProject
+	err = s.db.Order("id ASC").Find(&pubs).Error
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, int64(1), pubs[0].Id)
+	assert.Equal(t, "到期发布", pubs[0].Title)
+}
+
 func TestAdmin(t *testing.T) {
 	suite.Run(t, new(AdminProjectTestSuite))
 }
@@ -0,0 +1,31 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
+	"github.com/ecodeclub/webook/internal/project/internal/domain"
+	"github.com/ecodeclub/webook/internal/project/internal/service"
+)
+
+// ScheduledPublishJob 处理到期的定时发布和定时下线
+type ScheduledPublishJob = schedulex.Job[domain.Project]
+
+func NewScheduledPublishJob(svc service.ProjectAdminService, limit int) *ScheduledPublishJob {
+	return schedulex.NewJob[domain.Project]("ProjectScheduledPublishJob", "项目", svc,
+		func(t domain.Project) int64 {
+			return t.Id
+		}, limit)
+}
@@ -52,6 +52,15 @@ type ProjectAdminRepository interface {
 	ComboDetail(ctx context.Context, cid int64) (domain.Combo, error)
 	ComboSync(ctx context.Context, pid int64, c domain.Combo) (int64, error)
 	Delete(ctx context.Context, id int64) error
+	// Unpublish 下线，删除线上库的数据
+	Unpublish(ctx context.Context, id int64) error
+
+	UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	ClearPublishAt(ctx context.Context, id int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Project, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error)
 }
 
 var _ ProjectAdminRepository = (*projectAdminRepository)(nil)
@@ -64,6 +73,43 @@ func (repo *projectAdminRepository) Delete(ctx context.Context, id int64) error
 	return repo.dao.Delete(ctx, id)
 }
 
+func (repo *projectAdminRepository) Unpublish(ctx context.Context, id int64) error {
+	return repo.dao.Unpublish(ctx, id)
+}
+
+func (repo *projectAdminRepository) UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error {
+	return repo.dao.UpdateSchedule(ctx, id, publishAt, unpublishAt)
+}
+
+func (repo *projectAdminRepository) ClearPublishAt(ctx context.Context, id int64, publishAt int64) error {
+	return repo.dao.ClearPublishAt(ctx, id, publishAt)
+}
+
+func (repo *projectAdminRepository) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Project, error) {
+	res, err := repo.dao.ScheduledList(ctx, offset, limit)
+	return slice.Map(res, func(idx int, src dao.Project) domain.Project {
+		return repo.prjToDomain(src, nil, nil, nil, nil, nil)
+	}), err
+}
+
+func (repo *projectAdminRepository) ScheduledCount(ctx context.Context) (int64, error) {
+	return repo.dao.ScheduledCount(ctx)
+}
+
+func (repo *projectAdminRepository) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error) {
+	res, err := repo.dao.DuePublish(ctx, now, minId, limit)
+	return slice.Map(res, func(idx int, src dao.Project) domain.Project {
+		return repo.prjToDomain(src, nil, nil, nil, nil, nil)
+	}), err
+}
+
+func (repo *projectAdminRepository) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error) {
+	res, err := repo.dao.DueUnpublish(ctx, now, minId, limit)
+	return slice.Map(res, func(idx int, src dao.Project) domain.Project {
+		return repo.prjToDomain(src, nil, nil, nil, nil, nil)
+	}), err
+}
+
 func (repo *projectAdminRepository) ComboSync(ctx context.Context, pid int64, c domain.Combo) (int64, error) {
 	entity := repo.comboToEntity(c)
 	entity.Pid = pid
@@ -307,6 +353,8 @@ func (repo *projectAdminRepository) prjToDomain(prj dao.Project,
 		Utime:          prj.Utime,
 		CodeSPU:        prj.CodeSPU.String,
 		ProductSPU:     prj.ProductSPU.String,
+		PublishAt:      prj.PublishAt,
+		UnpublishAt:    prj.UnpublishAt,
 		Resumes: slice.Map(resumes, func(idx int, src dao.ProjectResume) domain.Resume {
 			return repo.rsmToDomain(src)
 		}),
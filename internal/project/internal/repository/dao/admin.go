@@ -18,6 +18,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
+	"github.com/ecodeclub/webook/internal/project/internal/domain"
 	"github.com/ego-component/egorm"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -55,11 +57,25 @@ type ProjectAdminDAO interface {
 	ComboSync(ctx context.Context, c ProjectCombo) (int64, error)
 	Combos(ctx context.Context, pid int64) ([]ProjectCombo, error)
 	Delete(ctx context.Context, id int64) error
+	// Unpublish 删除线上库的项目，并且将制作库的状态修改为未发布
+	Unpublish(ctx context.Context, id int64) error
+
+	// 定时发布
+	UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	// ClearPublishAt 只有在定时发布时间没有被修改过的情况下才会清空
+	ClearPublishAt(ctx context.Context, id int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]Project, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	// DuePublish 到期需要发布的数据，按照 id 升序，只返回 id 大于 minId 的
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]Project, error)
+	// DueUnpublish 到期需要下线的数据，按照 id 升序，只返回 id 大于 minId 的
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]Project, error)
 }
 
 var _ ProjectAdminDAO = &GORMProjectAdminDAO{}
 
 type GORMProjectAdminDAO struct {
+	*schedulex.GORMDAO[Project]
 	db               *egorm.Component
 	prjUpdateColumns []string
 }
@@ -69,6 +85,23 @@ func (dao *GORMProjectAdminDAO) Delete(ctx context.Context, id int64) error {
 	return dao.db.WithContext(ctx).Model(&Project{}).Delete("id = ?", id).Error
 }
 
+func (dao *GORMProjectAdminDAO) Unpublish(ctx context.Context, id int64) error {
+	// 和 Delete 一样，只需要处理 project 本体，别的数据也无法查询到了
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 手动下线的时候也要清空定时发布，避免定时任务又把它发布出去
+		err := tx.Model(&Project{}).Where("id = ?", id).Updates(map[string]any{
+			"status":       domain.ProjectStatusUnpublished.ToUint8(),
+			"publish_at":   0,
+			"unpublish_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&PubProject{}).Error
+	})
+}
+
 func (dao *GORMProjectAdminDAO) Combos(ctx context.Context, pid int64) ([]ProjectCombo, error) {
 	var res []ProjectCombo
 	err := dao.db.WithContext(ctx).Where("pid = ?", pid).Find(&res).Error
@@ -349,7 +382,8 @@ func (dao *GORMProjectAdminDAO) save(tx *gorm.DB, prj *Project) (int64, error) {
 
 func NewGORMProjectAdminDAO(db *egorm.Component) *GORMProjectAdminDAO {
 	return &GORMProjectAdminDAO{
-		db: db,
+		GORMDAO: schedulex.NewGORMDAO[Project](db),
+		db:      db,
 		prjUpdateColumns: []string{
 			"title", "status", "labels", "desc", "overview",
 			"github_repo", "gitee_repo", "ref_question_set",
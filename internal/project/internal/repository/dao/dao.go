@@ -16,11 +16,15 @@ package dao
 
 import (
 	"context"
+	"slices"
 
 	"github.com/ecodeclub/webook/internal/project/internal/domain"
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
 )
 
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
 type ProjectDAO interface {
 	List(ctx context.Context, offset int, limit int) ([]PubProject, error)
 	Count(ctx context.Context) (int64, error)
@@ -31,6 +35,10 @@ type ProjectDAO interface {
 	Questions(ctx context.Context, pid int64) ([]PubProjectQuestion, error)
 	Introductions(ctx context.Context, pid int64) ([]PubProjectIntroduction, error)
 	Combos(ctx context.Context, pid int64) ([]PubProjectCombo, error)
+	// ListByRefQuestionSets 关联了这些八股文题集的项目
+	ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]PubProject, error)
+	// Ids 全部已发布的项目的 id
+	Ids(ctx context.Context) ([]int64, error)
 }
 
 var _ ProjectDAO = &GORMProjectDAO{}
@@ -52,6 +60,15 @@ func (dao *GORMProjectDAO) Introductions(ctx context.Context, pid int64) ([]PubP
 	return res, err
 }
 
+func (dao *GORMProjectDAO) Ids(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).
+		Model(&PubProject{}).
+		Where("status = ?", domain.ProjectStatusPublished.ToUint8()).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 func (dao *GORMProjectDAO) GetById(ctx context.Context, id int64) (PubProject, error) {
 	var res PubProject
 	err := dao.db.WithContext(ctx).
@@ -60,6 +77,16 @@ func (dao *GORMProjectDAO) GetById(ctx context.Context, id int64) (PubProject, e
 	return res, err
 }
 
+func (dao *GORMProjectDAO) ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]PubProject, error) {
+	var res []PubProject
+	err := dao.db.WithContext(ctx).
+		Select(slices.Concat(dao.briefColumns, []string{"ref_question_set"})).
+		Where("ref_question_set IN ? AND status = ?",
+			qsids, domain.ProjectStatusPublished.ToUint8()).
+		Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMProjectDAO) BriefById(ctx context.Context, id int64) (PubProject, error) {
 	var res PubProject
 	err := dao.db.WithContext(ctx).
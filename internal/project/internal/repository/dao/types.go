@@ -35,8 +35,11 @@ type Project struct {
 	ProductSPU     sql.NullString            `gorm:"type:varchar(255);comment:商品的SPU SN"`
 	CodeSPU        sql.NullString            `gorm:"type:varchar(255);comment:作为兑换码的SPU SN"`
 	Desc           string
-	Utime          int64
-	Ctime          int64
+	// 定时发布和定时下线的时间，0 代表没有设置
+	PublishAt   int64 `gorm:"index;not null;default:0"`
+	UnpublishAt int64 `gorm:"index;not null;default:0"`
+	Utime       int64
+	Ctime       int64
 }
 
 type PubProject Project
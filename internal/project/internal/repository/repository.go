@@ -30,14 +30,25 @@ type Repository interface {
 	Count(ctx context.Context) (int64, error)
 	Detail(ctx context.Context, id int64) (domain.Project, error)
 	Brief(ctx context.Context, id int64) (domain.Project, error)
+	ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]domain.Project, error)
+	// Ids 全部已发布的项目的 id
+	Ids(ctx context.Context) ([]int64, error)
 }
 
-var _ Repository = &CachedRepository{}
+var (
+	_ Repository = &CachedRepository{}
+	// ErrProjectNotFound 项目不存在或者还没有发布
+	ErrProjectNotFound = dao.ErrRecordNotFound
+)
 
 type CachedRepository struct {
 	dao dao.ProjectDAO
 }
 
+func (repo *CachedRepository) Ids(ctx context.Context) ([]int64, error) {
+	return repo.dao.Ids(ctx)
+}
+
 func (repo *CachedRepository) Count(ctx context.Context) (int64, error) {
 	return repo.dao.Count(ctx)
 }
@@ -104,6 +115,13 @@ func (repo *CachedRepository) List(ctx context.Context, offset int, limit int) (
 	}), err
 }
 
+func (repo *CachedRepository) ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]domain.Project, error) {
+	res, err := repo.dao.ListByRefQuestionSets(ctx, qsids)
+	return slice.Map(res, func(idx int, src dao.PubProject) domain.Project {
+		return repo.prjToDomain(src, nil, nil, nil, nil, nil)
+	}), err
+}
+
 func (repo *CachedRepository) prjToDomain(prj dao.PubProject,
 	resumes []dao.PubProjectResume,
 	diff []dao.PubProjectDifficulty,
@@ -18,8 +18,11 @@ import (
 	"context"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/lithammer/shortuuid/v4"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/ecodeclub/webook/internal/project/internal/event"
 	"github.com/gotomicro/ego/core/elog"
 
@@ -55,8 +58,21 @@ type ProjectAdminService interface {
 	ComboDetail(ctx context.Context, cid int64) (domain.Combo, error)
 	ComboPublish(ctx context.Context, pid int64, c domain.Combo) (int64, error)
 	Delete(ctx context.Context, id int64) error
+	// Unpublish 下线，只会删除线上库的项目，制作库的数据会保留
+	Unpublish(ctx context.Context, id int64) error
+
+	// Schedule 设置定时发布和定时下线的时间，毫秒数，0 代表取消
+	Schedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Project, int64, error)
+	// DuePublish 和 DueUnpublish 给定时任务使用
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error)
+	// PublishScheduled 发布制作库中的项目，并且清空定时发布时间
+	PublishScheduled(ctx context.Context, prj domain.Project) error
 }
 
+var ErrInvalidSchedule = schedulex.ErrInvalidSchedule
+
 var _ ProjectAdminService = (*projectAdminService)(nil)
 
 type projectAdminService struct {
@@ -67,7 +83,61 @@ type projectAdminService struct {
 }
 
 func (svc *projectAdminService) Delete(ctx context.Context, id int64) error {
-	return svc.adminRepo.Delete(ctx, id)
+	err := svc.adminRepo.Delete(ctx, id)
+	if err == nil {
+		svc.syncDeletedToSearch(id)
+	}
+	return err
+}
+
+func (svc *projectAdminService) Unpublish(ctx context.Context, id int64) error {
+	err := svc.adminRepo.Unpublish(ctx, id)
+	if err == nil {
+		svc.syncUnpublishedToSearch(id)
+	}
+	return err
+}
+
+func (svc *projectAdminService) Schedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error {
+	if err := schedulex.Check(publishAt, unpublishAt); err != nil {
+		return err
+	}
+	return svc.adminRepo.UpdateSchedule(ctx, id, publishAt, unpublishAt)
+}
+
+func (svc *projectAdminService) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Project, int64, error) {
+	var (
+		eg    errgroup.Group
+		prjs  []domain.Project
+		total int64
+	)
+	eg.Go(func() error {
+		var err error
+		prjs, err = svc.adminRepo.ScheduledList(ctx, offset, limit)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		total, err = svc.adminRepo.ScheduledCount(ctx)
+		return err
+	})
+	return prjs, total, eg.Wait()
+}
+
+func (svc *projectAdminService) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error) {
+	return svc.adminRepo.DuePublish(ctx, now, minId, limit)
+}
+
+func (svc *projectAdminService) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Project, error) {
+	return svc.adminRepo.DueUnpublish(ctx, now, minId, limit)
+}
+
+func (svc *projectAdminService) PublishScheduled(ctx context.Context, prj domain.Project) error {
+	_, err := svc.Publish(ctx, prj)
+	if err != nil {
+		return err
+	}
+	return svc.adminRepo.ClearPublishAt(ctx, prj.Id, prj.PublishAt)
 }
 
 func (svc *projectAdminService) ComboPublish(ctx context.Context, pid int64, c domain.Combo) (int64, error) {
@@ -218,6 +288,37 @@ func (svc *projectAdminService) syncToSearch(id int64) {
 	}
 }
 
+// syncUnpublishedToSearch 线上库已经没有数据了，所以用制作库的数据同步，搜索那边只会返回已发布的数据
+func (svc *projectAdminService) syncUnpublishedToSearch(id int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	prj, err := svc.adminRepo.Detail(ctx, id)
+	if err != nil {
+		svc.logger.Error("准备同步数据，查询项目详情失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+		return
+	}
+	evt := event.NewSyncProjectToSearchEvent(prj)
+	err = svc.producer.Produce(ctx, evt)
+	if err != nil {
+		svc.logger.Error("同步数据到搜索失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+	}
+}
+
+func (svc *projectAdminService) syncDeletedToSearch(id int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	err := svc.producer.Produce(ctx, event.NewDeleteProjectFromSearchEvent(id))
+	if err != nil {
+		svc.logger.Error("从搜索中删除项目失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+	}
+}
+
 func NewProjectAdminService(
 	adminRepo repository.ProjectAdminRepository,
 	producer event.SyncProjectToSearchEventProducer,
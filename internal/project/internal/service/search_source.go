@@ -0,0 +1,50 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/project/internal/domain"
+	"github.com/ecodeclub/webook/internal/project/internal/event"
+	"github.com/ecodeclub/webook/internal/project/internal/repository"
+)
+
+// searchSource 搜索重建索引的时候读取全量的项目
+// 和发布时候的同步消息一样，使用线上库的数据
+type searchSource struct {
+	repo repository.Repository
+}
+
+func NewSearchSource(repo repository.Repository) searchx.Source {
+	return &searchSource{repo: repo}
+}
+
+func (s *searchSource) Biz() string {
+	return domain.BizProject
+}
+
+func (s *searchSource) Ids(ctx context.Context) ([]int64, error) {
+	return s.repo.Ids(ctx)
+}
+
+func (s *searchSource) Doc(ctx context.Context, id int64) (string, error) {
+	prj, err := s.repo.Detail(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewSyncProjectToSearchEvent(prj).Data, nil
+}
@@ -28,14 +28,22 @@ import (
 )
 
 // Service C 端接口
+//
+//go:generate mockgen -source=./service.go -destination=../../mocks/project.mock.go -package=projmocks -typed=true Service
 type Service interface {
 	List(ctx context.Context, offset int, limit int) (int64, []domain.Project, error)
-	Detail(ctx context.Context, id int64) (domain.Project, error)
+	// Detail uid 是浏览者，没有登录的时候为 0
+	Detail(ctx context.Context, uid, id int64) (domain.Project, error)
 	// Brief 获得 project 本身的内容
 	Brief(ctx context.Context, id int64) (domain.Project, error)
+	// ListByRefQuestionSets 关联了这些八股文题集的项目，只返回基本信息
+	ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]domain.Project, error)
 }
 
-var _ Service = &service{}
+var (
+	_                  Service = &service{}
+	ErrProjectNotFound         = repository.ErrProjectNotFound
+)
 
 type service struct {
 	repo     repository.Repository
@@ -47,13 +55,13 @@ func (s *service) Brief(ctx context.Context, id int64) (domain.Project, error) {
 	return s.repo.Brief(ctx, id)
 }
 
-func (s *service) Detail(ctx context.Context, id int64) (domain.Project, error) {
+func (s *service) Detail(ctx context.Context, uid, id int64) (domain.Project, error) {
 	prj, err := s.repo.Detail(ctx, id)
 	if err == nil {
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := s.producer.Produce(newCtx, event.NewViewCntEvent(id, domain.BizProject))
+			err1 := s.producer.Produce(newCtx, event.NewViewCntEvent(id, domain.BizProject, uid))
 			if err1 != nil {
 				if err1 != nil {
 					s.logger.Error("发送问题阅读计数消息到消息队列失败", elog.FieldErr(err1), elog.Int64("pid", id))
@@ -64,6 +72,13 @@ func (s *service) Detail(ctx context.Context, id int64) (domain.Project, error)
 	return prj, err
 }
 
+func (s *service) ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]domain.Project, error) {
+	if len(qsids) == 0 {
+		return nil, nil
+	}
+	return s.repo.ListByRefQuestionSets(ctx, qsids)
+}
+
 func (s *service) List(ctx context.Context, offset int, limit int) (int64, []domain.Project, error) {
 	var (
 		eg       errgroup.Group
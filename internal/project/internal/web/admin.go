@@ -15,6 +15,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/webook/internal/interactive"
@@ -35,6 +37,9 @@ func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
 	g.POST("/save", ginx.B[Project](h.Save))
 	g.POST("/publish", ginx.B[Project](h.Publish))
 	g.POST("/delete", ginx.B[IdReq](h.Delete))
+	g.POST("/unpublish", ginx.B[IdReq](h.Unpublish))
+	g.POST("/schedule", ginx.B[ScheduleReq](h.Schedule))
+	g.POST("/scheduled/list", ginx.B[Page](h.ScheduledList))
 
 	g.POST("/difficulty/save", ginx.B(h.DifficultySave))
 	g.POST("/difficulty/detail", ginx.B(h.DifficultyDetail))
@@ -284,6 +289,41 @@ func (h *AdminHandler) Delete(ctx *ginx.Context, req IdReq) (ginx.Result, error)
 	return ginx.Result{Msg: "OK"}, nil
 }
 
+func (h *AdminHandler) Unpublish(ctx *ginx.Context, req IdReq) (ginx.Result, error) {
+	err := h.svc.Unpublish(ctx, req.Id)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) Schedule(ctx *ginx.Context, req ScheduleReq) (ginx.Result, error) {
+	err := h.svc.Schedule(ctx, req.Id, req.PublishAt, req.UnpublishAt)
+	switch {
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return invalidScheduleResult, err
+	case err != nil:
+		return systemErrorResult, err
+	default:
+		return ginx.Result{Msg: "OK"}, nil
+	}
+}
+
+func (h *AdminHandler) ScheduledList(ctx *ginx.Context, req Page) (ginx.Result, error) {
+	list, cnt, err := h.svc.ScheduledList(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: ProjectList{
+			Projects: slice.Map(list, func(idx int, src domain.Project) Project {
+				return newProject(src, interactive.Interactive{})
+			}),
+			Total: cnt,
+		},
+	}, nil
+}
+
 func NewAdminHandler(svc service.ProjectAdminService) *AdminHandler {
 	return &AdminHandler{
 		svc: svc,
@@ -119,7 +119,7 @@ func (h *Handler) Detail(ctx *ginx.Context, req IdReq, sess session.Session) (gi
 		// 如果有权限，就返回详情，
 		// 否则只是返回一个粗略情况
 		if perm {
-			detail, err = h.svc.Detail(ctx, req.Id)
+			detail, err = h.svc.Detail(ctx, uid, req.Id)
 		} else {
 			detail, err = h.svc.Brief(ctx, req.Id)
 		}
@@ -51,6 +51,10 @@ type Project struct {
 	Permitted     bool           `json:"permitted"`
 	CodeSPU       string         `json:"codeSPU"`
 	ProductSPU    string         `json:"productSPU"`
+
+	// 定时发布和定时下线的时间，只有管理后台会用到
+	PublishAt   int64 `json:"publishAt,omitempty"`
+	UnpublishAt int64 `json:"unpublishAt,omitempty"`
 }
 
 func newProject(p domain.Project, intr interactive.Interactive) Project {
@@ -69,6 +73,8 @@ func newProject(p domain.Project, intr interactive.Interactive) Project {
 		Utime:          p.Utime,
 		CodeSPU:        p.CodeSPU,
 		ProductSPU:     p.ProductSPU,
+		PublishAt:      p.PublishAt,
+		UnpublishAt:    p.UnpublishAt,
 		Resumes: slice.Map(p.Resumes, func(idx int, src domain.Resume) Resume {
 			return newResume(src)
 		}),
@@ -269,8 +275,16 @@ type IdReq struct {
 	Id int64 `json:"id,omitempty"`
 }
 
+// ScheduleReq 定时发布和定时下线，毫秒数，0 代表取消
+type ScheduleReq struct {
+	Id          int64 `json:"id"`
+	PublishAt   int64 `json:"publishAt"`
+	UnpublishAt int64 `json:"unpublishAt"`
+}
+
 type Interactive struct {
 	CollectCnt int  `json:"collectCnt"`
+	CommentCnt int  `json:"commentCnt"`
 	LikeCnt    int  `json:"likeCnt"`
 	ViewCnt    int  `json:"viewCnt"`
 	Liked      bool `json:"liked"`
@@ -280,6 +294,7 @@ type Interactive struct {
 func newInteractive(intr interactive.Interactive) Interactive {
 	return Interactive{
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		ViewCnt:    intr.ViewCnt,
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./service.go -destination=../../mocks/project.mock.go -package=projmocks -typed=true Service
+//
+
+// Package projmocks is a generated GoMock package.
+package projmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/project/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Brief mocks base method.
+func (m *MockService) Brief(ctx context.Context, id int64) (domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Brief", ctx, id)
+	ret0, _ := ret[0].(domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Brief indicates an expected call of Brief.
+func (mr *MockServiceMockRecorder) Brief(ctx, id any) *ServiceBriefCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Brief", reflect.TypeOf((*MockService)(nil).Brief), ctx, id)
+	return &ServiceBriefCall{Call: call}
+}
+
+// ServiceBriefCall wrap *gomock.Call
+type ServiceBriefCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceBriefCall) Return(arg0 domain.Project, arg1 error) *ServiceBriefCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceBriefCall) Do(f func(context.Context, int64) (domain.Project, error)) *ServiceBriefCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceBriefCall) DoAndReturn(f func(context.Context, int64) (domain.Project, error)) *ServiceBriefCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Detail mocks base method.
+func (m *MockService) Detail(ctx context.Context, uid, id int64) (domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detail", ctx, uid, id)
+	ret0, _ := ret[0].(domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detail indicates an expected call of Detail.
+func (mr *MockServiceMockRecorder) Detail(ctx, uid, id any) *ServiceDetailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockService)(nil).Detail), ctx, uid, id)
+	return &ServiceDetailCall{Call: call}
+}
+
+// ServiceDetailCall wrap *gomock.Call
+type ServiceDetailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceDetailCall) Return(arg0 domain.Project, arg1 error) *ServiceDetailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceDetailCall) Do(f func(context.Context, int64, int64) (domain.Project, error)) *ServiceDetailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceDetailCall) DoAndReturn(f func(context.Context, int64, int64) (domain.Project, error)) *ServiceDetailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, offset, limit int) (int64, []domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, offset, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]domain.Project)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, offset, limit any) *ServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, offset, limit)
+	return &ServiceListCall{Call: call}
+}
+
+// ServiceListCall wrap *gomock.Call
+type ServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceListCall) Return(arg0 int64, arg1 []domain.Project, arg2 error) *ServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceListCall) Do(f func(context.Context, int, int) (int64, []domain.Project, error)) *ServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceListCall) DoAndReturn(f func(context.Context, int, int) (int64, []domain.Project, error)) *ServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListByRefQuestionSets mocks base method.
+func (m *MockService) ListByRefQuestionSets(ctx context.Context, qsids []int64) ([]domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRefQuestionSets", ctx, qsids)
+	ret0, _ := ret[0].([]domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRefQuestionSets indicates an expected call of ListByRefQuestionSets.
+func (mr *MockServiceMockRecorder) ListByRefQuestionSets(ctx, qsids any) *ServiceListByRefQuestionSetsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRefQuestionSets", reflect.TypeOf((*MockService)(nil).ListByRefQuestionSets), ctx, qsids)
+	return &ServiceListByRefQuestionSetsCall{Call: call}
+}
+
+// ServiceListByRefQuestionSetsCall wrap *gomock.Call
+type ServiceListByRefQuestionSetsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *ServiceListByRefQuestionSetsCall) Return(arg0 []domain.Project, arg1 error) *ServiceListByRefQuestionSetsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *ServiceListByRefQuestionSetsCall) Do(f func(context.Context, []int64) ([]domain.Project, error)) *ServiceListByRefQuestionSetsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *ServiceListByRefQuestionSetsCall) DoAndReturn(f func(context.Context, []int64) ([]domain.Project, error)) *ServiceListByRefQuestionSetsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
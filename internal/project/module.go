@@ -14,12 +14,27 @@
 
 package project
 
-import "github.com/ecodeclub/webook/internal/project/internal/web"
+import (
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/project/internal/domain"
+	"github.com/ecodeclub/webook/internal/project/internal/job"
+	"github.com/ecodeclub/webook/internal/project/internal/service"
+	"github.com/ecodeclub/webook/internal/project/internal/web"
+)
 
 type AdminHandler = web.AdminHandler
 type Handler = web.Handler
+type ScheduledPublishJob = job.ScheduledPublishJob
+type Service = service.Service
+type Project = domain.Project
+
+var ErrProjectNotFound = service.ErrProjectNotFound
 
 type Module struct {
-	AdminHdl *AdminHandler
-	Hdl      *Handler
+	AdminHdl            *AdminHandler
+	Hdl                 *Handler
+	ScheduledPublishJob *ScheduledPublishJob
+	Svc                 Service
+	// SearchSource 搜索重建索引的时候使用
+	SearchSource searchx.Source
 }
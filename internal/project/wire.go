@@ -27,6 +27,7 @@ import (
 
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/project/internal/event"
+	"github.com/ecodeclub/webook/internal/project/internal/job"
 
 	"github.com/ecodeclub/webook/internal/project/internal/repository"
 	"github.com/ecodeclub/webook/internal/project/internal/repository/dao"
@@ -50,10 +51,12 @@ func InitModule(db *egorm.Component,
 		event.NewSyncProjectToSearchEventProducer,
 		event.NewInteractiveEventProducer,
 		web.NewAdminHandler,
+		initScheduledPublishJob,
 
 		dao.NewGORMProjectDAO,
 		repository.NewCachedRepository,
 		service.NewService,
+		service.NewSearchSource,
 		web.NewHandler,
 		wire.FieldsOf(new(*interactive.Module), "Svc"),
 		wire.FieldsOf(new(*permission.Module), "Svc"),
@@ -77,6 +80,11 @@ func initAdminDAO(db *egorm.Component) dao.ProjectAdminDAO {
 	return adminDAO
 }
 
+func initScheduledPublishJob(svc service.ProjectAdminService) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func initSyncToSearchEventProducer(q mq.MQ) mq.Producer {
 	res, err := q.Producer(event.SyncTopic)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/permission"
 	"github.com/ecodeclub/webook/internal/project/internal/event"
+	"github.com/ecodeclub/webook/internal/project/internal/job"
 	"github.com/ecodeclub/webook/internal/project/internal/repository"
 	"github.com/ecodeclub/webook/internal/project/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/project/internal/service"
@@ -41,9 +42,14 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, permModule *permiss
 	service2 := permModule.Svc
 	service3 := intrModule.Svc
 	handler := web.NewHandler(serviceService, service2, service3, sp)
+	scheduledPublishJob := initScheduledPublishJob(projectAdminService)
+	source := service.NewSearchSource(repositoryRepository)
 	module := &Module{
-		AdminHdl: adminHandler,
-		Hdl:      handler,
+		AdminHdl:            adminHandler,
+		Hdl:                 handler,
+		ScheduledPublishJob: scheduledPublishJob,
+		Svc:                 serviceService,
+		SearchSource:        source,
 	}
 	return module, nil
 }
@@ -66,6 +72,11 @@ func initAdminDAO(db *egorm.Component) dao.ProjectAdminDAO {
 	return adminDAO
 }
 
+func initScheduledPublishJob(svc service.ProjectAdminService) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func initSyncToSearchEventProducer(q mq.MQ) mq.Producer {
 	res, err := q.Producer(event.SyncTopic)
 	if err != nil {
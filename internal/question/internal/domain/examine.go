@@ -25,6 +25,8 @@ type ExamineResult struct {
 	// 花费的金额
 	Amount int64
 	Tid    string
+	// 测试时间，导出个人数据的时候才有
+	Ctime int64
 }
 
 type Result uint8
@@ -34,6 +34,10 @@ type Question struct {
 	Status  QuestionStatus
 	Answer  Answer
 	Utime   time.Time
+
+	// 定时发布和定时下线的时间，毫秒数，0 代表没有设置
+	PublishAt   int64
+	UnpublishAt int64
 }
 
 func (q Question) IsBaguwen() bool {
@@ -1,6 +1,8 @@
 package errs
 
 var (
+	InvalidSchedule = ErrorCode{Code: 402001, Msg: "定时下线时间必须晚于定时发布时间"}
+
 	SystemError = ErrorCode{Code: 502001, Msg: "系统错误"}
 	// InsufficientCredit 这个不管说是客户端错误还是服务端错误，都有点勉强，所以随便用一个 5
 	InsufficientCredit = ErrorCode{Code: 502002, Msg: "积分不足"}
@@ -0,0 +1,62 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+)
+
+type ExamineEventProducer mqx.Producer[ExamineEvent]
+
+func NewExamineEventProducer(p mq.MQ) (ExamineEventProducer, error) {
+	return mqx.NewGeneralProducer[ExamineEvent](p, examineTopic)
+}
+
+const examineTopic = "examine_events"
+
+// ExamineEvent 测试结果发生变化，包括 AI 评价和人工修正
+type ExamineEvent struct {
+	Uid   int64  `json:"uid,omitempty"`
+	Biz   string `json:"biz,omitempty"`
+	BizId int64  `json:"bizId,omitempty"`
+	// 最新的测试结果
+	Result uint8 `json:"result,omitempty"`
+}
+
+const UserMergeEventName = "user_merge_events"
+
+// UserMergeEvent 合并账号，Id 是合并记录的 ID
+type UserMergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+const (
+	UserDeletionEventName     = "user_deletion_events"
+	UserDeletionDoneEventName = "user_deletion_done_events"
+)
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
@@ -15,6 +15,8 @@
 package event
 
 import (
+	"time"
+
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/pkg/mqx"
 )
@@ -34,12 +36,17 @@ type InteractiveEvent struct {
 	// like, collect, view 三个
 	Action string `json:"action,omitempty"`
 	Uid    int64  `json:"uid,omitempty"`
+	// Ctime 浏览发生的时间，毫秒，只有 view 才会使用
+	Ctime int64 `json:"ctime,omitempty"`
 }
 
-func NewViewCntEvent(id int64, biz string) InteractiveEvent {
+// NewViewCntEvent uid 为 0 代表没有登录，这种浏览没办法按照用户去重，也不记录浏览历史
+func NewViewCntEvent(id int64, biz string, uid int64) InteractiveEvent {
 	return InteractiveEvent{
 		Biz:    biz,
 		BizId:  id,
 		Action: "view",
+		Uid:    uid,
+		Ctime:  time.Now().UnixMilli(),
 	}
 }
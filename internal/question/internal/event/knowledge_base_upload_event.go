@@ -10,6 +10,8 @@ import (
 
 const (
 	KnowledgeBaseUploadTopic = "knowledge_base_upload_topic"
+	// KnowledgeBaseActionDelete 从知识库中删除
+	KnowledgeBaseActionDelete = "delete"
 )
 
 type KnowledgeBaseEvent struct {
@@ -22,6 +24,8 @@ type KnowledgeBaseEvent struct {
 	// 用途
 	Type            string `json:"type"`
 	KnowledgeBaseID string `json:"knowledgeBaseID"`
+	// 操作类型，为空代表上传
+	Action string `json:"action"`
 }
 
 func NewKnowledgeBaseEvent(que domain.Question) (KnowledgeBaseEvent, error) {
@@ -37,3 +41,14 @@ func NewKnowledgeBaseEvent(que domain.Question) (KnowledgeBaseEvent, error) {
 		Type:  ai.RepositoryBaseTypeRetrieval,
 	}, nil
 }
+
+// NewDeleteKnowledgeBaseEvent 问题下线之后从知识库中删除
+func NewDeleteKnowledgeBaseEvent(qid int64) KnowledgeBaseEvent {
+	return KnowledgeBaseEvent{
+		Biz:    domain.QuestionBiz,
+		BizID:  qid,
+		Name:   fmt.Sprintf("question_%d", qid),
+		Type:   ai.RepositoryBaseTypeRetrieval,
+		Action: KnowledgeBaseActionDelete,
+	}
+}
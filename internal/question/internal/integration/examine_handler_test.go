@@ -33,6 +33,7 @@ import (
 	"github.com/ecodeclub/ekit/iox"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/interactive"
+	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/question/internal/domain"
 	"github.com/ecodeclub/webook/internal/question/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/question/internal/repository/dao"
@@ -53,6 +54,7 @@ type ExamineHandlerTest struct {
 	server *egin.Component
 	db     *egorm.Component
 	dao    dao.ExamineDAO
+	svc    baguwen.ExamService
 }
 
 func (s *ExamineHandlerTest) SetupSuite() {
@@ -71,6 +73,7 @@ func (s *ExamineHandlerTest) SetupSuite() {
 		&member.Module{})
 	require.NoError(s.T(), err)
 	hdl := module.ExamineHdl
+	s.svc = module.ExamSvc
 	s.db = testioc.InitDB()
 	econf.Set("server", map[string]any{"contextTimeout": "1s"})
 	server := egin.Load("server").Build()
@@ -264,6 +267,46 @@ func (s *ExamineHandlerTest) TestExamine() {
 	}
 }
 
+func (s *ExamineHandlerTest) TestMergeResults() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	const sourceUid, targetUid = 2001, 2002
+	err := s.db.Create(&[]dao.QuestionResult{
+		{Uid: sourceUid, Qid: 1, Result: domain.ResultAdvanced.ToUint8()},
+		{Uid: sourceUid, Qid: 2, Result: domain.ResultBasic.ToUint8()},
+		{Uid: sourceUid, Qid: 3, Result: domain.ResultBasic.ToUint8()},
+		{Uid: targetUid, Qid: 1, Result: domain.ResultBasic.ToUint8()},
+		{Uid: targetUid, Qid: 2, Result: domain.ResultIntermediate.ToUint8()},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.ExamineRecord{Uid: sourceUid, Qid: 1, Tid: "merge-tid"}).Error
+	require.NoError(t, err)
+
+	// 重复执行结果也是一样的
+	require.NoError(t, s.svc.MergeResults(ctx, sourceUid, targetUid))
+	require.NoError(t, s.svc.MergeResults(ctx, sourceUid, targetUid))
+
+	results, err := s.dao.GetResultByUidAndQids(ctx, sourceUid, []int64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = s.dao.GetResultByUidAndQids(ctx, targetUid, []int64{1, 2, 3})
+	require.NoError(t, err)
+	got := make(map[int64]uint8, len(results))
+	for _, res := range results {
+		got[res.Qid] = res.Result
+	}
+	assert.Equal(t, map[int64]uint8{
+		1: domain.ResultAdvanced.ToUint8(),
+		2: domain.ResultIntermediate.ToUint8(),
+		3: domain.ResultBasic.ToUint8(),
+	}, got)
+	var record dao.ExamineRecord
+	err = s.db.WithContext(ctx).Where("tid = ?", "merge-tid").First(&record).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(targetUid), record.Uid)
+}
+
 func (s *ExamineHandlerTest) TestCorrect() {
 	testCases := []struct {
 		name   string
@@ -342,3 +385,38 @@ func (s *ExamineHandlerTest) TestCorrect() {
 func TestExamineHandler(t *testing.T) {
 	suite.Run(t, new(ExamineHandlerTest))
 }
+
+func (s *ExamineHandlerTest) TestDeleteResults() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	const deletedUid, otherUid = 2101, 2102
+	err := s.db.Create(&[]dao.QuestionResult{
+		{Uid: deletedUid, Qid: 1, Result: domain.ResultAdvanced.ToUint8()},
+		{Uid: otherUid, Qid: 1, Result: domain.ResultBasic.ToUint8()},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.ExamineRecord{
+		{Uid: deletedUid, Qid: 1, Tid: "deletion-tid-1", Ctime: 123},
+		{Uid: otherUid, Qid: 1, Tid: "deletion-tid-2"},
+	}).Error
+	require.NoError(t, err)
+
+	records, err := s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ExamineResult{
+		{Qid: 1, Tid: "deletion-tid-1", Ctime: 123},
+	}, records)
+
+	require.NoError(t, s.svc.DeleteResults(ctx, deletedUid))
+
+	records, err = s.svc.Records(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	results, err := s.dao.GetResultByUidAndQids(ctx, deletedUid, []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = s.dao.GetResultByUidAndQids(ctx, otherUid, []int64{1})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ecache/memory/lru"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/question/internal/domain"
+	"github.com/ecodeclub/webook/internal/question/internal/event"
+	eveMocks "github.com/ecodeclub/webook/internal/question/internal/event/mocks"
+	"github.com/ecodeclub/webook/internal/question/internal/repository"
+	"github.com/ecodeclub/webook/internal/question/internal/repository/cache"
+	"github.com/ecodeclub/webook/internal/question/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/question/internal/service"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// PublishedCacheTestSuite 使用真实的本地缓存 + Redis，
+// 验证发布、下线和删除之后，详情和列表的缓存都会失效
+type PublishedCacheTestSuite struct {
+	BaseTestSuite
+	svc service.Service
+}
+
+func (s *PublishedCacheTestSuite) SetupSuite() {
+	s.db = testioc.InitDB()
+	ctrl := gomock.NewController(s.T())
+	producer := eveMocks.NewMockSyncEventProducer(ctrl)
+	producer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	knowledgeBaseProducer := eveMocks.NewMockKnowledgeBaseEventProducer(ctrl)
+	knowledgeBaseProducer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	intrProducer, err := event.NewInteractiveEventProducer(testioc.InitMQ())
+	require.NoError(s.T(), err)
+
+	ec := testioc.InitCache()
+	c := cachex.NewTwoTierCache(lru.NewCache(100), ec, time.Minute)
+	// 每次运行都用不同的前缀，避免读到上一次运行留在 Redis 里面的数据
+	rc := cachex.NewReadThroughCache(c, time.Minute).
+		WithNamespace(fmt.Sprintf("test:%d:", time.Now().UnixNano()))
+	repo := repository.NewCacheRepository(baguwen.InitQuestionDAO(s.db),
+		cache.NewQuestionECache(ec), cache.NewPublishedCache(rc))
+	s.svc = service.NewService(repo, producer, intrProducer, knowledgeBaseProducer)
+}
+
+func (s *PublishedCacheTestSuite) TestPublish() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	id := s.publishAndWarmUp(ctx, "旧的标题")
+
+	_, err := s.svc.Publish(ctx, &domain.Question{
+		Id:    id,
+		Uid:   uid,
+		Biz:   domain.DefaultBiz,
+		BizId: id,
+		Title: "新的标题",
+	})
+	require.NoError(t, err)
+
+	que, err := s.svc.PubDetail(ctx, uid, id)
+	require.NoError(t, err)
+	assert.Equal(t, "新的标题", que.Title)
+	_, qs, err := s.svc.PubList(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, qs, 1)
+	assert.Equal(t, "新的标题", qs[0].Title)
+}
+
+func (s *PublishedCacheTestSuite) TestUnpublish() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	id := s.publishAndWarmUp(ctx, "准备下线的题目")
+
+	err := s.svc.Unpublish(ctx, id)
+	require.NoError(t, err)
+
+	_, err = s.svc.PubDetail(ctx, uid, id)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, qs, err := s.svc.PubList(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, qs)
+}
+
+func (s *PublishedCacheTestSuite) TestDelete() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	id := s.publishAndWarmUp(ctx, "准备删除的题目")
+
+	err := s.svc.Delete(ctx, id)
+	require.NoError(t, err)
+
+	_, err = s.svc.PubDetail(ctx, uid, id)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, qs, err := s.svc.PubList(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, qs)
+}
+
+// publishAndWarmUp 发布一道题，读取详情和列表，让数据进入缓存
+// 而后直接修改线上库，确认读到的是缓存里面的数据
+func (s *PublishedCacheTestSuite) publishAndWarmUp(ctx context.Context, title string) int64 {
+	t := s.T()
+	id, err := s.svc.Publish(ctx, &domain.Question{
+		Uid:   uid,
+		Biz:   domain.DefaultBiz,
+		Title: title,
+	})
+	require.NoError(t, err)
+	que, err := s.svc.PubDetail(ctx, uid, id)
+	require.NoError(t, err)
+	assert.Equal(t, title, que.Title)
+	_, qs, err := s.svc.PubList(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, qs, 1)
+	assert.Equal(t, title, qs[0].Title)
+
+	err = s.db.WithContext(ctx).Model(&dao.PublishQuestion{}).
+		Where("id = ?", id).Update("title", "直接修改的标题").Error
+	require.NoError(t, err)
+	que, err = s.svc.PubDetail(ctx, uid, id)
+	require.NoError(t, err)
+	assert.Equal(t, title, que.Title)
+	_, qs, err = s.svc.PubList(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, qs, 1)
+	assert.Equal(t, title, qs[0].Title)
+	return id
+}
+
+func TestPublishedCache(t *testing.T) {
+	suite.Run(t, new(PublishedCacheTestSuite))
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/ai"
+	"github.com/ecodeclub/webook/internal/interactive"
+	intrmocks "github.com/ecodeclub/webook/internal/interactive/mocks"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/question/internal/domain"
+	eveMocks "github.com/ecodeclub/webook/internal/question/internal/event/mocks"
+	"github.com/ecodeclub/webook/internal/question/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/question/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/question/internal/web"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type ScheduleTestSuite struct {
+	BaseTestSuite
+	server *egin.Component
+	job    *baguwen.ScheduledPublishJob
+}
+
+func (s *ScheduleTestSuite) SetupSuite() {
+	s.db = testioc.InitDB()
+	ctrl := gomock.NewController(s.T())
+	producer := eveMocks.NewMockSyncEventProducer(ctrl)
+	producer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	knowledgeBaseProducer := eveMocks.NewMockKnowledgeBaseEventProducer(ctrl)
+	knowledgeBaseProducer.EXPECT().Produce(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	intrSvc := intrmocks.NewMockService(ctrl)
+
+	module, err := startup.InitModule(producer, knowledgeBaseProducer,
+		&interactive.Module{Svc: intrSvc},
+		&permission.Module{}, &ai.Module{},
+		session.DefaultProvider(),
+		&member.Module{})
+	require.NoError(s.T(), err)
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: uid,
+			Data: map[string]string{
+				"creator":   "true",
+				"memberDDL": strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10),
+			},
+		}))
+	})
+	module.AdminHdl.PrivateRoutes(server.Engine)
+	s.server = server
+	s.job = module.ScheduledPublishJob
+}
+
+func (s *ScheduleTestSuite) TestSchedule() {
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+		req    web.ScheduleReq
+
+		wantCode int
+		wantResp test.Result[any]
+	}{
+		{
+			name: "设置定时发布和定时下线",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Question{Id: 1, Title: "标题1"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var q dao.Question
+				err := s.db.Where("id = ?", 1).First(&q).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(1000), q.PublishAt)
+				assert.Equal(t, int64(2000), q.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Qid: 1, PublishAt: 1000, UnpublishAt: 2000},
+			wantCode: 200,
+			wantResp: test.Result[any]{},
+		},
+		{
+			name: "取消定时发布",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Question{Id: 2, Title: "标题2", PublishAt: 1000, UnpublishAt: 2000}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var q dao.Question
+				err := s.db.Where("id = ?", 2).First(&q).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), q.PublishAt)
+				assert.Equal(t, int64(2000), q.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Qid: 2, UnpublishAt: 2000},
+			wantCode: 200,
+			wantResp: test.Result[any]{},
+		},
+		{
+			name: "下线时间早于发布时间",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Question{Id: 3, Title: "标题3"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var q dao.Question
+				err := s.db.Where("id = ?", 3).First(&q).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), q.PublishAt)
+				assert.Equal(t, int64(0), q.UnpublishAt)
+			},
+			req:      web.ScheduleReq{Qid: 3, PublishAt: 2000, UnpublishAt: 1000},
+			wantCode: 500,
+			wantResp: test.Result[any]{Code: 402001, Msg: "定时下线时间必须晚于定时发布时间"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/question/schedule", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[any]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+		})
+	}
+}
+
+func (s *ScheduleTestSuite) TestScheduledList() {
+	t := s.T()
+	qs := []dao.Question{
+		{Id: 1, Title: "定时发布", PublishAt: 1000},
+		{Id: 2, Title: "没有定时"},
+		{Id: 3, Title: "定时下线", UnpublishAt: 2000},
+	}
+	err := s.db.Create(&qs).Error
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost,
+		"/question/scheduled/list", iox.NewJSONReader(web.Page{Limit: 10}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[web.QuestionList]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	data := recorder.MustScan().Data
+	assert.Equal(t, int64(2), data.Total)
+	require.Len(t, data.Questions, 2)
+	assert.Equal(t, int64(3), data.Questions[0].Id)
+	assert.Equal(t, int64(2000), data.Questions[0].UnpublishAt)
+	assert.Equal(t, int64(1), data.Questions[1].Id)
+	assert.Equal(t, int64(1000), data.Questions[1].PublishAt)
+}
+
+func (s *ScheduleTestSuite) TestUnpublish() {
+	t := s.T()
+	future := time.Now().Add(time.Hour).UnixMilli()
+	published := domain.PublishedStatus.ToUint8()
+	err := s.db.Create(&dao.Question{Id: 1, Title: "手动下线", Status: published, PublishAt: future}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.PublishQuestion{Id: 1, Title: "手动下线", Status: published}).Error
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost,
+		"/question/unpublish", iox.NewJSONReader(web.Qid{Qid: 1}))
+	req.Header.Set("content-type", "application/json")
+	require.NoError(t, err)
+	recorder := test.NewJSONResponseRecorder[any]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+
+	// 定时发布被清空了，定时任务不会再把它发布出去
+	var q dao.Question
+	err = s.db.Where("id = ?", 1).First(&q).Error
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnPublishedStatus.ToUint8(), q.Status)
+	assert.Equal(t, int64(0), q.PublishAt)
+	err = s.db.Where("id = ?", 1).First(&dao.PublishQuestion{}).Error
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func (s *ScheduleTestSuite) TestScheduledPublishJob() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	now := time.Now()
+	past := now.Add(-time.Minute).UnixMilli()
+	future := now.Add(time.Hour).UnixMilli()
+	unpublished := domain.UnPublishedStatus.ToUint8()
+	published := domain.PublishedStatus.ToUint8()
+	qs := []dao.Question{
+		// 到期需要发布
+		{Id: 1, Title: "到期发布", Status: unpublished, PublishAt: past},
+		// 还没到期
+		{Id: 2, Title: "没有到期", Status: unpublished, PublishAt: future},
+		// 到期需要下线
+		{Id: 3, Title: "到期下线", Status: published, UnpublishAt: past},
+		// 发布和下线同时到期，最终是下线的状态
+		{Id: 4, Title: "同时到期", Status: unpublished, PublishAt: past, UnpublishAt: past},
+	}
+	err := s.db.Create(&qs).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.PublishQuestion{Id: 3, Title: "到期下线", Status: published}).Error
+	require.NoError(t, err)
+
+	err = s.job.Run(ctx)
+	require.NoError(t, err)
+
+	var res []dao.Question
+	err = s.db.Order("id ASC").Find(&res).Error
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	assert.Equal(t, published, res[0].Status)
+	assert.Equal(t, int64(0), res[0].PublishAt)
+	assert.Equal(t, unpublished, res[1].Status)
+	assert.Equal(t, future, res[1].PublishAt)
+	assert.Equal(t, unpublished, res[2].Status)
+	assert.Equal(t, int64(0), res[2].UnpublishAt)
+	assert.Equal(t, unpublished, res[3].Status)
+	assert.Equal(t, int64(0), res[3].PublishAt)
+	assert.Equal(t, int64(0), res[3].UnpublishAt)
+
+	// 线上库只剩下到期发布的
+	var pubs []dao.PublishQuestion
+	err = s.db.Order("id ASC").Find(&pubs).Error
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, int64(1), pubs[0].Id)
+	assert.Equal(t, "到期发布", pubs[0].Title)
+	err = s.db.Where("id = ?", 3).First(&dao.PublishQuestion{}).Error
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestSchedule(t *testing.T) {
+	suite.Run(t, new(ScheduleTestSuite))
+}
@@ -60,11 +60,13 @@ func InitModule(p event.SyncDataToSearchEventProducer,
 
 var moduleSet = wire.NewSet(baguwen.InitQuestionDAO,
 	cache.NewQuestionECache,
+	cache.NewPublishedCache,
 	repository.NewCacheRepository,
 	service.NewService,
 	web.NewHandler,
 	web.NewAdminHandler,
 	initKnowledgeJobStarter,
+	initScheduledPublishJob,
 	web.NewAdminQuestionSetHandler,
 	baguwen.ExamineHandlerSet,
 	baguwen.InitQuestionSetDAO,
@@ -73,13 +75,18 @@ var moduleSet = wire.NewSet(baguwen.InitQuestionDAO,
 	web.NewQuestionSetHandler,
 	initKnowledgeBaseSvc,
 	web.NewKnowledgeBaseHandler,
-	wire.Struct(new(baguwen.Module), "*"),
+	wire.Struct(new(baguwen.Module), "Svc", "SetSvc", "ExamSvc", "AdminHdl", "AdminSetHdl", "Hdl",
+		"QsHdl", "ExamineHdl", "KnowledgeJobStarter", "ScheduledPublishJob", "KnowledgeBaseHdl"),
 )
 
 func initKnowledgeJobStarter(svc service.Service) *job.KnowledgeJobStarter {
 	return job.NewKnowledgeJobStarter(svc, os.TempDir())
 }
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
 func initKnowledgeBaseSvc(svc ai.KnowledgeBaseService, queRepo repository.Repository) service.QuestionKnowledgeBase {
 	return service.NewQuestionKnowledgeBase("knowledge_id", queRepo, svc)
 }
@@ -33,7 +33,9 @@ func InitModule(p event.SyncDataToSearchEventProducer, knowledgeBaseP event.Know
 	questionDAO := baguwen.InitQuestionDAO(db)
 	ecacheCache := testioc.InitCache()
 	questionCache := cache.NewQuestionECache(ecacheCache)
-	repositoryRepository := repository.NewCacheRepository(questionDAO, questionCache)
+	readThroughCache := testioc.InitReadThroughCache()
+	publishedCache := cache.NewPublishedCache(readThroughCache)
+	repositoryRepository := repository.NewCacheRepository(questionDAO, questionCache, publishedCache)
 	mq := testioc.InitMQ()
 	interactiveEventProducer, err := event.NewInteractiveEventProducer(mq)
 	if err != nil {
@@ -41,12 +43,16 @@ func InitModule(p event.SyncDataToSearchEventProducer, knowledgeBaseP event.Know
 	}
 	serviceService := service.NewService(repositoryRepository, p, interactiveEventProducer, knowledgeBaseP)
 	questionSetDAO := baguwen.InitQuestionSetDAO(db)
-	questionSetRepository := repository.NewQuestionSetRepository(questionSetDAO)
+	questionSetRepository := repository.NewQuestionSetRepository(questionSetDAO, publishedCache)
 	questionSetService := service.NewQuestionSetService(questionSetRepository, repositoryRepository, interactiveEventProducer, p)
 	examineDAO := dao.NewGORMExamineDAO(db)
 	examineRepository := repository.NewCachedExamineRepository(examineDAO)
 	llmService := aiModule.Svc
-	examineService := service.NewLLMExamineService(repositoryRepository, examineRepository, llmService)
+	examineEventProducer, err := event.NewExamineEventProducer(mq)
+	if err != nil {
+		return nil, err
+	}
+	examineService := service.NewLLMExamineService(repositoryRepository, examineRepository, llmService, examineEventProducer)
 	adminHandler := web.NewAdminHandler(serviceService)
 	adminQuestionSetHandler := web.NewAdminQuestionSetHandler(questionSetService)
 	service2 := intrModule.Svc
@@ -56,6 +62,7 @@ func InitModule(p event.SyncDataToSearchEventProducer, knowledgeBaseP event.Know
 	questionSetHandler := web.NewQuestionSetHandler(questionSetService, examineService, service2, sp)
 	examineHandler := web.NewExamineHandler(examineService)
 	knowledgeJobStarter := initKnowledgeJobStarter(serviceService)
+	scheduledPublishJob := initScheduledPublishJob(serviceService)
 	repositoryBaseSvc := aiModule.KnowledgeBaseSvc
 	questionKnowledgeBase := initKnowledgeBaseSvc(repositoryBaseSvc, repositoryRepository)
 	knowledgeBaseHandler := web.NewKnowledgeBaseHandler(questionKnowledgeBase)
@@ -69,6 +76,7 @@ func InitModule(p event.SyncDataToSearchEventProducer, knowledgeBaseP event.Know
 		QsHdl:               questionSetHandler,
 		ExamineHdl:          examineHandler,
 		KnowledgeJobStarter: knowledgeJobStarter,
+		ScheduledPublishJob: scheduledPublishJob,
 		KnowledgeBaseHdl:    knowledgeBaseHandler,
 	}
 	return module, nil
@@ -76,12 +84,18 @@ func InitModule(p event.SyncDataToSearchEventProducer, knowledgeBaseP event.Know
 
 // wire.go:
 
-var moduleSet = wire.NewSet(baguwen.InitQuestionDAO, cache.NewQuestionECache, repository.NewCacheRepository, service.NewService, web.NewHandler, web.NewAdminHandler, initKnowledgeJobStarter, web.NewAdminQuestionSetHandler, baguwen.ExamineHandlerSet, baguwen.InitQuestionSetDAO, repository.NewQuestionSetRepository, service.NewQuestionSetService, web.NewQuestionSetHandler, initKnowledgeBaseSvc, web.NewKnowledgeBaseHandler, wire.Struct(new(baguwen.Module), "*"))
+var moduleSet = wire.NewSet(baguwen.InitQuestionDAO, cache.NewQuestionECache, cache.NewPublishedCache, repository.NewCacheRepository, service.NewService, web.NewHandler, web.NewAdminHandler, initKnowledgeJobStarter,
+	initScheduledPublishJob, web.NewAdminQuestionSetHandler, baguwen.ExamineHandlerSet, baguwen.InitQuestionSetDAO, repository.NewQuestionSetRepository, service.NewQuestionSetService, web.NewQuestionSetHandler, initKnowledgeBaseSvc, web.NewKnowledgeBaseHandler, wire.Struct(new(baguwen.Module), "*"),
+)
 
 func initKnowledgeJobStarter(svc service.Service) *job.KnowledgeJobStarter {
 	return job.NewKnowledgeJobStarter(svc, os.TempDir())
 }
 
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
 func initKnowledgeBaseSvc(svc ai.KnowledgeBaseService, queRepo repository.Repository) service.QuestionKnowledgeBase {
 	return service.NewQuestionKnowledgeBase("knowledge_id", queRepo, svc)
 }
@@ -93,7 +93,7 @@ func (s *KnowledgeJobStarter) Batch(ctx ejob.Context, offset, limit int, writer
 	})
 	// 优化性能
 	for _, qid := range ids {
-		detail, err := s.svc.PubDetail(batchCtx, qid)
+		detail, err := s.svc.PubDetail(batchCtx, 0, qid)
 		if err != nil {
 			return 0, err
 		}
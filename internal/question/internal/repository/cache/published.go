@@ -0,0 +1,92 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+	"github.com/ecodeclub/webook/internal/question/internal/domain"
+)
+
+// PublishedCache 已发布内容的读穿透缓存
+// 详情按照 id 缓存，列表页和题集没办法精确删除，所以 key 里面带上版本号，失效的时候删除版本号
+type PublishedCache interface {
+	GetQuestion(ctx context.Context, id int64,
+		load func(ctx context.Context) (domain.Question, error)) (domain.Question, error)
+	GetQuestionList(ctx context.Context, biz string, offset, limit int,
+		load func(ctx context.Context) ([]domain.Question, error)) ([]domain.Question, error)
+	GetQuestionSet(ctx context.Context, id int64,
+		load func(ctx context.Context) (domain.QuestionSet, error)) (domain.QuestionSet, error)
+	GetQuestionSetList(ctx context.Context, biz string, offset, limit int,
+		load func(ctx context.Context) ([]domain.QuestionSet, error)) ([]domain.QuestionSet, error)
+
+	// InvalidateQuestion 题目发布、下线或者删除之后调用
+	// 题集里面也有题目的数据，所以题集的缓存也会一起失效
+	InvalidateQuestion(ctx context.Context, id int64) error
+	// InvalidateQuestionSet 题集修改之后调用
+	InvalidateQuestionSet(ctx context.Context) error
+}
+
+type publishedCache struct {
+	rc *cachex.ReadThroughCache
+}
+
+func NewPublishedCache(rc *cachex.ReadThroughCache) PublishedCache {
+	return &publishedCache{
+		rc: rc.WithNamespace("question:pub:"),
+	}
+}
+
+func (p *publishedCache) GetQuestion(ctx context.Context, id int64,
+	load func(ctx context.Context) (domain.Question, error)) (domain.Question, error) {
+	return cachex.Get(ctx, p.rc, p.questionKey(id), load)
+}
+
+func (p *publishedCache) GetQuestionList(ctx context.Context, biz string, offset, limit int,
+	load func(ctx context.Context) ([]domain.Question, error)) ([]domain.Question, error) {
+	key := fmt.Sprintf("list:%s:%s:%d:%d", p.rc.Version(ctx, p.versionKey()), biz, offset, limit)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) GetQuestionSet(ctx context.Context, id int64,
+	load func(ctx context.Context) (domain.QuestionSet, error)) (domain.QuestionSet, error) {
+	key := fmt.Sprintf("set:%s:%d", p.rc.Version(ctx, p.versionKey()), id)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) GetQuestionSetList(ctx context.Context, biz string, offset, limit int,
+	load func(ctx context.Context) ([]domain.QuestionSet, error)) ([]domain.QuestionSet, error) {
+	key := fmt.Sprintf("setList:%s:%s:%d:%d", p.rc.Version(ctx, p.versionKey()), biz, offset, limit)
+	return cachex.Get(ctx, p.rc, key, load)
+}
+
+func (p *publishedCache) InvalidateQuestion(ctx context.Context, id int64) error {
+	return p.rc.Delete(ctx, p.questionKey(id), p.versionKey())
+}
+
+func (p *publishedCache) InvalidateQuestionSet(ctx context.Context) error {
+	return p.rc.Delete(ctx, p.versionKey())
+}
+
+func (p *publishedCache) questionKey(id int64) string {
+	return fmt.Sprintf("detail:%d", id)
+}
+
+// versionKey 列表页和题集共用一个版本号
+func (p *publishedCache) versionKey() string {
+	return "version"
+}
@@ -30,6 +30,13 @@ type ExamineDAO interface {
 	GetResultByUidAndQid(ctx context.Context, uid int64, qid int64) (QuestionResult, error)
 	GetResultByUidAndQids(ctx context.Context, uid int64, ids []int64) ([]QuestionResult, error)
 	UpdateQuestionResult(ctx context.Context, result QuestionResult) error
+	// MergeResults 把 sourceUid 的测试记录和结果转移到 targetUid 上，两边都测试过的保留更好的结果，
+	// 返回 targetUid 上发生了变化的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]QuestionResult, error)
+	// FindRecordsByUid 用户所有的测试记录，最早的在前面
+	FindRecordsByUid(ctx context.Context, uid int64) ([]ExamineRecord, error)
+	// DeleteByUid 删除用户的测试记录和结果
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 var _ ExamineDAO = &GORMExamineDAO{}
@@ -87,6 +94,72 @@ func (dao *GORMExamineDAO) UpdateQuestionResult(ctx context.Context, result Ques
 	})
 }
 
+func (dao *GORMExamineDAO) MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]QuestionResult, error) {
+	var changed []QuestionResult
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := tx.Model(&ExamineRecord{}).Where("uid = ?", sourceUid).
+			Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		var sources []QuestionResult
+		err = tx.Where("uid = ?", sourceUid).Find(&sources).Error
+		if err != nil || len(sources) == 0 {
+			return err
+		}
+		qids := make([]int64, 0, len(sources))
+		for _, src := range sources {
+			qids = append(qids, src.Qid)
+		}
+		var targets []QuestionResult
+		err = tx.Where("uid = ? AND qid IN ?", targetUid, qids).Find(&targets).Error
+		if err != nil {
+			return err
+		}
+		results := make(map[int64]uint8, len(targets))
+		for _, dst := range targets {
+			results[dst.Qid] = dst.Result
+		}
+		for _, src := range sources {
+			res, ok := results[src.Qid]
+			if ok && res >= src.Result {
+				continue
+			}
+			if ok {
+				err = tx.Model(&QuestionResult{}).Where("uid = ? AND qid = ?", targetUid, src.Qid).
+					Updates(map[string]any{"result": src.Result, "utime": now}).Error
+			} else {
+				err = tx.Model(&QuestionResult{}).Where("id = ?", src.Id).
+					Updates(map[string]any{"uid": targetUid, "utime": now}).Error
+			}
+			if err != nil {
+				return err
+			}
+			changed = append(changed, QuestionResult{Uid: targetUid, Qid: src.Qid, Result: src.Result})
+		}
+		// 转移走的已经不在 sourceUid 名下了，剩下的都是重复的
+		return tx.Where("uid = ?", sourceUid).Delete(&QuestionResult{}).Error
+	})
+	return changed, err
+}
+
+func (dao *GORMExamineDAO) FindRecordsByUid(ctx context.Context, uid int64) ([]ExamineRecord, error) {
+	var res []ExamineRecord
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMExamineDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("uid = ?", uid).Delete(&ExamineRecord{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&QuestionResult{}).Error
+	})
+}
+
 func NewGORMExamineDAO(db *egorm.Component) ExamineDAO {
 	return &GORMExamineDAO{db: db}
 }
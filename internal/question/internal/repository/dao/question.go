@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
+	"github.com/ecodeclub/webook/internal/question/internal/domain"
 	"github.com/ego-component/egorm"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -36,6 +38,19 @@ type QuestionDAO interface {
 	Delete(ctx context.Context, qid int64) error
 
 	Sync(ctx context.Context, que Question, eles []AnswerElement) (int64, error)
+	// Unpublish 删除线上库的数据，并且将制作库的状态修改为未发布
+	Unpublish(ctx context.Context, qid int64) error
+
+	// 定时发布 API
+	UpdateSchedule(ctx context.Context, qid int64, publishAt int64, unpublishAt int64) error
+	// ClearPublishAt 只有在定时发布时间没有被修改过的情况下才会清空
+	ClearPublishAt(ctx context.Context, qid int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]Question, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	// DuePublish 到期需要发布的数据，按照 id 升序，只返回 id 大于 minId 的
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]Question, error)
+	// DueUnpublish 到期需要下线的数据，按照 id 升序，只返回 id 大于 minId 的
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]Question, error)
 
 	// 线上库 API
 	PubList(ctx context.Context, offset int, limit int, biz string) ([]PublishQuestion, error)
@@ -47,6 +62,7 @@ type QuestionDAO interface {
 }
 
 type GORMQuestionDAO struct {
+	*schedulex.GORMDAO[Question]
 	db *egorm.Component
 }
 
@@ -258,6 +274,29 @@ func (g *GORMQuestionDAO) Sync(ctx context.Context, que Question, eles []AnswerE
 	return qid, err
 }
 
+func (g *GORMQuestionDAO) Unpublish(ctx context.Context, qid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 手动下线的时候也要清空定时发布，避免定时任务又把它发布出去
+		err := tx.Model(&Question{}).Where("id = ?", qid).Updates(map[string]any{
+			"status":       domain.UnPublishedStatus.ToUint8(),
+			"publish_at":   0,
+			"unpublish_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("id = ?", qid).Delete(&PublishQuestion{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("qid = ?", qid).Delete(&PublishAnswerElement{}).Error
+	})
+}
+
 func NewGORMQuestionDAO(db *egorm.Component) QuestionDAO {
-	return &GORMQuestionDAO{db: db}
+	return &GORMQuestionDAO{
+		GORMDAO: schedulex.NewGORMDAO[Question](db),
+		db:      db,
+	}
 }
@@ -41,6 +41,8 @@ type QuestionSetDAO interface {
 	GetByBiz(ctx context.Context, biz string, bizId int64) (QuestionSet, error)
 	// GetByIDsWithQuestions 返回题集和题集对应的题目id
 	GetByIDsWithQuestions(ctx context.Context, ids []int64) ([]QuestionSet, map[int64][]Question, error)
+	// GetIDsByQid 包含了该题目的题集
+	GetIDsByQid(ctx context.Context, qid int64) ([]int64, error)
 }
 
 type GORMQuestionSetDAO struct {
@@ -140,6 +142,13 @@ func (g *GORMQuestionSetDAO) GetByID(ctx context.Context, id int64) (QuestionSet
 	return qs, nil
 }
 
+func (g *GORMQuestionSetDAO) GetIDsByQid(ctx context.Context, qid int64) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(&QuestionSetQuestion{}).
+		Where("qid = ?", qid).Pluck("qs_id", &res).Error
+	return res, err
+}
+
 func (g *GORMQuestionSetDAO) GetQuestionsByID(ctx context.Context, id int64) ([]Question, error) {
 	var qsq []QuestionSetQuestion
 	tx := g.db.WithContext(ctx)
@@ -34,8 +34,11 @@ type Question struct {
 	BizId int64  `gorm:"index:biz;not null;default:0;"`
 
 	Status uint8 `gorm:"type:tinyint(3);comment:0-未知 1-未发表 2-已发表"`
-	Ctime  int64
-	Utime  int64 `gorm:"index"`
+	// 定时发布和定时下线的时间，0 代表没有设置
+	PublishAt   int64 `gorm:"index;not null;default:0"`
+	UnpublishAt int64 `gorm:"index;not null;default:0"`
+	Ctime       int64
+	Utime       int64 `gorm:"index"`
 }
 
 type PublishQuestion Question
@@ -94,7 +97,7 @@ type QuestionSet struct {
 type QuestionSetQuestion struct {
 	Id    int64 `gorm:"primaryKey,autoIncrement"`
 	QSID  int64 `gorm:"column:qs_id;uniqueIndex:qsid_qid"`
-	QID   int64 `gorm:"column:qid;uniqueIndex:qsid_qid"`
+	QID   int64 `gorm:"column:qid;uniqueIndex:qsid_qid;index"`
 	Ctime int64
 	Utime int64 `gorm:"index"`
 }
@@ -28,6 +28,10 @@ type ExamineRepository interface {
 	GetResultByUidAndQid(ctx context.Context, uid int64, qid int64) (domain.Result, error)
 	GetResultsByIds(ctx context.Context, uid int64, ids []int64) ([]domain.ExamineResult, error)
 	UpdateQuestionResult(ctx context.Context, uid int64, qid int64, result domain.Result) error
+	// MergeResults 返回 targetUid 上发生了变化的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]domain.ExamineResult, error)
+	Records(ctx context.Context, uid int64) ([]domain.ExamineResult, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 var _ ExamineRepository = &CachedExamineRepository{}
@@ -77,6 +81,35 @@ func (repo *CachedExamineRepository) UpdateQuestionResult(ctx context.Context, u
 	return err
 }
 
+func (repo *CachedExamineRepository) MergeResults(ctx context.Context, sourceUid, targetUid int64) ([]domain.ExamineResult, error) {
+	res, err := repo.dao.MergeResults(ctx, sourceUid, targetUid)
+	return slice.Map(res, func(idx int, src dao.QuestionResult) domain.ExamineResult {
+		return domain.ExamineResult{
+			Qid:    src.Qid,
+			Result: domain.Result(src.Result),
+		}
+	}), err
+}
+
+func (repo *CachedExamineRepository) Records(ctx context.Context, uid int64) ([]domain.ExamineResult, error) {
+	res, err := repo.dao.FindRecordsByUid(ctx, uid)
+	return slice.Map(res, func(idx int, src dao.ExamineRecord) domain.ExamineResult {
+		return domain.ExamineResult{
+			Qid:       src.Qid,
+			Result:    domain.Result(src.Result),
+			RawResult: src.RawResult,
+			Tokens:    src.Tokens,
+			Amount:    src.Amount,
+			Tid:       src.Tid,
+			Ctime:     src.Ctime,
+		}
+	}), err
+}
+
+func (repo *CachedExamineRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return repo.dao.DeleteByUid(ctx, uid)
+}
+
 func NewCachedExamineRepository(dao dao.ExamineDAO) ExamineRepository {
 	return &CachedExamineRepository{dao: dao}
 }
@@ -44,6 +44,15 @@ type Repository interface {
 
 	// Delete 会直接删除制作库和线上库的数据
 	Delete(ctx context.Context, qid int64) error
+	// Unpublish 下线，删除线上库的数据
+	Unpublish(ctx context.Context, qid int64) error
+
+	UpdateSchedule(ctx context.Context, qid int64, publishAt int64, unpublishAt int64) error
+	ClearPublishAt(ctx context.Context, qid int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Question, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error)
 
 	GetById(ctx context.Context, qid int64) (domain.Question, error)
 	GetPubByID(ctx context.Context, qid int64) (domain.Question, error)
@@ -53,11 +62,12 @@ type Repository interface {
 }
 
 // CachedRepository 支持缓存的 repository 实现
-// 虽然在 Save 的时候，理论上也需要更新缓存，但是没有必要
+// 只缓存线上库的数据，制作库的修改不影响缓存，发布、下线和删除的时候让缓存失效
 type CachedRepository struct {
-	dao    dao.QuestionDAO
-	cache  cache.QuestionCache
-	logger *elog.Component
+	dao      dao.QuestionDAO
+	cache    cache.QuestionCache
+	pubCache cache.PublishedCache
+	logger   *elog.Component
 }
 
 func (c *CachedRepository) PubCount(ctx context.Context, biz string) (int64, error) {
@@ -76,15 +86,16 @@ func (c *CachedRepository) GetPubByIDs(ctx context.Context, qids []int64) ([]dom
 }
 
 func (c *CachedRepository) GetPubByID(ctx context.Context, qid int64) (domain.Question, error) {
-	// 可以缓存
-	data, pubEles, err := c.dao.GetPubByID(ctx, qid)
-	if err != nil {
-		return domain.Question{}, err
-	}
-	eles := slice.Map(pubEles, func(idx int, src dao.PublishAnswerElement) dao.AnswerElement {
-		return dao.AnswerElement(src)
+	return c.pubCache.GetQuestion(ctx, qid, func(ctx context.Context) (domain.Question, error) {
+		data, pubEles, err := c.dao.GetPubByID(ctx, qid)
+		if err != nil {
+			return domain.Question{}, err
+		}
+		eles := slice.Map(pubEles, func(idx int, src dao.PublishAnswerElement) dao.AnswerElement {
+			return dao.AnswerElement(src)
+		})
+		return c.toDomainWithAnswer(dao.Question(data), eles), nil
 	})
-	return c.toDomainWithAnswer(dao.Question(data), eles), nil
 }
 
 func (c *CachedRepository) ExcludeQuestions(ctx context.Context, ids []int64, offset int, limit int) ([]domain.Question, int64, error) {
@@ -119,7 +130,62 @@ func (c *CachedRepository) GetById(ctx context.Context, qid int64) (domain.Quest
 }
 
 func (c *CachedRepository) Delete(ctx context.Context, qid int64) error {
-	return c.dao.Delete(ctx, qid)
+	err := c.dao.Delete(ctx, qid)
+	if err != nil {
+		return err
+	}
+	c.invalidate(ctx, qid)
+	return nil
+}
+
+func (c *CachedRepository) Unpublish(ctx context.Context, qid int64) error {
+	err := c.dao.Unpublish(ctx, qid)
+	if err != nil {
+		return err
+	}
+	c.invalidate(ctx, qid)
+	return nil
+}
+
+// invalidate 数据库已经修改成功了，缓存失效失败只记录日志，等缓存过期
+func (c *CachedRepository) invalidate(ctx context.Context, qid int64) {
+	err := c.pubCache.InvalidateQuestion(ctx, qid)
+	if err != nil {
+		c.logger.Error("让题目缓存失效失败", elog.FieldErr(err), elog.Int64("qid", qid))
+	}
+}
+
+func (c *CachedRepository) UpdateSchedule(ctx context.Context, qid int64, publishAt int64, unpublishAt int64) error {
+	return c.dao.UpdateSchedule(ctx, qid, publishAt, unpublishAt)
+}
+
+func (c *CachedRepository) ClearPublishAt(ctx context.Context, qid int64, publishAt int64) error {
+	return c.dao.ClearPublishAt(ctx, qid, publishAt)
+}
+
+func (c *CachedRepository) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Question, error) {
+	qs, err := c.dao.ScheduledList(ctx, offset, limit)
+	return slice.Map(qs, func(idx int, src dao.Question) domain.Question {
+		return c.toDomain(src)
+	}), err
+}
+
+func (c *CachedRepository) ScheduledCount(ctx context.Context) (int64, error) {
+	return c.dao.ScheduledCount(ctx)
+}
+
+func (c *CachedRepository) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error) {
+	qs, err := c.dao.DuePublish(ctx, now, minId, limit)
+	return slice.Map(qs, func(idx int, src dao.Question) domain.Question {
+		return c.toDomain(src)
+	}), err
+}
+
+func (c *CachedRepository) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error) {
+	qs, err := c.dao.DueUnpublish(ctx, now, minId, limit)
+	return slice.Map(qs, func(idx int, src dao.Question) domain.Question {
+		return c.toDomain(src)
+	}), err
 }
 
 func (c *CachedRepository) Update(ctx context.Context, question *domain.Question) error {
@@ -133,9 +199,13 @@ func (c *CachedRepository) Create(ctx context.Context, question *domain.Question
 }
 
 func (c *CachedRepository) Sync(ctx context.Context, que *domain.Question) (int64, error) {
-	// 理论上来说要更新缓存，但是我懒得写了
 	q, eles := c.toEntity(que)
-	return c.dao.Sync(ctx, q, eles)
+	id, err := c.dao.Sync(ctx, q, eles)
+	if err != nil {
+		return 0, err
+	}
+	c.invalidate(ctx, id)
+	return id, nil
 }
 
 func (c *CachedRepository) List(ctx context.Context, offset int, limit int) ([]domain.Question, error) {
@@ -150,11 +220,12 @@ func (c *CachedRepository) Total(ctx context.Context) (int64, error) {
 }
 
 func (c *CachedRepository) PubList(ctx context.Context, offset int, limit int, biz string) ([]domain.Question, error) {
-	// TODO 缓存第一页
-	qs, err := c.dao.PubList(ctx, offset, limit, biz)
-	return slice.Map(qs, func(idx int, src dao.PublishQuestion) domain.Question {
-		return c.toDomain(dao.Question(src))
-	}), err
+	return c.pubCache.GetQuestionList(ctx, biz, offset, limit, func(ctx context.Context) ([]domain.Question, error) {
+		qs, err := c.dao.PubList(ctx, offset, limit, biz)
+		return slice.Map(qs, func(idx int, src dao.PublishQuestion) domain.Question {
+			return c.toDomain(dao.Question(src))
+		}), err
+	})
 }
 
 func (c *CachedRepository) toDomainWithAnswer(que dao.Question, eles []dao.AnswerElement) domain.Question {
@@ -185,6 +256,9 @@ func (c *CachedRepository) toDomain(que dao.Question) domain.Question {
 		BizId:   que.BizId,
 		Status:  domain.QuestionStatus(que.Status),
 		Utime:   time.UnixMilli(que.Utime),
+
+		PublishAt:   que.PublishAt,
+		UnpublishAt: que.UnpublishAt,
 	}
 }
 
@@ -240,10 +314,11 @@ func (c *CachedRepository) ele2Entity(qid int64,
 	}
 }
 
-func NewCacheRepository(d dao.QuestionDAO, c cache.QuestionCache) Repository {
+func NewCacheRepository(d dao.QuestionDAO, c cache.QuestionCache, pc cache.PublishedCache) Repository {
 	return &CachedRepository{
-		dao:    d,
-		cache:  c,
-		logger: elog.DefaultLogger,
+		dao:      d,
+		cache:    c,
+		pubCache: pc,
+		logger:   elog.DefaultLogger,
 	}
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/question/internal/domain"
+	"github.com/ecodeclub/webook/internal/question/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/question/internal/repository/dao"
 	"github.com/gotomicro/ego/core/elog"
 )
@@ -39,13 +40,19 @@ type QuestionSetRepository interface {
 	ListByBiz(ctx context.Context, offset, limit int, biz string) ([]domain.QuestionSet, error)
 	GetByBiz(ctx context.Context, biz string, bizId int64) (domain.QuestionSet, error)
 	CountByBiz(ctx context.Context, biz string) (int64, error)
+	GetIDsByQid(ctx context.Context, qid int64) ([]int64, error)
 }
 
 var _ QuestionSetRepository = &questionSetRepository{}
 
 type questionSetRepository struct {
-	dao    dao.QuestionSetDAO
-	logger *elog.Component
+	dao      dao.QuestionSetDAO
+	pubCache cache.PublishedCache
+	logger   *elog.Component
+}
+
+func (q *questionSetRepository) GetIDsByQid(ctx context.Context, qid int64) ([]int64, error) {
+	return q.dao.GetIDsByQid(ctx, qid)
 }
 
 func (q *questionSetRepository) CountByBiz(ctx context.Context, biz string) (int64, error) {
@@ -96,13 +103,15 @@ func (q *questionSetRepository) GetByBiz(ctx context.Context, biz string, bizId
 }
 
 func (q *questionSetRepository) ListByBiz(ctx context.Context, offset, limit int, biz string) ([]domain.QuestionSet, error) {
-	qs, err := q.dao.ListByBiz(ctx, offset, limit, biz)
-	if err != nil {
-		return nil, err
-	}
-	return slice.Map(qs, func(idx int, src dao.QuestionSet) domain.QuestionSet {
-		return q.toDomainQuestionSet(src)
-	}), err
+	return q.pubCache.GetQuestionSetList(ctx, biz, offset, limit, func(ctx context.Context) ([]domain.QuestionSet, error) {
+		qs, err := q.dao.ListByBiz(ctx, offset, limit, biz)
+		if err != nil {
+			return nil, err
+		}
+		return slice.Map(qs, func(idx int, src dao.QuestionSet) domain.QuestionSet {
+			return q.toDomainQuestionSet(src)
+		}), err
+	})
 }
 
 func (q *questionSetRepository) GetByIDs(ctx context.Context, ids []int64) ([]domain.QuestionSet, error) {
@@ -113,11 +122,28 @@ func (q *questionSetRepository) GetByIDs(ctx context.Context, ids []int64) ([]do
 }
 
 func (q *questionSetRepository) UpdateNonZero(ctx context.Context, set domain.QuestionSet) error {
-	return q.dao.UpdateNonZero(ctx, q.toEntityQuestionSet(set))
+	err := q.dao.UpdateNonZero(ctx, q.toEntityQuestionSet(set))
+	if err != nil {
+		return err
+	}
+	q.invalidate(ctx)
+	return nil
 }
 
 func (q *questionSetRepository) Create(ctx context.Context, set domain.QuestionSet) (int64, error) {
-	return q.dao.Create(ctx, q.toEntityQuestionSet(set))
+	id, err := q.dao.Create(ctx, q.toEntityQuestionSet(set))
+	if err != nil {
+		return 0, err
+	}
+	q.invalidate(ctx)
+	return id, nil
+}
+
+func (q *questionSetRepository) invalidate(ctx context.Context) {
+	err := q.pubCache.InvalidateQuestionSet(ctx)
+	if err != nil {
+		q.logger.Error("让题集缓存失效失败", elog.FieldErr(err))
+	}
 }
 
 func (q *questionSetRepository) toEntityQuestionSet(d domain.QuestionSet) dao.QuestionSet {
@@ -137,7 +163,12 @@ func (q *questionSetRepository) UpdateQuestions(ctx context.Context, set domain.
 	for i := range set.Questions {
 		qids[i] = set.Questions[i].Id
 	}
-	return q.dao.UpdateQuestionsByID(ctx, set.Id, qids)
+	err := q.dao.UpdateQuestionsByID(ctx, set.Id, qids)
+	if err != nil {
+		return err
+	}
+	q.invalidate(ctx)
+	return nil
 }
 
 func (q *questionSetRepository) getPubDomainQuestions(ctx context.Context, id int64) ([]domain.Question, error) {
@@ -196,6 +227,12 @@ func (q *questionSetRepository) GetByID(ctx context.Context, id int64) (domain.Q
 }
 
 func (q *questionSetRepository) PubGetByID(ctx context.Context, id int64) (domain.QuestionSet, error) {
+	return q.pubCache.GetQuestionSet(ctx, id, func(ctx context.Context) (domain.QuestionSet, error) {
+		return q.pubGetByID(ctx, id)
+	})
+}
+
+func (q *questionSetRepository) pubGetByID(ctx context.Context, id int64) (domain.QuestionSet, error) {
 	set, err := q.dao.GetByID(ctx, id)
 	if err != nil {
 		return domain.QuestionSet{}, err
@@ -244,8 +281,9 @@ func (q *questionSetRepository) toDomainQuestionSet(qs dao.QuestionSet) domain.Q
 	}
 }
 
-func NewQuestionSetRepository(d dao.QuestionSetDAO) QuestionSetRepository {
+func NewQuestionSetRepository(d dao.QuestionSetDAO, pc cache.PublishedCache) QuestionSetRepository {
 	return &questionSetRepository{
-		dao:    d,
-		logger: elog.DefaultLogger}
+		dao:      d,
+		pubCache: pc,
+		logger:   elog.DefaultLogger}
 }
@@ -21,7 +21,9 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/question/internal/domain"
+	"github.com/ecodeclub/webook/internal/question/internal/event"
 	"github.com/ecodeclub/webook/internal/question/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
 	"github.com/lithammer/shortuuid/v4"
 )
 
@@ -37,15 +39,23 @@ type ExamineService interface {
 	QuestionResult(ctx context.Context, uid, qid int64) (domain.Result, error)
 	GetResults(ctx context.Context, uid int64, ids []int64) (map[int64]domain.ExamineResult, error)
 	Correct(ctx context.Context, uid int64, qid int64, questionResult domain.Result) error
+	// MergeResults 合并账号的时候，把 sourceUid 的测试结果转移到 targetUid 上，两边都测试过的保留更好的结果
+	MergeResults(ctx context.Context, sourceUid, targetUid int64) error
+	// Records 用户所有的测试记录，导出个人数据的时候使用
+	Records(ctx context.Context, uid int64) ([]domain.ExamineResult, error)
+	// DeleteResults 注销账号的时候删除测试记录和结果
+	DeleteResults(ctx context.Context, uid int64) error
 }
 
 var _ ExamineService = &LLMExamineService{}
 
 // LLMExamineService 使用 LLM 进行评价的测试服务
 type LLMExamineService struct {
-	queRepo repository.Repository
-	repo    repository.ExamineRepository
-	aiSvc   ai.LLMService
+	queRepo  repository.Repository
+	repo     repository.ExamineRepository
+	aiSvc    ai.LLMService
+	producer event.ExamineEventProducer
+	logger   *elog.Component
 }
 
 func (svc *LLMExamineService) GetResults(ctx context.Context, uid int64, ids []int64) (map[int64]domain.ExamineResult, error) {
@@ -96,7 +106,27 @@ func (svc *LLMExamineService) Examine(ctx context.Context,
 	}
 	// 开始记录结果
 	err = svc.repo.SaveResult(ctx, uid, qid, result)
-	return result, err
+	if err != nil {
+		return result, err
+	}
+	svc.sendExamineEvent(ctx, uid, qid, result.Result)
+	return result, nil
+}
+
+// sendExamineEvent 结果已经保存了，发送失败只记录日志
+func (svc *LLMExamineService) sendExamineEvent(ctx context.Context, uid, qid int64, res domain.Result) {
+	err := svc.producer.Produce(ctx, event.ExamineEvent{
+		Uid:    uid,
+		Biz:    domain.QuestionBiz,
+		BizId:  qid,
+		Result: res.ToUint8(),
+	})
+	if err != nil {
+		svc.logger.Error("发送测试结果事件失败",
+			elog.FieldErr(err),
+			elog.Int64("uid", uid),
+			elog.Int64("qid", qid))
+	}
 }
 
 func (svc *LLMExamineService) getKeyPoints(
@@ -156,7 +186,32 @@ func (svc *LLMExamineService) score(ctx context.Context,
 func (svc *LLMExamineService) Correct(ctx context.Context, uid int64,
 	qid int64, questionResult domain.Result) error {
 	// 更新结果
-	return svc.repo.UpdateQuestionResult(ctx, uid, qid, questionResult)
+	err := svc.repo.UpdateQuestionResult(ctx, uid, qid, questionResult)
+	if err != nil {
+		return err
+	}
+	svc.sendExamineEvent(ctx, uid, qid, questionResult)
+	return nil
+}
+
+func (svc *LLMExamineService) MergeResults(ctx context.Context, sourceUid, targetUid int64) error {
+	results, err := svc.repo.MergeResults(ctx, sourceUid, targetUid)
+	if err != nil {
+		return err
+	}
+	// 学习进度是根据测试结果事件计算的，所以变化了的结果要重新发一遍
+	for _, res := range results {
+		svc.sendExamineEvent(ctx, targetUid, res.Qid, res.Result)
+	}
+	return nil
+}
+
+func (svc *LLMExamineService) Records(ctx context.Context, uid int64) ([]domain.ExamineResult, error) {
+	return svc.repo.Records(ctx, uid)
+}
+
+func (svc *LLMExamineService) DeleteResults(ctx context.Context, uid int64) error {
+	return svc.repo.DeleteByUid(ctx, uid)
 }
 
 func (svc *LLMExamineService) parseExamineResult(answer string) domain.Result {
@@ -190,10 +245,13 @@ func NewLLMExamineService(
 	queRepo repository.Repository,
 	repo repository.ExamineRepository,
 	aiSvc ai.LLMService,
+	producer event.ExamineEventProducer,
 ) ExamineService {
 	return &LLMExamineService{
-		queRepo: queRepo,
-		repo:    repo,
-		aiSvc:   aiSvc,
+		queRepo:  queRepo,
+		repo:     repo,
+		aiSvc:    aiSvc,
+		producer: producer,
+		logger:   elog.DefaultLogger,
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/ecodeclub/webook/internal/question/internal/event"
 	"github.com/gotomicro/ego/core/elog"
 
@@ -27,6 +28,8 @@ import (
 	"github.com/ecodeclub/webook/internal/question/internal/repository"
 )
 
+var ErrInvalidSchedule = schedulex.ErrInvalidSchedule
+
 // Service TODO 要分离制作库接口和线上库接口
 //
 //go:generate mockgen -source=./question.go -destination=../../mocks/question.mock.go -package=quemocks -typed=true Service
@@ -38,12 +41,24 @@ type Service interface {
 	Detail(ctx context.Context, qid int64) (domain.Question, error)
 	// Delete 会直接删除制作库和线上库的数据
 	Delete(ctx context.Context, qid int64) error
+	// Unpublish 下线，只会删除线上库的数据，制作库的数据会保留
+	Unpublish(ctx context.Context, qid int64) error
+
+	// Schedule 设置定时发布和定时下线的时间，毫秒数，0 代表取消
+	Schedule(ctx context.Context, qid int64, publishAt int64, unpublishAt int64) error
+	ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Question, int64, error)
+	// DuePublish 和 DueUnpublish 给定时任务使用
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error)
+	// PublishScheduled 发布制作库中的数据，并且清空定时发布时间
+	PublishScheduled(ctx context.Context, que domain.Question) error
 
 	// PubList 只会返回八股文的数据
 	PubList(ctx context.Context, offset int, limit int) (int64, []domain.Question, error)
 	// GetPubByIDs 目前只会获取基础信息，也就是不包括答案在内的信息
 	GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Question, error)
-	PubDetail(ctx context.Context, qid int64) (domain.Question, error)
+	// PubDetail uid 是浏览者，没有登录的时候为 0
+	PubDetail(ctx context.Context, uid, qid int64) (domain.Question, error)
 }
 
 type service struct {
@@ -60,13 +75,13 @@ func (s *service) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Questi
 	return s.repo.GetPubByIDs(ctx, ids)
 }
 
-func (s *service) PubDetail(ctx context.Context, qid int64) (domain.Question, error) {
+func (s *service) PubDetail(ctx context.Context, uid, qid int64) (domain.Question, error) {
 	que, err := s.repo.GetPubByID(ctx, qid)
 	if err == nil {
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := s.intrProducer.Produce(newCtx, event.NewViewCntEvent(qid, domain.QuestionBiz))
+			err1 := s.intrProducer.Produce(newCtx, event.NewViewCntEvent(qid, domain.QuestionBiz, uid))
 			if err1 != nil {
 				s.logger.Error("发送问题阅读计数消息到消息队列失败", elog.FieldErr(err1), elog.Int64("qid", qid))
 			}
@@ -164,6 +179,77 @@ func (s *service) Publish(ctx context.Context, question *domain.Question) (int64
 	return id, nil
 }
 
+func (s *service) Unpublish(ctx context.Context, qid int64) error {
+	err := s.repo.Unpublish(ctx, qid)
+	if err != nil {
+		return err
+	}
+	qctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	que, eerr := s.getQuestion(qctx, qid)
+	if eerr == nil {
+		// 同步到搜索服务，搜索那边只会返回已发布的数据
+		s.syncQuestion(qctx, que)
+	}
+	// 从知识库中删除
+	evt := event.NewDeleteKnowledgeBaseEvent(qid)
+	eerr = s.knowledgeBaseProducer.Produce(qctx, evt)
+	if eerr != nil {
+		s.logger.Error("发送删除知识库事件失败",
+			elog.FieldErr(eerr),
+			elog.Any("event", evt),
+		)
+	}
+	return nil
+}
+
+func (s *service) Schedule(ctx context.Context, qid int64, publishAt int64, unpublishAt int64) error {
+	if err := schedulex.Check(publishAt, unpublishAt); err != nil {
+		return err
+	}
+	return s.repo.UpdateSchedule(ctx, qid, publishAt, unpublishAt)
+}
+
+func (s *service) ScheduledList(ctx context.Context, offset int, limit int) ([]domain.Question, int64, error) {
+	var (
+		eg    errgroup.Group
+		qs    []domain.Question
+		total int64
+	)
+	eg.Go(func() error {
+		var err error
+		qs, err = s.repo.ScheduledList(ctx, offset, limit)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		total, err = s.repo.ScheduledCount(ctx)
+		return err
+	})
+	return qs, total, eg.Wait()
+}
+
+func (s *service) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error) {
+	return s.repo.DuePublish(ctx, now, minId, limit)
+}
+
+func (s *service) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Question, error) {
+	return s.repo.DueUnpublish(ctx, now, minId, limit)
+}
+
+func (s *service) PublishScheduled(ctx context.Context, que domain.Question) error {
+	// 定时发布用的是制作库中的数据，所以要把答案也查询出来
+	detail, err := s.repo.GetById(ctx, que.Id)
+	if err != nil {
+		return err
+	}
+	_, err = s.Publish(ctx, &detail)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearPublishAt(ctx, que.Id, que.PublishAt)
+}
+
 func NewService(repo repository.Repository,
 	syncEvent event.SyncDataToSearchEventProducer,
 	intrEvent event.InteractiveEventProducer,
@@ -36,13 +36,16 @@ type QuestionSetService interface {
 	UpdateQuestions(ctx context.Context, set domain.QuestionSet) error
 	List(ctx context.Context, offset, limit int) ([]domain.QuestionSet, int64, error)
 	ListDefault(ctx context.Context, offset, limit int) ([]domain.QuestionSet, int64, error)
-	Detail(ctx context.Context, id int64) (domain.QuestionSet, error)
+	Detail(ctx context.Context, uid, id int64) (domain.QuestionSet, error)
 	GetByIds(ctx context.Context, ids []int64) ([]domain.QuestionSet, error)
 	DetailByBiz(ctx context.Context, biz string, bizId int64) (domain.QuestionSet, error)
 	GetCandidates(ctx context.Context, id int64, offset int, limit int) ([]domain.Question, int64, error)
 	GetByIDsWithQuestion(ctx context.Context, ids []int64) ([]domain.QuestionSet, error)
 
-	PubDetail(ctx context.Context, id int64) (domain.QuestionSet, error)
+	// PubDetail uid 是浏览者，没有登录的时候为 0
+	PubDetail(ctx context.Context, uid, id int64) (domain.QuestionSet, error)
+	// GetIDsByQid 包含了该题目的题集 id
+	GetIDsByQid(ctx context.Context, qid int64) ([]int64, error)
 }
 
 type questionSetService struct {
@@ -54,6 +57,10 @@ type questionSetService struct {
 	syncTimeout  time.Duration
 }
 
+func (q *questionSetService) GetIDsByQid(ctx context.Context, qid int64) ([]int64, error) {
+	return q.repo.GetIDsByQid(ctx, qid)
+}
+
 func (q *questionSetService) GetByIDsWithQuestion(ctx context.Context, ids []int64) ([]domain.QuestionSet, error) {
 	return q.repo.GetByIDsWithQuestion(ctx, ids)
 }
@@ -125,14 +132,14 @@ func (q *questionSetService) UpdateQuestions(ctx context.Context, set domain.Que
 	return nil
 }
 
-func (q *questionSetService) Detail(ctx context.Context, id int64) (domain.QuestionSet, error) {
+func (q *questionSetService) Detail(ctx context.Context, uid, id int64) (domain.QuestionSet, error) {
 	qs, err := q.repo.GetByID(ctx, id)
 	if err == nil {
 		// 没有区分 B 端还是 C 端，但是这种计数不需要精确计算
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := q.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.QuestionSetBiz))
+			err1 := q.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.QuestionSetBiz, uid))
 			if err1 != nil {
 				q.logger.Error("发送阅读计数消息到消息队列失败", elog.FieldErr(err1), elog.Int64("qsid", id))
 			}
@@ -141,14 +148,14 @@ func (q *questionSetService) Detail(ctx context.Context, id int64) (domain.Quest
 	return qs, err
 }
 
-func (q *questionSetService) PubDetail(ctx context.Context, id int64) (domain.QuestionSet, error) {
+func (q *questionSetService) PubDetail(ctx context.Context, uid, id int64) (domain.QuestionSet, error) {
 	qs, err := q.repo.PubGetByID(ctx, id)
 	if err == nil {
 		// 没有区分 B 端还是 C 端，但是这种计数不需要精确计算
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := q.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.QuestionSetBiz))
+			err1 := q.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.QuestionSetBiz, uid))
 			if err1 != nil {
 				q.logger.Error("发送阅读计数消息到消息队列失败", elog.FieldErr(err1), elog.Int64("qsid", id))
 			}
@@ -0,0 +1,87 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/question/internal/domain"
+	"github.com/ecodeclub/webook/internal/question/internal/event"
+	"github.com/ecodeclub/webook/internal/question/internal/repository"
+)
+
+// questionSearchSource 搜索重建索引的时候读取全量的题目
+// 和同步消息一样使用制作库的数据，搜索那边会按照状态过滤
+type questionSearchSource struct {
+	repo repository.Repository
+}
+
+func NewQuestionSearchSource(repo repository.Repository) searchx.Source {
+	return &questionSearchSource{repo: repo}
+}
+
+func (q *questionSearchSource) Biz() string {
+	return domain.QuestionBiz
+}
+
+func (q *questionSearchSource) Ids(ctx context.Context) ([]int64, error) {
+	return q.repo.QuestionIds(ctx)
+}
+
+func (q *questionSearchSource) Doc(ctx context.Context, id int64) (string, error) {
+	que, err := q.repo.GetById(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewQuestionEvent(que).Data, nil
+}
+
+type questionSetSearchSource struct {
+	repo      repository.QuestionSetRepository
+	batchSize int
+}
+
+func NewQuestionSetSearchSource(repo repository.QuestionSetRepository) searchx.Source {
+	return &questionSetSearchSource{repo: repo, batchSize: 100}
+}
+
+func (q *questionSetSearchSource) Biz() string {
+	return domain.QuestionSetBiz
+}
+
+func (q *questionSetSearchSource) Ids(ctx context.Context) ([]int64, error) {
+	var res []int64
+	for offset := 0; ; offset += q.batchSize {
+		sets, err := q.repo.List(ctx, offset, q.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range sets {
+			res = append(res, set.Id)
+		}
+		if len(sets) < q.batchSize {
+			return res, nil
+		}
+	}
+}
+
+func (q *questionSetSearchSource) Doc(ctx context.Context, id int64) (string, error) {
+	set, err := q.repo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewQuestionSetEvent(set).Data, nil
+}
@@ -35,6 +35,9 @@ func (h AdminBaseHandler) toQuestionList(data []domain.Question, cnt int64) Ques
 				BizId:   src.BizId,
 				Status:  src.Status.ToUint8(),
 				Utime:   src.Utime.UnixMilli(),
+
+				PublishAt:   src.PublishAt,
+				UnpublishAt: src.UnpublishAt,
 			}
 		}),
 	}
@@ -15,6 +15,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/interactive"
@@ -40,6 +42,39 @@ func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
 	server.POST("/question/detail", ginx.B[Qid](h.Detail))
 	server.POST("/question/delete", ginx.B[Qid](h.Delete))
 	server.POST("/question/publish", ginx.BS[SaveReq](h.Publish))
+	server.POST("/question/unpublish", ginx.B[Qid](h.Unpublish))
+	server.POST("/question/schedule", ginx.B[ScheduleReq](h.Schedule))
+	server.POST("/question/scheduled/list", ginx.B[Page](h.ScheduledList))
+}
+
+func (h *AdminHandler) Unpublish(ctx *ginx.Context, qid Qid) (ginx.Result, error) {
+	err := h.svc.Unpublish(ctx, qid.Qid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *AdminHandler) Schedule(ctx *ginx.Context, req ScheduleReq) (ginx.Result, error) {
+	err := h.svc.Schedule(ctx, req.Qid, req.PublishAt, req.UnpublishAt)
+	switch {
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return invalidScheduleResult, err
+	case err != nil:
+		return systemErrorResult, err
+	default:
+		return ginx.Result{}, nil
+	}
+}
+
+func (h *AdminHandler) ScheduledList(ctx *ginx.Context, req Page) (ginx.Result, error) {
+	data, cnt, err := h.svc.ScheduledList(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: h.toQuestionList(data, cnt),
+	}, nil
 }
 
 func (h *AdminHandler) Delete(ctx *ginx.Context, qid Qid) (ginx.Result, error) {
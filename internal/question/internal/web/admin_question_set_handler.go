@@ -40,7 +40,7 @@ func (h *AdminQuestionSetHandler) PrivateRoutes(server *gin.Engine) {
 	g.POST("/save", ginx.BS[QuestionSet](h.SaveQuestionSet))
 	g.POST("/questions/save", ginx.BS[UpdateQuestions](h.UpdateQuestions))
 	g.POST("/list", ginx.B[Page](h.ListQuestionSets))
-	g.POST("/detail", ginx.B(h.RetrieveQuestionSetDetail))
+	g.POST("/detail", ginx.BS(h.RetrieveQuestionSetDetail))
 	g.POST("/candidate", ginx.B[CandidateReq](h.Candidate))
 }
 
@@ -110,8 +110,8 @@ func (h *AdminQuestionSetHandler) ListQuestionSets(ctx *ginx.Context, req Page)
 
 func (h *AdminQuestionSetHandler) RetrieveQuestionSetDetail(
 	ctx *ginx.Context,
-	req QuestionSetID) (ginx.Result, error) {
-	data, err := h.svc.Detail(ctx.Request.Context(), req.QSID)
+	req QuestionSetID, sess session.Session) (ginx.Result, error) {
+	data, err := h.svc.Detail(ctx.Request.Context(), sess.Claims().Uid, req.QSID)
 	if err != nil {
 		return systemErrorResult, err
 	}
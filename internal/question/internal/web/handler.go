@@ -76,7 +76,7 @@ func (h *Handler) PubDetail(ctx *ginx.Context,
 		examine domain.Result
 	)
 
-	detail, err := h.svc.PubDetail(ctx, req.Qid)
+	detail, err := h.svc.PubDetail(ctx, h.getUid(ctx), req.Qid)
 	if err != nil {
 		return systemErrorResult, fmt.Errorf("查找面试题详情失败 %w", err)
 	}
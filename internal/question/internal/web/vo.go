@@ -47,6 +47,10 @@ type Question struct {
 
 	ExamineResult uint8 `json:"examineResult"`
 
+	// 定时发布和定时下线的时间，只有管理后台会用到
+	PublishAt   int64 `json:"publishAt,omitempty"`
+	UnpublishAt int64 `json:"unpublishAt,omitempty"`
+
 	// 是否有权限
 	Permitted bool `json:"permitted"`
 }
@@ -139,6 +143,13 @@ type Qid struct {
 	Qid int64 `json:"qid"`
 }
 
+// ScheduleReq 定时发布和定时下线，毫秒数，0 代表取消
+type ScheduleReq struct {
+	Qid         int64 `json:"qid"`
+	PublishAt   int64 `json:"publishAt"`
+	UnpublishAt int64 `json:"unpublishAt"`
+}
+
 type QuestionList struct {
 	Questions []Question `json:"questions,omitempty"`
 	Total     int64      `json:"total,omitempty"`
@@ -183,6 +194,7 @@ type QuestionSetList struct {
 
 type Interactive struct {
 	CollectCnt int  `json:"collectCnt"`
+	CommentCnt int  `json:"commentCnt"`
 	LikeCnt    int  `json:"likeCnt"`
 	ViewCnt    int  `json:"viewCnt"`
 	Liked      bool `json:"liked"`
@@ -192,6 +204,7 @@ type Interactive struct {
 func newInteractive(intr interactive.Interactive) Interactive {
 	return Interactive{
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		ViewCnt:    intr.ViewCnt,
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
@@ -40,6 +40,82 @@ func (m *MockExamineService) EXPECT() *MockExamineServiceMockRecorder {
 	return m.recorder
 }
 
+// Correct mocks base method.
+func (m *MockExamineService) Correct(ctx context.Context, uid, qid int64, questionResult domain.Result) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", ctx, uid, qid, questionResult)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockExamineServiceMockRecorder) Correct(ctx, uid, qid, questionResult any) *MockExamineServiceCorrectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockExamineService)(nil).Correct), ctx, uid, qid, questionResult)
+	return &MockExamineServiceCorrectCall{Call: call}
+}
+
+// MockExamineServiceCorrectCall wrap *gomock.Call
+type MockExamineServiceCorrectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExamineServiceCorrectCall) Return(arg0 error) *MockExamineServiceCorrectCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExamineServiceCorrectCall) Do(f func(context.Context, int64, int64, domain.Result) error) *MockExamineServiceCorrectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExamineServiceCorrectCall) DoAndReturn(f func(context.Context, int64, int64, domain.Result) error) *MockExamineServiceCorrectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteResults mocks base method.
+func (m *MockExamineService) DeleteResults(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResults", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResults indicates an expected call of DeleteResults.
+func (mr *MockExamineServiceMockRecorder) DeleteResults(ctx, uid any) *MockExamineServiceDeleteResultsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResults", reflect.TypeOf((*MockExamineService)(nil).DeleteResults), ctx, uid)
+	return &MockExamineServiceDeleteResultsCall{Call: call}
+}
+
+// MockExamineServiceDeleteResultsCall wrap *gomock.Call
+type MockExamineServiceDeleteResultsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExamineServiceDeleteResultsCall) Return(arg0 error) *MockExamineServiceDeleteResultsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExamineServiceDeleteResultsCall) Do(f func(context.Context, int64) error) *MockExamineServiceDeleteResultsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExamineServiceDeleteResultsCall) DoAndReturn(f func(context.Context, int64) error) *MockExamineServiceDeleteResultsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Examine mocks base method.
 func (m *MockExamineService) Examine(ctx context.Context, uid, qid int64, input string) (domain.ExamineResult, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +194,44 @@ func (c *MockExamineServiceGetResultsCall) DoAndReturn(f func(context.Context, i
 	return c
 }
 
+// MergeResults mocks base method.
+func (m *MockExamineService) MergeResults(ctx context.Context, sourceUid, targetUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeResults", ctx, sourceUid, targetUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeResults indicates an expected call of MergeResults.
+func (mr *MockExamineServiceMockRecorder) MergeResults(ctx, sourceUid, targetUid any) *MockExamineServiceMergeResultsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeResults", reflect.TypeOf((*MockExamineService)(nil).MergeResults), ctx, sourceUid, targetUid)
+	return &MockExamineServiceMergeResultsCall{Call: call}
+}
+
+// MockExamineServiceMergeResultsCall wrap *gomock.Call
+type MockExamineServiceMergeResultsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExamineServiceMergeResultsCall) Return(arg0 error) *MockExamineServiceMergeResultsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExamineServiceMergeResultsCall) Do(f func(context.Context, int64, int64) error) *MockExamineServiceMergeResultsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExamineServiceMergeResultsCall) DoAndReturn(f func(context.Context, int64, int64) error) *MockExamineServiceMergeResultsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // QuestionResult mocks base method.
 func (m *MockExamineService) QuestionResult(ctx context.Context, uid, qid int64) (domain.Result, error) {
 	m.ctrl.T.Helper()
@@ -157,40 +271,41 @@ func (c *MockExamineServiceQuestionResultCall) DoAndReturn(f func(context.Contex
 	return c
 }
 
-// Correct mocks base method.
-func (m *MockExamineService) Correct(ctx context.Context, uid, qid int64, questionResult domain.Result) error {
+// Records mocks base method.
+func (m *MockExamineService) Records(ctx context.Context, uid int64) ([]domain.ExamineResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Correct", ctx, uid, qid, questionResult)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Records", ctx, uid)
+	ret0, _ := ret[0].([]domain.ExamineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Correct indicates an expected call of Correct.
-func (mr *MockExamineServiceMockRecorder) Correct(ctx, uid, qid, questionResult any) *MockExamineServiceCorrectCall {
+// Records indicates an expected call of Records.
+func (mr *MockExamineServiceMockRecorder) Records(ctx, uid any) *MockExamineServiceRecordsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockExamineService)(nil).Correct), ctx, uid, qid, questionResult)
-	return &MockExamineServiceCorrectCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Records", reflect.TypeOf((*MockExamineService)(nil).Records), ctx, uid)
+	return &MockExamineServiceRecordsCall{Call: call}
 }
 
-// MockExamineServiceCorrectCall wraps *gomock.Call.
-type MockExamineServiceCorrectCall struct {
+// MockExamineServiceRecordsCall wrap *gomock.Call
+type MockExamineServiceRecordsCall struct {
 	*gomock.Call
 }
 
-// Return rewrite *gomock.Call.Return for Correct.
-func (c *MockExamineServiceCorrectCall) Return(arg0 error) *MockExamineServiceCorrectCall {
-	c.Call = c.Call.Return(arg0)
+// Return rewrite *gomock.Call.Return
+func (c *MockExamineServiceRecordsCall) Return(arg0 []domain.ExamineResult, arg1 error) *MockExamineServiceRecordsCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
-// Do rewrite *gomock.Call.Do for Correct.
-func (c *MockExamineServiceCorrectCall) Do(f func(context.Context, int64, int64, domain.Result) error) *MockExamineServiceCorrectCall {
+// Do rewrite *gomock.Call.Do
+func (c *MockExamineServiceRecordsCall) Do(f func(context.Context, int64) ([]domain.ExamineResult, error)) *MockExamineServiceRecordsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
-// DoAndReturn rewrite *gomock.Call.DoAndReturn for Correct.
-func (c *MockExamineServiceCorrectCall) DoAndReturn(f func(context.Context, int64, int64, domain.Result) error) *MockExamineServiceCorrectCall {
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExamineServiceRecordsCall) DoAndReturn(f func(context.Context, int64) ([]domain.ExamineResult, error)) *MockExamineServiceRecordsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
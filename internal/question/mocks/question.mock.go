@@ -117,6 +117,84 @@ func (c *MockServiceDetailCall) DoAndReturn(f func(context.Context, int64) (doma
 	return c
 }
 
+// DuePublish mocks base method.
+func (m *MockService) DuePublish(ctx context.Context, now, minId int64, limit int) ([]domain.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuePublish", ctx, now, minId, limit)
+	ret0, _ := ret[0].([]domain.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuePublish indicates an expected call of DuePublish.
+func (mr *MockServiceMockRecorder) DuePublish(ctx, now, minId, limit any) *MockServiceDuePublishCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuePublish", reflect.TypeOf((*MockService)(nil).DuePublish), ctx, now, minId, limit)
+	return &MockServiceDuePublishCall{Call: call}
+}
+
+// MockServiceDuePublishCall wrap *gomock.Call
+type MockServiceDuePublishCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDuePublishCall) Return(arg0 []domain.Question, arg1 error) *MockServiceDuePublishCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDuePublishCall) Do(f func(context.Context, int64, int64, int) ([]domain.Question, error)) *MockServiceDuePublishCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDuePublishCall) DoAndReturn(f func(context.Context, int64, int64, int) ([]domain.Question, error)) *MockServiceDuePublishCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DueUnpublish mocks base method.
+func (m *MockService) DueUnpublish(ctx context.Context, now, minId int64, limit int) ([]domain.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueUnpublish", ctx, now, minId, limit)
+	ret0, _ := ret[0].([]domain.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueUnpublish indicates an expected call of DueUnpublish.
+func (mr *MockServiceMockRecorder) DueUnpublish(ctx, now, minId, limit any) *MockServiceDueUnpublishCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueUnpublish", reflect.TypeOf((*MockService)(nil).DueUnpublish), ctx, now, minId, limit)
+	return &MockServiceDueUnpublishCall{Call: call}
+}
+
+// MockServiceDueUnpublishCall wrap *gomock.Call
+type MockServiceDueUnpublishCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDueUnpublishCall) Return(arg0 []domain.Question, arg1 error) *MockServiceDueUnpublishCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDueUnpublishCall) Do(f func(context.Context, int64, int64, int) ([]domain.Question, error)) *MockServiceDueUnpublishCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDueUnpublishCall) DoAndReturn(f func(context.Context, int64, int64, int) ([]domain.Question, error)) *MockServiceDueUnpublishCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetPubByIDs mocks base method.
 func (m *MockService) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Question, error) {
 	m.ctrl.T.Helper()
@@ -197,18 +275,18 @@ func (c *MockServiceListCall) DoAndReturn(f func(context.Context, int, int) ([]d
 }
 
 // PubDetail mocks base method.
-func (m *MockService) PubDetail(ctx context.Context, qid int64) (domain.Question, error) {
+func (m *MockService) PubDetail(ctx context.Context, uid, qid int64) (domain.Question, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PubDetail", ctx, qid)
+	ret := m.ctrl.Call(m, "PubDetail", ctx, uid, qid)
 	ret0, _ := ret[0].(domain.Question)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PubDetail indicates an expected call of PubDetail.
-func (mr *MockServiceMockRecorder) PubDetail(ctx, qid any) *MockServicePubDetailCall {
+func (mr *MockServiceMockRecorder) PubDetail(ctx, uid, qid any) *MockServicePubDetailCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubDetail", reflect.TypeOf((*MockService)(nil).PubDetail), ctx, qid)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubDetail", reflect.TypeOf((*MockService)(nil).PubDetail), ctx, uid, qid)
 	return &MockServicePubDetailCall{Call: call}
 }
 
@@ -224,13 +302,13 @@ func (c *MockServicePubDetailCall) Return(arg0 domain.Question, arg1 error) *Moc
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServicePubDetailCall) Do(f func(context.Context, int64) (domain.Question, error)) *MockServicePubDetailCall {
+func (c *MockServicePubDetailCall) Do(f func(context.Context, int64, int64) (domain.Question, error)) *MockServicePubDetailCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServicePubDetailCall) DoAndReturn(f func(context.Context, int64) (domain.Question, error)) *MockServicePubDetailCall {
+func (c *MockServicePubDetailCall) DoAndReturn(f func(context.Context, int64, int64) (domain.Question, error)) *MockServicePubDetailCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -314,6 +392,44 @@ func (c *MockServicePublishCall) DoAndReturn(f func(context.Context, *domain.Que
 	return c
 }
 
+// PublishScheduled mocks base method.
+func (m *MockService) PublishScheduled(ctx context.Context, que domain.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, que)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockServiceMockRecorder) PublishScheduled(ctx, que any) *MockServicePublishScheduledCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockService)(nil).PublishScheduled), ctx, que)
+	return &MockServicePublishScheduledCall{Call: call}
+}
+
+// MockServicePublishScheduledCall wrap *gomock.Call
+type MockServicePublishScheduledCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServicePublishScheduledCall) Return(arg0 error) *MockServicePublishScheduledCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServicePublishScheduledCall) Do(f func(context.Context, domain.Question) error) *MockServicePublishScheduledCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServicePublishScheduledCall) DoAndReturn(f func(context.Context, domain.Question) error) *MockServicePublishScheduledCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Save mocks base method.
 func (m *MockService) Save(ctx context.Context, question *domain.Question) (int64, error) {
 	m.ctrl.T.Helper()
@@ -352,3 +468,119 @@ func (c *MockServiceSaveCall) DoAndReturn(f func(context.Context, *domain.Questi
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Schedule mocks base method.
+func (m *MockService) Schedule(ctx context.Context, qid, publishAt, unpublishAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, qid, publishAt, unpublishAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockServiceMockRecorder) Schedule(ctx, qid, publishAt, unpublishAt any) *MockServiceScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockService)(nil).Schedule), ctx, qid, publishAt, unpublishAt)
+	return &MockServiceScheduleCall{Call: call}
+}
+
+// MockServiceScheduleCall wrap *gomock.Call
+type MockServiceScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceScheduleCall) Return(arg0 error) *MockServiceScheduleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceScheduleCall) Do(f func(context.Context, int64, int64, int64) error) *MockServiceScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceScheduleCall) DoAndReturn(f func(context.Context, int64, int64, int64) error) *MockServiceScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ScheduledList mocks base method.
+func (m *MockService) ScheduledList(ctx context.Context, offset, limit int) ([]domain.Question, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduledList", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Question)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ScheduledList indicates an expected call of ScheduledList.
+func (mr *MockServiceMockRecorder) ScheduledList(ctx, offset, limit any) *MockServiceScheduledListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduledList", reflect.TypeOf((*MockService)(nil).ScheduledList), ctx, offset, limit)
+	return &MockServiceScheduledListCall{Call: call}
+}
+
+// MockServiceScheduledListCall wrap *gomock.Call
+type MockServiceScheduledListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceScheduledListCall) Return(arg0 []domain.Question, arg1 int64, arg2 error) *MockServiceScheduledListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceScheduledListCall) Do(f func(context.Context, int, int) ([]domain.Question, int64, error)) *MockServiceScheduledListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceScheduledListCall) DoAndReturn(f func(context.Context, int, int) ([]domain.Question, int64, error)) *MockServiceScheduledListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Unpublish mocks base method.
+func (m *MockService) Unpublish(ctx context.Context, qid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpublish", ctx, qid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpublish indicates an expected call of Unpublish.
+func (mr *MockServiceMockRecorder) Unpublish(ctx, qid any) *MockServiceUnpublishCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpublish", reflect.TypeOf((*MockService)(nil).Unpublish), ctx, qid)
+	return &MockServiceUnpublishCall{Call: call}
+}
+
+// MockServiceUnpublishCall wrap *gomock.Call
+type MockServiceUnpublishCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceUnpublishCall) Return(arg0 error) *MockServiceUnpublishCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceUnpublishCall) Do(f func(context.Context, int64) error) *MockServiceUnpublishCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceUnpublishCall) DoAndReturn(f func(context.Context, int64) error) *MockServiceUnpublishCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
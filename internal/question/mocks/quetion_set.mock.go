@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./question_set.go -destination=../../mocks/quetion_set.mock.go -package=quemocks -typed=true QuestionSetService
 //
+
 // Package quemocks is a generated GoMock package.
 package quemocks
 
@@ -40,18 +41,18 @@ func (m *MockQuestionSetService) EXPECT() *MockQuestionSetServiceMockRecorder {
 }
 
 // Detail mocks base method.
-func (m *MockQuestionSetService) Detail(ctx context.Context, id int64) (domain.QuestionSet, error) {
+func (m *MockQuestionSetService) Detail(ctx context.Context, uid, id int64) (domain.QuestionSet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Detail", ctx, id)
+	ret := m.ctrl.Call(m, "Detail", ctx, uid, id)
 	ret0, _ := ret[0].(domain.QuestionSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Detail indicates an expected call of Detail.
-func (mr *MockQuestionSetServiceMockRecorder) Detail(ctx, id any) *QuestionSetServiceDetailCall {
+func (mr *MockQuestionSetServiceMockRecorder) Detail(ctx, uid, id any) *QuestionSetServiceDetailCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockQuestionSetService)(nil).Detail), ctx, id)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockQuestionSetService)(nil).Detail), ctx, uid, id)
 	return &QuestionSetServiceDetailCall{Call: call}
 }
 
@@ -67,13 +68,13 @@ func (c *QuestionSetServiceDetailCall) Return(arg0 domain.QuestionSet, arg1 erro
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *QuestionSetServiceDetailCall) Do(f func(context.Context, int64) (domain.QuestionSet, error)) *QuestionSetServiceDetailCall {
+func (c *QuestionSetServiceDetailCall) Do(f func(context.Context, int64, int64) (domain.QuestionSet, error)) *QuestionSetServiceDetailCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *QuestionSetServiceDetailCall) DoAndReturn(f func(context.Context, int64) (domain.QuestionSet, error)) *QuestionSetServiceDetailCall {
+func (c *QuestionSetServiceDetailCall) DoAndReturn(f func(context.Context, int64, int64) (domain.QuestionSet, error)) *QuestionSetServiceDetailCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -235,6 +236,45 @@ func (c *QuestionSetServiceGetCandidatesCall) DoAndReturn(f func(context.Context
 	return c
 }
 
+// GetIDsByQid mocks base method.
+func (m *MockQuestionSetService) GetIDsByQid(ctx context.Context, qid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByQid", ctx, qid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByQid indicates an expected call of GetIDsByQid.
+func (mr *MockQuestionSetServiceMockRecorder) GetIDsByQid(ctx, qid any) *QuestionSetServiceGetIDsByQidCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByQid", reflect.TypeOf((*MockQuestionSetService)(nil).GetIDsByQid), ctx, qid)
+	return &QuestionSetServiceGetIDsByQidCall{Call: call}
+}
+
+// QuestionSetServiceGetIDsByQidCall wrap *gomock.Call
+type QuestionSetServiceGetIDsByQidCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *QuestionSetServiceGetIDsByQidCall) Return(arg0 []int64, arg1 error) *QuestionSetServiceGetIDsByQidCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *QuestionSetServiceGetIDsByQidCall) Do(f func(context.Context, int64) ([]int64, error)) *QuestionSetServiceGetIDsByQidCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *QuestionSetServiceGetIDsByQidCall) DoAndReturn(f func(context.Context, int64) ([]int64, error)) *QuestionSetServiceGetIDsByQidCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // List mocks base method.
 func (m *MockQuestionSetService) List(ctx context.Context, offset, limit int) ([]domain.QuestionSet, int64, error) {
 	m.ctrl.T.Helper()
@@ -316,18 +356,18 @@ func (c *QuestionSetServiceListDefaultCall) DoAndReturn(f func(context.Context,
 }
 
 // PubDetail mocks base method.
-func (m *MockQuestionSetService) PubDetail(ctx context.Context, id int64) (domain.QuestionSet, error) {
+func (m *MockQuestionSetService) PubDetail(ctx context.Context, uid, id int64) (domain.QuestionSet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PubDetail", ctx, id)
+	ret := m.ctrl.Call(m, "PubDetail", ctx, uid, id)
 	ret0, _ := ret[0].(domain.QuestionSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PubDetail indicates an expected call of PubDetail.
-func (mr *MockQuestionSetServiceMockRecorder) PubDetail(ctx, id any) *QuestionSetServicePubDetailCall {
+func (mr *MockQuestionSetServiceMockRecorder) PubDetail(ctx, uid, id any) *QuestionSetServicePubDetailCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubDetail", reflect.TypeOf((*MockQuestionSetService)(nil).PubDetail), ctx, id)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubDetail", reflect.TypeOf((*MockQuestionSetService)(nil).PubDetail), ctx, uid, id)
 	return &QuestionSetServicePubDetailCall{Call: call}
 }
 
@@ -343,13 +383,13 @@ func (c *QuestionSetServicePubDetailCall) Return(arg0 domain.QuestionSet, arg1 e
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *QuestionSetServicePubDetailCall) Do(f func(context.Context, int64) (domain.QuestionSet, error)) *QuestionSetServicePubDetailCall {
+func (c *QuestionSetServicePubDetailCall) Do(f func(context.Context, int64, int64) (domain.QuestionSet, error)) *QuestionSetServicePubDetailCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *QuestionSetServicePubDetailCall) DoAndReturn(f func(context.Context, int64) (domain.QuestionSet, error)) *QuestionSetServicePubDetailCall {
+func (c *QuestionSetServicePubDetailCall) DoAndReturn(f func(context.Context, int64, int64) (domain.QuestionSet, error)) *QuestionSetServicePubDetailCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
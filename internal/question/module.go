@@ -14,6 +14,12 @@
 
 package baguwen
 
+import (
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/question/internal/event/consumer"
+)
+
 type Module struct {
 	Svc         Service
 	SetSvc      QuestionSetService
@@ -25,6 +31,15 @@ type Module struct {
 	ExamineHdl  *ExamineHandler
 
 	KnowledgeJobStarter *KnowledgeJobStarter
+	ScheduledPublishJob *ScheduledPublishJob
 
 	KnowledgeBaseHdl *KnowledgeBaseHandler
+
+	// SearchSources 搜索重建索引的时候使用
+	SearchSources []searchx.Source
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	mc *consumer.UserMergeConsumer
+	dc *consumer.UserDeletionConsumer
 }
@@ -36,3 +36,4 @@ type QuestionSet = domain.QuestionSet
 type ExamResult = domain.ExamineResult
 type ExamRes = domain.Result
 type KnowledgeJobStarter = job.KnowledgeJobStarter
+type ScheduledPublishJob = job.ScheduledPublishJob
@@ -17,6 +17,7 @@
 package baguwen
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/ginx/session"
@@ -31,9 +32,13 @@ import (
 
 	"github.com/ecodeclub/webook/internal/permission"
 
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+
 	"github.com/ecodeclub/webook/internal/interactive"
 
 	"github.com/ecodeclub/webook/internal/question/internal/event"
+	"github.com/ecodeclub/webook/internal/question/internal/event/consumer"
 
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/mq-api"
@@ -52,6 +57,7 @@ var ExamineHandlerSet = wire.NewSet(
 	web.NewExamineHandler,
 	service.NewLLMExamineService,
 	repository.NewCachedExamineRepository,
+	event.NewExamineEventProducer,
 	dao.NewGORMExamineDAO)
 
 func InitModule(db *egorm.Component,
@@ -61,9 +67,11 @@ func InitModule(db *egorm.Component,
 	aiModule *ai.Module,
 	memberModule *member.Module,
 	sp session.Provider,
-	q mq.MQ) (*Module, error) {
+	q mq.MQ,
+	rc *cachex.ReadThroughCache) (*Module, error) {
 	wire.Build(InitQuestionDAO,
 		cache.NewQuestionECache,
+		cache.NewPublishedCache,
 		repository.NewCacheRepository,
 		event.NewSyncEventProducer,
 		event.NewInteractiveEventProducer,
@@ -80,6 +88,11 @@ func InitModule(db *egorm.Component,
 		service.NewQuestionSetService,
 		web.NewQuestionSetHandler,
 		initKnowledgeStarter,
+		initScheduledPublishJob,
+		initUserMergeConsumer,
+		initUserDeletionConsumer,
+		service.NewExamineExportSource,
+		initSearchSources,
 		InitKnowledgeBaseSvc,
 		web.NewKnowledgeBaseHandler,
 		wire.FieldsOf(new(*interactive.Module), "Svc"),
@@ -100,6 +113,19 @@ func initKnowledgeStarter(svc service.Service) *job.KnowledgeJobStarter {
 	return job.NewKnowledgeJobStarter(svc, baseDir)
 }
 
+// initSearchSources 题目和题集都是搜索的数据来源
+func initSearchSources(repo repository.Repository, setRepo repository.QuestionSetRepository) []searchx.Source {
+	return []searchx.Source{
+		service.NewQuestionSearchSource(repo),
+		service.NewQuestionSetSearchSource(setRepo),
+	}
+}
+
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func InitTableOnce(db *gorm.DB) {
 	daoOnce.Do(func() {
 		err := dao.InitTables(db)
@@ -118,3 +144,21 @@ func InitQuestionSetDAO(db *egorm.Component) dao.QuestionSetDAO {
 	InitTableOnce(db)
 	return dao.NewGORMQuestionSetDAO(db)
 }
+
+func initUserMergeConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserMergeConsumer {
+	c, err := consumer.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
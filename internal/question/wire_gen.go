@@ -7,6 +7,7 @@
 package baguwen
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/ecache"
@@ -16,7 +17,10 @@ import (
 	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/member"
 	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	"github.com/ecodeclub/webook/internal/question/internal/event"
+	"github.com/ecodeclub/webook/internal/question/internal/event/consumer"
 	"github.com/ecodeclub/webook/internal/question/internal/job"
 	"github.com/ecodeclub/webook/internal/question/internal/repository"
 	"github.com/ecodeclub/webook/internal/question/internal/repository/cache"
@@ -31,10 +35,11 @@ import (
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, intrModule *interactive.Module, ec ecache.Cache, perm *permission.Module, aiModule *ai.Module, memberModule *member.Module, sp session.Provider, q mq.MQ) (*Module, error) {
+func InitModule(db *gorm.DB, intrModule *interactive.Module, ec ecache.Cache, perm *permission.Module, aiModule *ai.Module, memberModule *member.Module, sp session.Provider, q mq.MQ, rc *cachex.ReadThroughCache) (*Module, error) {
 	questionDAO := InitQuestionDAO(db)
 	questionCache := cache.NewQuestionECache(ec)
-	repositoryRepository := repository.NewCacheRepository(questionDAO, questionCache)
+	publishedCache := cache.NewPublishedCache(rc)
+	repositoryRepository := repository.NewCacheRepository(questionDAO, questionCache, publishedCache)
 	syncDataToSearchEventProducer, err := event.NewSyncEventProducer(q)
 	if err != nil {
 		return nil, err
@@ -46,12 +51,16 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, ec ecache.Cache, pe
 	knowledgeBaseEventProducer := InitKnowledgeBaseUploadProducer(q)
 	serviceService := service.NewService(repositoryRepository, syncDataToSearchEventProducer, interactiveEventProducer, knowledgeBaseEventProducer)
 	questionSetDAO := InitQuestionSetDAO(db)
-	questionSetRepository := repository.NewQuestionSetRepository(questionSetDAO)
+	questionSetRepository := repository.NewQuestionSetRepository(questionSetDAO, publishedCache)
 	questionSetService := service.NewQuestionSetService(questionSetRepository, repositoryRepository, interactiveEventProducer, syncDataToSearchEventProducer)
 	examineDAO := dao.NewGORMExamineDAO(db)
 	examineRepository := repository.NewCachedExamineRepository(examineDAO)
 	llmService := aiModule.Svc
-	examineService := service.NewLLMExamineService(repositoryRepository, examineRepository, llmService)
+	examineEventProducer, err := event.NewExamineEventProducer(q)
+	if err != nil {
+		return nil, err
+	}
+	examineService := service.NewLLMExamineService(repositoryRepository, examineRepository, llmService, examineEventProducer)
 	adminHandler := web.NewAdminHandler(serviceService)
 	adminQuestionSetHandler := web.NewAdminQuestionSetHandler(questionSetService)
 	service2 := intrModule.Svc
@@ -61,9 +70,14 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, ec ecache.Cache, pe
 	questionSetHandler := web.NewQuestionSetHandler(questionSetService, examineService, service2, sp)
 	examineHandler := web.NewExamineHandler(examineService)
 	knowledgeJobStarter := initKnowledgeStarter(serviceService)
+	scheduledPublishJob := initScheduledPublishJob(serviceService)
 	repositoryBaseSvc := aiModule.KnowledgeBaseSvc
 	questionKnowledgeBase := InitKnowledgeBaseSvc(repositoryBaseSvc, repositoryRepository)
 	knowledgeBaseHandler := web.NewKnowledgeBaseHandler(questionKnowledgeBase)
+	v := initSearchSources(repositoryRepository, questionSetRepository)
+	userMergeConsumer := initUserMergeConsumer(examineService, q)
+	userDeletionConsumer := initUserDeletionConsumer(examineService, q)
+	source := service.NewExamineExportSource(examineService)
 	module := &Module{
 		Svc:                 serviceService,
 		SetSvc:              questionSetService,
@@ -74,14 +88,19 @@ func InitModule(db *gorm.DB, intrModule *interactive.Module, ec ecache.Cache, pe
 		QsHdl:               questionSetHandler,
 		ExamineHdl:          examineHandler,
 		KnowledgeJobStarter: knowledgeJobStarter,
+		ScheduledPublishJob: scheduledPublishJob,
 		KnowledgeBaseHdl:    knowledgeBaseHandler,
+		SearchSources:       v,
+		ExportSource:        source,
+		mc:                  userMergeConsumer,
+		dc:                  userDeletionConsumer,
 	}
 	return module, nil
 }
 
 // wire.go:
 
-var ExamineHandlerSet = wire.NewSet(web.NewExamineHandler, service.NewLLMExamineService, repository.NewCachedExamineRepository, dao.NewGORMExamineDAO)
+var ExamineHandlerSet = wire.NewSet(web.NewExamineHandler, service.NewLLMExamineService, repository.NewCachedExamineRepository, event.NewExamineEventProducer, dao.NewGORMExamineDAO)
 
 var daoOnce = sync.Once{}
 
@@ -90,6 +109,16 @@ func initKnowledgeStarter(svc service.Service) *job.KnowledgeJobStarter {
 	return job.NewKnowledgeJobStarter(svc, baseDir)
 }
 
+// initSearchSources 题目和题集都是搜索的数据来源
+func initSearchSources(repo repository.Repository, setRepo repository.QuestionSetRepository) []searchx.Source {
+	return []searchx.Source{service.NewQuestionSearchSource(repo), service.NewQuestionSetSearchSource(setRepo)}
+}
+
+func initScheduledPublishJob(svc service.Service) *job.ScheduledPublishJob {
+	limit := 100
+	return job.NewScheduledPublishJob(svc, limit)
+}
+
 func InitTableOnce(db *gorm.DB) {
 	daoOnce.Do(func() {
 		err := dao.InitTables(db)
@@ -108,3 +137,21 @@ func InitQuestionSetDAO(db *egorm.Component) dao.QuestionSetDAO {
 	InitTableOnce(db)
 	return dao.NewGORMQuestionSetDAO(db)
 }
+
+func initUserMergeConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserMergeConsumer {
+	c, err := consumer.NewUserMergeConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.ExamineService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
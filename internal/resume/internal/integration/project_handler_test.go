@@ -4,6 +4,7 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -16,6 +17,8 @@ import (
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/cases"
 	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/resume"
+	"github.com/ecodeclub/webook/internal/resume/internal/domain"
 	"github.com/ecodeclub/webook/internal/resume/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/resume/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/resume/internal/web"
@@ -38,6 +41,7 @@ type ProjectTestSuite struct {
 	server *egin.Component
 	hdl    *web.ProjectHandler
 	pdao   dao.ResumeProjectDAO
+	module *resume.Module
 }
 
 func (s *ProjectTestSuite) TearDownTest() {
@@ -93,6 +97,7 @@ func (s *ProjectTestSuite) SetupSuite() {
 		}))
 	})
 	module.PrjHdl.MemberRoutes(server.Engine)
+	s.module = module
 	s.server = server
 	s.db = testioc.InitDB()
 	err := dao.InitTables(s.db)
@@ -1321,3 +1326,58 @@ func (s *ProjectTestSuite) assertContribution(actual *dao.Contribution,
 	actual.Utime = 0
 	assert.Equal(t, expected, actual)
 }
+
+func (s *ProjectTestSuite) TestDeleteByUid() {
+	t := s.T()
+	ctx := context.Background()
+	const deletedUid, otherUid = 3001, 3002
+	err := s.db.Create(&[]dao.ResumeProject{
+		{ID: 1, Uid: deletedUid, Name: "deleted"},
+		{ID: 2, Uid: otherUid, Name: "other"},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.Contribution{
+		{ID: 1, ProjectID: 1},
+		{ID: 2, ProjectID: 2},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.Difficulty{
+		{ID: 1, ProjectID: 1, CaseID: 1},
+		{ID: 2, ProjectID: 2, CaseID: 1},
+	}).Error
+	require.NoError(t, err)
+	err = s.db.Create(&[]dao.RefCase{
+		{ID: 1, ContributionID: 1, CaseID: 1},
+		{ID: 2, ContributionID: 2, CaseID: 1},
+	}).Error
+	require.NoError(t, err)
+	_, err = s.module.ExperienceSvc.SaveExperience(ctx, domain.Experience{
+		Uid:         deletedUid,
+		Title:       "deleted",
+		CompanyName: "company",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.db.Exec("TRUNCATE TABLE `experiences`").Error)
+	})
+
+	data, err := s.module.ExportSource.Export(ctx, deletedUid)
+	require.NoError(t, err)
+	val, err := json.Marshal(data)
+	require.NoError(t, err)
+	assert.Contains(t, string(val), `"Name":"deleted"`)
+	assert.Contains(t, string(val), `"CompanyName":"company"`)
+
+	require.NoError(t, s.module.Svc.DeleteByUid(ctx, deletedUid))
+	require.NoError(t, s.module.ExperienceSvc.DeleteByUid(ctx, deletedUid))
+
+	for _, table := range []any{&dao.ResumeProject{}, &dao.Contribution{}, &dao.Difficulty{}, &dao.RefCase{}} {
+		var ids []int64
+		err = s.db.Model(table).Pluck("id", &ids).Error
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, ids)
+	}
+	experiences, _, err := s.module.ExperienceSvc.List(ctx, deletedUid)
+	require.NoError(t, err)
+	assert.Empty(t, experiences)
+}
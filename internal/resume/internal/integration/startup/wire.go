@@ -30,7 +30,8 @@ func InitModule(caModule *cases.Module, aiModule *ai.Module) *resume.Module {
 		web.NewHandler,
 		web.NewExperienceHandler,
 		web.NewAnalysisHandler,
-		wire.Struct(new(resume.Module), "*"),
+		service.NewExportSource,
+		wire.Struct(new(resume.Module), "Svc", "ExperienceSvc", "PrjHdl", "ExperienceHdl", "AnalysisHandler", "ExportSource"),
 	)
 	return new(resume.Module)
 }
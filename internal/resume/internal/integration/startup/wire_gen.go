@@ -34,10 +34,14 @@ func InitModule(caModule *cases.Module, aiModule *ai.Module) *resume.Module {
 	llmService := aiModule.Svc
 	analysisService := service.NewAnalysisService(llmService)
 	analysisHandler := web.NewAnalysisHandler(analysisService)
+	source := service.NewExportSource(serviceService, experienceService)
 	module := &resume.Module{
+		Svc:             serviceService,
+		ExperienceSvc:   experienceService,
 		PrjHdl:          projectHandler,
 		ExperienceHdl:   experienceHandler,
 		AnalysisHandler: analysisHandler,
+		ExportSource:    source,
 	}
 	return module
 }
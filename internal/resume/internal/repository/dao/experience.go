@@ -14,6 +14,7 @@ type ExperienceDAO interface {
 	Upsert(ctx context.Context, experience Experience) (int64, error)
 	Delete(ctx context.Context, uid int64, id int64) error
 	Find(ctx context.Context, uid int64) ([]Experience, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type experienceDAO struct {
@@ -52,6 +53,10 @@ func (e *experienceDAO) Delete(ctx context.Context, uid int64, id int64) error {
 	})
 }
 
+func (e *experienceDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return e.db.WithContext(ctx).Where("uid = ?", uid).Delete(&Experience{}).Error
+}
+
 func (e *experienceDAO) Find(ctx context.Context, uid int64) ([]Experience, error) {
 	var experiences []Experience
 	err := e.db.WithContext(ctx).Where("uid = ?", uid).Order("start_time desc").Find(&experiences).Error
@@ -24,6 +24,8 @@ type ResumeProjectDAO interface {
 	FindDifficulties(ctx context.Context, projectId int64) ([]Difficulty, error)
 	DeleteDifficulty(ctx context.Context, id int64) error
 	DeleteContribution(ctx context.Context, id int64) error
+	// DeleteByUid 删除用户所有的项目及其关联数据
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type resumeProjectDAO struct {
@@ -223,6 +225,34 @@ func (r *resumeProjectDAO) Delete(ctx context.Context, uid, id int64) error {
 	})
 }
 
+func (r *resumeProjectDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pids []int64
+		err := tx.Model(&ResumeProject{}).Select("id").Where("uid = ?", uid).Find(&pids).Error
+		if err != nil || len(pids) == 0 {
+			return err
+		}
+		var cids []int64
+		err = tx.Model(&Contribution{}).Select("id").Where("project_id in ?", pids).Find(&cids).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&RefCase{}).Where("contribution_id in ?", cids).Delete(&RefCase{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Contribution{}).Where("project_id in ?", pids).Delete(&Contribution{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Difficulty{}).Where("project_id in ?", pids).Delete(&Difficulty{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&ResumeProject{}).Where("uid = ?", uid).Delete(&ResumeProject{}).Error
+	})
+}
+
 func (r *resumeProjectDAO) Find(ctx context.Context, uid int64) ([]ResumeProject, error) {
 	var projects []ResumeProject
 	err := r.db.WithContext(ctx).
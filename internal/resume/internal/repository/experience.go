@@ -14,6 +14,7 @@ type Experience interface {
 	SaveExperience(ctx context.Context, experience domain.Experience) (int64, error)
 	QueryAllExperiences(ctx context.Context, uid int64) ([]domain.Experience, error)
 	DeleteExperience(ctx context.Context, uid int64, id int64) error
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type experience struct {
@@ -47,6 +48,10 @@ func (e *experience) DeleteExperience(ctx context.Context, uid int64, id int64)
 	return e.expdao.Delete(ctx, uid, id)
 }
 
+func (e *experience) DeleteByUid(ctx context.Context, uid int64) error {
+	return e.expdao.DeleteByUid(ctx, uid)
+}
+
 func (e *experience) toExperienceEntity(experience domain.Experience) dao.Experience {
 	return dao.Experience{
 		ID:          experience.Id,
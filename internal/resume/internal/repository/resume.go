@@ -22,6 +22,7 @@ type ResumeProjectRepo interface {
 	SaveDifficulty(ctx context.Context, id int64, difficulty domain.Difficulty) error
 	// 删除难点
 	DeleteDifficulty(ctx context.Context, id int64) error
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type resumeProjectRepo struct {
@@ -63,6 +64,10 @@ func (r *resumeProjectRepo) DeleteProject(ctx context.Context, uid, id int64) er
 	return r.pdao.Delete(ctx, uid, id)
 }
 
+func (r *resumeProjectRepo) DeleteByUid(ctx context.Context, uid int64) error {
+	return r.pdao.DeleteByUid(ctx, uid)
+}
+
 func (r *resumeProjectRepo) FindProjects(ctx context.Context, uid int64) ([]domain.Project, error) {
 	pList, err := r.pdao.Find(ctx, uid)
 	if err != nil {
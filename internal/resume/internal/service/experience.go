@@ -12,6 +12,8 @@ type ExperienceService interface {
 	SaveExperience(ctx context.Context, experience domain.Experience) (int64, error)
 	List(ctx context.Context, uid int64) ([]domain.Experience, string, error)
 	Delete(ctx context.Context, uid int64, id int64) error
+	// DeleteByUid 注销账号的时候删除用户所有的工作经历
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type experienceService struct {
@@ -41,6 +43,10 @@ func (e *experienceService) Delete(ctx context.Context, uid int64, id int64) err
 	return e.experience.DeleteExperience(ctx, uid, id)
 }
 
+func (e *experienceService) DeleteByUid(ctx context.Context, uid int64) error {
+	return e.experience.DeleteByUid(ctx, uid)
+}
+
 func (e *experienceService) checkOverlap(experience []domain.Experience) string {
 	l := len(experience)
 
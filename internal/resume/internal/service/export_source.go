@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/resume/internal/domain"
+)
+
+type exportData struct {
+	Projects    []domain.Project
+	Experiences []domain.Experience
+}
+
+// exportSource 导出个人数据的时候读取简历里面的项目和工作经历
+type exportSource struct {
+	svc    Service
+	expSvc ExperienceService
+}
+
+func NewExportSource(svc Service, expSvc ExperienceService) exportx.Source {
+	return &exportSource{svc: svc, expSvc: expSvc}
+}
+
+func (s *exportSource) Name() string {
+	return "resume"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	projects, err := s.svc.FindProjects(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	experiences, _, err := s.expSvc.List(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return exportData{Projects: projects, Experiences: experiences}, nil
+}
@@ -20,6 +20,8 @@ type Service interface {
 	SaveDifficulty(ctx context.Context, id int64, difficulty domain.Difficulty) error
 	// 删除难点
 	DeleteDifficulty(ctx context.Context, id int64) error
+	// DeleteByUid 注销账号的时候删除用户所有的项目
+	DeleteByUid(ctx context.Context, uid int64) error
 }
 
 type service struct {
@@ -40,6 +42,10 @@ func (s *service) DeleteProject(ctx context.Context, uid, id int64) error {
 	return s.repo.DeleteProject(ctx, uid, id)
 }
 
+func (s *service) DeleteByUid(ctx context.Context, uid int64) error {
+	return s.repo.DeleteByUid(ctx, uid)
+}
+
 func (s *service) FindProjects(ctx context.Context, uid int64) ([]domain.Project, error) {
 	return s.repo.FindProjects(ctx, uid)
 
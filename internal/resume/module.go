@@ -14,14 +14,27 @@
 
 package resume
 
-import "github.com/ecodeclub/webook/internal/resume/internal/web"
+import (
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/resume/internal/event"
+	"github.com/ecodeclub/webook/internal/resume/internal/service"
+	"github.com/ecodeclub/webook/internal/resume/internal/web"
+)
 
 type ExperienceHandler = web.ExperienceHandler
 type ProjectHandler = web.ProjectHandler
 type AnalysisHandler = web.AnalysisHandler
+type Service = service.Service
+type ExperienceService = service.ExperienceService
 
 type Module struct {
+	Svc             Service
+	ExperienceSvc   ExperienceService
 	PrjHdl          *ProjectHandler
 	ExperienceHdl   *ExperienceHandler
 	AnalysisHandler *AnalysisHandler
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	dc *event.UserDeletionConsumer
 }
@@ -17,11 +17,14 @@
 package resume
 
 import (
+	"context"
 	"sync"
 
 	"github.com/ecodeclub/webook/internal/ai"
 
+	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/resume/internal/event"
 	"github.com/ecodeclub/webook/internal/resume/internal/repository"
 	"github.com/ecodeclub/webook/internal/resume/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/resume/internal/service"
@@ -30,7 +33,7 @@ import (
 	"github.com/google/wire"
 )
 
-func InitModule(db *egorm.Component, caModule *cases.Module, aiModule *ai.Module) *Module {
+func InitModule(db *egorm.Component, caModule *cases.Module, aiModule *ai.Module, q mq.MQ) *Module {
 	wire.Build(
 		initResumeProjectDAOOnce,
 		dao.NewExperienceDAO,
@@ -45,6 +48,8 @@ func InitModule(db *egorm.Component, caModule *cases.Module, aiModule *ai.Module
 		web.NewHandler,
 		web.NewAnalysisHandler,
 		web.NewExperienceHandler,
+		service.NewExportSource,
+		initUserDeletionConsumer,
 		wire.Struct(new(Module), "*"),
 	)
 	return new(Module)
@@ -65,3 +70,12 @@ func initResumeProjectDAOOnce(db *egorm.Component) dao.ResumeProjectDAO {
 	})
 	return resumeProjectDAO
 }
+
+func initUserDeletionConsumer(svc service.Service, expSvc service.ExperienceService, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, expSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
@@ -7,10 +7,13 @@
 package resume
 
 import (
+	"context"
 	"sync"
 
+	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/resume/internal/event"
 	"github.com/ecodeclub/webook/internal/resume/internal/repository"
 	"github.com/ecodeclub/webook/internal/resume/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/resume/internal/service"
@@ -21,7 +24,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, caModule *cases.Module, aiModule *ai.Module) *Module {
+func InitModule(db *gorm.DB, caModule *cases.Module, aiModule *ai.Module, q mq.MQ) *Module {
 	daoResumeProjectDAO := initResumeProjectDAOOnce(db)
 	resumeProjectRepo := repository.NewResumeProjectRepo(daoResumeProjectDAO)
 	serviceService := service.NewService(resumeProjectRepo)
@@ -35,10 +38,16 @@ func InitModule(db *gorm.DB, caModule *cases.Module, aiModule *ai.Module) *Modul
 	llmService := aiModule.Svc
 	analysisService := service.NewAnalysisService(llmService)
 	analysisHandler := web.NewAnalysisHandler(analysisService)
+	source := service.NewExportSource(serviceService, experienceService)
+	userDeletionConsumer := initUserDeletionConsumer(serviceService, experienceService, q)
 	module := &Module{
+		Svc:             serviceService,
+		ExperienceSvc:   experienceService,
 		PrjHdl:          projectHandler,
 		ExperienceHdl:   experienceHandler,
 		AnalysisHandler: analysisHandler,
+		ExportSource:    source,
+		dc:              userDeletionConsumer,
 	}
 	return module
 }
@@ -60,3 +69,12 @@ func initResumeProjectDAOOnce(db *egorm.Component) dao.ResumeProjectDAO {
 	})
 	return resumeProjectDAO
 }
+
+func initUserDeletionConsumer(svc service.Service, expSvc service.ExperienceService, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, expSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
@@ -15,6 +15,10 @@ type Review struct {
 	Resume           string
 	Status           ReviewStatus
 	Utime            int64
+
+	// 定时发布和定时下线的时间，毫秒数，0 代表没有设置
+	PublishAt   int64
+	UnpublishAt int64
 }
 type ReviewStatus uint8
 
@@ -1,6 +1,8 @@
 package errs
 
 var (
+	InvalidSchedule = ErrorCode{Code: 416001, Msg: "定时下线时间必须晚于定时发布时间"}
+
 	SystemError = ErrorCode{Code: 516001, Msg: "系统错误"}
 )
 
@@ -0,0 +1,74 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package event
+
+import (
+	"encoding/json"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/ecodeclub/webook/internal/review/internal/domain"
+)
+
+const syncTopic = "sync_data_to_search"
+
+type SyncEventProducer mqx.Producer[SyncEvent]
+
+func NewSyncEventProducer(p mq.MQ) (SyncEventProducer, error) {
+	return mqx.NewGeneralProducer[SyncEvent](p, syncTopic)
+}
+
+type SyncEvent struct {
+	Biz   string `json:"biz"`
+	BizID int    `json:"bizID"`
+	// Data 是面经序列化之后的 JSON
+	Data string `json:"data"`
+}
+
+type Review struct {
+	ID               int64    `json:"id"`
+	Uid              int64    `json:"uid"`
+	Title            string   `json:"title"`
+	Desc             string   `json:"desc"`
+	Labels           []string `json:"labels"`
+	JD               string   `json:"jd"`
+	JDAnalysis       string   `json:"jd_analysis"`
+	Questions        string   `json:"questions"`
+	QuestionAnalysis string   `json:"question_analysis"`
+	Resume           string   `json:"resume"`
+	Status           uint8    `json:"status"`
+	Utime            int64    `json:"utime"`
+}
+
+func NewSyncEvent(re domain.Review) SyncEvent {
+	val, _ := json.Marshal(Review{
+		ID:               re.ID,
+		Uid:              re.Uid,
+		Title:            re.Title,
+		Desc:             re.Desc,
+		Labels:           re.Labels,
+		JD:               re.JD,
+		JDAnalysis:       re.JDAnalysis,
+		Questions:        re.Questions,
+		QuestionAnalysis: re.QuestionAnalysis,
+		Resume:           re.Resume,
+		Status:           re.Status.ToUint8(),
+		Utime:            re.Utime,
+	})
+	return SyncEvent{
+		Biz:   domain.ReviewBiz,
+		BizID: int(re.ID),
+		Data:  string(val),
+	}
+}
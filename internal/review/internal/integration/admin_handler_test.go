@@ -27,6 +27,7 @@ import (
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/interactive"
 	intrmocks "github.com/ecodeclub/webook/internal/interactive/mocks"
+	"github.com/ecodeclub/webook/internal/review"
 	"github.com/ecodeclub/webook/internal/review/internal/domain"
 	"github.com/ecodeclub/webook/internal/review/internal/integration/startup"
 	"github.com/ecodeclub/webook/internal/review/internal/repository/dao"
@@ -48,6 +49,7 @@ type AdminHandlerTestSuite struct {
 	db        *egorm.Component
 	server    *egin.Component
 	reviewDao dao.ReviewDAO
+	job       *review.ScheduledPublishJob
 }
 
 func (s *AdminHandlerTestSuite) TearDownTest() {
@@ -89,6 +91,113 @@ func (s *AdminHandlerTestSuite) SetupSuite() {
 	s.db = db
 	s.server = server
 	s.reviewDao = reviewDao
+	s.job = mou.ScheduledPublishJob
+}
+
+func (s *AdminHandlerTestSuite) TestSchedule() {
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		after    func(t *testing.T)
+		req      web.ScheduleReq
+		wantCode int
+		wantResp test.Result[any]
+	}{
+		{
+			name: "设置定时发布和定时下线",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Review{ID: 1, Title: "标题1"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var re dao.Review
+				err := s.db.Where("id = ?", 1).First(&re).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(1000), re.PublishAt)
+				assert.Equal(t, int64(2000), re.UnpublishAt)
+			},
+			req:      web.ScheduleReq{ID: 1, PublishAt: 1000, UnpublishAt: 2000},
+			wantCode: 200,
+			wantResp: test.Result[any]{},
+		},
+		{
+			name: "下线时间早于发布时间",
+			before: func(t *testing.T) {
+				err := s.db.Create(&dao.Review{ID: 2, Title: "标题2"}).Error
+				require.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				var re dao.Review
+				err := s.db.Where("id = ?", 2).First(&re).Error
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), re.PublishAt)
+				assert.Equal(t, int64(0), re.UnpublishAt)
+			},
+			req:      web.ScheduleReq{ID: 2, PublishAt: 2000, UnpublishAt: 2000},
+			wantCode: 500,
+			wantResp: test.Result[any]{Code: 416001, Msg: "定时下线时间必须晚于定时发布时间"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/review/schedule", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[any]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, tc.wantCode, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+			tc.after(t)
+		})
+	}
+}
+
+func (s *AdminHandlerTestSuite) TestScheduledPublishJob() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	now := time.Now()
+	past := now.Add(-time.Minute).UnixMilli()
+	future := now.Add(time.Hour).UnixMilli()
+	unpublished := domain.UnPublishedStatus.ToUint8()
+	published := domain.PublishedStatus.ToUint8()
+	reviews := []dao.Review{
+		// 到期需要发布
+		{ID: 1, Title: "到期发布", Status: unpublished, PublishAt: past},
+		// 还没到期
+		{ID: 2, Title: "没有到期", Status: unpublished, PublishAt: future},
+		// 到期需要下线
+		{ID: 3, Title: "到期下线", Status: published, UnpublishAt: past},
+	}
+	err := s.db.Create(&reviews).Error
+	require.NoError(t, err)
+	err = s.db.Create(&dao.PublishReview{ID: 3, Title: "到期下线", Status: published}).Error
+	require.NoError(t, err)
+
+	err = s.job.Run(ctx)
+	require.NoError(t, err)
+
+	var res []dao.Review
+	err = s.db.Order("id ASC").Find(&res).Error
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, published, res[0].Status)
+	assert.Equal(t, int64(0), res[0].PublishAt)
+	assert.Equal(t, unpublished, res[1].Status)
+	assert.Equal(t, future, res[1].PublishAt)
+	assert.Equal(t, unpublished, res[2].Status)
+	assert.Equal(t, int64(0), res[2].UnpublishAt)
+
+	// 线上库只剩下到期发布的
+	var pubs []dao.PublishReview
+	err = s.db.Order("id ASC").Find(&pubs).Error
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, int64(1), pubs[0].ID)
+	assert.Equal(t, "到期发布", pubs[0].Title)
 }
 
 func (s *AdminHandlerTestSuite) TestSave() {
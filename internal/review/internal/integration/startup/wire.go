@@ -8,6 +8,7 @@ import (
 	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/review"
 	"github.com/ecodeclub/webook/internal/review/internal/event"
+	"github.com/ecodeclub/webook/internal/review/internal/job"
 	"github.com/ecodeclub/webook/internal/review/internal/repository"
 	"github.com/ecodeclub/webook/internal/review/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/review/internal/service"
@@ -20,10 +21,13 @@ func InitModule(db *egorm.Component, interSvc *interactive.Module, q mq.MQ, sp s
 	wire.Build(
 		initReviewDao,
 		initIntrProducer,
+		initSyncProducer,
 		repository.NewReviewRepo,
 		service.NewReviewSvc,
 		web.NewHandler,
 		web.NewAdminHandler,
+		initScheduledPublishJob,
+		service.NewSearchSource,
 		wire.Struct(new(review.Module), "*"),
 		wire.FieldsOf(new(*interactive.Module), "Svc"),
 	)
@@ -37,6 +41,18 @@ func initReviewDao(db *egorm.Component) dao.ReviewDAO {
 	}
 	return dao.NewReviewDAO(db)
 }
+func initScheduledPublishJob(svc service.ReviewSvc) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
+func initSyncProducer(q mq.MQ) event.SyncEventProducer {
+	producer, err := event.NewSyncEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
+
 func initIntrProducer(q mq.MQ) event.InteractiveEventProducer {
 	producer, err := event.NewInteractiveEventProducer(q)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/review"
 	"github.com/ecodeclub/webook/internal/review/internal/event"
+	"github.com/ecodeclub/webook/internal/review/internal/job"
 	"github.com/ecodeclub/webook/internal/review/internal/repository"
 	"github.com/ecodeclub/webook/internal/review/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/review/internal/service"
@@ -26,13 +27,18 @@ func InitModule(db *gorm.DB, interSvc *interactive.Module, q mq.MQ, sp session.P
 	reviewDAO := initReviewDao(db)
 	reviewRepo := repository.NewReviewRepo(reviewDAO)
 	interactiveEventProducer := initIntrProducer(q)
-	reviewSvc := service.NewReviewSvc(reviewRepo, interactiveEventProducer)
+	syncEventProducer := initSyncProducer(q)
+	reviewSvc := service.NewReviewSvc(reviewRepo, interactiveEventProducer, syncEventProducer)
 	serviceService := interSvc.Svc
 	handler := web.NewHandler(reviewSvc, serviceService, sp)
 	adminHandler := web.NewAdminHandler(reviewSvc)
+	scheduledPublishJob := initScheduledPublishJob(reviewSvc)
+	source := service.NewSearchSource(reviewRepo)
 	module := &review.Module{
-		Hdl:      handler,
-		AdminHdl: adminHandler,
+		Hdl:                 handler,
+		AdminHdl:            adminHandler,
+		ScheduledPublishJob: scheduledPublishJob,
+		SearchSource:        source,
 	}
 	return module
 }
@@ -47,6 +53,18 @@ func initReviewDao(db *egorm.Component) dao.ReviewDAO {
 	return dao.NewReviewDAO(db)
 }
 
+func initScheduledPublishJob(svc service.ReviewSvc) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 10)
+}
+
+func initSyncProducer(q mq.MQ) event.SyncEventProducer {
+	producer, err := event.NewSyncEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
+
 func initIntrProducer(q mq.MQ) event.InteractiveEventProducer {
 	producer, err := event.NewInteractiveEventProducer(q)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/ego-component/egorm"
 )
 
@@ -28,15 +29,32 @@ type ReviewDAO interface {
 	Sync(ctx context.Context, c Review) (int64, error)
 	PublishReviewList(ctx context.Context, offset, limit int) ([]PublishReview, error)
 	GetPublishReview(ctx context.Context, reviewId int64) (PublishReview, error)
+	// PubIds 线上库全部已发布的面经的 id
+	PubIds(ctx context.Context) ([]int64, error)
+
+	// Unpublish 删除线上库的数据，并且将制作库的状态修改为未发布
+	Unpublish(ctx context.Context, id int64) error
+	// UpdateSchedule 设置定时发布和定时下线的时间
+	UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	// ClearPublishAt 只有在定时发布时间没有被修改过的情况下才会清空
+	ClearPublishAt(ctx context.Context, id int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset, limit int) ([]Review, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	// DuePublish 到期需要发布的数据，按照 id 升序，只返回 id 大于 minId 的
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]Review, error)
+	// DueUnpublish 到期需要下线的数据，按照 id 升序，只返回 id 大于 minId 的
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]Review, error)
 }
 
 type reviewDao struct {
+	*schedulex.GORMDAO[Review]
 	db *egorm.Component
 }
 
 func NewReviewDAO(db *egorm.Component) ReviewDAO {
 	return &reviewDao{
-		db: db,
+		GORMDAO: schedulex.NewGORMDAO[Review](db),
+		db:      db,
 	}
 }
 
@@ -102,6 +120,15 @@ func (r *reviewDao) Sync(ctx context.Context, c Review) (int64, error) {
 	return id, err
 }
 
+func (r *reviewDao) PubIds(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Model(&PublishReview{}).
+		Where("status = ?", domain.PublishedStatus.ToUint8()).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 func (r *reviewDao) PublishReviewList(ctx context.Context, offset, limit int) ([]PublishReview, error) {
 	var publishReviews []PublishReview
 	err := r.db.WithContext(ctx).
@@ -127,6 +154,22 @@ func (r *reviewDao) GetPublishReview(ctx context.Context, reviewId int64) (Publi
 	return publishReview, nil
 }
 
+func (r *reviewDao) Unpublish(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 手动下线的时候也要清空定时发布，避免定时任务又把它发布出去
+		err := tx.Model(&Review{}).Where("id = ?", id).Updates(map[string]any{
+			"status":       domain.UnPublishedStatus.ToUint8(),
+			"publish_at":   0,
+			"unpublish_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&PublishReview{}).Error
+	})
+}
+
 func (r *reviewDao) getUpdateCols() []string {
 	return []string{
 		"jd",
@@ -15,8 +15,11 @@ type Review struct {
 	QuestionAnalysis string                    `gorm:"column:question_analysis;type:text"`
 	Resume           string                    `gorm:"column:resume;type:text"`
 	Status           uint8                     `gorm:"type:tinyint(3);comment:0-未知 1-未发表 2-已发表"`
-	Ctime            int64                     `gorm:"column:ctime"`
-	Utime            int64                     `gorm:"column:utime"`
+	// 定时发布和定时下线的时间，0 代表没有设置
+	PublishAt   int64 `gorm:"index;not null;default:0"`
+	UnpublishAt int64 `gorm:"index;not null;default:0"`
+	Ctime       int64 `gorm:"column:ctime"`
+	Utime       int64 `gorm:"column:utime"`
 }
 
 type PublishReview Review
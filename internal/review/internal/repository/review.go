@@ -19,6 +19,17 @@ type ReviewRepo interface {
 	Publish(ctx context.Context, re domain.Review) (int64, error)
 	PubList(ctx context.Context, offset, limit int) ([]domain.Review, error)
 	PubInfo(ctx context.Context, id int64) (domain.Review, error)
+	// PubIds 全部已发布的面经的 id
+	PubIds(ctx context.Context) ([]int64, error)
+
+	// Unpublish 下线，删除线上库的数据
+	Unpublish(ctx context.Context, id int64) error
+	UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	ClearPublishAt(ctx context.Context, id int64, publishAt int64) error
+	ScheduledList(ctx context.Context, offset, limit int) ([]domain.Review, error)
+	ScheduledCount(ctx context.Context) (int64, error)
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error)
 }
 type reviewRepo struct {
 	reviewDao dao.ReviewDAO
@@ -73,6 +84,10 @@ func (r *reviewRepo) PubList(ctx context.Context, offset, limit int) ([]domain.R
 	return list, nil
 }
 
+func (r *reviewRepo) PubIds(ctx context.Context) ([]int64, error) {
+	return r.reviewDao.PubIds(ctx)
+}
+
 func (r *reviewRepo) PubInfo(ctx context.Context, id int64) (domain.Review, error) {
 	pubReview, err := r.reviewDao.GetPublishReview(ctx, id)
 	if err != nil {
@@ -82,6 +97,43 @@ func (r *reviewRepo) PubInfo(ctx context.Context, id int64) (domain.Review, erro
 }
 
 // 将 domain.Review 转换为 dao.Review
+func (r *reviewRepo) Unpublish(ctx context.Context, id int64) error {
+	return r.reviewDao.Unpublish(ctx, id)
+}
+
+func (r *reviewRepo) UpdateSchedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error {
+	return r.reviewDao.UpdateSchedule(ctx, id, publishAt, unpublishAt)
+}
+
+func (r *reviewRepo) ClearPublishAt(ctx context.Context, id int64, publishAt int64) error {
+	return r.reviewDao.ClearPublishAt(ctx, id, publishAt)
+}
+
+func (r *reviewRepo) ScheduledList(ctx context.Context, offset, limit int) ([]domain.Review, error) {
+	reviews, err := r.reviewDao.ScheduledList(ctx, offset, limit)
+	return slice.Map(reviews, func(idx int, src dao.Review) domain.Review {
+		return toDomainReview(src)
+	}), err
+}
+
+func (r *reviewRepo) ScheduledCount(ctx context.Context) (int64, error) {
+	return r.reviewDao.ScheduledCount(ctx)
+}
+
+func (r *reviewRepo) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error) {
+	reviews, err := r.reviewDao.DuePublish(ctx, now, minId, limit)
+	return slice.Map(reviews, func(idx int, src dao.Review) domain.Review {
+		return toDomainReview(src)
+	}), err
+}
+
+func (r *reviewRepo) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error) {
+	reviews, err := r.reviewDao.DueUnpublish(ctx, now, minId, limit)
+	return slice.Map(reviews, func(idx int, src dao.Review) domain.Review {
+		return toDomainReview(src)
+	}), err
+}
+
 func toDaoReview(review domain.Review) dao.Review {
 	return dao.Review{
 		ID:               review.ID,
@@ -112,5 +164,7 @@ func toDomainReview(review dao.Review) domain.Review {
 		Resume:           review.Resume,
 		Status:           domain.ReviewStatus(review.Status),
 		Utime:            review.Utime,
+		PublishAt:        review.PublishAt,
+		UnpublishAt:      review.UnpublishAt,
 	}
 }
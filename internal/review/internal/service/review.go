@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ecodeclub/webook/internal/pkg/schedulex"
 	"github.com/ecodeclub/webook/internal/review/internal/event"
 	"github.com/gotomicro/ego/core/elog"
 
@@ -20,14 +21,30 @@ type ReviewSvc interface {
 
 	Publish(ctx context.Context, re domain.Review) (int64, error)
 	PubList(ctx context.Context, offset, limit int) ([]domain.Review, error)
-	PubInfo(ctx context.Context, id int64) (domain.Review, error)
+	PubInfo(ctx context.Context, uid, id int64) (domain.Review, error)
+
+	// Unpublish 下线，只会删除线上库的数据，制作库的数据会保留
+	Unpublish(ctx context.Context, id int64) error
+	// Schedule 设置定时发布和定时下线的时间，毫秒数，0 代表取消
+	Schedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error
+	ScheduledList(ctx context.Context, offset, limit int) (int64, []domain.Review, error)
+	// DuePublish 和 DueUnpublish 给定时任务使用
+	DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error)
+	DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error)
+	// PublishScheduled 发布制作库中的数据，并且清空定时发布时间
+	PublishScheduled(ctx context.Context, re domain.Review) error
 }
 
-func NewReviewSvc(repo repository.ReviewRepo, intrProducer event.InteractiveEventProducer) ReviewSvc {
+var ErrInvalidSchedule = schedulex.ErrInvalidSchedule
+
+func NewReviewSvc(repo repository.ReviewRepo,
+	intrProducer event.InteractiveEventProducer,
+	syncProducer event.SyncEventProducer) ReviewSvc {
 	return &reviewSvc{
 		repo:         repo,
 		logger:       elog.DefaultLogger,
 		intrProducer: intrProducer,
+		syncProducer: syncProducer,
 	}
 }
 
@@ -35,6 +52,7 @@ type reviewSvc struct {
 	repo         repository.ReviewRepo
 	logger       *elog.Component
 	intrProducer event.InteractiveEventProducer
+	syncProducer event.SyncEventProducer
 }
 
 func (r *reviewSvc) Save(ctx context.Context, re domain.Review) (int64, error) {
@@ -67,20 +85,24 @@ func (r *reviewSvc) Info(ctx context.Context, id int64) (domain.Review, error) {
 
 func (r *reviewSvc) Publish(ctx context.Context, re domain.Review) (int64, error) {
 	re.Status = domain.PublishedStatus
-	return r.repo.Publish(ctx, re)
+	id, err := r.repo.Publish(ctx, re)
+	if err == nil {
+		r.syncToSearch(id, r.repo.PubInfo)
+	}
+	return id, err
 }
 
 func (r *reviewSvc) PubList(ctx context.Context, offset, limit int) ([]domain.Review, error) {
 	return r.repo.PubList(ctx, offset, limit)
 }
 
-func (r *reviewSvc) PubInfo(ctx context.Context, id int64) (domain.Review, error) {
+func (r *reviewSvc) PubInfo(ctx context.Context, uid, id int64) (domain.Review, error) {
 	re, err := r.repo.PubInfo(ctx, id)
 	if err == nil {
 		go func() {
 			newCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer cancel()
-			err1 := r.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.ReviewBiz))
+			err1 := r.intrProducer.Produce(newCtx, event.NewViewCntEvent(id, domain.ReviewBiz, uid))
 			if err1 != nil {
 				if err1 != nil {
 					r.logger.Error("发送面经阅读计数消息到消息队列失败",
@@ -92,3 +114,72 @@ func (r *reviewSvc) PubInfo(ctx context.Context, id int64) (domain.Review, error
 	}
 	return re, err
 }
+
+func (r *reviewSvc) Unpublish(ctx context.Context, id int64) error {
+	err := r.repo.Unpublish(ctx, id)
+	if err == nil {
+		// 线上库已经没有数据了，所以用制作库的数据同步，搜索那边只会返回已发布的数据
+		r.syncToSearch(id, r.repo.Info)
+	}
+	return err
+}
+
+func (r *reviewSvc) syncToSearch(id int64,
+	info func(ctx context.Context, id int64) (domain.Review, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	re, err := info(ctx, id)
+	if err != nil {
+		r.logger.Error("准备同步数据，查询面经详情失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+		return
+	}
+	err = r.syncProducer.Produce(ctx, event.NewSyncEvent(re))
+	if err != nil {
+		r.logger.Error("同步数据到搜索失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+	}
+}
+
+func (r *reviewSvc) Schedule(ctx context.Context, id int64, publishAt int64, unpublishAt int64) error {
+	if err := schedulex.Check(publishAt, unpublishAt); err != nil {
+		return err
+	}
+	return r.repo.UpdateSchedule(ctx, id, publishAt, unpublishAt)
+}
+
+func (r *reviewSvc) ScheduledList(ctx context.Context, offset, limit int) (int64, []domain.Review, error) {
+	var eg errgroup.Group
+	var count int64
+	var reviews []domain.Review
+	eg.Go(func() error {
+		var eerr error
+		reviews, eerr = r.repo.ScheduledList(ctx, offset, limit)
+		return eerr
+	})
+	eg.Go(func() error {
+		var eerr error
+		count, eerr = r.repo.ScheduledCount(ctx)
+		return eerr
+	})
+	err := eg.Wait()
+	return count, reviews, err
+}
+
+func (r *reviewSvc) DuePublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error) {
+	return r.repo.DuePublish(ctx, now, minId, limit)
+}
+
+func (r *reviewSvc) DueUnpublish(ctx context.Context, now int64, minId int64, limit int) ([]domain.Review, error) {
+	return r.repo.DueUnpublish(ctx, now, minId, limit)
+}
+
+func (r *reviewSvc) PublishScheduled(ctx context.Context, re domain.Review) error {
+	_, err := r.Publish(ctx, re)
+	if err != nil {
+		return err
+	}
+	return r.repo.ClearPublishAt(ctx, re.ID, re.PublishAt)
+}
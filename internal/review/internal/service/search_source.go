@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/review/internal/domain"
+	"github.com/ecodeclub/webook/internal/review/internal/event"
+	"github.com/ecodeclub/webook/internal/review/internal/repository"
+)
+
+// searchSource 搜索重建索引的时候读取全量的面经
+// 和发布时候的同步消息一样，使用线上库的数据
+type searchSource struct {
+	repo repository.ReviewRepo
+}
+
+func NewSearchSource(repo repository.ReviewRepo) searchx.Source {
+	return &searchSource{repo: repo}
+}
+
+func (s *searchSource) Biz() string {
+	return domain.ReviewBiz
+}
+
+func (s *searchSource) Ids(ctx context.Context) ([]int64, error) {
+	return s.repo.PubIds(ctx)
+}
+
+func (s *searchSource) Doc(ctx context.Context, id int64) (string, error) {
+	re, err := s.repo.PubInfo(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewSyncEvent(re).Data, nil
+}
@@ -1,6 +1,8 @@
 package web
 
 import (
+	"errors"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
@@ -18,6 +20,9 @@ func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
 	server.POST("/review/list", ginx.B[Page](h.List))
 	server.POST("/review/detail", ginx.B[DetailReq](h.Detail))
 	server.POST("/review/publish", ginx.BS[ReviewSaveReq](h.Publish))
+	server.POST("/review/unpublish", ginx.B[DetailReq](h.Unpublish))
+	server.POST("/review/schedule", ginx.B[ScheduleReq](h.Schedule))
+	server.POST("/review/scheduled/list", ginx.B[Page](h.ScheduledList))
 }
 
 func NewAdminHandler(svc service.ReviewSvc) *AdminHandler {
@@ -76,3 +81,38 @@ func (h *AdminHandler) Publish(ctx *ginx.Context, req ReviewSaveReq, sess sessio
 		Data: id,
 	}, nil
 }
+
+func (h *AdminHandler) Unpublish(ctx *ginx.Context, req DetailReq) (ginx.Result, error) {
+	err := h.svc.Unpublish(ctx, req.ID)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{}, nil
+}
+
+func (h *AdminHandler) Schedule(ctx *ginx.Context, req ScheduleReq) (ginx.Result, error) {
+	err := h.svc.Schedule(ctx, req.ID, req.PublishAt, req.UnpublishAt)
+	switch {
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return invalidScheduleResult, err
+	case err != nil:
+		return systemErrorResult, err
+	default:
+		return ginx.Result{}, nil
+	}
+}
+
+func (h *AdminHandler) ScheduledList(ctx *ginx.Context, req Page) (ginx.Result, error) {
+	count, reviewList, err := h.svc.ScheduledList(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: ReviewListResp{
+			Total: count,
+			List: slice.Map(reviewList, func(idx int, src domain.Review) Review {
+				return newReview(src)
+			}),
+		},
+	}, nil
+}
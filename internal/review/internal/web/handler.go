@@ -72,7 +72,7 @@ func (h *Handler) PubList(ctx *ginx.Context, req Page) (ginx.Result, error) {
 // PubDetail 获取已发布的面试评测记录详情
 func (h *Handler) PubDetail(ctx *ginx.Context, req DetailReq) (ginx.Result, error) {
 	// 调用 service 层获取数据
-	review, err := h.svc.PubInfo(ctx, req.ID)
+	review, err := h.svc.PubInfo(ctx, h.getUid(ctx), req.ID)
 	if err != nil {
 		return systemErrorResult, err
 	}
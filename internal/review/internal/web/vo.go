@@ -22,9 +22,14 @@ type Review struct {
 	Status           uint8       `json:"status,omitempty"`
 	Utime            int64       `json:"utime,omitempty"`
 	Interactive      Interactive `json:"interactive,omitempty"`
+
+	// 定时发布和定时下线的时间，只有管理后台会用到
+	PublishAt   int64 `json:"publishAt,omitempty"`
+	UnpublishAt int64 `json:"unpublishAt,omitempty"`
 }
 type Interactive struct {
 	CollectCnt int  `json:"collectCnt"`
+	CommentCnt int  `json:"commentCnt"`
 	LikeCnt    int  `json:"likeCnt"`
 	ViewCnt    int  `json:"viewCnt"`
 	Liked      bool `json:"liked"`
@@ -54,6 +59,8 @@ func newReview(re domain.Review) Review {
 		Resume:           re.Resume,
 		Status:           re.Status.ToUint8(),
 		Utime:            re.Utime,
+		PublishAt:        re.PublishAt,
+		UnpublishAt:      re.UnpublishAt,
 	}
 }
 
@@ -76,6 +83,13 @@ func (r Review) toDomain() domain.Review {
 type DetailReq struct {
 	ID int64 `json:"id,omitempty"`
 }
+
+// ScheduleReq 定时发布和定时下线，毫秒数，0 代表取消
+type ScheduleReq struct {
+	ID          int64 `json:"id"`
+	PublishAt   int64 `json:"publishAt"`
+	UnpublishAt int64 `json:"unpublishAt"`
+}
 type Page struct {
 	Offset int `json:"offset,omitempty"`
 	Limit  int `json:"limit,omitempty"`
@@ -84,6 +98,7 @@ type Page struct {
 func newInteractive(intr interactive.Interactive) Interactive {
 	return Interactive{
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		ViewCnt:    intr.ViewCnt,
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
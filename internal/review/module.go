@@ -1,10 +1,17 @@
 package review
 
-import "github.com/ecodeclub/webook/internal/review/internal/web"
+import (
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/review/internal/job"
+	"github.com/ecodeclub/webook/internal/review/internal/web"
+)
 
 type Module struct {
-	Hdl      *Hdl
-	AdminHdl *AdminHdl
+	Hdl                 *Hdl
+	AdminHdl            *AdminHdl
+	ScheduledPublishJob *ScheduledPublishJob
+	SearchSource        searchx.Source
 }
 type AdminHdl = web.AdminHandler
 type Hdl = web.Handler
+type ScheduledPublishJob = job.ScheduledPublishJob
@@ -58,4 +58,6 @@ type Biz struct {
 const (
 	BizQuestion    = "question"
 	BizQuestionSet = "questionSet"
+	// BizRoadmap 学习路线本身，同步到搜索的时候使用
+	BizRoadmap = "roadmap"
 )
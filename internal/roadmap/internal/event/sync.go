@@ -0,0 +1,63 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package event
+
+import (
+	"encoding/json"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/domain"
+)
+
+const syncTopic = "sync_data_to_search"
+
+type SyncEventProducer mqx.Producer[SyncEvent]
+
+func NewSyncEventProducer(q mq.MQ) (SyncEventProducer, error) {
+	return mqx.NewGeneralProducer[SyncEvent](q, syncTopic)
+}
+
+type SyncEvent struct {
+	Biz   string `json:"biz"`
+	BizID int    `json:"bizID"`
+	// Data 是学习路线序列化之后的 JSON
+	Data string `json:"data"`
+}
+
+// Roadmap 只同步标题和所属的业务，节点不参与搜索
+type Roadmap struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Biz      string `json:"biz"`
+	BizId    int64  `json:"biz_id"`
+	BizTitle string `json:"biz_title"`
+	Utime    int64  `json:"utime"`
+}
+
+func NewSyncEvent(r domain.Roadmap, bizTitle string) SyncEvent {
+	val, _ := json.Marshal(Roadmap{
+		Id:       r.Id,
+		Title:    r.Title,
+		Biz:      r.Biz,
+		BizId:    r.BizId,
+		BizTitle: bizTitle,
+		Utime:    r.Utime,
+	})
+	return SyncEvent{
+		Biz:   domain.BizRoadmap,
+		BizID: int(r.Id),
+		Data:  string(val),
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 func InitModule(queModule *baguwen.Module) *roadmap.Module {
 	db := testioc.InitDB()
-	module := roadmap.InitModule(db, queModule)
+	mq := testioc.InitMQ()
+	module := roadmap.InitModule(db, queModule, mq)
 	return module
 }
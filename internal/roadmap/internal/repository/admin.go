@@ -29,6 +29,8 @@ type AdminRepository interface {
 	Save(ctx context.Context, r domain.Roadmap) (int64, error)
 	List(ctx context.Context, offset int, limit int) ([]domain.Roadmap, error)
 	GetById(ctx context.Context, id int64) (domain.Roadmap, error)
+	// Ids 全部学习路线的 id，重建搜索索引的时候使用
+	Ids(ctx context.Context) ([]int64, error)
 	AddEdge(ctx context.Context, rid int64, edge domain.Edge) error
 	DeleteEdge(ctx context.Context, id int64) error
 }
@@ -55,6 +57,10 @@ func (repo *CachedAdminRepository) AddEdge(ctx context.Context, rid int64, edge
 	})
 }
 
+func (repo *CachedAdminRepository) Ids(ctx context.Context) ([]int64, error) {
+	return repo.dao.Ids(ctx)
+}
+
 func (repo *CachedAdminRepository) GetById(ctx context.Context, id int64) (domain.Roadmap, error) {
 	var (
 		eg    errgroup.Group
@@ -26,6 +26,7 @@ type AdminDAO interface {
 	Save(ctx context.Context, r Roadmap) (int64, error)
 	GetById(ctx context.Context, id int64) (Roadmap, error)
 	List(ctx context.Context, offset int, limit int) ([]Roadmap, error)
+	Ids(ctx context.Context) ([]int64, error)
 	GetEdgesByRid(ctx context.Context, rid int64) ([]Edge, error)
 	AddEdge(ctx context.Context, edge Edge) error
 	DeleteEdge(ctx context.Context, id int64) error
@@ -61,6 +62,12 @@ func (dao *GORMAdminDAO) List(ctx context.Context, offset int, limit int) ([]Roa
 	return res, err
 }
 
+func (dao *GORMAdminDAO) Ids(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).Model(&Roadmap{}).Pluck("id", &ids).Error
+	return ids, err
+}
+
 func (dao *GORMAdminDAO) GetById(ctx context.Context, id int64) (Roadmap, error) {
 	var r Roadmap
 	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&r).Error
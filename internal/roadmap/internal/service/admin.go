@@ -16,9 +16,12 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ecodeclub/webook/internal/roadmap/internal/domain"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/event"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
 )
 
 type AdminService interface {
@@ -32,7 +35,10 @@ type AdminService interface {
 var _ AdminService = &adminService{}
 
 type adminService struct {
-	repo repository.AdminRepository
+	repo     repository.AdminRepository
+	bizSvc   BizService
+	producer event.SyncEventProducer
+	logger   *elog.Component
 }
 
 func (svc *adminService) DeleteEdge(ctx context.Context, id int64) error {
@@ -52,11 +58,39 @@ func (svc *adminService) List(ctx context.Context, offset int, limit int) ([]dom
 }
 
 func (svc *adminService) Save(ctx context.Context, r domain.Roadmap) (int64, error) {
-	return svc.repo.Save(ctx, r)
+	id, err := svc.repo.Save(ctx, r)
+	if err == nil {
+		svc.syncToSearch(id)
+	}
+	return id, err
+}
+
+// syncToSearch 学习路线没有发布的概念，保存之后就同步到搜索
+func (svc *adminService) syncToSearch(id int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	evt, err := newSyncEvent(ctx, svc.repo, svc.bizSvc, svc.logger, id)
+	if err != nil {
+		svc.logger.Error("准备同步数据，查询学习路线详情失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+		return
+	}
+	err = svc.producer.Produce(ctx, evt)
+	if err != nil {
+		svc.logger.Error("同步数据到搜索失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+	}
 }
 
-func NewAdminService(repo repository.AdminRepository) AdminService {
+func NewAdminService(repo repository.AdminRepository,
+	bizSvc BizService,
+	producer event.SyncEventProducer) AdminService {
 	return &adminService{
-		repo: repo,
+		repo:     repo,
+		bizSvc:   bizSvc,
+		producer: producer,
+		logger:   elog.DefaultLogger,
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/domain"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/event"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// searchSource 搜索重建索引的时候读取全量的学习路线
+type searchSource struct {
+	repo   repository.AdminRepository
+	bizSvc BizService
+	logger *elog.Component
+}
+
+func NewSearchSource(repo repository.AdminRepository, bizSvc BizService) searchx.Source {
+	return &searchSource{
+		repo:   repo,
+		bizSvc: bizSvc,
+		logger: elog.DefaultLogger,
+	}
+}
+
+func (s *searchSource) Biz() string {
+	return domain.BizRoadmap
+}
+
+func (s *searchSource) Ids(ctx context.Context) ([]int64, error) {
+	return s.repo.Ids(ctx)
+}
+
+func (s *searchSource) Doc(ctx context.Context, id int64) (string, error) {
+	evt, err := newSyncEvent(ctx, s.repo, s.bizSvc, s.logger, id)
+	if err != nil {
+		return "", err
+	}
+	return evt.Data, nil
+}
+
+// newSyncEvent 保存之后的同步和重建索引使用同样的数据
+func newSyncEvent(ctx context.Context, repo repository.AdminRepository,
+	bizSvc BizService, logger *elog.Component, id int64) (event.SyncEvent, error) {
+	r, err := repo.GetById(ctx, id)
+	if err != nil {
+		return event.SyncEvent{}, err
+	}
+	bizs, err := bizSvc.GetBizs(ctx, []string{r.Biz}, []int64{r.BizId})
+	if err != nil {
+		// 没有所属业务的标题也可以搜索
+		logger.Error("准备同步数据，查询学习路线所属的业务失败",
+			elog.Int64("id", id),
+			elog.FieldErr(err))
+	}
+	return event.NewSyncEvent(r, bizs[r.Biz][r.BizId].Title), nil
+}
@@ -15,12 +15,15 @@
 package roadmap
 
 import (
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/web"
 )
 
 type Module struct {
 	AdminHdl *AdminHandler
 	Hdl      *Handler
+	// SearchSource 搜索重建索引的时候使用
+	SearchSource searchx.Source
 }
 
 type AdminHandler = web.AdminHandler
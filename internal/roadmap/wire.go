@@ -19,7 +19,9 @@ package roadmap
 import (
 	"sync"
 
+	"github.com/ecodeclub/mq-api"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/event"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/repository"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/service"
@@ -28,11 +30,13 @@ import (
 	"github.com/google/wire"
 )
 
-func InitModule(db *egorm.Component, queModule *baguwen.Module) *Module {
+func InitModule(db *egorm.Component, queModule *baguwen.Module, q mq.MQ) *Module {
 	wire.Build(
+		initSyncProducer,
 		web.NewAdminHandler,
 		service.NewAdminService,
 		service.NewConcurrentBizService,
+		service.NewSearchSource,
 		repository.NewCachedAdminRepository,
 		initAdminDAO,
 
@@ -62,3 +66,11 @@ func initAdminDAO(db *egorm.Component) dao.AdminDAO {
 	})
 	return adminDAO
 }
+
+func initSyncProducer(q mq.MQ) event.SyncEventProducer {
+	producer, err := event.NewSyncEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
@@ -9,7 +9,9 @@ package roadmap
 import (
 	"sync"
 
+	"github.com/ecodeclub/mq-api"
 	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/roadmap/internal/event"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/repository"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/roadmap/internal/service"
@@ -20,21 +22,24 @@ import (
 
 // Injectors from wire.go:
 
-func InitModule(db *gorm.DB, queModule *baguwen.Module) *Module {
+func InitModule(db *gorm.DB, queModule *baguwen.Module, q mq.MQ) *Module {
 	daoAdminDAO := initAdminDAO(db)
 	adminRepository := repository.NewCachedAdminRepository(daoAdminDAO)
-	adminService := service.NewAdminService(adminRepository)
 	serviceService := queModule.Svc
 	questionSetService := queModule.SetSvc
 	bizService := service.NewConcurrentBizService(serviceService, questionSetService)
+	syncEventProducer := initSyncProducer(q)
+	adminService := service.NewAdminService(adminRepository, bizService, syncEventProducer)
 	adminHandler := web.NewAdminHandler(adminService, bizService)
 	roadmapDAO := dao.NewGORMRoadmapDAO(db)
 	repositoryRepository := repository.NewCachedRepository(roadmapDAO)
 	service2 := service.NewService(repositoryRepository)
 	handler := web.NewHandler(service2, bizService)
+	source := service.NewSearchSource(adminRepository, bizService)
 	module := &Module{
-		AdminHdl: adminHandler,
-		Hdl:      handler,
+		AdminHdl:     adminHandler,
+		Hdl:          handler,
+		SearchSource: source,
 	}
 	return module
 }
@@ -56,3 +61,11 @@ func initAdminDAO(db *egorm.Component) dao.AdminDAO {
 	})
 	return adminDAO
 }
+
+func initSyncProducer(q mq.MQ) event.SyncEventProducer {
+	producer, err := event.NewSyncEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
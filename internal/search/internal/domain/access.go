@@ -0,0 +1,56 @@
+package domain
+
+// BizBaguwen 八股文题目，需要会员才能打开
+const BizBaguwen = "baguwen"
+
+// Requirement 打开一条内容需要满足的条件，零值代表谁都可以打开
+type Requirement struct {
+	Member bool
+	// Biz 和 BizId 代表需要单独购买的权限，例如项目
+	Biz   string
+	BizId int64
+}
+
+func (r Requirement) NeedPermission() bool {
+	return r.Biz != ""
+}
+
+// Access 当前用户能打开哪些内容
+type Access struct {
+	Member bool
+	// Permissions 单独购买的权限，biz => id
+	Permissions map[string][]int64
+	// OnlyAccessible 为 true 的时候只搜索能打开的内容
+	OnlyAccessible bool
+}
+
+func (a Access) Allows(r Requirement) bool {
+	if r.Member && !a.Member {
+		return false
+	}
+	if !r.NeedPermission() {
+		return true
+	}
+	for _, id := range a.Permissions[r.Biz] {
+		if id == r.BizId {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Case) Requirement() Requirement {
+	return Requirement{Member: true}
+}
+
+// Requirement 八股文需要会员，属于其它业务的题目需要对应业务的权限，例如项目里面的题目
+func (q Question) Requirement() Requirement {
+	if q.Biz == "" || q.Biz == BizBaguwen {
+		return Requirement{Member: true}
+	}
+	return Requirement{Biz: q.Biz, BizId: q.BizId}
+}
+
+func (p Project) Requirement() Requirement {
+	return Requirement{Biz: BizProject, BizId: p.Id}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// QueryLog 一次搜索的记录，翻页不会产生新的记录
+type QueryLog struct {
+	// Sid 这一次搜索的标识，用户点击结果的时候带回来
+	Sid string
+	Uid int64
+	// Query 规范化之后的搜索表达式，参考 Query.String
+	Query string
+	// Biz 搜索的业务，多个业务用逗号分隔，为空代表全部业务
+	Biz string
+	// Hits 命中的结果总数
+	Hits    int64
+	Latency time.Duration
+	Ctime   time.Time
+}
+
+// Accept 点击是否可能来自这一次搜索：同一个用户，结果的位置没有越界，
+// 并且点击的业务在搜索的业务范围之内
+func (l QueryLog) Accept(click Click) bool {
+	if click.Uid != l.Uid || click.Position < 0 || int64(click.Position) >= l.Hits {
+		return false
+	}
+	return l.Biz == "" || slices.Contains(strings.Split(l.Biz, ","), click.Biz)
+}
+
+// Click 用户点击了某一次搜索的结果
+type Click struct {
+	Sid   string
+	Uid   int64
+	Biz   string
+	BizId int64
+	// Position 结果在这一次搜索里面的位置，从 0 开始
+	Position int
+	Ctime    time.Time
+}
+
+// QueryStat 一段时间内同一个搜索表达式的统计
+type QueryStat struct {
+	Query    string
+	Searches int64
+	// ZeroResults 没有任何结果的搜索次数
+	ZeroResults int64
+	// Clicks 至少点击了一个结果的搜索次数
+	Clicks int64
+}
+
+// ClickThroughRate 点击率，同一次搜索点击多次只算一次
+func (s QueryStat) ClickThroughRate() float64 {
+	if s.Searches == 0 {
+		return 0
+	}
+	return float64(s.Clicks) / float64(s.Searches)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// IndexName 业务对应的索引名字，重建索引之后它是指向真实索引的别名
+// biz 是驼峰的，例如 questionSet 对应 question_set_index
+func IndexName(biz string) string {
+	var sb strings.Builder
+	for i, r := range biz {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return fmt.Sprintf("%s_index", sb.String())
+}
+
+// CheckReport 索引和数据的权威来源之间的一致性检查结果
+type CheckReport struct {
+	Biz string
+	// Index 别名当前指向的索引
+	Index       string
+	SourceCount int64
+	IndexCount  int64
+	// Missing 数据来源有，索引里面没有
+	Missing []int64
+	// Extra 索引里面有，数据来源没有
+	Extra []int64
+	// Mismatched 两边都有，但是内容的哈希不一致
+	Mismatched []int64
+}
+
+func (r CheckReport) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
@@ -1,11 +1,168 @@
 package domain
 
-// 定义的查询元数据
-type QueryMeta struct {
-	// 查询的内容
+import (
+	"strings"
+	"time"
+)
+
+const (
+	LevelBasic        = "basic"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+)
+
+// Query 解析之后的搜索表达式
+// 例如 biz:question,case level:advanced "分布式锁" redis OR etcd -zookeeper label:redis updated:>2024-01-01
+type Query struct {
+	// Biz 要搜索的业务，为空代表搜索全部业务
+	Biz []string
+	// Level 只在对应级别的字段里面搜索关键字，例如题目的高级回答，为空代表不限制
+	// 没有级别概念的业务在指定了 Level 之后不会有结果
+	Level string
+	// Expr 关键字和过滤条件，nil 代表没有任何条件
+	Expr Expr
+	// Access 不为 nil 的时候只搜索用户能打开的内容，它不是搜索表达式的一部分
+	Access *Access
+}
+
+// String 规范化之后的搜索表达式，写法不同但是含义相同的搜索会得到同样的结果
+func (q Query) String() string {
+	parts := make([]string, 0, 3)
+	if len(q.Biz) > 0 {
+		parts = append(parts, "biz:"+strings.Join(q.Biz, ","))
+	}
+	if q.Level != "" {
+		parts = append(parts, "level:"+q.Level)
+	}
+	if q.Expr != nil {
+		parts = append(parts, q.Expr.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// Expr 搜索表达式的语法树节点
+type Expr interface {
+	// Scoring 为 true 的节点只影响排序，不要求一定命中；
+	// 否则就是必须满足的条件，例如短语、标签、时间、排除
+	Scoring() bool
+	// String 还原成搜索表达式
+	String() string
+}
+
+// Term 关键字
+type Term struct {
+	// Col 为空代表在所有字段里面搜索
+	Col     string
 	Keyword string
-	// 是否是全量字段的
-	IsAll bool
-	// 需要查询的关键字
-	Col string
+	// Phrase 为 true 代表精确匹配整个短语
+	Phrase bool
+}
+
+func (t Term) Scoring() bool {
+	return !t.Phrase
+}
+
+func (t Term) String() string {
+	keyword := t.Keyword
+	if t.Phrase {
+		keyword = `"` + keyword + `"`
+	}
+	if t.Col == "" {
+		return keyword
+	}
+	return t.Col + ":" + keyword
+}
+
+// Label 标签过滤
+type Label struct {
+	Label string
+}
+
+func (Label) Scoring() bool {
+	return false
+}
+
+func (l Label) String() string {
+	return "label:" + l.Label
+}
+
+// Updated 更新时间过滤，毫秒数，左闭右开，0 代表没有这一侧的限制
+type Updated struct {
+	Start int64
+	End   int64
+}
+
+func (Updated) Scoring() bool {
+	return false
+}
+
+// String 统一写成 2024-01-01..2024-02-01 的形式
+func (u Updated) String() string {
+	start, end := "*", "*"
+	if u.Start > 0 {
+		start = time.UnixMilli(u.Start).Format(time.DateOnly)
+	}
+	if u.End > 0 {
+		end = time.UnixMilli(u.End).AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+	return "updated:" + start + ".." + end
+}
+
+// Not 排除
+type Not struct {
+	Expr Expr
+}
+
+func (Not) Scoring() bool {
+	return false
+}
+
+func (n Not) String() string {
+	return "-" + n.Expr.String()
+}
+
+// Or 任意一个满足即可
+type Or struct {
+	Exprs []Expr
+}
+
+// Scoring 只要有一个分支是必须满足的条件，那么整个 Or 都是必须满足的条件
+func (o Or) Scoring() bool {
+	for _, e := range o.Exprs {
+		if !e.Scoring() {
+			return false
+		}
+	}
+	return true
+}
+
+func (o Or) String() string {
+	return joinExprs(o.Exprs, " OR ")
+}
+
+// And 并列的多个表达式
+// 其中用于排序的关键字至少命中一个，必须满足的条件全部都要满足
+type And struct {
+	Exprs []Expr
+}
+
+func (a And) Scoring() bool {
+	for _, e := range a.Exprs {
+		if !e.Scoring() {
+			return false
+		}
+	}
+	return true
+}
+
+func (a And) String() string {
+	return joinExprs(a.Exprs, " ")
+}
+
+func joinExprs(exprs []Expr, sep string) string {
+	strs := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		strs = append(strs, e.String())
+	}
+	return strings.Join(strs, sep)
 }
@@ -5,6 +5,16 @@ import (
 	"time"
 )
 
+const (
+	BizQuestion    = "question"
+	BizCase        = "case"
+	BizSkill       = "skill"
+	BizQuestionSet = "questionSet"
+	BizProject     = "project"
+	BizReview      = "review"
+	BizRoadmap     = "roadmap"
+)
+
 type Case struct {
 	Id int64
 	// 作者
@@ -43,8 +53,11 @@ const (
 )
 
 type Question struct {
-	ID      int64
-	UID     int64
+	ID  int64
+	UID int64
+	// Biz 和 BizId 是题目所属的业务，例如项目里面的题目
+	Biz     string
+	BizId   int64
 	Title   string
 	Labels  []string
 	Content string
@@ -108,12 +121,62 @@ type QuestionSet struct {
 	Utime     time.Time
 }
 
+// Project 项目，难点、简历、问题和介绍只用来搜索，结果里面只有命中的片段
+type Project struct {
+	Id     int64
+	Title  string
+	Desc   string
+	Labels []string
+	Status uint8
+	Utime  time.Time
+}
+
+// Review 面经
+type Review struct {
+	Id               int64
+	Uid              int64
+	Title            string
+	Desc             string
+	Labels           []string
+	JD               string
+	JDAnalysis       string
+	Questions        string
+	QuestionAnalysis string
+	Resume           string
+	Status           uint8
+	Utime            time.Time
+}
+
+// Roadmap 学习路线，Biz 和 BizId 是它所属的题集之类的
+type Roadmap struct {
+	Id       int64
+	Title    string
+	Biz      string
+	BizId    int64
+	BizTitle string
+	Utime    time.Time
+}
+
 type SearchResult struct {
 	mu          sync.RWMutex
 	Cases       []Case
 	Questions   []Question
 	Skills      []Skill
 	QuestionSet []QuestionSet
+	Projects    []Project
+	Reviews     []Review
+	Roadmaps    []Roadmap
+
+	// Hits 所有业务统一排序之后的结果，上面各个业务的结果顺序和这里一致
+	Hits []Hit
+	// Total 所有业务命中的总数
+	Total int64
+	// Counts 每个业务命中的数量
+	Counts map[string]int64
+	// Cursor 下一页的游标，为空代表没有下一页了
+	Cursor string
+	// Query 解析之后的搜索表达式
+	Query Query
 }
 
 func (s *SearchResult) SetCases(cases []Case) {
@@ -139,3 +202,56 @@ func (s *SearchResult) SetQuestionSet(qs []QuestionSet) {
 	defer s.mu.Unlock()
 	s.QuestionSet = qs
 }
+
+func (s *SearchResult) SetProjects(prjs []Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Projects = prjs
+}
+
+func (s *SearchResult) SetReviews(res []Review) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Reviews = res
+}
+
+func (s *SearchResult) SetRoadmaps(rs []Roadmap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Roadmaps = rs
+}
+
+// Hits 某个业务的一页搜索结果，Scores 和 Docs 一一对应
+type Hits[T any] struct {
+	Docs   []T
+	Scores []float64
+	// Highlights 命中的字段和高亮片段，key 是字段名
+	Highlights []map[string][]string
+	// Total 命中的总数，不受分页影响
+	Total int64
+	// MaxScore 所有命中的结果里面最高的分数，不受分页影响
+	MaxScore float64
+}
+
+// Hit 统一排序之后的一条结果
+type Hit struct {
+	Biz string
+	Id  int64
+	// Score 归一化之后的分数，不同业务之间可以比较
+	Score float64
+	// Highlights 命中的字段和高亮片段，key 是字段名，例如 answer.basic.keywords
+	Highlights map[string][]string
+	// Requirement 打开这条内容需要满足的条件
+	Requirement Requirement
+	// Locked 当前用户还不能打开这条内容
+	Locked bool
+}
+
+// Suggestion 输入过程中的搜索建议
+type Suggestion struct {
+	Biz   string
+	Id    int64
+	Title string
+	// Label 如果是因为标签命中的，这里是命中的标签
+	Label string
+}
@@ -1,6 +1,10 @@
 package errs
 
 var (
+	InvalidQuery  = ErrorCode{Code: 410001, Msg: "搜索表达式不合法"}
+	InvalidCursor = ErrorCode{Code: 410002, Msg: "搜索游标已经失效，请重新搜索"}
+	UnknownBiz    = ErrorCode{Code: 410003, Msg: "没有这个业务的数据来源"}
+
 	SystemError = ErrorCode{Code: 510001, Msg: "系统错误"}
 )
 
@@ -0,0 +1,123 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/mqx"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+const QueryLogTopic = "search_query_logs"
+
+type QueryLogEvent struct {
+	Sid   string `json:"sid"`
+	Uid   int64  `json:"uid"`
+	Query string `json:"query"`
+	Biz   string `json:"biz"`
+	Hits  int64  `json:"hits"`
+	// Latency 毫秒
+	Latency int64 `json:"latency"`
+	Ctime   int64 `json:"ctime"`
+}
+
+func NewQueryLogEvent(log domain.QueryLog) QueryLogEvent {
+	return QueryLogEvent{
+		Sid:     log.Sid,
+		Uid:     log.Uid,
+		Query:   log.Query,
+		Biz:     log.Biz,
+		Hits:    log.Hits,
+		Latency: log.Latency.Milliseconds(),
+		Ctime:   log.Ctime.UnixMilli(),
+	}
+}
+
+func (e QueryLogEvent) toDomain() domain.QueryLog {
+	return domain.QueryLog{
+		Sid:     e.Sid,
+		Uid:     e.Uid,
+		Query:   e.Query,
+		Biz:     e.Biz,
+		Hits:    e.Hits,
+		Latency: time.Duration(e.Latency) * time.Millisecond,
+		Ctime:   time.UnixMilli(e.Ctime),
+	}
+}
+
+type QueryLogProducer mqx.Producer[QueryLogEvent]
+
+func NewQueryLogProducer(q mq.MQ) (QueryLogProducer, error) {
+	return mqx.NewGeneralProducer[QueryLogEvent](q, QueryLogTopic)
+}
+
+// QueryLogConsumer 把搜索记录保存下来，不影响搜索本身的响应时间
+type QueryLogConsumer struct {
+	svc      service.AnalyticsService
+	consumer mq.Consumer
+	logger   *elog.Component
+}
+
+func NewQueryLogConsumer(svc service.AnalyticsService, q mq.MQ) (*QueryLogConsumer, error) {
+	groupID := "search_analytics"
+	consumer, err := q.Consumer(QueryLogTopic, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryLogConsumer{
+		svc:      svc,
+		consumer: consumer,
+		logger:   elog.DefaultLogger,
+	}, nil
+}
+
+func (c *QueryLogConsumer) Consume(ctx context.Context) error {
+	msg, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return fmt.Errorf("获取消息失败: %w", err)
+	}
+	var evt QueryLogEvent
+	err = json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		return fmt.Errorf("解析消息失败: %w", err)
+	}
+	err = c.svc.Record(ctx, evt.toDomain())
+	if err != nil {
+		c.logger.Error("保存搜索记录失败", elog.Any("QueryLogEvent", evt))
+	}
+	return err
+}
+
+func (c *QueryLogConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			err := c.Consume(ctx)
+			if err != nil {
+				c.logger.Error("消费搜索记录失败", elog.FieldErr(err))
+			}
+		}
+	}()
+}
+
+func (c *QueryLogConsumer) Stop(_ context.Context) error {
+	return c.consumer.Close()
+}
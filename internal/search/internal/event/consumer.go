@@ -19,9 +19,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
 	"github.com/ecodeclub/webook/internal/search/internal/service"
 	"github.com/gotomicro/ego/core/elog"
 )
@@ -56,9 +56,13 @@ func (s *SyncConsumer) Consume(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("解析消息失败: %w", err)
 	}
-	indexName := getIndexName(evt.Biz)
+	indexName := domain.IndexName(evt.Biz)
 	docId := strconv.Itoa(evt.BizID)
-	err = s.svc.Input(ctx, indexName, docId, evt.Data)
+	if evt.Data == "" {
+		err = s.svc.Delete(ctx, indexName, docId)
+	} else {
+		err = s.svc.Input(ctx, indexName, docId, evt.Data)
+	}
 	if err != nil {
 		s.logger.Error("同步消息失败", elog.Any("SyncEvent", evt))
 	}
@@ -78,7 +82,3 @@ func (s *SyncConsumer) Start(ctx context.Context) {
 func (s *SyncConsumer) Stop(_ context.Context) error {
 	return s.consumer.Close()
 }
-
-func getIndexName(biz string) string {
-	return fmt.Sprintf("%s_index", strings.ToLower(biz))
-}
@@ -21,6 +21,6 @@ const (
 type SyncEvent struct {
 	Biz   string `json:"biz"`
 	BizID int    `json:"bizID"`
-	// 具体内容
+	// 具体内容，为空代表数据已经被删除了
 	Data string `json:"data"`
 }
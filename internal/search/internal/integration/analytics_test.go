@@ -0,0 +1,163 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/search/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/search/internal/web"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/ego-component/egorm"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnalyticsTestSuite struct {
+	suite.Suite
+	db     *egorm.Component
+	server *egin.Component
+}
+
+func (s *AnalyticsTestSuite) SetupSuite() {
+	module, err := startup.InitModule(&cases.Module{}, &member.Module{}, &permission.Module{}, nil)
+	require.NoError(s.T(), err)
+	s.db = testioc.InitDB()
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: 123,
+		}))
+	})
+	module.AdminHdl.PrivateRoutes(server.Engine)
+	s.server = server
+
+	now := time.Now().UnixMilli()
+	logs := []dao.SearchQueryLog{
+		// redis 搜了三次，两次点击了结果
+		{Sid: "s1", Query: "redis", Hits: 10, Ctime: now},
+		{Sid: "s2", Query: "redis", Hits: 10, Ctime: now},
+		{Sid: "s3", Query: "redis", Hits: 10, Ctime: now},
+		// kafka 搜了两次，都没有结果
+		{Sid: "s4", Query: "kafka", Hits: 0, Ctime: now},
+		{Sid: "s5", Query: "kafka", Hits: 0, Ctime: now},
+		// mysql 搜了两次，一次点击
+		{Sid: "s6", Query: "mysql", Hits: 3, Ctime: now},
+		{Sid: "s7", Query: "mysql", Hits: 3, Ctime: now},
+		// 太早的搜索不参与统计
+		{Sid: "s8", Query: "etcd", Hits: 1, Ctime: now - 30*24*time.Hour.Milliseconds()},
+	}
+	err = s.db.Create(&logs).Error
+	require.NoError(s.T(), err)
+	clicks := []dao.SearchClick{
+		{Sid: "s1", Biz: "question", BizId: 1, Ctime: now},
+		// 同一次搜索点击了两个结果只算一次
+		{Sid: "s1", Biz: "question", BizId: 2, Position: 1, Ctime: now},
+		{Sid: "s2", Biz: "case", BizId: 1, Ctime: now},
+		{Sid: "s6", Biz: "case", BizId: 2, Ctime: now},
+	}
+	err = s.db.Create(&clicks).Error
+	require.NoError(s.T(), err)
+}
+
+func (s *AnalyticsTestSuite) TearDownSuite() {
+	err := s.db.Exec("TRUNCATE TABLE `search_query_logs`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `search_clicks`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *AnalyticsTestSuite) TestStats() {
+	testCases := []struct {
+		name string
+		path string
+		req  web.AnalyticsReq
+		want []web.QueryStat
+	}{
+		{
+			name: "搜索次数最多",
+			path: "/search/analytics/top",
+			want: []web.QueryStat{
+				{Query: "redis", Searches: 3, Clicks: 2, CTR: 2.0 / 3},
+				{Query: "kafka", Searches: 2, ZeroResults: 2},
+				{Query: "mysql", Searches: 2, Clicks: 1, CTR: 0.5},
+			},
+		},
+		{
+			name: "没有结果",
+			path: "/search/analytics/zero",
+			want: []web.QueryStat{
+				{Query: "kafka", Searches: 2, ZeroResults: 2},
+			},
+		},
+		{
+			name: "点击率最低",
+			path: "/search/analytics/ctr",
+			req:  web.AnalyticsReq{MinSearches: 2},
+			want: []web.QueryStat{
+				{Query: "kafka", Searches: 2, ZeroResults: 2},
+				{Query: "mysql", Searches: 2, Clicks: 1, CTR: 0.5},
+				{Query: "redis", Searches: 3, Clicks: 2, CTR: 2.0 / 3},
+			},
+		},
+		{
+			name: "搜索次数太少",
+			path: "/search/analytics/ctr",
+			req:  web.AnalyticsReq{MinSearches: 3},
+			want: []web.QueryStat{
+				{Query: "redis", Searches: 3, Clicks: 2, CTR: 2.0 / 3},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tc.path, iox.NewJSONReader(tc.req))
+			require.NoError(t, err)
+			req.Header.Set("content-type", "application/json")
+			recorder := test.NewJSONResponseRecorder[[]web.QueryStat]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			got := recorder.MustScan().Data
+			require.Equal(t, len(tc.want), len(got))
+			for i := range tc.want {
+				assert.Equal(t, tc.want[i].Query, got[i].Query)
+				assert.Equal(t, tc.want[i].Searches, got[i].Searches)
+				assert.Equal(t, tc.want[i].ZeroResults, got[i].ZeroResults)
+				assert.Equal(t, tc.want[i].Clicks, got[i].Clicks)
+				assert.InDelta(t, tc.want[i].CTR, got[i].CTR, 0.001)
+			}
+		})
+	}
+}
+
+func TestAnalytics(t *testing.T) {
+	suite.Run(t, new(AnalyticsTestSuite))
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/cases"
 	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ecodeclub/mq-api"
@@ -74,9 +77,12 @@ func (s *HandlerTestSuite) SetupSuite() {
 		}
 		return resMap, nil
 	}).AnyTimes()
+	permSvc := permissionmocks.NewMockService(ctrl)
+	permSvc.EXPECT().FindPersonalPermissions(gomock.Any(), gomock.Any()).
+		Return(map[string][]permission.Permission{}, nil).AnyTimes()
 	handler, err := startup.InitHandler(&cases.Module{
 		ExamineSvc: examSvc,
-	})
+	}, &member.Module{}, &permission.Module{Svc: permSvc}, nil)
 	require.NoError(s.T(), err)
 	econf.Set("server", map[string]any{"contextTimeout": "1s"})
 	server := egin.Load("server").Build()
@@ -716,7 +722,7 @@ func (s *HandlerTestSuite) TestBizSearch() {
 			recorder := test.NewJSONResponseRecorder[web.SearchResult]()
 			s.server.ServeHTTP(recorder, req)
 			require.Equal(t, 200, recorder.Code)
-			tc.after(t, tc.wantAns, recorder.MustScan().Data)
+			tc.after(t, tc.wantAns, resetPage(recorder.MustScan().Data))
 		})
 	}
 
@@ -783,6 +789,24 @@ func (s *HandlerTestSuite) TestSearch() {
 		},
 	}
 	ans := recorder.MustScan().Data
+	// 每个业务各命中一条，统一排序
+	assert.Equal(t, int64(4), ans.Total)
+	assert.Equal(t, map[string]int64{
+		"case":        1,
+		"question":    1,
+		"skill":       1,
+		"questionSet": 1,
+		"project":     0,
+		"review":      0,
+		"roadmap":     0,
+	}, ans.Counts)
+	assert.Len(t, ans.Hits, 4)
+	for _, hit := range ans.Hits {
+		// 标题都是 test_title，都会命中
+		assert.NotEmpty(t, hit.Highlights)
+	}
+	assert.Equal(t, "", ans.Cursor)
+	ans = resetPage(ans)
 	for idx := range ans.Cases {
 		ans.Cases[idx].Utime = ""
 		ans.Cases[idx].Ctime = ""
@@ -803,6 +827,75 @@ func (s *HandlerTestSuite) TestSearch() {
 	assert.Equal(t, want, ans)
 }
 
+func (s *HandlerTestSuite) TestSuggest() {
+	t := s.T()
+	s.initSearchData()
+	time.Sleep(1 * time.Second)
+	req, err := http.NewRequest(http.MethodPost,
+		"/search/suggest", iox.NewJSONReader(web.SuggestReq{
+			Prefix: "test_t",
+		}))
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[[]web.Suggestion]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	assert.ElementsMatch(t, []web.Suggestion{
+		{Biz: "case", Id: 2, Title: "test_title"},
+		{Biz: "question", Id: 2, Title: "test_title"},
+		{Biz: "skill", Id: 1, Title: "test_title"},
+		{Biz: "questionSet", Id: 2, Title: "test_title"},
+	}, recorder.MustScan().Data)
+}
+
+func (s *HandlerTestSuite) TestSearchCursor() {
+	t := s.T()
+	s.initSearchData()
+	time.Sleep(1 * time.Second)
+	search := func(req web.SearchReq) web.SearchResult {
+		httpReq, err := http.NewRequest(http.MethodPost,
+			"/search/list", iox.NewJSONReader(req))
+		require.NoError(t, err)
+		httpReq.Header.Set("content-type", "application/json")
+		recorder := test.NewJSONResponseRecorder[web.SearchResult]()
+		s.server.ServeHTTP(recorder, httpReq)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan().Data
+	}
+	// 一次性取全部，作为对照
+	all := search(web.SearchReq{Keywords: "test_title", Limit: 20})
+	require.Equal(t, int64(4), all.Total)
+
+	var (
+		hits   []web.Hit
+		cursor string
+	)
+	for i := 0; i < 4; i++ {
+		page := search(web.SearchReq{Keywords: "test_title", Limit: 1, Cursor: cursor})
+		assert.Equal(t, all.Total, page.Total)
+		assert.Equal(t, all.Counts, page.Counts)
+		hits = append(hits, page.Hits...)
+		cursor = page.Cursor
+	}
+	assert.Equal(t, all.Hits, hits)
+	assert.Equal(t, "", cursor)
+
+	// 游标不能用在别的搜索上
+	page := search(web.SearchReq{Keywords: "test_title", Limit: 1})
+	httpReq, err := http.NewRequest(http.MethodPost,
+		"/search/list", iox.NewJSONReader(web.SearchReq{
+			Keywords: "biz:case test_title",
+			Limit:    1,
+			Cursor:   page.Cursor,
+		}))
+	require.NoError(t, err)
+	httpReq.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[web.SearchResult]()
+	s.server.ServeHTTP(recorder, httpReq)
+	require.Equal(t, 200, recorder.Code)
+	assert.Equal(t, 410002, recorder.MustScan().Code)
+}
+
 func (s *HandlerTestSuite) TestSearchWithCol() {
 	testCases := []struct {
 		name    string
@@ -1025,7 +1118,7 @@ func (s *HandlerTestSuite) TestSearchWithCol() {
 			recorder := test.NewJSONResponseRecorder[web.SearchResult]()
 			s.server.ServeHTTP(recorder, req)
 			require.Equal(t, 200, recorder.Code)
-			tc.after(t, tc.wantAns, recorder.MustScan().Data)
+			tc.after(t, tc.wantAns, resetPage(recorder.MustScan().Data))
 		})
 	}
 
@@ -1401,7 +1494,7 @@ func (s *HandlerTestSuite) TestSearchLimit() {
 			recorder := test.NewJSONResponseRecorder[web.SearchResult]()
 			s.server.ServeHTTP(recorder, req)
 			require.Equal(t, 200, recorder.Code)
-			tc.after(t, tc.wantAns, recorder.MustScan().Data)
+			tc.after(t, tc.wantAns, resetPage(recorder.MustScan().Data))
 		})
 	}
 }
@@ -2197,3 +2290,12 @@ func handlerSkillLevel(t *testing.T, sk web.SkillLevel) web.SkillLevel {
 func TestHandler(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
+
+// resetPage 清空统一排序和分页的信息，只比较各个业务的结果
+func resetPage(res web.SearchResult) web.SearchResult {
+	res.Hits = nil
+	res.Total = 0
+	res.Counts = nil
+	res.Cursor = ""
+	return res
+}
@@ -0,0 +1,737 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/ecache/memory/lru"
+	"github.com/ecodeclub/ekit/iox"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/cases"
+	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/member"
+	membermocks "github.com/ecodeclub/webook/internal/member/mocks"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/search"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/event"
+	"github.com/ecodeclub/webook/internal/search/internal/integration/startup"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/search/internal/service"
+	"github.com/ecodeclub/webook/internal/search/internal/web"
+	"github.com/ecodeclub/webook/internal/test"
+	testioc "github.com/ecodeclub/webook/internal/test/ioc"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/server/egin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// LocalEngineTestSuite 使用进程内的搜索引擎，不依赖 ES，同步和搜索走的是完整的链路
+type LocalEngineTestSuite struct {
+	suite.Suite
+	server     *egin.Component
+	producer   mq.Producer
+	reindexSvc search.ReindexService
+	source     *fakeSource
+	analytics  *fakeAnalyticsDAO
+	export     exportx.Source
+	engine     search.Engine
+	// cache 代替 redis，重建索引的记录保存在这里
+	cache ecache.Cache
+}
+
+func (s *LocalEngineTestSuite) SetupSuite() {
+	ctrl := gomock.NewController(s.T())
+	examSvc := casemocks.NewMockExamineService(ctrl)
+	examSvc.EXPECT().GetResults(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[int64]cases.ExamineResult{}, nil).AnyTimes()
+	s.source = &fakeSource{biz: "questionSet", docs: map[int64]any{}}
+	s.analytics = &fakeAnalyticsDAO{}
+	// 不是会员，只买了项目 11
+	memberSvc := membermocks.NewMockService(ctrl)
+	memberSvc.EXPECT().GetMembershipInfo(gomock.Any(), gomock.Any()).
+		Return(member.Member{}, nil).AnyTimes()
+	permSvc := permissionmocks.NewMockService(ctrl)
+	permSvc.EXPECT().FindPersonalPermissions(gomock.Any(), gomock.Any()).
+		Return(map[string][]permission.Permission{
+			"project": {{Uid: 123, Biz: "project", BizID: 11}},
+		}, nil).AnyTimes()
+	s.engine = search.NewLocalEngine()
+	s.cache = lru.NewCache(1000)
+	module, err := startup.InitLocalModule(s.engine, &cases.Module{
+		ExamineSvc: examSvc,
+	}, &member.Module{Svc: memberSvc}, &permission.Module{Svc: permSvc},
+		[]searchx.Source{s.source}, s.analytics, s.cache)
+	require.NoError(s.T(), err)
+	s.reindexSvc = module.ReindexSvc
+	s.export = module.ExportSource
+	econf.Set("server", map[string]any{"contextTimeout": "1s"})
+	server := egin.Load("server").Build()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("_session", session.NewMemorySession(session.Claims{
+			Uid: 123,
+		}))
+	})
+	module.Hdl.PrivateRoutes(server.Engine)
+	s.server = server
+	s.producer, err = testioc.InitMQ().Producer(event.SyncTopic)
+	require.NoError(s.T(), err)
+
+	s.sync(event.SyncEvent{Biz: "case", BizID: 1}, dao.Case{
+		Id:      1,
+		Labels:  []string{"redis"},
+		Title:   "Redis 分布式锁",
+		Content: "使用 SETNX 实现分布式锁",
+		Status:  2,
+		Utime:   time.Now().UnixMilli(),
+	})
+	s.sync(event.SyncEvent{Biz: "case", BizID: 2}, dao.Case{
+		Id:      2,
+		Labels:  []string{"redis"},
+		Title:   "Redis 未发布的案例",
+		Content: "未发布的案例不会被搜索到",
+		Status:  1,
+	})
+	s.sync(event.SyncEvent{Biz: "question", BizID: 1}, dao.Question{
+		ID:      1,
+		Title:   "etcd 怎么实现分布式锁",
+		Labels:  []string{"etcd"},
+		Content: "和 Redis 相比有什么优势",
+		Status:  2,
+		Answer: dao.Answer{
+			Advanced: dao.AnswerElement{
+				Highlight: "租约和 revision",
+			},
+		},
+	})
+	s.sync(event.SyncEvent{Biz: "skill", BizID: 1}, dao.Skill{
+		ID:     1,
+		Labels: []string{"redis"},
+		Name:   "Redis",
+		Desc:   "缓存",
+	})
+	// 消费是异步的，等到最后一条数据可以搜索到
+	require.Eventually(s.T(), func() bool {
+		return len(s.search("redis").Skills) > 0
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func (s *LocalEngineTestSuite) sync(evt event.SyncEvent, data any) {
+	val, err := json.Marshal(data)
+	require.NoError(s.T(), err)
+	evt.Data = string(val)
+	val, err = json.Marshal(evt)
+	require.NoError(s.T(), err)
+	_, err = s.producer.Produce(context.Background(), &mq.Message{Value: val})
+	require.NoError(s.T(), err)
+}
+
+// search 带上 sid 不会产生搜索记录，避免轮询的时候产生大量的消息
+func (s *LocalEngineTestSuite) search(keywords string) web.SearchResult {
+	return s.searchPage(web.SearchReq{
+		Keywords: keywords,
+		Limit:    10,
+		Sid:      "test",
+	})
+}
+
+func (s *LocalEngineTestSuite) searchPage(searchReq web.SearchReq) web.SearchResult {
+	req, err := http.NewRequest(http.MethodPost,
+		"/search/list", iox.NewJSONReader(searchReq))
+	require.NoError(s.T(), err)
+	req.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[web.SearchResult]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(s.T(), 200, recorder.Code)
+	return recorder.MustScan().Data
+}
+
+func (s *LocalEngineTestSuite) TestSearch() {
+	testCases := []struct {
+		name     string
+		keywords string
+		wantHits []web.Hit
+	}{
+		{
+			name:     "中文关键字",
+			keywords: "分布式锁",
+			wantHits: []web.Hit{
+				{Biz: "case", Id: 1},
+				{Biz: "question", Id: 1},
+			},
+		},
+		{
+			name:     "短语和排除",
+			keywords: `"分布式锁" -label:etcd`,
+			wantHits: []web.Hit{
+				{Biz: "case", Id: 1},
+			},
+		},
+		{
+			name:     "指定业务和级别",
+			keywords: "biz:question level:advanced revision",
+			wantHits: []web.Hit{
+				{Biz: "question", Id: 1},
+			},
+		},
+		{
+			name:     "没有命中",
+			keywords: "kafka",
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			res := s.search(tc.keywords)
+			hits := make([]web.Hit, 0, len(res.Hits))
+			for _, hit := range res.Hits {
+				assert.NotEmpty(t, hit.Highlights)
+				hits = append(hits, web.Hit{Biz: hit.Biz, Id: hit.Id})
+			}
+			if tc.wantHits == nil {
+				tc.wantHits = []web.Hit{}
+			}
+			assert.Equal(t, tc.wantHits, hits)
+			assert.Equal(t, int64(len(tc.wantHits)), res.Total)
+		})
+	}
+}
+
+func (s *LocalEngineTestSuite) TestBadPaging() {
+	testCases := []struct {
+		name     string
+		offset   int
+		limit    int
+		wantHits int
+	}{
+		{
+			name:     "负数的 limit 使用默认值",
+			limit:    -1,
+			wantHits: 2,
+		},
+		{
+			name:     "负数的 offset 当作 0",
+			offset:   -5,
+			limit:    1,
+			wantHits: 1,
+		},
+		{
+			name:     "limit 过大",
+			limit:    1 << 40,
+			wantHits: 2,
+		},
+		{
+			name:     "offset 超过总数",
+			offset:   1 << 20,
+			limit:    10,
+			wantHits: 0,
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			res := s.searchPage(web.SearchReq{
+				Keywords: "分布式锁",
+				Offset:   tc.offset,
+				Limit:    tc.limit,
+				Sid:      "test",
+			})
+			assert.Len(t, res.Hits, tc.wantHits)
+			assert.Equal(t, int64(2), res.Total)
+		})
+	}
+}
+
+func (s *LocalEngineTestSuite) TestProjectReviewRoadmap() {
+	t := s.T()
+	s.sync(event.SyncEvent{Biz: "project", BizID: 1}, dao.Project{
+		Id:     1,
+		Title:  "电商项目",
+		Status: 2,
+		Difficulties: []dao.ProjectDifficulty{
+			{Id: 1, Title: "秒杀库存扣减", Status: 2},
+		},
+	})
+	s.sync(event.SyncEvent{Biz: "review", BizID: 1}, dao.Review{
+		Id:        1,
+		Title:     "电商公司面经",
+		Questions: "怎么设计秒杀",
+		Status:    2,
+	})
+	s.sync(event.SyncEvent{Biz: "review", BizID: 2}, dao.Review{
+		Id:     2,
+		Title:  "未发布的秒杀面经",
+		Status: 1,
+	})
+	s.sync(event.SyncEvent{Biz: "roadmap", BizID: 1}, dao.Roadmap{
+		Id:       1,
+		Title:    "秒杀学习路线",
+		Biz:      "questionSet",
+		BizId:    1,
+		BizTitle: "高并发",
+	})
+	require.Eventually(t, func() bool {
+		return len(s.search("biz:all 秒杀").Roadmaps) > 0
+	}, 3*time.Second, 10*time.Millisecond)
+
+	res := s.search("biz:all 秒杀")
+	hits := make([]web.Hit, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		hits = append(hits, web.Hit{Biz: hit.Biz, Id: hit.Id})
+	}
+	assert.ElementsMatch(t, []web.Hit{
+		{Biz: "project", Id: 1},
+		{Biz: "review", Id: 1},
+		{Biz: "roadmap", Id: 1},
+	}, hits)
+	assert.Equal(t, []web.Project{{Id: 1, Title: "电商项目", Status: 2, Utime: time.UnixMilli(0).Format(time.DateTime)}}, res.Projects)
+	assert.Equal(t, "高并发", res.Roadmaps[0].BizTitle)
+
+	// Data 为空代表删除
+	val, err := json.Marshal(event.SyncEvent{Biz: "project", BizID: 1})
+	require.NoError(t, err)
+	_, err = s.producer.Produce(context.Background(), &mq.Message{Value: val})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(s.search("biz:project 秒杀").Projects) == 0
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func (s *LocalEngineTestSuite) TestSuggest() {
+	t := s.T()
+	req, err := http.NewRequest(http.MethodPost,
+		"/search/suggest", iox.NewJSONReader(web.SuggestReq{
+			Prefix: "red",
+		}))
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[[]web.Suggestion]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+	// 未发布的案例不会出现在建议里面
+	assert.ElementsMatch(t, []web.Suggestion{
+		{Biz: "case", Id: 1, Title: "Redis 分布式锁"},
+		{Biz: "skill", Id: 1, Title: "Redis"},
+	}, recorder.MustScan().Data)
+}
+
+func (s *LocalEngineTestSuite) TestReindex() {
+	t := s.T()
+	ctx := context.Background()
+	s.sync(event.SyncEvent{Biz: "questionSet", BizID: 1}, dao.QuestionSet{
+		Id:          1,
+		Title:       "旧的题集",
+		Description: "消息同步过来的题集",
+	})
+	require.Eventually(t, func() bool {
+		return len(s.search(`biz:questionSet "旧的题集"`).QuestionSet) > 0
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// 数据来源里面 1 已经被修改了，2 是丢失了同步消息的
+	s.source.docs[1] = dao.QuestionSet{Id: 1, Title: "新的题集", Description: "重建索引"}
+	report, err := s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, report.Mismatched)
+	s.source.docs[2] = dao.QuestionSet{Id: 2, Title: "丢失的题集", Description: "重建索引"}
+	report, err = s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, report.Missing)
+	assert.False(t, report.Consistent())
+
+	err = s.reindexSvc.Reindex(ctx, "questionSet")
+	require.NoError(t, err)
+	report, err = s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	assert.True(t, report.Consistent())
+	assert.Equal(t, int64(2), report.IndexCount)
+	// 别名已经指向新的索引
+	assert.NotEqual(t, "question_set_index", report.Index)
+
+	res := s.search("biz:questionSet 重建索引")
+	ids := make([]int64, 0, len(res.QuestionSet))
+	for _, qs := range res.QuestionSet {
+		ids = append(ids, qs.Id)
+	}
+	assert.ElementsMatch(t, []int64{1, 2}, ids)
+	assert.Empty(t, s.search(`biz:questionSet "旧的题集"`).QuestionSet)
+
+	// 重建之后同步消息写入新的索引
+	s.source.docs[3] = dao.QuestionSet{Id: 3, Title: "新增的题集", Description: "同步"}
+	s.sync(event.SyncEvent{Biz: "questionSet", BizID: 3}, s.source.docs[3])
+	require.Eventually(t, func() bool {
+		report, err = s.reindexSvc.Check(ctx, "questionSet")
+		return err == nil && report.Consistent() && report.IndexCount == 3
+	}, 3*time.Second, 10*time.Millisecond)
+
+	err = s.reindexSvc.Reindex(ctx, "unknown")
+	assert.ErrorIs(t, err, service.ErrUnknownBiz)
+}
+
+func (s *LocalEngineTestSuite) TestAnalytics() {
+	t := s.T()
+	res := s.searchPage(web.SearchReq{Keywords: "label:redis  分布式锁", Limit: 10})
+	require.NotEmpty(t, res.Sid)
+	require.Eventually(t, func() bool {
+		_, ok := s.analytics.queryLog(res.Sid)
+		return ok
+	}, 3*time.Second, 10*time.Millisecond)
+	log, _ := s.analytics.queryLog(res.Sid)
+	assert.Equal(t, int64(123), log.Uid)
+	// 记录的是规范化之后的搜索表达式
+	assert.Equal(t, "label:redis 分布式锁", log.Query)
+	assert.Equal(t, res.Total, log.Hits)
+
+	// 翻页的时候带上 sid，不会重复记录
+	page := s.searchPage(web.SearchReq{Keywords: "kafka", Limit: 10, Sid: "page-sid"})
+	assert.Equal(t, "page-sid", page.Sid)
+
+	s.click(web.ClickReq{Sid: res.Sid, Biz: "case", BizId: 1, Position: 0})
+	click, ok := s.analytics.click(res.Sid)
+	require.True(t, ok)
+	assert.Equal(t, int64(123), click.Uid)
+	assert.Equal(t, "case", click.Biz)
+	assert.Equal(t, int64(1), click.BizId)
+
+	_, ok = s.analytics.queryLog("page-sid")
+	assert.False(t, ok)
+
+	// 和搜索记录对不上的点击直接丢弃
+	s.click(web.ClickReq{Sid: "page-sid", Biz: "case", BizId: 1})
+	_, ok = s.analytics.click("page-sid")
+	assert.False(t, ok)
+	bizRes := s.searchPage(web.SearchReq{Keywords: "biz:case 分布式锁", Limit: 10})
+	require.Eventually(t, func() bool {
+		_, ok := s.analytics.queryLog(bizRes.Sid)
+		return ok
+	}, 3*time.Second, 10*time.Millisecond)
+	s.click(web.ClickReq{Sid: bizRes.Sid, Biz: "question", BizId: 1})
+	s.click(web.ClickReq{Sid: bizRes.Sid, Biz: "case", BizId: 1, Position: int(bizRes.Total)})
+	_, ok = s.analytics.click(bizRes.Sid)
+	assert.False(t, ok)
+}
+
+func (s *LocalEngineTestSuite) TestUserData() {
+	t := s.T()
+	const deletedUid = 456
+	ctx := context.Background()
+	err := s.analytics.InsertQueryLog(ctx, dao.SearchQueryLog{Sid: "deleted-sid", Uid: deletedUid, Query: "redis", Hits: 1, Ctime: 123})
+	require.NoError(t, err)
+	err = s.analytics.InsertClick(ctx, dao.SearchClick{Sid: "deleted-sid", Uid: deletedUid, Biz: "case", BizId: 1, Ctime: 123})
+	require.NoError(t, err)
+
+	data, err := s.export.Export(ctx, deletedUid)
+	require.NoError(t, err)
+	val, err := json.Marshal(data)
+	require.NoError(t, err)
+	var exported struct {
+		QueryLogs []domain.QueryLog
+		Clicks    []domain.Click
+	}
+	require.NoError(t, json.Unmarshal(val, &exported))
+	require.Len(t, exported.QueryLogs, 1)
+	assert.Equal(t, "redis", exported.QueryLogs[0].Query)
+	require.Len(t, exported.Clicks, 1)
+	assert.Equal(t, int64(1), exported.Clicks[0].BizId)
+
+	// 注销账号之后删除搜索记录和点击记录
+	producer, err := testioc.InitMQ().Producer("user_deletion_events")
+	require.NoError(t, err)
+	val, err = json.Marshal(event.UserDeletionEvent{Uid: deletedUid})
+	require.NoError(t, err)
+	_, err = producer.Produce(ctx, &mq.Message{Value: val})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		logs, err1 := s.analytics.FindQueryLogsByUid(ctx, deletedUid)
+		clicks, err2 := s.analytics.FindClicksByUid(ctx, deletedUid)
+		return err1 == nil && err2 == nil && len(logs) == 0 && len(clicks) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+	_, ok := s.analytics.queryLog("deleted-sid")
+	assert.False(t, ok)
+}
+
+func (s *LocalEngineTestSuite) click(click web.ClickReq) {
+	req, err := http.NewRequest(http.MethodPost,
+		"/search/click", iox.NewJSONReader(click))
+	require.NoError(s.T(), err)
+	req.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[any]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(s.T(), 200, recorder.Code)
+}
+
+func (s *LocalEngineTestSuite) TestAccess() {
+	t := s.T()
+	s.sync(event.SyncEvent{Biz: "case", BizID: 11}, dao.Case{
+		Id:     11,
+		Title:  "消息队列消息积压",
+		Status: 2,
+	})
+	s.sync(event.SyncEvent{Biz: "project", BizID: 11}, dao.Project{
+		Id:     11,
+		Title:  "消息队列项目",
+		Status: 2,
+	})
+	s.sync(event.SyncEvent{Biz: "project", BizID: 12}, dao.Project{
+		Id:     12,
+		Title:  "没有买的消息队列项目",
+		Status: 2,
+	})
+	s.sync(event.SyncEvent{Biz: "question", BizID: 11}, dao.Question{
+		ID:     11,
+		Biz:    "project",
+		BizId:  11,
+		Title:  "消息队列怎么保证消息不丢",
+		Status: 2,
+	})
+	s.sync(event.SyncEvent{Biz: "question", BizID: 12}, dao.Question{
+		ID:     12,
+		Biz:    "project",
+		BizId:  12,
+		Title:  "没有买的项目里面的消息队列题目",
+		Status: 2,
+	})
+	require.Eventually(t, func() bool {
+		return len(s.search("消息队列").Hits) == 5
+	}, 3*time.Second, 10*time.Millisecond)
+
+	res := s.search("消息队列")
+	hits := make([]web.Hit, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		hits = append(hits, web.Hit{Biz: hit.Biz, Id: hit.Id, Requires: hit.Requires, Locked: hit.Locked})
+	}
+	assert.ElementsMatch(t, []web.Hit{
+		{Biz: "case", Id: 11, Requires: "member", Locked: true},
+		{Biz: "project", Id: 11, Requires: "permission"},
+		{Biz: "project", Id: 12, Requires: "permission", Locked: true},
+		{Biz: "question", Id: 11, Requires: "permission"},
+		{Biz: "question", Id: 12, Requires: "permission", Locked: true},
+	}, hits)
+
+	// 只看能打开的
+	res = s.searchPage(web.SearchReq{Keywords: "消息队列", Limit: 10, Sid: "test", Accessible: true})
+	hits = make([]web.Hit, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		hits = append(hits, web.Hit{Biz: hit.Biz, Id: hit.Id})
+	}
+	assert.ElementsMatch(t, []web.Hit{
+		{Biz: "project", Id: 11},
+		{Biz: "question", Id: 11},
+	}, hits)
+	assert.Empty(t, res.Cases)
+}
+
+func (s *LocalEngineTestSuite) TestReindexWithConcurrentSync() {
+	t := s.T()
+	ctx := context.Background()
+	alias := domain.IndexName("questionSet")
+	s.source.docs[11] = dao.QuestionSet{Id: 11, Title: "重建之前的题集", Description: "并发同步"}
+	// 另外一个消费同步消息的实例，只和当前实例共用缓存
+	other := service.NewSyncSvc(search.InitAnyRepo(s.engine), service.NewJournal(s.cache))
+	s.source.onDoc = func() {
+		s.source.onDoc = nil
+		// 全量数据读取到一半的时候，其它实例修改了 11，新增了 12
+		s.source.docs[11] = dao.QuestionSet{Id: 11, Title: "重建期间修改的题集", Description: "并发同步"}
+		val, err := json.Marshal(s.source.docs[11])
+		require.NoError(t, err)
+		require.NoError(t, other.Input(ctx, alias, "11", string(val)))
+		val, err = json.Marshal(dao.QuestionSet{Id: 12, Title: "重建期间新增的题集", Description: "并发同步"})
+		require.NoError(t, err)
+		require.NoError(t, other.Input(ctx, alias, "12", string(val)))
+	}
+
+	err := s.reindexSvc.Reindex(ctx, "questionSet")
+	require.NoError(t, err)
+	res := s.search(`biz:questionSet "并发同步"`)
+	titles := make([]string, 0, len(res.QuestionSet))
+	for _, qs := range res.QuestionSet {
+		titles = append(titles, qs.Title)
+	}
+	assert.ElementsMatch(t, []string{"重建期间修改的题集", "重建期间新增的题集"}, titles)
+
+	// 重建结束之后不再记录
+	s.source.docs[12] = dao.QuestionSet{Id: 12, Title: "重建期间新增的题集", Description: "并发同步"}
+	val, err := json.Marshal(s.source.docs[12])
+	require.NoError(t, err)
+	require.NoError(t, other.Input(ctx, alias, "12", string(val)))
+	report, err := s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	assert.True(t, report.Consistent())
+}
+
+func (s *LocalEngineTestSuite) TestReindexJournalExpired() {
+	t := s.T()
+	ctx := context.Background()
+	alias := domain.IndexName("questionSet")
+	before, err := s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	s.source.docs[21] = dao.QuestionSet{Id: 21, Title: "标记过期的题集", Description: "标记过期"}
+	defer delete(s.source.docs, 21)
+	s.source.onDoc = func() {
+		s.source.onDoc = nil
+		// 重建的时间太长，标记过期了，这期间的同步消息会直接写入旧的索引
+		_, err := s.cache.Delete(ctx, "search:reindex:"+alias)
+		require.NoError(t, err)
+	}
+
+	err = s.reindexSvc.Reindex(ctx, "questionSet")
+	assert.ErrorIs(t, err, service.ErrReindexExpired)
+	// 别名没有切换
+	after, err := s.reindexSvc.Check(ctx, "questionSet")
+	require.NoError(t, err)
+	assert.Equal(t, before.Index, after.Index)
+	assert.Empty(t, s.search(`biz:questionSet "标记过期"`).QuestionSet)
+}
+
+func TestLocalEngine(t *testing.T) {
+	suite.Run(t, new(LocalEngineTestSuite))
+}
+
+// fakeSource 代替题目模块作为数据来源
+type fakeSource struct {
+	biz  string
+	docs map[int64]any
+	// onDoc 读取文档的时候调用，用来模拟重建索引期间的修改
+	onDoc func()
+}
+
+func (f *fakeSource) Biz() string {
+	return f.biz
+}
+
+func (f *fakeSource) Ids(ctx context.Context) ([]int64, error) {
+	ids := make([]int64, 0, len(f.docs))
+	for id := range f.docs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (f *fakeSource) Doc(ctx context.Context, id int64) (string, error) {
+	if f.onDoc != nil {
+		f.onDoc()
+	}
+	val, err := json.Marshal(f.docs[id])
+	return string(val), err
+}
+
+// fakeAnalyticsDAO 代替数据库保存搜索记录，统计相关的查询在 e2e 测试里面覆盖
+type fakeAnalyticsDAO struct {
+	mu     sync.Mutex
+	logs   []dao.SearchQueryLog
+	clicks []dao.SearchClick
+}
+
+func (f *fakeAnalyticsDAO) InsertQueryLog(ctx context.Context, log dao.SearchQueryLog) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, log)
+	return nil
+}
+
+func (f *fakeAnalyticsDAO) InsertClick(ctx context.Context, click dao.SearchClick) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clicks = append(f.clicks, click)
+	return nil
+}
+
+func (f *fakeAnalyticsDAO) FindQueryLog(ctx context.Context, sid string) (dao.SearchQueryLog, error) {
+	log, ok := f.queryLog(sid)
+	if !ok {
+		return dao.SearchQueryLog{}, gorm.ErrRecordNotFound
+	}
+	return log, nil
+}
+
+func (f *fakeAnalyticsDAO) queryLog(sid string) (dao.SearchQueryLog, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx := slices.IndexFunc(f.logs, func(log dao.SearchQueryLog) bool {
+		return log.Sid == sid
+	})
+	if idx < 0 {
+		return dao.SearchQueryLog{}, false
+	}
+	return f.logs[idx], true
+}
+
+func (f *fakeAnalyticsDAO) click(sid string) (dao.SearchClick, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx := slices.IndexFunc(f.clicks, func(click dao.SearchClick) bool {
+		return click.Sid == sid
+	})
+	if idx < 0 {
+		return dao.SearchClick{}, false
+	}
+	return f.clicks[idx], true
+}
+
+func (f *fakeAnalyticsDAO) TopQueries(ctx context.Context, start, end int64, limit int) ([]dao.QueryStat, error) {
+	return nil, nil
+}
+
+func (f *fakeAnalyticsDAO) ZeroResultQueries(ctx context.Context, start, end int64, limit int) ([]dao.QueryStat, error) {
+	return nil, nil
+}
+
+func (f *fakeAnalyticsDAO) LowClickThroughQueries(ctx context.Context, start, end int64,
+	minSearches int64, limit int) ([]dao.QueryStat, error) {
+	return nil, nil
+}
+
+func (f *fakeAnalyticsDAO) FindQueryLogsByUid(ctx context.Context, uid int64) ([]dao.SearchQueryLog, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.DeleteFunc(slices.Clone(f.logs), func(log dao.SearchQueryLog) bool {
+		return log.Uid != uid
+	}), nil
+}
+
+func (f *fakeAnalyticsDAO) FindClicksByUid(ctx context.Context, uid int64) ([]dao.SearchClick, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.DeleteFunc(slices.Clone(f.clicks), func(click dao.SearchClick) bool {
+		return click.Uid != uid
+	}), nil
+}
+
+func (f *fakeAnalyticsDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = slices.DeleteFunc(f.logs, func(log dao.SearchQueryLog) bool {
+		return log.Uid == uid
+	})
+	f.clicks = slices.DeleteFunc(f.clicks, func(click dao.SearchClick) bool {
+		return click.Uid == uid
+	})
+	return nil
+}
@@ -17,15 +17,36 @@
 package startup
 
 import (
+	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	baguwen "github.com/ecodeclub/webook/internal/search"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/search/internal/web"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 	"github.com/google/wire"
 )
 
-func InitHandler(caModule *cases.Module) (*web.Handler, error) {
-	wire.Build(testioc.BaseSet, baguwen.InitModule,
+func InitHandler(caModule *cases.Module, memberModule *member.Module,
+	permModule *permission.Module, sources []searchx.Source) (*web.Handler, error) {
+	wire.Build(testioc.BaseSet, baguwen.NewElasticEngine, baguwen.InitModule,
 		wire.FieldsOf(new(*baguwen.Module), "Hdl"))
 	return new(web.Handler), nil
 }
+
+// InitLocalModule 使用进程内的搜索引擎，不需要 ES，也不需要数据库和 redis
+func InitLocalModule(e baguwen.Engine, caModule *cases.Module, memberModule *member.Module,
+	permModule *permission.Module, sources []searchx.Source, analyticsDAO dao.AnalyticsDAO,
+	ec ecache.Cache) (*baguwen.Module, error) {
+	wire.Build(testioc.InitMQ, baguwen.InitModuleWithDAO)
+	return new(baguwen.Module), nil
+}
+
+// InitModule 使用进程内的搜索引擎和真实的数据库
+func InitModule(caModule *cases.Module, memberModule *member.Module,
+	permModule *permission.Module, sources []searchx.Source) (*baguwen.Module, error) {
+	wire.Build(testioc.InitDB, testioc.InitCache, testioc.InitMQ, baguwen.NewLocalEngine, baguwen.InitModule)
+	return new(baguwen.Module), nil
+}
@@ -7,21 +7,53 @@
 package startup
 
 import (
+	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	"github.com/ecodeclub/webook/internal/search"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 	"github.com/ecodeclub/webook/internal/search/internal/web"
 	testioc "github.com/ecodeclub/webook/internal/test/ioc"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(caModule *cases.Module) (*web.Handler, error) {
+func InitHandler(caModule *cases.Module, memberModule *member.Module, permModule *permission.Module, sources []searchx.Source) (*web.Handler, error) {
 	client := testioc.InitES()
+	engine := search.NewElasticEngine(client)
+	db := testioc.InitDB()
+	cache := testioc.InitCache()
 	mq := testioc.InitMQ()
-	module, err := search.InitModule(client, mq, caModule)
+	module, err := search.InitModule(engine, db, cache, mq, caModule, memberModule, permModule, sources)
 	if err != nil {
 		return nil, err
 	}
 	handler := module.Hdl
 	return handler, nil
 }
+
+// InitLocalModule 使用进程内的搜索引擎，不需要 ES，也不需要数据库和 redis
+func InitLocalModule(e engine.Engine, caModule *cases.Module, memberModule *member.Module, permModule *permission.Module, sources []searchx.Source, analyticsDAO dao.AnalyticsDAO, ec ecache.Cache) (*search.Module, error) {
+	mq := testioc.InitMQ()
+	module, err := search.InitModuleWithDAO(e, analyticsDAO, ec, mq, caModule, memberModule, permModule, sources)
+	if err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// InitModule 使用进程内的搜索引擎和真实的数据库
+func InitModule(caModule *cases.Module, memberModule *member.Module, permModule *permission.Module, sources []searchx.Source) (*search.Module, error) {
+	engine := search.NewLocalEngine()
+	db := testioc.InitDB()
+	cache := testioc.InitCache()
+	mq := testioc.InitMQ()
+	module, err := search.InitModule(engine, db, cache, mq, caModule, memberModule, permModule, sources)
+	if err != nil {
+		return nil, err
+	}
+	return module, nil
+}
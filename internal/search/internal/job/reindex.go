@@ -0,0 +1,54 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"github.com/ecodeclub/webook/internal/search/internal/service"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/task/ejob"
+)
+
+// ReindexJob 依次重建所有业务的索引，然后检查一致性，手动运行
+type ReindexJob struct {
+	svc    service.ReindexService
+	logger *elog.Component
+}
+
+func NewReindexJob(svc service.ReindexService) *ReindexJob {
+	return &ReindexJob{
+		svc:    svc,
+		logger: elog.DefaultLogger,
+	}
+}
+
+func (j *ReindexJob) Start(ctx ejob.Context) error {
+	for _, biz := range j.svc.Bizs() {
+		err := j.svc.Reindex(ctx.Ctx, biz)
+		if err != nil {
+			return err
+		}
+		report, err := j.svc.Check(ctx.Ctx, biz)
+		if err != nil {
+			return err
+		}
+		if !report.Consistent() {
+			// 重建期间仍然有数据在变化，这里只是提醒，不算失败
+			j.logger.Warn("重建之后索引和数据来源不一致",
+				elog.String("biz", biz),
+				elog.Any("report", report))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type analyticsRepository struct {
+	dao dao.AnalyticsDAO
+}
+
+func NewAnalyticsRepo(dao dao.AnalyticsDAO) AnalyticsRepo {
+	return &analyticsRepository{
+		dao: dao,
+	}
+}
+
+func (a *analyticsRepository) SaveQueryLog(ctx context.Context, log domain.QueryLog) error {
+	return a.dao.InsertQueryLog(ctx, dao.SearchQueryLog{
+		Sid:     log.Sid,
+		Uid:     log.Uid,
+		Query:   log.Query,
+		Biz:     log.Biz,
+		Hits:    log.Hits,
+		Latency: log.Latency.Milliseconds(),
+		Ctime:   log.Ctime.UnixMilli(),
+	})
+}
+
+func (a *analyticsRepository) SaveClick(ctx context.Context, click domain.Click) error {
+	return a.dao.InsertClick(ctx, dao.SearchClick{
+		Sid:      click.Sid,
+		Uid:      click.Uid,
+		Biz:      click.Biz,
+		BizId:    click.BizId,
+		Position: click.Position,
+		Ctime:    click.Ctime.UnixMilli(),
+	})
+}
+
+func (a *analyticsRepository) FindQueryLog(ctx context.Context, sid string) (domain.QueryLog, error) {
+	log, err := a.dao.FindQueryLog(ctx, sid)
+	if err != nil {
+		return domain.QueryLog{}, err
+	}
+	return a.toQueryLog(log), nil
+}
+
+func (a *analyticsRepository) FindQueryLogsByUid(ctx context.Context, uid int64) ([]domain.QueryLog, error) {
+	logs, err := a.dao.FindQueryLogsByUid(ctx, uid)
+	return slice.Map(logs, func(idx int, src dao.SearchQueryLog) domain.QueryLog {
+		return a.toQueryLog(src)
+	}), err
+}
+
+func (a *analyticsRepository) FindClicksByUid(ctx context.Context, uid int64) ([]domain.Click, error) {
+	clicks, err := a.dao.FindClicksByUid(ctx, uid)
+	return slice.Map(clicks, func(idx int, src dao.SearchClick) domain.Click {
+		return domain.Click{
+			Sid:      src.Sid,
+			Uid:      src.Uid,
+			Biz:      src.Biz,
+			BizId:    src.BizId,
+			Position: src.Position,
+			Ctime:    time.UnixMilli(src.Ctime),
+		}
+	}), err
+}
+
+func (a *analyticsRepository) DeleteByUid(ctx context.Context, uid int64) error {
+	return a.dao.DeleteByUid(ctx, uid)
+}
+
+func (a *analyticsRepository) toQueryLog(log dao.SearchQueryLog) domain.QueryLog {
+	return domain.QueryLog{
+		Sid:     log.Sid,
+		Uid:     log.Uid,
+		Query:   log.Query,
+		Biz:     log.Biz,
+		Hits:    log.Hits,
+		Latency: time.Duration(log.Latency) * time.Millisecond,
+		Ctime:   time.UnixMilli(log.Ctime),
+	}
+}
+
+func (a *analyticsRepository) TopQueries(ctx context.Context, start, end time.Time, limit int) ([]domain.QueryStat, error) {
+	stats, err := a.dao.TopQueries(ctx, start.UnixMilli(), end.UnixMilli(), limit)
+	return a.toDomain(stats), err
+}
+
+func (a *analyticsRepository) ZeroResultQueries(ctx context.Context, start, end time.Time, limit int) ([]domain.QueryStat, error) {
+	stats, err := a.dao.ZeroResultQueries(ctx, start.UnixMilli(), end.UnixMilli(), limit)
+	return a.toDomain(stats), err
+}
+
+func (a *analyticsRepository) LowClickThroughQueries(ctx context.Context, start, end time.Time,
+	minSearches int64, limit int) ([]domain.QueryStat, error) {
+	stats, err := a.dao.LowClickThroughQueries(ctx, start.UnixMilli(), end.UnixMilli(), minSearches, limit)
+	return a.toDomain(stats), err
+}
+
+func (a *analyticsRepository) toDomain(stats []dao.QueryStat) []domain.QueryStat {
+	return slice.Map(stats, func(idx int, src dao.QueryStat) domain.QueryStat {
+		return domain.QueryStat{
+			Query:       src.Query,
+			Searches:    src.Searches,
+			ZeroResults: src.ZeroResults,
+			Clicks:      src.Clicks,
+		}
+	})
+}
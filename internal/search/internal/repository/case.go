@@ -32,16 +32,12 @@ func NewCaseRepo(caseDao dao.CaseDAO) CaseRepo {
 	}
 }
 
-func (c *caseRepository) SearchCase(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Case, error) {
-	cases, err := c.caseDao.SearchCase(ctx, offset, limit, queryMetas)
+func (c *caseRepository) SearchCase(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Case], error) {
+	hits, err := c.caseDao.SearchCase(ctx, offset, limit, query)
 	if err != nil {
-		return nil, err
+		return domain.Hits[domain.Case]{}, err
 	}
-	ans := make([]domain.Case, 0, len(cases))
-	for _, ca := range cases {
-		ans = append(ans, c.toDomain(ca))
-	}
-	return ans, err
+	return toDomainHits(hits, c.toDomain), nil
 }
 
 func (*caseRepository) toDomain(p dao.Case) domain.Case {
@@ -0,0 +1,80 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+// accessFilters 只搜索用户能打开的内容时额外的过滤条件，ok 为 false 代表一条都打不开，不需要再查询
+// 过滤条件之间只能是 AND 的关系，所以题目这里是宽松的过滤，
+// 例如有项目 1 的权限的时候，也会留下项目 2 里面 bizId 恰好为 1 的题目，最终由 service 逐条判断
+type accessFilters func(access domain.Access) (filters []engine.Filter, ok bool)
+
+func caseAccessFilters(access domain.Access) ([]engine.Filter, bool) {
+	return nil, access.Member
+}
+
+func projectAccessFilters(access domain.Access) ([]engine.Filter, bool) {
+	ids := access.Permissions[domain.BizProject]
+	if len(ids) == 0 {
+		return nil, false
+	}
+	return []engine.Filter{{Field: "id", Values: anySlice(ids)}}, true
+}
+
+func questionAccessFilters(access domain.Access) ([]engine.Filter, bool) {
+	bizs := make([]any, 0, len(access.Permissions))
+	var ids []any
+	for biz, bizIds := range access.Permissions {
+		if len(bizIds) == 0 {
+			continue
+		}
+		bizs = append(bizs, biz)
+		ids = append(ids, anySlice(bizIds)...)
+	}
+	switch {
+	case access.Member && len(bizs) == 0:
+		// 老数据没有 biz 字段，都是八股文
+		return []engine.Filter{{Field: "biz", Values: []any{domain.BizBaguwen, ""}, AllowMissing: true}}, true
+	case access.Member:
+		return nil, true
+	case len(bizs) == 0:
+		return nil, false
+	default:
+		return []engine.Filter{{Field: "biz", Values: bizs}, {Field: "bizId", Values: ids}}, true
+	}
+}
+
+// withAccess 在 filters 的基础上加上 query.Access 的过滤条件
+func withAccess(query domain.Query, filters []engine.Filter, fn accessFilters) ([]engine.Filter, bool) {
+	if query.Access == nil {
+		return filters, true
+	}
+	extra, ok := fn(*query.Access)
+	if !ok {
+		return nil, false
+	}
+	return append(filters, extra...), true
+}
+
+func anySlice[T any](src []T) []any {
+	res := make([]any, 0, len(src))
+	for _, ele := range src {
+		res = append(res, ele)
+	}
+	return res
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GORMAnalyticsDAO struct {
+	db *egorm.Component
+}
+
+func NewGORMAnalyticsDAO(db *egorm.Component) AnalyticsDAO {
+	return &GORMAnalyticsDAO{db: db}
+}
+
+func (g *GORMAnalyticsDAO) InsertQueryLog(ctx context.Context, log SearchQueryLog) error {
+	// 消息重复消费的时候忽略
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&log).Error
+}
+
+func (g *GORMAnalyticsDAO) InsertClick(ctx context.Context, click SearchClick) error {
+	return g.db.WithContext(ctx).Create(&click).Error
+}
+
+func (g *GORMAnalyticsDAO) FindQueryLog(ctx context.Context, sid string) (SearchQueryLog, error) {
+	var res SearchQueryLog
+	err := g.db.WithContext(ctx).Where("sid = ?", sid).First(&res).Error
+	return res, err
+}
+
+func (g *GORMAnalyticsDAO) TopQueries(ctx context.Context, start, end int64, limit int) ([]QueryStat, error) {
+	var res []QueryStat
+	err := g.stats(ctx, start, end).
+		Order("searches DESC, query ASC").
+		Limit(limit).Scan(&res).Error
+	return res, err
+}
+
+func (g *GORMAnalyticsDAO) ZeroResultQueries(ctx context.Context, start, end int64, limit int) ([]QueryStat, error) {
+	var res []QueryStat
+	err := g.stats(ctx, start, end).
+		Having("zero_results > 0").
+		Order("zero_results DESC, query ASC").
+		Limit(limit).Scan(&res).Error
+	return res, err
+}
+
+func (g *GORMAnalyticsDAO) LowClickThroughQueries(ctx context.Context, start, end int64,
+	minSearches int64, limit int) ([]QueryStat, error) {
+	var res []QueryStat
+	err := g.stats(ctx, start, end).
+		Having("searches >= ?", minSearches).
+		Order("COUNT(c.sid) / COUNT(*) ASC, searches DESC").
+		Limit(limit).Scan(&res).Error
+	return res, err
+}
+
+// stats 按照搜索表达式汇总 [start, end) 之间的搜索记录
+// 一次搜索点击了多个结果只算一次，所以先对点击记录的 sid 去重
+func (g *GORMAnalyticsDAO) stats(ctx context.Context, start, end int64) *gorm.DB {
+	clicked := g.db.WithContext(ctx).Model(&SearchClick{}).
+		Distinct("sid").Where("ctime >= ?", start)
+	return g.db.WithContext(ctx).Table("search_query_logs AS l").
+		Select("l.query AS query, COUNT(*) AS searches, "+
+			"SUM(CASE WHEN l.hits = 0 THEN 1 ELSE 0 END) AS zero_results, "+
+			"COUNT(c.sid) AS clicks").
+		Joins("LEFT JOIN (?) AS c ON c.sid = l.sid", clicked).
+		Where("l.ctime >= ? AND l.ctime < ?", start, end).
+		Group("l.query")
+}
+
+func (g *GORMAnalyticsDAO) FindQueryLogsByUid(ctx context.Context, uid int64) ([]SearchQueryLog, error) {
+	var res []SearchQueryLog
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *GORMAnalyticsDAO) FindClicksByUid(ctx context.Context, uid int64) ([]SearchClick, error) {
+	var res []SearchClick
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (g *GORMAnalyticsDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("uid = ?", uid).Delete(&SearchClick{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&SearchQueryLog{}).Error
+	})
+}
+
+// SearchQueryLog 搜索记录
+type SearchQueryLog struct {
+	Id  int64  `gorm:"primaryKey,autoIncrement"`
+	Sid string `gorm:"type:varchar(64);uniqueIndex"`
+	Uid int64  `gorm:"index"`
+	// Query 规范化之后的搜索表达式
+	Query string `gorm:"type:varchar(512);index:idx_ctime_query,priority:2"`
+	Biz   string `gorm:"type:varchar(256)"`
+	Hits  int64
+	// Latency 毫秒
+	Latency int64
+	Ctime   int64 `gorm:"index:idx_ctime_query,priority:1"`
+}
+
+// SearchClick 搜索结果的点击记录
+type SearchClick struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Sid      string `gorm:"type:varchar(64);index"`
+	Uid      int64  `gorm:"index"`
+	Biz      string `gorm:"type:varchar(128)"`
+	BizId    int64
+	Position int
+	Ctime    int64
+}
+
+type QueryStat struct {
+	Query       string
+	Searches    int64
+	ZeroResults int64
+	Clicks      int64
+}
@@ -17,23 +17,23 @@ package dao
 import (
 	"context"
 
-	"github.com/olivere/elastic/v7"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 )
 
-type anyESDAO struct {
-	client *elastic.Client
+type anyDAO struct {
+	engine engine.Engine
 }
 
-func NewAnyEsDAO(client *elastic.Client) AnyDAO {
-	return &anyESDAO{
-		client: client,
+func NewAnyDAO(e engine.Engine) AnyDAO {
+	return &anyDAO{
+		engine: e,
 	}
 }
 
-func (a *anyESDAO) Input(ctx context.Context, index string, docID string, data string) error {
-	_, err := a.client.Index().
-		Index(index).
-		Id(docID).
-		BodyJson(data).Do(ctx)
-	return err
+func (a *anyDAO) Input(ctx context.Context, index string, docID string, data string) error {
+	return a.engine.Index(ctx, index, docID, data)
+}
+
+func (a *anyDAO) Delete(ctx context.Context, index string, docID string) error {
+	return a.engine.Delete(ctx, index, docID)
 }
@@ -16,10 +16,9 @@ package dao
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
-	"github.com/olivere/elastic/v7"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 )
 
 const CaseIndexName = "case_index"
@@ -42,8 +41,8 @@ type Case struct {
 	Utime      int64    `json:"utime"`
 }
 type CaseElasticDAO struct {
-	client *elastic.Client
-	metas  map[string]Col
+	engine engine.Engine
+	metas  map[string]engine.Field
 }
 
 const (
@@ -54,33 +53,25 @@ const (
 	caseGuidanceBoost = 1
 )
 
-func (c *CaseElasticDAO) SearchCase(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Case, error) {
-	query := elastic.NewBoolQuery().Must(
-		elastic.NewBoolQuery().Should(buildCols(c.metas, queryMetas)...),
-		elastic.NewTermQuery("status", domain.PublishedStatus))
-	resp, err := c.client.Search(CaseIndexName).
-		From(offset).
-		Size(limit).
-		Query(query).Do(ctx)
-	if err != nil {
-		return nil, err
+func (c *CaseElasticDAO) SearchCase(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Case], error) {
+	filters, ok := withAccess(query, []engine.Filter{{Field: "status", Value: domain.PublishedStatus}}, caseAccessFilters)
+	if !ok {
+		return SearchHits[Case]{}, nil
 	}
-	res := make([]Case, 0, len(resp.Hits.Hits))
-	for _, hit := range resp.Hits.Hits {
-		var ele Case
-		err = json.Unmarshal(hit.Source, &ele)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, ele)
-	}
-	return res, nil
+	return search[Case](ctx, c.engine, engine.SearchReq{
+		Index:   CaseIndexName,
+		Query:   query,
+		Fields:  c.metas,
+		Filters: filters,
+		Offset:  offset,
+		Limit:   limit,
+	})
 }
 
-func NewCaseElasticDAO(client *elastic.Client) *CaseElasticDAO {
+func NewCaseElasticDAO(e engine.Engine) *CaseElasticDAO {
 	return &CaseElasticDAO{
-		client: client,
-		metas: map[string]Col{
+		engine: e,
+		metas: map[string]engine.Field{
 			"title": {
 				Name:  "title",
 				Boost: caseTitleBoost,
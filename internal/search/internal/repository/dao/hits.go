@@ -0,0 +1,61 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+// SearchHits 一页搜索结果，Scores 和 Docs 一一对应
+type SearchHits[T any] struct {
+	Docs   []T
+	Scores []float64
+	// Highlights 命中的字段和高亮片段，key 是字段名
+	Highlights []map[string][]string
+	// Total 命中的总数，不受分页影响
+	Total int64
+	// MaxScore 所有命中的结果里面最高的分数，不受分页影响
+	MaxScore float64
+}
+
+// search 按照分数降序，分数相同的按照 id 升序，保证翻页的时候顺序是稳定的
+// 同时返回 req.Fields 里面命中的字段的高亮片段
+func search[T any](ctx context.Context, e engine.Engine, req engine.SearchReq) (SearchHits[T], error) {
+	resp, err := e.Search(ctx, req)
+	if err != nil {
+		return SearchHits[T]{}, err
+	}
+	res := SearchHits[T]{
+		Docs:       make([]T, 0, len(resp.Hits)),
+		Scores:     make([]float64, 0, len(resp.Hits)),
+		Highlights: make([]map[string][]string, 0, len(resp.Hits)),
+		Total:      resp.Total,
+		MaxScore:   resp.MaxScore,
+	}
+	for _, hit := range resp.Hits {
+		var ele T
+		err = json.Unmarshal(hit.Source, &ele)
+		if err != nil {
+			return SearchHits[T]{}, err
+		}
+		res.Docs = append(res.Docs, ele)
+		res.Scores = append(res.Scores, hit.Score)
+		res.Highlights = append(res.Highlights, hit.Highlights)
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+type indexDAO struct {
+	engine   engine.Engine
+	mappings map[string]string
+}
+
+func NewIndexDAO(e engine.Engine) IndexDAO {
+	return &indexDAO{
+		engine:   e,
+		mappings: Mappings(),
+	}
+}
+
+// CreateVersion 索引的名字是别名加上创建时间，例如 case_index_1718000000000
+func (i *indexDAO) CreateVersion(ctx context.Context, alias string) (string, error) {
+	mapping, ok := i.mappings[alias]
+	if !ok {
+		return "", fmt.Errorf("索引 %s 没有对应的 mapping", alias)
+	}
+	index := fmt.Sprintf("%s_%d", alias, time.Now().UnixMilli())
+	return index, i.engine.CreateIndex(ctx, index, mapping)
+}
+
+func (i *indexDAO) Delete(ctx context.Context, index string) error {
+	return i.engine.DeleteIndex(ctx, index)
+}
+
+func (i *indexDAO) Current(ctx context.Context, alias string) (string, error) {
+	return i.engine.ResolveAlias(ctx, alias)
+}
+
+func (i *indexDAO) SwapAlias(ctx context.Context, alias, index string) (string, error) {
+	return i.engine.SwapAlias(ctx, alias, index)
+}
+
+func (i *indexDAO) Scan(ctx context.Context, index string, fn func(docID string, data json.RawMessage) error) error {
+	return i.engine.Scan(ctx, index, func(hit engine.Hit) error {
+		return fn(hit.Id, hit.Source)
+	})
+}
@@ -15,12 +15,9 @@
 package dao
 
 import (
-	"context"
 	_ "embed"
-	"time"
 
-	"github.com/olivere/elastic/v7"
-	"golang.org/x/sync/errgroup"
+	"github.com/ego-component/egorm"
 )
 
 var (
@@ -32,41 +29,29 @@ var (
 	skillIndex string
 	//go:embed questionset_index.json
 	questionSetIndex string
+	//go:embed project_index.json
+	projectIndex string
+	//go:embed review_index.json
+	reviewIndex string
+	//go:embed roadmap_index.json
+	roadmapIndex string
 )
 
-// InitES 创建索引
-func InitES(client *elastic.Client) error {
-	const timeout = time.Second * 10
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	var eg errgroup.Group
-	eg.Go(func() error {
-		return tryCreateIndex(ctx, client, CaseIndexName, caseIndex)
-	})
-	eg.Go(func() error {
-		return tryCreateIndex(ctx, client, QuestionIndexName, questionIndex)
-	})
-	eg.Go(func() error {
-		return tryCreateIndex(ctx, client, SkillIndexName, skillIndex)
-	})
-	eg.Go(func() error {
-		return tryCreateIndex(ctx, client, QuestionSetIndexName, questionSetIndex)
-	})
-	return eg.Wait()
+// Mappings 所有索引的 mapping，key 是索引名字
+// 只有 elastic 的实现需要用到
+func Mappings() map[string]string {
+	return map[string]string{
+		CaseIndexName:        caseIndex,
+		QuestionIndexName:    questionIndex,
+		SkillIndexName:       skillIndex,
+		QuestionSetIndexName: questionSetIndex,
+		ProjectIndexName:     projectIndex,
+		ReviewIndexName:      reviewIndex,
+		RoadmapIndexName:     roadmapIndex,
+	}
 }
 
-func tryCreateIndex(ctx context.Context,
-	client *elastic.Client,
-	idxName, idxCfg string,
-) error {
-	// 索引可能已经建好了
-	ok, err := client.IndexExists(idxName).Do(ctx)
-	if err != nil {
-		return err
-	}
-	if ok {
-		return nil
-	}
-	_, err = client.CreateIndex(idxName).Body(idxCfg).Do(ctx)
-	return err
+// InitTables 搜索分析用到的表，搜索本身的数据都在搜索引擎里面
+func InitTables(db *egorm.Component) error {
+	return db.AutoMigrate(&SearchQueryLog{}, &SearchClick{})
 }
@@ -0,0 +1,156 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dao
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+const (
+	ProjectIndexName         = "project_index"
+	projectTitleBoost        = 30
+	projectLabelBoost        = 29
+	projectDescBoost         = 5
+	projectDifficultyBoost   = 3
+	projectQuestionBoost     = 3
+	projectIntroductionBoost = 2
+	projectResumeBoost       = 2
+	projectAnalysisBoost     = 1
+)
+
+// Project 和项目模块同步过来的数据一致
+type Project struct {
+	Id            int64                 `json:"id"`
+	Title         string                `json:"title"`
+	Status        uint8                 `json:"status"`
+	Desc          string                `json:"desc"`
+	Labels        []string              `json:"labels"`
+	Utime         int64                 `json:"utime"`
+	Difficulties  []ProjectDifficulty   `json:"difficulties"`
+	Resumes       []ProjectResume       `json:"resumes"`
+	Questions     []ProjectQuestion     `json:"questions"`
+	Introductions []ProjectIntroduction `json:"introductions"`
+}
+
+type ProjectDifficulty struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Analysis string `json:"analysis"`
+	Content  string `json:"content"`
+	Status   uint8  `json:"status"`
+	Utime    int64  `json:"utime"`
+}
+
+type ProjectResume struct {
+	Id       int64  `json:"id"`
+	Role     uint8  `json:"role"`
+	Content  string `json:"content"`
+	Analysis string `json:"analysis"`
+	Status   uint8  `json:"status"`
+	Utime    int64  `json:"utime"`
+}
+
+type ProjectQuestion struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Analysis string `json:"analysis"`
+	Answer   string `json:"answer"`
+	Status   uint8  `json:"status"`
+	Utime    int64  `json:"utime"`
+}
+
+type ProjectIntroduction struct {
+	Id       int64  `json:"id"`
+	Role     uint8  `json:"role"`
+	Content  string `json:"content"`
+	Analysis string `json:"analysis"`
+	Status   uint8  `json:"status"`
+	Utime    int64  `json:"utime"`
+}
+
+type projectElasticDAO struct {
+	engine engine.Engine
+	metas  map[string]engine.Field
+}
+
+func NewProjectDAO(e engine.Engine) ProjectDAO {
+	return &projectElasticDAO{
+		engine: e,
+		metas: map[string]engine.Field{
+			"title": {
+				Name:  "title",
+				Boost: projectTitleBoost,
+			},
+			"labels": {
+				Name:   "labels",
+				Boost:  projectLabelBoost,
+				IsTerm: true,
+			},
+			"desc": {
+				Name:  "desc",
+				Boost: projectDescBoost,
+			},
+			"difficulties.title": {
+				Name:  "difficulties.title",
+				Boost: projectDifficultyBoost,
+			},
+			"difficulties.content": {
+				Name:  "difficulties.content",
+				Boost: projectDifficultyBoost,
+			},
+			"difficulties.analysis": {
+				Name:  "difficulties.analysis",
+				Boost: projectAnalysisBoost,
+			},
+			"questions.title": {
+				Name:  "questions.title",
+				Boost: projectQuestionBoost,
+			},
+			"questions.answer": {
+				Name:  "questions.answer",
+				Boost: projectQuestionBoost,
+			},
+			"questions.analysis": {
+				Name:  "questions.analysis",
+				Boost: projectAnalysisBoost,
+			},
+			"introductions.content": {
+				Name:  "introductions.content",
+				Boost: projectIntroductionBoost,
+			},
+			"resumes.content": {
+				Name:  "resumes.content",
+				Boost: projectResumeBoost,
+			},
+		},
+	}
+}
+
+func (p *projectElasticDAO) SearchProject(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Project], error) {
+	filters, ok := withAccess(query, []engine.Filter{{Field: "status", Value: domain.PublishedStatus}}, projectAccessFilters)
+	if !ok {
+		return SearchHits[Project]{}, nil
+	}
+	return search[Project](ctx, p.engine, engine.SearchReq{
+		Index:   ProjectIndexName,
+		Query:   query,
+		Fields:  p.metas,
+		Filters: filters,
+		Offset:  offset,
+		Limit:   limit,
+	})
+}
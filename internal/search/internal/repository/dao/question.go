@@ -16,11 +16,10 @@ package dao
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 
-	"github.com/olivere/elastic/v7"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 )
 
 const (
@@ -33,6 +32,8 @@ const (
 type Question struct {
 	ID      int64    `json:"id"`
 	UID     int64    `json:"uid"`
+	Biz     string   `json:"biz"`
+	BizId   int64    `json:"bizId"`
 	Title   string   `json:"title"`
 	Labels  []string `json:"labels"`
 	Content string   `json:"content"`
@@ -57,14 +58,14 @@ type AnswerElement struct {
 }
 
 type questionElasticDAO struct {
-	client *elastic.Client
-	metas  map[string]Col
+	engine engine.Engine
+	metas  map[string]engine.Field
 }
 
-func NewQuestionDAO(client *elastic.Client) QuestionDAO {
+func NewQuestionDAO(e engine.Engine) QuestionDAO {
 	return &questionElasticDAO{
-		client: client,
-		metas: map[string]Col{
+		engine: e,
+		metas: map[string]engine.Field{
 			"title": {
 				Name:  "title",
 				Boost: questionTitleBoost,
@@ -91,63 +92,68 @@ func NewQuestionDAO(client *elastic.Client) QuestionDAO {
 				Name: "answer.analysis.guidance",
 			},
 			"answer.basic.keywords": {
-				Name: "answer.basic.keywords",
+				Name:  "answer.basic.keywords",
+				Level: domain.LevelBasic,
 			},
 			"answer.basic.shorthand": {
-				Name: "answer.basic.shorthand",
+				Name:  "answer.basic.shorthand",
+				Level: domain.LevelBasic,
 			},
 			"answer.basic.highlight": {
-				Name: "answer.basic.highlight",
+				Name:  "answer.basic.highlight",
+				Level: domain.LevelBasic,
 			},
 			"answer.basic.guidance": {
-				Name: "answer.basic.guidance",
+				Name:  "answer.basic.guidance",
+				Level: domain.LevelBasic,
 			},
 			"answer.intermediate.keywords": {
-				Name: "answer.intermediate.keywords",
+				Name:  "answer.intermediate.keywords",
+				Level: domain.LevelIntermediate,
 			},
 			"answer.intermediate.shorthand": {
-				Name: "answer.intermediate.shorthand",
+				Name:  "answer.intermediate.shorthand",
+				Level: domain.LevelIntermediate,
 			},
 			"answer.intermediate.highlight": {
-				Name: "answer.intermediate.highlight",
+				Name:  "answer.intermediate.highlight",
+				Level: domain.LevelIntermediate,
 			},
 			"answer.intermediate.guidance": {
-				Name: "answer.intermediate.guidance",
+				Name:  "answer.intermediate.guidance",
+				Level: domain.LevelIntermediate,
 			},
 			"answer.advanced.keywords": {
-				Name: "answer.advanced.keywords",
+				Name:  "answer.advanced.keywords",
+				Level: domain.LevelAdvanced,
 			},
 			"answer.advanced.shorthand": {
-				Name: "answer.advanced.shorthand",
+				Name:  "answer.advanced.shorthand",
+				Level: domain.LevelAdvanced,
 			},
 			"answer.advanced.highlight": {
-				Name: "answer.advanced.highlight",
+				Name:  "answer.advanced.highlight",
+				Level: domain.LevelAdvanced,
 			},
 			"answer.advanced.guidance": {
-				Name: "answer.advanced.guidance",
+				Name:  "answer.advanced.guidance",
+				Level: domain.LevelAdvanced,
 			},
 		},
 	}
 }
 
-func (q *questionElasticDAO) SearchQuestion(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Question, error) {
-	query := elastic.NewBoolQuery().Must(
-		elastic.NewBoolQuery().Should(buildCols(q.metas, queryMetas)...),
-		elastic.NewTermQuery("status", 2))
-	resp, err := q.client.Search(QuestionIndexName).
-		From(offset).
-		Size(limit).Query(query).Do(ctx)
-	if err != nil {
-		return nil, err
+func (q *questionElasticDAO) SearchQuestion(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Question], error) {
+	filters, ok := withAccess(query, []engine.Filter{{Field: "status", Value: domain.PublishedStatus}}, questionAccessFilters)
+	if !ok {
+		return SearchHits[Question]{}, nil
 	}
-	res := make([]Question, 0, len(resp.Hits.Hits))
-	for _, hit := range resp.Hits.Hits {
-		var ele Question
-		err = json.Unmarshal(hit.Source, &ele)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, ele)
-	}
-	return res, nil
+	return search[Question](ctx, q.engine, engine.SearchReq{
+		Index:   QuestionIndexName,
+		Query:   query,
+		Fields:  q.metas,
+		Filters: filters,
+		Offset:  offset,
+		Limit:   limit,
+	})
 }
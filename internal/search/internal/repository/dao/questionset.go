@@ -16,11 +16,10 @@ package dao
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 
-	"github.com/olivere/elastic/v7"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 )
 
 const (
@@ -42,14 +41,14 @@ type QuestionSet struct {
 	Utime     int64   `json:"utime"`
 }
 type questionSetElasticDAO struct {
-	client *elastic.Client
-	metas  map[string]Col
+	engine engine.Engine
+	metas  map[string]engine.Field
 }
 
-func NewQuestionSetDAO(client *elastic.Client) QuestionSetDAO {
+func NewQuestionSetDAO(e engine.Engine) QuestionSetDAO {
 	return &questionSetElasticDAO{
-		client: client,
-		metas: map[string]Col{
+		engine: e,
+		metas: map[string]engine.Field{
 			"title": {
 				Name:  "title",
 				Boost: questionSetTitleBoost,
@@ -62,23 +61,12 @@ func NewQuestionSetDAO(client *elastic.Client) QuestionSetDAO {
 	}
 }
 
-func (q *questionSetElasticDAO) SearchQuestionSet(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]QuestionSet, error) {
-	query := elastic.NewBoolQuery().Should(buildCols(q.metas, queryMetas)...)
-	resp, err := q.client.Search(QuestionSetIndexName).
-		From(offset).
-		Size(limit).Query(query).Do(ctx)
-	if err != nil {
-		return nil, err
-	}
-	res := make([]QuestionSet, 0, len(resp.Hits.Hits))
-	for _, hit := range resp.Hits.Hits {
-		var ele QuestionSet
-		err = json.Unmarshal(hit.Source, &ele)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, ele)
-	}
-	return res, nil
-
+func (q *questionSetElasticDAO) SearchQuestionSet(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[QuestionSet], error) {
+	return search[QuestionSet](ctx, q.engine, engine.SearchReq{
+		Index:  QuestionSetIndexName,
+		Query:  query,
+		Fields: q.metas,
+		Offset: offset,
+		Limit:  limit,
+	})
 }
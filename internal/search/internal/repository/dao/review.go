@@ -0,0 +1,105 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dao
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+const (
+	ReviewIndexName      = "review_index"
+	reviewTitleBoost     = 30
+	reviewLabelBoost     = 29
+	reviewDescBoost      = 5
+	reviewQuestionsBoost = 3
+	reviewJDBoost        = 2
+	reviewAnalysisBoost  = 1
+	reviewResumeBoost    = 1
+)
+
+// Review 面经
+type Review struct {
+	Id               int64    `json:"id"`
+	Uid              int64    `json:"uid"`
+	Title            string   `json:"title"`
+	Desc             string   `json:"desc"`
+	Labels           []string `json:"labels"`
+	JD               string   `json:"jd"`
+	JDAnalysis       string   `json:"jd_analysis"`
+	Questions        string   `json:"questions"`
+	QuestionAnalysis string   `json:"question_analysis"`
+	Resume           string   `json:"resume"`
+	Status           uint8    `json:"status"`
+	Utime            int64    `json:"utime"`
+}
+
+type reviewElasticDAO struct {
+	engine engine.Engine
+	metas  map[string]engine.Field
+}
+
+func NewReviewDAO(e engine.Engine) ReviewDAO {
+	return &reviewElasticDAO{
+		engine: e,
+		metas: map[string]engine.Field{
+			"title": {
+				Name:  "title",
+				Boost: reviewTitleBoost,
+			},
+			"labels": {
+				Name:   "labels",
+				Boost:  reviewLabelBoost,
+				IsTerm: true,
+			},
+			"desc": {
+				Name:  "desc",
+				Boost: reviewDescBoost,
+			},
+			"questions": {
+				Name:  "questions",
+				Boost: reviewQuestionsBoost,
+			},
+			"jd": {
+				Name:  "jd",
+				Boost: reviewJDBoost,
+			},
+			"question_analysis": {
+				Name:  "question_analysis",
+				Boost: reviewAnalysisBoost,
+			},
+			"jd_analysis": {
+				Name:  "jd_analysis",
+				Boost: reviewAnalysisBoost,
+			},
+			"resume": {
+				Name:  "resume",
+				Boost: reviewResumeBoost,
+			},
+		},
+	}
+}
+
+func (r *reviewElasticDAO) SearchReview(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Review], error) {
+	return search[Review](ctx, r.engine, engine.SearchReq{
+		Index:   ReviewIndexName,
+		Query:   query,
+		Fields:  r.metas,
+		Filters: []engine.Filter{{Field: "status", Value: domain.PublishedStatus}},
+		Offset:  offset,
+		Limit:   limit,
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dao
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+const (
+	RoadmapIndexName     = "roadmap_index"
+	roadmapTitleBoost    = 30
+	roadmapBizTitleBoost = 10
+)
+
+// Roadmap 学习路线没有发布的概念，保存之后就可以搜索到
+type Roadmap struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Biz      string `json:"biz"`
+	BizId    int64  `json:"biz_id"`
+	BizTitle string `json:"biz_title"`
+	Utime    int64  `json:"utime"`
+}
+
+type roadmapElasticDAO struct {
+	engine engine.Engine
+	metas  map[string]engine.Field
+}
+
+func NewRoadmapDAO(e engine.Engine) RoadmapDAO {
+	return &roadmapElasticDAO{
+		engine: e,
+		metas: map[string]engine.Field{
+			"title": {
+				Name:  "title",
+				Boost: roadmapTitleBoost,
+			},
+			"biz_title": {
+				Name:  "biz_title",
+				Boost: roadmapBizTitleBoost,
+			},
+		},
+	}
+}
+
+func (r *roadmapElasticDAO) SearchRoadmap(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Roadmap], error) {
+	return search[Roadmap](ctx, r.engine, engine.SearchReq{
+		Index:  RoadmapIndexName,
+		Query:  query,
+		Fields: r.metas,
+		Offset: offset,
+		Limit:  limit,
+	})
+}
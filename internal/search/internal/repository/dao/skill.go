@@ -16,11 +16,10 @@ package dao
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 
-	"github.com/olivere/elastic/v7"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
 )
 
 const (
@@ -52,14 +51,14 @@ type Skill struct {
 }
 
 type skillElasticDAO struct {
-	client *elastic.Client
-	metas  map[string]Col
+	engine engine.Engine
+	metas  map[string]engine.Field
 }
 
-func NewSkillElasticDAO(client *elastic.Client) SkillDAO {
+func NewSkillElasticDAO(e engine.Engine) SkillDAO {
 	return &skillElasticDAO{
-		client: client,
-		metas: map[string]Col{
+		engine: e,
+		metas: map[string]engine.Field{
 			"name": {
 				Name:  "name",
 				Boost: skillNameBoost,
@@ -74,36 +73,28 @@ func NewSkillElasticDAO(client *elastic.Client) SkillDAO {
 				Boost: skillDescBoost,
 			},
 			"basic.desc": {
-				Name: "basic.desc",
+				Name:  "basic.desc",
+				Level: domain.LevelBasic,
 			},
 			"intermediate.desc": {
-				Name: "intermediate.desc",
+				Name:  "intermediate.desc",
+				Level: domain.LevelIntermediate,
 			},
 			"advanced.desc": {
-				Name: "advanced.desc",
+				Name:  "advanced.desc",
+				Level: domain.LevelAdvanced,
 			},
 		},
 	}
 
 }
 
-func (s *skillElasticDAO) SearchSkill(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Skill, error) {
-
-	query := elastic.NewBoolQuery().Should(buildCols(s.metas, queryMetas)...)
-	resp, err := s.client.Search(SkillIndexName).
-		From(offset).
-		Size(limit).Query(query).Do(ctx)
-	if err != nil {
-		return nil, err
-	}
-	res := make([]Skill, 0, len(resp.Hits.Hits))
-	for _, hit := range resp.Hits.Hits {
-		var ele Skill
-		err = json.Unmarshal(hit.Source, &ele)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, ele)
-	}
-	return res, nil
+func (s *skillElasticDAO) SearchSkill(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Skill], error) {
+	return search[Skill](ctx, s.engine, engine.SearchReq{
+		Index:  SkillIndexName,
+		Query:  query,
+		Fields: s.metas,
+		Offset: offset,
+		Limit:  limit,
+	})
 }
@@ -0,0 +1,121 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+// Suggestion 搜索建议，来自标题和标签的 suggest 子字段
+type Suggestion struct {
+	Biz    string
+	Id     int64
+	Title  string
+	Labels []string
+}
+
+type suggestDoc struct {
+	Id     int64    `json:"id"`
+	Title  string   `json:"title"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+type SuggestDAO interface {
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+}
+
+type suggestDAO struct {
+	engine engine.Engine
+	// indexes 索引到业务的映射
+	indexes map[string]string
+}
+
+func NewSuggestDAO(e engine.Engine) SuggestDAO {
+	return &suggestDAO{
+		engine: e,
+		indexes: map[string]string{
+			QuestionIndexName:    domain.BizQuestion,
+			CaseIndexName:        domain.BizCase,
+			SkillIndexName:       domain.BizSkill,
+			QuestionSetIndexName: domain.BizQuestionSet,
+			ProjectIndexName:     domain.BizProject,
+			ReviewIndexName:      domain.BizReview,
+			RoadmapIndexName:     domain.BizRoadmap,
+		},
+	}
+}
+
+func (s *suggestDAO) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	indexes := make([]string, 0, len(s.indexes))
+	for idx := range s.indexes {
+		indexes = append(indexes, idx)
+	}
+	hits, err := s.engine.Suggest(ctx, engine.SuggestReq{
+		Indexes: indexes,
+		Prefix:  prefix,
+		// 技能的标题叫做 name，题集和学习路线没有标签
+		Fields: []engine.SuggestField{
+			{Name: "title", Boost: 3},
+			{Name: "name", Boost: 3},
+			{Name: "labels", Boost: 1},
+		},
+		// 题目、案例、项目和面经只建议已经发布的，技能、题集和学习路线没有状态
+		Filters: []engine.Filter{
+			{Field: "status", Value: domain.PublishedStatus, AllowMissing: true},
+		},
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Suggestion, 0, len(hits))
+	for _, hit := range hits {
+		var doc suggestDoc
+		err = json.Unmarshal(hit.Source, &doc)
+		if err != nil {
+			return nil, err
+		}
+		title := doc.Title
+		if title == "" {
+			title = doc.Name
+		}
+		res = append(res, Suggestion{
+			// 索引名字可能带有版本号之类的后缀
+			Biz:    s.biz(hit.Index),
+			Id:     doc.Id,
+			Title:  title,
+			Labels: doc.Labels,
+		})
+	}
+	return res, nil
+}
+
+func (s *suggestDAO) biz(index string) string {
+	if biz, ok := s.indexes[index]; ok {
+		return biz
+	}
+	for idx, biz := range s.indexes {
+		if strings.HasPrefix(index, idx) {
+			return biz
+		}
+	}
+	return ""
+}
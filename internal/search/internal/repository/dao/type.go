@@ -16,28 +16,75 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 )
 
 type CaseDAO interface {
-	SearchCase(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Case, error)
+	SearchCase(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Case], error)
 }
 
 type QuestionDAO interface {
-	SearchQuestion(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Question, error)
+	SearchQuestion(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Question], error)
 }
 
 type SkillDAO interface {
 	// ids 为case的id 和question的id
-	SearchSkill(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]Skill, error)
+	SearchSkill(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Skill], error)
 }
 
 type QuestionSetDAO interface {
 	// ids 为case的id 和question的id
-	SearchQuestionSet(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]QuestionSet, error)
+	SearchQuestionSet(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[QuestionSet], error)
+}
+
+type ProjectDAO interface {
+	SearchProject(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Project], error)
+}
+
+type ReviewDAO interface {
+	SearchReview(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Review], error)
+}
+
+type RoadmapDAO interface {
+	SearchRoadmap(ctx context.Context, offset, limit int, query domain.Query) (SearchHits[Roadmap], error)
 }
 
 type AnyDAO interface {
 	Input(ctx context.Context, index string, docID string, data string) error
+	Delete(ctx context.Context, index string, docID string) error
+}
+
+// IndexDAO 管理索引和别名，业务读写的都是别名，重建索引的时候切换别名指向的索引
+type IndexDAO interface {
+	// CreateVersion 按照 alias 的 mapping 创建一个新版本的索引，返回索引的名字
+	CreateVersion(ctx context.Context, alias string) (string, error)
+	Delete(ctx context.Context, index string) error
+	// Current 别名当前指向的索引
+	Current(ctx context.Context, alias string) (string, error)
+	// SwapAlias 原子地切换别名指向的索引，返回原本指向的索引
+	SwapAlias(ctx context.Context, alias, index string) (string, error)
+	// Scan 遍历索引里面的全部文档
+	Scan(ctx context.Context, index string, fn func(docID string, data json.RawMessage) error) error
+}
+
+// AnalyticsDAO 搜索记录和点击记录，用于分析用户搜什么、有没有找到
+type AnalyticsDAO interface {
+	// InsertQueryLog 同一个 sid 只会保存一次
+	InsertQueryLog(ctx context.Context, log SearchQueryLog) error
+	InsertClick(ctx context.Context, click SearchClick) error
+	// FindQueryLog 按照 sid 查找搜索记录，点击结果的时候用来校验
+	FindQueryLog(ctx context.Context, sid string) (SearchQueryLog, error)
+	// TopQueries 按照搜索次数排序
+	TopQueries(ctx context.Context, start, end int64, limit int) ([]QueryStat, error)
+	// ZeroResultQueries 按照没有结果的次数排序
+	ZeroResultQueries(ctx context.Context, start, end int64, limit int) ([]QueryStat, error)
+	// LowClickThroughQueries 按照点击率从低到高排序，搜索次数少于 minSearches 的不参与排序
+	LowClickThroughQueries(ctx context.Context, start, end int64, minSearches int64, limit int) ([]QueryStat, error)
+	// FindQueryLogsByUid 和 FindClicksByUid 导出个人数据的时候使用，按照 id 升序
+	FindQueryLogsByUid(ctx context.Context, uid int64) ([]SearchQueryLog, error)
+	FindClicksByUid(ctx context.Context, uid int64) ([]SearchClick, error)
+	// DeleteByUid 删除用户的搜索记录和点击记录
+	DeleteByUid(ctx context.Context, uid int64) error
 }
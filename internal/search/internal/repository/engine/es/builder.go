@@ -0,0 +1,117 @@
+package es
+
+import (
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+	"github.com/olivere/elastic/v7"
+)
+
+// buildQuery 把搜索表达式编译成 elastic 的查询
+func buildQuery(cols map[string]engine.Field, query domain.Query) elastic.Query {
+	b := queryBuilder{cols: cols}
+	if query.Level != "" {
+		b.cols = make(map[string]engine.Field, len(cols))
+		for name, col := range cols {
+			if col.Level == query.Level {
+				b.cols[name] = col
+			}
+		}
+		// 没有级别概念的业务，指定了级别就不会有结果
+		if len(b.cols) == 0 {
+			return elastic.NewMatchNoneQuery()
+		}
+	}
+	if query.Expr == nil {
+		return elastic.NewMatchAllQuery()
+	}
+	return b.build(query.Expr)
+}
+
+type queryBuilder struct {
+	// cols 关键字可以搜索的列，已经按照级别过滤过了
+	cols map[string]engine.Field
+}
+
+func (b queryBuilder) build(expr domain.Expr) elastic.Query {
+	switch e := expr.(type) {
+	case domain.Term:
+		return b.buildTerm(e)
+	case domain.Label:
+		// 标签不受级别的限制
+		return elastic.NewTermQuery(engine.LabelsField, e.Label)
+	case domain.Updated:
+		q := elastic.NewRangeQuery(engine.UtimeField)
+		if e.Start > 0 {
+			q = q.Gte(e.Start)
+		}
+		if e.End > 0 {
+			q = q.Lt(e.End)
+		}
+		return q
+	case domain.Not:
+		return elastic.NewBoolQuery().MustNot(b.build(e.Expr))
+	case domain.Or:
+		q := elastic.NewBoolQuery().MinimumNumberShouldMatch(1)
+		for _, sub := range e.Exprs {
+			q = q.Should(b.build(sub))
+		}
+		return q
+	case domain.And:
+		return b.buildAnd(e)
+	default:
+		return elastic.NewMatchNoneQuery()
+	}
+}
+
+// buildAnd 用于排序的关键字放在 should 里面，至少命中一个；
+// 排除放在 must_not，标签和时间放在 filter，其余必须满足的条件放在 must
+func (b queryBuilder) buildAnd(and domain.And) elastic.Query {
+	q := elastic.NewBoolQuery()
+	hasShould := false
+	for _, sub := range and.Exprs {
+		switch e := sub.(type) {
+		case domain.Not:
+			q = q.MustNot(b.build(e.Expr))
+		case domain.Label, domain.Updated:
+			q = q.Filter(b.build(e))
+		default:
+			if e.Scoring() {
+				hasShould = true
+				q = q.Should(b.build(e))
+			} else {
+				q = q.Must(b.build(e))
+			}
+		}
+	}
+	if hasShould {
+		q = q.MinimumNumberShouldMatch(1)
+	}
+	return q
+}
+
+func (b queryBuilder) buildTerm(term domain.Term) elastic.Query {
+	if term.Col != "" {
+		col, ok := b.cols[term.Col]
+		if !ok {
+			return elastic.NewMatchNoneQuery()
+		}
+		return b.buildColTerm(col, term)
+	}
+	q := elastic.NewBoolQuery().MinimumNumberShouldMatch(1)
+	for _, col := range b.cols {
+		q = q.Should(b.buildColTerm(col, term))
+	}
+	return q
+}
+
+func (b queryBuilder) buildColTerm(col engine.Field, term domain.Term) elastic.Query {
+	boost := float64(col.Boost)
+	switch {
+	case col.IsTerm:
+		return elastic.NewTermQuery(col.Name, term.Keyword).Boost(boost)
+	case term.Phrase:
+		return elastic.NewMatchPhraseQuery(col.Name, term.Keyword).Boost(boost)
+	default:
+		return elastic.NewMatchQuery(col.Name, term.Keyword).Boost(boost)
+	}
+}
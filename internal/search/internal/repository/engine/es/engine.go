@@ -0,0 +1,215 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+	"github.com/olivere/elastic/v7"
+)
+
+var _ engine.Engine = &Engine{}
+
+// Engine 基于 elastic 的实现
+type Engine struct {
+	client *elastic.Client
+}
+
+func NewEngine(client *elastic.Client) *Engine {
+	return &Engine{client: client}
+}
+
+func (e *Engine) Index(ctx context.Context, index, docID, doc string) error {
+	_, err := e.client.Index().
+		Index(index).
+		Id(docID).
+		BodyJson(doc).Do(ctx)
+	return err
+}
+
+func (e *Engine) Delete(ctx context.Context, index, docID string) error {
+	_, err := e.client.Delete().
+		Index(index).
+		Id(docID).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (e *Engine) Search(ctx context.Context, req engine.SearchReq) (engine.SearchResp, error) {
+	query := elastic.NewBoolQuery().
+		Must(buildQuery(req.Fields, req.Query)).
+		Filter(e.filters(req.Filters)...)
+	hl := elastic.NewHighlight().
+		PreTags(engine.HighlightPreTag).
+		PostTags(engine.HighlightPostTag).
+		NumOfFragments(engine.HighlightFragments).
+		FragmentSize(engine.HighlightFragmentSize)
+	for _, field := range req.Fields {
+		hl = hl.Fields(elastic.NewHighlighterField(field.Name))
+	}
+	resp, err := e.client.Search(req.Index).
+		From(req.Offset).
+		Size(req.Limit).
+		Query(query).
+		Highlight(hl).
+		SortBy(elastic.NewScoreSort(), elastic.NewFieldSort(engine.IdField).Asc()).
+		TrackScores(true).
+		TrackTotalHits(true).
+		Do(ctx)
+	if err != nil {
+		return engine.SearchResp{}, err
+	}
+	res := engine.SearchResp{
+		Hits: e.hits(resp),
+	}
+	if resp.Hits.TotalHits != nil {
+		res.Total = resp.Hits.TotalHits.Value
+	}
+	if resp.Hits.MaxScore != nil {
+		res.MaxScore = *resp.Hits.MaxScore
+	}
+	return res, nil
+}
+
+// Suggest 依赖 mapping 里面的 suggest 子字段，它使用了 edge_ngram 分词
+func (e *Engine) Suggest(ctx context.Context, req engine.SuggestReq) ([]engine.Hit, error) {
+	fields := make([]string, 0, len(req.Fields))
+	for _, f := range req.Fields {
+		fields = append(fields, fmt.Sprintf("%s.suggest^%d", f.Name, max(f.Boost, 1)))
+	}
+	query := elastic.NewBoolQuery().
+		Must(elastic.NewMultiMatchQuery(req.Prefix, fields...).Operator("and")).
+		Filter(e.filters(req.Filters)...)
+	resp, err := e.client.Search(req.Indexes...).
+		Query(query).
+		Size(req.Limit).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return e.hits(resp), nil
+}
+
+func (e *Engine) CreateIndex(ctx context.Context, index, mapping string) error {
+	_, err := e.client.CreateIndex(index).Body(mapping).Do(ctx)
+	return err
+}
+
+func (e *Engine) DeleteIndex(ctx context.Context, index string) error {
+	_, err := e.client.DeleteIndex(index).Do(ctx)
+	return err
+}
+
+func (e *Engine) ResolveAlias(ctx context.Context, name string) (string, error) {
+	res, err := e.client.Aliases().Alias(name).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return name, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	indexes := res.IndicesByAlias(name)
+	if len(indexes) == 0 {
+		return name, nil
+	}
+	return indexes[0], nil
+}
+
+func (e *Engine) SwapAlias(ctx context.Context, alias, index string) (string, error) {
+	old, err := e.ResolveAlias(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+	svc := e.client.Alias().Add(index, alias)
+	if old != alias {
+		svc = svc.Remove(old, alias)
+	} else {
+		// 早期的索引没有使用别名，这里在同一个请求里面删掉同名的索引
+		ok, err := e.client.IndexExists(alias).Do(ctx)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			svc = svc.Action(elastic.NewAliasRemoveIndexAction(alias))
+		}
+		old = ""
+	}
+	_, err = svc.Do(ctx)
+	return old, err
+}
+
+func (e *Engine) Scan(ctx context.Context, index string, fn func(hit engine.Hit) error) error {
+	const batchSize = 500
+	scroll := e.client.Scroll(index).Size(batchSize)
+	defer func() {
+		_ = scroll.Clear(context.Background())
+	}()
+	for {
+		resp, err := scroll.Do(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, hit := range e.hits(resp) {
+			if err = fn(hit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (e *Engine) filters(filters []engine.Filter) []elastic.Query {
+	res := make([]elastic.Query, 0, len(filters))
+	for _, f := range filters {
+		var q elastic.Query = elastic.NewTermQuery(f.Field, f.Value)
+		if len(f.Values) > 0 {
+			q = elastic.NewTermsQuery(f.Field, f.Values...)
+		}
+		if f.AllowMissing {
+			q = elastic.NewBoolQuery().Should(
+				q,
+				elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery(f.Field)),
+			).MinimumNumberShouldMatch(1)
+		}
+		res = append(res, q)
+	}
+	return res
+}
+
+func (e *Engine) hits(resp *elastic.SearchResult) []engine.Hit {
+	res := make([]engine.Hit, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var score float64
+		if hit.Score != nil {
+			score = *hit.Score
+		}
+		res = append(res, engine.Hit{
+			Index:      hit.Index,
+			Id:         hit.Id,
+			Source:     hit.Source,
+			Score:      score,
+			Highlights: hit.Highlight,
+		})
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package es
+
+import (
+	"context"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+	"golang.org/x/sync/errgroup"
+)
+
+// InitIndexes 创建索引，mappings 是索引名字到 mapping 的映射
+func InitIndexes(client *elastic.Client, mappings map[string]string) error {
+	const timeout = time.Second * 10
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var eg errgroup.Group
+	for idxName, idxCfg := range mappings {
+		idxName, idxCfg := idxName, idxCfg
+		eg.Go(func() error {
+			return tryCreateIndex(ctx, client, idxName, idxCfg)
+		})
+	}
+	return eg.Wait()
+}
+
+func tryCreateIndex(ctx context.Context,
+	client *elastic.Client,
+	idxName, idxCfg string,
+) error {
+	// 索引可能已经建好了
+	ok, err := client.IndexExists(idxName).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	_, err = client.CreateIndex(idxName).Body(idxCfg).Do(ctx)
+	return err
+}
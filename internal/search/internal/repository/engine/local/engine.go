@@ -0,0 +1,694 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+var _ engine.Engine = &Engine{}
+
+// Engine 进程内的倒排索引实现，数据只保存在内存里面
+// 用于开发环境和 CI，不依赖任何外部服务
+type Engine struct {
+	mu      sync.RWMutex
+	indexes map[string]*index
+	// aliases 别名 => 索引
+	aliases map[string]string
+}
+
+func NewEngine() *Engine {
+	return &Engine{
+		indexes: make(map[string]*index),
+		aliases: make(map[string]string),
+	}
+}
+
+// resolve 调用者需要持有锁
+func (e *Engine) resolve(name string) string {
+	if idx, ok := e.aliases[name]; ok {
+		return idx
+	}
+	return name
+}
+
+func (e *Engine) Index(ctx context.Context, idx, docID, doc string) error {
+	d, err := newDocument(docID, doc)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// 和 elastic 一样，索引不存在的时候自动创建
+	idx = e.resolve(idx)
+	ix, ok := e.indexes[idx]
+	if !ok {
+		ix = newIndex()
+		e.indexes[idx] = ix
+	}
+	ix.put(d)
+	return nil
+}
+
+func (e *Engine) Delete(ctx context.Context, idx, docID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ix, ok := e.indexes[e.resolve(idx)]
+	if !ok {
+		return nil
+	}
+	if d, ok := ix.docs[docID]; ok {
+		ix.remove(d)
+	}
+	return nil
+}
+
+func (e *Engine) Search(ctx context.Context, req engine.SearchReq) (engine.SearchResp, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	idx := e.resolve(req.Index)
+	ix, ok := e.indexes[idx]
+	if !ok {
+		return engine.SearchResp{Hits: []engine.Hit{}}, nil
+	}
+	fields := filterFields(req.Fields, req.Query.Level)
+	if fields == nil {
+		// 没有级别概念的业务，指定了级别就不会有结果
+		return engine.SearchResp{Hits: []engine.Hit{}}, nil
+	}
+	m := matcher{ix: ix, fields: fields}
+	var matched []scoredDoc
+	for _, doc := range ix.candidates(m, req.Query.Expr) {
+		if !doc.accept(req.Filters) {
+			continue
+		}
+		score := 1.0
+		if req.Query.Expr != nil {
+			var ok bool
+			ok, score = m.match(doc, req.Query.Expr)
+			if !ok {
+				continue
+			}
+		}
+		matched = append(matched, scoredDoc{doc: doc, score: score})
+	}
+	sortDocs(matched)
+
+	offset, limit := max(req.Offset, 0), max(req.Limit, 0)
+	res := engine.SearchResp{
+		Hits:  make([]engine.Hit, 0, max(min(len(matched)-offset, limit), 0)),
+		Total: int64(len(matched)),
+	}
+	if len(matched) > 0 {
+		res.MaxScore = matched[0].score
+	}
+	hl := highlighter{fields: fields}
+	hl.collect(req.Query.Expr, false)
+	for i := offset; i < len(matched) && i < offset+limit; i++ {
+		sd := matched[i]
+		res.Hits = append(res.Hits, engine.Hit{
+			Index:      idx,
+			Id:         sd.doc.docID,
+			Source:     sd.doc.source,
+			Score:      sd.score,
+			Highlights: hl.highlight(sd.doc),
+		})
+	}
+	return res, nil
+}
+
+// Suggest 逐个文档扫描，每一个用空格分开的词都要是同一个字段里面某个词的前缀
+func (e *Engine) Suggest(ctx context.Context, req engine.SuggestReq) ([]engine.Hit, error) {
+	words := strings.Fields(strings.ToLower(req.Prefix))
+	if len(words) == 0 {
+		return []engine.Hit{}, nil
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var matched []scoredDoc
+	for _, name := range req.Indexes {
+		idx := e.resolve(name)
+		ix, ok := e.indexes[idx]
+		if !ok {
+			continue
+		}
+		for _, doc := range ix.docs {
+			if !doc.accept(req.Filters) {
+				continue
+			}
+			score := 0.0
+			for _, f := range req.Fields {
+				if prefixMatch(doc.fields[f.Name], words) {
+					score = max(score, float64(max(f.Boost, 1)))
+				}
+			}
+			if score > 0 {
+				matched = append(matched, scoredDoc{index: idx, doc: doc, score: score})
+			}
+		}
+	}
+	sortDocs(matched)
+	limit := max(req.Limit, 0)
+	res := make([]engine.Hit, 0, min(len(matched), limit))
+	for i := 0; i < len(matched) && i < limit; i++ {
+		res = append(res, engine.Hit{
+			Index:  matched[i].index,
+			Id:     matched[i].doc.docID,
+			Source: matched[i].doc.source,
+			Score:  matched[i].score,
+		})
+	}
+	return res, nil
+}
+
+func (e *Engine) CreateIndex(ctx context.Context, idx, mapping string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exists(idx) {
+		return fmt.Errorf("索引 %s 已经存在", idx)
+	}
+	e.indexes[idx] = newIndex()
+	return nil
+}
+
+func (e *Engine) DeleteIndex(ctx context.Context, idx string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.indexes[idx]; !ok {
+		return fmt.Errorf("索引 %s 不存在", idx)
+	}
+	delete(e.indexes, idx)
+	for alias, target := range e.aliases {
+		if target == idx {
+			delete(e.aliases, alias)
+		}
+	}
+	return nil
+}
+
+func (e *Engine) ResolveAlias(ctx context.Context, name string) (string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.resolve(name), nil
+}
+
+func (e *Engine) SwapAlias(ctx context.Context, alias, idx string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.indexes[idx]; !ok {
+		return "", fmt.Errorf("索引 %s 不存在", idx)
+	}
+	old, ok := e.aliases[alias]
+	if !ok {
+		// 和别名同名的索引会被删掉
+		delete(e.indexes, alias)
+	}
+	e.aliases[alias] = idx
+	return old, nil
+}
+
+// Scan 先复制一份，避免 fn 里面再调用 Engine 的时候死锁
+func (e *Engine) Scan(ctx context.Context, idx string, fn func(hit engine.Hit) error) error {
+	e.mu.RLock()
+	idx = e.resolve(idx)
+	ix, ok := e.indexes[idx]
+	if !ok {
+		e.mu.RUnlock()
+		return fmt.Errorf("索引 %s 不存在", idx)
+	}
+	hits := make([]engine.Hit, 0, len(ix.docs))
+	for _, d := range ix.docs {
+		hits = append(hits, engine.Hit{Index: idx, Id: d.docID, Source: d.source})
+	}
+	e.mu.RUnlock()
+	for _, hit := range hits {
+		if err := fn(hit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exists 调用者需要持有锁
+func (e *Engine) exists(name string) bool {
+	_, isIndex := e.indexes[name]
+	_, isAlias := e.aliases[name]
+	return isIndex || isAlias
+}
+
+func prefixMatch(values []string, words []string) bool {
+	var tokens []string
+	for _, v := range values {
+		tokens = append(tokens, suggestTokens(v)...)
+	}
+	for _, w := range words {
+		if !slices.ContainsFunc(tokens, func(t string) bool {
+			return strings.HasPrefix(t, w)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterFields 按照级别过滤字段，返回 nil 代表没有符合级别的字段
+func filterFields(fields map[string]engine.Field, level string) map[string]engine.Field {
+	if level == "" {
+		return fields
+	}
+	var res map[string]engine.Field
+	for name, f := range fields {
+		if f.Level == level {
+			if res == nil {
+				res = make(map[string]engine.Field)
+			}
+			res[name] = f
+		}
+	}
+	return res
+}
+
+type scoredDoc struct {
+	index string
+	doc   *document
+	score float64
+}
+
+// sortDocs 和 elastic 的实现保持一致，分数降序，分数相同的按照 id 升序
+func sortDocs(docs []scoredDoc) {
+	slices.SortFunc(docs, func(a, b scoredDoc) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		case a.doc.id != b.doc.id:
+			return compareInt(a.doc.id, b.doc.id)
+		case a.index != b.index:
+			return strings.Compare(a.index, b.index)
+		default:
+			return strings.Compare(a.doc.docID, b.doc.docID)
+		}
+	})
+}
+
+func compareInt(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+type document struct {
+	docID  string
+	id     int64
+	source json.RawMessage
+	// fields 展开之后的字段，嵌套的字段用 . 连接，数组展开成多个值
+	fields map[string][]string
+}
+
+func newDocument(docID, doc string) (*document, error) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	d := &document{
+		docID:  docID,
+		source: json.RawMessage(doc),
+		fields: make(map[string][]string),
+	}
+	flatten("", val, d.fields)
+	if ids := d.fields[engine.IdField]; len(ids) > 0 {
+		d.id, _ = strconv.ParseInt(ids[0], 10, 64)
+	}
+	return d, nil
+}
+
+func flatten(path string, val any, fields map[string][]string) {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, sub := range v {
+			if path != "" {
+				key = path + "." + key
+			}
+			flatten(key, sub, fields)
+		}
+	case []any:
+		for _, sub := range v {
+			flatten(path, sub, fields)
+		}
+	case string:
+		fields[path] = append(fields[path], v)
+	case json.Number:
+		fields[path] = append(fields[path], v.String())
+	case bool:
+		fields[path] = append(fields[path], strconv.FormatBool(v))
+	}
+}
+
+func (d *document) accept(filters []engine.Filter) bool {
+	for _, f := range filters {
+		values, ok := d.fields[f.Field]
+		if !ok {
+			if f.AllowMissing {
+				continue
+			}
+			return false
+		}
+		if !slices.ContainsFunc(filterValues(f), func(val any) bool {
+			return slices.Contains(values, filterValue(val))
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterValues(f engine.Filter) []any {
+	if len(f.Values) > 0 {
+		return f.Values
+	}
+	return []any{f.Value}
+}
+
+// filterValue 过滤条件的值统一转成和文档里面一样的字符串形式
+func filterValue(val any) string {
+	if s, ok := val.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(val)
+	return string(bytes.Trim(data, `"`))
+}
+
+func (d *document) utime() int64 {
+	values := d.fields[engine.UtimeField]
+	if len(values) == 0 {
+		return 0
+	}
+	res, _ := strconv.ParseInt(values[0], 10, 64)
+	return res
+}
+
+type index struct {
+	docs map[string]*document
+	// postings 字段 => 分词之后的词 => 文档 => 词频，用于关键字匹配
+	postings map[string]map[string]map[string]int
+	// values 字段 => 原始值 => 文档 => 出现次数，用于精确匹配
+	values map[string]map[string]map[string]int
+}
+
+func newIndex() *index {
+	return &index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]map[string]int),
+		values:   make(map[string]map[string]map[string]int),
+	}
+}
+
+func (ix *index) put(d *document) {
+	if old, ok := ix.docs[d.docID]; ok {
+		ix.remove(old)
+	}
+	ix.docs[d.docID] = d
+	for field, values := range d.fields {
+		for _, v := range values {
+			add(ix.values, field, v, d.docID)
+			for _, t := range tokenize(v) {
+				add(ix.postings, field, t, d.docID)
+			}
+		}
+	}
+}
+
+func (ix *index) remove(d *document) {
+	for field, values := range d.fields {
+		for _, v := range values {
+			del(ix.values, field, v, d.docID)
+			for _, t := range tokenize(v) {
+				del(ix.postings, field, t, d.docID)
+			}
+		}
+	}
+	delete(ix.docs, d.docID)
+}
+
+func add(postings map[string]map[string]map[string]int, field, token, docID string) {
+	terms := postings[field]
+	if terms == nil {
+		terms = make(map[string]map[string]int)
+		postings[field] = terms
+	}
+	if terms[token] == nil {
+		terms[token] = make(map[string]int)
+	}
+	terms[token][docID]++
+}
+
+func del(postings map[string]map[string]map[string]int, field, token, docID string) {
+	terms := postings[field]
+	delete(terms[token], docID)
+	if len(terms[token]) == 0 {
+		delete(terms, token)
+	}
+}
+
+// idf 和 BM25 的 idf 一样，保证结果总是正数
+func (ix *index) idf(df int) float64 {
+	n := float64(len(ix.docs))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+// candidates 利用倒排索引缩小需要逐个判断的文档范围
+func (ix *index) candidates(m matcher, expr domain.Expr) []*document {
+	set, all := m.candidates(expr)
+	if all {
+		res := make([]*document, 0, len(ix.docs))
+		for _, d := range ix.docs {
+			res = append(res, d)
+		}
+		return res
+	}
+	res := make([]*document, 0, len(set))
+	for id := range set {
+		res = append(res, ix.docs[id])
+	}
+	return res
+}
+
+// matcher 对单个文档求值，语义和 elastic 的实现里面的 buildQuery 保持一致
+type matcher struct {
+	ix *index
+	// fields 已经按照级别过滤过了
+	fields map[string]engine.Field
+}
+
+func (m matcher) termFields(term domain.Term) []engine.Field {
+	if term.Col != "" {
+		f, ok := m.fields[term.Col]
+		if !ok {
+			return nil
+		}
+		return []engine.Field{f}
+	}
+	res := make([]engine.Field, 0, len(m.fields))
+	for _, f := range m.fields {
+		res = append(res, f)
+	}
+	return res
+}
+
+// candidates 返回可能命中的文档，all 为 true 代表无法缩小范围
+func (m matcher) candidates(expr domain.Expr) (set map[string]struct{}, all bool) {
+	switch e := expr.(type) {
+	case nil:
+		return nil, true
+	case domain.Term:
+		if e.Phrase {
+			return nil, true
+		}
+		set = make(map[string]struct{})
+		for _, f := range m.termFields(e) {
+			if f.IsTerm {
+				for id := range m.ix.values[f.Name][e.Keyword] {
+					set[id] = struct{}{}
+				}
+				continue
+			}
+			for _, t := range queryTokens(e.Keyword) {
+				for id := range m.ix.postings[f.Name][t] {
+					set[id] = struct{}{}
+				}
+			}
+		}
+		return set, false
+	case domain.Label:
+		set = make(map[string]struct{})
+		for id := range m.ix.values[engine.LabelsField][e.Label] {
+			set[id] = struct{}{}
+		}
+		return set, false
+	case domain.Or:
+		set = make(map[string]struct{})
+		for _, sub := range e.Exprs {
+			s, a := m.candidates(sub)
+			if a {
+				return nil, true
+			}
+			for id := range s {
+				set[id] = struct{}{}
+			}
+		}
+		return set, false
+	case domain.And:
+		// 必须满足的条件取交集，用于排序的关键字取并集
+		var should domain.Or
+		all = true
+		for _, sub := range e.Exprs {
+			if sub.Scoring() {
+				should.Exprs = append(should.Exprs, sub)
+				continue
+			}
+			s, a := m.candidates(sub)
+			if a {
+				continue
+			}
+			set, all = intersect(set, s, all), false
+		}
+		if len(should.Exprs) > 0 {
+			s, a := m.candidates(should)
+			if !a {
+				set, all = intersect(set, s, all), false
+			}
+		}
+		return set, all
+	default:
+		return nil, true
+	}
+}
+
+func intersect(set, other map[string]struct{}, all bool) map[string]struct{} {
+	if all {
+		return other
+	}
+	res := make(map[string]struct{})
+	for id := range set {
+		if _, ok := other[id]; ok {
+			res[id] = struct{}{}
+		}
+	}
+	return res
+}
+
+func (m matcher) match(doc *document, expr domain.Expr) (bool, float64) {
+	switch e := expr.(type) {
+	case domain.Term:
+		ok, score := false, 0.0
+		for _, f := range m.termFields(e) {
+			if o, s := m.matchField(doc, f, e); o {
+				ok, score = true, score+s
+			}
+		}
+		return ok, score
+	case domain.Label:
+		return slices.Contains(doc.fields[engine.LabelsField], e.Label), 0
+	case domain.Updated:
+		utime := doc.utime()
+		if e.Start > 0 && utime < e.Start {
+			return false, 0
+		}
+		if e.End > 0 && utime >= e.End {
+			return false, 0
+		}
+		return true, 0
+	case domain.Not:
+		ok, _ := m.match(doc, e.Expr)
+		return !ok, 0
+	case domain.Or:
+		ok, score := false, 0.0
+		for _, sub := range e.Exprs {
+			if o, s := m.match(doc, sub); o {
+				ok, score = true, score+s
+			}
+		}
+		return ok, score
+	case domain.And:
+		hasShould, shouldOk, score := false, false, 0.0
+		for _, sub := range e.Exprs {
+			o, s := m.match(doc, sub)
+			if sub.Scoring() {
+				hasShould = true
+				shouldOk = shouldOk || o
+			} else if !o {
+				return false, 0
+			}
+			if o {
+				score += s
+			}
+		}
+		return !hasShould || shouldOk, score
+	default:
+		return false, 0
+	}
+}
+
+// matchField 权重为 0 的字段按照 1 计算，避免命中了却没有分数
+func (m matcher) matchField(doc *document, f engine.Field, term domain.Term) (bool, float64) {
+	boost := float64(max(f.Boost, 1))
+	values := doc.fields[f.Name]
+	switch {
+	case f.IsTerm:
+		if !slices.Contains(values, term.Keyword) {
+			return false, 0
+		}
+		return true, boost * m.ix.idf(len(m.ix.values[f.Name][term.Keyword]))
+	case term.Phrase:
+		phrase := string(lowerRunes(term.Keyword))
+		if !slices.ContainsFunc(values, func(v string) bool {
+			return strings.Contains(string(lowerRunes(v)), phrase)
+		}) {
+			return false, 0
+		}
+		score := 0.0
+		for _, t := range queryTokens(term.Keyword) {
+			score += boost * m.ix.idf(len(m.ix.postings[f.Name][t]))
+		}
+		return true, score
+	default:
+		ok, score := false, 0.0
+		for _, t := range queryTokens(term.Keyword) {
+			docs := m.ix.postings[f.Name][t]
+			tf := float64(docs[doc.docID])
+			if tf == 0 {
+				continue
+			}
+			ok = true
+			score += boost * m.ix.idf(len(docs)) * tf / (tf + 1.2)
+		}
+		return ok, score
+	}
+}
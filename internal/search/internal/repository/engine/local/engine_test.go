@@ -0,0 +1,307 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDoc struct {
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Labels  []string `json:"labels"`
+	Content string   `json:"content"`
+	Answer  struct {
+		Advanced struct {
+			Content string `json:"content"`
+		} `json:"advanced"`
+	} `json:"answer"`
+	Status uint8 `json:"status"`
+	Utime  int64 `json:"utime"`
+}
+
+const testIndex = "test_index"
+
+var testFields = map[string]engine.Field{
+	"title":   {Name: "title", Boost: 10},
+	"labels":  {Name: "labels", Boost: 5, IsTerm: true},
+	"content": {Name: "content", Boost: 1},
+	"answer.advanced.content": {
+		Name:  "answer.advanced.content",
+		Level: domain.LevelAdvanced,
+	},
+}
+
+func newTestEngine(t *testing.T) *Engine {
+	e := NewEngine()
+	docs := []testDoc{
+		{Id: 1, Title: "Redis 分布式锁", Labels: []string{"redis"}, Content: "使用 SETNX 实现", Status: 2, Utime: 100},
+		{Id: 2, Title: "MySQL 索引", Labels: []string{"mysql"}, Content: "B+ 树和分布式事务", Status: 2, Utime: 200},
+		{Id: 3, Title: "etcd 分布式锁", Labels: []string{"etcd"}, Content: "基于租约，比 redis 更可靠", Status: 2, Utime: 300},
+		{Id: 4, Title: "Redis 未发布", Labels: []string{"redis"}, Status: 1, Utime: 400},
+	}
+	docs[1].Answer.Advanced.Content = "聚簇索引"
+	for _, d := range docs {
+		data, err := json.Marshal(d)
+		require.NoError(t, err)
+		err = e.Index(context.Background(), testIndex, string(rune('0'+d.Id)), string(data))
+		require.NoError(t, err)
+	}
+	return e
+}
+
+func TestEngine_Search(t *testing.T) {
+	e := newTestEngine(t)
+	published := []engine.Filter{{Field: "status", Value: 2}}
+	testCases := []struct {
+		name    string
+		query   domain.Query
+		filters []engine.Filter
+		offset  int
+		limit   int
+
+		wantIds   []int64
+		wantTotal int64
+	}{
+		{
+			name:      "没有条件",
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{1, 2, 3},
+			wantTotal: 3,
+		},
+		{
+			name:      "标题权重更高",
+			query:     domain.Query{Expr: domain.Term{Keyword: "redis"}},
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "汉字",
+			query:     domain.Query{Expr: domain.Term{Keyword: "分布式锁"}},
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{1, 3, 2},
+			wantTotal: 3,
+		},
+		{
+			name:      "短语",
+			query:     domain.Query{Expr: domain.Term{Keyword: "分布式锁", Phrase: true}},
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name: "排除和标签",
+			query: domain.Query{Expr: domain.And{Exprs: []domain.Expr{
+				domain.Term{Keyword: "分布式"},
+				domain.Not{Expr: domain.Label{Label: "etcd"}},
+			}}},
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{1, 2},
+			wantTotal: 2,
+		},
+		{
+			name: "OR",
+			query: domain.Query{Expr: domain.Or{Exprs: []domain.Expr{
+				domain.Term{Col: "title", Keyword: "mysql"},
+				domain.Term{Col: "title", Keyword: "etcd"},
+			}}},
+			filters:   published,
+			limit:     10,
+			wantIds:   []int64{2, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "更新时间",
+			query:     domain.Query{Expr: domain.Updated{Start: 200, End: 400}},
+			limit:     10,
+			wantIds:   []int64{2, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "级别",
+			query:     domain.Query{Level: domain.LevelAdvanced, Expr: domain.Term{Keyword: "索引"}},
+			limit:     10,
+			wantIds:   []int64{2},
+			wantTotal: 1,
+		},
+		{
+			name:      "没有对应级别的字段",
+			query:     domain.Query{Level: domain.LevelBasic},
+			limit:     10,
+			wantIds:   []int64{},
+			wantTotal: 0,
+		},
+		{
+			name:      "分页",
+			filters:   published,
+			offset:    1,
+			limit:     1,
+			wantIds:   []int64{2},
+			wantTotal: 3,
+		},
+		{
+			name:      "负数的分页参数",
+			filters:   published,
+			offset:    -1,
+			limit:     -1,
+			wantIds:   []int64{},
+			wantTotal: 3,
+		},
+		{
+			name:      "offset 超过总数",
+			filters:   published,
+			offset:    10,
+			limit:     1 << 40,
+			wantIds:   []int64{},
+			wantTotal: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := e.Search(context.Background(), engine.SearchReq{
+				Index:   testIndex,
+				Query:   tc.query,
+				Fields:  testFields,
+				Filters: tc.filters,
+				Offset:  tc.offset,
+				Limit:   tc.limit,
+			})
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(resp.Hits))
+			for _, hit := range resp.Hits {
+				var doc testDoc
+				require.NoError(t, json.Unmarshal(hit.Source, &doc))
+				ids = append(ids, doc.Id)
+				assert.LessOrEqual(t, hit.Score, resp.MaxScore)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantTotal, resp.Total)
+		})
+	}
+}
+
+func TestEngine_Highlight(t *testing.T) {
+	e := newTestEngine(t)
+	resp, err := e.Search(context.Background(), engine.SearchReq{
+		Index: testIndex,
+		Query: domain.Query{Expr: domain.And{Exprs: []domain.Expr{
+			domain.Term{Keyword: "redis 分布式"},
+			domain.Not{Expr: domain.Term{Keyword: "setnx"}},
+		}}},
+		Fields:  testFields,
+		Filters: []engine.Filter{{Field: "status", Value: 2}},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	// 排除的关键字不会高亮，命中的汉字词合并在一起
+	highlights := make(map[int64]map[string][]string, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		var doc testDoc
+		require.NoError(t, json.Unmarshal(hit.Source, &doc))
+		highlights[doc.Id] = hit.Highlights
+	}
+	assert.Equal(t, map[int64]map[string][]string{
+		2: {
+			"content": {"B+ 树和<em>分布式</em>事务"},
+		},
+		3: {
+			"title":   {"etcd <em>分布式</em>锁"},
+			"content": {"基于租约，比 <em>redis</em> 更可靠"},
+		},
+	}, highlights)
+}
+
+func TestEngine_Reindex(t *testing.T) {
+	e := newTestEngine(t)
+	err := e.Index(context.Background(), testIndex, "1", `{"id":1,"title":"Kafka 消息","status":2}`)
+	require.NoError(t, err)
+	resp, err := e.Search(context.Background(), engine.SearchReq{
+		Index:  testIndex,
+		Query:  domain.Query{Expr: domain.Term{Keyword: "redis"}},
+		Fields: testFields,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		var doc testDoc
+		require.NoError(t, json.Unmarshal(hit.Source, &doc))
+		ids = append(ids, doc.Id)
+	}
+	assert.Equal(t, []int64{4, 3}, ids)
+}
+
+func TestEngine_Suggest(t *testing.T) {
+	e := newTestEngine(t)
+	testCases := []struct {
+		name    string
+		prefix  string
+		wantIds []int64
+	}{
+		{
+			name:    "标题前缀",
+			prefix:  "Red",
+			wantIds: []int64{1},
+		},
+		{
+			name:    "多个词都要命中",
+			prefix:  "redis 分布",
+			wantIds: []int64{1},
+		},
+		{
+			name:    "标签",
+			prefix:  "mys",
+			wantIds: []int64{2},
+		},
+		{
+			name:    "没有命中",
+			prefix:  "kafka",
+			wantIds: []int64{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, err := e.Suggest(context.Background(), engine.SuggestReq{
+				Indexes: []string{testIndex},
+				Prefix:  tc.prefix,
+				Fields:  []engine.SuggestField{{Name: "title", Boost: 3}, {Name: "labels", Boost: 1}},
+				Filters: []engine.Filter{{Field: "status", Value: uint8(2), AllowMissing: true}},
+				Limit:   10,
+			})
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(hits))
+			for _, hit := range hits {
+				var doc testDoc
+				require.NoError(t, json.Unmarshal(hit.Source, &doc))
+				ids = append(ids, doc.Id)
+				assert.Equal(t, testIndex, hit.Index)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
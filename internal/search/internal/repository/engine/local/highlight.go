@@ -0,0 +1,168 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+)
+
+// highlighter 高亮搜索表达式里面的关键字，排除的关键字不会高亮
+type highlighter struct {
+	// fields 已经按照级别过滤过了
+	fields map[string]engine.Field
+	// needles 字段 => 需要高亮的内容
+	needles map[string][]needle
+}
+
+type needle struct {
+	text []rune
+	// exact 为 true 代表整个值都要相等，用于精确匹配的字段
+	exact bool
+	// word 为 true 代表前后不能是字母或者数字，避免 redis 高亮了 redisson 的一部分
+	word bool
+}
+
+func (h *highlighter) collect(expr domain.Expr, negated bool) {
+	switch e := expr.(type) {
+	case domain.Term:
+		if negated {
+			return
+		}
+		for _, f := range (matcher{fields: h.fields}).termFields(e) {
+			h.add(f, e)
+		}
+	case domain.Not:
+		h.collect(e.Expr, !negated)
+	case domain.Or:
+		for _, sub := range e.Exprs {
+			h.collect(sub, negated)
+		}
+	case domain.And:
+		for _, sub := range e.Exprs {
+			h.collect(sub, negated)
+		}
+	}
+}
+
+func (h *highlighter) add(f engine.Field, term domain.Term) {
+	if h.needles == nil {
+		h.needles = make(map[string][]needle)
+	}
+	switch {
+	case f.IsTerm:
+		h.needles[f.Name] = append(h.needles[f.Name], needle{text: []rune(term.Keyword), exact: true})
+	case term.Phrase:
+		h.needles[f.Name] = append(h.needles[f.Name], needle{text: lowerRunes(term.Keyword)})
+	default:
+		for _, t := range queryTokens(term.Keyword) {
+			text := []rune(t)
+			h.needles[f.Name] = append(h.needles[f.Name], needle{text: text, word: !isHan(text[0])})
+		}
+	}
+}
+
+func (h *highlighter) highlight(doc *document) map[string][]string {
+	var res map[string][]string
+	for name, needles := range h.needles {
+		var frags []string
+		for _, v := range doc.fields[name] {
+			if len(frags) >= engine.HighlightFragments {
+				break
+			}
+			frags = append(frags, fragments(v, needles, engine.HighlightFragments-len(frags))...)
+		}
+		if len(frags) > 0 {
+			if res == nil {
+				res = make(map[string][]string)
+			}
+			res[name] = frags
+		}
+	}
+	return res
+}
+
+type span struct {
+	start, end int
+}
+
+// fragments 找到所有命中的位置，然后按照 HighlightFragmentSize 切成片段
+func fragments(value string, needles []needle, limit int) []string {
+	text := []rune(value)
+	lower := lowerRunes(value)
+	var spans []span
+	for _, n := range needles {
+		if n.exact {
+			if value == string(n.text) {
+				spans = append(spans, span{start: 0, end: len(text)})
+			}
+			continue
+		}
+		for i := 0; i+len(n.text) <= len(lower); i++ {
+			if !slices.Equal(lower[i:i+len(n.text)], n.text) {
+				continue
+			}
+			end := i + len(n.text)
+			if n.word && (i > 0 && isWordRune(lower[i-1]) || end < len(lower) && isWordRune(lower[end])) {
+				continue
+			}
+			spans = append(spans, span{start: i, end: end})
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	spans = merge(spans)
+
+	var res []string
+	for i := 0; i < len(spans) && len(res) < limit; {
+		// 片段尽量从第一个命中的位置开始，放不下的时候往前挪
+		from := max(0, min(spans[i].start, len(text)-engine.HighlightFragmentSize))
+		to := min(len(text), from+engine.HighlightFragmentSize)
+		var sb strings.Builder
+		pos := from
+		for ; i < len(spans) && spans[i].start < to; i++ {
+			end := min(spans[i].end, to)
+			sb.WriteString(string(text[pos:spans[i].start]))
+			sb.WriteString(engine.HighlightPreTag)
+			sb.WriteString(string(text[spans[i].start:end]))
+			sb.WriteString(engine.HighlightPostTag)
+			pos = end
+		}
+		sb.WriteString(string(text[pos:to]))
+		res = append(res, sb.String())
+	}
+	return res
+}
+
+// merge 合并重叠或者相邻的位置，例如汉字的两字词 "分布" 和 "布式" 合并成 "分布式"
+func merge(spans []span) []span {
+	slices.SortFunc(spans, func(a, b span) int {
+		return a.start - b.start
+	})
+	res := spans[:1]
+	for _, s := range spans[1:] {
+		last := &res[len(res)-1]
+		if s.start <= last.end {
+			last.end = max(last.end, s.end)
+			continue
+		}
+		res = append(res, s)
+	}
+	return res
+}
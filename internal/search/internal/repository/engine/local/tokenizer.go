@@ -0,0 +1,121 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"unicode"
+)
+
+// 分词规则：
+// 连续的字母、数字和下划线是一个词，统一转成小写；
+// 汉字没有天然的分隔，建索引的时候按照单字和相邻两个字切分，
+// 查询的时候只用相邻两个字，这样 "分布式锁" 不会命中只提到 "分" 和 "锁" 的文档，
+// 而查询只有一个汉字的时候依旧能够通过单字命中。
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize 建索引的时候使用
+func tokenize(text string) []string {
+	return split(text, true)
+}
+
+// queryTokens 查询的时候使用
+func queryTokens(text string) []string {
+	return split(text, false)
+}
+
+func split(text string, unigram bool) []string {
+	var (
+		res  []string
+		word []rune
+		han  []rune
+	)
+	flushWord := func() {
+		if len(word) > 0 {
+			res = append(res, string(word))
+			word = word[:0]
+		}
+	}
+	flushHan := func() {
+		switch {
+		case len(han) == 1:
+			res = append(res, string(han))
+		case len(han) > 1:
+			for i := range han {
+				if unigram {
+					res = append(res, string(han[i]))
+				}
+				if i+1 < len(han) {
+					res = append(res, string(han[i:i+2]))
+				}
+			}
+		}
+		han = han[:0]
+	}
+	for _, r := range text {
+		switch {
+		case isHan(r):
+			flushWord()
+			han = append(han, r)
+		case isWordRune(r):
+			flushHan()
+			word = append(word, unicode.ToLower(r))
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+	return res
+}
+
+// suggestTokens 搜索建议使用的切分，和 mapping 里面的 autocomplete_tokenizer 保持一致
+// 汉字也是字母，所以连续的汉字是一个整体，只是按照前缀匹配
+func suggestTokens(text string) []string {
+	var (
+		res  []string
+		word []rune
+	)
+	for _, r := range text {
+		if isWordRune(r) || r == '-' || r == '.' {
+			word = append(word, unicode.ToLower(r))
+			continue
+		}
+		if len(word) > 0 {
+			res = append(res, string(word))
+			word = word[:0]
+		}
+	}
+	if len(word) > 0 {
+		res = append(res, string(word))
+	}
+	return res
+}
+
+// lowerRunes 逐个字符转小写，保证和原文的下标一一对应，用于高亮
+func lowerRunes(text string) []rune {
+	res := []rune(text)
+	for i, r := range res {
+		res[i] = unicode.ToLower(r)
+	}
+	return res
+}
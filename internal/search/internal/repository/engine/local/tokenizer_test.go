@@ -0,0 +1,66 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		text      string
+		wantIndex []string
+		wantQuery []string
+	}{
+		{
+			name:      "英文和数字",
+			text:      "Redis 6.0 read_write",
+			wantIndex: []string{"redis", "6", "0", "read_write"},
+			wantQuery: []string{"redis", "6", "0", "read_write"},
+		},
+		{
+			name:      "汉字",
+			text:      "分布式锁",
+			wantIndex: []string{"分", "分布", "布", "布式", "式", "式锁", "锁"},
+			wantQuery: []string{"分布", "布式", "式锁"},
+		},
+		{
+			name:      "单个汉字",
+			text:      "锁",
+			wantIndex: []string{"锁"},
+			wantQuery: []string{"锁"},
+		},
+		{
+			name:      "中英文混合",
+			text:      "Redis的锁，MySQL",
+			wantIndex: []string{"redis", "的", "的锁", "锁", "mysql"},
+			wantQuery: []string{"redis", "的锁", "mysql"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantIndex, tokenize(tc.text))
+			assert.Equal(t, tc.wantQuery, queryTokens(tc.text))
+		})
+	}
+}
+
+func TestSuggestTokens(t *testing.T) {
+	assert.Equal(t, []string{"test_title", "分布式锁", "v1.0-beta"},
+		suggestTokens("Test_Title 分布式锁，v1.0-beta"))
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+)
+
+// Engine 搜索引擎的抽象，和具体的实现无关
+// 目前有 elastic 的实现，以及一个进程内的倒排索引实现，后者用于开发环境和 CI
+type Engine interface {
+	// Index 写入文档，文档已经存在的话就覆盖，doc 是 JSON
+	Index(ctx context.Context, index, docID, doc string) error
+	// Delete 删除文档，文档不存在的时候不会返回错误
+	Delete(ctx context.Context, index, docID string) error
+	// Search 按照分数降序，分数相同的按照 id 升序排序，同时返回命中字段的高亮片段
+	Search(ctx context.Context, req SearchReq) (SearchResp, error)
+	// Suggest 根据前缀给出搜索建议，只匹配 req.Fields 里面的字段
+	Suggest(ctx context.Context, req SuggestReq) ([]Hit, error)
+
+	// 下面是重建索引用到的方法，读写的时候 index 既可以是索引，也可以是别名
+
+	// CreateIndex 创建索引，mapping 只有 elastic 的实现用到
+	CreateIndex(ctx context.Context, index, mapping string) error
+	DeleteIndex(ctx context.Context, index string) error
+	// ResolveAlias 返回别名指向的索引，name 不是别名的时候原样返回
+	ResolveAlias(ctx context.Context, name string) (string, error)
+	// SwapAlias 原子地把别名指向 index，返回原本指向的索引
+	// 如果原本存在一个和别名同名的索引，这个索引会被删掉，返回空字符串
+	SwapAlias(ctx context.Context, alias, index string) (string, error)
+	// Scan 遍历索引里面的全部文档，只有 Hit.Id 和 Hit.Source 是有意义的
+	Scan(ctx context.Context, index string, fn func(hit Hit) error) error
+}
+
+// 所有索引都有的字段，搜索表达式里面的 label 和 updated 用到了这些字段
+const (
+	IdField     = "id"
+	LabelsField = "labels"
+	UtimeField  = "utime"
+)
+
+// Field 关键字可以搜索的字段
+type Field struct {
+	// 字段名，嵌套的字段用 . 分隔，例如 answer.basic.keywords
+	Name string
+	// 权重
+	Boost int
+	// 是否是精确匹配
+	IsTerm bool
+	// 所属的级别，例如题目的高级回答，为空代表不区分级别
+	Level string
+}
+
+// Filter 精确匹配的过滤条件
+type Filter struct {
+	Field string
+	Value any
+	// Values 不为空的时候等于其中任意一个即可，忽略 Value
+	Values []any
+	// AllowMissing 为 true 的时候，没有这个字段的文档也满足条件
+	AllowMissing bool
+}
+
+type SearchReq struct {
+	Index string
+	Query domain.Query
+	// Fields 关键字可以搜索的字段，key 是字段名
+	Fields  map[string]Field
+	Filters []Filter
+	Offset  int
+	Limit   int
+}
+
+type Hit struct {
+	// Index 文档所在的索引，通过别名查询的时候是真实的索引名字
+	Index  string
+	Id     string
+	Source json.RawMessage
+	Score  float64
+	// Highlights 命中的字段和高亮片段，key 是字段名，命中的部分用 HighlightPreTag 和 HighlightPostTag 包起来
+	Highlights map[string][]string
+}
+
+type SearchResp struct {
+	Hits []Hit
+	// Total 命中的总数，不受分页影响
+	Total int64
+	// MaxScore 所有命中的结果里面最高的分数，不受分页影响
+	MaxScore float64
+}
+
+type SuggestField struct {
+	Name  string
+	Boost int
+}
+
+type SuggestReq struct {
+	Indexes []string
+	// Prefix 用户正在输入的内容，按照空格切开之后每一部分都要命中
+	Prefix  string
+	Fields  []SuggestField
+	Filters []Filter
+	Limit   int
+}
+
+const (
+	HighlightPreTag  = "<em>"
+	HighlightPostTag = "</em>"
+	// 每个字段最多返回的片段数量和片段长度
+	HighlightFragments    = 3
+	HighlightFragmentSize = 100
+)
@@ -0,0 +1,52 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type indexRepo struct {
+	dao dao.IndexDAO
+}
+
+func NewIndexRepo(dao dao.IndexDAO) IndexRepo {
+	return &indexRepo{
+		dao: dao,
+	}
+}
+
+func (i *indexRepo) CreateVersion(ctx context.Context, alias string) (string, error) {
+	return i.dao.CreateVersion(ctx, alias)
+}
+
+func (i *indexRepo) Delete(ctx context.Context, index string) error {
+	return i.dao.Delete(ctx, index)
+}
+
+func (i *indexRepo) Current(ctx context.Context, alias string) (string, error) {
+	return i.dao.Current(ctx, alias)
+}
+
+func (i *indexRepo) SwapAlias(ctx context.Context, alias, index string) (string, error) {
+	return i.dao.SwapAlias(ctx, alias, index)
+}
+
+func (i *indexRepo) Scan(ctx context.Context, index string, fn func(docID string, data json.RawMessage) error) error {
+	return i.dao.Scan(ctx, index, fn)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type projectRepository struct {
+	projectDao dao.ProjectDAO
+}
+
+func NewProjectRepo(projectDao dao.ProjectDAO) ProjectRepo {
+	return &projectRepository{
+		projectDao: projectDao,
+	}
+}
+
+func (p *projectRepository) SearchProject(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Project], error) {
+	hits, err := p.projectDao.SearchProject(ctx, offset, limit, query)
+	if err != nil {
+		return domain.Hits[domain.Project]{}, err
+	}
+	return toDomainHits(hits, p.toDomain), nil
+}
+
+func (*projectRepository) toDomain(p dao.Project) domain.Project {
+	return domain.Project{
+		Id:     p.Id,
+		Title:  p.Title,
+		Desc:   p.Desc,
+		Labels: p.Labels,
+		Status: p.Status,
+		Utime:  time.UnixMilli(p.Utime),
+	}
+}
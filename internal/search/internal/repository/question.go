@@ -31,22 +31,20 @@ func NewQuestionRepo(questionDao dao.QuestionDAO) QuestionRepo {
 	}
 }
 
-func (q *questionRepository) SearchQuestion(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Question, error) {
-	ques, err := q.questionDao.SearchQuestion(ctx, offset, limit, queryMetas)
+func (q *questionRepository) SearchQuestion(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Question], error) {
+	hits, err := q.questionDao.SearchQuestion(ctx, offset, limit, query)
 	if err != nil {
-		return nil, err
+		return domain.Hits[domain.Question]{}, err
 	}
-	ans := make([]domain.Question, 0, len(ques))
-	for _, qu := range ques {
-		ans = append(ans, q.questionToDomain(qu))
-	}
-	return ans, nil
+	return toDomainHits(hits, q.questionToDomain), nil
 }
 
 func (q *questionRepository) questionToDomain(que dao.Question) domain.Question {
 	return domain.Question{
 		ID:      que.ID,
 		UID:     que.UID,
+		Biz:     que.Biz,
+		BizId:   que.BizId,
 		Title:   que.Title,
 		Labels:  que.Labels,
 		Content: que.Content,
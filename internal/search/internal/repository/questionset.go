@@ -31,17 +31,12 @@ func NewQuestionSetRepo(questionSetDao dao.QuestionSetDAO) QuestionSetRepo {
 		qsDao: questionSetDao,
 	}
 }
-func (q *questionSetRepo) SearchQuestionSet(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.QuestionSet, error) {
-	sets, err := q.qsDao.SearchQuestionSet(ctx, offset, limit, queryMetas)
+func (q *questionSetRepo) SearchQuestionSet(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.QuestionSet], error) {
+	hits, err := q.qsDao.SearchQuestionSet(ctx, offset, limit, query)
 	if err != nil {
-		return nil, err
+		return domain.Hits[domain.QuestionSet]{}, err
 	}
-	ans := make([]domain.QuestionSet, 0, len(sets))
-	for _, set := range sets {
-		ans = append(ans, q.toDomain(set))
-	}
-
-	return ans, nil
+	return toDomainHits(hits, q.toDomain), nil
 }
 
 func (*questionSetRepo) toDomain(qs dao.QuestionSet) domain.QuestionSet {
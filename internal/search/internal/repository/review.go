@@ -0,0 +1,57 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type reviewRepository struct {
+	reviewDao dao.ReviewDAO
+}
+
+func NewReviewRepo(reviewDao dao.ReviewDAO) ReviewRepo {
+	return &reviewRepository{
+		reviewDao: reviewDao,
+	}
+}
+
+func (r *reviewRepository) SearchReview(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Review], error) {
+	hits, err := r.reviewDao.SearchReview(ctx, offset, limit, query)
+	if err != nil {
+		return domain.Hits[domain.Review]{}, err
+	}
+	return toDomainHits(hits, r.toDomain), nil
+}
+
+func (*reviewRepository) toDomain(r dao.Review) domain.Review {
+	return domain.Review{
+		Id:               r.Id,
+		Uid:              r.Uid,
+		Title:            r.Title,
+		Desc:             r.Desc,
+		Labels:           r.Labels,
+		JD:               r.JD,
+		JDAnalysis:       r.JDAnalysis,
+		Questions:        r.Questions,
+		QuestionAnalysis: r.QuestionAnalysis,
+		Resume:           r.Resume,
+		Status:           r.Status,
+		Utime:            time.UnixMilli(r.Utime),
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type roadmapRepository struct {
+	roadmapDao dao.RoadmapDAO
+}
+
+func NewRoadmapRepo(roadmapDao dao.RoadmapDAO) RoadmapRepo {
+	return &roadmapRepository{
+		roadmapDao: roadmapDao,
+	}
+}
+
+func (r *roadmapRepository) SearchRoadmap(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Roadmap], error) {
+	hits, err := r.roadmapDao.SearchRoadmap(ctx, offset, limit, query)
+	if err != nil {
+		return domain.Hits[domain.Roadmap]{}, err
+	}
+	return toDomainHits(hits, r.toDomain), nil
+}
+
+func (*roadmapRepository) toDomain(r dao.Roadmap) domain.Roadmap {
+	return domain.Roadmap{
+		Id:       r.Id,
+		Title:    r.Title,
+		Biz:      r.Biz,
+		BizId:    r.BizId,
+		BizTitle: r.BizTitle,
+		Utime:    time.UnixMilli(r.Utime),
+	}
+}
@@ -32,16 +32,12 @@ func NewSKillRepo(skillDao dao.SkillDAO) SkillRepo {
 	}
 }
 
-func (s *skillRepo) SearchSkill(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Skill, error) {
-	skillList, err := s.skillDao.SearchSkill(ctx, offset, limit, queryMetas)
+func (s *skillRepo) SearchSkill(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Skill], error) {
+	hits, err := s.skillDao.SearchSkill(ctx, offset, limit, query)
 	if err != nil {
-		return nil, err
+		return domain.Hits[domain.Skill]{}, err
 	}
-	ans := make([]domain.Skill, 0, len(skillList))
-	for _, sk := range skillList {
-		ans = append(ans, s.toSkillDomain(sk))
-	}
-	return ans, nil
+	return toDomainHits(hits, s.toSkillDomain), nil
 }
 
 func (sk *skillRepo) toSkillDomain(s dao.Skill) domain.Skill {
@@ -0,0 +1,64 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+)
+
+type suggestRepository struct {
+	dao dao.SuggestDAO
+}
+
+func NewSuggestRepo(dao dao.SuggestDAO) SuggestRepo {
+	return &suggestRepository{
+		dao: dao,
+	}
+}
+
+func (s *suggestRepository) Suggest(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	suggestions, err := s.dao.Suggest(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Suggestion, 0, len(suggestions))
+	for _, sg := range suggestions {
+		res = append(res, domain.Suggestion{
+			Biz:   sg.Biz,
+			Id:    sg.Id,
+			Title: sg.Title,
+			Label: s.matchedLabel(prefix, sg),
+		})
+	}
+	return res, nil
+}
+
+// matchedLabel 标题没有命中的时候，找出命中的标签
+func (s *suggestRepository) matchedLabel(prefix string, sg dao.Suggestion) string {
+	prefix = strings.ToLower(prefix)
+	if strings.Contains(strings.ToLower(sg.Title), prefix) {
+		return ""
+	}
+	for _, label := range sg.Labels {
+		if strings.HasPrefix(strings.ToLower(label), prefix) {
+			return label
+		}
+	}
+	return ""
+}
@@ -16,25 +16,85 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
 )
 
 type CaseRepo interface {
-	SearchCase(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Case, error)
+	SearchCase(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Case], error)
 }
 
 type QuestionRepo interface {
-	SearchQuestion(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Question, error)
+	SearchQuestion(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Question], error)
 }
 type QuestionSetRepo interface {
-	SearchQuestionSet(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.QuestionSet, error)
+	SearchQuestionSet(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.QuestionSet], error)
 }
 
 type SkillRepo interface {
-	SearchSkill(ctx context.Context, offset, limit int, queryMetas []domain.QueryMeta) ([]domain.Skill, error)
+	SearchSkill(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Skill], error)
+}
+
+type ProjectRepo interface {
+	SearchProject(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Project], error)
+}
+
+type ReviewRepo interface {
+	SearchReview(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Review], error)
+}
+
+type RoadmapRepo interface {
+	SearchRoadmap(ctx context.Context, offset, limit int, query domain.Query) (domain.Hits[domain.Roadmap], error)
+}
+
+type SuggestRepo interface {
+	// Suggest 根据用户正在输入的前缀，给出搜索建议
+	Suggest(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error)
 }
 
 type AnyRepo interface {
 	Input(ctx context.Context, index string, docID string, data string) error
+	Delete(ctx context.Context, index string, docID string) error
+}
+
+// IndexRepo 管理索引和别名，用于重建索引和一致性检查
+type IndexRepo interface {
+	// CreateVersion 按照 alias 的 mapping 创建一个新版本的索引，返回索引的名字
+	CreateVersion(ctx context.Context, alias string) (string, error)
+	Delete(ctx context.Context, index string) error
+	// Current 别名当前指向的索引
+	Current(ctx context.Context, alias string) (string, error)
+	// SwapAlias 原子地切换别名指向的索引，返回原本指向的索引
+	SwapAlias(ctx context.Context, alias, index string) (string, error)
+	// Scan 遍历索引里面的全部文档
+	Scan(ctx context.Context, index string, fn func(docID string, data json.RawMessage) error) error
+}
+
+func toDomainHits[S, D any](hits dao.SearchHits[S], toDomain func(S) D) domain.Hits[D] {
+	docs := make([]D, 0, len(hits.Docs))
+	for _, doc := range hits.Docs {
+		docs = append(docs, toDomain(doc))
+	}
+	return domain.Hits[D]{
+		Docs:       docs,
+		Scores:     hits.Scores,
+		Highlights: hits.Highlights,
+		Total:      hits.Total,
+		MaxScore:   hits.MaxScore,
+	}
+}
+
+type AnalyticsRepo interface {
+	SaveQueryLog(ctx context.Context, log domain.QueryLog) error
+	SaveClick(ctx context.Context, click domain.Click) error
+	FindQueryLog(ctx context.Context, sid string) (domain.QueryLog, error)
+	TopQueries(ctx context.Context, start, end time.Time, limit int) ([]domain.QueryStat, error)
+	ZeroResultQueries(ctx context.Context, start, end time.Time, limit int) ([]domain.QueryStat, error)
+	LowClickThroughQueries(ctx context.Context, start, end time.Time, minSearches int64, limit int) ([]domain.QueryStat, error)
+	FindQueryLogsByUid(ctx context.Context, uid int64) ([]domain.QueryLog, error)
+	FindClicksByUid(ctx context.Context, uid int64) ([]domain.Click, error)
+	DeleteByUid(ctx context.Context, uid int64) error
 }
@@ -0,0 +1,71 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+// AccessService 一次性查出用户能打开哪些内容，而不是每条搜索结果查一次
+type AccessService interface {
+	// Access memberDDL 是登录态里面的会员截止时间，没有过期的话就不再查询会员
+	Access(ctx context.Context, uid, memberDDL int64) (domain.Access, error)
+}
+
+type accessSvc struct {
+	memberSvc member.Service
+	permSvc   permission.Service
+}
+
+func NewAccessSvc(memberSvc member.Service, permSvc permission.Service) AccessService {
+	return &accessSvc{memberSvc: memberSvc, permSvc: permSvc}
+}
+
+func (a *accessSvc) Access(ctx context.Context, uid, memberDDL int64) (domain.Access, error) {
+	var (
+		eg  errgroup.Group
+		res domain.Access
+	)
+	now := time.Now().UnixMilli()
+	res.Member = memberDDL > now
+	if !res.Member {
+		eg.Go(func() error {
+			info, err := a.memberSvc.GetMembershipInfo(ctx, uid)
+			// 查不到会员信息就当作不是会员，和案例那边的处理一样
+			res.Member = err == nil && info.EndAt > now
+			return nil
+		})
+	}
+	eg.Go(func() error {
+		ps, err := a.permSvc.FindPersonalPermissions(ctx, uid)
+		if err != nil {
+			return err
+		}
+		res.Permissions = make(map[string][]int64, len(ps))
+		for biz, bizPs := range ps {
+			for _, p := range bizPs {
+				res.Permissions[biz] = append(res.Permissions[biz], p.BizID)
+			}
+		}
+		return nil
+	})
+	return res, eg.Wait()
+}
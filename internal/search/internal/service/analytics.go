@@ -0,0 +1,134 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidClick = errors.New("点击和搜索记录对不上")
+
+// AnalyticsService 搜索分析，帮助内容编辑了解用户搜什么、有没有找到
+type AnalyticsService interface {
+	// Record 保存一次搜索，由消费者调用
+	Record(ctx context.Context, log domain.QueryLog) error
+	// Click 保存一次点击，sid 必须对应这个用户的一次搜索，否则返回 ErrInvalidClick
+	Click(ctx context.Context, click domain.Click) error
+	// TopQueries 搜索次数最多的搜索表达式
+	TopQueries(ctx context.Context, r StatRange) ([]domain.QueryStat, error)
+	// ZeroResultQueries 没有结果的次数最多的搜索表达式，这些往往是需要补充的内容
+	ZeroResultQueries(ctx context.Context, r StatRange) ([]domain.QueryStat, error)
+	// LowClickThroughQueries 点击率最低的搜索表达式，这些往往是结果不符合预期的搜索
+	LowClickThroughQueries(ctx context.Context, r StatRange, minSearches int64) ([]domain.QueryStat, error)
+	// UserQueryLogs 和 UserClicks 用户自己的搜索记录和点击记录
+	UserQueryLogs(ctx context.Context, uid int64) ([]domain.QueryLog, error)
+	UserClicks(ctx context.Context, uid int64) ([]domain.Click, error)
+	// DeleteUserData 注销账号的时候删除用户的搜索记录和点击记录
+	DeleteUserData(ctx context.Context, uid int64) error
+}
+
+const (
+	defaultStatLimit   = 20
+	maxStatLimit       = 100
+	defaultStatPeriod  = 7 * 24 * time.Hour
+	defaultMinSearches = 5
+)
+
+// StatRange 统计 [Start, End) 之间的搜索，零值代表最近七天
+type StatRange struct {
+	Start time.Time
+	End   time.Time
+	Limit int
+}
+
+func (r StatRange) normalize() StatRange {
+	if r.End.IsZero() {
+		r.End = time.Now()
+	}
+	if r.Start.IsZero() {
+		r.Start = r.End.Add(-defaultStatPeriod)
+	}
+	if r.Limit <= 0 {
+		r.Limit = defaultStatLimit
+	}
+	r.Limit = min(r.Limit, maxStatLimit)
+	return r
+}
+
+type analyticsService struct {
+	repo repository.AnalyticsRepo
+}
+
+func NewAnalyticsSvc(repo repository.AnalyticsRepo) AnalyticsService {
+	return &analyticsService{
+		repo: repo,
+	}
+}
+
+func (a *analyticsService) Record(ctx context.Context, log domain.QueryLog) error {
+	return a.repo.SaveQueryLog(ctx, log)
+}
+
+func (a *analyticsService) Click(ctx context.Context, click domain.Click) error {
+	log, err := a.repo.FindQueryLog(ctx, click.Sid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: sid %s 没有搜索记录", ErrInvalidClick, click.Sid)
+	}
+	if err != nil {
+		return err
+	}
+	if !log.Accept(click) {
+		return fmt.Errorf("%w: sid %s", ErrInvalidClick, click.Sid)
+	}
+	click.Ctime = time.Now()
+	return a.repo.SaveClick(ctx, click)
+}
+
+func (a *analyticsService) TopQueries(ctx context.Context, r StatRange) ([]domain.QueryStat, error) {
+	r = r.normalize()
+	return a.repo.TopQueries(ctx, r.Start, r.End, r.Limit)
+}
+
+func (a *analyticsService) ZeroResultQueries(ctx context.Context, r StatRange) ([]domain.QueryStat, error) {
+	r = r.normalize()
+	return a.repo.ZeroResultQueries(ctx, r.Start, r.End, r.Limit)
+}
+
+func (a *analyticsService) LowClickThroughQueries(ctx context.Context, r StatRange, minSearches int64) ([]domain.QueryStat, error) {
+	r = r.normalize()
+	if minSearches <= 0 {
+		minSearches = defaultMinSearches
+	}
+	return a.repo.LowClickThroughQueries(ctx, r.Start, r.End, minSearches, r.Limit)
+}
+
+func (a *analyticsService) UserQueryLogs(ctx context.Context, uid int64) ([]domain.QueryLog, error) {
+	return a.repo.FindQueryLogsByUid(ctx, uid)
+}
+
+func (a *analyticsService) UserClicks(ctx context.Context, uid int64) ([]domain.Click, error) {
+	return a.repo.FindClicksByUid(ctx, uid)
+}
+
+func (a *analyticsService) DeleteUserData(ctx context.Context, uid int64) error {
+	return a.repo.DeleteByUid(ctx, uid)
+}
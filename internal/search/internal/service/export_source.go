@@ -0,0 +1,52 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+)
+
+type exportData struct {
+	QueryLogs []domain.QueryLog
+	Clicks    []domain.Click
+}
+
+// exportSource 导出个人数据的时候读取搜索记录和点击记录
+type exportSource struct {
+	svc AnalyticsService
+}
+
+func NewExportSource(svc AnalyticsService) exportx.Source {
+	return &exportSource{svc: svc}
+}
+
+func (s *exportSource) Name() string {
+	return "search"
+}
+
+func (s *exportSource) Export(ctx context.Context, uid int64) (any, error) {
+	logs, err := s.svc.UserQueryLogs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	clicks, err := s.svc.UserClicks(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return exportData{QueryLogs: logs, Clicks: clicks}, nil
+}
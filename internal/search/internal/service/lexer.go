@@ -0,0 +1,99 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// ParseError 搜索表达式的语法错误，Pos 是出错的字符位置，从 0 开始
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("搜索表达式第 %d 个字符: %s", e.Pos+1, e.Msg)
+}
+
+type tokenKind uint8
+
+const (
+	tokEOF tokenKind = iota
+	// tokWord 普通的单词，也可能是 label:redis 这种限定词
+	tokWord
+	// tokPhrase 双引号括起来的短语
+	tokPhrase
+	// tokQualifier 后面紧跟着短语的限定词，例如 title:"分布式 锁" 里面的 title
+	tokQualifier
+	// tokMinus 排除
+	tokMinus
+	tokOr
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lex 把搜索表达式切割成 token
+func lex(expr string) ([]token, error) {
+	runes := []rune(expr)
+	var tokens []token
+	i := 0
+	for {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			return append(tokens, token{kind: tokEOF, pos: i}), nil
+		}
+		start := i
+		switch {
+		case runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, token{kind: tokMinus, text: "-", pos: start})
+			i++
+		case runes[i] == '"':
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, &ParseError{Pos: start, Msg: "引号没有闭合"}
+			}
+			text := string(runes[start+1 : i])
+			if text == "" {
+				return nil, &ParseError{Pos: start, Msg: "短语不能为空"}
+			}
+			tokens = append(tokens, token{kind: tokPhrase, text: text, pos: start})
+			i++
+		default:
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' {
+				i++
+			}
+			text := string(runes[start:i])
+			switch {
+			case text == "OR":
+				tokens = append(tokens, token{kind: tokOr, text: text, pos: start})
+			case runes[i-1] == ':' && i < len(runes) && runes[i] == '"':
+				tokens = append(tokens, token{kind: tokQualifier, text: string(runes[start : i-1]), pos: start})
+			default:
+				tokens = append(tokens, token{kind: tokWord, text: text, pos: start})
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+)
+
+const bizAll = "all"
+
+// queryParser 解析类似 github 的搜索表达式，语法是：
+//
+//	query   := unit*
+//	unit    := operand ("OR" operand)*
+//	operand := "-"? atom
+//	atom    := WORD | PHRASE | QUALIFIER PHRASE
+//
+// WORD 里面带有冒号的是限定词，支持 biz、level、label、updated，其余的当作字段名，
+// 例如 title:redis 只在标题里面搜索 redis。
+// 兼容早期的 biz:question:redis 写法。
+type queryParser struct {
+	tokens []token
+	idx    int
+	// bizs 合法的业务
+	bizs  map[string]struct{}
+	query domain.Query
+}
+
+func parseQuery(expr string, bizs map[string]struct{}) (domain.Query, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return domain.Query{}, err
+	}
+	p := &queryParser{tokens: tokens, bizs: bizs}
+	return p.parse()
+}
+
+func (p *queryParser) parse() (domain.Query, error) {
+	var exprs []domain.Expr
+	for p.peek().kind != tokEOF {
+		expr, err := p.parseUnit()
+		if err != nil {
+			return domain.Query{}, err
+		}
+		// biz 和 level 不会产生节点
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	switch len(exprs) {
+	case 0:
+	case 1:
+		p.query.Expr = exprs[0]
+	default:
+		p.query.Expr = domain.And{Exprs: exprs}
+	}
+	return p.query, nil
+}
+
+func (p *queryParser) parseUnit() (domain.Expr, error) {
+	if tok := p.peek(); tok.kind == tokOr {
+		return nil, &ParseError{Pos: tok.pos, Msg: "OR 前面缺少表达式"}
+	}
+	first, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokOr {
+		return first, nil
+	}
+	if first == nil {
+		return nil, &ParseError{Pos: p.peek().pos, Msg: "biz 和 level 不能和 OR 一起使用"}
+	}
+	exprs := []domain.Expr{first}
+	for p.peek().kind == tokOr {
+		or := p.next()
+		if k := p.peek().kind; k == tokEOF || k == tokOr {
+			return nil, &ParseError{Pos: or.pos, Msg: "OR 后面缺少表达式"}
+		}
+		expr, err := p.parseOperand(true)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return domain.Or{Exprs: exprs}, nil
+}
+
+// parseOperand inOr 代表当前在 OR 里面
+func (p *queryParser) parseOperand(inOr bool) (domain.Expr, error) {
+	negated := false
+	if tok := p.peek(); tok.kind == tokMinus {
+		p.next()
+		negated = true
+		if k := p.peek().kind; k != tokWord && k != tokPhrase && k != tokQualifier {
+			return nil, &ParseError{Pos: tok.pos, Msg: "- 后面缺少要排除的内容"}
+		}
+	}
+	expr, err := p.parseAtom(negated || inOr)
+	if err != nil || expr == nil || !negated {
+		return expr, err
+	}
+	return domain.Not{Expr: expr}, nil
+}
+
+// parseAtom nested 代表当前在 OR 或者排除里面，这时候不能使用 biz 和 level
+func (p *queryParser) parseAtom(nested bool) (domain.Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokPhrase:
+		return domain.Term{Keyword: tok.text, Phrase: true}, nil
+	case tokQualifier:
+		phrase := p.next()
+		return p.parseQualifier(tok.text, phrase.text, true, tok.pos, phrase.pos, nested)
+	case tokWord:
+		name, val, ok := strings.Cut(tok.text, ":")
+		if !ok {
+			return domain.Term{Keyword: tok.text}, nil
+		}
+		return p.parseQualifier(name, val, false, tok.pos, tok.pos+len([]rune(name))+1, nested)
+	default:
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("不应该出现 %s", tok.text)}
+	}
+}
+
+func (p *queryParser) parseQualifier(name, val string, phrase bool,
+	pos, valPos int, nested bool) (domain.Expr, error) {
+	if name == "" {
+		return nil, &ParseError{Pos: pos, Msg: "冒号前面缺少字段名"}
+	}
+	if name == "biz" || name == "level" {
+		if nested {
+			return nil, &ParseError{Pos: pos, Msg: fmt.Sprintf("%s 不能出现在 OR 或者排除里面", name)}
+		}
+		if name == "biz" {
+			return p.parseBiz(val, valPos)
+		}
+		return nil, p.parseLevel(val, valPos)
+	}
+	if val == "" {
+		return nil, &ParseError{Pos: valPos, Msg: fmt.Sprintf("%s 后面缺少值", name)}
+	}
+	switch name {
+	case "label":
+		return domain.Label{Label: val}, nil
+	case "updated":
+		return p.parseUpdated(val, valPos)
+	default:
+		return domain.Term{Col: name, Keyword: val, Phrase: phrase}, nil
+	}
+}
+
+// parseBiz 支持 biz:question,case，也兼容早期 biz:question:redis 的写法
+func (p *queryParser) parseBiz(val string, pos int) (domain.Expr, error) {
+	bizs, rest, legacy := strings.Cut(val, ":")
+	if bizs == "" {
+		return nil, &ParseError{Pos: pos, Msg: "biz 后面缺少业务"}
+	}
+	for _, biz := range strings.Split(bizs, ",") {
+		if _, ok := p.bizs[biz]; !ok && biz != bizAll {
+			return nil, &ParseError{Pos: pos, Msg: fmt.Sprintf("未知的业务 %s", biz)}
+		}
+		p.query.Biz = append(p.query.Biz, biz)
+		pos += len([]rune(biz)) + 1
+	}
+	if !legacy || rest == "" {
+		return nil, nil
+	}
+	// 剩下的部分当成一个普通的单词重新解析
+	p.idx--
+	p.tokens[p.idx] = token{kind: tokWord, text: rest, pos: pos}
+	return p.parseAtom(false)
+}
+
+func (p *queryParser) parseLevel(val string, pos int) error {
+	switch val {
+	case domain.LevelBasic, domain.LevelIntermediate, domain.LevelAdvanced:
+	default:
+		return &ParseError{Pos: pos, Msg: fmt.Sprintf("未知的级别 %s", val)}
+	}
+	if p.query.Level != "" && p.query.Level != val {
+		return &ParseError{Pos: pos, Msg: "level 只能指定一个"}
+	}
+	p.query.Level = val
+	return nil
+}
+
+// parseUpdated 支持 >2024-01-01、>=2024-01-01、<2024-01-01、<=2024-01-01、
+// 2024-01-01..2024-02-01（两边都可以用 * 代替）以及 2024-01-01 这几种写法
+func (p *queryParser) parseUpdated(val string, pos int) (domain.Expr, error) {
+	const day = 24 * time.Hour
+	if start, end, ok := strings.Cut(val, ".."); ok {
+		var res domain.Updated
+		if start != "*" {
+			t, err := p.parseDate(start, pos)
+			if err != nil {
+				return nil, err
+			}
+			res.Start = t.UnixMilli()
+		}
+		if end != "*" {
+			t, err := p.parseDate(end, pos+len([]rune(start))+2)
+			if err != nil {
+				return nil, err
+			}
+			res.End = t.Add(day).UnixMilli()
+		}
+		return res, nil
+	}
+	ops := []string{">=", "<=", ">", "<"}
+	op := ""
+	for _, o := range ops {
+		if strings.HasPrefix(val, o) {
+			op = o
+			break
+		}
+	}
+	t, err := p.parseDate(val[len(op):], pos+len(op))
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case ">=":
+		return domain.Updated{Start: t.UnixMilli()}, nil
+	case ">":
+		return domain.Updated{Start: t.Add(day).UnixMilli()}, nil
+	case "<=":
+		return domain.Updated{End: t.Add(day).UnixMilli()}, nil
+	case "<":
+		return domain.Updated{End: t.UnixMilli()}, nil
+	default:
+		return domain.Updated{Start: t.UnixMilli(), End: t.Add(day).UnixMilli()}, nil
+	}
+}
+
+func (p *queryParser) parseDate(val string, pos int) (time.Time, error) {
+	t, err := time.ParseInLocation(time.DateOnly, val, time.Local)
+	if err != nil {
+		return time.Time{}, &ParseError{Pos: pos, Msg: fmt.Sprintf("日期 %s 的格式应该是 2006-01-02", val)}
+	}
+	return t, nil
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.idx]
+}
+
+func (p *queryParser) next() token {
+	tok := p.tokens[p.idx]
+	if tok.kind != tokEOF {
+		p.idx++
+	}
+	return tok
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	bizs := map[string]struct{}{
+		"question": {},
+		"case":     {},
+	}
+	day := func(s string) int64 {
+		d, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+		if err != nil {
+			panic(err)
+		}
+		return d.UnixMilli()
+	}
+	testCases := []struct {
+		name    string
+		expr    string
+		wantRes domain.Query
+		wantErr error
+	}{
+		{
+			name:    "空表达式",
+			expr:    "  ",
+			wantRes: domain.Query{},
+		},
+		{
+			name: "单个关键字",
+			expr: "redis",
+			wantRes: domain.Query{
+				Expr: domain.Term{Keyword: "redis"},
+			},
+		},
+		{
+			name: "兼容早期写法",
+			expr: "biz:question:redis title:mysql",
+			wantRes: domain.Query{
+				Biz: []string{"question"},
+				Expr: domain.And{Exprs: []domain.Expr{
+					domain.Term{Keyword: "redis"},
+					domain.Term{Col: "title", Keyword: "mysql"},
+				}},
+			},
+		},
+		{
+			name: "兼容早期写法-all",
+			expr: "biz:all:redis",
+			wantRes: domain.Query{
+				Biz:  []string{"all"},
+				Expr: domain.Term{Keyword: "redis"},
+			},
+		},
+		{
+			name: "多个业务和级别",
+			expr: "biz:question,case level:advanced redis",
+			wantRes: domain.Query{
+				Biz:   []string{"question", "case"},
+				Level: domain.LevelAdvanced,
+				Expr:  domain.Term{Keyword: "redis"},
+			},
+		},
+		{
+			name: "短语、排除、OR 和标签",
+			expr: `"分布式 锁" redis OR etcd -zookeeper label:redis title:"缓存 一致性"`,
+			wantRes: domain.Query{
+				Expr: domain.And{Exprs: []domain.Expr{
+					domain.Term{Keyword: "分布式 锁", Phrase: true},
+					domain.Or{Exprs: []domain.Expr{
+						domain.Term{Keyword: "redis"},
+						domain.Term{Keyword: "etcd"},
+					}},
+					domain.Not{Expr: domain.Term{Keyword: "zookeeper"}},
+					domain.Label{Label: "redis"},
+					domain.Term{Col: "title", Keyword: "缓存 一致性", Phrase: true},
+				}},
+			},
+		},
+		{
+			name: "排除标签",
+			expr: "-label:mysql",
+			wantRes: domain.Query{
+				Expr: domain.Not{Expr: domain.Label{Label: "mysql"}},
+			},
+		},
+		{
+			name: "中间的横线不是排除",
+			expr: "read-write",
+			wantRes: domain.Query{
+				Expr: domain.Term{Keyword: "read-write"},
+			},
+		},
+		{
+			name: "更新时间-大于",
+			expr: "updated:>2024-01-01",
+			wantRes: domain.Query{
+				Expr: domain.Updated{Start: day("2024-01-02")},
+			},
+		},
+		{
+			name: "更新时间-小于等于",
+			expr: "updated:<=2024-01-01",
+			wantRes: domain.Query{
+				Expr: domain.Updated{End: day("2024-01-02")},
+			},
+		},
+		{
+			name: "更新时间-区间",
+			expr: "updated:2024-01-01..2024-01-31",
+			wantRes: domain.Query{
+				Expr: domain.Updated{Start: day("2024-01-01"), End: day("2024-02-01")},
+			},
+		},
+		{
+			name: "更新时间-某一天",
+			expr: "updated:2024-01-01",
+			wantRes: domain.Query{
+				Expr: domain.Updated{Start: day("2024-01-01"), End: day("2024-01-02")},
+			},
+		},
+		{
+			name:    "引号没有闭合",
+			expr:    `redis "分布式`,
+			wantErr: &ParseError{Pos: 6, Msg: "引号没有闭合"},
+		},
+		{
+			name:    "OR 在开头",
+			expr:    "OR redis",
+			wantErr: &ParseError{Pos: 0, Msg: "OR 前面缺少表达式"},
+		},
+		{
+			name:    "OR 在结尾",
+			expr:    "redis OR",
+			wantErr: &ParseError{Pos: 6, Msg: "OR 后面缺少表达式"},
+		},
+		{
+			name:    "未知业务",
+			expr:    "biz:question,skill redis",
+			wantErr: &ParseError{Pos: 13, Msg: "未知的业务 skill"},
+		},
+		{
+			name:    "未知级别",
+			expr:    "level:expert",
+			wantErr: &ParseError{Pos: 6, Msg: "未知的级别 expert"},
+		},
+		{
+			name:    "排除业务",
+			expr:    "-biz:case",
+			wantErr: &ParseError{Pos: 1, Msg: "biz 不能出现在 OR 或者排除里面"},
+		},
+		{
+			name:    "日期格式错误",
+			expr:    "redis updated:>=2024/01/01",
+			wantErr: &ParseError{Pos: 16, Msg: "日期 2024/01/01 的格式应该是 2006-01-02"},
+		},
+		{
+			name:    "缺少值",
+			expr:    "label:",
+			wantErr: &ParseError{Pos: 6, Msg: "label 后面缺少值"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := parseQuery(tc.expr, bizs)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	bizs := map[string]struct{}{
+		"question": {},
+		"case":     {},
+	}
+	testCases := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{name: "多余的空格", expr: "  redis   分布式锁 ", want: "redis 分布式锁"},
+		{name: "业务和级别", expr: "level:advanced redis biz:question", want: "biz:question level:advanced redis"},
+		{name: "短语和字段", expr: `title:"分布式 锁" -label:etcd`, want: `title:"分布式 锁" -label:etcd`},
+		{name: "OR", expr: "redis OR etcd zookeeper", want: "redis OR etcd zookeeper"},
+		{name: "更新时间", expr: "updated:>=2024-01-01", want: "updated:2024-01-01..*"},
+		{name: "某一天", expr: "updated:2024-01-01", want: "updated:2024-01-01..2024-01-01"},
+		{name: "没有条件", expr: "", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := parseQuery(tc.expr, bizs)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, q.String())
+			// 规范化之后的表达式解析出来的结果不变
+			q2, err := parseQuery(q.String(), bizs)
+			assert.NoError(t, err)
+			assert.Equal(t, q, q2)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/repository"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+var (
+	ErrUnknownBiz     = errors.New("没有这个业务的数据来源")
+	ErrReindexRunning = errors.New("索引正在重建")
+	ErrReindexExpired = errors.New("重建索引的标记已经失效")
+)
+
+// ReindexService 从数据的权威来源重建索引，以及检查索引是否和数据来源一致
+type ReindexService interface {
+	// Bizs 可以重建索引的业务
+	Bizs() []string
+	// Reindex 把 biz 的全量数据写入一个新版本的索引，然后原子地切换别名，
+	// 重建期间搜索和同步都不受影响
+	Reindex(ctx context.Context, biz string) error
+	// Check 比较索引和数据来源的文档数量，以及每个文档的哈希
+	Check(ctx context.Context, biz string) (domain.CheckReport, error)
+}
+
+type reindexService struct {
+	sources   map[string]searchx.Source
+	anyRepo   repository.AnyRepo
+	indexRepo repository.IndexRepo
+	journal   *Journal
+	logger    *elog.Component
+}
+
+func NewReindexSvc(sources []searchx.Source,
+	anyRepo repository.AnyRepo,
+	indexRepo repository.IndexRepo,
+	journal *Journal) ReindexService {
+	srcs := make(map[string]searchx.Source, len(sources))
+	for _, src := range sources {
+		srcs[src.Biz()] = src
+	}
+	return &reindexService{
+		sources:   srcs,
+		anyRepo:   anyRepo,
+		indexRepo: indexRepo,
+		journal:   journal,
+		logger:    elog.DefaultLogger,
+	}
+}
+
+func (s *reindexService) Bizs() []string {
+	res := make([]string, 0, len(s.sources))
+	for biz := range s.sources {
+		res = append(res, biz)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (s *reindexService) source(biz string) (searchx.Source, error) {
+	src, ok := s.sources[biz]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownBiz, biz)
+	}
+	return src, nil
+}
+
+func (s *reindexService) Reindex(ctx context.Context, biz string) error {
+	src, err := s.source(biz)
+	if err != nil {
+		return err
+	}
+	alias := domain.IndexName(biz)
+	cursor, err := s.journal.start(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if cursor == nil {
+		return fmt.Errorf("%w %s", ErrReindexRunning, alias)
+	}
+	defer func() {
+		if err := s.journal.stop(ctx, cursor); err != nil {
+			s.logger.Error("结束记录同步消息失败", elog.FieldErr(err), elog.String("alias", alias))
+		}
+	}()
+
+	index, err := s.indexRepo.CreateVersion(ctx, alias)
+	if err != nil {
+		return err
+	}
+	old, err := s.rebuild(ctx, src, cursor, alias, index)
+	if err != nil {
+		// 新的索引还没有被使用过，直接删掉
+		if err1 := s.indexRepo.Delete(ctx, index); err1 != nil {
+			s.logger.Error("删除重建失败的索引失败", elog.FieldErr(err1), elog.String("index", index))
+		}
+		return err
+	}
+	if err = s.catchUp(ctx, cursor, alias, index); err != nil {
+		// 别名已经切换成功了，这时候不能再删除新的索引，没有重放的消息靠检查索引发现
+		s.logger.Error("重放同步消息失败", elog.FieldErr(err), elog.String("index", index))
+	}
+	if old != "" {
+		// 别名已经切换成功了，旧的索引删除失败只记录日志
+		if err = s.indexRepo.Delete(ctx, old); err != nil {
+			s.logger.Error("删除旧的索引失败", elog.FieldErr(err), elog.String("index", old))
+		}
+	}
+	s.logger.Info("重建索引成功", elog.String("alias", alias), elog.String("index", index))
+	return nil
+}
+
+// rebuild 写入全量数据，重放重建期间到达的同步消息，最后切换别名
+func (s *reindexService) rebuild(ctx context.Context, src searchx.Source,
+	cursor *journalCursor, alias, index string) (string, error) {
+	ids, err := src.Ids(ctx)
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 的全部 id 失败 %w", src.Biz(), err)
+	}
+	for _, id := range ids {
+		if err = s.keepAlive(ctx, cursor, index); err != nil {
+			return "", err
+		}
+		doc, err := src.Doc(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("读取 %s %d 失败 %w", src.Biz(), id, err)
+		}
+		err = s.anyRepo.Input(ctx, index, strconv.FormatInt(id, 10), doc)
+		if err != nil {
+			return "", err
+		}
+	}
+	if _, err = s.replay(ctx, cursor, index); err != nil {
+		return "", err
+	}
+	// 切换之前确认标记一直都在，标记不在的那段时间里，同步消息直接写入了旧的索引
+	if err = s.journal.renew(ctx, cursor); err != nil {
+		return "", err
+	}
+	return s.indexRepo.SwapAlias(ctx, alias, index)
+}
+
+// keepAlive 重建的时间比较长的时候，定期续期标记，并且把已经到达的记录重放到新的索引，
+// 避免标记和记录在重建结束之前过期
+func (s *reindexService) keepAlive(ctx context.Context, cursor *journalCursor, index string) error {
+	if time.Since(cursor.renewedAt) < journalRenewInterval {
+		return nil
+	}
+	if err := s.journal.renew(ctx, cursor); err != nil {
+		return err
+	}
+	_, err := s.replay(ctx, cursor, index)
+	return err
+}
+
+// catchUp 其它实例在切换之前写入旧索引的消息，切换之后才会被读到，继续重放到读不到新的记录为止。
+// 重放的时候可能覆盖掉同时直接写入新索引的更新，但是这些更新也被记录了下来，下一轮会再重放一次
+func (s *reindexService) catchUp(ctx context.Context, cursor *journalCursor, alias, index string) error {
+	for i := 0; i < maxReplayRounds; i++ {
+		n, err := s.replay(ctx, cursor, index)
+		if err != nil || n == 0 {
+			return err
+		}
+	}
+	return fmt.Errorf("%s 重建期间同步消息过多，没有重放完", alias)
+}
+
+// maxReplayRounds 切换别名之后最多重放多少轮
+const maxReplayRounds = 10
+
+func (s *reindexService) replay(ctx context.Context, cursor *journalCursor, index string) (int, error) {
+	entries, err := s.journal.drain(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if entry.Data == "" {
+			err = s.anyRepo.Delete(ctx, index, entry.DocID)
+		} else {
+			err = s.anyRepo.Input(ctx, index, entry.DocID, entry.Data)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(entries), nil
+}
+
+func (s *reindexService) Check(ctx context.Context, biz string) (domain.CheckReport, error) {
+	src, err := s.source(biz)
+	if err != nil {
+		return domain.CheckReport{}, err
+	}
+	alias := domain.IndexName(biz)
+	index, err := s.indexRepo.Current(ctx, alias)
+	if err != nil {
+		return domain.CheckReport{}, err
+	}
+	// 索引里面每个文档的哈希
+	hashes := make(map[int64]string)
+	err = s.indexRepo.Scan(ctx, index, func(docID string, data json.RawMessage) error {
+		id, err := strconv.ParseInt(docID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("文档 id %s 不合法 %w", docID, err)
+		}
+		hashes[id], err = docHash(data)
+		return err
+	})
+	if err != nil {
+		return domain.CheckReport{}, err
+	}
+	ids, err := src.Ids(ctx)
+	if err != nil {
+		return domain.CheckReport{}, fmt.Errorf("读取 %s 的全部 id 失败 %w", biz, err)
+	}
+	report := domain.CheckReport{
+		Biz:         biz,
+		Index:       index,
+		SourceCount: int64(len(ids)),
+		IndexCount:  int64(len(hashes)),
+	}
+	for _, id := range ids {
+		hash, ok := hashes[id]
+		if !ok {
+			report.Missing = append(report.Missing, id)
+			continue
+		}
+		delete(hashes, id)
+		doc, err := src.Doc(ctx, id)
+		if err != nil {
+			return domain.CheckReport{}, fmt.Errorf("读取 %s %d 失败 %w", biz, id, err)
+		}
+		srcHash, err := docHash([]byte(doc))
+		if err != nil {
+			return domain.CheckReport{}, err
+		}
+		if srcHash != hash {
+			report.Mismatched = append(report.Mismatched, id)
+		}
+	}
+	for id := range hashes {
+		report.Extra = append(report.Extra, id)
+	}
+	slices.Sort(report.Missing)
+	slices.Sort(report.Mismatched)
+	slices.Sort(report.Extra)
+	return report, nil
+}
+
+// docHash 先把 JSON 规范化，避免字段顺序和空白影响结果
+func docHash(data []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -16,86 +16,233 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 	"github.com/ecodeclub/webook/internal/search/internal/repository"
 	"golang.org/x/sync/errgroup"
 )
 
+var ErrInvalidCursor = errors.New("搜索游标不合法")
+
 type SearchService interface {
-	// Search 出于长远考虑，这里你用 expr 来代表搜索的表达式，后期我们会考虑支持类似 github 那种复杂的搜索表达式
-	Search(ctx context.Context, offset, limit int, expr string) (*domain.SearchResult, error)
+	// Search expr 是类似 github 那种搜索表达式，语法参考 queryParser
+	// 所有业务的结果统一排序之后分页，cursor 为空的时候按照 offset 分页，否则忽略 offset，
+	// 从 cursor 的位置继续往后翻，cursor 来自上一页的 SearchResult.Cursor
+	// access 是当前用户能打开的内容，用来标记每条结果是否锁定，
+	// access.OnlyAccessible 为 true 的时候只返回能打开的结果，此时一页可能不满 limit 条，
+	// Total 和 Counts 也只是近似值，应该按照游标翻到没有下一页为止
+	Search(ctx context.Context, offset, limit int, cursor, expr string, access domain.Access) (*domain.SearchResult, error)
+	// Suggest 用户输入过程中的搜索建议，只匹配标题和标签的前缀
+	Suggest(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error)
 }
 
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 20
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+)
+
 type searchSvc struct {
 	searchHandlers map[string]SearchHandler
+	bizs           map[string]struct{}
+	suggestRepo    repository.SuggestRepo
+}
+
+func (s *searchSvc) Suggest(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []domain.Suggestion{}, nil
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	return s.suggestRepo.Suggest(ctx, prefix, min(limit, maxSuggestLimit))
 }
 
-func (s *searchSvc) Search(ctx context.Context, offset, limit int, expr string) (*domain.SearchResult, error) {
-	biz, keywords, err := s.parseExpr(expr)
+func (s *searchSvc) Search(ctx context.Context, offset, limit int, cursor, expr string, access domain.Access) (*domain.SearchResult, error) {
+	// 分页参数直接来自前端，每个业务都要取 offset + limit 条，必须限制住
+	offset = max(offset, 0)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+	query, err := parseQuery(expr, s.bizs)
 	if err != nil {
 		return nil, err
 	}
-	var eg errgroup.Group
-	res := &domain.SearchResult{}
-	if biz == "all" {
-		for _, handler := range s.searchHandlers {
-			bizHandler := handler
-			eg.Go(func() error {
-				return bizHandler.search(ctx, keywords, offset, limit, res)
-			})
-		}
-		if err = eg.Wait(); err != nil {
-			return nil, err
-		}
-	} else {
-		bizhandler, ok := s.searchHandlers[biz]
-		if !ok {
-			return nil, errors.New("无相关的业务处理方式")
-		}
-		err = bizhandler.search(ctx, keywords, offset, limit, res)
+	if access.OnlyAccessible {
+		query.Access = &access
+	}
+	// 只看能打开的内容和看全部内容的翻页位置不一样，游标不能混用
+	key := expr
+	if access.OnlyAccessible {
+		key += "\x00accessible"
+	}
+	handlers := s.handlers(query.Biz)
+	offsets := make(map[string]int, len(handlers))
+	// 没有游标的时候，每个业务都从头取 offset + limit 条，归并之后跳过前面 offset 条
+	skip := offset
+	if cursor != "" {
+		offsets, err = s.decodeCursor(key, cursor, handlers)
 		if err != nil {
 			return nil, err
 		}
+		skip = 0
+	}
+	var (
+		eg    errgroup.Group
+		mu    sync.Mutex
+		pages = make(map[string]bizPage, len(handlers))
+	)
+	for biz, handler := range handlers {
+		biz, bizHandler := biz, handler
+		eg.Go(func() error {
+			page, err := bizHandler.search(ctx, query, offsets[biz], skip+limit)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			pages[biz] = page
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
 	}
+	res := s.merge(key, access, pages, offsets, skip, limit)
+	res.Query = query
 	return res, nil
-
 }
-func (s *searchSvc) parseExpr(expr string) (string, []domain.QueryMeta, error) {
-	searchParams := strings.SplitN(expr, ":", 3)
-	if len(searchParams) == 3 {
-		typ := searchParams[0]
-		if typ != "biz" {
-			return "", nil, errors.New("参数错误")
-		}
-		biz := searchParams[1]
-		keywords := searchParams[2]
-		return biz, s.getQueryMeta(keywords), nil
+
+// merge 按照归一化之后的分数做 k 路归并，分数相同的按照业务名字排序，保证翻页的时候结果是稳定的
+// 只看能打开的内容时，业务那边的过滤是宽松的，打不开的结果在这里跳过，但是依旧计入游标
+func (s *searchSvc) merge(key string, access domain.Access, pages map[string]bizPage,
+	offsets map[string]int, skip, limit int) *domain.SearchResult {
+	bizs := make([]string, 0, len(pages))
+	for biz := range pages {
+		bizs = append(bizs, biz)
 	}
-	return "", nil, errors.New("参数错误")
-}
+	slices.Sort(bizs)
 
-func (s *searchSvc) getQueryMeta(keywords string) []domain.QueryMeta {
-	keywordList := strings.Split(keywords, " ")
-	metas := make([]domain.QueryMeta, 0, len(keywordList))
-	for _, keyword := range keywordList {
-		params := strings.Split(keyword, ":")
-		if len(params) == 1 {
-			metas = append(metas, domain.QueryMeta{
-				Keyword: params[0],
-				IsAll:   true,
-			})
+	res := &domain.SearchResult{
+		Counts: make(map[string]int64, len(bizs)),
+		Hits:   make([]domain.Hit, 0, limit),
+	}
+	for _, biz := range bizs {
+		res.Counts[biz] = pages[biz].total
+		res.Total += pages[biz].total
+	}
+	// heads 是每个业务下一条待归并的结果，kept 是每个业务最终保留的结果的下标
+	heads := make(map[string]int, len(bizs))
+	kept := make(map[string][]int, len(bizs))
+	for i := 0; i < skip+limit; i++ {
+		best, truncated := "", false
+		for _, biz := range bizs {
+			page := pages[biz]
+			for access.OnlyAccessible && heads[biz] < len(page.ids) &&
+				!access.Allows(page.requirement(heads[biz])) {
+				heads[biz]++
+			}
+			if heads[biz] >= len(page.ids) {
+				// 取回来的都被跳过了，但是后面还有，这时候继续归并顺序就不对了，留到下一页
+				truncated = truncated || int64(offsets[biz]+len(page.ids)) < page.total
+				continue
+			}
+			if best == "" ||
+				pages[biz].normalizedScore(heads[biz]) > pages[best].normalizedScore(heads[best]) {
+				best = biz
+			}
+		}
+		if best == "" || truncated {
+			break
 		}
-		if len(params) == 2 {
-			metas = append(metas, domain.QueryMeta{
-				Keyword: params[1],
-				Col:     params[0],
+		if i >= skip {
+			page, idx := pages[best], heads[best]
+			requirement := page.requirement(idx)
+			res.Hits = append(res.Hits, domain.Hit{
+				Biz:         best,
+				Id:          page.ids[idx],
+				Score:       page.normalizedScore(idx),
+				Highlights:  page.highlight(idx),
+				Requirement: requirement,
+				Locked:      !access.Allows(requirement),
 			})
+			kept[best] = append(kept[best], idx)
+		}
+		heads[best]++
+	}
+
+	next := make(map[string]int, len(bizs))
+	var consumed int64
+	for _, biz := range bizs {
+		if len(kept[biz]) > 0 {
+			pages[biz].set(res, kept[biz])
 		}
+		next[biz] = offsets[biz] + heads[biz]
+		consumed += int64(next[biz])
+	}
+	if consumed < res.Total {
+		res.Cursor = s.encodeCursor(key, next)
 	}
-	return metas
+	return res
+}
+
+// searchCursor 记录每个业务已经翻过去多少条结果
+type searchCursor struct {
+	// Expr 搜索表达式的摘要，避免游标被用在别的搜索上
+	Expr    uint32         `json:"e"`
+	Offsets map[string]int `json:"o"`
+}
+
+func (s *searchSvc) encodeCursor(expr string, offsets map[string]int) string {
+	val, _ := json.Marshal(searchCursor{
+		Expr:    crc32.ChecksumIEEE([]byte(expr)),
+		Offsets: offsets,
+	})
+	return base64.RawURLEncoding.EncodeToString(val)
+}
+
+func (s *searchSvc) decodeCursor(expr, cursor string,
+	handlers map[string]SearchHandler) (map[string]int, error) {
+	val, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var c searchCursor
+	if err = json.Unmarshal(val, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if c.Expr != crc32.ChecksumIEEE([]byte(expr)) {
+		return nil, fmt.Errorf("%w: 搜索表达式已经变了", ErrInvalidCursor)
+	}
+	for biz, offset := range c.Offsets {
+		if _, ok := handlers[biz]; !ok || offset < 0 {
+			return nil, fmt.Errorf("%w: 业务 %s", ErrInvalidCursor, biz)
+		}
+	}
+	return c.Offsets, nil
+}
+
+// handlers 没有指定业务，或者指定了 all 的时候搜索全部业务
+func (s *searchSvc) handlers(bizs []string) map[string]SearchHandler {
+	if len(bizs) == 0 || slices.Contains(bizs, bizAll) {
+		return s.searchHandlers
+	}
+	res := make(map[string]SearchHandler, len(bizs))
+	for _, biz := range bizs {
+		res[biz] = s.searchHandlers[biz]
+	}
+	return res
 }
 
 func NewSearchSvc(
@@ -103,14 +250,27 @@ func NewSearchSvc(
 	questionSetRepo repository.QuestionSetRepo,
 	skillRepo repository.SkillRepo,
 	caseRepo repository.CaseRepo,
+	projectRepo repository.ProjectRepo,
+	reviewRepo repository.ReviewRepo,
+	roadmapRepo repository.RoadmapRepo,
+	suggestRepo repository.SuggestRepo,
 ) SearchService {
 	searchHandlers := map[string]SearchHandler{
-		"skill":       NewSkillHandler(skillRepo),
-		"case":        NewCaseHandler(caseRepo),
-		"questionSet": NewQuestionSetHandler(questionSetRepo),
-		"question":    NewQuestionHandler(questionRepo),
+		domain.BizSkill:       NewSkillHandler(skillRepo),
+		domain.BizCase:        NewCaseHandler(caseRepo),
+		domain.BizQuestionSet: NewQuestionSetHandler(questionSetRepo),
+		domain.BizQuestion:    NewQuestionHandler(questionRepo),
+		domain.BizProject:     NewProjectHandler(projectRepo),
+		domain.BizReview:      NewReviewHandler(reviewRepo),
+		domain.BizRoadmap:     NewRoadmapHandler(roadmapRepo),
+	}
+	bizs := make(map[string]struct{}, len(searchHandlers))
+	for biz := range searchHandlers {
+		bizs[biz] = struct{}{}
 	}
 	return &searchSvc{
 		searchHandlers: searchHandlers,
+		bizs:           bizs,
+		suggestRepo:    suggestRepo,
 	}
 }
@@ -17,26 +17,87 @@ package service
 import (
 	"context"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
 	"github.com/ecodeclub/webook/internal/search/internal/repository"
 )
 
 type SearchHandler interface {
-	// 不加锁 res
-	search(ctx context.Context, metas []domain.QueryMeta, offset, limit int, res *domain.SearchResult) error
+	// search 查询一页结果，但是不会直接写入 SearchResult，
+	// 因为统一排序之后每个业务最终保留多少条是不确定的
+	search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error)
+}
+
+// bizPage 某个业务的一页结果
+type bizPage struct {
+	ids        []int64
+	scores     []float64
+	highlights []map[string][]string
+	total      int64
+	// maxScore 用来归一化分数
+	maxScore float64
+	// requirements 打开每条结果需要满足的条件，为空代表谁都可以打开
+	requirements []domain.Requirement
+	// set 把下标为 idxs 的结果写入 SearchResult
+	set func(res *domain.SearchResult, idxs []int)
+}
+
+func newBizPage[T any](hits domain.Hits[T], id func(T) int64,
+	set func(res *domain.SearchResult, docs []T)) bizPage {
+	return bizPage{
+		ids:        slice.Map(hits.Docs, func(idx int, src T) int64 { return id(src) }),
+		scores:     hits.Scores,
+		highlights: hits.Highlights,
+		total:      hits.Total,
+		maxScore:   hits.MaxScore,
+		set: func(res *domain.SearchResult, idxs []int) {
+			set(res, slice.Map(idxs, func(_ int, idx int) T { return hits.Docs[idx] }))
+		},
+	}
+}
+
+// withRequirements 需要权限或者会员才能打开的业务设置每条结果的要求
+func (p bizPage) withRequirements(requirements []domain.Requirement) bizPage {
+	p.requirements = requirements
+	return p
+}
+
+// normalizedScore 不同业务的分数没有可比性，所以用这个业务里面最高的分数来归一化
+func (p bizPage) normalizedScore(idx int) float64 {
+	if p.maxScore <= 0 {
+		return 0
+	}
+	return p.scores[idx] / p.maxScore
+}
+
+func (p bizPage) requirement(idx int) domain.Requirement {
+	if idx >= len(p.requirements) {
+		return domain.Requirement{}
+	}
+	return p.requirements[idx]
+}
+
+func (p bizPage) highlight(idx int) map[string][]string {
+	if idx >= len(p.highlights) {
+		return nil
+	}
+	return p.highlights[idx]
 }
 
 type caseHandler struct {
 	caseRepo repository.CaseRepo
 }
 
-func (c *caseHandler) search(ctx context.Context, queryMetas []domain.QueryMeta, offset, limit int, res *domain.SearchResult) error {
-	cases, err := c.caseRepo.SearchCase(ctx, offset, limit, queryMetas)
+func (c *caseHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := c.caseRepo.SearchCase(ctx, offset, limit, query)
 	if err != nil {
-		return err
+		return bizPage{}, err
 	}
-	res.SetCases(cases)
-	return nil
+	return newBizPage(hits, func(src domain.Case) int64 {
+		return src.Id
+	}, (*domain.SearchResult).SetCases).withRequirements(slice.Map(hits.Docs, func(idx int, src domain.Case) domain.Requirement {
+		return src.Requirement()
+	})), nil
 }
 
 func NewCaseHandler(caseRepo repository.CaseRepo) SearchHandler {
@@ -49,13 +110,16 @@ type questionHandler struct {
 	questionRepo repository.QuestionRepo
 }
 
-func (q *questionHandler) search(ctx context.Context, queryMetas []domain.QueryMeta, offset, limit int, res *domain.SearchResult) error {
-	ques, err := q.questionRepo.SearchQuestion(ctx, offset, limit, queryMetas)
+func (q *questionHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := q.questionRepo.SearchQuestion(ctx, offset, limit, query)
 	if err != nil {
-		return err
+		return bizPage{}, err
 	}
-	res.SetQuestions(ques)
-	return nil
+	return newBizPage(hits, func(src domain.Question) int64 {
+		return src.ID
+	}, (*domain.SearchResult).SetQuestions).withRequirements(slice.Map(hits.Docs, func(idx int, src domain.Question) domain.Requirement {
+		return src.Requirement()
+	})), nil
 }
 
 func NewQuestionHandler(questionRepo repository.QuestionRepo) SearchHandler {
@@ -68,13 +132,14 @@ type questionSetHandler struct {
 	questionSetRepo repository.QuestionSetRepo
 }
 
-func (q *questionSetHandler) search(ctx context.Context, queryMetas []domain.QueryMeta, offset, limit int, res *domain.SearchResult) error {
-	questionSets, err := q.questionSetRepo.SearchQuestionSet(ctx, offset, limit, queryMetas)
+func (q *questionSetHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := q.questionSetRepo.SearchQuestionSet(ctx, offset, limit, query)
 	if err != nil {
-		return err
+		return bizPage{}, err
 	}
-	res.SetQuestionSet(questionSets)
-	return nil
+	return newBizPage(hits, func(src domain.QuestionSet) int64 {
+		return src.Id
+	}, (*domain.SearchResult).SetQuestionSet), nil
 }
 
 func NewQuestionSetHandler(questionSetRepo repository.QuestionSetRepo) SearchHandler {
@@ -93,11 +158,74 @@ func NewSkillHandler(
 		skillRepo: skillRepo,
 	}
 }
-func (s *skillHandler) search(ctx context.Context, queryMetas []domain.QueryMeta, offset, limit int, res *domain.SearchResult) error {
-	skills, err := s.skillRepo.SearchSkill(ctx, offset, limit, queryMetas)
+func (s *skillHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := s.skillRepo.SearchSkill(ctx, offset, limit, query)
+	if err != nil {
+		return bizPage{}, err
+	}
+	return newBizPage(hits, func(src domain.Skill) int64 {
+		return src.ID
+	}, (*domain.SearchResult).SetSkills), nil
+}
+
+type projectHandler struct {
+	projectRepo repository.ProjectRepo
+}
+
+func NewProjectHandler(projectRepo repository.ProjectRepo) SearchHandler {
+	return &projectHandler{
+		projectRepo: projectRepo,
+	}
+}
+
+func (p *projectHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := p.projectRepo.SearchProject(ctx, offset, limit, query)
+	if err != nil {
+		return bizPage{}, err
+	}
+	return newBizPage(hits, func(src domain.Project) int64 {
+		return src.Id
+	}, (*domain.SearchResult).SetProjects).withRequirements(slice.Map(hits.Docs, func(idx int, src domain.Project) domain.Requirement {
+		return src.Requirement()
+	})), nil
+}
+
+type reviewHandler struct {
+	reviewRepo repository.ReviewRepo
+}
+
+func NewReviewHandler(reviewRepo repository.ReviewRepo) SearchHandler {
+	return &reviewHandler{
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (r *reviewHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := r.reviewRepo.SearchReview(ctx, offset, limit, query)
+	if err != nil {
+		return bizPage{}, err
+	}
+	return newBizPage(hits, func(src domain.Review) int64 {
+		return src.Id
+	}, (*domain.SearchResult).SetReviews), nil
+}
+
+type roadmapHandler struct {
+	roadmapRepo repository.RoadmapRepo
+}
+
+func NewRoadmapHandler(roadmapRepo repository.RoadmapRepo) SearchHandler {
+	return &roadmapHandler{
+		roadmapRepo: roadmapRepo,
+	}
+}
+
+func (r *roadmapHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	hits, err := r.roadmapRepo.SearchRoadmap(ctx, offset, limit, query)
 	if err != nil {
-		return err
+		return bizPage{}, err
 	}
-	res.SetSkills(skills)
-	return nil
+	return newBizPage(hits, func(src domain.Roadmap) int64 {
+		return src.Id
+	}, (*domain.SearchResult).SetRoadmaps), nil
 }
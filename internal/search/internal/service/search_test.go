@@ -0,0 +1,161 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHandler 按照分数降序排好的结果
+type fakeHandler struct {
+	ids          []int64
+	scores       []float64
+	maxScore     float64
+	requirements []domain.Requirement
+}
+
+func (f *fakeHandler) search(ctx context.Context, query domain.Query, offset, limit int) (bizPage, error) {
+	from := min(offset, len(f.ids))
+	to := min(offset+limit, len(f.ids))
+	ids := f.ids[from:to]
+	page := bizPage{
+		ids:      ids,
+		scores:   f.scores[from:to],
+		total:    int64(len(f.ids)),
+		maxScore: f.maxScore,
+		set: func(res *domain.SearchResult, idxs []int) {
+			for _, idx := range idxs {
+				res.Cases = append(res.Cases, domain.Case{Id: ids[idx]})
+			}
+		},
+	}
+	if f.requirements != nil {
+		page.requirements = f.requirements[from:to]
+	}
+	return page, nil
+}
+
+func TestSearchSvc_Search(t *testing.T) {
+	svc := &searchSvc{
+		searchHandlers: map[string]SearchHandler{
+			// 归一化之后是 1, 0.5, 0.25
+			"case": &fakeHandler{ids: []int64{1, 2, 3}, scores: []float64{8, 4, 2}, maxScore: 8},
+			// 归一化之后是 1, 0.75
+			"question": &fakeHandler{ids: []int64{11, 12}, scores: []float64{2, 1.5}, maxScore: 2},
+		},
+		bizs: map[string]struct{}{"case": {}, "question": {}},
+	}
+	want := []domain.Hit{
+		{Biz: "case", Id: 1, Score: 1},
+		{Biz: "question", Id: 11, Score: 1},
+		{Biz: "question", Id: 12, Score: 0.75},
+		{Biz: "case", Id: 2, Score: 0.5},
+		{Biz: "case", Id: 3, Score: 0.25},
+	}
+	const expr = "redis"
+	ctx := context.Background()
+
+	// 按照游标翻页
+	var (
+		hits   []domain.Hit
+		cursor string
+	)
+	for i := 0; i < 3; i++ {
+		res, err := svc.Search(ctx, 0, 2, cursor, expr, domain.Access{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), res.Total)
+		assert.Equal(t, map[string]int64{"case": 3, "question": 2}, res.Counts)
+		hits = append(hits, res.Hits...)
+		cursor = res.Cursor
+		if cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, want, hits)
+	assert.Equal(t, "", cursor)
+
+	// 按照 offset 翻页，结果和游标一致
+	res, err := svc.Search(ctx, 2, 2, "", expr, domain.Access{})
+	require.NoError(t, err)
+	assert.Equal(t, want[2:4], res.Hits)
+	// fakeHandler 都写到 Cases 里面，按照业务名字的顺序写入
+	assert.Equal(t, []domain.Case{{Id: 2}, {Id: 12}}, res.Cases)
+
+	// 游标不能用在别的搜索上
+	_, err = svc.Search(ctx, 0, 2, res.Cursor, "mysql", domain.Access{})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = svc.Search(ctx, 0, 2, "abc", expr, domain.Access{})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestSearchSvc_SearchAccess(t *testing.T) {
+	member := domain.Requirement{Member: true}
+	project := func(id int64) domain.Requirement {
+		return domain.Requirement{Biz: domain.BizProject, BizId: id}
+	}
+	svc := &searchSvc{
+		searchHandlers: map[string]SearchHandler{
+			"case": &fakeHandler{ids: []int64{1, 2}, scores: []float64{4, 1}, maxScore: 4,
+				requirements: []domain.Requirement{member, member}},
+			"project": &fakeHandler{ids: []int64{11, 12, 13}, scores: []float64{2, 1.5, 1}, maxScore: 2,
+				requirements: []domain.Requirement{project(11), project(12), project(13)}},
+			"skill": &fakeHandler{ids: []int64{21}, scores: []float64{1}, maxScore: 2},
+		},
+		bizs: map[string]struct{}{"case": {}, "project": {}, "skill": {}},
+	}
+	ctx := context.Background()
+	const expr = "redis"
+	access := domain.Access{Permissions: map[string][]int64{domain.BizProject: {12}}}
+
+	// 默认返回全部结果，标记出打不开的
+	res, err := svc.Search(ctx, 0, 10, "", expr, access)
+	require.NoError(t, err)
+	locked := make(map[int64]bool, len(res.Hits))
+	for _, hit := range res.Hits {
+		locked[hit.Id] = hit.Locked
+	}
+	assert.Equal(t, map[int64]bool{1: true, 2: true, 11: true, 12: false, 13: true, 21: false}, locked)
+
+	// 只看能打开的，按照游标翻页也不会重复或者遗漏
+	access.OnlyAccessible = true
+	var (
+		ids    []int64
+		cursor string
+	)
+	for i := 0; i < 5; i++ {
+		res, err = svc.Search(ctx, 0, 1, cursor, expr, access)
+		require.NoError(t, err)
+		for _, hit := range res.Hits {
+			assert.False(t, hit.Locked)
+			ids = append(ids, hit.Id)
+		}
+		cursor = res.Cursor
+		if cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, []int64{12, 21}, ids)
+
+	// 全部结果的游标不能用来翻只看能打开的结果
+	res, err = svc.Search(ctx, 0, 1, "", expr, domain.Access{})
+	require.NoError(t, err)
+	_, err = svc.Search(ctx, 0, 1, res.Cursor, expr, access)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
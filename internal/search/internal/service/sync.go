@@ -15,24 +15,232 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/ecache"
 
 	"github.com/ecodeclub/webook/internal/search/internal/repository"
 )
 
 type SyncService interface {
 	Input(ctx context.Context, index string, docID string, data string) error
+	// Delete 数据已经被删除了，从索引里面删掉
+	Delete(ctx context.Context, index string, docID string) error
 }
 type syncService struct {
 	anyRepo repository.AnyRepo
+	journal *Journal
 }
 
 func (s *syncService) Input(ctx context.Context, index string, docID string, data string) error {
-	return s.anyRepo.Input(ctx, index, docID, data)
+	return s.journal.write(ctx, index, docID, data, func() error {
+		return s.anyRepo.Input(ctx, index, docID, data)
+	})
+}
+
+func (s *syncService) Delete(ctx context.Context, index string, docID string) error {
+	return s.journal.write(ctx, index, docID, "", func() error {
+		return s.anyRepo.Delete(ctx, index, docID)
+	})
 }
 
-func NewSyncSvc(anyRepo repository.AnyRepo) SyncService {
+func NewSyncSvc(anyRepo repository.AnyRepo, journal *Journal) SyncService {
 	return &syncService{
 		anyRepo: anyRepo,
+		journal: journal,
+	}
+}
+
+// journalExpiration 重建索引的标记和记录的过期时间，重建索引的实例崩溃了也不会一直处于重建状态
+const journalExpiration = time.Hour
+
+// journalRenewInterval 重建期间每隔多久续期一次标记，同时取出已经到达的记录，
+// 所以重建的时间超过 journalExpiration，标记和记录也不会过期
+const journalRenewInterval = 5 * time.Minute
+
+// Journal 记录重建索引期间到达的同步消息
+// 全量数据写入新的索引之后，再把这些消息重放一遍，
+// 这样重建期间的修改既不会丢失，也不会被更早读出来的全量数据覆盖。
+// 记录保存在缓存里面，所有消费同步消息的实例共用，每条记录按照写入的顺序编号：
+//   - {alias} 有这个 key 代表正在重建，值是重建索引的实例生成的 token
+//   - {alias}:seq 最后一条记录的编号
+//   - {alias}:{seq} 一条记录
+type Journal struct {
+	cache ecache.Cache
+}
+
+type journalEntry struct {
+	Seq   int64  `json:"seq"`
+	DocID string `json:"docId"`
+	// Data 为空代表删除
+	Data string `json:"data,omitempty"`
+}
+
+// journalCursor 重建索引的实例读到了哪里
+type journalCursor struct {
+	alias string
+	// token 用来确认标记还是自己设置的那个
+	token     string
+	renewedAt time.Time
+	next      int64
+	// missing 已经分配了编号但是还没有写入的记录，下一次继续读
+	missing []int64
+	// latest 每个文档已经取出过的最新的记录，更早的记录不能再覆盖它
+	latest map[string]int64
+}
+
+func NewJournal(c ecache.Cache) *Journal {
+	return &Journal{
+		cache: &ecache.NamespaceCache{
+			Namespace: "search:reindex:",
+			C:         c,
+		},
+	}
+}
+
+// write 先记录再写入索引，重建索引的实例读不到的记录，对应的写入一定发生在切换别名之后
+func (j *Journal) write(ctx context.Context, index, docID, data string, fn func() error) error {
+	val := j.cache.Get(ctx, index)
+	switch {
+	case val.KeyNotFound():
+		return fn()
+	case val.Err != nil:
+		return val.Err
+	}
+	seq, err := j.cache.IncrBy(ctx, j.seqKey(index), 1)
+	if err != nil {
+		return err
+	}
+	entry, err := json.Marshal(journalEntry{Seq: seq, DocID: docID, Data: data})
+	if err != nil {
+		return err
+	}
+	err = j.cache.Set(ctx, j.entryKey(index, seq), string(entry), journalExpiration)
+	if err != nil {
+		return err
+	}
+	return fn()
+}
+
+// start 开始记录，返回 nil 代表这个别名已经在重建了
+func (j *Journal) start(ctx context.Context, alias string) (*journalCursor, error) {
+	c := &journalCursor{
+		alias:     alias,
+		token:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		renewedAt: time.Now(),
+		latest:    make(map[string]int64),
+	}
+	ok, err := j.cache.SetNX(ctx, alias, c.token, journalExpiration)
+	if err != nil || !ok {
+		return nil, err
 	}
+	last, err := j.cache.IncrBy(ctx, j.seqKey(alias), 0)
+	if err != nil {
+		return nil, errors.Join(err, j.stop(ctx, c))
+	}
+	c.next = last + 1
+	return c, nil
+}
+
+// renew 续期重建的标记。标记已经过期或者被别的实例占用的时候返回 ErrReindexExpired，
+// 这时候同步消息可能已经直接写入了旧的索引，不能再切换别名
+func (j *Journal) renew(ctx context.Context, c *journalCursor) error {
+	owned, err := j.owned(ctx, c)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return fmt.Errorf("%w %s", ErrReindexExpired, c.alias)
+	}
+	err = j.cache.Set(ctx, c.alias, c.token, journalExpiration)
+	if err != nil {
+		return err
+	}
+	c.renewedAt = time.Now()
+	return nil
+}
+
+func (j *Journal) owned(ctx context.Context, c *journalCursor) (bool, error) {
+	val := j.cache.Get(ctx, c.alias)
+	if val.KeyNotFound() {
+		return false, nil
+	}
+	token, err := val.String()
+	if err != nil {
+		return false, err
+	}
+	return token == c.token, nil
+}
+
+// drain 按照写入的顺序取出上一次之后的记录，同一个文档只保留最新的一条
+func (j *Journal) drain(ctx context.Context, c *journalCursor) ([]journalEntry, error) {
+	last, err := j.cache.IncrBy(ctx, j.seqKey(c.alias), 0)
+	if err != nil {
+		return nil, err
+	}
+	seqs := c.missing
+	for seq := c.next; seq <= last; seq++ {
+		seqs = append(seqs, seq)
+	}
+	c.next, c.missing = last+1, nil
+	found := make([]journalEntry, 0, len(seqs))
+	keys := make([]string, 0, len(seqs))
+	for _, seq := range seqs {
+		key := j.entryKey(c.alias, seq)
+		val := j.cache.Get(ctx, key)
+		if val.KeyNotFound() {
+			c.missing = append(c.missing, seq)
+			continue
+		}
+		str, err := val.String()
+		if err != nil {
+			return nil, err
+		}
+		var entry journalEntry
+		if err = json.Unmarshal([]byte(str), &entry); err != nil {
+			return nil, err
+		}
+		found = append(found, entry)
+		keys = append(keys, key)
+		c.latest[entry.DocID] = max(c.latest[entry.DocID], entry.Seq)
+	}
+	if len(keys) > 0 {
+		// 删除失败也会过期
+		_, _ = j.cache.Delete(ctx, keys...)
+	}
+	entries := make([]journalEntry, 0, len(found))
+	for _, entry := range found {
+		if entry.Seq == c.latest[entry.DocID] {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b journalEntry) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return entries, nil
+}
+
+// stop 只删除自己设置的标记，标记过期之后别的实例可能已经开始了新的重建
+func (j *Journal) stop(ctx context.Context, c *journalCursor) error {
+	owned, err := j.owned(ctx, c)
+	if err != nil || !owned {
+		return err
+	}
+	_, err = j.cache.Delete(ctx, c.alias)
+	return err
+}
+
+func (j *Journal) seqKey(alias string) string {
+	return alias + ":seq"
+}
+
+func (j *Journal) entryKey(alias string, seq int64) string {
+	return fmt.Sprintf("%s:%d", alias, seq)
 }
@@ -0,0 +1,108 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// AdminHandler 重建索引、一致性检查和搜索分析
+type AdminHandler struct {
+	svc          service.ReindexService
+	analyticsSvc service.AnalyticsService
+	logger       *elog.Component
+}
+
+func NewAdminHandler(svc service.ReindexService, analyticsSvc service.AnalyticsService) *AdminHandler {
+	return &AdminHandler{
+		svc:          svc,
+		analyticsSvc: analyticsSvc,
+		logger:       elog.DefaultLogger,
+	}
+}
+
+func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
+	server.POST("/search/reindex", ginx.B[ReindexReq](h.Reindex))
+	server.POST("/search/check", ginx.B[ReindexReq](h.Check))
+	g := server.Group("/search/analytics")
+	g.POST("/top", ginx.B[AnalyticsReq](h.TopQueries))
+	g.POST("/zero", ginx.B[AnalyticsReq](h.ZeroResultQueries))
+	g.POST("/ctr", ginx.B[AnalyticsReq](h.LowClickThroughQueries))
+}
+
+// Reindex 重建索引耗时比较长，异步执行，结果看日志或者调用 Check
+func (h *AdminHandler) Reindex(ctx *ginx.Context, req ReindexReq) (ginx.Result, error) {
+	if !slices.Contains(h.svc.Bizs(), req.Biz) {
+		return unknownBizResult, nil
+	}
+	go func() {
+		err := h.svc.Reindex(context.Background(), req.Biz)
+		if err != nil {
+			h.logger.Error("重建索引失败", elog.FieldErr(err), elog.String("biz", req.Biz))
+		}
+	}()
+	return ginx.Result{}, nil
+}
+
+func (h *AdminHandler) Check(ctx *ginx.Context, req ReindexReq) (ginx.Result, error) {
+	report, err := h.svc.Check(ctx, req.Biz)
+	switch {
+	case errors.Is(err, service.ErrUnknownBiz):
+		return unknownBizResult, nil
+	case err != nil:
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: newCheckReport(report),
+	}, nil
+}
+
+// TopQueries 搜索次数最多的搜索
+func (h *AdminHandler) TopQueries(ctx *ginx.Context, req AnalyticsReq) (ginx.Result, error) {
+	stats, err := h.analyticsSvc.TopQueries(ctx, req.statRange())
+	return h.statsResult(stats, err)
+}
+
+// ZeroResultQueries 经常没有结果的搜索，通常意味着缺少对应的内容
+func (h *AdminHandler) ZeroResultQueries(ctx *ginx.Context, req AnalyticsReq) (ginx.Result, error) {
+	stats, err := h.analyticsSvc.ZeroResultQueries(ctx, req.statRange())
+	return h.statsResult(stats, err)
+}
+
+// LowClickThroughQueries 点击率最低的搜索，通常意味着结果不是用户想要的
+func (h *AdminHandler) LowClickThroughQueries(ctx *ginx.Context, req AnalyticsReq) (ginx.Result, error) {
+	stats, err := h.analyticsSvc.LowClickThroughQueries(ctx, req.statRange(), req.MinSearches)
+	return h.statsResult(stats, err)
+}
+
+func (h *AdminHandler) statsResult(stats []domain.QueryStat, err error) (ginx.Result, error) {
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: slice.Map(stats, func(idx int, src domain.QueryStat) QueryStat {
+			return newQueryStat(src)
+		}),
+	}, nil
+}
@@ -15,42 +15,117 @@
 package web
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/errs"
+	"github.com/ecodeclub/webook/internal/search/internal/event"
 	"github.com/ecodeclub/webook/internal/search/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/elog"
+	"github.com/lithammer/shortuuid/v4"
 )
 
 type Handler struct {
-	svc     service.SearchService
-	logger  *elog.Component
-	examSvc cases.ExamineService
+	svc          service.SearchService
+	logger       *elog.Component
+	examSvc      cases.ExamineService
+	analyticsSvc service.AnalyticsService
+	accessSvc    service.AccessService
+	producer     event.QueryLogProducer
 }
 
-func NewHandler(svc service.SearchService, examSvc cases.ExamineService) *Handler {
+func NewHandler(svc service.SearchService, examSvc cases.ExamineService,
+	analyticsSvc service.AnalyticsService, accessSvc service.AccessService,
+	producer event.QueryLogProducer) *Handler {
 	return &Handler{
-		svc:     svc,
-		logger:  elog.DefaultLogger,
-		examSvc: examSvc,
+		svc:          svc,
+		logger:       elog.DefaultLogger,
+		examSvc:      examSvc,
+		analyticsSvc: analyticsSvc,
+		accessSvc:    accessSvc,
+		producer:     producer,
 	}
 }
 
 func (h *Handler) PrivateRoutes(server *gin.Engine) {
 	server.POST("/search/list", ginx.BS[SearchReq](h.List))
+	server.POST("/search/suggest", ginx.B[SuggestReq](h.Suggest))
+	server.POST("/search/click", ginx.BS[ClickReq](h.Click))
 }
 
-func (h *Handler) List(ctx *ginx.Context, req SearchReq, sess session.Session) (ginx.Result, error) {
-	data, err := h.svc.Search(ctx, req.Offset, req.Limit, req.Keywords)
+// Click 记录用户点击了哪一个搜索结果，失败了也不影响用户，所以只打日志
+func (h *Handler) Click(ctx *ginx.Context, req ClickReq, sess session.Session) (ginx.Result, error) {
+	if req.Sid == "" {
+		return ginx.Result{}, nil
+	}
+	err := h.analyticsSvc.Click(ctx, domain.Click{
+		Sid:      req.Sid,
+		Uid:      sess.Claims().Uid,
+		Biz:      req.Biz,
+		BizId:    req.BizId,
+		Position: req.Position,
+	})
+	switch {
+	case errors.Is(err, service.ErrInvalidClick):
+		// 伪造的或者过期的 sid，直接丢弃，避免污染点击率
+		h.logger.Warn("丢弃不合法的搜索点击", elog.FieldErr(err), elog.String("sid", req.Sid))
+	case err != nil:
+		h.logger.Error("记录搜索点击失败", elog.FieldErr(err), elog.String("sid", req.Sid))
+	}
+	return ginx.Result{}, nil
+}
+
+// Suggest 输入过程中的搜索建议
+func (h *Handler) Suggest(ctx *ginx.Context, req SuggestReq) (ginx.Result, error) {
+	suggestions, err := h.svc.Suggest(ctx, req.Prefix, req.Limit)
 	if err != nil {
 		return systemErrorResult, err
 	}
+	return ginx.Result{
+		Data: slice.Map(suggestions, func(idx int, src domain.Suggestion) Suggestion {
+			return newSuggestion(src)
+		}),
+	}, nil
+}
+
+func (h *Handler) List(ctx *ginx.Context, req SearchReq, sess session.Session) (ginx.Result, error) {
+	start := time.Now()
+	data, err := h.svc.Search(ctx, req.Offset, req.Limit, req.Cursor, req.Keywords, h.access(ctx, req, sess))
+	latency := time.Since(start)
+	var parseErr *service.ParseError
+	switch {
+	case errors.As(err, &parseErr):
+		// 把出错的位置告诉前端
+		return ginx.Result{
+			Code: errs.InvalidQuery.Code,
+			Msg:  parseErr.Error(),
+		}, nil
+	case errors.Is(err, service.ErrInvalidCursor):
+		return ginx.Result{
+			Code: errs.InvalidCursor.Code,
+			Msg:  errs.InvalidCursor.Msg,
+		}, nil
+	case err != nil:
+		return systemErrorResult, err
+	}
+	uid := sess.Claims().Uid
+	sid := req.Sid
+	// 翻页的时候前端会带上第一页的 sid，同一次搜索只记录一次
+	if sid == "" {
+		sid = shortuuid.New()
+		if req.Cursor == "" && req.Offset == 0 {
+			h.logQuery(ctx, sid, uid, data, latency)
+		}
+	}
 	var examMap map[int64]cases.ExamineResult
 	if data.Cases != nil {
-		uid := sess.Claims().Uid
 		cids := slice.Map(data.Cases, func(idx int, src domain.Case) int64 {
 			return src.Id
 		})
@@ -59,7 +134,44 @@ func (h *Handler) List(ctx *ginx.Context, req SearchReq, sess session.Session) (
 			return systemErrorResult, err
 		}
 	}
+	res := NewSearchResult(data, examMap)
+	res.Sid = sid
 	return ginx.Result{
-		Data: NewSearchResult(data, examMap),
+		Data: res,
 	}, nil
 }
+
+// access 查询失败的时候当作什么都打不开，结果都标记成锁定，打开的时候各个业务还会再校验
+func (h *Handler) access(ctx *ginx.Context, req SearchReq, sess session.Session) domain.Access {
+	claims := sess.Claims()
+	// jwt 里面的数据格式不对的时候是 0，会去查询会员
+	memberDDL, _ := claims.Get("memberDDL").AsInt64()
+	access, err := h.accessSvc.Access(ctx, claims.Uid, memberDDL)
+	if err != nil {
+		h.logger.Error("查询用户权限失败", elog.FieldErr(err), elog.Int64("uid", claims.Uid))
+		access = domain.Access{}
+	}
+	access.OnlyAccessible = req.Accessible
+	return access
+}
+
+// logQuery 通过消息队列异步保存搜索记录，失败了不影响搜索
+func (h *Handler) logQuery(ctx *ginx.Context, sid string, uid int64,
+	data *domain.SearchResult, latency time.Duration) {
+	query := data.Query.String()
+	if query == "" {
+		return
+	}
+	err := h.producer.Produce(ctx, event.NewQueryLogEvent(domain.QueryLog{
+		Sid:     sid,
+		Uid:     uid,
+		Query:   query,
+		Biz:     strings.Join(data.Query.Biz, ","),
+		Hits:    data.Total,
+		Latency: latency,
+		Ctime:   time.Now(),
+	}))
+	if err != nil {
+		h.logger.Error("发送搜索记录失败", elog.FieldErr(err), elog.String("query", query))
+	}
+}
@@ -24,4 +24,8 @@ var (
 		Code: errs.SystemError.Code,
 		Msg:  errs.SystemError.Msg,
 	}
+	unknownBizResult = ginx.Result{
+		Code: errs.UnknownBiz.Code,
+		Msg:  errs.UnknownBiz.Msg,
+	}
 )
@@ -17,15 +17,32 @@ package web
 import (
 	"time"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/cases"
 
 	"github.com/ecodeclub/webook/internal/search/internal/domain"
+	"github.com/ecodeclub/webook/internal/search/internal/service"
 )
 
 type SearchReq struct {
 	Offset   int    `json:"offset"`
 	Limit    int    `json:"limit"`
 	Keywords string `json:"keywords,omitempty"`
+	// Cursor 上一页返回的游标，不为空的时候忽略 Offset
+	Cursor string `json:"cursor,omitempty"`
+	// Sid 翻页的时候带上第一页返回的 sid
+	Sid string `json:"sid,omitempty"`
+	// Accessible 为 true 的时候只搜索当前用户能打开的内容
+	Accessible bool `json:"accessible,omitempty"`
+}
+
+type ClickReq struct {
+	// Sid 搜索结果里面的 sid
+	Sid   string `json:"sid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// Position 被点击的结果在 Hits 里面的位置，从 0 开始
+	Position int `json:"position"`
 }
 
 type Case struct {
@@ -103,15 +120,126 @@ type QuestionSet struct {
 	Utime       string  `json:"utime,omitempty"`
 }
 
+type Project struct {
+	Id     int64    `json:"id,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Desc   string   `json:"desc,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	Status uint8    `json:"status,omitempty"`
+	Utime  string   `json:"utime,omitempty"`
+}
+
+type Review struct {
+	Id               int64    `json:"id,omitempty"`
+	Uid              int64    `json:"uid,omitempty"`
+	Title            string   `json:"title,omitempty"`
+	Desc             string   `json:"desc,omitempty"`
+	Labels           []string `json:"labels,omitempty"`
+	JD               string   `json:"jd,omitempty"`
+	JDAnalysis       string   `json:"jdAnalysis,omitempty"`
+	Questions        string   `json:"questions,omitempty"`
+	QuestionAnalysis string   `json:"questionAnalysis,omitempty"`
+	Resume           string   `json:"resume,omitempty"`
+	Status           uint8    `json:"status,omitempty"`
+	Utime            string   `json:"utime,omitempty"`
+}
+
+type Roadmap struct {
+	Id       int64  `json:"id,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Biz      string `json:"biz,omitempty"`
+	BizId    int64  `json:"bizId,omitempty"`
+	BizTitle string `json:"bizTitle,omitempty"`
+	Utime    string `json:"utime,omitempty"`
+}
+
 type SearchResult struct {
 	Cases       []Case        `json:"cases,omitempty"`
 	Questions   []Question    `json:"questions,omitempty"`
 	Skills      []Skill       `json:"skills,omitempty"`
 	QuestionSet []QuestionSet `json:"questionSet,omitempty"`
+	Projects    []Project     `json:"projects,omitempty"`
+	Reviews     []Review      `json:"reviews,omitempty"`
+	Roadmaps    []Roadmap     `json:"roadmaps,omitempty"`
+
+	// Hits 所有业务统一排序之后的顺序，前端按照这个顺序展示
+	Hits []Hit `json:"hits,omitempty"`
+	// Total 所有业务命中的总数
+	Total int64 `json:"total,omitempty"`
+	// Counts 每个业务命中的数量
+	Counts map[string]int64 `json:"counts,omitempty"`
+	// Cursor 下一页的游标，为空代表没有下一页了
+	Cursor string `json:"cursor,omitempty"`
+	// Sid 这一次搜索的标识，翻页和点击结果的时候带上
+	Sid string `json:"sid,omitempty"`
+}
+
+type Hit struct {
+	Biz   string  `json:"biz"`
+	Id    int64   `json:"id"`
+	Score float64 `json:"score"`
+	// Highlights 命中的字段和高亮片段，命中的部分用 <em></em> 包起来
+	Highlights map[string][]string `json:"highlights,omitempty"`
+	// Requires 打开这条内容需要什么，member 是会员，permission 是单独购买，为空代表不需要
+	Requires string `json:"requires,omitempty"`
+	// Locked 当前用户还不能打开这条内容，前端展示成锁定的样子
+	Locked bool `json:"locked"`
+}
+
+const (
+	requiresMember     = "member"
+	requiresPermission = "permission"
+)
+
+func requires(r domain.Requirement) string {
+	switch {
+	case r.NeedPermission():
+		return requiresPermission
+	case r.Member:
+		return requiresMember
+	default:
+		return ""
+	}
+}
+
+type SuggestReq struct {
+	// Prefix 用户正在输入的内容
+	Prefix string `json:"prefix"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+type Suggestion struct {
+	Biz   string `json:"biz"`
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+	Label string `json:"label,omitempty"`
+}
+
+func newSuggestion(s domain.Suggestion) Suggestion {
+	return Suggestion{
+		Biz:   s.Biz,
+		Id:    s.Id,
+		Title: s.Title,
+		Label: s.Label,
+	}
 }
 
 func NewSearchResult(res *domain.SearchResult, examMap map[int64]cases.ExamineResult) SearchResult {
-	var newResult SearchResult
+	newResult := SearchResult{
+		Hits: slice.Map(res.Hits, func(idx int, src domain.Hit) Hit {
+			return Hit{
+				Biz:        src.Biz,
+				Id:         src.Id,
+				Score:      src.Score,
+				Highlights: src.Highlights,
+				Requires:   requires(src.Requirement),
+				Locked:     src.Locked,
+			}
+		}),
+		Total:  res.Total,
+		Counts: res.Counts,
+		Cursor: res.Cursor,
+	}
 	for _, oldCase := range res.Cases {
 		newCase := Case{
 			Id:         oldCase.Id,
@@ -181,6 +309,42 @@ func NewSearchResult(res *domain.SearchResult, examMap map[int64]cases.ExamineRe
 		}
 		newResult.QuestionSet = append(newResult.QuestionSet, newQuestionSet)
 	}
+	for _, prj := range res.Projects {
+		newResult.Projects = append(newResult.Projects, Project{
+			Id:     prj.Id,
+			Title:  prj.Title,
+			Desc:   prj.Desc,
+			Labels: prj.Labels,
+			Status: prj.Status,
+			Utime:  prj.Utime.Format(time.DateTime),
+		})
+	}
+	for _, re := range res.Reviews {
+		newResult.Reviews = append(newResult.Reviews, Review{
+			Id:               re.Id,
+			Uid:              re.Uid,
+			Title:            re.Title,
+			Desc:             re.Desc,
+			Labels:           re.Labels,
+			JD:               re.JD,
+			JDAnalysis:       re.JDAnalysis,
+			Questions:        re.Questions,
+			QuestionAnalysis: re.QuestionAnalysis,
+			Resume:           re.Resume,
+			Status:           re.Status,
+			Utime:            re.Utime.Format(time.DateTime),
+		})
+	}
+	for _, r := range res.Roadmaps {
+		newResult.Roadmaps = append(newResult.Roadmaps, Roadmap{
+			Id:       r.Id,
+			Title:    r.Title,
+			Biz:      r.Biz,
+			BizId:    r.BizId,
+			BizTitle: r.BizTitle,
+			Utime:    r.Utime.Format(time.DateTime),
+		})
+	}
 
 	return newResult
 }
@@ -205,3 +369,70 @@ func NewSkillLevel(l domain.SkillLevel) SkillLevel {
 		Cases:     l.Cases,
 	}
 }
+
+type ReindexReq struct {
+	Biz string `json:"biz"`
+}
+
+type CheckReport struct {
+	Biz         string  `json:"biz"`
+	Index       string  `json:"index"`
+	SourceCount int64   `json:"sourceCount"`
+	IndexCount  int64   `json:"indexCount"`
+	Consistent  bool    `json:"consistent"`
+	Missing     []int64 `json:"missing,omitempty"`
+	Extra       []int64 `json:"extra,omitempty"`
+	Mismatched  []int64 `json:"mismatched,omitempty"`
+}
+
+func newCheckReport(r domain.CheckReport) CheckReport {
+	return CheckReport{
+		Biz:         r.Biz,
+		Index:       r.Index,
+		SourceCount: r.SourceCount,
+		IndexCount:  r.IndexCount,
+		Consistent:  r.Consistent(),
+		Missing:     r.Missing,
+		Extra:       r.Extra,
+		Mismatched:  r.Mismatched,
+	}
+}
+
+type AnalyticsReq struct {
+	// Start 和 End 是毫秒数，统计 [Start, End) 之间的搜索，不传就是最近七天
+	Start int64 `json:"start,omitempty"`
+	End   int64 `json:"end,omitempty"`
+	Limit int   `json:"limit,omitempty"`
+	// MinSearches 只用于点击率，搜索次数太少的点击率没有参考意义
+	MinSearches int64 `json:"minSearches,omitempty"`
+}
+
+func (r AnalyticsReq) statRange() service.StatRange {
+	var res service.StatRange
+	if r.Start > 0 {
+		res.Start = time.UnixMilli(r.Start)
+	}
+	if r.End > 0 {
+		res.End = time.UnixMilli(r.End)
+	}
+	res.Limit = r.Limit
+	return res
+}
+
+type QueryStat struct {
+	Query       string  `json:"query"`
+	Searches    int64   `json:"searches"`
+	ZeroResults int64   `json:"zeroResults"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+func newQueryStat(s domain.QueryStat) QueryStat {
+	return QueryStat{
+		Query:       s.Query,
+		Searches:    s.Searches,
+		ZeroResults: s.ZeroResults,
+		Clicks:      s.Clicks,
+		CTR:         s.ClickThroughRate(),
+	}
+}
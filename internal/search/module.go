@@ -14,11 +14,21 @@
 
 package search
 
-import "github.com/ecodeclub/webook/internal/search/internal/event"
+import (
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/search/internal/event"
+)
 
 type Module struct {
-	SearchSvc SearchService
-	SyncSvc   SyncService
-	c         *event.SyncConsumer
-	Hdl       *Handler
+	SearchSvc  SearchService
+	SyncSvc    SyncService
+	ReindexSvc ReindexService
+	c          *event.SyncConsumer
+	qc         *event.QueryLogConsumer
+	dc         *event.UserDeletionConsumer
+	Hdl        *Handler
+	AdminHdl   *AdminHandler
+	ReindexJob *ReindexJob
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
 }
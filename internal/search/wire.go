@@ -20,68 +20,119 @@ import (
 	"context"
 	"sync"
 
+	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/permission"
 
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	"github.com/ecodeclub/webook/internal/search/internal/event"
+	"github.com/ecodeclub/webook/internal/search/internal/job"
 
 	"github.com/ecodeclub/webook/internal/search/internal/repository"
 	"github.com/ecodeclub/webook/internal/search/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine/es"
+	"github.com/ecodeclub/webook/internal/search/internal/repository/engine/local"
 	"github.com/ecodeclub/webook/internal/search/internal/service"
 	"github.com/ecodeclub/webook/internal/search/internal/web"
+	"github.com/ego-component/egorm"
 	"github.com/google/wire"
 	"github.com/olivere/elastic/v7"
 )
 
-func InitModule(es *elastic.Client, q mq.MQ, caModule *cases.Module) (*Module, error) {
+func InitModule(e Engine, db *egorm.Component, ec ecache.Cache, q mq.MQ, caModule *cases.Module, memberModule *member.Module, permModule *permission.Module, sources []searchx.Source) (*Module, error) {
+	wire.Build(InitAnalyticsDAO, InitModuleWithDAO)
+	return new(Module), nil
+}
+
+// InitModuleWithDAO 搜索模块只有分析功能需要用到数据库，测试的时候可以替换掉
+func InitModuleWithDAO(e Engine, analyticsDAO dao.AnalyticsDAO, ec ecache.Cache, q mq.MQ,
+	caModule *cases.Module, memberModule *member.Module, permModule *permission.Module, sources []searchx.Source) (*Module, error) {
 	wire.Build(
 		InitSearchSvc,
-		InitSyncSvc,
+		InitAnyRepo,
+		service.NewJournal,
+		service.NewSyncSvc,
+		dao.NewIndexDAO,
+		repository.NewIndexRepo,
+		service.NewReindexSvc,
 		initSyncConsumer,
+		repository.NewAnalyticsRepo,
+		service.NewAnalyticsSvc,
+		event.NewQueryLogProducer,
+		initQueryLogConsumer,
+		initUserDeletionConsumer,
+		service.NewExportSource,
+		service.NewAccessSvc,
 		wire.FieldsOf(new(*cases.Module), "ExamineSvc"),
+		wire.FieldsOf(new(*member.Module), "Svc"),
+		wire.FieldsOf(new(*permission.Module), "Svc"),
 		web.NewHandler,
+		web.NewAdminHandler,
+		job.NewReindexJob,
 		wire.Struct(new(Module), "*"),
 	)
 	return new(Module), nil
 }
 
-var daoOnce = sync.Once{}
+var (
+	indexOnce = sync.Once{}
+	tableOnce = sync.Once{}
+)
+
+func InitAnalyticsDAO(db *egorm.Component) dao.AnalyticsDAO {
+	tableOnce.Do(func() {
+		err := dao.InitTables(db)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return dao.NewGORMAnalyticsDAO(db)
+}
 
-func InitIndexOnce(es *elastic.Client) {
-	daoOnce.Do(func() {
-		err := dao.InitES(es)
+// NewElasticEngine 基于 elastic 的搜索引擎，会创建好所有的索引
+func NewElasticEngine(client *elastic.Client) Engine {
+	indexOnce.Do(func() {
+		err := es.InitIndexes(client, dao.Mappings())
 		if err != nil {
 			panic(err)
 		}
 	})
+	return es.NewEngine(client)
 }
 
-func InitRepo(es *elastic.Client) (repository.CaseRepo, repository.QuestionRepo, repository.QuestionSetRepo, repository.SkillRepo) {
-	InitIndexOnce(es)
-	questionDao := dao.NewQuestionDAO(es)
-	caseDao := dao.NewCaseElasticDAO(es)
-	questionSetDao := dao.NewQuestionSetDAO(es)
-	skillDao := dao.NewSkillElasticDAO(es)
+// NewLocalEngine 进程内的搜索引擎，数据只保存在内存里面，用于开发环境和测试
+func NewLocalEngine() Engine {
+	return local.NewEngine()
+}
+
+func InitRepo(e Engine) (repository.CaseRepo, repository.QuestionRepo, repository.QuestionSetRepo, repository.SkillRepo) {
+	questionDao := dao.NewQuestionDAO(e)
+	caseDao := dao.NewCaseElasticDAO(e)
+	questionSetDao := dao.NewQuestionSetDAO(e)
+	skillDao := dao.NewSkillElasticDAO(e)
 	questionRepo := repository.NewQuestionRepo(questionDao)
 	caseRepo := repository.NewCaseRepo(caseDao)
 	questionSetRepo := repository.NewQuestionSetRepo(questionSetDao)
 	skillRepo := repository.NewSKillRepo(skillDao)
 	return caseRepo, questionRepo, questionSetRepo, skillRepo
 }
-func InitAnyRepo(es *elastic.Client) repository.AnyRepo {
-	InitIndexOnce(es)
-	anyDAO := dao.NewAnyEsDAO(es)
+func InitAnyRepo(e Engine) repository.AnyRepo {
+	anyDAO := dao.NewAnyDAO(e)
 	anyRepo := repository.NewAnyRepo(anyDAO)
 	return anyRepo
 }
 
-func InitSearchSvc(es *elastic.Client) service.SearchService {
-	caseRepo, questionRepo, questionSetRepo, skillRepo := InitRepo(es)
-	return service.NewSearchSvc(questionRepo, questionSetRepo, skillRepo, caseRepo)
-}
-func InitSyncSvc(es *elastic.Client) service.SyncService {
-	anyRepo := InitAnyRepo(es)
-	return service.NewSyncSvc(anyRepo)
+func InitSearchSvc(e Engine) service.SearchService {
+	caseRepo, questionRepo, questionSetRepo, skillRepo := InitRepo(e)
+	projectRepo := repository.NewProjectRepo(dao.NewProjectDAO(e))
+	reviewRepo := repository.NewReviewRepo(dao.NewReviewDAO(e))
+	roadmapRepo := repository.NewRoadmapRepo(dao.NewRoadmapDAO(e))
+	suggestRepo := repository.NewSuggestRepo(dao.NewSuggestDAO(e))
+	return service.NewSearchSvc(questionRepo, questionSetRepo, skillRepo, caseRepo,
+		projectRepo, reviewRepo, roadmapRepo, suggestRepo)
 }
 func initSyncConsumer(svc service.SyncService, q mq.MQ) *event.SyncConsumer {
 	c, err := event.NewSyncConsumer(svc, q)
@@ -92,6 +143,29 @@ func initSyncConsumer(svc service.SyncService, q mq.MQ) *event.SyncConsumer {
 	return c
 }
 
+func initQueryLogConsumer(svc service.AnalyticsService, q mq.MQ) *event.QueryLogConsumer {
+	c, err := event.NewQueryLogConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+func initUserDeletionConsumer(svc service.AnalyticsService, q mq.MQ) *event.UserDeletionConsumer {
+	c, err := event.NewUserDeletionConsumer(svc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
+
+type Engine = engine.Engine
 type SearchService = service.SearchService
 type SyncService = service.SyncService
 type Handler = web.Handler
+type AdminHandler = web.AdminHandler
+type ReindexJob = job.ReindexJob
+type ReindexService = service.ReindexService
+type AnalyticsService = service.AnalyticsService
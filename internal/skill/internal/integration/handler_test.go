@@ -17,6 +17,8 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/cases"
 	casemocks "github.com/ecodeclub/webook/internal/cases/mocks"
+	"github.com/ecodeclub/webook/internal/permission"
+	permissionmocks "github.com/ecodeclub/webook/internal/permission/mocks"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	quemocks "github.com/ecodeclub/webook/internal/question/mocks"
 	evemocks "github.com/ecodeclub/webook/internal/skill/internal/event/mocks"
@@ -160,12 +162,17 @@ func (s *HandlerTestSuite) SetupSuite() {
 			return res, nil
 		}).AnyTimes()
 
+	rbacSvc := permissionmocks.NewMockRBACService(ctrl)
+	rbacSvc.EXPECT().Check(gomock.Any(), int64(uid), "skill", "edit").
+		Return(true, nil).AnyTimes()
+
 	s.ctrl = ctrl
 	s.producer = evemocks.NewMockSyncEventProducer(s.ctrl)
 
 	handler, err := startup.InitHandler(
 		&baguwen.Module{Svc: queSvc, SetSvc: queSetSvc, ExamSvc: examSvc},
 		&cases.Module{Svc: caseSvc, SetSvc: caseSetSvc, ExamineSvc: caseExamSvc},
+		&permission.Module{RBACSvc: rbacSvc},
 		s.producer,
 	)
 	require.NoError(s.T(), err)
@@ -175,7 +182,6 @@ func (s *HandlerTestSuite) SetupSuite() {
 		ctx.Set("_session", session.NewMemorySession(session.Claims{
 			Uid: uid,
 			Data: map[string]string{
-				"creator":   "true",
 				"memberDDL": strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10),
 			},
 		}))
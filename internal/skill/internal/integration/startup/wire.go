@@ -7,6 +7,8 @@ import (
 
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/skill/internal/event"
 	"github.com/ecodeclub/webook/internal/skill/internal/repository"
@@ -20,7 +22,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func InitHandler(bm *baguwen.Module, cm *cases.Module, p event.SyncEventProducer) (*web.Handler, error) {
+func InitHandler(bm *baguwen.Module, cm *cases.Module, perm *permission.Module, p event.SyncEventProducer) (*web.Handler, error) {
 	wire.Build(testioc.BaseSet, initHandler)
 	return new(web.Handler), nil
 }
@@ -30,12 +32,15 @@ func initHandler(
 	ec ecache.Cache,
 	queModule *baguwen.Module,
 	caseModule *cases.Module,
+	permModule *permission.Module,
 	p event.SyncEventProducer) (*web.Handler, error) {
 	wire.Build(
 		InitSkillDAO,
 		wire.FieldsOf(new(*baguwen.Module), "Svc", "SetSvc"),
 		wire.FieldsOf(new(*cases.Module), "ExamineSvc", "Svc", "SetSvc"),
 		wire.FieldsOf(new(*baguwen.Module), "ExamSvc"),
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
 		cache.NewSkillCache,
 		repository.NewSkillRepo,
 		service.NewSkillService,
@@ -11,6 +11,8 @@ import (
 
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/skill/internal/event"
 	"github.com/ecodeclub/webook/internal/skill/internal/repository"
@@ -25,17 +27,17 @@ import (
 
 // Injectors from wire.go:
 
-func InitHandler(bm *baguwen.Module, cm *cases.Module, p event.SyncEventProducer) (*web.Handler, error) {
+func InitHandler(bm *baguwen.Module, cm *cases.Module, perm *permission.Module, p event.SyncEventProducer) (*web.Handler, error) {
 	db := testioc.InitDB()
 	cache := testioc.InitCache()
-	handler, err := initHandler(db, cache, bm, cm, p)
+	handler, err := initHandler(db, cache, bm, cm, perm, p)
 	if err != nil {
 		return nil, err
 	}
 	return handler, nil
 }
 
-func initHandler(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseModule *cases.Module, p event.SyncEventProducer) (*web.Handler, error) {
+func initHandler(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseModule *cases.Module, permModule *permission.Module, p event.SyncEventProducer) (*web.Handler, error) {
 	skillDAO := InitSkillDAO(db)
 	skillCache := cache.NewSkillCache(ec)
 	skillRepo := repository.NewSkillRepo(skillDAO, skillCache)
@@ -46,7 +48,9 @@ func initHandler(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseMo
 	examineService := caseModule.ExamineSvc
 	questionSetService := queModule.SetSvc
 	serviceExamineService := queModule.ExamSvc
-	handler := web.NewHandler(skillService, serviceService, service2, caseSetService, examineService, questionSetService, serviceExamineService)
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	handler := web.NewHandler(skillService, serviceService, service2, caseSetService, examineService, questionSetService, serviceExamineService, checkRBACMiddlewareBuilder)
 	return handler, nil
 }
 
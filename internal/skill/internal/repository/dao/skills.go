@@ -19,6 +19,7 @@ type SkillDAO interface {
 	List(ctx context.Context, offset, limit int) ([]Skill, error)
 	// Info 详情
 	Info(ctx context.Context, id int64) (Skill, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]Skill, error)
 	SkillLevelInfo(tx context.Context, id int64) ([]SkillLevel, error)
 	SkillLevelInfoByIDs(tx context.Context, ids []int64) ([]SkillLevel, error)
 	// Refs id 为skill的id
@@ -27,6 +28,10 @@ type SkillDAO interface {
 	RefsByLevelIDs(ctx context.Context, ids []int64) ([]SkillRef, error)
 	Count(ctx context.Context) (int64, error)
 	SkillLevelFirst(ctx context.Context, id int64) (SkillLevel, error)
+	// SkillLevelsByIDs ids 为 SkillLevel 的 ID
+	SkillLevelsByIDs(ctx context.Context, ids []int64) ([]SkillLevel, error)
+	// LevelIDsByRef 关联了某道题目或者某个案例的 SkillLevel 的 ID
+	LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error)
 }
 
 type skillDAO struct {
@@ -39,6 +44,21 @@ func (s *skillDAO) SkillLevelFirst(ctx context.Context, id int64) (SkillLevel, e
 	return level, err
 }
 
+func (s *skillDAO) SkillLevelsByIDs(ctx context.Context, ids []int64) ([]SkillLevel, error) {
+	var res []SkillLevel
+	err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
+	return res, err
+}
+
+func (s *skillDAO) LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error) {
+	var res []int64
+	err := s.db.WithContext(ctx).Model(&SkillRef{}).
+		Distinct("slid").
+		Where("rtype = ? AND rid = ?", rtype, rid).
+		Pluck("slid", &res).Error
+	return res, err
+}
+
 func (s *skillDAO) RefsByLevelIDs(ctx context.Context, ids []int64) ([]SkillRef, error) {
 	var res []SkillRef
 	err := s.db.WithContext(ctx).Where("slid IN ?", ids).Find(&res).Error
@@ -143,6 +163,12 @@ func (s *skillDAO) Info(ctx context.Context, id int64) (Skill, error) {
 	return skill, err
 }
 
+func (s *skillDAO) FindByIDs(ctx context.Context, ids []int64) ([]Skill, error) {
+	var skills []Skill
+	err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&skills).Error
+	return skills, err
+}
+
 func (s *skillDAO) SkillLevelInfo(ctx context.Context, id int64) ([]SkillLevel, error) {
 	var skillLevels []SkillLevel
 	err := s.db.WithContext(ctx).Model(&SkillLevel{}).Where("sid = ? ", id).Find(&skillLevels).Error
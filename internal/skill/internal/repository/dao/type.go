@@ -44,9 +44,9 @@ type SkillRef struct {
 	// Skill Level 的 ID
 	Slid int64 `gorm:"index"`
 	// 相关 id，
-	Rid int64
+	Rid int64 `gorm:"index:rid_rtype"`
 	// 关联的类型，question-八股文，case-案例
-	Rtype string
+	Rtype string `gorm:"index:rid_rtype;type:varchar(64)"`
 	Ctime int64
 	Utime int64 `gorm:"index"`
 }
@@ -28,6 +28,8 @@ type SkillRepo interface {
 	Count(ctx context.Context) (int64, error)
 	RefsByLevelIDs(ctx context.Context, ids []int64) ([]domain.SkillLevel, error)
 	LevelInfo(ctx context.Context, id int64) (domain.SkillLevel, error)
+	LevelsByIDs(ctx context.Context, ids []int64) ([]domain.Skill, error)
+	LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error)
 }
 type skillRepo struct {
 	skillDao dao.SkillDAO
@@ -58,6 +60,41 @@ func (s *skillRepo) LevelInfo(ctx context.Context, id int64) (domain.SkillLevel,
 	return level, nil
 }
 
+func (s *skillRepo) LevelsByIDs(ctx context.Context, ids []int64) ([]domain.Skill, error) {
+	var eg errgroup.Group
+	var levels []dao.SkillLevel
+	var refs []dao.SkillRef
+	eg.Go(func() error {
+		var err error
+		levels, err = s.skillDao.SkillLevelsByIDs(ctx, ids)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		refs, err = s.skillDao.RefsByLevelIDs(ctx, ids)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	slm := mapx.NewMultiBuiltinMap[int64, dao.SkillLevel](len(levels))
+	for _, sl := range levels {
+		_ = slm.Put(sl.Sid, sl)
+	}
+	skills, err := s.skillDao.FindByIDs(ctx, slm.Keys())
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(skills, func(idx int, src dao.Skill) domain.Skill {
+		skSL, _ := slm.Get(src.Id)
+		return s.skillToInfoDomain(src, skSL, refs)
+	}), nil
+}
+
+func (s *skillRepo) LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error) {
+	return s.skillDao.LevelIDsByRef(ctx, rtype, rid)
+}
+
 func (s *skillRepo) RefsByLevelIDs(ctx context.Context, ids []int64) ([]domain.SkillLevel, error) {
 	refs, err := s.skillDao.RefsByLevelIDs(ctx, ids)
 	if err != nil {
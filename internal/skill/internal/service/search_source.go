@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/skill/internal/event"
+	"github.com/ecodeclub/webook/internal/skill/internal/repository"
+)
+
+// searchSource 搜索重建索引的时候读取全量的技能
+type searchSource struct {
+	repo      repository.SkillRepo
+	batchSize int
+}
+
+func NewSearchSource(repo repository.SkillRepo) searchx.Source {
+	return &searchSource{repo: repo, batchSize: 100}
+}
+
+func (s *searchSource) Biz() string {
+	return "skill"
+}
+
+func (s *searchSource) Ids(ctx context.Context) ([]int64, error) {
+	var res []int64
+	for offset := 0; ; offset += s.batchSize {
+		skills, err := s.repo.List(ctx, offset, s.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, sk := range skills {
+			res = append(res, sk.ID)
+		}
+		if len(skills) < s.batchSize {
+			return res, nil
+		}
+	}
+}
+
+func (s *searchSource) Doc(ctx context.Context, id int64) (string, error) {
+	sk, err := s.repo.Info(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return event.NewSkillEvent(sk).Data, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ecodeclub/webook/internal/skill/internal/repository"
 )
 
+//go:generate mockgen -source=./skill.go -destination=../../mocks/skill.mock.go -package=skillmocks -typed=true SkillService
 type SkillService interface {
 	// Save 保存基本信息
 	Save(ctx context.Context, skill domain.Skill) (int64, error)
@@ -22,6 +23,12 @@ type SkillService interface {
 	RefsByLevelIDs(ctx context.Context, ids []int64) ([]domain.SkillLevel, error)
 
 	LevelInfo(ctx context.Context, slid int64) (domain.SkillLevel, error)
+	// LevelsByIDs ids 为 SkillLevel 的 ID
+	// 返回的 Skill 里面只有 ids 对应的等级，等级里面带上了关联信息
+	LevelsByIDs(ctx context.Context, ids []int64) ([]domain.Skill, error)
+	// LevelIDsByRef 关联了某道题目或者某个案例的 SkillLevel 的 ID
+	// rtype 为 question 或者 case
+	LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error)
 }
 
 type skillService struct {
@@ -35,6 +42,14 @@ func (s *skillService) LevelInfo(ctx context.Context, slid int64) (domain.SkillL
 	return s.repo.LevelInfo(ctx, slid)
 }
 
+func (s *skillService) LevelsByIDs(ctx context.Context, ids []int64) ([]domain.Skill, error) {
+	return s.repo.LevelsByIDs(ctx, ids)
+}
+
+func (s *skillService) LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error) {
+	return s.repo.LevelIDsByRef(ctx, rtype, rid)
+}
+
 func (s *skillService) RefsByLevelIDs(ctx context.Context, ids []int64) ([]domain.SkillLevel, error) {
 	return s.repo.RefsByLevelIDs(ctx, ids)
 }
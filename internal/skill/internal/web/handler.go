@@ -2,8 +2,6 @@ package web
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
 	"github.com/ecodeclub/webook/internal/cases"
 	baguwen "github.com/ecodeclub/webook/internal/question"
@@ -12,6 +10,7 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ecodeclub/webook/internal/skill/internal/domain"
 	"github.com/ecodeclub/webook/internal/skill/internal/service"
 	"github.com/gin-gonic/gin"
@@ -26,6 +25,7 @@ type Handler struct {
 	caseExamSvc cases.ExamineService
 	queSetSvc   baguwen.QuestionSetService
 	queExamSvc  baguwen.ExamService
+	rbac        *middleware.CheckRBACMiddlewareBuilder
 	logger      *elog.Component
 }
 
@@ -35,9 +35,11 @@ func NewHandler(svc service.SkillService,
 	caseSetSvc cases.SetService,
 	caseExamSvc cases.ExamineService,
 	queSetSvc baguwen.QuestionSetService,
-	examSvc baguwen.ExamService) *Handler {
+	examSvc baguwen.ExamService,
+	rbac *middleware.CheckRBACMiddlewareBuilder) *Handler {
 	return &Handler{
 		svc:         svc,
+		rbac:        rbac,
 		logger:      elog.DefaultLogger,
 		queSvc:      queSvc,
 		queSetSvc:   queSetSvc,
@@ -49,8 +51,10 @@ func NewHandler(svc service.SkillService,
 }
 
 func (h *Handler) PrivateRoutes(server *gin.Engine) {
-	server.POST("/skill/save", ginx.S(h.Permission), ginx.B[SaveReq](h.Save))
-	server.POST("/skill/save-refs", ginx.S(h.Permission), ginx.B(h.SaveRefs))
+	// 编辑技能需要 (skill, edit) 权限
+	edit := h.rbac.Build("skill", "edit")
+	server.POST("/skill/save", edit, ginx.B[SaveReq](h.Save))
+	server.POST("/skill/save-refs", edit, ginx.B(h.SaveRefs))
 }
 
 func (h *Handler) MemberRoutes(server *gin.Engine) {
@@ -62,14 +66,6 @@ func (h *Handler) MemberRoutes(server *gin.Engine) {
 func (h *Handler) PublicRoutes(server *gin.Engine) {
 }
 
-func (h *Handler) Permission(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
-	if sess.Claims().Get("creator").StringOrDefault("") != "true" {
-		ctx.AbortWithStatus(http.StatusInternalServerError)
-		return ginx.Result{}, fmt.Errorf("非法访问创作中心 uid: %d", sess.Claims().Uid)
-	}
-	return ginx.Result{}, ginx.ErrNoResponse
-}
-
 func (h *Handler) Save(ctx *ginx.Context, req SaveReq) (ginx.Result, error) {
 	skill := req.Skill.toDomain()
 	id, err := h.svc.Save(ctx, skill)
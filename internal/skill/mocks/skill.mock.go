@@ -0,0 +1,353 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./skill.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./skill.go -destination=../../mocks/skill.mock.go -package=skillmocks -typed=true SkillService
+//
+
+// Package skillmocks is a generated GoMock package.
+package skillmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/skill/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSkillService is a mock of SkillService interface.
+type MockSkillService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSkillServiceMockRecorder
+}
+
+// MockSkillServiceMockRecorder is the mock recorder for MockSkillService.
+type MockSkillServiceMockRecorder struct {
+	mock *MockSkillService
+}
+
+// NewMockSkillService creates a new mock instance.
+func NewMockSkillService(ctrl *gomock.Controller) *MockSkillService {
+	mock := &MockSkillService{ctrl: ctrl}
+	mock.recorder = &MockSkillServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSkillService) EXPECT() *MockSkillServiceMockRecorder {
+	return m.recorder
+}
+
+// Info mocks base method.
+func (m *MockSkillService) Info(ctx context.Context, id int64) (domain.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info", ctx, id)
+	ret0, _ := ret[0].(domain.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockSkillServiceMockRecorder) Info(ctx, id any) *MockSkillServiceInfoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockSkillService)(nil).Info), ctx, id)
+	return &MockSkillServiceInfoCall{Call: call}
+}
+
+// MockSkillServiceInfoCall wrap *gomock.Call
+type MockSkillServiceInfoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceInfoCall) Return(arg0 domain.Skill, arg1 error) *MockSkillServiceInfoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceInfoCall) Do(f func(context.Context, int64) (domain.Skill, error)) *MockSkillServiceInfoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceInfoCall) DoAndReturn(f func(context.Context, int64) (domain.Skill, error)) *MockSkillServiceInfoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LevelIDsByRef mocks base method.
+func (m *MockSkillService) LevelIDsByRef(ctx context.Context, rtype string, rid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LevelIDsByRef", ctx, rtype, rid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LevelIDsByRef indicates an expected call of LevelIDsByRef.
+func (mr *MockSkillServiceMockRecorder) LevelIDsByRef(ctx, rtype, rid any) *MockSkillServiceLevelIDsByRefCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LevelIDsByRef", reflect.TypeOf((*MockSkillService)(nil).LevelIDsByRef), ctx, rtype, rid)
+	return &MockSkillServiceLevelIDsByRefCall{Call: call}
+}
+
+// MockSkillServiceLevelIDsByRefCall wrap *gomock.Call
+type MockSkillServiceLevelIDsByRefCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceLevelIDsByRefCall) Return(arg0 []int64, arg1 error) *MockSkillServiceLevelIDsByRefCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceLevelIDsByRefCall) Do(f func(context.Context, string, int64) ([]int64, error)) *MockSkillServiceLevelIDsByRefCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceLevelIDsByRefCall) DoAndReturn(f func(context.Context, string, int64) ([]int64, error)) *MockSkillServiceLevelIDsByRefCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LevelInfo mocks base method.
+func (m *MockSkillService) LevelInfo(ctx context.Context, slid int64) (domain.SkillLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LevelInfo", ctx, slid)
+	ret0, _ := ret[0].(domain.SkillLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LevelInfo indicates an expected call of LevelInfo.
+func (mr *MockSkillServiceMockRecorder) LevelInfo(ctx, slid any) *MockSkillServiceLevelInfoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LevelInfo", reflect.TypeOf((*MockSkillService)(nil).LevelInfo), ctx, slid)
+	return &MockSkillServiceLevelInfoCall{Call: call}
+}
+
+// MockSkillServiceLevelInfoCall wrap *gomock.Call
+type MockSkillServiceLevelInfoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceLevelInfoCall) Return(arg0 domain.SkillLevel, arg1 error) *MockSkillServiceLevelInfoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceLevelInfoCall) Do(f func(context.Context, int64) (domain.SkillLevel, error)) *MockSkillServiceLevelInfoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceLevelInfoCall) DoAndReturn(f func(context.Context, int64) (domain.SkillLevel, error)) *MockSkillServiceLevelInfoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LevelsByIDs mocks base method.
+func (m *MockSkillService) LevelsByIDs(ctx context.Context, ids []int64) ([]domain.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LevelsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LevelsByIDs indicates an expected call of LevelsByIDs.
+func (mr *MockSkillServiceMockRecorder) LevelsByIDs(ctx, ids any) *MockSkillServiceLevelsByIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LevelsByIDs", reflect.TypeOf((*MockSkillService)(nil).LevelsByIDs), ctx, ids)
+	return &MockSkillServiceLevelsByIDsCall{Call: call}
+}
+
+// MockSkillServiceLevelsByIDsCall wrap *gomock.Call
+type MockSkillServiceLevelsByIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceLevelsByIDsCall) Return(arg0 []domain.Skill, arg1 error) *MockSkillServiceLevelsByIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceLevelsByIDsCall) Do(f func(context.Context, []int64) ([]domain.Skill, error)) *MockSkillServiceLevelsByIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceLevelsByIDsCall) DoAndReturn(f func(context.Context, []int64) ([]domain.Skill, error)) *MockSkillServiceLevelsByIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockSkillService) List(ctx context.Context, offset, limit int) ([]domain.Skill, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Skill)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSkillServiceMockRecorder) List(ctx, offset, limit any) *MockSkillServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSkillService)(nil).List), ctx, offset, limit)
+	return &MockSkillServiceListCall{Call: call}
+}
+
+// MockSkillServiceListCall wrap *gomock.Call
+type MockSkillServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceListCall) Return(arg0 []domain.Skill, arg1 int64, arg2 error) *MockSkillServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceListCall) Do(f func(context.Context, int, int) ([]domain.Skill, int64, error)) *MockSkillServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceListCall) DoAndReturn(f func(context.Context, int, int) ([]domain.Skill, int64, error)) *MockSkillServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RefsByLevelIDs mocks base method.
+func (m *MockSkillService) RefsByLevelIDs(ctx context.Context, ids []int64) ([]domain.SkillLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefsByLevelIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.SkillLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefsByLevelIDs indicates an expected call of RefsByLevelIDs.
+func (mr *MockSkillServiceMockRecorder) RefsByLevelIDs(ctx, ids any) *MockSkillServiceRefsByLevelIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefsByLevelIDs", reflect.TypeOf((*MockSkillService)(nil).RefsByLevelIDs), ctx, ids)
+	return &MockSkillServiceRefsByLevelIDsCall{Call: call}
+}
+
+// MockSkillServiceRefsByLevelIDsCall wrap *gomock.Call
+type MockSkillServiceRefsByLevelIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceRefsByLevelIDsCall) Return(arg0 []domain.SkillLevel, arg1 error) *MockSkillServiceRefsByLevelIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceRefsByLevelIDsCall) Do(f func(context.Context, []int64) ([]domain.SkillLevel, error)) *MockSkillServiceRefsByLevelIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceRefsByLevelIDsCall) DoAndReturn(f func(context.Context, []int64) ([]domain.SkillLevel, error)) *MockSkillServiceRefsByLevelIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockSkillService) Save(ctx context.Context, skill domain.Skill) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, skill)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSkillServiceMockRecorder) Save(ctx, skill any) *MockSkillServiceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSkillService)(nil).Save), ctx, skill)
+	return &MockSkillServiceSaveCall{Call: call}
+}
+
+// MockSkillServiceSaveCall wrap *gomock.Call
+type MockSkillServiceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceSaveCall) Return(arg0 int64, arg1 error) *MockSkillServiceSaveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceSaveCall) Do(f func(context.Context, domain.Skill) (int64, error)) *MockSkillServiceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceSaveCall) DoAndReturn(f func(context.Context, domain.Skill) (int64, error)) *MockSkillServiceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveRefs mocks base method.
+func (m *MockSkillService) SaveRefs(ctx context.Context, skill domain.Skill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRefs", ctx, skill)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRefs indicates an expected call of SaveRefs.
+func (mr *MockSkillServiceMockRecorder) SaveRefs(ctx, skill any) *MockSkillServiceSaveRefsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRefs", reflect.TypeOf((*MockSkillService)(nil).SaveRefs), ctx, skill)
+	return &MockSkillServiceSaveRefsCall{Call: call}
+}
+
+// MockSkillServiceSaveRefsCall wrap *gomock.Call
+type MockSkillServiceSaveRefsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSkillServiceSaveRefsCall) Return(arg0 error) *MockSkillServiceSaveRefsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSkillServiceSaveRefsCall) Do(f func(context.Context, domain.Skill) error) *MockSkillServiceSaveRefsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSkillServiceSaveRefsCall) DoAndReturn(f func(context.Context, domain.Skill) error) *MockSkillServiceSaveRefsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
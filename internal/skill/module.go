@@ -0,0 +1,45 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skill
+
+import (
+	"github.com/ecodeclub/webook/internal/skill/internal/domain"
+	"github.com/ecodeclub/webook/internal/skill/internal/repository/dao"
+	"github.com/ecodeclub/webook/internal/skill/internal/service"
+	"github.com/ecodeclub/webook/internal/skill/internal/web"
+)
+
+type Module struct {
+	Svc Service
+	Hdl *Handler
+}
+
+type Handler = web.Handler
+type Service = service.SkillService
+type Skill = domain.Skill
+type SkillLevel = domain.SkillLevel
+
+// 关联的类型
+const (
+	RefTypeQuestion = dao.RTypeQuestion
+	RefTypeCase     = dao.RTypeCase
+)
+
+// 技能的等级
+const (
+	LevelBasic        = dao.LevelBasic
+	LevelIntermediate = dao.LevelIntermediate
+	LevelAdvanced     = dao.LevelAdvanced
+)
@@ -20,11 +20,14 @@ import (
 	"sync"
 
 	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	"github.com/ecodeclub/webook/internal/skill/internal/event"
 
 	"github.com/ecodeclub/webook/internal/cases"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ecodeclub/webook/internal/skill/internal/repository"
 	"github.com/ecodeclub/webook/internal/skill/internal/repository/cache"
 	dao2 "github.com/ecodeclub/webook/internal/skill/internal/repository/dao"
@@ -38,12 +41,13 @@ import (
 	"gorm.io/gorm"
 )
 
-func InitHandler(
+func InitModule(
 	db *egorm.Component,
 	ec ecache.Cache,
 	queModule *baguwen.Module,
 	caseModule *cases.Module,
-	q mq.MQ) (*Handler, error) {
+	permModule *permission.Module,
+	q mq.MQ) (*Module, error) {
 	wire.Build(
 		InitSkillDAO,
 		wire.FieldsOf(new(*baguwen.Module), "Svc"),
@@ -54,9 +58,23 @@ func InitHandler(
 		repository.NewSkillRepo,
 		event.NewSyncEventProducer,
 		service.NewSkillService,
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
 		web.NewHandler,
+		wire.Struct(new(Module), "*"),
 	)
-	return new(Handler), nil
+	return new(Module), nil
+}
+
+// InitSearchSource 搜索重建索引的时候使用
+func InitSearchSource(db *egorm.Component, ec ecache.Cache) searchx.Source {
+	wire.Build(
+		InitSkillDAO,
+		cache.NewSkillCache,
+		repository.NewSkillRepo,
+		service.NewSearchSource,
+	)
+	return nil
 }
 
 var daoOnce = sync.Once{}
@@ -74,5 +92,3 @@ func InitSkillDAO(db *egorm.Component) dao2.SkillDAO {
 	InitTableOnce(db)
 	return dao2.NewSkillDAO(db)
 }
-
-type Handler = web.Handler
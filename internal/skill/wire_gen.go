@@ -12,6 +12,9 @@ import (
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/skill/internal/event"
 	"github.com/ecodeclub/webook/internal/skill/internal/repository"
@@ -25,7 +28,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitHandler(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseModule *cases.Module, q mq.MQ) (*web.Handler, error) {
+func InitModule(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseModule *cases.Module, permModule *permission.Module, q mq.MQ) (*Module, error) {
 	skillDAO := InitSkillDAO(db)
 	skillCache := cache.NewSkillCache(ec)
 	skillRepo := repository.NewSkillRepo(skillDAO, skillCache)
@@ -40,8 +43,23 @@ func InitHandler(db *gorm.DB, ec ecache.Cache, queModule *baguwen.Module, caseMo
 	examineService := caseModule.ExamineSvc
 	questionSetService := queModule.SetSvc
 	serviceExamineService := queModule.ExamSvc
-	handler := web.NewHandler(skillService, serviceService, service2, caseSetService, examineService, questionSetService, serviceExamineService)
-	return handler, nil
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	handler := web.NewHandler(skillService, serviceService, service2, caseSetService, examineService, questionSetService, serviceExamineService, checkRBACMiddlewareBuilder)
+	module := &Module{
+		Svc: skillService,
+		Hdl: handler,
+	}
+	return module, nil
+}
+
+// InitSearchSource 搜索重建索引的时候使用
+func InitSearchSource(db *gorm.DB, ec ecache.Cache) searchx.Source {
+	skillDAO := InitSkillDAO(db)
+	skillCache := cache.NewSkillCache(ec)
+	skillRepo := repository.NewSkillRepo(skillDAO, skillCache)
+	source := service.NewSearchSource(skillRepo)
+	return source
 }
 
 // wire.go:
@@ -61,5 +79,3 @@ func InitSkillDAO(db *egorm.Component) dao.SkillDAO {
 	InitTableOnce(db)
 	return dao.NewSkillDAO(db)
 }
-
-type Handler = web.Handler
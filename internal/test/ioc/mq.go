@@ -67,6 +67,18 @@ func initMQ() (mq.MQ, error) {
 			Name:       "user_registration_events",
 			Partitions: 1,
 		},
+		{
+			Name:       "user_merge_events",
+			Partitions: 1,
+		},
+		{
+			Name:       "user_deletion_events",
+			Partitions: 1,
+		},
+		{
+			Name:       "user_deletion_done_events",
+			Partitions: 1,
+		},
 		{
 			Name:       "credit_increase_events",
 			Partitions: 1,
@@ -95,6 +107,18 @@ func initMQ() (mq.MQ, error) {
 			Name:       "knowledge_base_upload_topic",
 			Partitions: 1,
 		},
+		{
+			Name:       "cache_invalidation_events",
+			Partitions: 1,
+		},
+		{
+			Name:       "examine_events",
+			Partitions: 1,
+		},
+		{
+			Name:       "search_query_logs",
+			Partitions: 1,
+		},
 	}
 	// 替换用内存实现，方便测试
 	qq := memory.NewMQ()
@@ -1,8 +1,11 @@
 package testioc
 
 import (
+	"time"
+
 	"github.com/ecodeclub/ecache"
 	eredis "github.com/ecodeclub/ecache/redis"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -31,3 +34,8 @@ func InitRedis() redis.Cmdable {
 		Addr: "localhost:6379",
 	})
 }
+
+// InitReadThroughCache 测试里面会直接修改数据库，所以不缓存
+func InitReadThroughCache() *cachex.ReadThroughCache {
+	return cachex.NewReadThroughCache(cachex.NopCache{}, time.Minute)
+}
@@ -2,4 +2,4 @@ package testioc
 
 import "github.com/google/wire"
 
-var BaseSet = wire.NewSet(InitDB, InitCache, InitMQ, InitES)
+var BaseSet = wire.NewSet(InitDB, InitCache, InitMQ, InitES, InitReadThroughCache)
@@ -15,16 +15,23 @@
 package user
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/member"
 	"github.com/ecodeclub/webook/internal/permission"
 	"github.com/ecodeclub/webook/internal/user/internal/event"
+	"github.com/ecodeclub/webook/internal/user/internal/event/consumer"
+	"github.com/ecodeclub/webook/internal/user/internal/repository"
 	"github.com/ecodeclub/webook/internal/user/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
+	"github.com/ecodeclub/webook/internal/user/internal/service/sender"
 	"github.com/ecodeclub/webook/internal/user/internal/web"
 	"github.com/ego-component/egorm"
+	"github.com/gotomicro/ego/core/eapp"
 	"github.com/gotomicro/ego/core/econf"
 )
 
@@ -32,10 +39,86 @@ func iniHandler(
 	weSvc wechatWebOAuth2Service,
 	weMiniSvc wechatMiniOAuth2Service,
 	userSvc service.UserService,
+	smsCodeSvc service.SMSCodeService,
+	magicLinkSvc service.MagicLinkService,
+	sessionSvc service.SessionService,
 	memberSvc member.Service,
 	sp session.Provider,
-	permissionSvc permission.Service, creators []string) *Handler {
-	return web.NewHandler(weSvc, weMiniSvc, userSvc, memberSvc, permissionSvc, sp, creators)
+	permissionSvc permission.Service,
+	rbacSvc permission.RBACService) *Handler {
+	return web.NewHandler(weSvc, weMiniSvc, userSvc, smsCodeSvc, magicLinkSvc, sessionSvc, memberSvc, permissionSvc, rbacSvc, sp)
+}
+
+const senderTypeLog = "log"
+
+// initSMSCodeService type 为 tencent 的时候使用腾讯云短信，
+// 打印日志的实现只能在开发环境（EGO_DEBUG=true）使用，避免线上验证码只进了日志
+func initSMSCodeService(repo repository.CodeRepository) service.SMSCodeService {
+	type Config struct {
+		Type    string                  `yaml:"type"`
+		TplID   string                  `yaml:"tplID"`
+		Tencent sender.TencentSMSConfig `yaml:"tencent"`
+	}
+	var cfg Config
+	err := econf.UnmarshalKey("user.sms", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	var smsSender sender.SMSSender
+	switch cfg.Type {
+	case "tencent":
+		smsSender = sender.NewTencentSMSSender(cfg.Tencent)
+	case senderTypeLog:
+		mustDevelopmentMode("user.sms")
+		smsSender = sender.NewLogSMSSender()
+	default:
+		panic(fmt.Errorf("未知的短信服务商 %q", cfg.Type))
+	}
+	return service.NewSMSCodeService(repo, smsSender, cfg.TplID)
+}
+
+// initMagicLinkService type 为 smtp 的时候通过 SMTP 发送，打印日志的实现和短信一样只能在开发环境使用
+func initMagicLinkService(repo repository.CodeRepository) service.MagicLinkService {
+	type Config struct {
+		Type     string            `yaml:"type"`
+		LoginURL string            `yaml:"loginURL"`
+		SMTP     sender.SMTPConfig `yaml:"smtp"`
+	}
+	var cfg Config
+	err := econf.UnmarshalKey("user.email", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	var emailSender sender.EmailSender
+	switch cfg.Type {
+	case "smtp":
+		emailSender = sender.NewSMTPEmailSender(cfg.SMTP)
+	case senderTypeLog:
+		mustDevelopmentMode("user.email")
+		emailSender = sender.NewLogEmailSender()
+	default:
+		panic(fmt.Errorf("未知的邮件服务商 %q", cfg.Type))
+	}
+	return service.NewMagicLinkService(repo, emailSender, cfg.LoginURL)
+}
+
+func mustDevelopmentMode(key string) {
+	if !eapp.IsDevelopmentMode() {
+		panic(fmt.Errorf("%s 只有开发环境才能使用打印日志的实现，请设置 EGO_DEBUG=true 或者配置真实的服务商", key))
+	}
+}
+
+// initSessionService maxActive 是一个账号最多可以同时登录几个设备，不配置的时候不限制
+func initSessionService(repo repository.SessionRepository) service.SessionService {
+	type Config struct {
+		MaxActive int `yaml:"maxActive"`
+	}
+	var cfg Config
+	err := econf.UnmarshalKey("user.session", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewSessionService(repo, cfg.MaxActive)
 }
 
 func initWechatMiniOAuthService() wechatMiniOAuth2Service {
@@ -80,3 +163,21 @@ func initRegistrationEventProducer(q mq.MQ) event.RegistrationEventProducer {
 	}
 	return producer
 }
+
+func initMergeEventProducer(q mq.MQ) event.MergeEventProducer {
+	producer, err := event.NewMergeEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return producer
+}
+
+func initUserDeletionConsumer(svc service.UserService,
+	sessionSvc service.SessionService, q mq.MQ) *consumer.UserDeletionConsumer {
+	c, err := consumer.NewUserDeletionConsumer(svc, sessionSvc, q)
+	if err != nil {
+		panic(err)
+	}
+	c.Start(context.Background())
+	return c
+}
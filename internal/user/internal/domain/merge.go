@@ -0,0 +1,26 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Merge 合并账号的记录。源账号的登录方式会转移到目标账号上，
+// 其它模块的数据（积分、会员、权限等）由各个模块收到合并消息之后自己转移
+type Merge struct {
+	Id        int64
+	SourceUid int64
+	TargetUid int64
+	// Operator 发起合并的人，用户自己绑定的时候就是目标账号，否则是管理员
+	Operator int64
+	Ctime    int64
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Session 一次登录产生的会话，登录的时候记录下来，方便用户查看和踢掉自己的会话
+type Session struct {
+	// SSID 和 ginx 里面 session.Claims 的 SSID 是同一个
+	SSID string
+	Uid  int64
+	// Device 目前直接记录 User-Agent
+	Device string
+	IP     string
+	// AppID 来自 X-APP 头部，没有设置的时候是 0
+	AppID uint
+	// 登录时间，毫秒数
+	Ctime int64
+}
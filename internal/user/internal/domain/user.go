@@ -19,6 +19,9 @@ type User struct {
 	Avatar   string
 	Nickname string
 	SN       string
+	// Phone 和 Email 可以用来登录，没有绑定的时候是空字符串
+	Phone string
+	Email string
 	// 不要使用组合，因为你将来可能还有 DingDingInfo 之类的
 	WechatInfo WechatInfo
 }
@@ -1,7 +1,15 @@
 package errs
 
 var (
-	SystemError = ErrorCode{Code: 501001, Msg: "系统错误"}
+	InvalidPhone      = ErrorCode{Code: 401001, Msg: "手机号码格式不对"}
+	InvalidEmail      = ErrorCode{Code: 401002, Msg: "邮箱格式不对"}
+	CodeSendTooMany   = ErrorCode{Code: 401003, Msg: "发送太频繁，请稍后再试"}
+	CodeInvalid       = ErrorCode{Code: 401004, Msg: "验证码不对或者已经过期"}
+	CodeVerifyTooMany = ErrorCode{Code: 401005, Msg: "验证次数太多，请重新获取验证码"}
+	IdentityBound     = ErrorCode{Code: 401006, Msg: "已经绑定了其它账号"}
+	IdentityConflict  = ErrorCode{Code: 401007, Msg: "已经绑定了同类的其它登录方式"}
+	InvalidMerge      = ErrorCode{Code: 401008, Msg: "非法的合并账号请求"}
+	SystemError       = ErrorCode{Code: 501001, Msg: "系统错误"}
 )
 
 type ErrorCode struct {
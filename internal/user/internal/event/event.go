@@ -16,9 +16,32 @@ package event
 
 const (
 	registrationEventName = "user_registration_events"
+	mergeEventName        = "user_merge_events"
+	// UserDeletionEventName 用户模块自己也要消费，清空个人信息
+	UserDeletionEventName     = "user_deletion_events"
+	UserDeletionDoneEventName = "user_deletion_done_events"
 )
 
 type RegistrationEvent struct {
 	Uid            int64  `json:"uid,omitempty"`
 	InvitationCode string `json:"invitationCode,omitempty"`
 }
+
+// MergeEvent 合并账号之后发送，各个模块收到之后把源账号的数据转移到目标账号。
+// Id 是合并记录的 ID，重复消费的时候可以用来去重
+type MergeEvent struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+// UserDeletionEvent 注销账号的冷静期结束之后发出来，各个模块清理自己的数据
+type UserDeletionEvent struct {
+	Uid int64 `json:"uid"`
+}
+
+// UserDeletionDoneEvent 清理完数据之后发出来，所有模块都确认了才算注销完成
+type UserDeletionDoneEvent struct {
+	Uid    int64  `json:"uid"`
+	Module string `json:"module"`
+}
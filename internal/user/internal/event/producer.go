@@ -28,3 +28,11 @@ type RegistrationEventProducer interface {
 func NewRegistrationEventProducer(q mq.MQ) (RegistrationEventProducer, error) {
 	return mqx.NewGeneralProducer[RegistrationEvent](q, registrationEventName)
 }
+
+type MergeEventProducer interface {
+	Produce(ctx context.Context, evt MergeEvent) error
+}
+
+func NewMergeEventProducer(q mq.MQ) (MergeEventProducer, error) {
+	return mqx.NewGeneralProducer[MergeEvent](q, mergeEventName)
+}
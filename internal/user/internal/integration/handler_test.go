@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ecodeclub/webook/internal/pkg/middleware"
 
@@ -52,6 +53,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/econf"
 	"github.com/gotomicro/ego/server/egin"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -63,10 +65,12 @@ type HandleTestSuite struct {
 	mockWeSvc     *svcmocks.MockOAuth2Service
 	mockWeMiniSvc *svcmocks.MockOAuth2Service
 	mockPermSvc   *permissionmocks.MockService
+	rdb           redis.Cmdable
 }
 
 func (s *HandleTestSuite) SetupSuite() {
 	s.db = testioc.InitDB()
+	s.rdb = testioc.InitRedis()
 	err := dao.InitTables(s.db)
 	require.NoError(s.T(), err)
 	econf.Set("server", map[string]any{"debug": true})
@@ -83,14 +87,18 @@ func (s *HandleTestSuite) SetupSuite() {
 		}).AnyTimes()
 	permSvc := permissionmocks.NewMockService(ctrl)
 	s.mockPermSvc = permSvc
+	rbacSvc := permissionmocks.NewMockRBACService(ctrl)
+	rbacSvc.EXPECT().Check(gomock.Any(), gomock.Any(), "admin", "login").
+		Return(false, nil).AnyTimes()
 	wesvc := svcmocks.NewMockOAuth2Service(ctrl)
 	weMiniSvc := svcmocks.NewMockOAuth2Service(ctrl)
 	hdl := startup.InitHandler(wesvc,
 		weMiniSvc,
 		&member.Module{Svc: memSvc},
 		&permission.Module{
-			Svc: permSvc,
-		}, session.DefaultProvider(), nil)
+			Svc:     permSvc,
+			RBACSvc: rbacSvc,
+		}, session.DefaultProvider())
 	s.mockWeSvc = wesvc
 	s.mockWeMiniSvc = weMiniSvc
 	server.Use(func(ctx *gin.Context) {
@@ -339,6 +347,523 @@ func (s *HandleTestSuite) TestVerify() {
 	}
 }
 
+func (s *HandleTestSuite) TestSMSLogin() {
+	const phone = "13800000001"
+	key := "webook:user:code:login:" + phone
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		path     string
+		req      func(t *testing.T) any
+		wantResp test.Result[web.Profile]
+	}{
+		{
+			name:   "手机号码格式不对",
+			before: func(t *testing.T) {},
+			path:   "/users/sms/code/send",
+			req: func(t *testing.T) any {
+				return web.SendSMSCodeReq{Phone: "1380000"}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401001, Msg: "手机号码格式不对"},
+		},
+		{
+			name:   "发送验证码",
+			before: func(t *testing.T) {},
+			path:   "/users/sms/code/send",
+			req: func(t *testing.T) any {
+				return web.SendSMSCodeReq{Phone: phone}
+			},
+			wantResp: test.Result[web.Profile]{Msg: "OK"},
+		},
+		{
+			name:   "发送太频繁",
+			before: func(t *testing.T) {},
+			path:   "/users/sms/code/send",
+			req: func(t *testing.T) any {
+				return web.SendSMSCodeReq{Phone: phone}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401003, Msg: "发送太频繁，请稍后再试"},
+		},
+		{
+			name:   "验证码不对",
+			before: func(t *testing.T) {},
+			path:   "/users/sms/login",
+			req: func(t *testing.T) any {
+				return web.SMSLoginReq{Phone: phone, Code: "wrong"}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401004, Msg: "验证码不对或者已经过期"},
+		},
+		{
+			name: "登录并创建用户",
+			before: func(t *testing.T) {
+				s.mockPermSvc.EXPECT().
+					FindPersonalPermissions(gomock.Any(), gomock.Any()).
+					Return(map[string][]permission.Permission{}, nil)
+			},
+			path: "/users/sms/login",
+			req: func(t *testing.T) any {
+				code, err := s.rdb.Get(context.Background(), key).Result()
+				require.NoError(t, err)
+				return web.SMSLoginReq{Phone: phone, Code: code}
+			},
+			wantResp: test.Result[web.Profile]{
+				Data: web.Profile{Phone: phone, MemberDDL: 1234},
+			},
+		},
+		{
+			name:   "验证码只能用一次",
+			before: func(t *testing.T) {},
+			path:   "/users/sms/login",
+			req: func(t *testing.T) any {
+				return web.SMSLoginReq{Phone: phone, Code: "123456"}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401004, Msg: "验证码不对或者已经过期"},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				tc.path, iox.NewJSONReader(tc.req(t)))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Profile]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			val := recorder.MustScan()
+			// 新用户的 SN 和昵称是随机生成的
+			val.Data.SN = ""
+			val.Data.Nickname = ""
+			assert.Equal(t, tc.wantResp, val)
+		})
+	}
+	var u dao.User
+	err := s.db.Where("phone = ?", phone).First(&u).Error
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), sqlx.NewNullString(phone), u.Phone)
+
+	err = s.rdb.Del(context.Background(), key, key+":cnt", key+":daily").Err()
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandleTestSuite) TestEmailLogin() {
+	const email = "test@meoying.com"
+	key := "webook:user:code:login:" + email
+	// 已经用邮箱注册过的用户，登录的时候不会再创建
+	err := s.db.Create(&dao.User{
+		Id:       124,
+		Avatar:   "mock avatar",
+		Nickname: "nickname",
+		SN:       "mock-sn-124",
+		Email:    sqlx.NewNullString(email),
+		Ctime:    123,
+		Utime:    123,
+	}).Error
+	require.NoError(s.T(), err)
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		path     string
+		req      func(t *testing.T) any
+		wantResp test.Result[web.Profile]
+	}{
+		{
+			name:   "邮箱格式不对",
+			before: func(t *testing.T) {},
+			path:   "/users/email/link/send",
+			req: func(t *testing.T) any {
+				return web.SendMagicLinkReq{Email: "meoying.com"}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401002, Msg: "邮箱格式不对"},
+		},
+		{
+			name:   "发送登录链接",
+			before: func(t *testing.T) {},
+			path:   "/users/email/link/send",
+			req: func(t *testing.T) any {
+				// 大小写和前后的空格不影响，和注册时候的邮箱是同一个
+				return web.SendMagicLinkReq{Email: " Test@Meoying.com "}
+			},
+			wantResp: test.Result[web.Profile]{Msg: "OK"},
+		},
+		{
+			name:   "token 不对",
+			before: func(t *testing.T) {},
+			path:   "/users/email/login",
+			req: func(t *testing.T) any {
+				return web.EmailLoginReq{Email: email, Token: "wrong"}
+			},
+			wantResp: test.Result[web.Profile]{Code: 401004, Msg: "验证码不对或者已经过期"},
+		},
+		{
+			name: "登录已有用户",
+			before: func(t *testing.T) {
+				s.mockPermSvc.EXPECT().
+					FindPersonalPermissions(gomock.Any(), gomock.Any()).
+					Return(map[string][]permission.Permission{}, nil)
+			},
+			path: "/users/email/login",
+			req: func(t *testing.T) any {
+				token, err := s.rdb.Get(context.Background(), key).Result()
+				require.NoError(t, err)
+				return web.EmailLoginReq{Email: "TEST@meoying.com", Token: token}
+			},
+			wantResp: test.Result[web.Profile]{
+				Data: web.Profile{
+					SN:        "mock-sn-124",
+					Nickname:  "nickname",
+					Avatar:    "mock avatar",
+					Email:     email,
+					MemberDDL: 1234,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				tc.path, iox.NewJSONReader(tc.req(t)))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Profile]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
+	var cnt int64
+	err = s.db.Model(&dao.User{}).Where("email = ?", email).Count(&cnt).Error
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), cnt)
+
+	err = s.rdb.Del(context.Background(), key, key+":cnt", key+":daily").Err()
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+}
+
+// TestCodeIPLimit 同一个 IP 换着手机号码发送验证码，或者换着手机号码猜验证码，都有次数限制
+func (s *HandleTestSuite) TestCodeIPLimit() {
+	t := s.T()
+	const ip = "10.0.0.1"
+	call := func(path string, body any) test.Result[web.Profile] {
+		req, err := http.NewRequest(http.MethodPost, path, iox.NewJSONReader(body))
+		require.NoError(t, err)
+		req.Header.Set("content-type", "application/json")
+		req.RemoteAddr = ip + ":12345"
+		recorder := test.NewJSONResponseRecorder[web.Profile]()
+		s.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan()
+	}
+	keys := []string{"webook:user:code:ip:send:" + ip, "webook:user:code:ip:verify:" + ip}
+	for idx := 0; idx < 21; idx++ {
+		phone := fmt.Sprintf("1390000%04d", idx)
+		key := "webook:user:code:login:" + phone
+		keys = append(keys, key, key+":cnt", key+":daily")
+	}
+
+	for idx := 0; idx < 20; idx++ {
+		res := call("/users/sms/code/send", web.SendSMSCodeReq{Phone: fmt.Sprintf("1390000%04d", idx)})
+		assert.Equal(t, test.Result[web.Profile]{Msg: "OK"}, res)
+	}
+	res := call("/users/sms/code/send", web.SendSMSCodeReq{Phone: "13900000020"})
+	assert.Equal(t, test.Result[web.Profile]{Code: 401003, Msg: "发送太频繁，请稍后再试"}, res)
+
+	// 每个手机号码都还没有用完三次验证机会，但是这个 IP 一共失败了五十次，就不能再验证了
+	for idx := 0; idx < 50; idx++ {
+		res = call("/users/sms/login", web.SMSLoginReq{Phone: fmt.Sprintf("1390000%04d", idx%20), Code: "wrong"})
+		assert.Equal(t, 401004, res.Code)
+	}
+	code, err := s.rdb.Get(context.Background(), "webook:user:code:login:13900000019").Result()
+	require.NoError(t, err)
+	res = call("/users/sms/login", web.SMSLoginReq{Phone: "13900000019", Code: code})
+	assert.Equal(t, 401005, res.Code)
+
+	err = s.rdb.Del(context.Background(), keys...).Err()
+	require.NoError(t, err)
+}
+
+func (s *HandleTestSuite) TestBindPhone() {
+	const (
+		phone      = "13800000002"
+		otherPhone = "13800000003"
+	)
+	err := s.db.Create(&[]dao.User{
+		{Id: 123, SN: "sn-123", Email: sqlx.NewNullString("bind@meoying.com")},
+		{Id: 456, SN: "sn-456", Phone: sqlx.NewNullString(phone)},
+	}).Error
+	require.NoError(s.T(), err)
+	// 源账号还登录着，合并之后要被踢掉
+	err = s.rdb.HSet(context.Background(), "webook:user:sessions:456", "ssid-456", `{"uid":456}`).Err()
+	require.NoError(s.T(), err)
+	err = s.rdb.Set(context.Background(), "session:ssid-456", "1", time.Minute).Err()
+	require.NoError(s.T(), err)
+	// 直接写入验证码，避开发送频率的限制
+	setCode := func(t *testing.T, phone string) {
+		key := "webook:user:code:bind:" + phone
+		err := s.rdb.Set(context.Background(), key, "123456", time.Minute).Err()
+		require.NoError(t, err)
+		err = s.rdb.Set(context.Background(), key+":cnt", 3, time.Minute).Err()
+		require.NoError(t, err)
+	}
+	testCases := []struct {
+		name     string
+		before   func(t *testing.T)
+		req      web.BindPhoneReq
+		wantResp test.Result[web.Profile]
+	}{
+		{
+			name:     "验证码不对",
+			before:   func(t *testing.T) {},
+			req:      web.BindPhoneReq{Phone: phone, Code: "123456"},
+			wantResp: test.Result[web.Profile]{Code: 401004, Msg: "验证码不对或者已经过期"},
+		},
+		{
+			name: "已经绑定了其它账号",
+			before: func(t *testing.T) {
+				setCode(t, phone)
+			},
+			req:      web.BindPhoneReq{Phone: phone, Code: "123456"},
+			wantResp: test.Result[web.Profile]{Code: 401006, Msg: "已经绑定了其它账号"},
+		},
+		{
+			name: "合并另外一个账号",
+			before: func(t *testing.T) {
+				setCode(t, phone)
+			},
+			req: web.BindPhoneReq{Phone: phone, Code: "123456", Merge: true},
+			wantResp: test.Result[web.Profile]{
+				Data: web.Profile{SN: "sn-123", Phone: phone, Email: "bind@meoying.com"},
+			},
+		},
+		{
+			name: "已经绑定了其它手机号码",
+			before: func(t *testing.T) {
+				setCode(t, otherPhone)
+			},
+			req:      web.BindPhoneReq{Phone: otherPhone, Code: "123456"},
+			wantResp: test.Result[web.Profile]{Code: 401007, Msg: "已经绑定了同类的其它登录方式"},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			req, err := http.NewRequest(http.MethodPost,
+				"/users/bind/phone", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Profile]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
+
+	var source dao.User
+	err = s.db.Where("id = ?", 456).First(&source).Error
+	require.NoError(s.T(), err)
+	assert.False(s.T(), source.Phone.Valid)
+	var merges []dao.UserMerge
+	err = s.db.Find(&merges).Error
+	require.NoError(s.T(), err)
+	require.Len(s.T(), merges, 1)
+	assert.Equal(s.T(), []int64{456, 123, 123},
+		[]int64{merges[0].SourceUid, merges[0].TargetUid, merges[0].Operator})
+	cnt, err := s.rdb.Exists(context.Background(), "webook:user:sessions:456", "session:ssid-456").Result()
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), cnt)
+
+	err = s.rdb.Del(context.Background(), "webook:user:code:bind:"+otherPhone,
+		"webook:user:code:bind:"+otherPhone+":cnt").Err()
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `user_merges`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandleTestSuite) TestBindWechat() {
+	// 123 是网页扫码注册的，456 是小程序注册的，两边都没有 unionId
+	err := s.db.Create(&[]dao.User{
+		{Id: 123, SN: "sn-123", WechatOpenId: sqlx.NewNullString("web-open-id-123")},
+		{Id: 456, SN: "sn-456", WechatMiniOpenId: sqlx.NewNullString("mini-open-id-456")},
+	}).Error
+	require.NoError(s.T(), err)
+	s.mockWeMiniSvc.EXPECT().Verify(gomock.Any(), service.CallbackParams{
+		State: "bind state",
+		Code:  "bind code",
+	}).Return(domain.WechatInfo{
+		MiniOpenId: "mini-open-id-456",
+	}, nil).Times(2)
+	testCases := []struct {
+		name     string
+		req      web.BindWechatReq
+		wantResp test.Result[web.Profile]
+	}{
+		{
+			name:     "已经绑定了其它账号",
+			req:      web.BindWechatReq{State: "bind state", Code: "bind code"},
+			wantResp: test.Result[web.Profile]{Code: 401006, Msg: "已经绑定了其它账号"},
+		},
+		{
+			name: "合并另外一个账号",
+			req:  web.BindWechatReq{State: "bind state", Code: "bind code", Merge: true},
+			wantResp: test.Result[web.Profile]{
+				Data: web.Profile{SN: "sn-123"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost,
+				"/users/bind/wechat/mini", iox.NewJSONReader(tc.req))
+			req.Header.Set("content-type", "application/json")
+			require.NoError(t, err)
+			recorder := test.NewJSONResponseRecorder[web.Profile]()
+			s.server.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+			assert.Equal(t, tc.wantResp, recorder.MustScan())
+		})
+	}
+
+	var users []dao.User
+	err = s.db.Order("id ASC").Find(&users).Error
+	require.NoError(s.T(), err)
+	require.Len(s.T(), users, 2)
+	assert.Equal(s.T(), "web-open-id-123", users[0].WechatOpenId.String)
+	assert.Equal(s.T(), "mini-open-id-456", users[0].WechatMiniOpenId.String)
+	assert.False(s.T(), users[1].WechatOpenId.Valid)
+	assert.False(s.T(), users[1].WechatMiniOpenId.Valid)
+	var merges []dao.UserMerge
+	err = s.db.Find(&merges).Error
+	require.NoError(s.T(), err)
+	require.Len(s.T(), merges, 1)
+	assert.Equal(s.T(), []int64{456, 123, 123},
+		[]int64{merges[0].SourceUid, merges[0].TargetUid, merges[0].Operator})
+
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `user_merges`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandleTestSuite) TestAnonymize() {
+	const uid = 123
+	err := s.db.Create(&dao.User{
+		Id:            uid,
+		SN:            "sn-123",
+		Nickname:      "Tom",
+		Avatar:        "http://avatar",
+		Phone:         sqlx.NewNullString("13800138000"),
+		Email:         sqlx.NewNullString("tom@example.com"),
+		WechatUnionId: sqlx.NewNullString("union-id"),
+	}).Error
+	require.NoError(s.T(), err)
+
+	svc := startup.InitModule().Svc
+	err = svc.Anonymize(context.Background(), uid)
+	require.NoError(s.T(), err)
+
+	var u dao.User
+	err = s.db.Where("id = ?", uid).First(&u).Error
+	require.NoError(s.T(), err)
+	// 序列号保留下来，其它的都清空了
+	assert.Equal(s.T(), "sn-123", u.SN)
+	assert.Equal(s.T(), "", u.Nickname)
+	assert.Equal(s.T(), "", u.Avatar)
+	assert.False(s.T(), u.Phone.Valid)
+	assert.False(s.T(), u.Email.Valid)
+	assert.False(s.T(), u.WechatUnionId.Valid)
+
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+}
+
+func (s *HandleTestSuite) TestSessions() {
+	const (
+		uid   = 123
+		phone = "13800000004"
+	)
+	err := s.db.Create(&dao.User{Id: uid, SN: "sn-123", Phone: sqlx.NewNullString(phone)}).Error
+	require.NoError(s.T(), err)
+	key := "webook:user:code:login:" + phone
+	login := func(t *testing.T, device string) {
+		// 直接写入验证码，避开发送频率的限制
+		err := s.rdb.Set(context.Background(), key, "123456", time.Minute).Err()
+		require.NoError(t, err)
+		err = s.rdb.Set(context.Background(), key+":cnt", 3, time.Minute).Err()
+		require.NoError(t, err)
+		s.mockPermSvc.EXPECT().FindPersonalPermissions(gomock.Any(), int64(uid)).
+			Return(map[string][]permission.Permission{}, nil)
+		req, err := http.NewRequest(http.MethodPost,
+			"/users/sms/login", iox.NewJSONReader(web.SMSLoginReq{Phone: phone, Code: "123456"}))
+		require.NoError(t, err)
+		req.Header.Set("content-type", "application/json")
+		req.Header.Set("User-Agent", device)
+		recorder := test.NewJSONResponseRecorder[web.Profile]()
+		s.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		require.Equal(t, test.Result[web.Profile]{
+			Data: web.Profile{SN: "sn-123", Phone: phone, MemberDDL: 1234},
+		}, recorder.MustScan())
+		// 保证登录时间不一样
+		time.Sleep(time.Millisecond * 2)
+	}
+	list := func(t *testing.T) []web.Session {
+		req, err := http.NewRequest(http.MethodPost, "/users/sessions/list", iox.NewJSONReader(nil))
+		require.NoError(t, err)
+		req.Header.Set("content-type", "application/json")
+		recorder := test.NewJSONResponseRecorder[web.SessionList]()
+		s.server.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		return recorder.MustScan().Data.Sessions
+	}
+	devices := func(sessions []web.Session) []string {
+		res := make([]string, 0, len(sessions))
+		for _, sess := range sessions {
+			res = append(res, sess.Device)
+		}
+		return res
+	}
+
+	login(s.T(), "device-1")
+	sessions := list(s.T())
+	require.Len(s.T(), sessions, 1)
+	first := sessions[0].SSID
+
+	// 测试里面最多同时登录两个设备，第三次登录会踢掉最早的会话
+	login(s.T(), "device-2")
+	login(s.T(), "device-3")
+	sessions = list(s.T())
+	assert.Equal(s.T(), []string{"device-3", "device-2"}, devices(sessions))
+	cnt, err := s.rdb.Exists(context.Background(), "session:"+first).Result()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), cnt)
+
+	revoked := sessions[1].SSID
+	req, err := http.NewRequest(http.MethodPost,
+		"/users/sessions/revoke", iox.NewJSONReader(web.RevokeSessionReq{SSID: revoked}))
+	require.NoError(s.T(), err)
+	req.Header.Set("content-type", "application/json")
+	recorder := test.NewJSONResponseRecorder[any]()
+	s.server.ServeHTTP(recorder, req)
+	require.Equal(s.T(), 200, recorder.Code)
+	assert.Equal(s.T(), []string{"device-3"}, devices(list(s.T())))
+	cnt, err = s.rdb.Exists(context.Background(), "session:"+revoked).Result()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), cnt)
+
+	err = s.rdb.Del(context.Background(), "session:"+sessions[0].SSID,
+		fmt.Sprintf("webook:user:sessions:%d", uid)).Err()
+	require.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE table `users`").Error
+	require.NoError(s.T(), err)
+}
+
 func (s *HandleTestSuite) TestMiniVerify() {
 	testCases := []struct {
 		name   string
@@ -557,14 +1082,18 @@ func (s *HandlerWithAppTestSuite) SetupSuite() {
 		}).AnyTimes()
 	permSvc := permissionmocks.NewMockService(ctrl)
 	s.mockPermSvc = permSvc
+	rbacSvc := permissionmocks.NewMockRBACService(ctrl)
+	rbacSvc.EXPECT().Check(gomock.Any(), gomock.Any(), "admin", "login").
+		Return(false, nil).AnyTimes()
 	wesvc := svcmocks.NewMockOAuth2Service(ctrl)
 	weMiniSvc := svcmocks.NewMockOAuth2Service(ctrl)
 	hdl := startup.InitHandler(wesvc,
 		weMiniSvc,
 		&member.Module{Svc: memSvc},
 		&permission.Module{
-			Svc: permSvc,
-		}, session.DefaultProvider(), nil)
+			Svc:     permSvc,
+			RBACSvc: rbacSvc,
+		}, session.DefaultProvider())
 	s.mockWeSvc = wesvc
 	s.mockWeMiniSvc = weMiniSvc
 	//
@@ -28,6 +28,7 @@ import (
 	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/user/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
+	"github.com/ecodeclub/webook/internal/user/internal/service/sender"
 	"github.com/ecodeclub/webook/internal/user/internal/web"
 	"github.com/google/wire"
 )
@@ -36,17 +37,25 @@ func InitHandler(weSvc wechatWebOAuth2Service,
 	weMiniSvc wechatMiniOAuth2Service,
 	mem *member.Module,
 	perm *permission.Module,
-	sp session.Provider,
-	creators []string) *user.Handler {
+	sp session.Provider) *user.Handler {
 	wire.Build(iniHandler,
 		testioc.BaseSet,
+		testioc.InitRedis,
 		wire.FieldsOf(new(*member.Module), "Svc"),
-		wire.FieldsOf(new(*permission.Module), "Svc"),
+		wire.FieldsOf(new(*permission.Module), "Svc", "RBACSvc"),
 		initRegistrationEventProducer,
+		initMergeEventProducer,
 		service.NewUserService,
 		dao.NewGORMUserDAO,
 		cache.NewUserECache,
-		repository.NewCachedUserRepository)
+		repository.NewCachedUserRepository,
+		initSMSCodeService,
+		initMagicLinkService,
+		cache.NewCodeRedisCache,
+		repository.NewCachedCodeRepository,
+		initSessionService,
+		cache.NewSessionRedisCache,
+		repository.NewCachedSessionRepository)
 	return new(user.Handler)
 }
 
@@ -54,20 +63,28 @@ func iniHandler(
 	weSvc wechatWebOAuth2Service,
 	weMiniSvc wechatMiniOAuth2Service,
 	userSvc service.UserService,
+	smsCodeSvc service.SMSCodeService,
+	magicLinkSvc service.MagicLinkService,
+	sessionSvc service.SessionService,
 	memberSvc member.Service,
 	permissionSvc permission.Service,
-	sp session.Provider,
-	creators []string) *web.Handler {
-	return web.NewHandler(weSvc, weMiniSvc, userSvc, memberSvc, permissionSvc, sp, creators)
+	rbacSvc permission.RBACService,
+	sp session.Provider) *web.Handler {
+	return web.NewHandler(weSvc, weMiniSvc, userSvc, smsCodeSvc, magicLinkSvc, sessionSvc, memberSvc, permissionSvc, rbacSvc, sp)
 }
 func InitModule() *user.Module {
 	wire.Build(
 		testioc.BaseSet,
 		initRegistrationEventProducer,
+		initMergeEventProducer,
 		service.NewUserService,
 		dao.NewGORMUserDAO,
 		cache.NewUserECache,
 		repository.NewCachedUserRepository,
+		testioc.InitRedis,
+		initSessionService,
+		cache.NewSessionRedisCache,
+		repository.NewCachedSessionRepository,
 		wire.Struct(new(user.Module), "Svc"),
 	)
 	return new(user.Module)
@@ -80,3 +97,24 @@ func initRegistrationEventProducer(q mq.MQ) event.RegistrationEventProducer {
 	}
 	return p
 }
+
+func initMergeEventProducer(q mq.MQ) event.MergeEventProducer {
+	p, err := event.NewMergeEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func initSMSCodeService(repo repository.CodeRepository) service.SMSCodeService {
+	return service.NewSMSCodeService(repo, sender.NewLogSMSSender(), "login_tpl")
+}
+
+func initMagicLinkService(repo repository.CodeRepository) service.MagicLinkService {
+	return service.NewMagicLinkService(repo, sender.NewLogEmailSender(), "https://meoying.com/login/email")
+}
+
+// initSessionService 测试里面一个账号最多同时登录两个设备
+func initSessionService(repo repository.SessionRepository) service.SessionService {
+	return service.NewSessionService(repo, 2)
+}
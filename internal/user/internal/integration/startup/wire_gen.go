@@ -18,12 +18,13 @@ import (
 	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/user/internal/repository/dao"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
+	"github.com/ecodeclub/webook/internal/user/internal/service/sender"
 	"github.com/ecodeclub/webook/internal/user/internal/web"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(weSvc wechatWebOAuth2Service, weMiniSvc wechatMiniOAuth2Service, mem *member.Module, perm *permission.Module, sp session.Provider, creators []string) *web.Handler {
+func InitHandler(weSvc wechatWebOAuth2Service, weMiniSvc wechatMiniOAuth2Service, mem *member.Module, perm *permission.Module, sp session.Provider) *web.Handler {
 	db := testioc.InitDB()
 	userDAO := dao.NewGORMUserDAO(db)
 	ecacheCache := testioc.InitCache()
@@ -31,10 +32,20 @@ func InitHandler(weSvc wechatWebOAuth2Service, weMiniSvc wechatMiniOAuth2Service
 	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
 	mq := testioc.InitMQ()
 	registrationEventProducer := initRegistrationEventProducer(mq)
-	userService := service.NewUserService(userRepository, registrationEventProducer)
+	mergeEventProducer := initMergeEventProducer(mq)
+	cmdable := testioc.InitRedis()
+	sessionCache := cache.NewSessionRedisCache(cmdable)
+	sessionRepository := repository.NewCachedSessionRepository(sessionCache)
+	sessionService := initSessionService(sessionRepository)
+	userService := service.NewUserService(userRepository, registrationEventProducer, mergeEventProducer, sessionService)
+	codeCache := cache.NewCodeRedisCache(cmdable)
+	codeRepository := repository.NewCachedCodeRepository(codeCache)
+	smsCodeService := initSMSCodeService(codeRepository)
+	magicLinkService := initMagicLinkService(codeRepository)
 	serviceService := mem.Svc
 	service2 := perm.Svc
-	handler := iniHandler(weSvc, weMiniSvc, userService, serviceService, service2, sp, creators)
+	rbacService := perm.RBACSvc
+	handler := iniHandler(weSvc, weMiniSvc, userService, smsCodeService, magicLinkService, sessionService, serviceService, service2, rbacService, sp)
 	return handler
 }
 
@@ -46,7 +57,12 @@ func InitModule() *user.Module {
 	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
 	mq := testioc.InitMQ()
 	registrationEventProducer := initRegistrationEventProducer(mq)
-	userService := service.NewUserService(userRepository, registrationEventProducer)
+	mergeEventProducer := initMergeEventProducer(mq)
+	cmdable := testioc.InitRedis()
+	sessionCache := cache.NewSessionRedisCache(cmdable)
+	sessionRepository := repository.NewCachedSessionRepository(sessionCache)
+	sessionService := initSessionService(sessionRepository)
+	userService := service.NewUserService(userRepository, registrationEventProducer, mergeEventProducer, sessionService)
 	module := &user.Module{
 		Svc: userService,
 	}
@@ -59,11 +75,14 @@ func iniHandler(
 	weSvc wechatWebOAuth2Service,
 	weMiniSvc wechatMiniOAuth2Service,
 	userSvc service.UserService,
+	smsCodeSvc service.SMSCodeService,
+	magicLinkSvc service.MagicLinkService,
+	sessionSvc service.SessionService,
 	memberSvc member.Service,
 	permissionSvc permission.Service,
-	sp session.Provider,
-	creators []string) *web.Handler {
-	return web.NewHandler(weSvc, weMiniSvc, userSvc, memberSvc, permissionSvc, sp, creators)
+	rbacSvc permission.RBACService,
+	sp session.Provider) *web.Handler {
+	return web.NewHandler(weSvc, weMiniSvc, userSvc, smsCodeSvc, magicLinkSvc, sessionSvc, memberSvc, permissionSvc, rbacSvc, sp)
 }
 
 func initRegistrationEventProducer(q mq.MQ) event.RegistrationEventProducer {
@@ -73,3 +92,24 @@ func initRegistrationEventProducer(q mq.MQ) event.RegistrationEventProducer {
 	}
 	return p
 }
+
+func initMergeEventProducer(q mq.MQ) event.MergeEventProducer {
+	p, err := event.NewMergeEventProducer(q)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func initSMSCodeService(repo repository.CodeRepository) service.SMSCodeService {
+	return service.NewSMSCodeService(repo, sender.NewLogSMSSender(), "login_tpl")
+}
+
+func initMagicLinkService(repo repository.CodeRepository) service.MagicLinkService {
+	return service.NewMagicLinkService(repo, sender.NewLogEmailSender(), "https://meoying.com/login/email")
+}
+
+// initSessionService 测试里面一个账号最多同时登录两个设备
+func initSessionService(repo repository.SessionRepository) service.SessionService {
+	return service.NewSessionService(repo, 2)
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	//go:embed lua/set_code.lua
+	luaSetCode string
+	//go:embed lua/verify_code.lua
+	luaVerifyCode string
+)
+
+var (
+	ErrCodeSendTooMany   = errors.New("发送验证码太频繁")
+	ErrCodeVerifyTooMany = errors.New("验证次数太多")
+)
+
+const (
+	// codeResendInterval 同一个目标两次发送之间至少要间隔多久
+	codeResendInterval = time.Minute
+	// codeMaxAttempts 一个验证码最多可以验证几次
+	codeMaxAttempts = 3
+	// codeDailyLimit 同一个目标一天最多发送几次
+	codeDailyLimit = 10
+	// codeIPWindow 按照 IP 限流的窗口，避免换着手机号码或者邮箱刷短信和猜验证码
+	codeIPWindow = time.Hour
+	// codeIPSendLimit 同一个 IP 在窗口内最多发送几次
+	codeIPSendLimit = 20
+	// codeIPVerifyLimit 同一个 IP 在窗口内最多验证失败几次
+	codeIPVerifyLimit = 50
+)
+
+// CodeCache 验证码需要原子地检查和修改，ecache 做不到，所以直接用 redis 执行 lua 脚本
+type CodeCache interface {
+	// Set 同一个目标或者同一个 ip 发送太频繁的时候返回 ErrCodeSendTooMany
+	Set(ctx context.Context, biz, target, ip, code string, expiration time.Duration) error
+	// Verify 验证码不对或者已经过期的时候返回 false，
+	// 验证次数用完或者同一个 ip 失败太多次的时候返回 ErrCodeVerifyTooMany
+	Verify(ctx context.Context, biz, target, ip, code string) (bool, error)
+}
+
+type CodeRedisCache struct {
+	cmd redis.Cmdable
+}
+
+func NewCodeRedisCache(cmd redis.Cmdable) CodeCache {
+	return &CodeRedisCache{cmd: cmd}
+}
+
+func (c *CodeRedisCache) Set(ctx context.Context, biz, target, ip, code string, expiration time.Duration) error {
+	key := c.key(biz, target)
+	res, err := c.cmd.Eval(ctx, luaSetCode, []string{key, key + ":cnt", key + ":daily", c.ipKey("send", ip)},
+		code, int64(expiration/time.Second), int64(codeResendInterval/time.Second),
+		codeMaxAttempts, codeDailyLimit, codeIPSendLimit, int64(codeIPWindow/time.Second)).Int()
+	if err != nil {
+		return err
+	}
+	switch res {
+	case 0:
+		return nil
+	case -1, -2, -4:
+		return ErrCodeSendTooMany
+	default:
+		return fmt.Errorf("验证码的 key 没有过期时间 %s", key)
+	}
+}
+
+func (c *CodeRedisCache) Verify(ctx context.Context, biz, target, ip, code string) (bool, error) {
+	key := c.key(biz, target)
+	res, err := c.cmd.Eval(ctx, luaVerifyCode, []string{key, key + ":cnt", c.ipKey("verify", ip)},
+		code, codeIPVerifyLimit, int64(codeIPWindow/time.Second)).Int()
+	if err != nil {
+		return false, err
+	}
+	switch res {
+	case 0:
+		return true, nil
+	case -1:
+		return false, ErrCodeVerifyTooMany
+	default:
+		return false, nil
+	}
+}
+
+func (c *CodeRedisCache) key(biz, target string) string {
+	return fmt.Sprintf("webook:user:code:%s:%s", biz, target)
+}
+
+// ipKey 不区分业务，登录和绑定共用一个额度
+func (c *CodeRedisCache) ipKey(action, ip string) string {
+	if ip == "" {
+		ip = "unknown"
+	}
+	return fmt.Sprintf("webook:user:code:ip:%s:%s", action, ip)
+}
@@ -0,0 +1,35 @@
+-- KEYS[1] 验证码，KEYS[2] 剩余的验证次数，KEYS[3] 当天的发送次数，KEYS[4] 同一个 IP 的发送次数
+-- ARGV[1] 验证码，ARGV[2] 有效期（秒），ARGV[3] 重发间隔（秒），
+-- ARGV[4] 最多验证几次，ARGV[5] 每天最多发送几次，
+-- ARGV[6] 同一个 IP 在窗口内最多发送几次，ARGV[7] IP 限流的窗口（秒）
+local key = KEYS[1]
+local cntKey = KEYS[2]
+local dailyKey = KEYS[3]
+local ipKey = KEYS[4]
+local expiration = tonumber(ARGV[2])
+local ttl = tonumber(redis.call("ttl", key))
+if ttl == -1 then
+    -- key 存在，但是没有过期时间，说明被别人误用了
+    return -3
+elseif ttl > expiration - tonumber(ARGV[3]) then
+    -- 还没有过重发间隔
+    return -1
+end
+local sent = tonumber(redis.call("get", dailyKey) or "0")
+if sent >= tonumber(ARGV[5]) then
+    return -2
+end
+-- 换着目标发送也绕不过去
+local ipSent = tonumber(redis.call("get", ipKey) or "0")
+if ipSent >= tonumber(ARGV[6]) then
+    return -4
+end
+redis.call("set", key, ARGV[1], "EX", expiration)
+redis.call("set", cntKey, ARGV[4], "EX", expiration)
+if redis.call("incr", dailyKey) == 1 then
+    redis.call("expire", dailyKey, 86400)
+end
+if redis.call("incr", ipKey) == 1 then
+    redis.call("expire", ipKey, tonumber(ARGV[7]))
+end
+return 0
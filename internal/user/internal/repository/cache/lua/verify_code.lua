@@ -0,0 +1,30 @@
+-- KEYS[1] 验证码，KEYS[2] 剩余的验证次数，KEYS[3] 同一个 IP 验证失败的次数
+-- ARGV[1] 用户输入的验证码，ARGV[2] 同一个 IP 在窗口内最多失败几次，ARGV[3] IP 限流的窗口（秒）
+local key = KEYS[1]
+local cntKey = KEYS[2]
+local ipKey = KEYS[3]
+-- 换着目标猜验证码也绕不过去
+local ipFailed = tonumber(redis.call("get", ipKey) or "0")
+if ipFailed >= tonumber(ARGV[2]) then
+    return -1
+end
+local code = redis.call("get", key)
+if not code then
+    -- 没有发送过，或者已经过期了
+    return -2
+end
+local cnt = tonumber(redis.call("get", cntKey) or "0")
+if cnt <= 0 then
+    -- 验证次数用完了，只能重新发送
+    return -1
+end
+if code == ARGV[1] then
+    -- 验证码只能用一次
+    redis.call("del", key, cntKey)
+    return 0
+end
+redis.call("decr", cntKey)
+if redis.call("incr", ipKey) == 1 then
+    redis.call("expire", ipKey, tonumber(ARGV[3]))
+end
+return -2
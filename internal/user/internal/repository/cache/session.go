@@ -0,0 +1,144 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// sessionKeyPrefix 必须和 ginx 的 redis session 实现保持一致，
+	// 删除了这个 key，对应的 token 就不能再用了
+	sessionKeyPrefix = "session:"
+	// sessionExpiration 和 ioc.InitSession 里面 session 的过期时间保持一致
+	sessionExpiration = time.Hour * 24
+)
+
+// SessionCache 记录每个用户有哪些会话。
+// 会话本身由 ginx 管理，这里只保存登录时的设备、IP 等信息
+type SessionCache interface {
+	Add(ctx context.Context, sess domain.Session) error
+	// List 只返回还没有过期的会话，按照登录时间从新到旧排列
+	List(ctx context.Context, uid int64) ([]domain.Session, error)
+	// Delete 删除会话，对应的 token 会立刻失效
+	Delete(ctx context.Context, uid int64, ssids ...string) error
+	DeleteAll(ctx context.Context, uid int64) error
+}
+
+type SessionRedisCache struct {
+	cmd redis.Cmdable
+}
+
+func NewSessionRedisCache(cmd redis.Cmdable) SessionCache {
+	return &SessionRedisCache{cmd: cmd}
+}
+
+func (c *SessionRedisCache) Add(ctx context.Context, sess domain.Session) error {
+	val, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	key := c.key(sess.Uid)
+	pip := c.cmd.TxPipeline()
+	pip.HSet(ctx, key, sess.SSID, val)
+	// 每次登录都续期，最后一个会话过期之后整个 key 也就过期了
+	pip.Expire(ctx, key, sessionExpiration)
+	_, err = pip.Exec(ctx)
+	return err
+}
+
+func (c *SessionRedisCache) List(ctx context.Context, uid int64) ([]domain.Session, error) {
+	key := c.key(uid)
+	vals, err := c.cmd.HGetAll(ctx, key).Result()
+	if err != nil || len(vals) == 0 {
+		return nil, err
+	}
+	ssids := make([]string, 0, len(vals))
+	pip := c.cmd.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(vals))
+	for ssid := range vals {
+		ssids = append(ssids, ssid)
+		cmds = append(cmds, pip.Exists(ctx, sessionKeyPrefix+ssid))
+	}
+	_, err = pip.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Session, 0, len(vals))
+	expired := make([]string, 0, len(vals))
+	for i, ssid := range ssids {
+		if cmds[i].Val() == 0 {
+			expired = append(expired, ssid)
+			continue
+		}
+		var sess domain.Session
+		err = json.Unmarshal([]byte(vals[ssid]), &sess)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sess)
+	}
+	// 顺手清理掉已经过期的会话
+	if len(expired) > 0 {
+		err = c.cmd.HDel(ctx, key, expired...).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Ctime > res[j].Ctime
+	})
+	return res, nil
+}
+
+func (c *SessionRedisCache) Delete(ctx context.Context, uid int64, ssids ...string) error {
+	if len(ssids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ssids))
+	for _, ssid := range ssids {
+		keys = append(keys, sessionKeyPrefix+ssid)
+	}
+	pip := c.cmd.TxPipeline()
+	pip.Del(ctx, keys...)
+	pip.HDel(ctx, c.key(uid), ssids...)
+	_, err := pip.Exec(ctx)
+	return err
+}
+
+func (c *SessionRedisCache) DeleteAll(ctx context.Context, uid int64) error {
+	key := c.key(uid)
+	ssids, err := c.cmd.HKeys(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(ssids)+1)
+	for _, ssid := range ssids {
+		keys = append(keys, sessionKeyPrefix+ssid)
+	}
+	keys = append(keys, key)
+	return c.cmd.Del(ctx, keys...).Err()
+}
+
+func (c *SessionRedisCache) key(uid int64) string {
+	return fmt.Sprintf("webook:user:sessions:%d", uid)
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
+)
+
+type CodeRepository interface {
+	// Store ip 是请求发送验证码的客户端，用来限制同一个 ip 的发送次数
+	Store(ctx context.Context, biz, target, ip, code string, expiration time.Duration) error
+	Verify(ctx context.Context, biz, target, ip, code string) (bool, error)
+}
+
+// CachedCodeRepository 验证码只存在缓存里面
+type CachedCodeRepository struct {
+	cache cache.CodeCache
+}
+
+func NewCachedCodeRepository(c cache.CodeCache) CodeRepository {
+	return &CachedCodeRepository{cache: c}
+}
+
+func (repo *CachedCodeRepository) Store(ctx context.Context, biz, target, ip, code string, expiration time.Duration) error {
+	return repo.cache.Set(ctx, biz, target, ip, code, expiration)
+}
+
+func (repo *CachedCodeRepository) Verify(ctx context.Context, biz, target, ip, code string) (bool, error) {
+	return repo.cache.Verify(ctx, biz, target, ip, code)
+}
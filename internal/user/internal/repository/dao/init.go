@@ -13,6 +13,7 @@ func InitTables(db *egorm.Component) error {
 	return db.AutoMigrate(
 		&User{},
 		&UsersIelts{},
+		&UserMerge{},
 	)
 }
 
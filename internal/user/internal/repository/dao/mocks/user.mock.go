@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./user.go -package=daomocks -destination=mocks/user.mock.go UserDAO
 //
+
 // Package daomocks is a generated GoMock package.
 package daomocks
 
@@ -39,6 +40,50 @@ func (m *MockUserDAO) EXPECT() *MockUserDAOMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserDAO) Anonymize(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserDAOMockRecorder) Anonymize(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserDAO)(nil).Anonymize), ctx, uid)
+}
+
+// FindAllByWechat mocks base method.
+func (m *MockUserDAO) FindAllByWechat(ctx context.Context, unionId, openId, miniOpenId string) ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByWechat", ctx, unionId, openId, miniOpenId)
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByWechat indicates an expected call of FindAllByWechat.
+func (mr *MockUserDAOMockRecorder) FindAllByWechat(ctx, unionId, openId, miniOpenId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByWechat", reflect.TypeOf((*MockUserDAO)(nil).FindAllByWechat), ctx, unionId, openId, miniOpenId)
+}
+
+// FindByEmail mocks base method.
+func (m *MockUserDAO) FindByEmail(ctx context.Context, email string) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserDAOMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserDAO)(nil).FindByEmail), ctx, email)
+}
+
 // FindById mocks base method.
 func (m *MockUserDAO) FindById(ctx context.Context, id int64) (dao.User, error) {
 	m.ctrl.T.Helper()
@@ -54,19 +99,34 @@ func (mr *MockUserDAOMockRecorder) FindById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserDAO)(nil).FindById), ctx, id)
 }
 
+// FindByPhone mocks base method.
+func (m *MockUserDAO) FindByPhone(ctx context.Context, phone string) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPhone", ctx, phone)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPhone indicates an expected call of FindByPhone.
+func (mr *MockUserDAOMockRecorder) FindByPhone(ctx, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhone", reflect.TypeOf((*MockUserDAO)(nil).FindByPhone), ctx, phone)
+}
+
 // FindByWechat mocks base method.
-func (m *MockUserDAO) FindByWechat(ctx context.Context, openId string) (dao.User, error) {
+func (m *MockUserDAO) FindByWechat(ctx context.Context, unionId string) (dao.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByWechat", ctx, openId)
+	ret := m.ctrl.Call(m, "FindByWechat", ctx, unionId)
 	ret0, _ := ret[0].(dao.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByWechat indicates an expected call of FindByWechat.
-func (mr *MockUserDAOMockRecorder) FindByWechat(ctx, openId any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) FindByWechat(ctx, unionId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserDAO)(nil).FindByWechat), ctx, openId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserDAO)(nil).FindByWechat), ctx, unionId)
 }
 
 // Insert mocks base method.
@@ -84,6 +144,21 @@ func (mr *MockUserDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserDAO)(nil).Insert), ctx, u)
 }
 
+// Merge mocks base method.
+func (m_2 *MockUserDAO) Merge(ctx context.Context, m dao.UserMerge) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Merge", ctx, m)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockUserDAOMockRecorder) Merge(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockUserDAO)(nil).Merge), ctx, m)
+}
+
 // UpdateNonZeroFields mocks base method.
 func (m *MockUserDAO) UpdateNonZeroFields(ctx context.Context, u dao.User) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	"github.com/ego-component/egorm"
 	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ErrDataNotFound 通用的数据没找到
@@ -22,7 +23,15 @@ type UserDAO interface {
 	Insert(ctx context.Context, u User) (int64, error)
 	UpdateNonZeroFields(ctx context.Context, u User) error
 	FindByWechat(ctx context.Context, unionId string) (User, error)
+	// FindAllByWechat 任意一个 id 匹配就算，为空的 id 不参与查询
+	FindAllByWechat(ctx context.Context, unionId, openId, miniOpenId string) ([]User, error)
+	FindByPhone(ctx context.Context, phone string) (User, error)
+	FindByEmail(ctx context.Context, email string) (User, error)
 	FindById(ctx context.Context, id int64) (User, error)
+	// Merge 把源账号的登录方式转移到目标账号上，并且留下合并记录
+	Merge(ctx context.Context, m UserMerge) (int64, error)
+	// Anonymize 注销账号的时候清空个人信息和所有的登录方式，ID 和序列号保留下来
+	Anonymize(ctx context.Context, uid int64) error
 }
 
 type GORMUserDAO struct {
@@ -36,7 +45,12 @@ func NewGORMUserDAO(db *egorm.Component) UserDAO {
 }
 
 func (ud *GORMUserDAO) UpdateNonZeroFields(ctx context.Context, u User) error {
-	return ud.db.WithContext(ctx).Updates(&u).Error
+	err := ud.db.WithContext(ctx).Updates(&u).Error
+	if ud.isUniqueIndexError(err) {
+		// 绑定的手机号码、邮箱之类的已经被别人用了
+		return ErrUserDuplicate
+	}
+	return err
 }
 
 func (ud *GORMUserDAO) Insert(ctx context.Context, u User) (int64, error) {
@@ -44,27 +58,127 @@ func (ud *GORMUserDAO) Insert(ctx context.Context, u User) (int64, error) {
 	u.Ctime = now
 	u.Utime = now
 	err := ud.db.WithContext(ctx).Create(&u).Error
-	if me, ok := err.(*mysql.MySQLError); ok {
-		const uniqueIndexErrNo uint16 = 1062
-		if me.Number == uniqueIndexErrNo {
-			return 0, ErrUserDuplicate
-		}
+	if ud.isUniqueIndexError(err) {
+		return 0, ErrUserDuplicate
 	}
 	return u.Id, err
 }
 
+func (ud *GORMUserDAO) isUniqueIndexError(err error) bool {
+	var me *mysql.MySQLError
+	if errors.As(err, &me) {
+		const uniqueIndexErrNo uint16 = 1062
+		return me.Number == uniqueIndexErrNo
+	}
+	return false
+}
+
 func (ud *GORMUserDAO) FindByWechat(ctx context.Context, unionId string) (User, error) {
 	var u User
 	err := ud.db.WithContext(ctx).First(&u, "wechat_union_id = ?", unionId).Error
 	return u, err
 }
 
+func (ud *GORMUserDAO) FindAllByWechat(ctx context.Context, unionId, openId, miniOpenId string) ([]User, error) {
+	var res []User
+	query := ud.db.WithContext(ctx).Where("1 = 0")
+	if unionId != "" {
+		query = query.Or("wechat_union_id = ?", unionId)
+	}
+	if openId != "" {
+		query = query.Or("wechat_open_id = ?", openId)
+	}
+	if miniOpenId != "" {
+		query = query.Or("wechat_mini_open_id = ?", miniOpenId)
+	}
+	err := query.Find(&res).Error
+	return res, err
+}
+
+func (ud *GORMUserDAO) FindByPhone(ctx context.Context, phone string) (User, error) {
+	var u User
+	err := ud.db.WithContext(ctx).First(&u, "phone = ?", phone).Error
+	return u, err
+}
+
+func (ud *GORMUserDAO) FindByEmail(ctx context.Context, email string) (User, error) {
+	var u User
+	err := ud.db.WithContext(ctx).First(&u, "email = ?", email).Error
+	return u, err
+}
+
 func (ud *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	var u User
 	err := ud.db.WithContext(ctx).First(&u, "id = ?", id).Error
 	return u, err
 }
 
+func (ud *GORMUserDAO) Anonymize(ctx context.Context, uid int64) error {
+	return ud.db.WithContext(ctx).Model(&User{}).Where("id = ?", uid).Updates(map[string]any{
+		"nickname":            "",
+		"avatar":              "",
+		"wechat_open_id":      nil,
+		"wechat_union_id":     nil,
+		"wechat_mini_open_id": nil,
+		"phone":               nil,
+		"email":               nil,
+		"utime":               time.Now().UnixMilli(),
+	}).Error
+}
+
+func (ud *GORMUserDAO) Merge(ctx context.Context, m UserMerge) (int64, error) {
+	now := time.Now().UnixMilli()
+	err := ud.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source, target User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&source, "id = ?", m.SourceUid).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&target, "id = ?", m.TargetUid).Error
+		if err != nil {
+			return err
+		}
+		// 两边都有的登录方式保留目标账号的，源账号的直接丢掉
+		moved := User{Id: target.Id, Utime: now}
+		if !target.WechatOpenId.Valid {
+			moved.WechatOpenId = source.WechatOpenId
+		}
+		if !target.WechatUnionId.Valid {
+			moved.WechatUnionId = source.WechatUnionId
+		}
+		if !target.WechatMiniOpenId.Valid {
+			moved.WechatMiniOpenId = source.WechatMiniOpenId
+		}
+		if !target.Phone.Valid {
+			moved.Phone = source.Phone
+		}
+		if !target.Email.Valid {
+			moved.Email = source.Email
+		}
+		// 先清空源账号的，不然转移的时候会违反唯一索引
+		err = tx.Model(&User{}).Where("id = ?", source.Id).Updates(map[string]any{
+			"wechat_open_id":      nil,
+			"wechat_union_id":     nil,
+			"wechat_mini_open_id": nil,
+			"phone":               nil,
+			"email":               nil,
+			"utime":               now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Updates(&moved).Error
+		if err != nil {
+			return err
+		}
+		m.Ctime = now
+		return tx.Create(&m).Error
+	})
+	return m.Id, err
+}
+
 type User struct {
 	Id       int64 `gorm:"primaryKey,autoIncrement"`
 	Nickname string
@@ -74,8 +188,20 @@ type User struct {
 	WechatOpenId     sql.NullString `gorm:"type:varchar(256);unique"`
 	WechatUnionId    sql.NullString `gorm:"type:varchar(256);unique"`
 	WechatMiniOpenId sql.NullString `gorm:"type:varchar(256);unique"`
+	Phone            sql.NullString `gorm:"type:varchar(32);unique"`
+	Email            sql.NullString `gorm:"type:varchar(256);unique"`
 	// 创建时间
 	Ctime int64
 	// 更新时间
 	Utime int64
 }
+
+// UserMerge 合并账号的记录，只增不改，用于审计
+type UserMerge struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	SourceUid int64 `gorm:"index"`
+	TargetUid int64 `gorm:"index"`
+	// Operator 发起合并的人
+	Operator int64
+	Ctime    int64
+}
@@ -1,5 +1,13 @@
 package repository
 
-import "github.com/ecodeclub/webook/internal/user/internal/repository/dao"
+import (
+	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
+	"github.com/ecodeclub/webook/internal/user/internal/repository/dao"
+)
 
-var ErrUserNotFound = dao.ErrDataNotFound
+var (
+	ErrUserNotFound      = dao.ErrDataNotFound
+	ErrUserDuplicate     = dao.ErrUserDuplicate
+	ErrCodeSendTooMany   = cache.ErrCodeSendTooMany
+	ErrCodeVerifyTooMany = cache.ErrCodeVerifyTooMany
+)
@@ -0,0 +1,54 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
+)
+
+type SessionRepository interface {
+	Add(ctx context.Context, sess domain.Session) error
+	List(ctx context.Context, uid int64) ([]domain.Session, error)
+	Delete(ctx context.Context, uid int64, ssids ...string) error
+	DeleteAll(ctx context.Context, uid int64) error
+}
+
+// CachedSessionRepository 会话和验证码一样只存在缓存里面
+type CachedSessionRepository struct {
+	cache cache.SessionCache
+}
+
+func NewCachedSessionRepository(c cache.SessionCache) SessionRepository {
+	return &CachedSessionRepository{cache: c}
+}
+
+func (repo *CachedSessionRepository) Add(ctx context.Context, sess domain.Session) error {
+	return repo.cache.Add(ctx, sess)
+}
+
+func (repo *CachedSessionRepository) List(ctx context.Context, uid int64) ([]domain.Session, error) {
+	return repo.cache.List(ctx, uid)
+}
+
+func (repo *CachedSessionRepository) Delete(ctx context.Context, uid int64, ssids ...string) error {
+	return repo.cache.Delete(ctx, uid, ssids...)
+}
+
+func (repo *CachedSessionRepository) DeleteAll(ctx context.Context, uid int64) error {
+	return repo.cache.DeleteAll(ctx, uid)
+}
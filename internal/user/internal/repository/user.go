@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/ekit/sqlx"
 
 	"github.com/ecodeclub/webook/internal/user/internal/domain"
@@ -17,7 +19,15 @@ type UserRepository interface {
 	Update(ctx context.Context, u domain.User) error
 	// FindByWechat 按照 unionId 来查询
 	FindByWechat(ctx context.Context, unionId string) (domain.User, error)
+	// FindAllByWechat 网页和小程序可能没有 unionId，所以 openId 和 miniOpenId 也要查，
+	// 每一个 id 都可能属于不同的账号
+	FindAllByWechat(ctx context.Context, info domain.WechatInfo) ([]domain.User, error)
+	FindByPhone(ctx context.Context, phone string) (domain.User, error)
+	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindById(ctx context.Context, id int64) (domain.User, error)
+	// Merge 返回合并记录的 ID
+	Merge(ctx context.Context, m domain.Merge) (int64, error)
+	Anonymize(ctx context.Context, uid int64) error
 }
 
 // CachedUserRepository 使用了缓存的 repository 实现
@@ -53,6 +63,23 @@ func (ur *CachedUserRepository) FindByWechat(ctx context.Context,
 	return ur.entityToDomain(u), err
 }
 
+func (ur *CachedUserRepository) FindAllByWechat(ctx context.Context, info domain.WechatInfo) ([]domain.User, error) {
+	users, err := ur.dao.FindAllByWechat(ctx, info.UnionId, info.OpenId, info.MiniOpenId)
+	return slice.Map(users, func(idx int, src dao.User) domain.User {
+		return ur.entityToDomain(src)
+	}), err
+}
+
+func (ur *CachedUserRepository) FindByPhone(ctx context.Context, phone string) (domain.User, error) {
+	u, err := ur.dao.FindByPhone(ctx, phone)
+	return ur.entityToDomain(u), err
+}
+
+func (ur *CachedUserRepository) FindByEmail(ctx context.Context, email string) (domain.User, error) {
+	u, err := ur.dao.FindByEmail(ctx, email)
+	return ur.entityToDomain(u), err
+}
+
 func (ur *CachedUserRepository) FindById(ctx context.Context,
 	id int64) (domain.User, error) {
 	u, err := ur.cache.Get(ctx, id)
@@ -69,6 +96,28 @@ func (ur *CachedUserRepository) FindById(ctx context.Context,
 	return u, nil
 }
 
+func (ur *CachedUserRepository) Merge(ctx context.Context, m domain.Merge) (int64, error) {
+	id, err := ur.dao.Merge(ctx, dao.UserMerge{
+		SourceUid: m.SourceUid,
+		TargetUid: m.TargetUid,
+		Operator:  m.Operator,
+	})
+	if err != nil {
+		return 0, err
+	}
+	// 两个账号的登录方式都变了
+	err = errors.Join(ur.cache.Delete(ctx, m.SourceUid), ur.cache.Delete(ctx, m.TargetUid))
+	return id, err
+}
+
+func (ur *CachedUserRepository) Anonymize(ctx context.Context, uid int64) error {
+	err := ur.dao.Anonymize(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return ur.cache.Delete(ctx, uid)
+}
+
 func (ur *CachedUserRepository) domainToEntity(u domain.User) dao.User {
 	return dao.User{
 		Id:               u.Id,
@@ -78,6 +127,8 @@ func (ur *CachedUserRepository) domainToEntity(u domain.User) dao.User {
 		WechatUnionId:    sqlx.NewNullString(u.WechatInfo.UnionId),
 		WechatOpenId:     sqlx.NewNullString(u.WechatInfo.OpenId),
 		WechatMiniOpenId: sqlx.NewNullString(u.WechatInfo.MiniOpenId),
+		Phone:            sqlx.NewNullString(u.Phone),
+		Email:            sqlx.NewNullString(u.Email),
 	}
 }
 
@@ -87,6 +138,8 @@ func (ur *CachedUserRepository) entityToDomain(ue dao.User) domain.User {
 		Nickname: ue.Nickname,
 		SN:       ue.SN,
 		Avatar:   ue.Avatar,
+		Phone:    ue.Phone.String,
+		Email:    ue.Email.String,
 		WechatInfo: domain.WechatInfo{
 			OpenId:     ue.WechatOpenId.String,
 			UnionId:    ue.WechatUnionId.String,
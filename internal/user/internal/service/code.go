@@ -0,0 +1,145 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"html"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/ecodeclub/webook/internal/user/internal/repository"
+	"github.com/ecodeclub/webook/internal/user/internal/service/sender"
+	"github.com/lithammer/shortuuid/v4"
+)
+
+const (
+	// BizLogin 登录用的验证码
+	BizLogin = "login"
+	// BizBind 绑定手机号码或者邮箱用的验证码
+	BizBind = "bind"
+)
+
+const (
+	smsCodeExpiration   = 5 * time.Minute
+	magicLinkExpiration = 15 * time.Minute
+)
+
+var (
+	ErrCodeSendTooMany   = repository.ErrCodeSendTooMany
+	ErrCodeVerifyTooMany = repository.ErrCodeVerifyTooMany
+	ErrCodeInvalid       = errors.New("验证码不对或者已经过期")
+)
+
+//go:generate mockgen -source=./code.go -package=svcmocks -typed=true -destination=mocks/code.mock.go SMSCodeService MagicLinkService
+type SMSCodeService interface {
+	// Send 发送六位数字的验证码，同一个手机号码一分钟只能发送一次，一天最多发送十次，
+	// ip 是请求的客户端，同一个 ip 换着手机号码发送也有次数限制
+	Send(ctx context.Context, biz, phone, ip string) error
+	// Verify 验证码只能用一次，同一个验证码最多验证三次，同一个 ip 失败太多次之后也不能再验证
+	Verify(ctx context.Context, biz, phone, ip, code string) error
+}
+
+// MagicLinkService 往邮箱发送登录链接，点击链接就能登录
+type MagicLinkService interface {
+	// Send 发送频率的限制和短信验证码一样，invitationCode 会被带在链接里面
+	Send(ctx context.Context, biz, email, ip, invitationCode string) error
+	Verify(ctx context.Context, biz, email, ip, token string) error
+}
+
+type smsCodeService struct {
+	repo   repository.CodeRepository
+	sender sender.SMSSender
+	tplId  string
+}
+
+func NewSMSCodeService(repo repository.CodeRepository, s sender.SMSSender, tplId string) SMSCodeService {
+	return &smsCodeService{
+		repo:   repo,
+		sender: s,
+		tplId:  tplId,
+	}
+}
+
+func (s *smsCodeService) Send(ctx context.Context, biz, phone, ip string) error {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return err
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+	err = s.repo.Store(ctx, biz, phone, ip, code, smsCodeExpiration)
+	if err != nil {
+		return err
+	}
+	return s.sender.Send(ctx, s.tplId, []string{code}, phone)
+}
+
+func (s *smsCodeService) Verify(ctx context.Context, biz, phone, ip, code string) error {
+	return verifyCode(ctx, s.repo, biz, phone, ip, code)
+}
+
+type magicLinkService struct {
+	repo   repository.CodeRepository
+	sender sender.EmailSender
+	// loginURL 前端处理登录链接的页面
+	loginURL string
+}
+
+func NewMagicLinkService(repo repository.CodeRepository, s sender.EmailSender, loginURL string) MagicLinkService {
+	return &magicLinkService{
+		repo:     repo,
+		sender:   s,
+		loginURL: loginURL,
+	}
+}
+
+func (m *magicLinkService) Send(ctx context.Context, biz, email, ip, invitationCode string) error {
+	token := shortuuid.New()
+	err := m.repo.Store(ctx, biz, email, ip, token, magicLinkExpiration)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("email", email)
+	params.Set("token", token)
+	// 前端根据 biz 决定是登录还是绑定
+	params.Set("biz", biz)
+	if invitationCode != "" {
+		params.Set("invitationCode", invitationCode)
+	}
+	link := html.EscapeString(m.loginURL + "?" + params.Encode())
+	content := fmt.Sprintf(`<p>点击下面的链接登录，链接 %d 分钟内有效，只能使用一次。</p><p><a href="%s">%s</a></p>`,
+		int(magicLinkExpiration/time.Minute), link, link)
+	return m.sender.Send(ctx, email, "登录 webook", content)
+}
+
+func (m *magicLinkService) Verify(ctx context.Context, biz, email, ip, token string) error {
+	return verifyCode(ctx, m.repo, biz, email, ip, token)
+}
+
+func verifyCode(ctx context.Context, repo repository.CodeRepository, biz, target, ip, code string) error {
+	ok, err := repo.Verify(ctx, biz, target, ip, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCodeInvalid
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./code.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./code.go -package=svcmocks -typed=true -destination=mocks/code.mock.go SMSCodeService MagicLinkService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSMSCodeService is a mock of SMSCodeService interface.
+type MockSMSCodeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSMSCodeServiceMockRecorder
+}
+
+// MockSMSCodeServiceMockRecorder is the mock recorder for MockSMSCodeService.
+type MockSMSCodeServiceMockRecorder struct {
+	mock *MockSMSCodeService
+}
+
+// NewMockSMSCodeService creates a new mock instance.
+func NewMockSMSCodeService(ctrl *gomock.Controller) *MockSMSCodeService {
+	mock := &MockSMSCodeService{ctrl: ctrl}
+	mock.recorder = &MockSMSCodeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSMSCodeService) EXPECT() *MockSMSCodeServiceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSMSCodeService) Send(ctx context.Context, biz, phone, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, biz, phone, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSMSCodeServiceMockRecorder) Send(ctx, biz, phone, ip any) *MockSMSCodeServiceSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSMSCodeService)(nil).Send), ctx, biz, phone, ip)
+	return &MockSMSCodeServiceSendCall{Call: call}
+}
+
+// MockSMSCodeServiceSendCall wrap *gomock.Call
+type MockSMSCodeServiceSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSMSCodeServiceSendCall) Return(arg0 error) *MockSMSCodeServiceSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSMSCodeServiceSendCall) Do(f func(context.Context, string, string, string) error) *MockSMSCodeServiceSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSMSCodeServiceSendCall) DoAndReturn(f func(context.Context, string, string, string) error) *MockSMSCodeServiceSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Verify mocks base method.
+func (m *MockSMSCodeService) Verify(ctx context.Context, biz, phone, ip, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, biz, phone, ip, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockSMSCodeServiceMockRecorder) Verify(ctx, biz, phone, ip, code any) *MockSMSCodeServiceVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSMSCodeService)(nil).Verify), ctx, biz, phone, ip, code)
+	return &MockSMSCodeServiceVerifyCall{Call: call}
+}
+
+// MockSMSCodeServiceVerifyCall wrap *gomock.Call
+type MockSMSCodeServiceVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSMSCodeServiceVerifyCall) Return(arg0 error) *MockSMSCodeServiceVerifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSMSCodeServiceVerifyCall) Do(f func(context.Context, string, string, string, string) error) *MockSMSCodeServiceVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSMSCodeServiceVerifyCall) DoAndReturn(f func(context.Context, string, string, string, string) error) *MockSMSCodeServiceVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockMagicLinkService is a mock of MagicLinkService interface.
+type MockMagicLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkServiceMockRecorder
+}
+
+// MockMagicLinkServiceMockRecorder is the mock recorder for MockMagicLinkService.
+type MockMagicLinkServiceMockRecorder struct {
+	mock *MockMagicLinkService
+}
+
+// NewMockMagicLinkService creates a new mock instance.
+func NewMockMagicLinkService(ctrl *gomock.Controller) *MockMagicLinkService {
+	mock := &MockMagicLinkService{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkService) EXPECT() *MockMagicLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMagicLinkService) Send(ctx context.Context, biz, email, ip, invitationCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, biz, email, ip, invitationCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMagicLinkServiceMockRecorder) Send(ctx, biz, email, ip, invitationCode any) *MockMagicLinkServiceSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMagicLinkService)(nil).Send), ctx, biz, email, ip, invitationCode)
+	return &MockMagicLinkServiceSendCall{Call: call}
+}
+
+// MockMagicLinkServiceSendCall wrap *gomock.Call
+type MockMagicLinkServiceSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMagicLinkServiceSendCall) Return(arg0 error) *MockMagicLinkServiceSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMagicLinkServiceSendCall) Do(f func(context.Context, string, string, string, string) error) *MockMagicLinkServiceSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMagicLinkServiceSendCall) DoAndReturn(f func(context.Context, string, string, string, string) error) *MockMagicLinkServiceSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Verify mocks base method.
+func (m *MockMagicLinkService) Verify(ctx context.Context, biz, email, ip, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, biz, email, ip, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockMagicLinkServiceMockRecorder) Verify(ctx, biz, email, ip, token any) *MockMagicLinkServiceVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockMagicLinkService)(nil).Verify), ctx, biz, email, ip, token)
+	return &MockMagicLinkServiceVerifyCall{Call: call}
+}
+
+// MockMagicLinkServiceVerifyCall wrap *gomock.Call
+type MockMagicLinkServiceVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMagicLinkServiceVerifyCall) Return(arg0 error) *MockMagicLinkServiceVerifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMagicLinkServiceVerifyCall) Do(f func(context.Context, string, string, string, string) error) *MockMagicLinkServiceVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMagicLinkServiceVerifyCall) DoAndReturn(f func(context.Context, string, string, string, string) error) *MockMagicLinkServiceVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
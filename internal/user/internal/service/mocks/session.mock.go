@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./session.go -package=svcmocks -typed=true -destination=mocks/session.mock.go SessionService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/ecodeclub/webook/internal/user/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionService is a mock of SessionService interface.
+type MockSessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionServiceMockRecorder
+}
+
+// MockSessionServiceMockRecorder is the mock recorder for MockSessionService.
+type MockSessionServiceMockRecorder struct {
+	mock *MockSessionService
+}
+
+// NewMockSessionService creates a new mock instance.
+func NewMockSessionService(ctrl *gomock.Controller) *MockSessionService {
+	mock := &MockSessionService{ctrl: ctrl}
+	mock.recorder = &MockSessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionService) EXPECT() *MockSessionServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSessionService) List(ctx context.Context, uid int64) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSessionServiceMockRecorder) List(ctx, uid any) *MockSessionServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSessionService)(nil).List), ctx, uid)
+	return &MockSessionServiceListCall{Call: call}
+}
+
+// MockSessionServiceListCall wrap *gomock.Call
+type MockSessionServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSessionServiceListCall) Return(arg0 []domain.Session, arg1 error) *MockSessionServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSessionServiceListCall) Do(f func(context.Context, int64) ([]domain.Session, error)) *MockSessionServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSessionServiceListCall) DoAndReturn(f func(context.Context, int64) ([]domain.Session, error)) *MockSessionServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Record mocks base method.
+func (m *MockSessionService) Record(ctx context.Context, sess domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, sess)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockSessionServiceMockRecorder) Record(ctx, sess any) *MockSessionServiceRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockSessionService)(nil).Record), ctx, sess)
+	return &MockSessionServiceRecordCall{Call: call}
+}
+
+// MockSessionServiceRecordCall wrap *gomock.Call
+type MockSessionServiceRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSessionServiceRecordCall) Return(arg0 error) *MockSessionServiceRecordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSessionServiceRecordCall) Do(f func(context.Context, domain.Session) error) *MockSessionServiceRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSessionServiceRecordCall) DoAndReturn(f func(context.Context, domain.Session) error) *MockSessionServiceRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockSessionService) Revoke(ctx context.Context, uid int64, ssid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, uid, ssid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionServiceMockRecorder) Revoke(ctx, uid, ssid any) *MockSessionServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionService)(nil).Revoke), ctx, uid, ssid)
+	return &MockSessionServiceRevokeCall{Call: call}
+}
+
+// MockSessionServiceRevokeCall wrap *gomock.Call
+type MockSessionServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSessionServiceRevokeCall) Return(arg0 error) *MockSessionServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSessionServiceRevokeCall) Do(f func(context.Context, int64, string) error) *MockSessionServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSessionServiceRevokeCall) DoAndReturn(f func(context.Context, int64, string) error) *MockSessionServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeAll mocks base method.
+func (m *MockSessionService) RevokeAll(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionServiceMockRecorder) RevokeAll(ctx, uid any) *MockSessionServiceRevokeAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSessionService)(nil).RevokeAll), ctx, uid)
+	return &MockSessionServiceRevokeAllCall{Call: call}
+}
+
+// MockSessionServiceRevokeAllCall wrap *gomock.Call
+type MockSessionServiceRevokeAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSessionServiceRevokeAllCall) Return(arg0 error) *MockSessionServiceRevokeAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSessionServiceRevokeAllCall) Do(f func(context.Context, int64) error) *MockSessionServiceRevokeAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSessionServiceRevokeAllCall) DoAndReturn(f func(context.Context, int64) error) *MockSessionServiceRevokeAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./user.go -package=svcmocks -typed=true -destination=mocks/user.mock.go UserService
 //
+
 // Package svcmocks is a generated GoMock package.
 package svcmocks
 
@@ -39,6 +40,278 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserService) Anonymize(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserServiceMockRecorder) Anonymize(ctx, uid any) *MockUserServiceAnonymizeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserService)(nil).Anonymize), ctx, uid)
+	return &MockUserServiceAnonymizeCall{Call: call}
+}
+
+// MockUserServiceAnonymizeCall wrap *gomock.Call
+type MockUserServiceAnonymizeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceAnonymizeCall) Return(arg0 error) *MockUserServiceAnonymizeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceAnonymizeCall) Do(f func(context.Context, int64) error) *MockUserServiceAnonymizeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceAnonymizeCall) DoAndReturn(f func(context.Context, int64) error) *MockUserServiceAnonymizeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BindEmail mocks base method.
+func (m *MockUserService) BindEmail(ctx context.Context, uid int64, email string, merge bool) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindEmail", ctx, uid, email, merge)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindEmail indicates an expected call of BindEmail.
+func (mr *MockUserServiceMockRecorder) BindEmail(ctx, uid, email, merge any) *MockUserServiceBindEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindEmail", reflect.TypeOf((*MockUserService)(nil).BindEmail), ctx, uid, email, merge)
+	return &MockUserServiceBindEmailCall{Call: call}
+}
+
+// MockUserServiceBindEmailCall wrap *gomock.Call
+type MockUserServiceBindEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceBindEmailCall) Return(arg0 domain.User, arg1 error) *MockUserServiceBindEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceBindEmailCall) Do(f func(context.Context, int64, string, bool) (domain.User, error)) *MockUserServiceBindEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceBindEmailCall) DoAndReturn(f func(context.Context, int64, string, bool) (domain.User, error)) *MockUserServiceBindEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BindPhone mocks base method.
+func (m *MockUserService) BindPhone(ctx context.Context, uid int64, phone string, merge bool) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindPhone", ctx, uid, phone, merge)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindPhone indicates an expected call of BindPhone.
+func (mr *MockUserServiceMockRecorder) BindPhone(ctx, uid, phone, merge any) *MockUserServiceBindPhoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindPhone", reflect.TypeOf((*MockUserService)(nil).BindPhone), ctx, uid, phone, merge)
+	return &MockUserServiceBindPhoneCall{Call: call}
+}
+
+// MockUserServiceBindPhoneCall wrap *gomock.Call
+type MockUserServiceBindPhoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceBindPhoneCall) Return(arg0 domain.User, arg1 error) *MockUserServiceBindPhoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceBindPhoneCall) Do(f func(context.Context, int64, string, bool) (domain.User, error)) *MockUserServiceBindPhoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceBindPhoneCall) DoAndReturn(f func(context.Context, int64, string, bool) (domain.User, error)) *MockUserServiceBindPhoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BindWechat mocks base method.
+func (m *MockUserService) BindWechat(ctx context.Context, uid int64, info domain.WechatInfo, merge bool) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindWechat", ctx, uid, info, merge)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindWechat indicates an expected call of BindWechat.
+func (mr *MockUserServiceMockRecorder) BindWechat(ctx, uid, info, merge any) *MockUserServiceBindWechatCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindWechat", reflect.TypeOf((*MockUserService)(nil).BindWechat), ctx, uid, info, merge)
+	return &MockUserServiceBindWechatCall{Call: call}
+}
+
+// MockUserServiceBindWechatCall wrap *gomock.Call
+type MockUserServiceBindWechatCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceBindWechatCall) Return(arg0 domain.User, arg1 error) *MockUserServiceBindWechatCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceBindWechatCall) Do(f func(context.Context, int64, domain.WechatInfo, bool) (domain.User, error)) *MockUserServiceBindWechatCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceBindWechatCall) DoAndReturn(f func(context.Context, int64, domain.WechatInfo, bool) (domain.User, error)) *MockUserServiceBindWechatCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FindByWechat mocks base method.
+func (m *MockUserService) FindByWechat(ctx context.Context, unionId string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWechat", ctx, unionId)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWechat indicates an expected call of FindByWechat.
+func (mr *MockUserServiceMockRecorder) FindByWechat(ctx, unionId any) *MockUserServiceFindByWechatCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserService)(nil).FindByWechat), ctx, unionId)
+	return &MockUserServiceFindByWechatCall{Call: call}
+}
+
+// MockUserServiceFindByWechatCall wrap *gomock.Call
+type MockUserServiceFindByWechatCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceFindByWechatCall) Return(arg0 domain.User, arg1 error) *MockUserServiceFindByWechatCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceFindByWechatCall) Do(f func(context.Context, string) (domain.User, error)) *MockUserServiceFindByWechatCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceFindByWechatCall) DoAndReturn(f func(context.Context, string) (domain.User, error)) *MockUserServiceFindByWechatCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FindOrCreateByEmail mocks base method.
+func (m *MockUserService) FindOrCreateByEmail(ctx context.Context, email, invitationCode string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateByEmail", ctx, email, invitationCode)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateByEmail indicates an expected call of FindOrCreateByEmail.
+func (mr *MockUserServiceMockRecorder) FindOrCreateByEmail(ctx, email, invitationCode any) *MockUserServiceFindOrCreateByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByEmail", reflect.TypeOf((*MockUserService)(nil).FindOrCreateByEmail), ctx, email, invitationCode)
+	return &MockUserServiceFindOrCreateByEmailCall{Call: call}
+}
+
+// MockUserServiceFindOrCreateByEmailCall wrap *gomock.Call
+type MockUserServiceFindOrCreateByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceFindOrCreateByEmailCall) Return(arg0 domain.User, arg1 error) *MockUserServiceFindOrCreateByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceFindOrCreateByEmailCall) Do(f func(context.Context, string, string) (domain.User, error)) *MockUserServiceFindOrCreateByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceFindOrCreateByEmailCall) DoAndReturn(f func(context.Context, string, string) (domain.User, error)) *MockUserServiceFindOrCreateByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// FindOrCreateByPhone mocks base method.
+func (m *MockUserService) FindOrCreateByPhone(ctx context.Context, phone, invitationCode string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateByPhone", ctx, phone, invitationCode)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateByPhone indicates an expected call of FindOrCreateByPhone.
+func (mr *MockUserServiceMockRecorder) FindOrCreateByPhone(ctx, phone, invitationCode any) *MockUserServiceFindOrCreateByPhoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByPhone", reflect.TypeOf((*MockUserService)(nil).FindOrCreateByPhone), ctx, phone, invitationCode)
+	return &MockUserServiceFindOrCreateByPhoneCall{Call: call}
+}
+
+// MockUserServiceFindOrCreateByPhoneCall wrap *gomock.Call
+type MockUserServiceFindOrCreateByPhoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceFindOrCreateByPhoneCall) Return(arg0 domain.User, arg1 error) *MockUserServiceFindOrCreateByPhoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceFindOrCreateByPhoneCall) Do(f func(context.Context, string, string) (domain.User, error)) *MockUserServiceFindOrCreateByPhoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceFindOrCreateByPhoneCall) DoAndReturn(f func(context.Context, string, string) (domain.User, error)) *MockUserServiceFindOrCreateByPhoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // FindOrCreateByWechat mocks base method.
 func (m *MockUserService) FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -49,31 +322,70 @@ func (m *MockUserService) FindOrCreateByWechat(ctx context.Context, info domain.
 }
 
 // FindOrCreateByWechat indicates an expected call of FindOrCreateByWechat.
-func (mr *MockUserServiceMockRecorder) FindOrCreateByWechat(ctx, info any) *UserServiceFindOrCreateByWechatCall {
+func (mr *MockUserServiceMockRecorder) FindOrCreateByWechat(ctx, info any) *MockUserServiceFindOrCreateByWechatCall {
 	mr.mock.ctrl.T.Helper()
 	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByWechat", reflect.TypeOf((*MockUserService)(nil).FindOrCreateByWechat), ctx, info)
-	return &UserServiceFindOrCreateByWechatCall{Call: call}
+	return &MockUserServiceFindOrCreateByWechatCall{Call: call}
+}
+
+// MockUserServiceFindOrCreateByWechatCall wrap *gomock.Call
+type MockUserServiceFindOrCreateByWechatCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserServiceFindOrCreateByWechatCall) Return(arg0 domain.User, arg1 error) *MockUserServiceFindOrCreateByWechatCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserServiceFindOrCreateByWechatCall) Do(f func(context.Context, domain.WechatInfo) (domain.User, error)) *MockUserServiceFindOrCreateByWechatCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserServiceFindOrCreateByWechatCall) DoAndReturn(f func(context.Context, domain.WechatInfo) (domain.User, error)) *MockUserServiceFindOrCreateByWechatCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Merge mocks base method.
+func (m_2 *MockUserService) Merge(ctx context.Context, m domain.Merge) (domain.Merge, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Merge", ctx, m)
+	ret0, _ := ret[0].(domain.Merge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockUserServiceMockRecorder) Merge(ctx, m any) *MockUserServiceMergeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockUserService)(nil).Merge), ctx, m)
+	return &MockUserServiceMergeCall{Call: call}
 }
 
-// UserServiceFindOrCreateByWechatCall wrap *gomock.Call
-type UserServiceFindOrCreateByWechatCall struct {
+// MockUserServiceMergeCall wrap *gomock.Call
+type MockUserServiceMergeCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *UserServiceFindOrCreateByWechatCall) Return(arg0 domain.User, arg1 error) *UserServiceFindOrCreateByWechatCall {
+func (c *MockUserServiceMergeCall) Return(arg0 domain.Merge, arg1 error) *MockUserServiceMergeCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *UserServiceFindOrCreateByWechatCall) Do(f func(context.Context, domain.WechatInfo) (domain.User, error)) *UserServiceFindOrCreateByWechatCall {
+func (c *MockUserServiceMergeCall) Do(f func(context.Context, domain.Merge) (domain.Merge, error)) *MockUserServiceMergeCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *UserServiceFindOrCreateByWechatCall) DoAndReturn(f func(context.Context, domain.WechatInfo) (domain.User, error)) *UserServiceFindOrCreateByWechatCall {
+func (c *MockUserServiceMergeCall) DoAndReturn(f func(context.Context, domain.Merge) (domain.Merge, error)) *MockUserServiceMergeCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -88,31 +400,31 @@ func (m *MockUserService) Profile(ctx context.Context, id int64) (domain.User, e
 }
 
 // Profile indicates an expected call of Profile.
-func (mr *MockUserServiceMockRecorder) Profile(ctx, id any) *UserServiceProfileCall {
+func (mr *MockUserServiceMockRecorder) Profile(ctx, id any) *MockUserServiceProfileCall {
 	mr.mock.ctrl.T.Helper()
 	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockUserService)(nil).Profile), ctx, id)
-	return &UserServiceProfileCall{Call: call}
+	return &MockUserServiceProfileCall{Call: call}
 }
 
-// UserServiceProfileCall wrap *gomock.Call
-type UserServiceProfileCall struct {
+// MockUserServiceProfileCall wrap *gomock.Call
+type MockUserServiceProfileCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *UserServiceProfileCall) Return(arg0 domain.User, arg1 error) *UserServiceProfileCall {
+func (c *MockUserServiceProfileCall) Return(arg0 domain.User, arg1 error) *MockUserServiceProfileCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *UserServiceProfileCall) Do(f func(context.Context, int64) (domain.User, error)) *UserServiceProfileCall {
+func (c *MockUserServiceProfileCall) Do(f func(context.Context, int64) (domain.User, error)) *MockUserServiceProfileCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *UserServiceProfileCall) DoAndReturn(f func(context.Context, int64) (domain.User, error)) *UserServiceProfileCall {
+func (c *MockUserServiceProfileCall) DoAndReturn(f func(context.Context, int64) (domain.User, error)) *MockUserServiceProfileCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -126,31 +438,31 @@ func (m *MockUserService) UpdateNonSensitiveInfo(ctx context.Context, user domai
 }
 
 // UpdateNonSensitiveInfo indicates an expected call of UpdateNonSensitiveInfo.
-func (mr *MockUserServiceMockRecorder) UpdateNonSensitiveInfo(ctx, user any) *UserServiceUpdateNonSensitiveInfoCall {
+func (mr *MockUserServiceMockRecorder) UpdateNonSensitiveInfo(ctx, user any) *MockUserServiceUpdateNonSensitiveInfoCall {
 	mr.mock.ctrl.T.Helper()
 	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNonSensitiveInfo", reflect.TypeOf((*MockUserService)(nil).UpdateNonSensitiveInfo), ctx, user)
-	return &UserServiceUpdateNonSensitiveInfoCall{Call: call}
+	return &MockUserServiceUpdateNonSensitiveInfoCall{Call: call}
 }
 
-// UserServiceUpdateNonSensitiveInfoCall wrap *gomock.Call
-type UserServiceUpdateNonSensitiveInfoCall struct {
+// MockUserServiceUpdateNonSensitiveInfoCall wrap *gomock.Call
+type MockUserServiceUpdateNonSensitiveInfoCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *UserServiceUpdateNonSensitiveInfoCall) Return(arg0 error) *UserServiceUpdateNonSensitiveInfoCall {
+func (c *MockUserServiceUpdateNonSensitiveInfoCall) Return(arg0 error) *MockUserServiceUpdateNonSensitiveInfoCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *UserServiceUpdateNonSensitiveInfoCall) Do(f func(context.Context, domain.User) error) *UserServiceUpdateNonSensitiveInfoCall {
+func (c *MockUserServiceUpdateNonSensitiveInfoCall) Do(f func(context.Context, domain.User) error) *MockUserServiceUpdateNonSensitiveInfoCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *UserServiceUpdateNonSensitiveInfoCall) DoAndReturn(f func(context.Context, domain.User) error) *UserServiceUpdateNonSensitiveInfoCall {
+func (c *MockUserServiceUpdateNonSensitiveInfoCall) DoAndReturn(f func(context.Context, domain.User) error) *MockUserServiceUpdateNonSensitiveInfoCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
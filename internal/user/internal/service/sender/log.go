@@ -0,0 +1,51 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sender
+
+import (
+	"context"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// LogSMSSender 只打印日志，在本地开发和测试环境使用
+type LogSMSSender struct {
+	logger *elog.Component
+}
+
+func NewLogSMSSender() *LogSMSSender {
+	return &LogSMSSender{logger: elog.DefaultLogger}
+}
+
+func (l *LogSMSSender) Send(ctx context.Context, tplId string, args []string, numbers ...string) error {
+	l.logger.Info("发送短信", elog.String("tplId", tplId),
+		elog.Any("args", args), elog.Any("numbers", numbers))
+	return nil
+}
+
+// LogEmailSender 只打印日志，在本地开发和测试环境使用
+type LogEmailSender struct {
+	logger *elog.Component
+}
+
+func NewLogEmailSender() *LogEmailSender {
+	return &LogEmailSender{logger: elog.DefaultLogger}
+}
+
+func (l *LogEmailSender) Send(ctx context.Context, to, subject, content string) error {
+	l.logger.Info("发送邮件", elog.String("to", to),
+		elog.String("subject", subject), elog.String("content", content))
+	return nil
+}
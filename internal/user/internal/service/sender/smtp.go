@@ -0,0 +1,110 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sender
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTPConfig 邮件服务商的 SMTP 配置，From 是发件人地址
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// SMTPEmailSender 通过 SMTP 发送邮件，服务商需要支持 STARTTLS，否则 PLAIN 认证会失败
+type SMTPEmailSender struct {
+	cfg  SMTPConfig
+	auth smtp.Auth
+}
+
+func NewSMTPEmailSender(cfg SMTPConfig) *SMTPEmailSender {
+	return &SMTPEmailSender{
+		cfg:  cfg,
+		auth: smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host),
+	}
+}
+
+func (s *SMTPEmailSender) Send(ctx context.Context, to, subject, content string) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("收件人地址不合法 %q", to)
+	}
+	var sb strings.Builder
+	sb.WriteString("From: " + s.cfg.From + "\r\n")
+	sb.WriteString("To: " + to + "\r\n")
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(content)
+	return s.send(ctx, to, []byte(sb.String()))
+}
+
+// send 和 smtp.SendMail 一样，只是连接受 ctx 控制，服务商卡住的时候不会一直阻塞
+func (s *SMTPEmailSender) send(ctx context.Context, to string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+	err = c.StartTLS(&tls.Config{ServerName: s.cfg.Host})
+	if err != nil {
+		return err
+	}
+	err = c.Auth(s.auth)
+	if err != nil {
+		return err
+	}
+	err = c.Mail(s.cfg.From)
+	if err != nil {
+		return err
+	}
+	err = c.Rcpt(to)
+	if err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
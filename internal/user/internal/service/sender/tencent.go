@@ -0,0 +1,153 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	tencentSMSHost    = "sms.tencentcloudapi.com"
+	tencentSMSService = "sms"
+	tencentSMSVersion = "2021-01-11"
+)
+
+// TencentSMSConfig 腾讯云短信的配置，AppID 是短信应用的 SdkAppId
+type TencentSMSConfig struct {
+	SecretID  string `yaml:"secretID"`
+	SecretKey string `yaml:"secretKey"`
+	Region    string `yaml:"region"`
+	AppID     string `yaml:"appID"`
+	SignName  string `yaml:"signName"`
+}
+
+// TencentSMSSender 直接调用腾讯云短信的 API，签名方法是 TC3-HMAC-SHA256
+type TencentSMSSender struct {
+	cfg    TencentSMSConfig
+	client *http.Client
+}
+
+func NewTencentSMSSender(cfg TencentSMSConfig) *TencentSMSSender {
+	return &TencentSMSSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type tencentSendSMSReq struct {
+	PhoneNumberSet   []string `json:"PhoneNumberSet"`
+	SmsSdkAppId      string   `json:"SmsSdkAppId"`
+	SignName         string   `json:"SignName"`
+	TemplateId       string   `json:"TemplateId"`
+	TemplateParamSet []string `json:"TemplateParamSet"`
+}
+
+type tencentSendSMSResp struct {
+	Response struct {
+		SendStatusSet []struct {
+			PhoneNumber string `json:"PhoneNumber"`
+			Code        string `json:"Code"`
+			Message     string `json:"Message"`
+		} `json:"SendStatusSet"`
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+		RequestId string `json:"RequestId"`
+	} `json:"Response"`
+}
+
+func (t *TencentSMSSender) Send(ctx context.Context, tplId string, args []string, numbers ...string) error {
+	payload, err := json.Marshal(tencentSendSMSReq{
+		PhoneNumberSet:   numbers,
+		SmsSdkAppId:      t.cfg.AppID,
+		SignName:         t.cfg.SignName,
+		TemplateId:       tplId,
+		TemplateParamSet: args,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"https://"+tencentSMSHost, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-TC-Action", "SendSms")
+	req.Header.Set("X-TC-Version", tencentSMSVersion)
+	req.Header.Set("X-TC-Region", t.cfg.Region)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set("Authorization", t.authorization(payload, now))
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var res tencentSendSMSResp
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return fmt.Errorf("解析腾讯云短信的响应失败，HTTP 状态码 %d: %w", resp.StatusCode, err)
+	}
+	if res.Response.Error != nil {
+		return fmt.Errorf("发送短信失败，请求 %s: %s %s", res.Response.RequestId,
+			res.Response.Error.Code, res.Response.Error.Message)
+	}
+	for _, status := range res.Response.SendStatusSet {
+		if status.Code != "Ok" {
+			return fmt.Errorf("发送短信失败，请求 %s，手机号 %s: %s %s", res.Response.RequestId,
+				status.PhoneNumber, status.Code, status.Message)
+		}
+	}
+	return nil
+}
+
+// authorization 参考 https://cloud.tencent.com/document/api/382/52072
+func (t *TencentSMSSender) authorization(payload []byte, now time.Time) string {
+	const signedHeaders = "content-type;host;x-tc-action"
+	canonicalRequest := fmt.Sprintf("POST\n/\n\ncontent-type:application/json; charset=utf-8\nhost:%s\nx-tc-action:sendsms\n\n%s\n%s",
+		tencentSMSHost, signedHeaders, sha256Hex(payload))
+	date := now.UTC().Format("2006-01-02")
+	scope := fmt.Sprintf("%s/%s/tc3_request", date, tencentSMSService)
+	stringToSign := fmt.Sprintf("TC3-HMAC-SHA256\n%d\n%s\n%s",
+		now.Unix(), scope, sha256Hex([]byte(canonicalRequest)))
+	secretDate := hmacSHA256([]byte("TC3"+t.cfg.SecretKey), date)
+	secretService := hmacSHA256(secretDate, tencentSMSService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.cfg.SecretID, scope, signedHeaders, signature)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
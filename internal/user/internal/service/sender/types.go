@@ -0,0 +1,31 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sender
+
+import (
+	"context"
+)
+
+// SMSSender 短信服务商，接入新的服务商只需要实现这个接口
+type SMSSender interface {
+	// Send tplId 是服务商那边的短信模板，args 按照顺序填充模板里面的参数
+	Send(ctx context.Context, tplId string, args []string, numbers ...string) error
+}
+
+// EmailSender 邮件服务商
+type EmailSender interface {
+	// Send content 是 HTML
+	Send(ctx context.Context, to, subject, content string) error
+}
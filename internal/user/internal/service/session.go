@@ -0,0 +1,77 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/ecodeclub/webook/internal/user/internal/repository"
+)
+
+//go:generate mockgen -source=./session.go -package=svcmocks -typed=true -destination=mocks/session.mock.go SessionService
+type SessionService interface {
+	// Record 登录之后记录会话，超过同时在线的上限的时候，会踢掉最早登录的会话
+	Record(ctx context.Context, sess domain.Session) error
+	List(ctx context.Context, uid int64) ([]domain.Session, error)
+	// Revoke 踢掉某个会话，会话不存在的时候什么也不做
+	Revoke(ctx context.Context, uid int64, ssid string) error
+	// RevokeAll 踢掉用户所有的会话，给管理员处理被盗或者被封禁的账号用
+	RevokeAll(ctx context.Context, uid int64) error
+}
+
+type sessionService struct {
+	repo repository.SessionRepository
+	// maxActive 一个账号最多同时有几个会话，小于等于 0 的时候不限制
+	maxActive int
+}
+
+func NewSessionService(repo repository.SessionRepository, maxActive int) SessionService {
+	return &sessionService{
+		repo:      repo,
+		maxActive: maxActive,
+	}
+}
+
+func (s *sessionService) Record(ctx context.Context, sess domain.Session) error {
+	sess.Ctime = time.Now().UnixMilli()
+	err := s.repo.Add(ctx, sess)
+	if err != nil || s.maxActive <= 0 {
+		return err
+	}
+	sessions, err := s.repo.List(ctx, sess.Uid)
+	if err != nil || len(sessions) <= s.maxActive {
+		return err
+	}
+	// List 是按照登录时间从新到旧排列的，刚刚登录的会话总是会被保留下来
+	ssids := slice.Map(sessions[s.maxActive:], func(idx int, src domain.Session) string {
+		return src.SSID
+	})
+	return s.repo.Delete(ctx, sess.Uid, ssids...)
+}
+
+func (s *sessionService) List(ctx context.Context, uid int64) ([]domain.Session, error) {
+	return s.repo.List(ctx, uid)
+}
+
+func (s *sessionService) Revoke(ctx context.Context, uid int64, ssid string) error {
+	return s.repo.Delete(ctx, uid, ssid)
+}
+
+func (s *sessionService) RevokeAll(ctx context.Context, uid int64) error {
+	return s.repo.DeleteAll(ctx, uid)
+}
@@ -17,7 +17,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/ecodeclub/webook/internal/user/internal/event"
 	"github.com/lithammer/shortuuid/v4"
 
@@ -26,29 +28,65 @@ import (
 	"github.com/gotomicro/ego/core/elog"
 )
 
+var (
+	// ErrIdentityBound 登录方式已经属于另外一个账号
+	ErrIdentityBound = errors.New("登录方式已经绑定了其它账号")
+	// ErrIdentityConflict 账号已经绑定了同一类但是不同的登录方式，比如说另外一个手机号码
+	ErrIdentityConflict = errors.New("账号已经绑定了同类的其它登录方式")
+	ErrInvalidMerge     = errors.New("非法的合并账号请求")
+	ErrUserNotFound     = repository.ErrUserNotFound
+)
+
 //go:generate mockgen -source=./user.go -package=svcmocks -typed=true -destination=mocks/user.mock.go UserService
 type UserService interface {
 	Profile(ctx context.Context, id int64) (domain.User, error)
+	// FindByWechat 按照 unionId 查找，用户不存在的时候返回 ErrUserNotFound
+	FindByWechat(ctx context.Context, unionId string) (domain.User, error)
 	// FindOrCreateByWechat 查找或者初始化
 	// 随着业务增长，这边可以考虑拆分出去作为一个新的 Service
 	FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error)
+	// FindOrCreateByPhone 手机号码必须是验证过的，新用户和微信注册的一样会发送注册消息
+	FindOrCreateByPhone(ctx context.Context, phone, invitationCode string) (domain.User, error)
+	// FindOrCreateByEmail 邮箱必须是验证过的
+	FindOrCreateByEmail(ctx context.Context, email, invitationCode string) (domain.User, error)
+
+	// BindWechat 给 uid 绑定微信，网页扫码和小程序都用这个方法。
+	// unionId、openId 和 miniOpenId 任何一个已经属于另外的账号的时候，
+	// merge 为 true 就把那些账号合并到 uid 上，否则返回 ErrIdentityBound
+	BindWechat(ctx context.Context, uid int64, info domain.WechatInfo, merge bool) (domain.User, error)
+	// BindPhone 手机号码必须是验证过的，merge 的含义和 BindWechat 一样
+	BindPhone(ctx context.Context, uid int64, phone string, merge bool) (domain.User, error)
+	// BindEmail 邮箱必须是验证过的，merge 的含义和 BindWechat 一样
+	BindEmail(ctx context.Context, uid int64, email string, merge bool) (domain.User, error)
+	// Merge 把源账号合并到目标账号，源账号的登录方式会转移到目标账号上，
+	// 其它模块的数据靠合并消息异步转移
+	Merge(ctx context.Context, m domain.Merge) (domain.Merge, error)
 
 	// UpdateNonSensitiveInfo 更新非敏感数据
 	// 你可以在这里进一步补充究竟哪些数据会被更新
 	UpdateNonSensitiveInfo(ctx context.Context, user domain.User) error
+	// Anonymize 注销账号，清空个人信息和登录方式，之后这个账号就没办法再登录了
+	Anonymize(ctx context.Context, uid int64) error
 }
 
 type userService struct {
-	repo     repository.UserRepository
-	producer event.RegistrationEventProducer
-	logger   *elog.Component
+	repo          repository.UserRepository
+	producer      event.RegistrationEventProducer
+	mergeProducer event.MergeEventProducer
+	sessionSvc    SessionService
+	logger        *elog.Component
 }
 
-func NewUserService(repo repository.UserRepository, p event.RegistrationEventProducer) UserService {
+func NewUserService(repo repository.UserRepository,
+	p event.RegistrationEventProducer,
+	mergeProducer event.MergeEventProducer,
+	sessionSvc SessionService) UserService {
 	return &userService{
-		repo:     repo,
-		producer: p,
-		logger:   elog.DefaultLogger,
+		repo:          repo,
+		producer:      p,
+		mergeProducer: mergeProducer,
+		sessionSvc:    sessionSvc,
+		logger:        elog.DefaultLogger,
 	}
 }
 
@@ -76,19 +114,58 @@ func (svc *userService) FindOrCreateByWechat(ctx context.Context,
 	if !errors.Is(err, repository.ErrUserNotFound) {
 		return u, err
 	}
-	sn := shortuuid.New()
 	u = domain.User{
 		WechatInfo: info,
-		SN:         sn,
-		Nickname:   sn[:4],
 	}
-	id, err := svc.repo.Create(ctx, u)
+	err = svc.create(ctx, &u, info.InvitationCode)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return u, nil
+}
 
+func (svc *userService) FindOrCreateByPhone(ctx context.Context, phone, invitationCode string) (domain.User, error) {
+	return svc.findOrCreate(ctx, func(ctx context.Context) (domain.User, error) {
+		return svc.repo.FindByPhone(ctx, phone)
+	}, domain.User{Phone: phone}, invitationCode)
+}
+
+func (svc *userService) FindOrCreateByEmail(ctx context.Context, email, invitationCode string) (domain.User, error) {
+	return svc.findOrCreate(ctx, func(ctx context.Context) (domain.User, error) {
+		return svc.repo.FindByEmail(ctx, email)
+	}, domain.User{Email: email}, invitationCode)
+}
+
+func (svc *userService) findOrCreate(ctx context.Context,
+	find func(ctx context.Context) (domain.User, error),
+	u domain.User, invitationCode string) (domain.User, error) {
+	found, err := find(ctx)
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return found, err
+	}
+	err = svc.create(ctx, &u, invitationCode)
+	if errors.Is(err, repository.ErrUserDuplicate) {
+		// 同时登录了两次，另外一次已经创建好了
+		return find(ctx)
+	}
 	if err != nil {
 		return domain.User{}, err
 	}
+	return u, nil
+}
+
+// create 创建新用户并且发送注册成功消息，不管是哪一种登录方式注册的，营销那边都一样处理
+func (svc *userService) create(ctx context.Context, u *domain.User, invitationCode string) error {
+	sn := shortuuid.New()
+	u.SN = sn
+	u.Nickname = sn[:4]
+	id, err := svc.repo.Create(ctx, *u)
+	if err != nil {
+		return err
+	}
+	u.Id = id
 	// 发送注册成功消息
-	evt := event.RegistrationEvent{Uid: id, InvitationCode: info.InvitationCode}
+	evt := event.RegistrationEvent{Uid: id, InvitationCode: invitationCode}
 	if e := svc.producer.Produce(ctx, evt); e != nil {
 		svc.logger.Error("发送注册成功消息失败",
 			elog.FieldErr(e),
@@ -96,8 +173,151 @@ func (svc *userService) FindOrCreateByWechat(ctx context.Context,
 			elog.FieldValueAny(evt),
 		)
 	}
-	u.Id = id
-	return u, nil
+	return nil
+}
+
+func (svc *userService) BindWechat(ctx context.Context, uid int64, info domain.WechatInfo, merge bool) (domain.User, error) {
+	return svc.bind(ctx, uid, merge, func(ctx context.Context) ([]domain.User, error) {
+		// 没有 unionId 的时候只能靠 openId 和 miniOpenId 找到原来的账号
+		return svc.repo.FindAllByWechat(ctx, info)
+	}, func(u *domain.User) bool {
+		conflict := func(old, val string) bool {
+			return old != "" && val != "" && old != val
+		}
+		if conflict(u.WechatInfo.UnionId, info.UnionId) ||
+			conflict(u.WechatInfo.OpenId, info.OpenId) ||
+			conflict(u.WechatInfo.MiniOpenId, info.MiniOpenId) {
+			return false
+		}
+		if info.UnionId != "" {
+			u.WechatInfo.UnionId = info.UnionId
+		}
+		if info.OpenId != "" {
+			u.WechatInfo.OpenId = info.OpenId
+		}
+		if info.MiniOpenId != "" {
+			u.WechatInfo.MiniOpenId = info.MiniOpenId
+		}
+		return true
+	})
+}
+
+func (svc *userService) BindPhone(ctx context.Context, uid int64, phone string, merge bool) (domain.User, error) {
+	return svc.bind(ctx, uid, merge, func(ctx context.Context) ([]domain.User, error) {
+		return svc.owners(svc.repo.FindByPhone(ctx, phone))
+	}, func(u *domain.User) bool {
+		if u.Phone != "" && u.Phone != phone {
+			return false
+		}
+		u.Phone = phone
+		return true
+	})
+}
+
+func (svc *userService) BindEmail(ctx context.Context, uid int64, email string, merge bool) (domain.User, error) {
+	return svc.bind(ctx, uid, merge, func(ctx context.Context) ([]domain.User, error) {
+		return svc.owners(svc.repo.FindByEmail(ctx, email))
+	}, func(u *domain.User) bool {
+		if u.Email != "" && u.Email != email {
+			return false
+		}
+		u.Email = email
+		return true
+	})
+}
+
+// owners 把按照登录方式查找的结果转成 bind 需要的形式，没找到就是还没有绑定过
+func (svc *userService) owners(u domain.User, err error) ([]domain.User, error) {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []domain.User{u}, nil
+}
+
+// bind find 查找登录方式当前属于哪些账号，apply 把登录方式设置到用户上，
+// 用户已经有了同一类但是不同的登录方式的时候返回 false
+func (svc *userService) bind(ctx context.Context, uid int64, merge bool,
+	find func(ctx context.Context) ([]domain.User, error),
+	apply func(u *domain.User) bool) (domain.User, error) {
+	u, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return domain.User{}, err
+	}
+	// 先检查，避免合并了之后才发现目标账号的登录方式冲突，源账号的就被丢掉了
+	if !apply(&u) {
+		return domain.User{}, ErrIdentityConflict
+	}
+	owners, err := find(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+	others := slice.FilterDelete(owners, func(idx int, src domain.User) bool {
+		return src.Id == uid
+	})
+	if len(others) > 0 {
+		if !merge {
+			return domain.User{}, ErrIdentityBound
+		}
+		for _, owner := range others {
+			_, err = svc.Merge(ctx, domain.Merge{SourceUid: owner.Id, TargetUid: uid, Operator: uid})
+			if err != nil {
+				return domain.User{}, err
+			}
+		}
+		// 合并只转移了源账号上有的登录方式，剩下的还是要设置一遍
+		u, err = svc.repo.FindById(ctx, uid)
+		if err != nil {
+			return domain.User{}, err
+		}
+		if !apply(&u) {
+			return domain.User{}, ErrIdentityConflict
+		}
+	}
+	err = svc.repo.Update(ctx, u)
+	if errors.Is(err, repository.ErrUserDuplicate) {
+		// 同时有人用这个登录方式注册或者绑定了
+		return domain.User{}, ErrIdentityBound
+	}
+	return u, err
+}
+
+func (svc *userService) Merge(ctx context.Context, m domain.Merge) (domain.Merge, error) {
+	if m.SourceUid <= 0 || m.TargetUid <= 0 || m.SourceUid == m.TargetUid {
+		return domain.Merge{}, fmt.Errorf("%w, source %d, target %d", ErrInvalidMerge, m.SourceUid, m.TargetUid)
+	}
+	id, err := svc.repo.Merge(ctx, m)
+	if err != nil {
+		return domain.Merge{}, err
+	}
+	m.Id = id
+	// 其它模块的数据都依赖这个消息来转移，所以发送失败要返回错误，让调用者重试
+	evt := event.MergeEvent{Id: id, SourceUid: m.SourceUid, TargetUid: m.TargetUid}
+	err = svc.mergeProducer.Produce(ctx, evt)
+	if err != nil {
+		return m, fmt.Errorf("发送合并账号消息失败 %w, merge %d", err, id)
+	}
+	// 源账号已经没有登录方式了，还登录着的会话也要踢掉，不然还能继续往源账号上写数据
+	err = svc.sessionSvc.RevokeAll(ctx, m.SourceUid)
+	if err != nil {
+		return m, fmt.Errorf("踢掉源账号的会话失败 %w, merge %d, source %d", err, id, m.SourceUid)
+	}
+	svc.logger.Info("合并账号",
+		elog.Int64("id", id),
+		elog.Int64("source", m.SourceUid),
+		elog.Int64("target", m.TargetUid),
+		elog.Int64("operator", m.Operator))
+	return m, nil
+}
+
+func (svc *userService) Anonymize(ctx context.Context, uid int64) error {
+	return svc.repo.Anonymize(ctx, uid)
+}
+
+func (svc *userService) FindByWechat(ctx context.Context, unionId string) (domain.User, error) {
+	return svc.repo.FindByWechat(ctx, unionId)
 }
 
 func (svc *userService) Profile(ctx context.Context,
@@ -0,0 +1,75 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/ecodeclub/webook/internal/user/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	svc        service.UserService
+	sessionSvc service.SessionService
+	rbac       *middleware.CheckRBACMiddlewareBuilder
+}
+
+func NewAdminHandler(svc service.UserService, sessionSvc service.SessionService,
+	rbac *middleware.CheckRBACMiddlewareBuilder) *AdminHandler {
+	return &AdminHandler{svc: svc, sessionSvc: sessionSvc, rbac: rbac}
+}
+
+func (h *AdminHandler) PrivateRoutes(server *gin.Engine) {
+	g := server.Group("/users")
+	// 合并账号需要 (user, merge) 权限，踢掉会话需要 (user, session) 权限
+	g.POST("/merge", h.rbac.Build("user", "merge"), ginx.BS[MergeReq](h.Merge))
+	g.POST("/sessions/revoke", h.rbac.Build("user", "session"), ginx.B[RevokeSessionsReq](h.RevokeSessions))
+}
+
+// RevokeSessions 踢掉账号所有的会话，用于账号被盗或者被封禁的场景
+func (h *AdminHandler) RevokeSessions(ctx *ginx.Context, req RevokeSessionsReq) (ginx.Result, error) {
+	err := h.sessionSvc.RevokeAll(ctx, req.Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+// Merge 用户没办法自己证明两个账号都是自己的时候，由管理员来合并
+func (h *AdminHandler) Merge(ctx *ginx.Context, req MergeReq, sess session.Session) (ginx.Result, error) {
+	m, err := h.svc.Merge(ctx, domain.Merge{
+		SourceUid: req.SourceUid,
+		TargetUid: req.TargetUid,
+		Operator:  sess.Claims().Uid,
+	})
+	switch {
+	case err == nil:
+		return ginx.Result{Data: Merge{
+			Id:        m.Id,
+			SourceUid: m.SourceUid,
+			TargetUid: m.TargetUid,
+			Operator:  m.Operator,
+		}}, nil
+	case errors.Is(err, service.ErrInvalidMerge):
+		return invalidMergeResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/ecodeclub/webook/internal/user/internal/service"
+)
+
+func (h *Handler) BindWechat(ctx *ginx.Context, req BindWechatReq, sess session.Session) (ginx.Result, error) {
+	return h.bindWechat(ctx, h.weSvc, req, sess.Claims().Uid)
+}
+
+func (h *Handler) BindWechatMini(ctx *ginx.Context, req BindWechatReq, sess session.Session) (ginx.Result, error) {
+	return h.bindWechat(ctx, h.weMiniSvc, req, sess.Claims().Uid)
+}
+
+func (h *Handler) bindWechat(ctx *ginx.Context, svc service.OAuth2Service, req BindWechatReq, uid int64) (ginx.Result, error) {
+	info, err := svc.Verify(ctx, service.CallbackParams{
+		Code:  req.Code,
+		State: req.State,
+	})
+	if err != nil {
+		return systemErrorResult, err
+	}
+	user, err := h.userSvc.BindWechat(ctx, uid, info, req.Merge)
+	return h.bindResult(user, err)
+}
+
+func (h *Handler) SendBindSMSCode(ctx *ginx.Context, req SendSMSCodeReq) (ginx.Result, error) {
+	return h.sendSMSCode(ctx, service.BizBind, req.Phone)
+}
+
+func (h *Handler) BindPhone(ctx *ginx.Context, req BindPhoneReq, sess session.Session) (ginx.Result, error) {
+	if !phoneRegexp.MatchString(req.Phone) {
+		return invalidPhoneResult, nil
+	}
+	err := h.smsCodeSvc.Verify(ctx, service.BizBind, req.Phone, ctx.ClientIP(), req.Code)
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	user, err := h.userSvc.BindPhone(ctx, sess.Claims().Uid, req.Phone, req.Merge)
+	return h.bindResult(user, err)
+}
+
+func (h *Handler) SendBindMagicLink(ctx *ginx.Context, req SendMagicLinkReq) (ginx.Result, error) {
+	// 绑定不需要邀请码
+	return h.sendMagicLink(ctx, service.BizBind, req.Email, "")
+}
+
+func (h *Handler) BindEmail(ctx *ginx.Context, req BindEmailReq, sess session.Session) (ginx.Result, error) {
+	req.Email = normalizeEmail(req.Email)
+	if !emailRegexp.MatchString(req.Email) {
+		return invalidEmailResult, nil
+	}
+	err := h.magicLinkSvc.Verify(ctx, service.BizBind, req.Email, ctx.ClientIP(), req.Token)
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	user, err := h.userSvc.BindEmail(ctx, sess.Claims().Uid, req.Email, req.Merge)
+	return h.bindResult(user, err)
+}
+
+func (h *Handler) bindResult(user domain.User, err error) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{Data: newProfile(user)}, nil
+	case errors.Is(err, service.ErrIdentityBound):
+		return identityBoundResult, nil
+	case errors.Is(err, service.ErrIdentityConflict):
+		return identityConflictResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
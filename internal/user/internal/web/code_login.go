@@ -0,0 +1,122 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/ecodeclub/webook/internal/user/internal/service"
+)
+
+var (
+	// 只支持国内的手机号码
+	phoneRegexp = regexp.MustCompile(`^1\d{10}$`)
+	// 只做粗略的校验，邮箱是否真的存在由登录链接来验证
+	emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+func (h *Handler) SendSMSCode(ctx *ginx.Context, req SendSMSCodeReq) (ginx.Result, error) {
+	return h.sendSMSCode(ctx, service.BizLogin, req.Phone)
+}
+
+func (h *Handler) sendSMSCode(ctx *ginx.Context, biz, phone string) (ginx.Result, error) {
+	if !phoneRegexp.MatchString(phone) {
+		return invalidPhoneResult, nil
+	}
+	err := h.smsCodeSvc.Send(ctx, biz, phone, ctx.ClientIP())
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *Handler) SMSLogin(ctx *ginx.Context, req SMSLoginReq) (ginx.Result, error) {
+	if !phoneRegexp.MatchString(req.Phone) {
+		return invalidPhoneResult, nil
+	}
+	err := h.smsCodeSvc.Verify(ctx, service.BizLogin, req.Phone, ctx.ClientIP(), req.Code)
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	user, err := h.userSvc.FindOrCreateByPhone(ctx, req.Phone, req.InvitationCode)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return h.login(ctx, user)
+}
+
+func (h *Handler) SendMagicLink(ctx *ginx.Context, req SendMagicLinkReq) (ginx.Result, error) {
+	return h.sendMagicLink(ctx, service.BizLogin, req.Email, req.InvitationCode)
+}
+
+func (h *Handler) sendMagicLink(ctx *ginx.Context, biz, email, invitationCode string) (ginx.Result, error) {
+	email = normalizeEmail(email)
+	if !emailRegexp.MatchString(email) {
+		return invalidEmailResult, nil
+	}
+	err := h.magicLinkSvc.Send(ctx, biz, email, ctx.ClientIP(), invitationCode)
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *Handler) EmailLogin(ctx *ginx.Context, req EmailLoginReq) (ginx.Result, error) {
+	req.Email = normalizeEmail(req.Email)
+	if !emailRegexp.MatchString(req.Email) {
+		return invalidEmailResult, nil
+	}
+	err := h.magicLinkSvc.Verify(ctx, service.BizLogin, req.Email, ctx.ClientIP(), req.Token)
+	if err != nil {
+		return h.codeErrResult(err)
+	}
+	user, err := h.userSvc.FindOrCreateByEmail(ctx, req.Email, req.InvitationCode)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return h.login(ctx, user)
+}
+
+// normalizeEmail 邮箱不区分大小写，统一转成小写，避免同一个邮箱注册出多个账号
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (h *Handler) login(ctx *ginx.Context, user domain.User) (ginx.Result, error) {
+	res, err := h.setupSession(ctx, user)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{
+		Data: res,
+	}, nil
+}
+
+func (h *Handler) codeErrResult(err error) (ginx.Result, error) {
+	switch {
+	case errors.Is(err, service.ErrCodeSendTooMany):
+		return codeSendTooManyResult, nil
+	case errors.Is(err, service.ErrCodeInvalid):
+		return codeInvalidResult, nil
+	case errors.Is(err, service.ErrCodeVerifyTooMany):
+		return codeVerifyTooManyResult, nil
+	default:
+		return systemErrorResult, err
+	}
+}
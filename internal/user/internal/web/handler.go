@@ -41,12 +41,14 @@ type Handler struct {
 	weSvc         service.OAuth2Service
 	weMiniSvc     service.OAuth2Service
 	userSvc       service.UserService
+	smsCodeSvc    service.SMSCodeService
+	magicLinkSvc  service.MagicLinkService
+	sessionSvc    service.SessionService
 	memberSvc     member.Service
 	permissionSvc permission.Service
-	// 白名单
-	creators []string
-	logger   *elog.Component
-	sp       session.Provider
+	rbacSvc       permission.RBACService
+	logger        *elog.Component
+	sp            session.Provider
 }
 
 func NewHandler(
@@ -55,17 +57,26 @@ func NewHandler(
 	// 微信小程序
 	weMiniSvc service.OAuth2Service,
 	userSvc service.UserService,
+	// 手机验证码登录
+	smsCodeSvc service.SMSCodeService,
+	// 邮箱登录链接
+	magicLinkSvc service.MagicLinkService,
+	// 登录之后记录会话
+	sessionSvc service.SessionService,
 	memberSvc member.Service,
 	permissionSvc permission.Service,
-	sp session.Provider,
-	creators []string) *Handler {
+	rbacSvc permission.RBACService,
+	sp session.Provider) *Handler {
 	return &Handler{
 		weSvc:         weSvc,
 		weMiniSvc:     weMiniSvc,
 		userSvc:       userSvc,
+		smsCodeSvc:    smsCodeSvc,
+		magicLinkSvc:  magicLinkSvc,
+		sessionSvc:    sessionSvc,
 		memberSvc:     memberSvc,
 		permissionSvc: permissionSvc,
-		creators:      creators,
+		rbacSvc:       rbacSvc,
 		logger:        elog.DefaultLogger,
 		sp:            sp,
 	}
@@ -74,8 +85,22 @@ func NewHandler(
 func (h *Handler) PrivateRoutes(server *gin.Engine) {
 	users := server.Group("/users")
 	users.GET("/profile", ginx.S(h.Profile))
-	users.POST("/logout", ginx.W(h.Logout))
+	users.POST("/logout", ginx.S(h.Logout))
 	users.POST("/profile", ginx.BS[EditReq](h.Edit))
+
+	// 查看和踢掉自己在其它设备上的会话
+	sessions := users.Group("/sessions")
+	sessions.POST("/list", ginx.S(h.ListSessions))
+	sessions.POST("/revoke", ginx.BS[RevokeSessionReq](h.RevokeSession))
+
+	// 给当前账号绑定其它的登录方式，登录方式属于另外一个账号的时候可以顺便合并
+	bind := users.Group("/bind")
+	bind.POST("/wechat", ginx.BS[BindWechatReq](h.BindWechat))
+	bind.POST("/wechat/mini", ginx.BS[BindWechatReq](h.BindWechatMini))
+	bind.POST("/sms/code/send", ginx.B[SendSMSCodeReq](h.SendBindSMSCode))
+	bind.POST("/phone", ginx.BS[BindPhoneReq](h.BindPhone))
+	bind.POST("/email/link/send", ginx.B[SendMagicLinkReq](h.SendBindMagicLink))
+	bind.POST("/email", ginx.BS[BindEmailReq](h.BindEmail))
 }
 
 func (h *Handler) PublicRoutes(server *gin.Engine) {
@@ -89,13 +114,25 @@ func (h *Handler) PublicRoutes(server *gin.Engine) {
 	// 小程序登录回调
 	oauth2.Any("/wechat/mini/callback", appidFunc, ginx.B[WechatCallback](h.MiniCallback))
 	oauth2.Any("/wechat/token/refresh", appidFunc, ginx.W(h.RefreshAccessToken))
+
+	// 不能用微信的用户，用手机号码或者邮箱登录
+	users := server.Group("/users")
+	users.POST("/sms/code/send", appidFunc, ginx.B[SendSMSCodeReq](h.SendSMSCode))
+	users.POST("/sms/login", appidFunc, ginx.B[SMSLoginReq](h.SMSLogin))
+	users.POST("/email/link/send", appidFunc, ginx.B[SendMagicLinkReq](h.SendMagicLink))
+	users.POST("/email/login", appidFunc, ginx.B[EmailLoginReq](h.EmailLogin))
 }
 
-func (h *Handler) Logout(ctx *ginx.Context) (ginx.Result, error) {
+func (h *Handler) Logout(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
 	err := h.sp.Destroy(ctx)
 	if err != nil {
 		return systemErrorResult, nil
 	}
+	// 会话已经销毁了，这里只是把它从会话列表里面去掉
+	err = h.sessionSvc.Revoke(ctx, sess.Claims().Uid, sess.Claims().SSID)
+	if err != nil {
+		h.logger.Error("清理会话记录失败", elog.FieldErr(err))
+	}
 	return ginx.Result{
 		Msg: "OK",
 	}, nil
@@ -127,9 +164,10 @@ func (h *Handler) RefreshAccessToken(ctx *ginx.Context) (ginx.Result, error) {
 
 func (h *Handler) Profile(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
 	var (
-		eg errgroup.Group
-		u  domain.User
-		m  member.Member
+		eg        errgroup.Group
+		u         domain.User
+		m         member.Member
+		isCreator bool
 	)
 	uid := sess.Claims().Uid
 	eg.Go(func() error {
@@ -147,14 +185,22 @@ func (h *Handler) Profile(ctx *ginx.Context, sess session.Session) (ginx.Result,
 		return nil
 	})
 
+	eg.Go(func() error {
+		var err error
+		// 以角色为准，这样授权或者撤销角色之后不需要重新登录
+		isCreator, err = h.rbacSvc.Check(ctx, uid, "admin", "login")
+		if err != nil {
+			h.logger.Error("查找用户的角色权限失败", elog.FieldErr(err))
+		}
+		return nil
+	})
+
 	err := eg.Wait()
 	if err != nil {
 		return systemErrorResult, err
 	}
 	res := newProfile(u)
-	res.IsCreator = sess.Claims().
-		Get("creator").
-		StringOrDefault("") == "true"
+	res.IsCreator = isCreator
 	res.MemberDDL = m.EndAt
 	return ginx.Result{
 		Data: res,
@@ -203,8 +249,11 @@ func (h *Handler) Callback(ctx *ginx.Context, req WechatCallback) (ginx.Result,
 func (h *Handler) setupSession(ctx *ginx.Context, user domain.User) (Profile, error) {
 	// 构建session
 	jwtData := map[string]string{}
-	// 设置是否 creator 的标记位，后续引入权限控制再来改造
-	isCreator := slice.Contains(h.creators, user.WechatInfo.UnionId)
+	// 设置是否 creator 的标记位，只是给前端展示入口用，真正的校验在后端的 RBAC 中间件
+	isCreator, err := h.rbacSvc.Check(ctx, user.Id, "admin", "login")
+	if err != nil {
+		return Profile{}, err
+	}
 	jwtData["creator"] = strconv.FormatBool(isCreator)
 	// 设置会员截止日期
 	memberDDL := h.getMemberDDL(ctx, user.Id)
@@ -224,10 +273,11 @@ func (h *Handler) setupSession(ctx *ginx.Context, user domain.User) (Profile, er
 	permsVal, _ := json.Marshal(perms)
 	// redis 不能处理 map[string]map[string][string] 这种二层结构
 	sessData := map[string]any{"permission": permsVal}
-	_, err = session.NewSessionBuilder(ctx, user.Id).SetJwtData(jwtData).SetSessData(sessData).Build()
+	sess, err := session.NewSessionBuilder(ctx, user.Id).SetJwtData(jwtData).SetSessData(sessData).Build()
 	if err != nil {
 		return Profile{}, err
 	}
+	h.recordSession(ctx, sess)
 	res := newProfile(user)
 	res.IsCreator = isCreator
 	res.MemberDDL = memberDDL
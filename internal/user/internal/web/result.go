@@ -10,4 +10,36 @@ var (
 		Code: errs.SystemError.Code,
 		Msg:  errs.SystemError.Msg,
 	}
+	invalidPhoneResult = ginx.Result{
+		Code: errs.InvalidPhone.Code,
+		Msg:  errs.InvalidPhone.Msg,
+	}
+	invalidEmailResult = ginx.Result{
+		Code: errs.InvalidEmail.Code,
+		Msg:  errs.InvalidEmail.Msg,
+	}
+	codeSendTooManyResult = ginx.Result{
+		Code: errs.CodeSendTooMany.Code,
+		Msg:  errs.CodeSendTooMany.Msg,
+	}
+	codeInvalidResult = ginx.Result{
+		Code: errs.CodeInvalid.Code,
+		Msg:  errs.CodeInvalid.Msg,
+	}
+	codeVerifyTooManyResult = ginx.Result{
+		Code: errs.CodeVerifyTooMany.Code,
+		Msg:  errs.CodeVerifyTooMany.Msg,
+	}
+	identityBoundResult = ginx.Result{
+		Code: errs.IdentityBound.Code,
+		Msg:  errs.IdentityBound.Msg,
+	}
+	identityConflictResult = ginx.Result{
+		Code: errs.IdentityConflict.Code,
+		Msg:  errs.IdentityConflict.Msg,
+	}
+	invalidMergeResult = ginx.Result{
+		Code: errs.InvalidMerge.Code,
+		Msg:  errs.InvalidMerge.Msg,
+	}
 )
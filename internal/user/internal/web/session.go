@@ -0,0 +1,71 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ginx"
+	"github.com/ecodeclub/ginx/session"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/user/internal/domain"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// ListSessions 当前账号所有在线的会话
+func (h *Handler) ListSessions(ctx *ginx.Context, sess session.Session) (ginx.Result, error) {
+	claims := sess.Claims()
+	sessions, err := h.sessionSvc.List(ctx, claims.Uid)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Data: SessionList{
+		Sessions: slice.Map(sessions, func(idx int, src domain.Session) Session {
+			return Session{
+				SSID:    src.SSID,
+				Device:  src.Device,
+				IP:      src.IP,
+				AppID:   src.AppID,
+				Ctime:   src.Ctime,
+				Current: src.SSID == claims.SSID,
+			}
+		}),
+	}}, nil
+}
+
+// RevokeSession 踢掉自己的某个会话，踢掉当前会话相当于退出登录
+func (h *Handler) RevokeSession(ctx *ginx.Context, req RevokeSessionReq, sess session.Session) (ginx.Result, error) {
+	err := h.sessionSvc.Revoke(ctx, sess.Claims().Uid, req.SSID)
+	if err != nil {
+		return systemErrorResult, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+// recordSession 记录会话失败不影响登录，只是用户看不到这个会话
+func (h *Handler) recordSession(ctx *ginx.Context, sess session.Session) {
+	claims := sess.Claims()
+	appID, _ := ctx.Get(middleware.AppCtxKey)
+	app, _ := appID.(uint)
+	err := h.sessionSvc.Record(ctx, domain.Session{
+		SSID:   claims.SSID,
+		Uid:    claims.Uid,
+		Device: ctx.Request.UserAgent(),
+		IP:     ctx.ClientIP(),
+		AppID:  app,
+	})
+	if err != nil {
+		h.logger.Error("记录会话失败", elog.FieldErr(err), elog.Int64("uid", claims.Uid))
+	}
+}
@@ -21,6 +21,8 @@ type Profile struct {
 	Nickname  string `json:"nickname,omitempty"`
 	Avatar    string `json:"avatar,omitempty"`
 	SN        string `json:"sn,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Email     string `json:"email,omitempty"`
 	IsCreator bool   `json:"isCreator,omitempty"`
 	// 毫秒数
 	MemberDDL int64 `json:"memberDDL,omitempty"`
@@ -31,6 +33,8 @@ func newProfile(u domain.User) Profile {
 		Nickname: u.Nickname,
 		Avatar:   u.Avatar,
 		SN:       u.SN,
+		Phone:    u.Phone,
+		Email:    u.Email,
 	}
 }
 
@@ -39,7 +43,85 @@ type WechatCallback struct {
 	State string `json:"state"`
 }
 
+type SendSMSCodeReq struct {
+	Phone string `json:"phone"`
+}
+
+type SMSLoginReq struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+	// InvitationCode 新用户的邀请码
+	InvitationCode string `json:"invitationCode"`
+}
+
+type SendMagicLinkReq struct {
+	Email string `json:"email"`
+	// InvitationCode 新用户的邀请码，会被带在登录链接里面
+	InvitationCode string `json:"invitationCode"`
+}
+
+// EmailLoginReq 字段都来自登录链接
+type EmailLoginReq struct {
+	Email          string `json:"email"`
+	Token          string `json:"token"`
+	InvitationCode string `json:"invitationCode"`
+}
+
 type EditReq struct {
 	Avatar   string `json:"avatar"`
 	Nickname string `json:"nickname"`
 }
+
+type BindWechatReq struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+	// Merge 微信已经属于另外一个账号的时候，是否把那个账号合并进来
+	Merge bool `json:"merge"`
+}
+
+type BindPhoneReq struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+	Merge bool   `json:"merge"`
+}
+
+type BindEmailReq struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+	Merge bool   `json:"merge"`
+}
+
+type MergeReq struct {
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+}
+
+type Merge struct {
+	Id        int64 `json:"id"`
+	SourceUid int64 `json:"sourceUid"`
+	TargetUid int64 `json:"targetUid"`
+	Operator  int64 `json:"operator"`
+}
+
+type Session struct {
+	SSID   string `json:"ssid"`
+	Device string `json:"device"`
+	IP     string `json:"ip"`
+	AppID  uint   `json:"appId"`
+	// 登录时间，毫秒数
+	Ctime int64 `json:"ctime"`
+	// Current 是不是发起请求的这个会话
+	Current bool `json:"current"`
+}
+
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
+
+type RevokeSessionReq struct {
+	SSID string `json:"ssid"`
+}
+
+type RevokeSessionsReq struct {
+	Uid int64 `json:"uid"`
+}
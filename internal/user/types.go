@@ -15,19 +15,28 @@
 package user
 
 import (
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/user/internal/event/consumer"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
 	"github.com/ecodeclub/webook/internal/user/internal/web"
 )
 
 // Handler 暴露出去给 ioc 使用
 type Handler = web.Handler
+type AdminHandler = web.AdminHandler
 
 // UserService 方便测试
 type UserService = service.UserService
 
+var ErrUserNotFound = service.ErrUserNotFound
+
 type Module struct {
 	Hdl *Handler
 	Svc UserService
+	// ExportSource 导出个人数据的时候使用
+	ExportSource exportx.Source
+
+	dc *consumer.UserDeletionConsumer
 }
 
 // 规避 wire 的坑
@@ -22,11 +22,14 @@ import (
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/member"
 	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ecodeclub/webook/internal/user/internal/repository"
 	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
+	"github.com/ecodeclub/webook/internal/user/internal/web"
 	"github.com/ego-component/egorm"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 )
 
 var ProviderSet = wire.NewSet(
@@ -36,19 +39,53 @@ var ProviderSet = wire.NewSet(
 	initWechatWebOAuthService,
 	initWechatMiniOAuthService,
 	initRegistrationEventProducer,
+	initMergeEventProducer,
+	initSMSCodeService,
+	initMagicLinkService,
+	initSessionService,
 	service.NewUserService,
+	cache.NewCodeRedisCache,
+	repository.NewCachedCodeRepository,
+	cache.NewSessionRedisCache,
+	repository.NewCachedSessionRepository,
 	repository.NewCachedUserRepository)
 
-func InitHandler(db *egorm.Component,
+func InitModule(db *egorm.Component,
 	cache ecache.Cache,
-	q mq.MQ, creators []string,
+	cmd redis.Cmdable,
+	q mq.MQ,
 	memberSvc *member.Module,
 	sp session.Provider,
-	permissionSvc *permission.Module) *Handler {
+	permissionSvc *permission.Module) *Module {
 	wire.Build(
 		ProviderSet,
+		service.NewExportSource,
+		initUserDeletionConsumer,
 		wire.FieldsOf(new(*member.Module), "Svc"),
-		wire.FieldsOf(new(*permission.Module), "Svc"),
+		wire.FieldsOf(new(*permission.Module), "Svc", "RBACSvc"),
+		wire.Struct(new(Module), "*"),
 	)
-	return new(Handler)
+	return new(Module)
+}
+
+func InitAdminHandler(db *egorm.Component,
+	ec ecache.Cache,
+	cmd redis.Cmdable,
+	q mq.MQ,
+	permModule *permission.Module) *AdminHandler {
+	wire.Build(
+		initDAO,
+		cache.NewUserECache,
+		repository.NewCachedUserRepository,
+		initRegistrationEventProducer,
+		initMergeEventProducer,
+		service.NewUserService,
+		cache.NewSessionRedisCache,
+		repository.NewCachedSessionRepository,
+		initSessionService,
+		wire.FieldsOf(new(*permission.Module), "RBACSvc"),
+		middleware.NewCheckRBACMiddlewareBuilder,
+		web.NewAdminHandler,
+	)
+	return new(AdminHandler)
 }
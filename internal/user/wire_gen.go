@@ -12,28 +12,63 @@ import (
 	"github.com/ecodeclub/mq-api"
 	"github.com/ecodeclub/webook/internal/member"
 	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ecodeclub/webook/internal/user/internal/repository"
 	"github.com/ecodeclub/webook/internal/user/internal/repository/cache"
 	"github.com/ecodeclub/webook/internal/user/internal/service"
 	"github.com/ecodeclub/webook/internal/user/internal/web"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-func InitHandler(db *gorm.DB, cache2 ecache.Cache, q mq.MQ, creators []string, memberSvc *member.Module, sp session.Provider, permissionSvc *permission.Module) *web.Handler {
+func InitModule(db *gorm.DB, cache2 ecache.Cache, cmd redis.Cmdable, q mq.MQ, memberSvc *member.Module, sp session.Provider, permissionSvc *permission.Module) *Module {
 	userWechatWebOAuth2Service := initWechatWebOAuthService(cache2)
 	userWechatMiniOAuth2Service := initWechatMiniOAuthService()
 	userDAO := initDAO(db)
 	userCache := cache.NewUserECache(cache2)
 	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
 	registrationEventProducer := initRegistrationEventProducer(q)
-	userService := service.NewUserService(userRepository, registrationEventProducer)
+	mergeEventProducer := initMergeEventProducer(q)
+	sessionCache := cache.NewSessionRedisCache(cmd)
+	sessionRepository := repository.NewCachedSessionRepository(sessionCache)
+	sessionService := initSessionService(sessionRepository)
+	userService := service.NewUserService(userRepository, registrationEventProducer, mergeEventProducer, sessionService)
+	codeCache := cache.NewCodeRedisCache(cmd)
+	codeRepository := repository.NewCachedCodeRepository(codeCache)
+	smsCodeService := initSMSCodeService(codeRepository)
+	magicLinkService := initMagicLinkService(codeRepository)
 	serviceService := memberSvc.Svc
 	service2 := permissionSvc.Svc
-	handler := iniHandler(userWechatWebOAuth2Service, userWechatMiniOAuth2Service, userService, serviceService, sp, service2, creators)
-	return handler
+	rbacService := permissionSvc.RBACSvc
+	handler := iniHandler(userWechatWebOAuth2Service, userWechatMiniOAuth2Service, userService, smsCodeService, magicLinkService, sessionService, serviceService, sp, service2, rbacService)
+	source := service.NewExportSource(userService)
+	userDeletionConsumer := initUserDeletionConsumer(userService, sessionService, q)
+	module := &Module{
+		Hdl:          handler,
+		Svc:          userService,
+		ExportSource: source,
+		dc:           userDeletionConsumer,
+	}
+	return module
+}
+
+func InitAdminHandler(db *gorm.DB, ec ecache.Cache, cmd redis.Cmdable, q mq.MQ, permModule *permission.Module) *web.AdminHandler {
+	userDAO := initDAO(db)
+	userCache := cache.NewUserECache(ec)
+	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
+	registrationEventProducer := initRegistrationEventProducer(q)
+	mergeEventProducer := initMergeEventProducer(q)
+	sessionCache := cache.NewSessionRedisCache(cmd)
+	sessionRepository := repository.NewCachedSessionRepository(sessionCache)
+	sessionService := initSessionService(sessionRepository)
+	userService := service.NewUserService(userRepository, registrationEventProducer, mergeEventProducer, sessionService)
+	rbacService := permModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	adminHandler := web.NewAdminHandler(userService, sessionService, checkRBACMiddlewareBuilder)
+	return adminHandler
 }
 
 // wire.go:
@@ -42,5 +77,9 @@ var ProviderSet = wire.NewSet(
 	iniHandler, cache.NewUserECache, initDAO,
 	initWechatWebOAuthService,
 	initWechatMiniOAuthService,
-	initRegistrationEventProducer, service.NewUserService, repository.NewCachedUserRepository,
+	initRegistrationEventProducer,
+	initMergeEventProducer,
+	initSMSCodeService,
+	initMagicLinkService,
+	initSessionService, service.NewUserService, cache.NewCodeRedisCache, repository.NewCachedCodeRepository, cache.NewSessionRedisCache, repository.NewCachedSessionRepository, repository.NewCachedUserRepository,
 )
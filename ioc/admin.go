@@ -18,7 +18,13 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ecodeclub/webook/internal/comment"
+	"github.com/ecodeclub/webook/internal/credit"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/middleware"
 	"github.com/ecodeclub/webook/internal/review"
+	"github.com/ecodeclub/webook/internal/search"
+	"github.com/ecodeclub/webook/internal/user"
 
 	"github.com/ecodeclub/webook/internal/ai"
 
@@ -28,13 +34,11 @@ import (
 
 	"github.com/ecodeclub/webook/internal/roadmap"
 
-	"github.com/ecodeclub/ginx"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/webook/internal/marketing"
 	"github.com/ecodeclub/webook/internal/project"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/gotomicro/ego/core/elog"
 	"github.com/gotomicro/ego/server/egin"
 )
 
@@ -51,6 +55,13 @@ func InitAdminServer(prj *project.AdminHandler,
 	reviewAdminHdl *review.AdminHdl,
 	caseKnowledgeBaseHdl *cases.KnowledgeBaseHandler,
 	queKnowledgeBaseHdl *baguwen.KnowledgeBaseHandler,
+	commentAdminHdl *comment.AdminHandler,
+	searchAdminHdl *search.AdminHandler,
+	userAdminHdl *user.AdminHandler,
+	permAdminHdl *permission.AdminHandler,
+	creditAdminHdl *credit.AdminHandler,
+	creditLedgerHdl *credit.LedgerHandler,
+	rbac *middleware.CheckRBACMiddlewareBuilder,
 ) AdminServer {
 	res := egin.Load("admin").Build()
 	res.Use(cors.New(cors.Config{
@@ -74,7 +85,8 @@ func InitAdminServer(prj *project.AdminHandler,
 	//res.Use(nonsense.NonSenseV1)
 	// 登录校验
 	res.Use(session.CheckLoginMiddleware())
-	res.Use(AdminPermission())
+	// 权限校验，拥有 admin:login 权限的角色才能进入后台
+	res.Use(rbac.Build("admin", "login"))
 	prj.PrivateRoutes(res.Engine)
 	queSet.PrivateRoutes(res.Engine)
 	mark.PrivateRoutes(res.Engine)
@@ -86,22 +98,12 @@ func InitAdminServer(prj *project.AdminHandler,
 	reviewAdminHdl.PrivateRoutes(res.Engine)
 	queKnowledgeBaseHdl.PrivateRoutes(res.Engine)
 	caseKnowledgeBaseHdl.PrivateRoutes(res.Engine)
+	commentAdminHdl.PrivateRoutes(res.Engine)
+	searchAdminHdl.PrivateRoutes(res.Engine)
+	userAdminHdl.PrivateRoutes(res.Engine)
+	creditAdminHdl.PrivateRoutes(res.Engine)
+	creditLedgerHdl.PrivateRoutes(res.Engine)
+	// 角色和授权管理需要额外的权限
+	permAdminHdl.PrivateRoutes(res.Group("", rbac.Build("permission", "manage")))
 	return res
 }
-
-func AdminPermission() gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		xctx := &ginx.Context{Context: ctx}
-		sess, err := session.Get(xctx)
-		if err != nil {
-			ctx.AbortWithStatus(http.StatusInternalServerError)
-			elog.Error("非法访问 admin 接口", elog.FieldErr(err))
-			return
-		}
-		if sess.Claims().Get("creator").StringOrDefault("") != "true" {
-			ctx.AbortWithStatus(http.StatusInternalServerError)
-			elog.Error("非法访问 admin 接口，未设置权限")
-			return
-		}
-	}
-}
@@ -1,6 +1,10 @@
 package ioc
 
 import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/gotomicro/ego/server/egin"
 	"github.com/gotomicro/ego/task/ecron"
 	"github.com/gotomicro/ego/task/ejob"
@@ -11,4 +15,21 @@ type App struct {
 	Admin AdminServer
 	Crons []ecron.Ecron
 	Jobs  []ejob.Ejob
+	// Closers 服务和定时任务都停下来之后执行，把还在内存或者缓存里面的数据写下去
+	Closers []Closer
+}
+
+type Closer func(ctx context.Context) error
+
+// Close 所有的 Closer 都会执行，一个失败了不影响别的
+func (a *App) Close(ctx context.Context) error {
+	errs := make([]error, 0, len(a.Closers))
+	for _, c := range a.Closers {
+		errs = append(errs, c(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func initClosers(intrModule *interactive.Module) []Closer {
+	return []Closer{intrModule.Stop}
 }
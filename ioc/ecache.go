@@ -15,8 +15,16 @@
 package ioc
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/ecache/memory/lru"
 	eredis "github.com/ecodeclub/ecache/redis"
+	"github.com/ecodeclub/mq-api"
+	"github.com/ecodeclub/webook/internal/pkg/cachex"
+	"github.com/gotomicro/ego/core/econf"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -26,3 +34,52 @@ func InitCache(cmd redis.Cmdable) ecache.Cache {
 		Namespace: "webook:",
 	}
 }
+
+// InitReadThroughCache 已发布内容的读穿透缓存
+// 使用了本地缓存的时候，会通过消息队列通知其它节点删除本地缓存
+func InitReadThroughCache(ec ecache.Cache, q mq.MQ) *cachex.ReadThroughCache {
+	type Config struct {
+		Type            string        `yaml:"type"`
+		Expiration      time.Duration `yaml:"expiration"`
+		LocalCapacity   int           `yaml:"localCapacity"`
+		LocalExpiration time.Duration `yaml:"localExpiration"`
+		// InstanceID 节点的唯一标识，用来命名接收失效消息的消费者组，为空的时候使用主机名
+		InstanceID string `yaml:"instanceId"`
+	}
+	var cfg Config
+	err := econf.UnmarshalKey("cache.readThrough", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	var c cachex.Cache
+	switch cfg.Type {
+	case cachex.TypeNone:
+		return cachex.NewReadThroughCache(cachex.NopCache{}, cfg.Expiration)
+	case cachex.TypeRedis:
+		return cachex.NewReadThroughCache(ec, cfg.Expiration)
+	case cachex.TypeLocal:
+		local := lru.NewCache(cfg.LocalCapacity)
+		startInvalidationConsumer(local, q, cfg.InstanceID)
+		c = local
+	case cachex.TypeTwoTier:
+		local := lru.NewCache(cfg.LocalCapacity)
+		startInvalidationConsumer(local, q, cfg.InstanceID)
+		c = cachex.NewTwoTierCache(local, ec, cfg.LocalExpiration)
+	default:
+		panic(fmt.Errorf("未知的缓存类型 %s", cfg.Type))
+	}
+	ic, err := cachex.NewInvalidationCache(c, q)
+	if err != nil {
+		panic(err)
+	}
+	return cachex.NewReadThroughCache(ic, cfg.Expiration)
+}
+
+func startInvalidationConsumer(local cachex.Cache, q mq.MQ, instanceID string) {
+	consumer, err := cachex.NewInvalidationConsumer(local, q, instanceID)
+	if err != nil {
+		panic(err)
+	}
+	consumer.Start(context.Background())
+}
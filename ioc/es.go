@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ecodeclub/webook/internal/search"
 	"github.com/gotomicro/ego/core/econf"
 	"github.com/olivere/elastic/v7"
 )
@@ -44,3 +45,24 @@ func InitES() *elastic.Client {
 	}
 	return client
 }
+
+// InitSearchEngine 根据 search.backend 选择搜索引擎的实现
+// local 是进程内的实现，不需要 ES，适合开发环境；默认使用 elastic
+func InitSearchEngine() search.Engine {
+	type Config struct {
+		Backend string `yaml:"backend"`
+	}
+	var cfg Config
+	err := econf.UnmarshalKey("search", &cfg)
+	if err != nil {
+		panic(fmt.Errorf("读取搜索配置失败 %w", err))
+	}
+	switch cfg.Backend {
+	case "", "elastic":
+		return search.NewElasticEngine(InitES())
+	case "local":
+		return search.NewLocalEngine()
+	default:
+		panic(fmt.Errorf("未知的搜索引擎 %s", cfg.Backend))
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ecodeclub/webook/internal/comment"
+	"github.com/ecodeclub/webook/internal/privacy"
 	"github.com/ecodeclub/webook/internal/review"
 
 	"github.com/ecodeclub/webook/internal/ai"
@@ -91,6 +93,8 @@ func initGinxServer(sp session.Provider,
 	resumeAnaHdl *resume.AnalysisHandler,
 	aiHdl *ai.LLMHandler,
 	reviewHdl *review.Hdl,
+	commentHdl *comment.Handler,
+	privacyHdl *privacy.Handler,
 ) *egin.Component {
 	session.SetDefaultProvider(sp)
 	res := egin.Load("web").Build()
@@ -136,6 +140,7 @@ func initGinxServer(sp session.Provider,
 	csHdl.PublicRoutes(res.Engine)
 	prjHdl.PublicRoutes(res.Engine)
 	reviewHdl.PublicRoutes(res.Engine)
+	commentHdl.PublicRoutes(res.Engine)
 
 	// 登录校验
 	res.Use(session.CheckLoginMiddleware())
@@ -153,6 +158,8 @@ func initGinxServer(sp session.Provider,
 	prjHdl.PrivateRoutes(res.Engine)
 	bffHdl.PrivateRoutes(res.Engine)
 	csHdl.PrivateRoutes(res.Engine)
+	commentHdl.PrivateRoutes(res.Engine)
+	privacyHdl.PrivateRoutes(res.Engine)
 
 	// 权限校验
 
@@ -21,18 +21,25 @@ import (
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/gotomicro/ego/task/ejob"
 
+	"github.com/ecodeclub/webook/internal/cases"
 	"github.com/ecodeclub/webook/internal/credit"
+	"github.com/ecodeclub/webook/internal/interactive"
 	"github.com/ecodeclub/webook/internal/order"
 	"github.com/ecodeclub/webook/internal/payment"
+	"github.com/ecodeclub/webook/internal/privacy"
+	"github.com/ecodeclub/webook/internal/project"
 	"github.com/ecodeclub/webook/internal/recon"
+	"github.com/ecodeclub/webook/internal/review"
+	"github.com/ecodeclub/webook/internal/search"
 	"github.com/gotomicro/ego/core/elog"
 	"github.com/gotomicro/ego/task/ecron"
 )
 
 // 手动运行，或者通过 http 来触发
-func initJobs(knowledgeStarter *baguwen.KnowledgeJobStarter) []ejob.Ejob {
+func initJobs(knowledgeStarter *baguwen.KnowledgeJobStarter, reindexJob *search.ReindexJob) []ejob.Ejob {
 	return []ejob.Ejob{
 		ejob.Job("gen-knowledge", knowledgeStarter.Start),
+		ejob.Job("search-reindex", reindexJob.Start),
 	}
 }
 
@@ -40,14 +47,36 @@ func initJobs(knowledgeStarter *baguwen.KnowledgeJobStarter) []ejob.Ejob {
 func initCronJobs(
 	oJob *order.CloseTimeoutOrdersJob,
 	cJob *credit.CloseTimeoutLockedCreditsJob,
+	expireCreditsJob *credit.ExpireCreditLotsJob,
+	verifyCreditLedgerJob *credit.VerifyCreditLedgerJob,
 	pJob *payment.SyncWechatOrderJob,
 	rJob *recon.SyncPaymentAndOrderJob,
+	queScheduleJob *baguwen.ScheduledPublishJob,
+	caseScheduleJob *cases.ScheduledPublishJob,
+	prjScheduleJob *project.ScheduledPublishJob,
+	reviewScheduleJob *review.ScheduledPublishJob,
+	flushViewCntJob *interactive.FlushViewCntJob,
+	rankTrendingJob *interactive.RankTrendingJob,
+	exportJob *privacy.ExportJob,
+	cleanExportJob *privacy.CleanExportJob,
+	accountDeletionJob *privacy.AccountDeletionJob,
 ) []ecron.Ecron {
 	return []ecron.Ecron{
 		ecron.Load("cron.closeTimeoutOrder").Build(ecron.WithJob(funcJobWrapper(oJob))),
 		ecron.Load("cron.unlockTimeoutCredit").Build(ecron.WithJob(funcJobWrapper(cJob))),
+		ecron.Load("cron.expireCredits").Build(ecron.WithJob(funcJobWrapper(expireCreditsJob))),
+		ecron.Load("cron.verifyCreditLedger").Build(ecron.WithJob(funcJobWrapper(verifyCreditLedgerJob))),
 		ecron.Load("cron.syncWechatOrder").Build(ecron.WithJob(funcJobWrapper(pJob))),
 		ecron.Load("cron.syncPaymentAndOrder").Build(ecron.WithJob(funcJobWrapper(rJob))),
+		ecron.Load("cron.questionScheduledPublish").Build(ecron.WithJob(funcJobWrapper(queScheduleJob))),
+		ecron.Load("cron.caseScheduledPublish").Build(ecron.WithJob(funcJobWrapper(caseScheduleJob))),
+		ecron.Load("cron.projectScheduledPublish").Build(ecron.WithJob(funcJobWrapper(prjScheduleJob))),
+		ecron.Load("cron.reviewScheduledPublish").Build(ecron.WithJob(funcJobWrapper(reviewScheduleJob))),
+		ecron.Load("cron.flushViewCnt").Build(ecron.WithJob(funcJobWrapper(flushViewCntJob))),
+		ecron.Load("cron.rankTrending").Build(ecron.WithJob(funcJobWrapper(rankTrendingJob))),
+		ecron.Load("cron.exportPersonalData").Build(ecron.WithJob(funcJobWrapper(exportJob))),
+		ecron.Load("cron.cleanPersonalDataExport").Build(ecron.WithJob(funcJobWrapper(cleanExportJob))),
+		ecron.Load("cron.accountDeletion").Build(ecron.WithJob(funcJobWrapper(accountDeletionJob))),
 	}
 }
 
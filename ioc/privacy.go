@@ -0,0 +1,70 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"github.com/ecodeclub/webook/internal/ai"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/comment"
+	"github.com/ecodeclub/webook/internal/credit"
+	"github.com/ecodeclub/webook/internal/feedback"
+	"github.com/ecodeclub/webook/internal/interactive"
+	"github.com/ecodeclub/webook/internal/member"
+	"github.com/ecodeclub/webook/internal/order"
+	"github.com/ecodeclub/webook/internal/payment"
+	"github.com/ecodeclub/webook/internal/permission"
+	"github.com/ecodeclub/webook/internal/pkg/exportx"
+	"github.com/ecodeclub/webook/internal/progress"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/resume"
+	"github.com/ecodeclub/webook/internal/search"
+	"github.com/ecodeclub/webook/internal/user"
+)
+
+// initExportSources 导出个人数据的时候，每个模块的数据对应压缩包里面的一个文件
+func initExportSources(
+	userModule *user.Module,
+	creditModule *credit.Module,
+	memberModule *member.Module,
+	permModule *permission.Module,
+	intrModule *interactive.Module,
+	queModule *baguwen.Module,
+	caseModule *cases.Module,
+	resumeModule *resume.Module,
+	fbModule *feedback.Module,
+	aiModule *ai.Module,
+	orderModule *order.Module,
+	paymentModule *payment.Module,
+	commentModule *comment.Module,
+	progressModule *progress.Module,
+	searchModule *search.Module) []exportx.Source {
+	return []exportx.Source{
+		userModule.ExportSource,
+		creditModule.ExportSource,
+		memberModule.ExportSource,
+		permModule.ExportSource,
+		intrModule.ExportSource,
+		queModule.ExportSource,
+		caseModule.ExportSource,
+		resumeModule.ExportSource,
+		fbModule.ExportSource,
+		aiModule.ExportSource,
+		orderModule.ExportSource,
+		paymentModule.ExportSource,
+		commentModule.ExportSource,
+		progressModule.ExportSource,
+		searchModule.ExportSource,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"slices"
+
+	"github.com/ecodeclub/ecache"
+	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/pkg/searchx"
+	"github.com/ecodeclub/webook/internal/project"
+	baguwen "github.com/ecodeclub/webook/internal/question"
+	"github.com/ecodeclub/webook/internal/review"
+	"github.com/ecodeclub/webook/internal/roadmap"
+	"github.com/ecodeclub/webook/internal/skill"
+	"github.com/ego-component/egorm"
+)
+
+// initSearchSources 搜索重建索引的数据来源
+// 所有会同步到搜索的业务都要在这里注册，否则重建索引之后这部分数据就丢了
+func initSearchSources(db *egorm.Component, ec ecache.Cache,
+	queModule *baguwen.Module, caseModule *cases.Module,
+	prjModule *project.Module, reviewModule *review.Module,
+	roadmapModule *roadmap.Module) []searchx.Source {
+	return append(slices.Clone(queModule.SearchSources),
+		caseModule.SearchSource,
+		prjModule.SearchSource,
+		reviewModule.SearchSource,
+		roadmapModule.SearchSource,
+		skill.InitSearchSource(db, ec))
+}
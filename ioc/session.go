@@ -17,6 +17,7 @@ package ioc
 import (
 	"time"
 
+	"github.com/ecodeclub/ginx/gctx"
 	"github.com/ecodeclub/ginx/session/header"
 	"github.com/ecodeclub/ginx/session/mixin"
 
@@ -51,5 +52,28 @@ func InitSession(cmd redis.Cmdable) session.Provider {
 	}
 	headerC := header.NewTokenCarrier()
 	sp.TokenCarrier = mixin.NewTokenCarrier(headerC, cookieC)
-	return sp
+	return &checkedSessionProvider{SessionProvider: sp}
+}
+
+// checkedSessionProvider 默认的实现只校验 JWT，
+// 这里还要求 redis 里面的 session 还在，这样被踢掉的会话会立刻失效
+type checkedSessionProvider struct {
+	*redis2.SessionProvider
+}
+
+func (p *checkedSessionProvider) Get(ctx *gctx.Context) (session.Session, error) {
+	val, _ := ctx.Get(session.CtxSessionKey)
+	if sess, ok := val.(session.Session); ok {
+		return sess, nil
+	}
+	sess, err := p.SessionProvider.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 创建 session 的时候一定会写入 uid
+	err = sess.Get(ctx, "uid").Err
+	if err != nil {
+		return nil, err
+	}
+	return sess, nil
 }
@@ -15,6 +15,10 @@
 package ioc
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/ecodeclub/ecache"
 	"github.com/ecodeclub/ginx/session"
 	"github.com/ecodeclub/mq-api"
@@ -23,14 +27,17 @@ import (
 	"github.com/ecodeclub/webook/internal/user"
 	"github.com/ego-component/egorm"
 	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
 )
 
-func InitUserHandler(db *egorm.Component,
+func InitUserModule(db *egorm.Component,
 	sp session.Provider,
 	ec ecache.Cache,
+	cmd redis.Cmdable,
 	q mq.MQ,
 	memModule *member.Module,
-	perm *permission.Module) *user.Handler {
+	perm *permission.Module) *user.Module {
 	type UserConfig struct {
 		Creators []string `json:"creators"`
 	}
@@ -39,5 +46,33 @@ func InitUserHandler(db *egorm.Component,
 	if err != nil {
 		panic(err)
 	}
-	return user.InitHandler(db, ec, q, cfg.Creators, memModule, sp, perm)
+	module := user.InitModule(db, ec, cmd, q, memModule, sp, perm)
+	seedCreators(module.Svc, perm.RBACSvc, cfg.Creators)
+	return module
+}
+
+// seedCreators 启动的时候给白名单里已经注册了的用户补上超级管理员角色，之后谁能进管理后台都以 RBAC 为准。
+// 白名单里还没有注册的用户，注册之后要在管理后台授权，或者等下一次启动
+func seedCreators(svc user.UserService, rbacSvc permission.RBACService, creators []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	for _, unionId := range creators {
+		u, err := svc.FindByWechat(ctx, unionId)
+		if errors.Is(err, user.ErrUserNotFound) {
+			elog.DefaultLogger.Warn("白名单里的用户还没有注册", elog.String("unionId", unionId))
+			continue
+		}
+		if err != nil {
+			panic(err)
+		}
+		err = rbacSvc.AssignRoles(ctx, u.Id, permission.SuperAdminRoleID)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func InitUserAdminHandler(db *egorm.Component, ec ecache.Cache, cmd redis.Cmdable, q mq.MQ,
+	perm *permission.Module) *user.AdminHandler {
+	return user.InitAdminHandler(db, ec, cmd, q, perm)
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/bff"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/comment"
 	"github.com/ecodeclub/webook/internal/cos"
 	"github.com/ecodeclub/webook/internal/credit"
 	"github.com/ecodeclub/webook/internal/feedback"
@@ -31,7 +32,9 @@ import (
 	"github.com/ecodeclub/webook/internal/payment"
 	"github.com/ecodeclub/webook/internal/permission"
 	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/privacy"
 	"github.com/ecodeclub/webook/internal/product"
+	"github.com/ecodeclub/webook/internal/progress"
 	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/recon"
@@ -40,10 +43,11 @@ import (
 	"github.com/ecodeclub/webook/internal/roadmap"
 	"github.com/ecodeclub/webook/internal/search"
 	"github.com/ecodeclub/webook/internal/skill"
+	"github.com/ecodeclub/webook/internal/user"
 	"github.com/google/wire"
 )
 
-var BaseSet = wire.NewSet(InitDB, InitCache, InitES, InitRedis, InitMQ, InitCosConfig)
+var BaseSet = wire.NewSet(InitDB, InitCache, InitReadThroughCache, InitSearchEngine, InitRedis, InitMQ, InitCosConfig)
 
 func InitApp() (*App, error) {
 	wire.Build(wire.Struct(new(App), "*"),
@@ -54,14 +58,18 @@ func InitApp() (*App, error) {
 		initJobs,
 		wire.FieldsOf(new(*baguwen.Module),
 			"AdminHdl", "AdminSetHdl", "KnowledgeJobStarter",
-			"ExamineHdl", "Hdl", "QsHdl", "KnowledgeBaseHdl"),
-		InitUserHandler,
+			"ExamineHdl", "Hdl", "QsHdl", "KnowledgeBaseHdl", "ScheduledPublishJob"),
+		InitUserModule,
+		wire.FieldsOf(new(*user.Module), "Hdl"),
+		InitUserAdminHandler,
 		label.InitHandler,
 		cases.InitModule,
 		wire.FieldsOf(new(*cases.Module),
-			"CsHdl", "Hdl", "ExamineHdl", "AdminHandler", "AdminSetHandler", "KnowledgeBaseHandler"),
-		skill.InitHandler,
-		feedback.InitHandler,
+			"CsHdl", "Hdl", "ExamineHdl", "AdminHandler", "AdminSetHandler", "KnowledgeBaseHandler", "ScheduledPublishJob"),
+		skill.InitModule,
+		wire.FieldsOf(new(*skill.Module), "Hdl"),
+		feedback.InitModule,
+		wire.FieldsOf(new(*feedback.Module), "Hdl"),
 		member.InitModule,
 		wire.FieldsOf(new(*member.Module), "Svc"),
 		middleware.NewCheckMembershipMiddlewareBuilder,
@@ -72,33 +80,42 @@ func InitApp() (*App, error) {
 		payment.InitModule,
 		wire.FieldsOf(new(*payment.Module), "Hdl", "SyncWechatOrderJob"),
 		credit.InitModule,
-		wire.FieldsOf(new(*credit.Module), "Hdl", "CloseTimeoutLockedCreditsJob"),
+		wire.FieldsOf(new(*credit.Module), "Hdl", "AdminHdl", "LedgerHdl", "VerifyCreditLedgerJob", "CloseTimeoutLockedCreditsJob", "ExpireCreditLotsJob"),
 		project.InitModule,
-		wire.FieldsOf(new(*project.Module), "AdminHdl", "Hdl"),
+		wire.FieldsOf(new(*project.Module), "AdminHdl", "Hdl", "ScheduledPublishJob"),
 		recon.InitModule,
 		wire.FieldsOf(new(*recon.Module), "SyncPaymentAndOrderJob"),
 		marketing.InitModule,
 		wire.FieldsOf(new(*marketing.Module), "AdminHdl", "Hdl"),
 		interactive.InitModule,
-		wire.FieldsOf(new(*interactive.Module), "Hdl"),
+		wire.FieldsOf(new(*interactive.Module), "Hdl", "FlushViewCntJob", "RankTrendingJob"),
 		permission.InitModule,
-		wire.FieldsOf(new(*permission.Module), "Svc"),
+		wire.FieldsOf(new(*permission.Module), "Svc", "RBACSvc", "AdminHdl"),
 		middleware.NewCheckPermissionMiddlewareBuilder,
+		middleware.NewCheckRBACMiddlewareBuilder,
+		initSearchSources,
 		search.InitModule,
-		wire.FieldsOf(new(*search.Module), "Hdl"),
+		wire.FieldsOf(new(*search.Module), "Hdl", "AdminHdl", "ReindexJob"),
 		roadmap.InitModule,
 		wire.FieldsOf(new(*roadmap.Module), "Hdl", "AdminHdl"),
 		ai.InitModule,
+		progress.InitModule,
 		bff.InitModule,
 		wire.FieldsOf(new(*bff.Module), "Hdl"),
 		resume.InitModule,
 		wire.FieldsOf(new(*resume.Module), "PrjHdl", "AnalysisHandler"),
 		wire.FieldsOf(new(*ai.Module), "Hdl", "AdminHandler"),
 		review.InitModule,
-		wire.FieldsOf(new(*review.Module), "Hdl", "AdminHdl"),
+		wire.FieldsOf(new(*review.Module), "Hdl", "AdminHdl", "ScheduledPublishJob"),
+		comment.InitModule,
+		wire.FieldsOf(new(*comment.Module), "Hdl", "AdminHdl"),
+		initExportSources,
+		privacy.InitModule,
+		wire.FieldsOf(new(*privacy.Module), "Hdl", "ExportJob", "CleanExportJob", "AccountDeletionJob"),
 
 		initLocalActiveLimiterBuilder,
 		initCronJobs,
+		initClosers,
 		// 这两个顺序不要换
 		initGinxServer,
 		InitAdminServer,
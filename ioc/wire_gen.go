@@ -10,6 +10,7 @@ import (
 	"github.com/ecodeclub/webook/internal/ai"
 	"github.com/ecodeclub/webook/internal/bff"
 	"github.com/ecodeclub/webook/internal/cases"
+	"github.com/ecodeclub/webook/internal/comment"
 	"github.com/ecodeclub/webook/internal/cos"
 	"github.com/ecodeclub/webook/internal/credit"
 	"github.com/ecodeclub/webook/internal/feedback"
@@ -21,7 +22,9 @@ import (
 	"github.com/ecodeclub/webook/internal/payment"
 	"github.com/ecodeclub/webook/internal/permission"
 	"github.com/ecodeclub/webook/internal/pkg/middleware"
+	"github.com/ecodeclub/webook/internal/privacy"
 	"github.com/ecodeclub/webook/internal/product"
+	"github.com/ecodeclub/webook/internal/progress"
 	"github.com/ecodeclub/webook/internal/project"
 	baguwen "github.com/ecodeclub/webook/internal/question"
 	"github.com/ecodeclub/webook/internal/recon"
@@ -53,12 +56,12 @@ func InitApp() (*App, error) {
 	}
 	serviceService := permissionModule.Svc
 	checkPermissionMiddlewareBuilder := middleware.NewCheckPermissionMiddlewareBuilder(serviceService)
-	interactiveModule, err := interactive.InitModule(db, mq)
+	cache := InitCache(cmdable)
+	interactiveModule, err := interactive.InitModule(db, mq, cache, cmdable)
 	if err != nil {
 		return nil, err
 	}
-	cache := InitCache(cmdable)
-	creditModule, err := credit.InitModule(db, mq, cache)
+	creditModule, err := credit.InitModule(db, mq, cache, permissionModule)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +69,8 @@ func InitApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	baguwenModule, err := baguwen.InitModule(db, interactiveModule, cache, permissionModule, aiModule, module, provider, mq)
+	readThroughCache := InitReadThroughCache(cache, mq)
+	baguwenModule, err := baguwen.InitModule(db, interactiveModule, cache, permissionModule, aiModule, module, provider, mq, readThroughCache)
 	if err != nil {
 		return nil, err
 	}
@@ -74,22 +78,25 @@ func InitApp() (*App, error) {
 	examineHandler := baguwenModule.ExamineHdl
 	questionSetHandler := baguwenModule.QsHdl
 	webHandler := label.InitHandler(db)
-	handler2 := InitUserHandler(db, provider, cache, mq, module, permissionModule)
+	userModule := InitUserModule(db, provider, cache, cmdable, mq, module, permissionModule)
+	handler2 := userModule.Hdl
 	config := InitCosConfig()
 	handler3 := cos.InitHandler(config)
-	casesModule, err := cases.InitModule(db, interactiveModule, aiModule, module, provider, mq)
+	casesModule, err := cases.InitModule(db, interactiveModule, aiModule, module, provider, mq, readThroughCache)
 	if err != nil {
 		return nil, err
 	}
 	handler4 := casesModule.Hdl
-	handler5, err := skill.InitHandler(db, cache, baguwenModule, casesModule, mq)
+	skillModule, err := skill.InitModule(db, cache, baguwenModule, casesModule, permissionModule, mq)
 	if err != nil {
 		return nil, err
 	}
-	handler6, err := feedback.InitHandler(db, mq)
+	handler5 := skillModule.Hdl
+	feedbackModule, err := feedback.InitModule(db, mq, permissionModule)
 	if err != nil {
 		return nil, err
 	}
+	handler6 := feedbackModule.Hdl
 	productModule, err := product.InitModule(db, mq)
 	if err != nil {
 		return nil, err
@@ -117,28 +124,38 @@ func InitApp() (*App, error) {
 	}
 	handler12 := marketingModule.Hdl
 	handler13 := interactiveModule.Hdl
-	client := InitES()
-	searchModule, err := search.InitModule(client, mq, casesModule)
+	engine := InitSearchEngine()
+	roadmapModule := roadmap.InitModule(db, baguwenModule, mq)
+	reviewModule := review.InitModule(db, interactiveModule, mq, provider)
+	v := initSearchSources(db, cache, baguwenModule, casesModule, projectModule, reviewModule, roadmapModule)
+	searchModule, err := search.InitModule(engine, db, cache, mq, casesModule, module, permissionModule, v)
 	if err != nil {
 		return nil, err
 	}
 	handler14 := searchModule.Hdl
-	roadmapModule := roadmap.InitModule(db, baguwenModule)
 	handler15 := roadmapModule.Hdl
-	bffModule, err := bff.InitModule(interactiveModule, casesModule, baguwenModule)
+	progressModule, err := progress.InitModule(db, mq, baguwenModule, casesModule, skillModule)
+	if err != nil {
+		return nil, err
+	}
+	bffModule, err := bff.InitModule(interactiveModule, casesModule, baguwenModule, progressModule, projectModule, skillModule)
 	if err != nil {
 		return nil, err
 	}
 	handler16 := bffModule.Hdl
 	caseSetHandler := casesModule.CsHdl
 	webExamineHandler := casesModule.ExamineHdl
-	resumeModule := resume.InitModule(db, casesModule, aiModule)
+	resumeModule := resume.InitModule(db, casesModule, aiModule, mq)
 	projectHandler := resumeModule.PrjHdl
 	analysisHandler := resumeModule.AnalysisHandler
 	handler17 := aiModule.Hdl
-	reviewModule := review.InitModule(db, interactiveModule, mq, provider)
 	handler18 := reviewModule.Hdl
-	component := initGinxServer(provider, checkMembershipMiddlewareBuilder, localActiveLimit, checkPermissionMiddlewareBuilder, handler, examineHandler, questionSetHandler, webHandler, handler2, handler3, handler4, handler5, handler6, handler7, handler8, handler9, handler10, handler11, handler12, handler13, handler14, handler15, handler16, caseSetHandler, webExamineHandler, projectHandler, analysisHandler, handler17, handler18)
+	commentModule := comment.InitModule(db, mq, provider)
+	handler19 := commentModule.Hdl
+	v2 := initExportSources(userModule, creditModule, module, permissionModule, interactiveModule, baguwenModule, casesModule, resumeModule, feedbackModule, aiModule, orderModule, paymentModule, commentModule, progressModule, searchModule)
+	privacyModule := privacy.InitModule(db, mq, v2)
+	handler20 := privacyModule.Hdl
+	component := initGinxServer(provider, checkMembershipMiddlewareBuilder, localActiveLimit, checkPermissionMiddlewareBuilder, handler, examineHandler, questionSetHandler, webHandler, handler2, handler3, handler4, handler5, handler6, handler7, handler8, handler9, handler10, handler11, handler12, handler13, handler14, handler15, handler16, caseSetHandler, webExamineHandler, projectHandler, analysisHandler, handler17, handler18, handler19, handler20)
 	adminHandler := projectModule.AdminHdl
 	webAdminHandler := roadmapModule.AdminHdl
 	adminHandler2 := baguwenModule.AdminHdl
@@ -150,27 +167,49 @@ func InitApp() (*App, error) {
 	adminHandler5 := reviewModule.AdminHdl
 	knowledgeBaseHandler := casesModule.KnowledgeBaseHandler
 	webKnowledgeBaseHandler := baguwenModule.KnowledgeBaseHdl
-	adminServer := InitAdminServer(adminHandler, webAdminHandler, adminHandler2, adminQuestionSetHandler, adminCaseHandler, adminCaseSetHandler, adminHandler3, adminHandler4, adminHandler5, knowledgeBaseHandler, webKnowledgeBaseHandler)
+	adminHandler6 := commentModule.AdminHdl
+	adminHandler7 := searchModule.AdminHdl
+	userAdminHandler := InitUserAdminHandler(db, cache, cmdable, mq, permissionModule)
+	adminHandler8 := permissionModule.AdminHdl
+	adminHandler9 := creditModule.AdminHdl
+	ledgerHandler := creditModule.LedgerHdl
+	rbacService := permissionModule.RBACSvc
+	checkRBACMiddlewareBuilder := middleware.NewCheckRBACMiddlewareBuilder(rbacService)
+	adminServer := InitAdminServer(adminHandler, webAdminHandler, adminHandler2, adminQuestionSetHandler, adminCaseHandler, adminCaseSetHandler, adminHandler3, adminHandler4, adminHandler5, knowledgeBaseHandler, webKnowledgeBaseHandler, adminHandler6, adminHandler7, userAdminHandler, adminHandler8, adminHandler9, ledgerHandler, checkRBACMiddlewareBuilder)
 	closeTimeoutOrdersJob := orderModule.CloseTimeoutOrdersJob
 	closeTimeoutLockedCreditsJob := creditModule.CloseTimeoutLockedCreditsJob
+	expireCreditLotsJob := creditModule.ExpireCreditLotsJob
+	verifyCreditLedgerJob := creditModule.VerifyCreditLedgerJob
 	syncWechatOrderJob := paymentModule.SyncWechatOrderJob
 	reconModule, err := recon.InitModule(orderModule, paymentModule, creditModule)
 	if err != nil {
 		return nil, err
 	}
 	syncPaymentAndOrderJob := reconModule.SyncPaymentAndOrderJob
-	v := initCronJobs(closeTimeoutOrdersJob, closeTimeoutLockedCreditsJob, syncWechatOrderJob, syncPaymentAndOrderJob)
+	scheduledPublishJob := baguwenModule.ScheduledPublishJob
+	jobScheduledPublishJob := casesModule.ScheduledPublishJob
+	scheduledPublishJob2 := projectModule.ScheduledPublishJob
+	scheduledPublishJob3 := reviewModule.ScheduledPublishJob
+	flushViewCntJob := interactiveModule.FlushViewCntJob
+	rankTrendingJob := interactiveModule.RankTrendingJob
+	exportJob := privacyModule.ExportJob
+	cleanExportJob := privacyModule.CleanExportJob
+	accountDeletionJob := privacyModule.AccountDeletionJob
+	v3 := initCronJobs(closeTimeoutOrdersJob, closeTimeoutLockedCreditsJob, expireCreditLotsJob, verifyCreditLedgerJob, syncWechatOrderJob, syncPaymentAndOrderJob, scheduledPublishJob, jobScheduledPublishJob, scheduledPublishJob2, scheduledPublishJob3, flushViewCntJob, rankTrendingJob, exportJob, cleanExportJob, accountDeletionJob)
 	knowledgeJobStarter := baguwenModule.KnowledgeJobStarter
-	v2 := initJobs(knowledgeJobStarter)
+	reindexJob := searchModule.ReindexJob
+	v4 := initJobs(knowledgeJobStarter, reindexJob)
+	v5 := initClosers(interactiveModule)
 	app := &App{
-		Web:   component,
-		Admin: adminServer,
-		Crons: v,
-		Jobs:  v2,
+		Web:     component,
+		Admin:   adminServer,
+		Crons:   v3,
+		Jobs:    v4,
+		Closers: v5,
 	}
 	return app, nil
 }
 
 // wire.go:
 
-var BaseSet = wire.NewSet(InitDB, InitCache, InitES, InitRedis, InitMQ, InitCosConfig)
+var BaseSet = wire.NewSet(InitDB, InitCache, InitReadThroughCache, InitSearchEngine, InitRedis, InitMQ, InitCosConfig)
@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/ecodeclub/webook/ioc"
 	"github.com/gotomicro/ego"
 	"github.com/gotomicro/ego/server/egin"
 	"github.com/gotomicro/ego/server/egovernor"
 )
 
+// closeTimeout 退出之前清理的最长时间
+const closeTimeout = 10 * time.Second
+
 // export EGO_DEBUG=true
 // 记得修改为你的配置文件
 // go run main.go --config=config/config.yaml
 func main() {
+	var app *ioc.App
 	// 先触发初始化
-	egoApp := ego.New()
+	egoApp := ego.New(ego.WithAfterStopClean(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+		return app.Close(ctx)
+	}))
 	app, err := ioc.InitApp()
 	if err != nil {
 		panic(err)